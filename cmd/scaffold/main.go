@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+)
+
+// Stamps out a new service with the layout of the user service: an entity with its repository,
+// use case and gRPC controller, the proto definition, main and setup wired with the core
+// interceptors, a Dockerfile and the Kubernetes manifests. It then prints what to add to the
+// gateway, the Makefile and the proto generation.
+//
+//	go run ./cmd/scaffold -name inventory -entity Item
+func main() {
+	name := flag.String("name", "", "service name, e.g. inventory (\"-service\" is appended when missing)")
+	entityName := flag.String("entity", "", "resource entity in PascalCase, e.g. Item or StockLevel")
+	plural := flag.String("plural", "", "plural of the entity in kebab-case for routes and tables (derived when empty)")
+	port := flag.String("port", "9090", "gRPC port of the service")
+	root := flag.String("root", ".", "repository root the files are written to")
+	force := flag.Bool("force", false, "overwrite existing files")
+	dryRun := flag.Bool("dry-run", false, "list the files without writing them")
+	flag.Parse()
+
+	spec, err := NewSpec(*root, *name, *entityName, *plural, *port)
+	if err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+	files, err := Render(spec)
+	if err != nil {
+		log.Fatalf("Failed to render templates: %v", err)
+	}
+
+	if *dryRun {
+		for _, file := range files {
+			log.Printf("Would write %s", file.Path)
+		}
+	} else {
+		if err := Write(*root, files, *force); err != nil {
+			log.Fatalf("Failed to write %s: %v", spec.Name, err)
+		}
+		for _, file := range files {
+			log.Printf("Wrote %s", file.Path)
+		}
+	}
+
+	if err := Instructions(os.Stdout, spec); err != nil {
+		log.Fatalf("Failed to print instructions: %v", err)
+	}
+}
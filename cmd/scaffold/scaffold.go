@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// outputs maps each template to the path of the file it renders, itself a template
+var outputs = map[string]string{
+	"main.go.tmpl":         "services/{{.Name}}/cmd/main.go",
+	"setup.go.tmpl":        "services/{{.Name}}/cmd/setup.go",
+	"entity.go.tmpl":       "services/{{.Name}}/internal/entity/{{.FileName}}.go",
+	"repository.go.tmpl":   "services/{{.Name}}/internal/repository/{{.FileName}}.go",
+	"usecase.go.tmpl":      "services/{{.Name}}/internal/usecase/{{.FileName}}.go",
+	"controller.go.tmpl":   "services/{{.Name}}/internal/controller/{{.FileName}}.go",
+	"service.proto.tmpl":   "proto/{{.Name}}/{{.FileName}}.proto",
+	"Dockerfile.tmpl":      "services/{{.Name}}/Dockerfile",
+	"deployment.yaml.tmpl": "k8s/{{.Name}}/deployment.yaml",
+}
+
+// reservedNames are the packages imported next to variables named after the entity
+var reservedNames = map[string]bool{"entity": true, "repository": true, "usecase": true, "controller": true, "pb": true}
+
+var (
+	serviceNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+	entityNamePattern  = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// Spec holds the names a new service is generated with
+type Spec struct {
+	Module       string // Go module path, from go.mod
+	Name         string // Service name, e.g. inventory-service
+	Title        string // e.g. Inventory Service
+	GoName       string // Service name in Go identifiers, e.g. InventoryService
+	ProtoPackage string // e.g. inventoryservice
+	Entity       string // e.g. StockLevel
+	EntityVar    string // e.g. stockLevel
+	EntityPlural string // e.g. StockLevels
+	EntityLabel  string // e.g. stock level
+	LabelPlural  string // e.g. stock levels
+	FileName     string // e.g. stock_level
+	Resource     string // Route segment, e.g. stock-levels
+	Table        string // e.g. stock_levels
+	Port         string // gRPC port
+}
+
+// NewSpec validates the arguments and derives the names of a service
+func NewSpec(root, name, entityName, plural, port string) (Spec, error) {
+	if name == "" || entityName == "" {
+		return Spec{}, errors.New("-name and -entity are required")
+	}
+	if !strings.HasSuffix(name, "-service") {
+		name += "-service"
+	}
+	if !serviceNamePattern.MatchString(name) {
+		return Spec{}, fmt.Errorf("service name %q must be lowercase kebab-case", name)
+	}
+	if !entityNamePattern.MatchString(entityName) {
+		return Spec{}, fmt.Errorf("entity %q must be PascalCase", entityName)
+	}
+	words := splitWords(entityName)
+	if reservedNames[strings.ToLower(entityName[:1])+entityName[1:]] {
+		return Spec{}, fmt.Errorf("entity %q clashes with a package of the generated service", entityName)
+	}
+	if plural == "" {
+		plural = strings.Join(append(words[:len(words)-1:len(words)-1], pluralize(words[len(words)-1])), "-")
+	}
+	if !serviceNamePattern.MatchString(plural) {
+		return Spec{}, fmt.Errorf("plural %q must be lowercase kebab-case", plural)
+	}
+	module, err := modulePath(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return Spec{}, err
+	}
+
+	serviceWords := strings.Split(name, "-")
+	return Spec{
+		Module:       module,
+		Name:         name,
+		Title:        strings.Join(capitalize(serviceWords), " "),
+		GoName:       pascalCase(serviceWords),
+		ProtoPackage: strings.Join(serviceWords, ""),
+		Entity:       entityName,
+		EntityVar:    strings.ToLower(entityName[:1]) + entityName[1:],
+		EntityPlural: pascalCase(strings.Split(plural, "-")),
+		EntityLabel:  strings.Join(words, " "),
+		LabelPlural:  strings.ReplaceAll(plural, "-", " "),
+		FileName:     strings.Join(words, "_"),
+		Resource:     plural,
+		Table:        strings.ReplaceAll(plural, "-", "_"),
+		Port:         port,
+	}, nil
+}
+
+// splitWords splits a PascalCase name into lowercase words: "StockLevel" -> [stock level]
+func splitWords(name string) []string {
+	var words []string
+	start := 0
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(rune(name[i-1])) {
+			words = append(words, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(name[start:]))
+}
+
+// capitalize returns the words with their first letter in upper case
+func capitalize(words []string) []string {
+	capitalized := make([]string, 0, len(words))
+	for _, word := range words {
+		capitalized = append(capitalized, strings.ToUpper(word[:1])+word[1:])
+	}
+	return capitalized
+}
+
+// pascalCase joins lowercase words into a Go identifier: [stock levels] -> StockLevels
+func pascalCase(words []string) string {
+	return strings.Join(capitalize(words), "")
+}
+
+// pluralize returns the English plural of a lowercase word for the regular cases
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+// modulePath reads the module path from a go.mod file
+func modulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", fmt.Errorf("run the generator from the repository root or pass -root: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s has no module directive", goMod)
+}
+
+// File is a generated file, with its path relative to the repository root
+type File struct {
+	Path     string
+	Contents []byte
+}
+
+// Render renders every template for spec. Go sources are gofmt-ed, so a template error that
+// produces invalid Go is reported here instead of by the first build.
+func Render(spec Spec) ([]File, error) {
+	files := make([]File, 0, len(outputs))
+	for name, output := range outputs {
+		target, err := execute("path", output, spec)
+		if err != nil {
+			return nil, err
+		}
+		targetPath := string(target)
+		source, err := templates.ReadFile(path.Join("templates", name))
+		if err != nil {
+			return nil, err
+		}
+		contents, err := execute(name, string(source), spec)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(targetPath, ".go") {
+			if contents, err = format.Source(contents); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		files = append(files, File{Path: targetPath, Contents: contents})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+func execute(name, text string, spec Spec) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, spec); err != nil {
+		return nil, err
+	}
+	return []byte(out.String()), nil
+}
+
+// Write writes the files under root. Without force, nothing is written if any file exists.
+func Write(root string, files []File, force bool) error {
+	if !force {
+		for _, file := range files {
+			if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(file.Path))); err == nil {
+				return fmt.Errorf("%s already exists (use -force to overwrite)", file.Path)
+			}
+		}
+	}
+	for _, file := range files {
+		target := filepath.Join(root, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, file.Contents, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Instructions prints the steps that edit existing files: proto generation, gateway registration
+// and the build targets
+func Instructions(w io.Writer, spec Spec) error {
+	source, err := templates.ReadFile("templates/instructions.txt.tmpl")
+	if err != nil {
+		return err
+	}
+	text, err := execute("instructions", string(source), spec)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(text)
+	return err
+}
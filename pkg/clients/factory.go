@@ -0,0 +1,94 @@
+// Package clients provides typed wrappers around the gRPC clients of each service.
+// Connections are created through a ClientFactory, which installs metadata propagation,
+// retries and a per-service circuit breaker on every call.
+package clients
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+
+	"google.golang.org/grpc"
+)
+
+// ClientOptions configures the resilience behaviour of clients created by a ClientFactory
+type ClientOptions struct {
+	MaxRetries       int           // Extra attempts after the first failed call
+	RetryBackoff     time.Duration // Initial backoff, doubled after every attempt
+	BreakerThreshold int           // Consecutive failures that open the circuit
+	BreakerCooldown  time.Duration // Time the circuit stays open before a trial call is allowed
+}
+
+// DefaultClientOptions reads client options from environment variables
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		MaxRetries:       utils.GetEnvAsInt("GRPC_CLIENT_MAX_RETRIES", 2),
+		RetryBackoff:     utils.GetEnvDuration("GRPC_CLIENT_RETRY_BACKOFF", 100*time.Millisecond),
+		BreakerThreshold: utils.GetEnvAsInt("GRPC_CLIENT_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  utils.GetEnvDuration("GRPC_CLIENT_BREAKER_COOLDOWN", 30*time.Second),
+	}
+}
+
+// ClientFactory creates and caches connections to other services
+type ClientFactory struct {
+	logger  logger.Logger
+	options ClientOptions
+	mu      sync.Mutex
+	clients map[string]*core_grpc.BaseGrpcClient
+}
+
+// NewClientFactory creates a new ClientFactory
+func NewClientFactory(logger logger.Logger, options ClientOptions) *ClientFactory {
+	return &ClientFactory{
+		logger:  logger,
+		options: options,
+		clients: make(map[string]*core_grpc.BaseGrpcClient),
+	}
+}
+
+// Conn returns a connection for the configured service, dialing it on first use
+func (f *ClientFactory) Conn(config *core_grpc.GrpcClientConfig) (*core_grpc.BaseGrpcClient, error) {
+	key := fmt.Sprintf("%s:%d", config.ServiceHost, config.ServicePort)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if client, ok := f.clients[key]; ok {
+		return client, nil
+	}
+
+	breaker := NewCircuitBreaker(config.ServiceName, f.options.BreakerThreshold, f.options.BreakerCooldown)
+	client, err := core_grpc.NewBaseGrpcClient(f.logger, config,
+		// Metadata propagation is installed by NewBaseGrpcClient and runs first, so metadata is
+		// attached once; the breaker sees every retried attempt
+		grpc.WithChainUnaryInterceptor(
+			RetryInterceptor(f.options.MaxRetries, f.options.RetryBackoff),
+			breaker.UnaryClientInterceptor(),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	f.clients[key] = client
+	return client, nil
+}
+
+// Close closes all connections created by the factory
+func (f *ClientFactory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var firstErr error
+	for key, client := range f.clients {
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(f.clients, key)
+	}
+	return firstErr
+}
@@ -0,0 +1,25 @@
+package clients
+
+import (
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+
+	"google.golang.org/grpc"
+)
+
+// RequestIDKey is the metadata key carrying the request ID across services
+const RequestIDKey = core_grpc.RequestIDMetadataKey
+
+// MetadataPropagationInterceptor forwards auth, request ID and trace metadata from the incoming
+// context. Connections created with core_grpc.NewBaseGrpcClient already install it.
+//
+// Deprecated: use core_grpc.PropagationUnaryClientInterceptor.
+func MetadataPropagationInterceptor() grpc.UnaryClientInterceptor {
+	return core_grpc.PropagationUnaryClientInterceptor(0)
+}
+
+// MetadataPropagationStreamInterceptor is the streaming counterpart of MetadataPropagationInterceptor
+//
+// Deprecated: use core_grpc.PropagationStreamClientInterceptor.
+func MetadataPropagationStreamInterceptor() grpc.StreamClientInterceptor {
+	return core_grpc.PropagationStreamClientInterceptor()
+}
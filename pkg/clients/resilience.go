@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+)
+
+// isTransient reports whether err is a transport-level failure worth retrying.
+// Services return HTTP-style status codes for business errors, which are never retried.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryInterceptor retries transient failures with exponential backoff while the context allows it.
+// Only retry-safe methods (see core_grpc.MethodIdempotency) are retried: a failed call to an unsafe
+// method may still have taken effect, so it is returned to the caller.
+func RetryInterceptor(maxRetries int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || !core_grpc.MethodIdempotency(method).RetrySafe() {
+			return err
+		}
+		wait := backoff
+		for attempt := 0; attempt < maxRetries && isTransient(err); attempt++ {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(wait):
+			}
+			wait *= 2
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return err
+	}
+}
+
+// CircuitBreaker stops calling a service after repeated transient failures,
+// letting a single trial call through once the cooldown has elapsed. The trial is a retry-safe call,
+// since it goes to a service that was just failing; unsafe calls may take it only once the circuit
+// has been open for twice the cooldown, so a service that only gets unsafe calls still recovers.
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker; a threshold of zero disables it
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{name: name, threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may proceed
+func (b *CircuitBreaker) allow(retrySafe bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	// Open: allow exactly one trial call after the cooldown
+	cooldown := b.cooldown
+	if !retrySafe {
+		cooldown *= 2
+	}
+	if !b.trial && time.Since(b.openedAt) >= cooldown {
+		b.trial = true
+		return true
+	}
+	return false
+}
+
+// record updates the breaker state with the outcome of a call
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !isTransient(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// UnaryClientInterceptor returns the interceptor enforcing the breaker
+func (b *CircuitBreaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow(core_grpc.MethodIdempotency(method).RetrySafe()) {
+			return status.Errorf(codes.Unavailable, "circuit open for %s", b.name)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
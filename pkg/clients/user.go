@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/utils"
+	core_pb "golang-microservices-boilerplate/proto/core"
+	user_pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// UserClient is a typed client for the user service
+type UserClient struct {
+	client user_pb.UserServiceClient
+}
+
+// NewUserClient creates a new UserClient, using USER_SERVICE_HOST/USER_SERVICE_PORT for the address
+func NewUserClient(factory *ClientFactory) (*UserClient, error) {
+	config := core_grpc.DefaultGrpcClientConfig(
+		"user-service",
+		utils.GetEnv("USER_SERVICE_HOST", "user-service"),
+		utils.GetEnvAsInt("USER_SERVICE_PORT", 9090),
+	)
+	conn, err := factory.Conn(config)
+	if err != nil {
+		return nil, err
+	}
+	return &UserClient{client: user_pb.NewUserServiceClient(conn.Conn)}, nil
+}
+
+// Raw exposes the generated client for RPCs without a typed wrapper
+func (c *UserClient) Raw() user_pb.UserServiceClient {
+	return c.client
+}
+
+// Login authenticates a user and returns the issued tokens
+func (c *UserClient) Login(ctx context.Context, email, password string) (*user_pb.LoginResponse, error) {
+	return c.client.Login(ctx, &user_pb.LoginRequest{Email: email, Password: password})
+}
+
+// Refresh exchanges a refresh token for a new access token
+func (c *UserClient) Refresh(ctx context.Context, refreshToken string) (*user_pb.RefreshResponse, error) {
+	return c.client.Refresh(ctx, &user_pb.RefreshRequest{RefreshToken: refreshToken})
+}
+
+// Introspect asks the user service whether a token is active, for services that do not hold the
+// token secrets
+func (c *UserClient) Introspect(ctx context.Context, token string) (*user_pb.IntrospectResponse, error) {
+	return c.client.Introspect(ctx, &user_pb.IntrospectRequest{Token: token})
+}
+
+// GetByID returns a single user
+func (c *UserClient) GetByID(ctx context.Context, id string) (*user_pb.User, error) {
+	resp, err := c.client.GetByID(ctx, &user_pb.GetUserByIDRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// List returns a page of users
+func (c *UserClient) List(ctx context.Context, opts *core_pb.FilterOptions) ([]*user_pb.User, *core_pb.PaginationInfo, error) {
+	resp, err := c.client.List(ctx, &user_pb.ListUsersRequest{Options: opts})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Users, resp.PaginationInfo, nil
+}
+
+// FindWithFilter returns the users matching opts.Filters
+func (c *UserClient) FindWithFilter(ctx context.Context, opts *core_pb.FilterOptions) ([]*user_pb.User, *core_pb.PaginationInfo, error) {
+	resp, err := c.client.FindWithFilter(ctx, &user_pb.FindUsersWithFilterRequest{Options: opts})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Users, resp.PaginationInfo, nil
+}
+
+// Create creates a new user
+func (c *UserClient) Create(ctx context.Context, req *user_pb.CreateUserRequest) (*user_pb.User, error) {
+	resp, err := c.client.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// Update applies a partial update to a user
+func (c *UserClient) Update(ctx context.Context, req *user_pb.UpdateUserRequest) (*user_pb.User, error) {
+	resp, err := c.client.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// Delete soft deletes a user, or permanently deletes it when hardDelete is set
+func (c *UserClient) Delete(ctx context.Context, id string, hardDelete bool) error {
+	_, err := c.client.Delete(ctx, &user_pb.DeleteUserRequest{Id: id, HardDelete: hardDelete})
+	return err
+}
+
+// Restore undoes the soft delete of a user
+func (c *UserClient) Restore(ctx context.Context, id string) (*user_pb.User, error) {
+	resp, err := c.client.Restore(ctx, &user_pb.RestoreUserRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
@@ -0,0 +1,207 @@
+// Package authz delegates authorization decisions to a policy engine. A decision is asked for with
+// an Input (who is acting, on what, doing what, with which attributes), so rules that depend on
+// more than the caller's role, such as ownership, tenancy or the state of a record, live in a policy
+// instead of being hard-coded in Go.
+//
+// The default engine, "permissions", grants the permission "resource:action" from the role matrix
+// of package permissions. The "opa" engine evaluates a rego policy bundle embedded in the service;
+// it is compiled in only with the opa build tag (go build -tags opa), so other services carry no
+// dependency on OPA.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Supported AUTHZ_ENGINE values
+const (
+	EnginePermissions = "permissions"
+	EngineOPA         = "opa"
+)
+
+// Actor is the caller as seen by policies
+type Actor struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	TenantID string `json:"tenant_id"`
+	OrgRole  string `json:"org_role"`
+}
+
+// Input is what a decision is made on. Policies read it as input.actor, input.resource,
+// input.action and input.attributes.
+type Input struct {
+	Actor         Actor                  `json:"actor"`
+	Authenticated bool                   `json:"authenticated"`
+	Resource      string                 `json:"resource"`
+	Action        string                 `json:"action"`
+	Attributes    map[string]interface{} `json:"attributes"`
+}
+
+// Decision is the outcome of a check
+type Decision struct {
+	Allowed bool
+	Reason  string // Why the request was denied, if the engine says; never shown to the caller
+}
+
+// Authorizer decides whether an input is allowed. Implementations must be safe for concurrent use.
+// An error means no decision could be made; callers deny the request.
+type Authorizer interface {
+	Authorize(ctx context.Context, input Input) (Decision, error)
+}
+
+// AuthorizerFunc adapts a function to Authorizer
+type AuthorizerFunc func(ctx context.Context, input Input) (Decision, error)
+
+// Authorize implements Authorizer
+func (f AuthorizerFunc) Authorize(ctx context.Context, input Input) (Decision, error) {
+	return f(ctx, input)
+}
+
+// PermissionsAuthorizer allows an input when the actor's role grants "resource:action" in the
+// default permission matrix (see permissions.SetDefault)
+type PermissionsAuthorizer struct{}
+
+// Authorize implements Authorizer
+func (PermissionsAuthorizer) Authorize(_ context.Context, input Input) (Decision, error) {
+	required := permissions.New(input.Resource, input.Action)
+	if input.Authenticated && permissions.Has(input.Actor.Role, required) {
+		return Decision{Allowed: true}, nil
+	}
+	return Decision{Reason: fmt.Sprintf("role %q lacks permission %s", input.Actor.Role, required)}, nil
+}
+
+// Config selects and configures the engine
+type Config struct {
+	Engine     string // AUTHZ_ENGINE, default "permissions"
+	PolicyPath string // AUTHZ_POLICY_PATH: rego file, bundle directory or bundle .tar.gz (opa)
+	Query      string // AUTHZ_QUERY: rule holding the decision, default "data.authz.decision" (opa)
+}
+
+// LoadConfigFromEnv reads the engine configuration
+func LoadConfigFromEnv() Config {
+	return Config{
+		Engine:     strings.ToLower(utils.GetEnv("AUTHZ_ENGINE", EnginePermissions)),
+		PolicyPath: utils.GetEnv("AUTHZ_POLICY_PATH", ""),
+		Query:      utils.GetEnv("AUTHZ_QUERY", "data.authz.decision"),
+	}
+}
+
+// EngineFactory builds an engine from the configuration
+type EngineFactory func(ctx context.Context, config Config) (Authorizer, error)
+
+var (
+	enginesMu sync.RWMutex
+	engines   = map[string]EngineFactory{
+		EnginePermissions: func(context.Context, Config) (Authorizer, error) { return PermissionsAuthorizer{}, nil },
+	}
+)
+
+// RegisterEngine makes an engine available as an AUTHZ_ENGINE value; registering a name again
+// replaces it
+func RegisterEngine(name string, factory EngineFactory) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	engines[strings.ToLower(name)] = factory
+}
+
+// registeredEngines lists the available engines, sorted
+func registeredEngines() []string {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds the engine named by config.Engine
+func New(ctx context.Context, config Config) (Authorizer, error) {
+	name := strings.ToLower(config.Engine)
+	if name == "" {
+		name = EnginePermissions
+	}
+	enginesMu.RLock()
+	factory, ok := engines[name]
+	enginesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported AUTHZ_ENGINE %q (available: %s; opa needs the build tag of the same name)",
+			config.Engine, strings.Join(registeredEngines(), ", "))
+	}
+	return factory(ctx, config)
+}
+
+// UseFromEnv builds the configured engine and installs it with SetDefault. It has the shape of a
+// preflight check, so a missing or invalid policy is reported with the other configuration problems.
+func UseFromEnv(ctx context.Context) error {
+	a, err := New(ctx, LoadConfigFromEnv())
+	if err != nil {
+		return err
+	}
+	SetDefault(a)
+	return nil
+}
+
+// holder lets atomic.Value store different Authorizer implementations
+type holder struct{ Authorizer }
+
+var active atomic.Value
+
+// Default returns the authorizer installed with SetDefault, or PermissionsAuthorizer
+func Default() Authorizer {
+	if h, ok := active.Load().(holder); ok {
+		return h.Authorizer
+	}
+	return PermissionsAuthorizer{}
+}
+
+// SetDefault installs the authorizer used by Authorize, Require and the gRPC interceptors
+func SetDefault(a Authorizer) {
+	active.Store(holder{a})
+}
+
+// InputFromContext builds the input for the actor of ctx, if any
+func InputFromContext(ctx context.Context, resource, action string, attributes map[string]interface{}) Input {
+	input := Input{Resource: resource, Action: action, Attributes: attributes}
+	if input.Attributes == nil {
+		input.Attributes = map[string]interface{}{}
+	}
+	if actor, ok := usecase.ActorFromContext(ctx); ok {
+		input.Authenticated = true
+		input.Actor = Actor{ID: actor.ID, Email: actor.Email, Role: actor.Role, TenantID: actor.TenantID, OrgRole: actor.OrgRole}
+	}
+	return input
+}
+
+// Authorize asks the default authorizer whether the actor of ctx may perform action on resource
+func Authorize(ctx context.Context, resource, action string, attributes map[string]interface{}) (Decision, error) {
+	return Default().Authorize(ctx, InputFromContext(ctx, resource, action, attributes))
+}
+
+// Require is Authorize for use cases: it returns ErrForbidden when the request is denied and
+// ErrInternal when no decision could be made
+//
+//	if err := authz.Require(ctx, "reports", "read", map[string]interface{}{"owner_id": report.OwnerID.String()}); err != nil {
+//		return nil, err
+//	}
+func Require(ctx context.Context, resource, action string, attributes map[string]interface{}) error {
+	decision, err := Authorize(ctx, resource, action, attributes)
+	if err != nil {
+		return usecase.NewUseCaseError(usecase.ErrInternal, "failed to evaluate authorization policy")
+	}
+	if !decision.Allowed {
+		return usecase.NewLocalizedError(usecase.ErrForbidden, "auth.resource_forbidden", nil)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+//go:build opa
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// OPA evaluates the decision rule of a rego policy, compiled once when it is created. The rule
+// yields either a boolean or an object {"allow": bool, "reason": string}; an undefined rule denies:
+//
+//	package authz
+//
+//	default decision := {"allow": false}
+//
+//	decision := {"allow": true} if input.actor.role == "admin"
+//
+//	decision := {"allow": true} if {
+//		input.resource == "reports"
+//		input.action == "read"
+//		input.attributes.owner_id == input.actor.id
+//	}
+type OPA struct {
+	query rego.PreparedEvalQuery
+}
+
+// OPAOptions configures an OPA engine
+type OPAOptions struct {
+	Query      string            // Rule holding the decision, e.g. "data.authz.decision"
+	PolicyPath string            // Rego file, bundle directory or bundle .tar.gz; optional with Modules
+	Modules    map[string]string // Rego sources by file name, e.g. read with go:embed
+}
+
+// NewOPA compiles the policy. It fails when there is no policy, it does not compile, or the query
+// is invalid.
+func NewOPA(ctx context.Context, opts OPAOptions) (*OPA, error) {
+	if opts.Query == "" {
+		return nil, errors.New("opa: query is required")
+	}
+	if opts.PolicyPath == "" && len(opts.Modules) == 0 {
+		return nil, errors.New("opa: AUTHZ_POLICY_PATH or embedded modules are required")
+	}
+
+	options := []func(*rego.Rego){rego.Query(opts.Query)}
+	for name, source := range opts.Modules {
+		options = append(options, rego.Module(name, source))
+	}
+	if opts.PolicyPath != "" {
+		info, err := os.Stat(opts.PolicyPath)
+		if err != nil {
+			return nil, fmt.Errorf("opa: policy path: %w", err)
+		}
+		if info.IsDir() || strings.HasSuffix(opts.PolicyPath, ".tar.gz") {
+			options = append(options, rego.LoadBundle(opts.PolicyPath))
+		} else {
+			options = append(options, rego.Load([]string{opts.PolicyPath}, nil))
+		}
+	}
+
+	query, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opa: failed to compile policy: %w", err)
+	}
+	return &OPA{query: query}, nil
+}
+
+// Authorize implements Authorizer
+func (o *OPA) Authorize(ctx context.Context, input Input) (Decision, error) {
+	// Round trip through JSON so that policies see the json field names and plain JSON values
+	data, err := json.Marshal(input)
+	if err != nil {
+		return Decision{}, fmt.Errorf("opa: failed to encode input: %w", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return Decision{}, fmt.Errorf("opa: failed to encode input: %w", err)
+	}
+
+	results, err := o.query.Eval(ctx, rego.EvalInput(document))
+	if err != nil {
+		return Decision{}, fmt.Errorf("opa: evaluation failed: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return Decision{Reason: "policy is undefined for the input"}, nil
+	}
+	switch value := results[0].Expressions[0].Value.(type) {
+	case bool:
+		return Decision{Allowed: value}, nil
+	case map[string]interface{}:
+		allowed, ok := value["allow"].(bool)
+		if !ok {
+			return Decision{}, errors.New("opa: decision object has no boolean \"allow\"")
+		}
+		reason, _ := value["reason"].(string)
+		return Decision{Allowed: allowed, Reason: reason}, nil
+	default:
+		return Decision{}, fmt.Errorf("opa: decision must be a boolean or an object, got %T", value)
+	}
+}
+
+func init() {
+	RegisterEngine(EngineOPA, func(ctx context.Context, config Config) (Authorizer, error) {
+		return NewOPA(ctx, OPAOptions{Query: config.Query, PolicyPath: config.PolicyPath})
+	})
+}
@@ -0,0 +1,62 @@
+// Package blob stores binary objects such as generated exports and reports by key, on the local
+// filesystem or in memory, so services hand large files around by reference instead of by value.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ErrNotFound is returned when no object is stored under the key
+var ErrNotFound = errors.New("blob: not found")
+
+// Store is a blob backend. Keys are slash-separated relative paths such as "exports/<id>.zip".
+type Store interface {
+	// Put stores the content of r under key, replacing any previous object
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open returns a reader for the object under key; the caller closes it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object under key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Config holds the blob store configuration
+type Config struct {
+	Driver string // "file" or "memory"
+	Dir    string // Root directory of the file store
+}
+
+// LoadConfigFromEnv reads the blob store configuration from environment variables
+func LoadConfigFromEnv() Config {
+	return Config{
+		Driver: strings.ToLower(utils.GetEnv("BLOB_DRIVER", "file")),
+		Dir:    utils.GetEnv("BLOB_DIR", "./data/blobs"),
+	}
+}
+
+// NewFromConfig creates the store selected by cfg.Driver
+func NewFromConfig(cfg Config) (Store, error) {
+	switch cfg.Driver {
+	case "", "file":
+		return NewFileStore(cfg.Dir)
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown BLOB_DRIVER %q (expected file or memory)", cfg.Driver)
+	}
+}
+
+// cleanKey validates key and returns it in canonical form; keys cannot escape the store's root
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(key, "/"))
+	if key == "" || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("blob: invalid key %q", key)
+	}
+	return cleaned, nil
+}
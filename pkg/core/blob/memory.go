@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// MemoryStore keeps objects in process memory. It suits tests and single-instance development;
+// objects are lost on restart.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{objects: make(map[string][]byte)}
+}
+
+// Put implements Store
+func (s *MemoryStore) Put(ctx context.Context, key string, r io.Reader) error {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.objects[cleaned] = data
+	s.mu.Unlock()
+	return nil
+}
+
+// Open implements Store
+func (s *MemoryStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	data, ok := s.objects[cleaned]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Delete implements Store
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.objects, cleaned)
+	s.mu.Unlock()
+	return nil
+}
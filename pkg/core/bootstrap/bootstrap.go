@@ -0,0 +1,114 @@
+// Package bootstrap orchestrates service startup: ordered phases with logging, retrying of
+// dependencies that may not be up yet (such as the database), and readiness/liveness probes.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Phase is one named startup step
+type Phase struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Runner executes startup phases in order, stopping at the first failure
+type Runner struct {
+	logger logger.Logger
+	phases []Phase
+}
+
+// NewRunner creates a startup runner
+func NewRunner(log logger.Logger) *Runner {
+	return &Runner{logger: log}
+}
+
+// Phase appends a startup phase
+func (r *Runner) Phase(name string, run func(ctx context.Context) error) *Runner {
+	r.phases = append(r.phases, Phase{Name: name, Run: run})
+	return r
+}
+
+// Run executes the phases in order
+func (r *Runner) Run(ctx context.Context) error {
+	started := time.Now()
+	for _, phase := range r.phases {
+		phaseStart := time.Now()
+		r.logger.Info("Startup phase started", "phase", phase.Name)
+		if err := phase.Run(ctx); err != nil {
+			r.logger.Error("Startup phase failed", "phase", phase.Name, "duration", time.Since(phaseStart), "error", err)
+			return fmt.Errorf("startup phase %q failed: %w", phase.Name, err)
+		}
+		r.logger.Info("Startup phase completed", "phase", phase.Name, "duration", time.Since(phaseStart))
+	}
+	r.logger.Info("Startup completed", "phases", len(r.phases), "duration", time.Since(started))
+	return nil
+}
+
+// RetryConfig controls retrying with exponential backoff
+type RetryConfig struct {
+	Attempts   int // 0 retries until ctx is cancelled
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// LoadRetryConfigFromEnv reads a retry configuration from <prefix>_ATTEMPTS, <prefix>_BACKOFF and
+// <prefix>_BACKOFF_MAX, e.g. DB_CONNECT_ATTEMPTS
+func LoadRetryConfigFromEnv(prefix string) RetryConfig {
+	env := utils.NewEnvScope(prefix)
+	return RetryConfig{
+		Attempts:   env.Int("ATTEMPTS", 10),
+		Backoff:    env.Duration("BACKOFF", time.Second),
+		MaxBackoff: env.Duration("BACKOFF_MAX", 30*time.Second),
+	}
+}
+
+// Retry calls fn until it succeeds, the attempts are exhausted or ctx is cancelled,
+// doubling the wait between attempts up to MaxBackoff
+func Retry(ctx context.Context, log logger.Logger, name string, cfg RetryConfig, fn func(ctx context.Context) error) error {
+	backoff := cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if cfg.Attempts > 0 && attempt >= cfg.Attempts {
+			return fmt.Errorf("%s failed after %d attempts: %w", name, attempt, err)
+		}
+		log.Warn("Dependency not available, retrying", "dependency", name, "attempt", attempt, "retry_in", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w (last error: %v)", name, ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; cfg.MaxBackoff > 0 && backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
+
+// ConnectDatabase opens the database, retrying with DB_CONNECT_* backoff until it is reachable
+func ConnectDatabase(ctx context.Context, log logger.Logger, cfg database.DBConfig) (*database.DatabaseConnection, error) {
+	var db *database.DatabaseConnection
+	err := Retry(ctx, log, "database", LoadRetryConfigFromEnv("DB_CONNECT"), func(ctx context.Context) error {
+		conn, err := database.NewDatabaseConnection(cfg)
+		if err != nil {
+			return err
+		}
+		// gorm.Open may succeed lazily; make sure the server actually answers
+		if err := conn.PingContext(ctx); err != nil {
+			conn.Close()
+			return err
+		}
+		db = conn
+		return nil
+	})
+	return db, err
+}
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/preflight"
+)
+
+// DatabaseCheck is a preflight check of the database settings: a production password other than
+// the development default and a database host that resolves. A host that does not answer yet is
+// only a warning; the database phase keeps retrying it.
+func DatabaseCheck(cfg database.DBConfig) preflight.Check {
+	return func(ctx context.Context) error {
+		if strings.EqualFold(cfg.Driver, database.DriverSQLite) || cfg.URI != "" {
+			return nil // Nothing to resolve, or the host is inside the URI
+		}
+		var errs []error
+		if preflight.IsProduction() && cfg.Password == "postgres" {
+			errs = append(errs, errors.New("DB_PASSWORD is set to the development default"))
+		}
+		return errors.Join(append(errs, preflight.Reachable(cfg.Host, cfg.Port)(ctx))...)
+	}
+}
+
+// FieldEncryptionCheck is a preflight check of FIELD_ENCRYPTION_KEYS: malformed keys are an error,
+// and so are missing keys in production (elsewhere the development key is used)
+func FieldEncryptionCheck(context.Context) error {
+	_, err := database.LoadFieldCipherFromEnv()
+	if errors.Is(err, database.ErrFieldEncryptionKeysNotSet) && !preflight.IsProduction() {
+		return preflight.Warning(errors.New("FIELD_ENCRYPTION_KEYS is not set, the insecure development key is used"))
+	}
+	return err
+}
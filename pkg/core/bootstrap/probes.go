@@ -0,0 +1,182 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Check reports the health of one dependency; a nil error means healthy
+type Check func(ctx context.Context) error
+
+// namedCheck is a registered check
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// checkResult is the per-check entry in a probe response
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Probes serves Kubernetes liveness (/live) and readiness (/ready) endpoints on a separate port,
+// so probes keep working while the service's main listener is starting or draining.
+// The service is not ready until SetReady(true) is called and every readiness check passes.
+type Probes struct {
+	server       *http.Server
+	mux          *http.ServeMux
+	logger       logger.Logger
+	checkTimeout time.Duration
+	ready        atomic.Bool
+
+	mu        sync.RWMutex
+	liveness  []namedCheck
+	readiness []namedCheck
+}
+
+// NewProbes creates the probe server listening on addr (e.g. ":8081")
+func NewProbes(addr string, log logger.Logger) *Probes {
+	p := &Probes{
+		logger:       log,
+		checkTimeout: utils.GetEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/live", p.handleLive)
+	mux.HandleFunc("/ready", p.handleReady)
+	p.mux = mux
+	p.server = &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	return p
+}
+
+// NewProbesFromEnv creates the probe server on HEALTH_PORT (default 8081)
+func NewProbesFromEnv(log logger.Logger) *Probes {
+	return NewProbes(":"+utils.GetEnv("HEALTH_PORT", "8081"), log)
+}
+
+// AddLivenessCheck registers a check whose failure means the process should be restarted.
+// Keep these to conditions a restart fixes; a failing dependency belongs in readiness.
+func (p *Probes) AddLivenessCheck(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.liveness = append(p.liveness, namedCheck{name: name, check: check})
+}
+
+// AddReadinessCheck registers a check whose failure takes the service out of load balancing
+func (p *Probes) AddReadinessCheck(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.readiness = append(p.readiness, namedCheck{name: name, check: check})
+}
+
+// Handle serves an additional operational endpoint on the probe port, e.g. "/metrics"
+func (p *Probes) Handle(pattern string, handler http.Handler) {
+	p.mux.Handle(pattern, handler)
+}
+
+// PrometheusCollector writes metrics in the Prometheus text exposition format
+type PrometheusCollector interface {
+	WritePrometheus(w io.Writer) error
+}
+
+// MetricsHandler serves the metrics of several collectors on one endpoint, e.g. "/metrics"
+func MetricsHandler(collectors ...PrometheusCollector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		for _, c := range collectors {
+			if err := c.WritePrometheus(w); err != nil {
+				return
+			}
+		}
+	})
+}
+
+// SetReady marks startup as finished (or, with false, the start of shutdown draining)
+func (p *Probes) SetReady(ready bool) {
+	p.ready.Store(ready)
+}
+
+// Start serves the probes in the background
+func (p *Probes) Start() {
+	go func() {
+		if err := p.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Error("Probe server failed", "addr", p.server.Addr, "error", err)
+		}
+	}()
+	p.logger.Info("Probe server started", "addr", p.server.Addr)
+}
+
+// Shutdown stops the probe server
+func (p *Probes) Shutdown(ctx context.Context) error {
+	return p.server.Shutdown(ctx)
+}
+
+func (p *Probes) handleLive(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	checks := p.liveness
+	p.mu.RUnlock()
+
+	results, ok := p.run(r.Context(), checks)
+	writeProbe(w, ok, results)
+}
+
+func (p *Probes) handleReady(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	checks := p.readiness
+	p.mu.RUnlock()
+
+	results, ok := p.run(r.Context(), checks)
+	if !p.ready.Load() {
+		results["startup"] = checkResult{Status: "pending"}
+		ok = false
+	}
+	writeProbe(w, ok, results)
+}
+
+// run executes checks concurrently, each bounded by the check timeout
+func (p *Probes) run(ctx context.Context, checks []namedCheck) (map[string]checkResult, bool) {
+	ctx, cancel := context.WithTimeout(ctx, p.checkTimeout)
+	defer cancel()
+
+	results := make(map[string]checkResult, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ok := true
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c namedCheck) {
+			defer wg.Done()
+			err := c.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				ok = false
+				results[c.name] = checkResult{Status: "failing", Error: err.Error()}
+				return
+			}
+			results[c.name] = checkResult{Status: "ok"}
+		}(c)
+	}
+	wg.Wait()
+	return results, ok
+}
+
+func writeProbe(w http.ResponseWriter, ok bool, results map[string]checkResult) {
+	status, code := "ok", http.StatusOK
+	if !ok {
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": status, "checks": results})
+}
@@ -0,0 +1,209 @@
+// Package cache provides a shared cache abstraction with Redis and in-memory stores, so token
+// blacklists, rate-limit counters and repository reads are cached the same way in every service.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ErrMiss is returned by Get when the key is not cached
+var ErrMiss = errors.New("cache: miss")
+
+// Store is a cache backend. Values are opaque bytes; a ttl of 0 means no expiry.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Incr adds delta to an integer counter and returns the new value. The ttl is applied
+	// only when the counter is created, which gives fixed windows for rate limiting.
+	Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+	Close() error
+}
+
+// Loader produces a value on a cache miss
+type Loader func(ctx context.Context) ([]byte, error)
+
+// Cache is a Store with key namespacing, metrics and de-duplicated loading
+type Cache interface {
+	Store
+	// GetOrLoad returns the cached value or calls load and caches its result.
+	// Concurrent misses for the same key share a single load.
+	GetOrLoad(ctx context.Context, key string, ttl time.Duration, load Loader) ([]byte, error)
+	// Stats returns a snapshot of the cache's counters
+	Stats() Stats
+}
+
+// Stats are cumulative cache counters
+type Stats struct {
+	Hits       uint64 `json:"hits"`
+	Misses     uint64 `json:"misses"`
+	Sets       uint64 `json:"sets"`
+	Deletes    uint64 `json:"deletes"`
+	Loads      uint64 `json:"loads"`
+	LoadErrors uint64 `json:"load_errors"`
+	Errors     uint64 `json:"errors"` // Store failures other than misses
+}
+
+// HitRatio returns hits / (hits + misses), or 0 before any lookup
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Config holds the cache configuration
+type Config struct {
+	Driver string // "memory" or "redis"
+	Prefix string // Prepended to every key, e.g. "user-service:"
+	Memory MemoryConfig
+	Redis  RedisConfig
+}
+
+// LoadConfigFromEnv reads the cache configuration from environment variables
+func LoadConfigFromEnv() Config {
+	return Config{
+		Driver: strings.ToLower(utils.GetEnv("CACHE_DRIVER", "memory")),
+		Prefix: utils.GetEnv("CACHE_PREFIX", ""),
+		Memory: LoadMemoryConfigFromEnv(),
+		Redis:  LoadRedisConfigFromEnv(),
+	}
+}
+
+// NewFromConfig creates the store selected by cfg.Driver and wraps it in a Cache
+func NewFromConfig(cfg Config) (Cache, error) {
+	var store Store
+	switch cfg.Driver {
+	case "", "memory":
+		store = NewMemoryStore(cfg.Memory)
+	case "redis":
+		redisStore, err := NewRedisStore(cfg.Redis)
+		if err != nil {
+			return nil, err
+		}
+		store = redisStore
+	default:
+		return nil, fmt.Errorf("unknown CACHE_DRIVER %q (expected memory or redis)", cfg.Driver)
+	}
+	return New(store, cfg.Prefix), nil
+}
+
+// cache implements Cache on top of a Store
+type cache struct {
+	store  Store
+	prefix string
+	group  singleflight.Group
+
+	hits, misses, sets, deletes, loads, loadErrors, errors atomic.Uint64
+}
+
+// New wraps a store, prefixing every key with prefix
+func New(store Store, prefix string) Cache {
+	return &cache{store: store, prefix: prefix}
+}
+
+func (c *cache) key(key string) string {
+	return c.prefix + key
+}
+
+// Get implements Store
+func (c *cache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.store.Get(ctx, c.key(key))
+	switch {
+	case err == nil:
+		c.hits.Add(1)
+	case errors.Is(err, ErrMiss):
+		c.misses.Add(1)
+	default:
+		c.errors.Add(1)
+	}
+	return value, err
+}
+
+// Set implements Store
+func (c *cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.store.Set(ctx, c.key(key), value, ttl); err != nil {
+		c.errors.Add(1)
+		return err
+	}
+	c.sets.Add(1)
+	return nil
+}
+
+// Delete implements Store
+func (c *cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, k := range keys {
+		prefixed[i] = c.key(k)
+	}
+	if err := c.store.Delete(ctx, prefixed...); err != nil {
+		c.errors.Add(1)
+		return err
+	}
+	c.deletes.Add(uint64(len(keys)))
+	return nil
+}
+
+// Incr implements Store
+func (c *cache) Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	n, err := c.store.Incr(ctx, c.key(key), delta, ttl)
+	if err != nil {
+		c.errors.Add(1)
+	}
+	return n, err
+}
+
+// Close implements Store
+func (c *cache) Close() error {
+	return c.store.Close()
+}
+
+// GetOrLoad implements Cache. A failing store is treated as a miss so the cache never
+// makes a read fail that the loader could serve.
+func (c *cache) GetOrLoad(ctx context.Context, key string, ttl time.Duration, load Loader) ([]byte, error) {
+	if value, err := c.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		c.loads.Add(1)
+		value, err := load(ctx)
+		if err != nil {
+			c.loadErrors.Add(1)
+			return nil, err
+		}
+		// Caching is best effort; the loaded value is returned either way
+		_ = c.Set(ctx, key, value, ttl)
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]byte), nil
+}
+
+// Stats implements Cache
+func (c *cache) Stats() Stats {
+	return Stats{
+		Hits:       c.hits.Load(),
+		Misses:     c.misses.Load(),
+		Sets:       c.sets.Load(),
+		Deletes:    c.deletes.Load(),
+		Loads:      c.loads.Load(),
+		LoadErrors: c.loadErrors.Load(),
+		Errors:     c.errors.Load(),
+	}
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// GetJSON reads a JSON-encoded value. It returns ErrMiss if the key is not cached.
+func GetJSON[T any](ctx context.Context, c Cache, key string) (T, error) {
+	var value T
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+// SetJSON stores a value as JSON
+func SetJSON[T any](ctx context.Context, c Cache, key string, value T, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, key, data, ttl)
+}
+
+// GetOrLoadJSON is GetOrLoad for JSON-encoded values
+func GetOrLoadJSON[T any](ctx context.Context, c Cache, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	data, err := c.GetOrLoad(ctx, key, ttl, func(ctx context.Context) ([]byte, error) {
+		loaded, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(loaded)
+	})
+	if err != nil {
+		return value, err
+	}
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+// TokenBlacklist records revoked tokens (by JWT ID) until they would have expired anyway
+type TokenBlacklist struct {
+	cache Cache
+}
+
+// NewTokenBlacklist creates a token blacklist on the given cache
+func NewTokenBlacklist(c Cache) *TokenBlacklist {
+	return &TokenBlacklist{cache: c}
+}
+
+// Revoke blacklists a token until its expiry; tokens that already expired are ignored
+func (b *TokenBlacklist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return b.cache.Set(ctx, "token:revoked:"+tokenID, []byte("1"), ttl)
+}
+
+// IsRevoked reports whether a token has been revoked
+func (b *TokenBlacklist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	_, err := b.cache.Get(ctx, "token:revoked:"+tokenID)
+	if errors.Is(err, ErrMiss) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// RateCounter counts hits per key in fixed windows, e.g. requests per client per minute
+type RateCounter struct {
+	cache  Cache
+	window time.Duration
+}
+
+// NewRateCounter creates a rate counter with the given window length
+func NewRateCounter(c Cache, window time.Duration) *RateCounter {
+	return &RateCounter{cache: c, window: window}
+}
+
+// Hit records one hit for key and returns the count in the current window and when it resets
+func (r *RateCounter) Hit(ctx context.Context, key string) (int64, time.Time, error) {
+	windowStart := time.Now().Truncate(r.window)
+	count, err := r.cache.Incr(ctx, r.key(key, windowStart), 1, r.window)
+	return count, windowStart.Add(r.window), err
+}
+
+// Peek returns the count of key in the current window and when it resets, without recording a hit
+func (r *RateCounter) Peek(ctx context.Context, key string) (int64, time.Time, error) {
+	windowStart := time.Now().Truncate(r.window)
+	resetAt := windowStart.Add(r.window)
+	raw, err := r.cache.Get(ctx, r.key(key, windowStart))
+	if errors.Is(err, ErrMiss) {
+		return 0, resetAt, nil
+	}
+	if err != nil {
+		return 0, resetAt, err
+	}
+	count, err := strconv.ParseInt(string(raw), 10, 64)
+	return count, resetAt, err
+}
+
+func (r *RateCounter) key(key string, windowStart time.Time) string {
+	return fmt.Sprintf("rate:%s:%d", key, windowStart.Unix())
+}
+
+// ReadThrough caches repository reads of one resource type by ID. Writers must call
+// Invalidate after changing a record so other instances stop serving the old value.
+type ReadThrough[T any] struct {
+	cache    Cache
+	resource string
+	ttl      time.Duration
+}
+
+// NewReadThrough creates a read-through cache for a resource, e.g. NewReadThrough[entity.User](c, "user", time.Minute)
+func NewReadThrough[T any](c Cache, resource string, ttl time.Duration) *ReadThrough[T] {
+	return &ReadThrough[T]{cache: c, resource: resource, ttl: ttl}
+}
+
+// Get returns the cached record or loads it; concurrent misses for the same ID share one load
+func (r *ReadThrough[T]) Get(ctx context.Context, id string, load func(ctx context.Context) (T, error)) (T, error) {
+	return GetOrLoadJSON(ctx, r.cache, r.key(id), r.ttl, load)
+}
+
+// Invalidate drops the cached records with the given IDs
+func (r *ReadThrough[T]) Invalidate(ctx context.Context, ids ...string) error {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.key(id)
+	}
+	return r.cache.Delete(ctx, keys...)
+}
+
+func (r *ReadThrough[T]) key(id string) string {
+	return r.resource + ":" + id
+}
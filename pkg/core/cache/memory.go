@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// MemoryConfig configures the in-memory store
+type MemoryConfig struct {
+	MaxEntries int // Least recently used entries are evicted beyond this; 0 means unbounded
+}
+
+// LoadMemoryConfigFromEnv reads the in-memory store configuration from environment variables
+func LoadMemoryConfigFromEnv() MemoryConfig {
+	return MemoryConfig{
+		MaxEntries: utils.GetEnvAsInt("CACHE_MEMORY_MAX_ENTRIES", 10000),
+	}
+}
+
+// memoryEntry is a cached value with its expiry (zero for none)
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryStore is a process-local LRU store with per-entry expiry. It suits single-instance
+// deployments and tests; use Redis when several instances must share cached state.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List // Front is most recently used
+}
+
+// NewMemoryStore creates an in-memory store
+func NewMemoryStore(cfg MemoryConfig) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: cfg.MaxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.lookup(key)
+	if !ok {
+		return nil, ErrMiss
+	}
+	return append([]byte(nil), entry.value...), nil
+}
+
+// Set implements Store
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(key, append([]byte(nil), value...), expiry(ttl))
+	return nil
+}
+
+// Delete implements Store
+func (s *MemoryStore) Delete(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := s.entries[key]; ok {
+			s.remove(el)
+		}
+	}
+	return nil
+}
+
+// Incr implements Store
+func (s *MemoryStore) Incr(_ context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	expiresAt := expiry(ttl)
+	if entry, ok := s.lookup(key); ok {
+		current, err := strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		n = current
+		expiresAt = entry.expiresAt
+	}
+	n += delta
+	s.put(key, []byte(strconv.FormatInt(n, 10)), expiresAt)
+	return n, nil
+}
+
+// Close implements Store
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = make(map[string]*list.Element)
+	s.lru.Init()
+	return nil
+}
+
+// Len returns the number of entries, including expired ones not yet evicted
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+// lookup returns a live entry and marks it as recently used; expired entries are dropped
+func (s *MemoryStore) lookup(key string) (*memoryEntry, bool) {
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		s.remove(el)
+		return nil, false
+	}
+	s.lru.MoveToFront(el)
+	return entry, true
+}
+
+func (s *MemoryStore) put(key string, value []byte, expiresAt time.Time) {
+	if el, ok := s.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		s.lru.MoveToFront(el)
+		return
+	}
+	s.entries[key] = s.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for s.maxEntries > 0 && s.lru.Len() > s.maxEntries {
+		s.remove(s.lru.Back())
+	}
+}
+
+func (s *MemoryStore) remove(el *list.Element) {
+	s.lru.Remove(el)
+	delete(s.entries, el.Value.(*memoryEntry).key)
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
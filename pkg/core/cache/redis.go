@@ -0,0 +1,291 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// RedisConfig configures the Redis store
+type RedisConfig struct {
+	Addr        string
+	Username    string
+	Password    string
+	DB          int
+	PoolSize    int           // Idle connections kept for reuse
+	DialTimeout time.Duration // Timeout for establishing a connection
+	IOTimeout   time.Duration // Per-command timeout when the context has no earlier deadline
+}
+
+// LoadRedisConfigFromEnv reads the Redis configuration from environment variables
+func LoadRedisConfigFromEnv() RedisConfig {
+	return RedisConfig{
+		Addr:        utils.GetEnv("REDIS_ADDR", "localhost:6379"),
+		Username:    utils.GetEnv("REDIS_USERNAME", ""),
+		Password:    utils.GetEnv("REDIS_PASSWORD", ""),
+		DB:          utils.GetEnvAsInt("REDIS_DB", 0),
+		PoolSize:    utils.GetEnvAsInt("REDIS_POOL_SIZE", 10),
+		DialTimeout: utils.GetEnvDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
+		IOTimeout:   utils.GetEnvDuration("REDIS_IO_TIMEOUT", 3*time.Second),
+	}
+}
+
+// incrScript increments a counter and sets its expiry only when the increment created it
+const incrScript = `local v = redis.call('INCRBY', KEYS[1], ARGV[1])
+if v == tonumber(ARGV[1]) and tonumber(ARGV[2]) > 0 then redis.call('PEXPIRE', KEYS[1], ARGV[2]) end
+return v`
+
+// RedisStore is a Store backed by Redis, speaking RESP directly over a small connection pool
+type RedisStore struct {
+	cfg    RedisConfig
+	idle   chan *redisConn
+	closed atomic.Bool
+}
+
+// redisError is an error reply from the server
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// NewRedisStore connects to Redis and verifies the connection
+func NewRedisStore(cfg RedisConfig) (*RedisStore, error) {
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 10
+	}
+	s := &RedisStore{cfg: cfg, idle: make(chan *redisConn, cfg.PoolSize)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DialTimeout)
+	defer cancel()
+	if _, err := s.do(ctx, "PING"); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", cfg.Addr, err)
+	}
+	return s, nil
+}
+
+// Get implements Store
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := s.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrMiss
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, nil
+}
+
+// Set implements Store
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+// Delete implements Store
+func (s *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := s.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// Incr implements Store
+func (s *RedisStore) Incr(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	reply, err := s.do(ctx, "EVAL", incrScript, "1", key,
+		strconv.FormatInt(delta, 10), strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return 0, err
+	}
+	n, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCRBY reply %T", reply)
+	}
+	return n, nil
+}
+
+// Close implements Store
+func (s *RedisStore) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+	for {
+		select {
+		case conn := <-s.idle:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do runs one command. Connections that fail mid-command are discarded rather than reused,
+// since the protocol stream may be out of sync.
+func (s *RedisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	if s.closed.Load() {
+		return nil, errors.New("redis: store closed")
+	}
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(s.cfg.IOTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reply, err := conn.roundTrip(args)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		conn.Close()
+		return nil, err
+	}
+	s.release(conn)
+	return reply, err
+}
+
+// conn takes an idle connection or dials a new one
+func (s *RedisStore) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-s.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: s.cfg.DialTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+	if err := conn.SetDeadline(time.Now().Add(s.cfg.DialTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if s.cfg.Password != "" {
+		auth := []string{"AUTH", s.cfg.Password}
+		if s.cfg.Username != "" {
+			auth = []string{"AUTH", s.cfg.Username, s.cfg.Password}
+		}
+		if _, err := conn.roundTrip(auth); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if s.cfg.DB != 0 {
+		if _, err := conn.roundTrip([]string{"SELECT", strconv.Itoa(s.cfg.DB)}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// release returns a healthy connection to the pool, closing it if the pool is full
+func (s *RedisStore) release(conn *redisConn) {
+	if s.closed.Load() {
+		conn.Close()
+		return
+	}
+	select {
+	case s.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// roundTrip writes a command as a RESP array of bulk strings and reads the reply
+func (c *redisConn) roundTrip(args []string) (interface{}, error) {
+	fmt.Fprintf(c.w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(c.w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// readReply parses one RESP2 reply: strings and bulk strings as []byte (nil for a null bulk),
+// integers as int64, arrays as []interface{}, and error replies as redisError.
+func (c *redisConn) readReply() (interface{}, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return []byte(body), nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			item, err := c.readReply()
+			var replyErr redisError
+			if errors.As(err, &replyErr) {
+				// Keep reading so the connection stays in sync
+				item = replyErr
+			} else if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// BatchCreator writes entities in chunks; usecase.BaseUseCase satisfies it
+type BatchCreator[E entity.Entity] interface {
+	CreateInBatches(ctx context.Context, entities []*E, opts types.BatchOptions) (*types.BatchReport[E], error)
+}
+
+// StreamCreateSummary is the outcome of a streamed bulk create
+type StreamCreateSummary struct {
+	Received   int                  // Items read from the stream
+	CreatedIDs []uuid.UUID          // IDs of the created entities, in stream order
+	Failed     []types.BatchFailure // Items that were not created; Index is the position in the stream
+}
+
+// CreateFromStream reads items with recv until io.EOF, converts each with toEntity and hands them to
+// creator.CreateInBatches every opts.BatchSize items, so a client-streaming RPC never holds more
+// than one batch in memory. Items that fail to convert or to insert are reported in the summary
+// (pass opts.ContinueOnError to isolate failing rows instead of stopping at the first failed batch).
+// An error is returned when the stream breaks or a batch fails without ContinueOnError; the
+// summary then covers the items written so far.
+func CreateFromStream[Req any, E entity.Entity](
+	ctx context.Context,
+	recv func() (*Req, error),
+	toEntity func(*Req) (*E, error),
+	creator BatchCreator[E],
+	opts types.BatchOptions,
+) (*StreamCreateSummary, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = types.DefaultBatchOptions().BatchSize
+	}
+	summary := &StreamCreateSummary{}
+	batch := make([]*E, 0, opts.BatchSize)
+	positions := make([]int, 0, opts.BatchSize) // Stream position of each batch item
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		report, err := creator.CreateInBatches(ctx, batch, opts)
+		if report != nil {
+			for _, created := range report.Succeeded {
+				summary.CreatedIDs = append(summary.CreatedIDs, (*created).GetID())
+			}
+			for _, failure := range report.Failed {
+				failure.Index = positions[failure.Index]
+				summary.Failed = append(summary.Failed, failure)
+			}
+		}
+		batch, positions = batch[:0], positions[:0]
+		return err
+	}
+
+	for {
+		req, err := recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+		position := summary.Received
+		summary.Received++
+
+		item, err := toEntity(req)
+		if err != nil {
+			var ucErr *usecase.UseCaseError
+			if !errors.As(err, &ucErr) {
+				if log := logger.FromContext(ctx, nil); log != nil {
+					log.Error("Failed to convert streamed item", "index", position, "error", err)
+				}
+			}
+			summary.Failed = append(summary.Failed, types.BatchFailure{Index: position, Reason: usecase.FailureReason(ctx, err), Err: err})
+			continue
+		}
+		batch = append(batch, item)
+		positions = append(positions, position)
+		if len(batch) >= opts.BatchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+	err := flush()
+	slices.SortFunc(summary.Failed, func(a, b types.BatchFailure) int { return cmp.Compare(a.Index, b.Index) })
+	return summary, err
+}
@@ -1,33 +1,103 @@
 package controller
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"golang-microservices-boilerplate/pkg/core/i18n"
+	"golang-microservices-boilerplate/pkg/core/logger"
 	"golang-microservices-boilerplate/pkg/core/usecase"
-	"net/http"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
-func MapErrorToHttpStatus(err error) error {
+// GrpcError returns a gRPC status error. Handlers use it instead of status.Error so the codes stay
+// gRPC codes, which the gateway maps to HTTP statuses (InvalidArgument -> 400, NotFound -> 404, ...).
+func GrpcError(code codes.Code, msg string) error {
+	return status.Error(code, msg)
+}
+
+// GrpcErrorf is GrpcError with a formatted message
+func GrpcErrorf(code codes.Code, format string, args ...interface{}) error {
+	return status.Errorf(code, format, args...)
+}
+
+// useCaseErrorCodes maps each use case error type to the gRPC code it is returned as
+var useCaseErrorCodes = map[usecase.UseCaseErrorType]codes.Code{
+	usecase.ErrNotFound:          codes.NotFound,
+	usecase.ErrInvalidInput:      codes.InvalidArgument,
+	usecase.ErrConflict:          codes.AlreadyExists,
+	usecase.ErrInternal:          codes.Internal,
+	usecase.ErrUnauthorized:      codes.Unauthenticated,
+	usecase.ErrForbidden:         codes.PermissionDenied,
+	usecase.ErrResourceExhausted: codes.ResourceExhausted,
+}
+
+// FromUseCaseError converts an error returned by a use case into a gRPC status error. Use case errors
+// get the code of their type, status errors and context errors keep their code, and anything else
+// is Internal. Errors of unknown types, such as repository errors that may quote the database, are
+// logged with the request logger of ctx and returned with a generic message.
+func FromUseCaseError(ctx context.Context, err error) error {
 	var ucErr *usecase.UseCaseError
 	if errors.As(err, &ucErr) {
-		switch ucErr.Type {
-		case usecase.ErrNotFound:
-			return status.Error(http.StatusNotFound, ucErr.Message)
-		case usecase.ErrInvalidInput:
-			return status.Error(http.StatusBadRequest, ucErr.Message)
-		case usecase.ErrConflict:
-			return status.Error(http.StatusConflict, ucErr.Message)
-		case usecase.ErrInternal:
-			return status.Error(http.StatusInternalServerError, ucErr.Message)
-		case usecase.ErrUnauthorized:
-			return status.Error(http.StatusUnauthorized, ucErr.Message)
-		case usecase.ErrForbidden:
-			return status.Error(http.StatusForbidden, ucErr.Message)
-		default:
-			return status.Error(http.StatusInternalServerError, fmt.Sprintf("an unexpected error occurred: %v", ucErr.Message))
+		if code, ok := useCaseErrorCodes[ucErr.Type]; ok {
+			return newStatusError(code, ucErr)
 		}
+		return internalError(ctx, err)
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+	if st := status.FromContextError(err); st.Code() != codes.Unknown {
+		return st.Err()
+	}
+	return internalError(ctx, err)
+}
+
+// internalError logs err and returns the generic Internal status sent in its place
+func internalError(ctx context.Context, err error) error {
+	if log := logger.FromContext(ctx, nil); log != nil {
+		log.Error("Unexpected error", "error", err)
+	}
+	return newStatusError(codes.Internal, usecase.NewLocalizedError(usecase.ErrInternal, "error.internal", nil).(*usecase.UseCaseError))
+}
+
+// newStatusError builds the status for a use case error. Localizable errors carry their message ID as an
+// ErrorInfo reason and their field violations as a BadRequest detail, which the gateway uses to localize them.
+func newStatusError(code codes.Code, ucErr *usecase.UseCaseError) error {
+	st := status.New(code, ucErr.Message)
+	if ucErr.MessageID == "" {
+		return st.Err()
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   ucErr.MessageID,
+		Domain:   i18n.ErrorDomain,
+		Metadata: ucErr.Params,
+	}}
+	if len(ucErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range ucErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+				Reason:      v.MessageID,
+			})
+			// Per-violation params travel in their own ErrorInfo keyed by field
+			metadata := map[string]string{i18n.FieldParam: v.Field}
+			for k, val := range v.Params {
+				metadata[k] = val
+			}
+			details = append(details, &errdetails.ErrorInfo{Reason: v.MessageID, Domain: i18n.ErrorDomain, Metadata: metadata})
+		}
+		details = append(details, badRequest)
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
 	}
-	return status.Error(http.StatusInternalServerError, fmt.Sprintf("an unexpected error occurred: %v", ucErr.Message))
+	return withDetails.Err()
 }
@@ -1,20 +1,22 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"golang-microservices-boilerplate/pkg/utils"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // DBConfig contains all the database configuration options
 type DBConfig struct {
+	Driver       string // postgres (default), sqlite or mysql; see RegisterDriver
 	URI          string
 	Host         string
 	Port         int
@@ -26,14 +28,19 @@ type DBConfig struct {
 	MaxOpenConns int
 	MaxLifetime  time.Duration
 	LogLevel     logger.LogLevel
+	// PrepareStmt caches prepared statements per connection, so repeated queries are not parsed again
+	PrepareStmt bool
+	// StatementTimeout aborts statements running longer; 0 keeps the server default
+	StatementTimeout time.Duration
+	// IdleInTransactionTimeout closes sessions idle inside a transaction for longer (PostgreSQL only);
+	// 0 keeps the server default
+	IdleInTransactionTimeout time.Duration
 }
 
 // DefaultDBConfig returns a default database configuration using environment variables
 func DefaultDBConfig() DBConfig {
-	port, _ := strconv.Atoi(utils.GetEnv("DB_PORT", "5432"))
-	maxIdleConns, _ := strconv.Atoi(utils.GetEnv("DB_MAX_IDLE_CONNS", "10"))
-	maxOpenConns, _ := strconv.Atoi(utils.GetEnv("DB_MAX_OPEN_CONNS", "100"))
-	maxLifetime, _ := strconv.Atoi(utils.GetEnv("DB_MAX_LIFETIME", "60"))
+	driver := strings.ToLower(utils.GetEnv("DB_DRIVER", DriverPostgres))
+	port, _ := strconv.Atoi(defaultPort(driver))
 
 	logLevelStr := utils.GetEnv("DB_LOG_LEVEL", "info")
 	var logLevel logger.LogLevel
@@ -49,17 +56,22 @@ func DefaultDBConfig() DBConfig {
 	}
 
 	return DBConfig{
+		Driver:       driver,
 		URI:          utils.GetEnv("DB_URI", ""),
 		Host:         utils.GetEnv("DB_HOST", "localhost"),
-		Port:         port,
+		Port:         utils.GetEnvInt("DB_PORT", port),
 		Username:     utils.GetEnv("DB_USER", "postgres"),
 		Password:     utils.GetEnv("DB_PASSWORD", "postgres"),
 		Database:     utils.GetEnv("DB_NAME", "microservices"),
 		SSLMode:      utils.GetEnv("DB_SSL_MODE", "disable"),
-		MaxIdleConns: maxIdleConns,
-		MaxOpenConns: maxOpenConns,
-		MaxLifetime:  time.Duration(maxLifetime) * time.Minute,
+		MaxIdleConns: utils.GetEnvInt("DB_MAX_IDLE_CONNS", 10),
+		MaxOpenConns: utils.GetEnvInt("DB_MAX_OPEN_CONNS", 100),
+		MaxLifetime:  time.Duration(utils.GetEnvInt("DB_MAX_LIFETIME", 60)) * time.Minute, // Minutes
 		LogLevel:     logLevel,
+
+		PrepareStmt:              utils.GetEnvBool("DB_PREPARE_STMT", true),
+		StatementTimeout:         utils.GetEnvDuration("DB_STATEMENT_TIMEOUT", 30*time.Second),
+		IdleInTransactionTimeout: utils.GetEnvDuration("DB_IDLE_IN_TRANSACTION_TIMEOUT", time.Minute),
 	}
 }
 
@@ -71,7 +83,10 @@ type DatabaseConnection struct {
 
 // NewDatabaseConnection creates a new database connection using the provided configuration
 func NewDatabaseConnection(config DBConfig) (*DatabaseConnection, error) {
-	dsn := config.URI
+	dialector, err := config.dialector()
+	if err != nil {
+		return nil, err
+	}
 
 	// Configure GORM logger
 	gormLogger := logger.New(
@@ -85,8 +100,11 @@ func NewDatabaseConnection(config DBConfig) (*DatabaseConnection, error) {
 	)
 
 	// Open connection to the database
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:      gormLogger,
+		PrepareStmt: config.PrepareStmt,
+		// Unique violations become gorm.ErrDuplicatedKey, which repositories report as ErrAlreadyExists
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -98,8 +116,12 @@ func NewDatabaseConnection(config DBConfig) (*DatabaseConnection, error) {
 		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
+	maxOpenConns := config.MaxOpenConns
+	if config.driver() == DriverSQLite && strings.Contains(config.DSN(), ":memory:") {
+		maxOpenConns = 1 // Every connection to :memory: opens a separate, empty database
+	}
 	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
-	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	sqlDB.SetMaxOpenConns(maxOpenConns)
 	sqlDB.SetConnMaxLifetime(config.MaxLifetime)
 
 	return &DatabaseConnection{
@@ -125,6 +147,9 @@ func (dc *DatabaseConnection) Close() error {
 
 // MigrateModels runs database migrations for the provided models
 func (dc *DatabaseConnection) MigrateModels(models ...interface{}) error {
+	if err := dc.adaptColumnTypes(models...); err != nil {
+		return err
+	}
 	return dc.DB.AutoMigrate(models...)
 }
 
@@ -137,6 +162,15 @@ func (dc *DatabaseConnection) Ping() error {
 	return sqlDB.Ping()
 }
 
+// PingContext checks if the database connection is still alive, honoring ctx's deadline
+func (dc *DatabaseConnection) PingContext(ctx context.Context) error {
+	sqlDB, err := dc.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	return sqlDB.PingContext(ctx)
+}
+
 // Transaction executes a function within a database transaction
 func (dc *DatabaseConnection) Transaction(fn func(tx *gorm.DB) error) error {
 	return dc.DB.Transaction(fn)
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// portableColumnTypes maps PostgreSQL-only column types used in model tags to types the other
+// drivers accept. UUIDs are stored in their 36-character text form, which uuid.UUID scans back.
+var portableColumnTypes = map[string]string{
+	"uuid":   "char(36)",
+	"citext": "varchar(255)",
+	"jsonb":  "json",
+	// types.GeoPoint is kept as EWKT text; geo filters still need PostGIS
+	"geography(point,4326)": "varchar(64)",
+}
+
+// IsPostgres reports whether db talks to PostgreSQL
+func IsPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == DriverPostgres
+}
+
+// adaptColumnTypes rewrites the PostgreSQL-only column types of models' fields for the connected
+// driver, so the same entities migrate on every driver. GORM caches parsed schemas per connection,
+// so the rewrite applies to every later migration and diff of those models.
+func (dc *DatabaseConnection) adaptColumnTypes(models ...interface{}) error {
+	if IsPostgres(dc.DB) {
+		return nil
+	}
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: dc.DB}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if portable, ok := portableColumnTypes[strings.ToLower(string(field.DataType))]; ok {
+				field.DataType = schema.DataType(portable)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Supported DB_DRIVER values. Drivers other than Postgres are compiled in with the build tag of
+// the same name (go build -tags sqlite), so services that do not use them carry no extra dependency.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMySQL    = "mysql"
+)
+
+// DialectorFactory opens a GORM dialector for a DSN
+type DialectorFactory func(dsn string) gorm.Dialector
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DialectorFactory{DriverPostgres: postgres.Open}
+)
+
+// RegisterDriver makes a driver available as a DB_DRIVER value; registering a name again replaces it
+func RegisterDriver(name string, open DialectorFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[strings.ToLower(name)] = open
+}
+
+// registeredDrivers lists the available drivers, sorted
+func registeredDrivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dialector returns the dialector of the configured driver
+func (c DBConfig) dialector() (gorm.Dialector, error) {
+	driversMu.RLock()
+	open, ok := drivers[c.driver()]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q (available: %s; sqlite and mysql need the build tag of the same name)",
+			c.Driver, strings.Join(registeredDrivers(), ", "))
+	}
+	return open(c.withSessionParams(c.DSN())), nil
+}
+
+// driver returns the normalized driver name; empty means Postgres
+func (c DBConfig) driver() string {
+	if c.Driver == "" {
+		return DriverPostgres
+	}
+	return strings.ToLower(c.Driver)
+}
+
+// DSN returns URI when set, otherwise a connection string for the driver built from the other fields.
+// For SQLite, Database is the file name (":memory:" for an in-memory database).
+func (c DBConfig) DSN() string {
+	if c.URI != "" {
+		return c.URI
+	}
+	switch c.driver() {
+	case DriverSQLite:
+		if c.Database == ":memory:" || strings.Contains(c.Database, ".") {
+			return c.Database
+		}
+		return c.Database + ".db"
+	case DriverMySQL:
+		return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+			c.Username, c.Password, c.Host, c.Port, c.Database)
+	default:
+		return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			c.Host, c.Port, c.Username, c.Password, c.Database, c.SSLMode)
+	}
+}
+
+// defaultPort returns the standard port of a driver
+func defaultPort(driver string) string {
+	if strings.ToLower(driver) == DriverMySQL {
+		return "3306"
+	}
+	return "5432"
+}
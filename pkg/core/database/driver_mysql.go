@@ -0,0 +1,15 @@
+//go:build mysql
+
+package database
+
+import (
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// Strings without a size become VARCHAR(255) rather than LONGTEXT, which MySQL cannot index
+func init() {
+	RegisterDriver(DriverMySQL, func(dsn string) gorm.Dialector {
+		return mysql.New(mysql.Config{DSN: dsn, DefaultStringSize: 255})
+	})
+}
@@ -0,0 +1,10 @@
+//go:build sqlite
+
+package database
+
+import "github.com/glebarez/sqlite"
+
+// The pure-Go SQLite driver needs no cgo, so tests and local runs work without a C toolchain
+func init() {
+	RegisterDriver(DriverSQLite, sqlite.Open)
+}
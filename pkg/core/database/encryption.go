@@ -0,0 +1,388 @@
+package database
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Serializer names for encrypted string columns, e.g. `gorm:"type:text;serializer:encrypted"`.
+// Searchable columns encrypt equal values to equal ciphertexts, so they can be matched exactly
+// (see SearchableValues) at the cost of revealing which rows share a value.
+const (
+	EncryptedSerializer           = "encrypted"
+	SearchableEncryptedSerializer = "encrypted_searchable"
+)
+
+// Prefixes of stored ciphertexts: "<mode>:v<key version>:<base64 nonce+ciphertext>"
+const (
+	randomizedPrefix    = "enc"
+	deterministicPrefix = "det"
+)
+
+var (
+	// ErrFieldCipherNotConfigured is returned when an encrypted column is used before SetFieldCipher
+	ErrFieldCipherNotConfigured = errors.New("field encryption is not configured")
+	// ErrFieldEncryptionKeysNotSet is returned by LoadFieldCipherFromEnv without FIELD_ENCRYPTION_KEYS
+	ErrFieldEncryptionKeysNotSet = errors.New("FIELD_ENCRYPTION_KEYS is not set")
+)
+
+// DevelopmentFieldKey is a well-known key for local development only; never use it where data matters
+var DevelopmentFieldKey = []byte("insecure-development-field-key!!")
+
+func init() {
+	schema.RegisterSerializer(EncryptedSerializer, encryptedSerializer{})
+	schema.RegisterSerializer(SearchableEncryptedSerializer, encryptedSerializer{deterministic: true})
+}
+
+// fieldKey is one key version, split into an AES-256-GCM key and the HMAC key deriving deterministic nonces
+type fieldKey struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// FieldCipher encrypts column values with AES-256-GCM under versioned keys. Values are written with
+// the active key; values written with older keys stay readable until ReencryptColumns rewrites them.
+type FieldCipher struct {
+	keys   map[uint32]fieldKey
+	active uint32
+}
+
+// NewFieldCipher creates a cipher from 32-byte master keys by version, writing with the active version
+func NewFieldCipher(keys map[uint32][]byte, active uint32) (*FieldCipher, error) {
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("active field encryption key v%d is not configured", active)
+	}
+	c := &FieldCipher{keys: make(map[uint32]fieldKey, len(keys)), active: active}
+	for version, master := range keys {
+		if len(master) != 32 {
+			return nil, fmt.Errorf("field encryption key v%d must be 32 bytes, got %d", version, len(master))
+		}
+		encKey, err := hkdf.Key(sha256.New, master, nil, "field-encryption aes-256-gcm", 32)
+		if err != nil {
+			return nil, err
+		}
+		nonceKey, err := hkdf.Key(sha256.New, master, nil, "field-encryption deterministic nonce", 32)
+		if err != nil {
+			return nil, err
+		}
+		block, err := aes.NewCipher(encKey)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		c.keys[version] = fieldKey{aead: aead, nonceKey: nonceKey}
+	}
+	return c, nil
+}
+
+// LoadFieldCipherFromEnv reads the keys.
+//
+//	FIELD_ENCRYPTION_KEYS=1=<base64 32 bytes>,2=<base64 32 bytes>   keys by version (generate with `openssl rand -base64 32`)
+//	FIELD_ENCRYPTION_KEY_VERSION=2                                  version new values are written with (default: the highest)
+func LoadFieldCipherFromEnv() (*FieldCipher, error) {
+	keys := make(map[uint32][]byte)
+	var highest uint32
+	for _, pair := range strings.Split(utils.GetEnv("FIELD_ENCRYPTION_KEYS", ""), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		versionStr, encoded, ok := strings.Cut(pair, "=")
+		version, err := strconv.ParseUint(strings.TrimPrefix(versionStr, "v"), 10, 32)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid FIELD_ENCRYPTION_KEYS entry %q (expected <version>=<base64 key>)", versionStr)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("field encryption key v%d is not valid base64: %w", version, err)
+		}
+		keys[uint32(version)] = key
+		highest = max(highest, uint32(version))
+	}
+	if len(keys) == 0 {
+		return nil, ErrFieldEncryptionKeysNotSet
+	}
+
+	active := highest
+	if v := utils.GetEnv("FIELD_ENCRYPTION_KEY_VERSION", ""); v != "" {
+		version, err := strconv.ParseUint(strings.TrimPrefix(v, "v"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FIELD_ENCRYPTION_KEY_VERSION %q", v)
+		}
+		active = uint32(version)
+	}
+	return NewFieldCipher(keys, active)
+}
+
+// Encrypt encrypts a value with the active key. Deterministic encryption derives the nonce from the
+// value, so equal values under the same key give equal ciphertexts.
+func (c *FieldCipher) Encrypt(plaintext string, deterministic bool) (string, error) {
+	return c.encryptWith(c.active, plaintext, deterministic)
+}
+
+func (c *FieldCipher) encryptWith(version uint32, plaintext string, deterministic bool) (string, error) {
+	key := c.keys[version]
+	nonce := make([]byte, key.aead.NonceSize())
+	prefix := randomizedPrefix
+	if deterministic {
+		mac := hmac.New(sha256.New, key.nonceKey)
+		mac.Write([]byte(plaintext))
+		copy(nonce, mac.Sum(nil))
+		prefix = deterministicPrefix
+	} else if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := key.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return prefix + ":v" + strconv.FormatUint(uint64(version), 10) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// parseCiphertext splits a stored value; ok is false for values that were not written encrypted
+func parseCiphertext(value string) (mode string, version uint32, payload string, ok bool) {
+	mode, rest, found := strings.Cut(value, ":")
+	if !found || (mode != randomizedPrefix && mode != deterministicPrefix) {
+		return "", 0, "", false
+	}
+	versionStr, payload, found := strings.Cut(rest, ":")
+	v, err := strconv.ParseUint(strings.TrimPrefix(versionStr, "v"), 10, 32)
+	if !found || !strings.HasPrefix(versionStr, "v") || err != nil {
+		return "", 0, "", false
+	}
+	return mode, uint32(v), payload, true
+}
+
+// Decrypt returns the plaintext of a stored value. Values that were not written encrypted (rows
+// from before a column was encrypted) are returned unchanged.
+func (c *FieldCipher) Decrypt(value string) (string, error) {
+	_, version, payload, ok := parseCiphertext(value)
+	if !ok {
+		return value, nil
+	}
+	key, ok := c.keys[version]
+	if !ok {
+		return "", fmt.Errorf("field encryption key v%d is not configured", version)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < key.aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	plaintext, err := key.aead.Open(nil, sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key v%d: %w", version, err)
+	}
+	return string(plaintext), nil
+}
+
+// LookupValues returns the deterministic ciphertexts of a value under every configured key, to match
+// a searchable column written before and after a key rotation: WHERE phone IN (values). Rows still
+// holding plaintext only match once ReencryptColumns has run.
+func (c *FieldCipher) LookupValues(plaintext string) ([]string, error) {
+	versions := make([]uint32, 0, len(c.keys))
+	for version := range c.keys {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	values := make([]string, 0, len(versions)+1)
+	for _, version := range versions {
+		value, err := c.encryptWith(version, plaintext, true)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+var activeFieldCipher atomic.Pointer[FieldCipher]
+
+// SetFieldCipher installs the cipher used by the encrypted serializers
+func SetFieldCipher(c *FieldCipher) {
+	activeFieldCipher.Store(c)
+}
+
+// UseFieldCipherFromEnv loads the keys (see LoadFieldCipherFromEnv) and installs the cipher. Outside
+// production a missing FIELD_ENCRYPTION_KEYS falls back to DevelopmentFieldKey with a warning.
+func UseFieldCipherFromEnv(log logger.Logger) error {
+	c, err := LoadFieldCipherFromEnv()
+	if errors.Is(err, ErrFieldEncryptionKeysNotSet) && !strings.EqualFold(utils.GetEnv("APP_ENV", "development"), "production") {
+		log.Warn("FIELD_ENCRYPTION_KEYS is not set, encrypting with the insecure development key")
+		c, err = NewFieldCipher(map[uint32][]byte{0: DevelopmentFieldKey}, 0)
+	}
+	if err != nil {
+		return err
+	}
+	SetFieldCipher(c)
+	return nil
+}
+
+// SearchableValues returns the values matching plaintext in a column using the
+// SearchableEncryptedSerializer: db.Where("phone IN ?", values)
+func SearchableValues(plaintext string) ([]string, error) {
+	c := activeFieldCipher.Load()
+	if c == nil {
+		return nil, ErrFieldCipherNotConfigured
+	}
+	return c.LookupValues(plaintext)
+}
+
+// encryptedSerializer encrypts string fields on write and decrypts them on read. Empty strings are
+// stored as-is.
+type encryptedSerializer struct {
+	deterministic bool
+}
+
+// Scan decrypts the column value into the field
+func (s encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType).Elem()
+	var stored string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("encrypted column %s: unsupported value type %T", field.DBName, dbValue)
+	}
+	if stored != "" {
+		plaintext := stored
+		if _, _, _, ok := parseCiphertext(stored); ok {
+			c := activeFieldCipher.Load()
+			if c == nil {
+				return ErrFieldCipherNotConfigured
+			}
+			var err error
+			if plaintext, err = c.Decrypt(stored); err != nil {
+				return fmt.Errorf("encrypted column %s: %w", field.DBName, err)
+			}
+		}
+		if fieldValue.Kind() == reflect.Pointer {
+			fieldValue.Set(reflect.New(field.FieldType.Elem()))
+			fieldValue.Elem().SetString(plaintext)
+		} else {
+			fieldValue.SetString(plaintext)
+		}
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value encrypts the field for the database
+func (s encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(fieldValue)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.String {
+		return nil, fmt.Errorf("encrypted column %s: only string fields can be encrypted", field.DBName)
+	}
+	if rv.String() == "" {
+		return "", nil
+	}
+	c := activeFieldCipher.Load()
+	if c == nil {
+		return nil, ErrFieldCipherNotConfigured
+	}
+	return c.Encrypt(rv.String(), s.deterministic)
+}
+
+// ReencryptColumns rewrites the encrypted columns of a model whose values are not encrypted with
+// the active key: plaintext left from before a column was encrypted, and values written with older
+// keys after a rotation. Rows are updated in batches, each only if it still holds the value read,
+// so concurrent writes are never overwritten. It returns the number of values rewritten.
+func ReencryptColumns(ctx context.Context, db *gorm.DB, model interface{}, batchSize int) (int64, error) {
+	c := activeFieldCipher.Load()
+	if c == nil {
+		return 0, ErrFieldCipherNotConfigured
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return 0, err
+	}
+	pk := stmt.Schema.PrioritizedPrimaryField
+	if pk == nil {
+		return 0, fmt.Errorf("%s has no primary key", stmt.Schema.Name)
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	var rewritten int64
+	for _, field := range stmt.Schema.Fields {
+		serializer := field.TagSettings["SERIALIZER"]
+		if serializer != EncryptedSerializer && serializer != SearchableEncryptedSerializer {
+			continue
+		}
+		deterministic := serializer == SearchableEncryptedSerializer
+		prefix := randomizedPrefix
+		if deterministic {
+			prefix = deterministicPrefix
+		}
+		column := clause.Column{Name: field.DBName}
+		current := fmt.Sprintf("%s:v%d:%%", prefix, c.active)
+
+		for {
+			var rows []map[string]interface{}
+			err := db.WithContext(ctx).Table(stmt.Schema.Table).
+				Select([]string{pk.DBName, field.DBName}).
+				Where(clause.Neq{Column: column, Value: ""}).
+				Where(clause.Not(clause.Like{Column: column, Value: current})).
+				Limit(batchSize).Find(&rows).Error
+			if err != nil {
+				return rewritten, err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			for _, row := range rows {
+				stored, _ := row[field.DBName].(string)
+				plaintext, err := c.Decrypt(stored)
+				if err != nil {
+					return rewritten, fmt.Errorf("%s.%s of %v: %w", stmt.Schema.Table, field.DBName, row[pk.DBName], err)
+				}
+				value, err := c.Encrypt(plaintext, deterministic)
+				if err != nil {
+					return rewritten, err
+				}
+				result := db.WithContext(ctx).Table(stmt.Schema.Table).
+					Where(clause.Eq{Column: clause.Column{Name: pk.DBName}, Value: row[pk.DBName]}).
+					Where(clause.Eq{Column: column, Value: stored}).
+					UpdateColumn(field.DBName, value)
+				if result.Error != nil {
+					return rewritten, result.Error
+				}
+				rewritten += result.RowsAffected
+			}
+			if len(rows) < batchSize {
+				break
+			}
+		}
+	}
+	return rewritten, nil
+}
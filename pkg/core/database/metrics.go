@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+type callerContextKey struct{}
+
+// WithCaller names the use case issuing queries on ctx, e.g. "UserUseCase.Login".
+// Slow-query logs fall back to the gRPC method when no caller is set.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller set by WithCaller, or the gRPC method handling the request
+func CallerFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if caller, ok := ctx.Value(callerContextKey{}).(string); ok {
+		return caller
+	}
+	if method, ok := grpc.Method(ctx); ok {
+		return method
+	}
+	return ""
+}
+
+// QueryMetricsConfig configures the query metrics plugin
+type QueryMetricsConfig struct {
+	SlowThreshold time.Duration // Queries at or above this duration are logged; 0 disables the log
+}
+
+// LoadQueryMetricsConfigFromEnv reads DB_SLOW_QUERY_THRESHOLD (default 200ms)
+func LoadQueryMetricsConfigFromEnv() QueryMetricsConfig {
+	return QueryMetricsConfig{
+		SlowThreshold: utils.GetEnvDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+	}
+}
+
+// queryDurationBuckets are the histogram upper bounds in seconds
+var queryDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// queryKey identifies one series: the GORM operation and the table it touched
+type queryKey struct {
+	operation string
+	table     string
+}
+
+// querySeries holds the measurements of one series
+type querySeries struct {
+	buckets      []uint64 // Cumulative counts per queryDurationBuckets entry
+	count        uint64
+	sum          float64
+	rowsAffected uint64
+	errors       uint64
+	slow         uint64
+}
+
+// QueryMetrics aggregates per-query duration histograms, rows affected, errors and slow queries.
+// It implements http.Handler, serving the Prometheus text exposition format.
+type QueryMetrics struct {
+	mu     sync.Mutex
+	series map[queryKey]*querySeries
+}
+
+// NewQueryMetrics creates an empty metrics collector
+func NewQueryMetrics() *QueryMetrics {
+	return &QueryMetrics{series: make(map[queryKey]*querySeries)}
+}
+
+// observe records one executed statement
+func (m *QueryMetrics) observe(operation, table string, duration time.Duration, rows int64, failed, slow bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := queryKey{operation: operation, table: table}
+	s, ok := m.series[key]
+	if !ok {
+		s = &querySeries{buckets: make([]uint64, len(queryDurationBuckets))}
+		m.series[key] = s
+	}
+	seconds := duration.Seconds()
+	for i, upper := range queryDurationBuckets {
+		if seconds <= upper {
+			s.buckets[i]++
+		}
+	}
+	s.count++
+	s.sum += seconds
+	if rows > 0 {
+		s.rowsAffected += uint64(rows)
+	}
+	if failed {
+		s.errors++
+	}
+	if slow {
+		s.slow++
+	}
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *QueryMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	keys := make([]queryKey, 0, len(m.series))
+	snapshot := make(map[queryKey]querySeries, len(m.series))
+	for k, s := range m.series {
+		keys = append(keys, k)
+		copied := *s
+		copied.buckets = append([]uint64(nil), s.buckets...)
+		snapshot[k] = copied
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].table != keys[j].table {
+			return keys[i].table < keys[j].table
+		}
+		return keys[i].operation < keys[j].operation
+	})
+	labels := func(k queryKey) string {
+		return fmt.Sprintf(`operation=%q,table=%q`, k.operation, k.table)
+	}
+
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("# HELP db_query_duration_seconds Duration of database statements.\n# TYPE db_query_duration_seconds histogram\n")
+	for _, k := range keys {
+		s := snapshot[k]
+		for i, upper := range queryDurationBuckets {
+			printf("db_query_duration_seconds_bucket{%s,le=%q} %d\n", labels(k), strconv.FormatFloat(upper, 'g', -1, 64), s.buckets[i])
+		}
+		printf("db_query_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels(k), s.count)
+		printf("db_query_duration_seconds_sum{%s} %g\n", labels(k), s.sum)
+		printf("db_query_duration_seconds_count{%s} %d\n", labels(k), s.count)
+	}
+
+	counters := []struct {
+		name, help string
+		value      func(querySeries) uint64
+	}{
+		{"db_query_rows_affected_total", "Rows affected or returned by database statements.", func(s querySeries) uint64 { return s.rowsAffected }},
+		{"db_query_errors_total", "Database statements that returned an error.", func(s querySeries) uint64 { return s.errors }},
+		{"db_slow_queries_total", "Database statements slower than DB_SLOW_QUERY_THRESHOLD.", func(s querySeries) uint64 { return s.slow }},
+	}
+	for _, c := range counters {
+		printf("# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, k := range keys {
+			printf("%s{%s} %d\n", c.name, labels(k), c.value(snapshot[k]))
+		}
+	}
+	return err
+}
+
+// ServeHTTP serves the metrics for scraping
+func (m *QueryMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// queryStartKey is the statement setting holding the start time of a statement
+const queryStartKey = "core:query_metrics:start"
+
+// QueryMetricsPlugin is a GORM plugin feeding QueryMetrics and logging slow queries
+type QueryMetricsPlugin struct {
+	metrics *QueryMetrics
+	config  QueryMetricsConfig
+	logger  logger.Logger
+}
+
+// NewQueryMetricsPlugin creates the plugin; register it with db.Use
+func NewQueryMetricsPlugin(metrics *QueryMetrics, config QueryMetricsConfig, log logger.Logger) *QueryMetricsPlugin {
+	return &QueryMetricsPlugin{metrics: metrics, config: config, logger: log}
+}
+
+// Name implements gorm.Plugin
+func (p *QueryMetricsPlugin) Name() string {
+	return "core:query_metrics"
+}
+
+// Initialize implements gorm.Plugin by timing every callback chain
+func (p *QueryMetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("core:query_metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("core:query_metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("core:query_metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("core:query_metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("core:query_metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("core:query_metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("core:query_metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("core:query_metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("core:query_metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("core:query_metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("core:query_metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("core:query_metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (p *QueryMetricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (p *QueryMetricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		duration := time.Since(start)
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		failed := db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound)
+		slow := p.config.SlowThreshold > 0 && duration >= p.config.SlowThreshold
+		p.metrics.observe(operation, table, duration, db.RowsAffected, failed, slow)
+
+		if slow {
+			// Bind variables are left out: they may hold credentials or personal data
+			p.logger.Warn("Slow query",
+				"operation", operation,
+				"table", table,
+				"duration_ms", duration.Milliseconds(),
+				"rows_affected", db.RowsAffected,
+				"caller", CallerFromContext(db.Statement.Context),
+				"sql", db.Statement.SQL.String(),
+			)
+		}
+	}
+}
+
+// UseQueryMetrics installs the query metrics plugin on the connection and returns its collector
+func (dc *DatabaseConnection) UseQueryMetrics(config QueryMetricsConfig, log logger.Logger) (*QueryMetrics, error) {
+	metrics := NewQueryMetrics()
+	if err := dc.DB.Use(NewQueryMetricsPlugin(metrics, config, log)); err != nil {
+		return nil, fmt.Errorf("failed to install query metrics: %w", err)
+	}
+	return metrics, nil
+}
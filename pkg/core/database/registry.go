@@ -0,0 +1,227 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// MigrationMode controls what SyncRegisteredModels does at startup
+type MigrationMode string
+
+const (
+	// MigrationAuto applies GORM AutoMigrate to every registered model (development)
+	MigrationAuto MigrationMode = "auto"
+	// MigrationDiff only compares the registered models with the live schema and reports drift (production)
+	MigrationDiff MigrationMode = "diff"
+	// MigrationOff skips schema handling entirely
+	MigrationOff MigrationMode = "off"
+)
+
+// MigrationModeFromEnv reads DB_MIGRATION_MODE (auto, diff or off; default auto)
+func MigrationModeFromEnv() (MigrationMode, error) {
+	mode := MigrationMode(strings.ToLower(utils.GetEnv("DB_MIGRATION_MODE", string(MigrationAuto))))
+	switch mode {
+	case MigrationAuto, MigrationDiff, MigrationOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid DB_MIGRATION_MODE %q (expected auto, diff or off)", mode)
+	}
+}
+
+// modelRegistry holds the models registered by the service, in registration order
+type modelRegistry struct {
+	mu     sync.Mutex
+	models []interface{}
+	seen   map[reflect.Type]bool
+}
+
+var registry = &modelRegistry{seen: make(map[reflect.Type]bool)}
+
+// RegisterModels adds models (pointers to entity structs) to the service's schema.
+// Registering the same type twice has no effect.
+func RegisterModels(models ...interface{}) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, m := range models {
+		t := reflect.TypeOf(m)
+		if registry.seen[t] {
+			continue
+		}
+		registry.seen[t] = true
+		registry.models = append(registry.models, m)
+	}
+}
+
+// RegisteredModels returns the registered models in registration order
+func RegisteredModels() []interface{} {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return append([]interface{}(nil), registry.models...)
+}
+
+// SyncRegisteredModels migrates or diffs the registered models according to mode.
+// In diff mode the returned SchemaDiff lists the drift; nothing is changed in the database.
+func (dc *DatabaseConnection) SyncRegisteredModels(mode MigrationMode) (*SchemaDiff, error) {
+	models := RegisteredModels()
+	switch mode {
+	case MigrationAuto:
+		return nil, dc.MigrateModels(models...)
+	case MigrationDiff:
+		return dc.DiffModels(models...)
+	case MigrationOff:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown migration mode %q", mode)
+	}
+}
+
+// SchemaChangeKind classifies a difference between a model and the live schema
+type SchemaChangeKind string
+
+const (
+	MissingTable  SchemaChangeKind = "missing_table"
+	MissingColumn SchemaChangeKind = "missing_column"
+	ExtraColumn   SchemaChangeKind = "extra_column" // In the database but not in the model
+	TypeMismatch  SchemaChangeKind = "type_mismatch"
+	MissingIndex  SchemaChangeKind = "missing_index"
+)
+
+// SchemaChange is one difference between a model and the live schema
+type SchemaChange struct {
+	Kind   SchemaChangeKind
+	Table  string
+	Column string // Column or index name, when applicable
+	Detail string
+}
+
+// String renders the change for logs
+func (c SchemaChange) String() string {
+	s := fmt.Sprintf("%s %s", c.Kind, c.Table)
+	if c.Column != "" {
+		s += "." + c.Column
+	}
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+	return s
+}
+
+// SchemaDiff is the drift between the registered models and the live schema
+type SchemaDiff struct {
+	Changes []SchemaChange
+}
+
+// Empty reports whether the schema matches the models
+func (d *SchemaDiff) Empty() bool {
+	return d == nil || len(d.Changes) == 0
+}
+
+// DiffModels compares models with the live schema without modifying it
+func (dc *DatabaseConnection) DiffModels(models ...interface{}) (*SchemaDiff, error) {
+	if err := dc.adaptColumnTypes(models...); err != nil {
+		return nil, err
+	}
+	diff := &SchemaDiff{}
+	migrator := dc.DB.Migrator()
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: dc.DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			diff.Changes = append(diff.Changes, SchemaChange{Kind: MissingTable, Table: table})
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		live := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, ct := range columnTypes {
+			live[ct.Name()] = ct
+		}
+
+		expected := make(map[string]bool)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			expected[field.DBName] = true
+
+			ct, ok := live[field.DBName]
+			if !ok {
+				diff.Changes = append(diff.Changes, SchemaChange{Kind: MissingColumn, Table: table, Column: field.DBName})
+				continue
+			}
+			want := dc.DB.Dialector.DataTypeOf(field)
+			if !sameColumnType(want, ct.DatabaseTypeName()) {
+				diff.Changes = append(diff.Changes, SchemaChange{
+					Kind:   TypeMismatch,
+					Table:  table,
+					Column: field.DBName,
+					Detail: fmt.Sprintf("model %s, database %s", want, strings.ToLower(ct.DatabaseTypeName())),
+				})
+			}
+		}
+		for _, ct := range columnTypes {
+			if !expected[ct.Name()] {
+				diff.Changes = append(diff.Changes, SchemaChange{Kind: ExtraColumn, Table: table, Column: ct.Name()})
+			}
+		}
+
+		// ParseIndexes returns a map before GORM 1.30 and a slice since; both range to an index
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if !migrator.HasIndex(model, index.Name) {
+				diff.Changes = append(diff.Changes, SchemaChange{Kind: MissingIndex, Table: table, Column: index.Name})
+			}
+		}
+	}
+	return diff, nil
+}
+
+// columnTypeAliases maps SQL type spellings to the names PostgreSQL reports
+var columnTypeAliases = map[string]string{
+	"bigint":                      "int8",
+	"bigserial":                   "int8",
+	"integer":                     "int4",
+	"int":                         "int4",
+	"serial":                      "int4",
+	"smallint":                    "int2",
+	"smallserial":                 "int2",
+	"boolean":                     "bool",
+	"character varying":           "varchar",
+	"character":                   "bpchar",
+	"char":                        "bpchar",
+	"decimal":                     "numeric",
+	"double precision":            "float8",
+	"real":                        "float4",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+}
+
+// sameColumnType compares a model's SQL type with the type reported by the database,
+// ignoring length/precision and spelling differences
+func sameColumnType(model, database string) bool {
+	return normalizeColumnType(model) == normalizeColumnType(database)
+}
+
+func normalizeColumnType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	if i := strings.Index(t, "("); i >= 0 {
+		t = strings.TrimSpace(t[:i])
+	}
+	if alias, ok := columnTypeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
@@ -0,0 +1,337 @@
+// Package seed loads YAML/JSON fixtures into GORM entities.
+//
+// A fixture file seeds one registered entity:
+//
+//	entity: users            # name passed to Seeder.Register
+//	depends_on: [roles]      # fixtures (by entity name) that must be seeded first
+//	key: [email]             # natural key used to detect existing rows
+//	on_conflict: skip        # skip (default) or update existing rows
+//	records:
+//	  - email: admin@example.com
+//	    password: ${SEED_ADMIN_PASSWORD:-admin123}
+//
+// Values of the form ${VAR} or ${VAR:-default} in record fields are expanded from the environment
+// after the file is parsed, so an expanded value is always a string and cannot change the structure
+// of the file. Fields a record sets explicitly are written even when they are zero, e.g.
+// is_active: false on a column with a database default.
+// Seeding is idempotent: rows whose key already exists are skipped or updated, never duplicated.
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+
+	"gorm.io/gorm"
+	"sigs.k8s.io/yaml"
+)
+
+// Conflict strategies for rows that already exist
+const (
+	OnConflictSkip   = "skip"
+	OnConflictUpdate = "update"
+)
+
+// Fixture is a set of records for a single entity
+type Fixture struct {
+	Entity     string                   `json:"entity"`
+	DependsOn  []string                 `json:"depends_on,omitempty"`
+	Key        []string                 `json:"key"`
+	OnConflict string                   `json:"on_conflict,omitempty"`
+	Records    []map[string]interface{} `json:"records"`
+
+	source string // File the fixture was loaded from, for error messages
+}
+
+// Seeder applies fixtures to a database
+type Seeder struct {
+	db       *gorm.DB
+	logger   logger.Logger
+	entities map[string]func() interface{}
+}
+
+// NewSeeder creates a new Seeder
+func NewSeeder(db *gorm.DB, logger logger.Logger) *Seeder {
+	return &Seeder{
+		db:       db,
+		logger:   logger,
+		entities: make(map[string]func() interface{}),
+	}
+}
+
+// Register makes an entity seedable under name; newEntity must return a pointer to a new zero value
+func (s *Seeder) Register(name string, newEntity func() interface{}) {
+	s.entities[name] = newEntity
+}
+
+// LoadDirs reads all *.yaml, *.yml and *.json fixtures from the given directories in order.
+// Missing directories are ignored so environments can omit optional seed sets.
+func LoadDirs(dirs ...string) ([]Fixture, error) {
+	var fixtures []Fixture
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed directory %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			ext := strings.ToLower(filepath.Ext(e.Name()))
+			if e.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+				continue
+			}
+			f, err := LoadFile(filepath.Join(dir, e.Name()))
+			if err != nil {
+				return nil, err
+			}
+			fixtures = append(fixtures, f)
+		}
+	}
+	return fixtures, nil
+}
+
+// LoadFile reads a single fixture file
+func LoadFile(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("failed to read seed file %s: %w", path, err)
+	}
+
+	var f Fixture
+	// YAML is a superset of JSON, so both formats go through the same decoder
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return Fixture{}, fmt.Errorf("failed to parse seed file %s: %w", path, err)
+	}
+	for _, record := range f.Records {
+		for k, v := range record {
+			record[k] = expandValue(v)
+		}
+	}
+	if f.Entity == "" {
+		return Fixture{}, fmt.Errorf("seed file %s: entity is required", path)
+	}
+	if len(f.Key) == 0 {
+		return Fixture{}, fmt.Errorf("seed file %s: key is required for idempotent seeding", path)
+	}
+	if f.OnConflict == "" {
+		f.OnConflict = OnConflictSkip
+	}
+	if f.OnConflict != OnConflictSkip && f.OnConflict != OnConflictUpdate {
+		return Fixture{}, fmt.Errorf("seed file %s: unknown on_conflict %q", path, f.OnConflict)
+	}
+	f.source = path
+	return f, nil
+}
+
+// Run seeds all fixtures in dependency order inside a single transaction
+func (s *Seeder) Run(ctx context.Context, fixtures []Fixture) error {
+	ordered, err := orderFixtures(fixtures)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, f := range ordered {
+			if err := s.apply(tx, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// apply seeds the records of one fixture
+func (s *Seeder) apply(tx *gorm.DB, f Fixture) error {
+	newEntity, ok := s.entities[f.Entity]
+	if !ok {
+		return fmt.Errorf("seed file %s: entity %q is not registered", f.source, f.Entity)
+	}
+
+	created, updated, skipped := 0, 0, 0
+	for i, record := range f.Records {
+		where := make(map[string]interface{}, len(f.Key))
+		for _, k := range f.Key {
+			v, ok := record[k]
+			if !ok {
+				return fmt.Errorf("seed file %s: record %d is missing key field %q", f.source, i, k)
+			}
+			where[k] = v
+		}
+
+		obj, err := decodeRecord(record, newEntity)
+		if err != nil {
+			return fmt.Errorf("seed file %s: record %d: %w", f.source, i, err)
+		}
+		columns, zeroValues, err := recordColumns(tx, obj, record)
+		if err != nil {
+			return fmt.Errorf("seed file %s: record %d: %w", f.source, i, err)
+		}
+
+		existing := newEntity()
+		result := tx.Where(where).Limit(1).Find(existing)
+		if result.Error != nil {
+			return fmt.Errorf("seed file %s: record %d: %w", f.source, i, result.Error)
+		}
+
+		switch {
+		case result.RowsAffected == 0:
+			if err := tx.Create(obj).Error; err != nil {
+				return fmt.Errorf("seed file %s: failed to create record %d: %w", f.source, i, err)
+			}
+			// Create replaces zero values by the column defaults, so they are written afterwards
+			if len(zeroValues) > 0 {
+				if err := tx.Model(obj).UpdateColumns(zeroValues).Error; err != nil {
+					return fmt.Errorf("seed file %s: failed to create record %d: %w", f.source, i, err)
+				}
+			}
+			created++
+		case f.OnConflict == OnConflictUpdate:
+			// Only the fields the record sets are written, zero or not; the entity's update hooks run
+			if err := tx.Model(existing).Select(columns).Updates(obj).Error; err != nil {
+				return fmt.Errorf("seed file %s: failed to update record %d: %w", f.source, i, err)
+			}
+			updated++
+		default:
+			skipped++
+		}
+	}
+
+	s.logger.Info("Seeded fixture", "entity", f.Entity, "file", f.source, "created", created, "updated", updated, "skipped", skipped)
+	return nil
+}
+
+// decodeRecord converts a generic record into a new entity using its JSON tags
+func decodeRecord(record map[string]interface{}, newEntity func() interface{}) (interface{}, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	obj := newEntity()
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// recordColumns returns the columns of the fields a record sets plus the auto-update timestamps of
+// the entity, and the values of those fields that are zero in obj by column
+func recordColumns(tx *gorm.DB, obj interface{}, record map[string]interface{}) ([]string, map[string]interface{}, error) {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(obj); err != nil {
+		return nil, nil, err
+	}
+	value := reflect.ValueOf(obj)
+	var columns []string
+	zeroValues := make(map[string]interface{})
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if field.AutoUpdateTime > 0 {
+			columns = append(columns, field.DBName)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := record[name]; !ok || field.PrimaryKey {
+			continue
+		}
+		columns = append(columns, field.DBName)
+		if v, zero := field.ValueOf(context.Background(), value); zero {
+			zeroValues[field.DBName] = v
+		}
+	}
+	return columns, zeroValues, nil
+}
+
+// orderFixtures sorts fixtures so that every fixture comes after the entities it depends on
+func orderFixtures(fixtures []Fixture) ([]Fixture, error) {
+	byEntity := make(map[string][]Fixture)
+	var names []string
+	for _, f := range fixtures {
+		if _, seen := byEntity[f.Entity]; !seen {
+			names = append(names, f.Entity)
+		}
+		byEntity[f.Entity] = append(byEntity[f.Entity], f)
+	}
+	sort.Strings(names) // Deterministic order among independent entities
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var ordered []Fixture
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("seed dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+		group, ok := byEntity[name]
+		if !ok {
+			return fmt.Errorf("seed dependency %q has no fixture (required by %s)", name, path[len(path)-1])
+		}
+
+		state[name] = visiting
+		for _, f := range group {
+			for _, dep := range f.DependsOn {
+				if err := visit(dep, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, group...)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// expandValue expands environment references in the strings of a parsed record value
+func expandValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return os.Expand(v, expandEnv)
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = expandValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = expandValue(item)
+		}
+	}
+	return v
+}
+
+// expandEnv resolves ${VAR} and ${VAR:-default}
+func expandEnv(key string) string {
+	name, def, hasDefault := strings.Cut(key, ":-")
+	if v, ok := os.LookupEnv(name); ok && v != "" {
+		return v
+	}
+	if hasDefault {
+		return def
+	}
+	return ""
+}
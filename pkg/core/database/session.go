@@ -0,0 +1,67 @@
+package database
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sessionParams returns the server settings every connection of the driver starts with, keyed by
+// connection string parameter. Zero timeouts leave the server defaults.
+func (c DBConfig) sessionParams() map[string]string {
+	params := map[string]string{}
+	switch c.driver() {
+	case DriverPostgres:
+		// pgx sends parameters it does not know itself to the server as run-time settings
+		if c.StatementTimeout > 0 {
+			params["statement_timeout"] = strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)
+		}
+		if c.IdleInTransactionTimeout > 0 {
+			params["idle_in_transaction_session_timeout"] = strconv.FormatInt(c.IdleInTransactionTimeout.Milliseconds(), 10)
+		}
+	case DriverMySQL:
+		// MySQL only bounds SELECT statements and has no idle transaction timeout
+		if c.StatementTimeout > 0 {
+			params["max_execution_time"] = strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)
+		}
+	}
+	return params
+}
+
+// withSessionParams adds the session parameters to a connection string. Parameters the string
+// already sets, e.g. in DB_URI, are kept.
+func (c DBConfig) withSessionParams(dsn string) string {
+	params := c.sessionParams()
+	if len(params) == 0 {
+		return dsn
+	}
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// URL forms: postgres://... and MySQL's user:pass@tcp(host)/db?...
+	if strings.Contains(dsn, "://") || c.driver() == DriverMySQL {
+		base, rawQuery, _ := strings.Cut(dsn, "?")
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return dsn
+		}
+		for _, name := range names {
+			if !query.Has(name) {
+				query.Set(name, params[name])
+			}
+		}
+		return base + "?" + query.Encode()
+	}
+
+	// PostgreSQL key=value form
+	for _, name := range names {
+		if !strings.Contains(dsn, name+"=") {
+			dsn += " " + name + "=" + params[name]
+		}
+	}
+	return dsn
+}
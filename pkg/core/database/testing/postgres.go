@@ -0,0 +1,200 @@
+// Package testing provides a disposable PostgreSQL instance for repository integration tests.
+// A container is started with the docker CLI; set TEST_DB_URI to use an existing database instead
+// (e.g. a CI service container).
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// errRollback aborts the isolation transaction in WithTx
+var errRollback = errors.New("rollback test transaction")
+
+// PostgresConfig configures the test database container
+type PostgresConfig struct {
+	Image        string
+	Database     string
+	Username     string
+	Password     string
+	StartTimeout time.Duration
+}
+
+// DefaultPostgresConfig returns the default container configuration
+func DefaultPostgresConfig() PostgresConfig {
+	return PostgresConfig{
+		Image:        utils.GetEnv("TEST_DB_IMAGE", "postgres:16-alpine"),
+		Database:     "test",
+		Username:     "test",
+		Password:     "test",
+		StartTimeout: 60 * time.Second,
+	}
+}
+
+// PostgresContainer is a running test database
+type PostgresContainer struct {
+	ContainerID string // Empty when TEST_DB_URI is used
+	URI         string
+	Conn        *database.DatabaseConnection
+}
+
+// TB is the subset of testing.TB used by MustStartPostgres
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Fatalf(format string, args ...any)
+	Skipf(format string, args ...any)
+}
+
+// StartPostgres starts a PostgreSQL container (or connects to TEST_DB_URI) and waits until it accepts connections
+func StartPostgres(ctx context.Context, config ...PostgresConfig) (*PostgresContainer, error) {
+	cfg := DefaultPostgresConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	pc := &PostgresContainer{URI: os.Getenv("TEST_DB_URI")}
+	if pc.URI == "" {
+		if err := pc.runContainer(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	dbConfig := database.DefaultDBConfig()
+	dbConfig.Driver = database.DriverPostgres
+	dbConfig.URI = pc.URI
+	dbConfig.LogLevel = logger.Silent
+
+	deadline := time.Now().Add(cfg.StartTimeout)
+	for {
+		conn, err := database.NewDatabaseConnection(dbConfig)
+		if err == nil {
+			if err = conn.Ping(); err == nil {
+				pc.Conn = conn
+				return pc, nil
+			}
+			_ = conn.Close()
+		}
+		if time.Now().After(deadline) {
+			_ = pc.Terminate(context.Background())
+			return nil, fmt.Errorf("test database not ready after %s: %w", cfg.StartTimeout, err)
+		}
+		select {
+		case <-ctx.Done():
+			_ = pc.Terminate(context.Background())
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// MustStartPostgres starts the database for a test, migrates models and terminates it on cleanup.
+// The test is skipped when docker is unavailable and TEST_DB_URI is not set.
+func MustStartPostgres(t TB, models ...interface{}) *PostgresContainer {
+	t.Helper()
+	return MustStartPostgresWithConfig(t, DefaultPostgresConfig(), models...)
+}
+
+// MustStartPostgresWithConfig is MustStartPostgres with a custom container configuration, e.g. an
+// image with extensions such as PostGIS
+func MustStartPostgresWithConfig(t TB, config PostgresConfig, models ...interface{}) *PostgresContainer {
+	t.Helper()
+	if os.Getenv("TEST_DB_URI") == "" {
+		if _, err := exec.LookPath("docker"); err != nil {
+			t.Skipf("docker not available and TEST_DB_URI not set")
+		}
+	}
+
+	pc, err := StartPostgres(context.Background(), config)
+	if err != nil {
+		t.Fatalf("failed to start test database: %v", err)
+	}
+	t.Cleanup(func() { _ = pc.Terminate(context.Background()) })
+
+	if err := pc.Conn.MigrateModels(models...); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return pc
+}
+
+// runContainer starts the container on a random local port and records its URI
+func (pc *PostgresContainer) runContainer(ctx context.Context, cfg PostgresConfig) error {
+	out, err := exec.CommandContext(ctx, "docker", "run", "-d", "--rm",
+		"-e", "POSTGRES_DB="+cfg.Database,
+		"-e", "POSTGRES_USER="+cfg.Username,
+		"-e", "POSTGRES_PASSWORD="+cfg.Password,
+		"-p", "127.0.0.1::5432",
+		cfg.Image,
+	).Output()
+	if err != nil {
+		return fmt.Errorf("failed to start postgres container: %w", commandError(err))
+	}
+	pc.ContainerID = strings.TrimSpace(string(out))
+
+	out, err = exec.CommandContext(ctx, "docker", "port", pc.ContainerID, "5432/tcp").Output()
+	if err != nil {
+		_ = pc.Terminate(context.Background())
+		return fmt.Errorf("failed to resolve postgres container port: %w", commandError(err))
+	}
+	// "docker port" may print one line per address family; the first is enough
+	hostPort := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+
+	pc.URI = fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable", cfg.Username, cfg.Password, hostPort, cfg.Database)
+	return nil
+}
+
+// Terminate closes the connection and removes the container
+func (pc *PostgresContainer) Terminate(ctx context.Context) error {
+	if pc.Conn != nil {
+		_ = pc.Conn.Close()
+	}
+	if pc.ContainerID == "" {
+		return nil
+	}
+	if err := exec.CommandContext(ctx, "docker", "rm", "-f", pc.ContainerID).Run(); err != nil {
+		return fmt.Errorf("failed to remove postgres container: %w", commandError(err))
+	}
+	return nil
+}
+
+// WithTx runs fn inside a transaction that is always rolled back, isolating each test's writes
+func (pc *PostgresContainer) WithTx(fn func(tx *gorm.DB) error) error {
+	err := pc.Conn.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if errors.Is(err, errRollback) {
+		return nil
+	}
+	return err
+}
+
+// Truncate empties the given tables, for tests that must commit (e.g. code that opens its own transactions)
+func (pc *PostgresContainer) Truncate(tables ...string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	return pc.Conn.DB.Exec("TRUNCATE TABLE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE").Error
+}
+
+// commandError includes stderr of a failed docker command in the error
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,218 @@
+// Package dataloader batches and caches lookups made while serving one request, so code that
+// resolves related records item by item (users of a page of events, owners of webhooks, ...)
+// issues one query per batch instead of one per item.
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ErrNotFound is returned by Load when the batch function returned no value for the key
+var ErrNotFound = errors.New("dataloader: key not found")
+
+// BatchFunc loads the values of keys in one call. Keys missing from the returned map resolve to ErrNotFound;
+// an error fails every key of the batch.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Config tunes batching
+type Config struct {
+	Wait     time.Duration // How long to collect keys before dispatching a batch
+	MaxBatch int           // Dispatch immediately once a batch has this many keys
+}
+
+// LoadConfigFromEnv reads DATALOADER_WAIT (default 1ms) and DATALOADER_MAX_BATCH (default 100)
+func LoadConfigFromEnv() Config {
+	return Config{
+		Wait:     utils.GetEnvDuration("DATALOADER_WAIT", time.Millisecond),
+		MaxBatch: utils.GetEnvAsInt("DATALOADER_MAX_BATCH", 100),
+	}
+}
+
+// result is the eventual value of one key
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// batch is a set of keys dispatched together
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results map[K]*result[V]
+	once    sync.Once
+}
+
+// Loader batches Load calls into BatchFunc calls and caches the results for its lifetime.
+// Create one per request (see For); a long-lived Loader would serve stale data.
+type Loader[K comparable, V any] struct {
+	fetch  BatchFunc[K, V]
+	config Config
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending *batch[K, V]
+}
+
+// New creates a loader
+func New[K comparable, V any](fetch BatchFunc[K, V], config Config) *Loader[K, V] {
+	if config.MaxBatch <= 0 {
+		config.MaxBatch = 100
+	}
+	return &Loader[K, V]{fetch: fetch, config: config, cache: make(map[K]*result[V])}
+}
+
+// Load returns the value of key, waiting for the batch it joins to be fetched
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return l.wait(ctx, l.enqueue(ctx, key))
+}
+
+// LoadMany returns the values of keys found by the batch function; missing keys are left out
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) (map[K]V, error) {
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.enqueue(ctx, key)
+	}
+
+	values := make(map[K]V, len(keys))
+	for i, r := range results {
+		value, err := l.wait(ctx, r)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[keys[i]] = value
+	}
+	return values, nil
+}
+
+// Prime caches a value already known to the caller, e.g. an entity just created
+func (l *Loader[K, V]) Prime(key K, value V) {
+	r := &result[V]{done: make(chan struct{}), value: value}
+	close(r.done)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cache[key] = r
+}
+
+// Clear drops the cached value of key, e.g. after it was updated
+func (l *Loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.cache, key)
+}
+
+// enqueue returns the cached result of key or adds key to the pending batch
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.cache[key]; ok {
+		return r
+	}
+	r := &result[V]{done: make(chan struct{})}
+	l.cache[key] = r
+
+	b := l.pending
+	if b == nil {
+		b = &batch[K, V]{ctx: ctx, results: make(map[K]*result[V])}
+		l.pending = b
+		time.AfterFunc(l.config.Wait, func() { l.dispatch(b) })
+	}
+	b.keys = append(b.keys, key)
+	b.results[key] = r
+
+	if len(b.keys) >= l.config.MaxBatch {
+		l.pending = nil
+		go l.dispatch(b)
+	}
+	return r
+}
+
+// dispatch fetches a batch once, whichever of the timer and the size limit fires first
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	b.once.Do(func() {
+		values, err := l.fetch(b.ctx, b.keys)
+		if err != nil {
+			// Failed keys are not cached, so a later Load retries them
+			l.mu.Lock()
+			for _, key := range b.keys {
+				if l.cache[key] == b.results[key] {
+					delete(l.cache, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+		for _, key := range b.keys {
+			r := b.results[key]
+			switch value, ok := values[key]; {
+			case err != nil:
+				r.err = err
+			case ok:
+				r.value = value
+			default:
+				r.err = ErrNotFound
+			}
+			close(r.done)
+		}
+	})
+}
+
+// wait blocks until r is resolved or ctx is done
+func (l *Loader[K, V]) wait(ctx context.Context, r *result[V]) (V, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// loadersContextKey is the context key of the request's loaders
+type loadersContextKey struct{}
+
+// loaders holds the loaders created during one request, by name
+type loaders struct {
+	mu     sync.Mutex
+	config Config
+	byName map[string]interface{}
+}
+
+// WithLoaders returns a context in which For shares loaders (and their caches) by name.
+// The gRPC server calls it for every request.
+func WithLoaders(ctx context.Context, config Config) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, &loaders{config: config, byName: make(map[string]interface{})})
+}
+
+// For returns the request's loader called name, creating it with fetch on first use.
+// Without WithLoaders in ctx, every call returns a new loader, so nothing is shared.
+func For[K comparable, V any](ctx context.Context, name string, fetch BatchFunc[K, V]) *Loader[K, V] {
+	reg, ok := ctx.Value(loadersContextKey{}).(*loaders)
+	if !ok {
+		return New(fetch, LoadConfigFromEnv())
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if loader, ok := reg.byName[name].(*Loader[K, V]); ok {
+		return loader
+	}
+	loader := New(fetch, reg.config)
+	reg.byName[name] = loader
+	return loader
+}
@@ -0,0 +1,66 @@
+// Package deadletter keeps the domain events that named event bus consumers failed to handle, so
+// operators can inspect why and replay them to the consumer once it is fixed, instead of losing
+// them or repairing the data by hand.
+package deadletter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/events"
+)
+
+// Status is the state of a dead-lettered message
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Waiting to be replayed or discarded
+	StatusReplayed  Status = "replayed"  // The consumer handled the event on replay
+	StatusDiscarded Status = "discarded" // An operator decided not to replay it
+)
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusReplayed, StatusDiscarded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Message is an event a consumer failed to handle. Failing again, on publish or on replay, updates
+// the same message, so there is one per consumer and event.
+type Message struct {
+	entity.BaseEntity
+	Consumer     string     `json:"consumer" gorm:"size:128;not null;uniqueIndex:idx_dead_letters_consumer_event"`
+	EventID      uuid.UUID  `json:"event_id" gorm:"type:uuid;not null;uniqueIndex:idx_dead_letters_consumer_event"`
+	EventType    string     `json:"event_type" gorm:"size:128;not null;index"`
+	Payload      string     `json:"payload" gorm:"type:text;not null"` // The event as JSON
+	Status       Status     `json:"status" gorm:"size:16;not null;index"`
+	Attempts     int        `json:"attempts" gorm:"not null;default:0"` // Failed deliveries, including replays
+	LastError    string     `json:"last_error" gorm:"type:text"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	ReplayedAt   *time.Time `json:"replayed_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (Message) TableName() string {
+	return "dead_letters"
+}
+
+// Event decodes the stored event. Its Data is the decoded JSON (maps, slices and scalars), not the
+// type it was published with.
+func (m *Message) Event() (events.Event, error) {
+	var event events.Event
+	err := json.Unmarshal([]byte(m.Payload), &event)
+	return event, err
+}
+
+// Models returns the entities to auto-migrate
+func Models() []interface{} {
+	return []interface{}{&Message{}}
+}
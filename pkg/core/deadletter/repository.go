@@ -0,0 +1,66 @@
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+)
+
+// Repository defines persistence operations for dead-lettered messages
+type Repository interface {
+	core_repo.BaseRepository[Message]
+
+	// Record stores a failed delivery of message.EventID to message.Consumer. A message already
+	// stored for them gets the new error, one more attempt and the pending status again.
+	Record(ctx context.Context, message *Message) error
+	// SetStatus changes the status of a message and, for StatusReplayed, sets ReplayedAt
+	SetStatus(ctx context.Context, id uuid.UUID, status Status, at time.Time) error
+}
+
+type gormRepository struct {
+	*core_repo.GormBaseRepository[Message]
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[Message](db),
+	}
+}
+
+// Record implements Repository
+func (r *gormRepository) Record(ctx context.Context, message *Message) error {
+	message.Status = StatusPending
+	message.Attempts = 1
+	return r.Conn(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "consumer"}, {Name: "event_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status":         StatusPending,
+			"attempts":       gorm.Expr("dead_letters.attempts + 1"),
+			"last_error":     message.LastError,
+			"last_failed_at": message.LastFailedAt,
+			"updated_at":     time.Now().UTC(),
+		}),
+	}).Create(message).Error
+}
+
+// SetStatus implements Repository
+func (r *gormRepository) SetStatus(ctx context.Context, id uuid.UUID, status Status, at time.Time) error {
+	updates := map[string]interface{}{"status": status, "updated_at": at}
+	if status == StatusReplayed {
+		updates["replayed_at"] = at
+	}
+	result := r.Conn(ctx).Model(&Message{}).Where("id = ? AND deleted_at IS NULL", id).UpdateColumns(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return core_repo.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/events"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// MaxReplayBatch is the largest number of messages one Replay call accepts
+const MaxReplayBatch = 100
+
+// Redeliverer hands an event to the handlers of one consumer, e.g. *events.InMemoryBus
+type Redeliverer interface {
+	Redeliver(ctx context.Context, consumer string, event events.Event) error
+}
+
+// ReplayResult is the outcome of replaying one message
+type ReplayResult struct {
+	ID       uuid.UUID
+	Replayed bool
+	Error    string // Why the message was not replayed; it stays pending when the consumer failed again
+}
+
+// Service records failed events and implements the dead-letter management operations exposed over gRPC
+type Service struct {
+	*core_usecase.BaseUseCaseImpl[Message]
+	messages Repository
+	bus      Redeliverer
+}
+
+// NewService creates a new dead-letter service replaying messages through bus
+func NewService(messages Repository, bus Redeliverer, logger logger.Logger) *Service {
+	return &Service{
+		BaseUseCaseImpl: core_usecase.NewBaseUseCase[Message](messages, logger),
+		messages:        messages,
+		bus:             bus,
+	}
+}
+
+// Attach makes the bus dead-letter the events its named consumers fail to handle
+func (s *Service) Attach(bus *events.InMemoryBus) {
+	bus.OnFailure(s.record)
+}
+
+// record stores a failed event; a failure to store it is logged only, like the handler error
+func (s *Service) record(ctx context.Context, consumer string, event events.Event, cause error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		s.Logger.Error("Failed to encode dead-lettered event", "consumer", consumer, "event_id", event.ID, "error", err)
+		return
+	}
+	message := &Message{
+		Consumer:     consumer,
+		EventID:      event.ID,
+		EventType:    event.Type,
+		Payload:      string(payload),
+		LastError:    cause.Error(),
+		LastFailedAt: time.Now().UTC(),
+	}
+	if err := s.messages.Record(ctx, message); err != nil {
+		s.Logger.Error("Failed to dead-letter event", "consumer", consumer, "event_id", event.ID, "error", err)
+	}
+}
+
+// Messages lists dead-lettered messages, optionally of one consumer and status, newest first by default
+func (s *Service) Messages(ctx context.Context, consumer string, status Status, opts types.FilterOptions) (*types.PaginationResult[Message], error) {
+	filter := map[string]interface{}{}
+	if consumer != "" {
+		filter["consumer"] = consumer
+	}
+	if status != "" {
+		if !status.IsValid() {
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, fmt.Sprintf("unknown status %q", status))
+		}
+		filter["status"] = status
+	}
+	result, err := s.FindWithFilter(ctx, filter, opts)
+	if err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to list dead letters")
+	}
+	return result, nil
+}
+
+// Replay redelivers messages to their consumers, in the given order. A message that fails again
+// stays pending with the new error; the others are marked replayed.
+func (s *Service) Replay(ctx context.Context, ids []uuid.UUID) ([]ReplayResult, error) {
+	if len(ids) == 0 {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "at least one message ID is required")
+	}
+	if len(ids) > MaxReplayBatch {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, fmt.Sprintf("at most %d messages can be replayed at once", MaxReplayBatch))
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "replays do not support dry runs")
+	}
+
+	results := make([]ReplayResult, 0, len(ids))
+	for _, id := range ids {
+		result := ReplayResult{ID: id}
+		if err := s.replay(ctx, id); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Replayed = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// replay redelivers one message and records the outcome
+func (s *Service) replay(ctx context.Context, id uuid.UUID) error {
+	message, err := s.GetByID(ctx, id)
+	if err != nil {
+		return errors.New("message not found")
+	}
+	if message.Status == StatusReplayed {
+		return errors.New("message was already replayed")
+	}
+	event, err := message.Event()
+	if err != nil {
+		return fmt.Errorf("stored event cannot be decoded: %w", err)
+	}
+
+	if err := s.bus.Redeliver(ctx, message.Consumer, event); err != nil {
+		if errors.Is(err, events.ErrUnknownConsumer) {
+			return fmt.Errorf("consumer %s does not receive %s events", message.Consumer, message.EventType)
+		}
+		s.record(ctx, message.Consumer, event, err)
+		s.Logger.Warn("Dead-lettered event failed again", "id", id, "consumer", message.Consumer, "error", err)
+		return err
+	}
+	if err := s.messages.SetStatus(ctx, id, StatusReplayed, time.Now().UTC()); err != nil {
+		// The consumer handled the event; only the bookkeeping failed
+		s.Logger.Error("Failed to mark dead letter replayed", "id", id, "error", err)
+	}
+	s.Logger.Info("Dead-lettered event replayed", "id", id, "consumer", message.Consumer, "event_type", message.EventType)
+	return nil
+}
+
+// Discard marks a pending message as not to be replayed
+func (s *Service) Discard(ctx context.Context, id uuid.UUID) (*Message, error) {
+	message, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Status == StatusReplayed {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, "message was already replayed")
+	}
+	if err := s.messages.SetStatus(ctx, id, StatusDiscarded, time.Now().UTC()); err != nil {
+		s.Logger.Error("Failed to discard dead letter", "id", id, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to discard dead letter")
+	}
+	return s.GetByID(ctx, id)
+}
@@ -0,0 +1,145 @@
+// Package diagnostics exposes the Go runtime's profiling and debugging data of a running process:
+// net/http/pprof profiles, expvar variables and goroutine and heap dumps. Services serve them on a
+// separate port, the gateway under /debug; both require an access token with an admin role.
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"slices"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Config holds the diagnostics settings
+type Config struct {
+	Enabled     bool     // Serve the diagnostics endpoints
+	Addr        string   // Listen address of the services' diagnostics server
+	RequireAuth bool     // Require an access token with one of Roles
+	Roles       []string // Roles allowed to read diagnostics, also checked by the dump RPCs
+}
+
+// LoadConfigFromEnv reads DIAGNOSTICS_ENABLED (default false), DIAGNOSTICS_ADDR (default
+// 127.0.0.1:6060, reachable through kubectl port-forward only), DIAGNOSTICS_REQUIRE_AUTH (default
+// true) and DIAGNOSTICS_ROLES (comma separated, default "admin")
+func LoadConfigFromEnv() Config {
+	var roles []string
+	for _, role := range strings.Split(utils.GetEnv("DIAGNOSTICS_ROLES", "admin"), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return Config{
+		Enabled:     utils.GetEnv("DIAGNOSTICS_ENABLED", "false") == "true",
+		Addr:        utils.GetEnv("DIAGNOSTICS_ADDR", "127.0.0.1:6060"),
+		RequireAuth: utils.GetEnv("DIAGNOSTICS_REQUIRE_AUTH", "true") == "true",
+		Roles:       roles,
+	}
+}
+
+// Handler serves the diagnostics endpoints under /debug:
+//
+//	/debug/pprof/                 profile index (heap, goroutine, allocs, block, mutex, ...)
+//	/debug/pprof/profile?seconds= CPU profile
+//	/debug/pprof/trace?seconds=   execution trace
+//	/debug/vars                   expvar variables, including memstats
+//	/debug/dump/goroutines        stacks of all goroutines, as text
+//	/debug/dump/heap              heap profile after a garbage collection
+//	/debug/env                    environment variables read through pkg/utils, secrets redacted
+//
+// It does not check the caller; wrap it with RequireRole, or mount it behind the gateway's admin auth.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/dump/goroutines", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = WriteGoroutineDump(w)
+	})
+	mux.HandleFunc("/debug/dump/heap", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", `attachment; filename="heap.pprof"`)
+		_ = WriteHeapDump(w)
+	})
+	mux.HandleFunc("/debug/env", func(w http.ResponseWriter, _ *http.Request) {
+		utils.SendJSONResponse(w, http.StatusOK, utils.EnvSnapshot())
+	})
+	return mux
+}
+
+// RequireRole rejects requests without a valid access token carrying one of roles
+func RequireRole(next http.Handler, roles []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		claims, err := middleware.ValidateAccessToken(token, middleware.DefaultJWTConfig.AccessTokenSecret)
+		if err != nil {
+			http.Error(w, "invalid access token", http.StatusUnauthorized)
+			return
+		}
+		typed, err := claims.Claims()
+		if err != nil || !slices.Contains(roles, typed.Role) {
+			http.Error(w, "insufficient permissions", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteGoroutineDump writes the stacks of all goroutines, in the format of an unrecovered panic
+func WriteGoroutineDump(w io.Writer) error {
+	return runtimepprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// WriteHeapDump runs a garbage collection and writes the heap profile in the pprof format, for
+// `go tool pprof`. It holds allocation sites and sizes, not the contents of memory.
+func WriteHeapDump(w io.Writer) error {
+	runtime.GC()
+	return runtimepprof.WriteHeapProfile(w)
+}
+
+// Serve runs the diagnostics server on config.Addr until ctx is cancelled. It returns nil at once
+// when diagnostics are disabled.
+func Serve(ctx context.Context, config Config, log logger.Logger) error {
+	if !config.Enabled {
+		return nil
+	}
+	handler := Handler()
+	if config.RequireAuth {
+		handler = RequireRole(handler, config.Roles)
+	} else {
+		log.Warn("Diagnostics endpoints do not require authentication", "addr", config.Addr)
+	}
+	// No write timeout: CPU profiles and traces stream for the requested duration
+	server := &http.Server{Addr: config.Addr, Handler: handler, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	log.Info("Diagnostics server started", "addr", config.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("diagnostics server: %w", err)
+	}
+	return nil
+}
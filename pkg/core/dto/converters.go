@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// converterKey identifies a conversion between two concrete types
+type converterKey struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+// converters holds the registered field conversions used by MapToEntity, MapToDTO and ApplyPartialUpdate
+var (
+	convertersMu sync.RWMutex
+	converters   = map[converterKey]func(reflect.Value) (reflect.Value, error){}
+)
+
+// RegisterConverter registers a conversion used when a source field of type From is mapped onto a
+// destination field of type To. Pointer variants (*From -> To, From -> *To) are derived automatically;
+// a nil source pointer leaves the destination untouched.
+//
+//	dto.RegisterConverter(func(s string) (Money, error) { return ParseMoney(s) })
+func RegisterConverter[From, To any](fn func(From) (To, error)) {
+	key := converterKey{
+		from: reflect.TypeOf((*From)(nil)).Elem(),
+		to:   reflect.TypeOf((*To)(nil)).Elem(),
+	}
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[key] = func(v reflect.Value) (reflect.Value, error) {
+		out, err := fn(v.Interface().(From))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&out).Elem(), nil
+	}
+}
+
+// Convert converts v to To using a registered converter
+func Convert[To any](v interface{}) (To, error) {
+	var out To
+	if v == nil {
+		return out, nil
+	}
+	ok, err := convertInto(reflect.ValueOf(v), reflect.ValueOf(&out).Elem())
+	if err != nil {
+		return out, err
+	}
+	if !ok {
+		return out, fmt.Errorf("no converter registered from %T to %T", v, out)
+	}
+	return out, nil
+}
+
+func lookupConverter(from, to reflect.Type) (func(reflect.Value) (reflect.Value, error), bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[converterKey{from: from, to: to}]
+	return fn, ok
+}
+
+// convertInto sets dst from src through a registered converter, trying the exact types first and then
+// the pointer variants. It reports false when no converter applies.
+func convertInto(src, dst reflect.Value) (bool, error) {
+	if fn, ok := lookupConverter(src.Type(), dst.Type()); ok {
+		return setConverted(fn, src, dst)
+	}
+
+	// *From -> To
+	if src.Kind() == reflect.Ptr {
+		if fn, ok := lookupConverter(src.Type().Elem(), dst.Type()); ok {
+			if src.IsNil() {
+				return true, nil
+			}
+			return setConverted(fn, src.Elem(), dst)
+		}
+	}
+
+	// From -> *To and *From -> *To
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		ok, err := convertInto(src, elem)
+		if !ok || err != nil {
+			return ok, err
+		}
+		if src.Kind() == reflect.Ptr && src.IsNil() {
+			return true, nil
+		}
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(elem)
+		dst.Set(ptr)
+		return true, nil
+	}
+	return false, nil
+}
+
+func setConverted(fn func(reflect.Value) (reflect.Value, error), src, dst reflect.Value) (bool, error) {
+	out, err := fn(src)
+	if err != nil {
+		return true, err
+	}
+	dst.Set(out)
+	return true, nil
+}
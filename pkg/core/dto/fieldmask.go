@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Field masks (google.protobuf.FieldMask) name the fields an update request changes, following
+// https://google.aip.dev/134. Unlike the pointer convention of ApplyPartialUpdate, a field listed in
+// the mask is applied even when it holds its zero value, so a request can clear a field.
+
+// UpdateMaskPaths returns the top-level fields of msg that an update with mask changes:
+//   - the listed paths, when the mask has any
+//   - every field of msg for the single path "*" (full replacement)
+//   - the populated (non-zero) fields of msg when the mask is nil or empty
+//
+// Fields named in ignore, such as the ID and the mask itself, are never returned; listing one of
+// them, an unknown field or a nested path is an error.
+func UpdateMaskPaths(msg proto.Message, mask *fieldmaskpb.FieldMask, ignore ...string) ([]string, error) {
+	if msg == nil {
+		return nil, errors.New("update message must not be nil")
+	}
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	ignored := make(map[string]bool, len(ignore))
+	for _, name := range ignore {
+		ignored[name] = true
+	}
+
+	listed := mask.GetPaths()
+	if len(listed) == 0 || (len(listed) == 1 && listed[0] == "*") {
+		var paths []string
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if ignored[string(fd.Name())] || (len(listed) == 0 && !m.Has(fd)) {
+				continue
+			}
+			paths = append(paths, string(fd.Name()))
+		}
+		return paths, nil
+	}
+
+	paths := make([]string, 0, len(listed))
+	seen := make(map[string]bool, len(listed))
+	for _, path := range listed {
+		switch {
+		case path == "*":
+			return nil, errors.New("update mask path \"*\" cannot be combined with other paths")
+		case strings.Contains(path, "."):
+			return nil, fmt.Errorf("nested update mask path %q is not supported", path)
+		case fields.ByName(protoreflect.Name(path)) == nil:
+			return nil, fmt.Errorf("unknown field %q in update mask", path)
+		case ignored[path]:
+			return nil, fmt.Errorf("field %q cannot be updated", path)
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// ApplyFieldMask copies the fields of src selected by mask (see UpdateMaskPaths) onto the struct
+// dst points to, matching proto fields to struct fields by their Go name (first_name -> FirstName).
+// Values are converted like ApplyPartialUpdate does, and an unset message field clears the
+// destination. Every selected field must exist on dst.
+//
+//	err := dto.ApplyFieldMask(req, req.GetUpdateMask(), user, "id", "update_mask")
+func ApplyFieldMask(src proto.Message, mask *fieldmaskpb.FieldMask, dst interface{}, ignore ...string) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		return errors.New("destination must be a non-nil pointer to a struct")
+	}
+	paths, err := UpdateMaskPaths(src, mask, ignore...)
+	if err != nil {
+		return err
+	}
+
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() != reflect.Ptr || srcValue.IsNil() || srcValue.Elem().Kind() != reflect.Struct {
+		return errors.New("source must be a generated proto message")
+	}
+	names := protoGoNames(srcValue.Elem().Type())
+	for _, path := range paths {
+		goName, ok := names[path]
+		if !ok {
+			return fmt.Errorf("field %q of %T has no Go struct field", path, src)
+		}
+		dstField := dstValue.Elem().FieldByName(goName)
+		if !dstField.IsValid() || !dstField.CanSet() {
+			return fmt.Errorf("field %q has no settable counterpart %s in %T", path, goName, dst)
+		}
+		if err := assignField(goName, srcValue.Elem().FieldByName(goName), dstField); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoGoNames maps the proto field names of a generated message struct to its Go field names,
+// read from the protobuf struct tags
+func protoGoNames(t reflect.Type) map[string]string {
+	names := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, part := range strings.Split(field.Tag.Get("protobuf"), ",") {
+			if name, ok := strings.CutPrefix(part, "name="); ok {
+				names[name] = field.Name
+			}
+		}
+	}
+	return names
+}
+
+// assignField sets dst to src, dereferencing, converting or zeroing as needed
+func assignField(name string, src, dst reflect.Value) error {
+	if src.Kind() == reflect.Ptr && src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if ok, err := convertInto(src, dst); ok {
+		if err != nil {
+			return fmt.Errorf("error converting field '%s': %w", name, err)
+		}
+		return nil
+	}
+	if src.Kind() == reflect.Ptr {
+		src = src.Elem()
+	}
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case src.Type().ConvertibleTo(dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+	case dst.Kind() == reflect.Ptr && src.Type().AssignableTo(dst.Type().Elem()):
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(src)
+		dst.Set(ptr)
+	default:
+		return fmt.Errorf("cannot assign/convert field '%s' (%s) to %s", name, src.Type(), dst.Type())
+	}
+	return nil
+}
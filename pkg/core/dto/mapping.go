@@ -57,6 +57,14 @@ func MapToEntity(from interface{}, to interface{}) error {
 				continue
 			}
 
+			// Registered converters (decimals, dates, durations, proto well-known types, ...)
+			if ok, err := convertInto(fromFieldValue, toFieldValue); ok {
+				if err != nil {
+					return fmt.Errorf("error converting field '%s': %w", fromFieldName, err)
+				}
+				continue
+			}
+
 			// Case 2: Destination is Ptr, Source is not Ptr
 			if isToPtr && !isFromPtr {
 				// Check if Source type is assignable to Dest Elem type
@@ -150,7 +158,8 @@ func MapToDTO(from interface{}, to interface{}) error {
 }
 
 // applyPartialUpdate applies non-nil fields from a DTO struct (src) to a target entity struct (dst).
-// It assumes the convention that pointer fields in the DTO indicate fields to be updated.
+// It assumes the convention that pointer fields in the DTO indicate fields to be updated, so a field
+// cannot be set to its zero value; proto update requests use ApplyFieldMask instead.
 // dst must be a pointer to a struct.
 func ApplyPartialUpdate(src interface{}, dst interface{}) error {
 	dstValue := reflect.ValueOf(dst)
@@ -190,6 +199,10 @@ func ApplyPartialUpdate(src interface{}, dst interface{}) error {
 				// Check if the source element type can be assigned or converted to the destination field type
 				if srcElemValue.Type().AssignableTo(dstField.Type()) {
 					dstField.Set(srcElemValue)
+				} else if ok, err := convertInto(srcElemValue, dstField); ok {
+					if err != nil {
+						return fmt.Errorf("error converting DTO field '%s': %w", srcFieldType.Name, err)
+					}
 				} else if srcElemValue.Type().ConvertibleTo(dstField.Type()) {
 					dstField.Set(srcElemValue.Convert(dstField.Type()))
 				} else {
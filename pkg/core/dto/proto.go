@@ -0,0 +1,231 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"golang-microservices-boilerplate/pkg/core/types"
+	corePb "golang-microservices-boilerplate/proto/core"
+)
+
+// Proto conversions for the core value types. Decimals, dates and zoned times travel as strings in proto
+// messages (string fields, google.protobuf.StringValue or structpb string values) so no precision or
+// zone information is lost; durations and plain timestamps use the well-known types, and locations
+// the core.GeoPoint message.
+
+// DecimalToProto returns the decimal's string form for a string proto field
+func DecimalToProto(d types.Decimal) string {
+	return d.String()
+}
+
+// DecimalFromProto parses a decimal proto string field; an empty string is zero
+func DecimalFromProto(s string) (types.Decimal, error) {
+	if s == "" {
+		return types.Decimal{}, nil
+	}
+	return types.ParseDecimal(s)
+}
+
+// DateToProto returns the date as YYYY-MM-DD, or "" when unset
+func DateToProto(d types.Date) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+// DateFromProto parses a YYYY-MM-DD proto string field; an empty string is the zero date
+func DateFromProto(s string) (types.Date, error) {
+	if s == "" {
+		return types.Date{}, nil
+	}
+	return types.ParseDate(s)
+}
+
+// ZonedTimeToProto returns the zoned time's text form, e.g. 2026-10-15T09:00:00+07:00[Asia/Ho_Chi_Minh]
+func ZonedTimeToProto(z types.ZonedTime) string {
+	return z.String()
+}
+
+// ZonedTimeFromProto parses a zoned time proto string field; an empty string is the zero value
+func ZonedTimeFromProto(s string) (types.ZonedTime, error) {
+	if s == "" {
+		return types.ZonedTime{}, nil
+	}
+	return types.ParseZonedTime(s)
+}
+
+// TimestampToProto converts t, returning nil for the zero time
+func TimestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// TimestampFromProto converts ts into loc (UTC when loc is nil), returning the zero time for nil
+func TimestampFromProto(ts *timestamppb.Timestamp, loc *time.Location) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, err
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return ts.AsTime().In(loc), nil
+}
+
+// DurationToProto converts d
+func DurationToProto(d time.Duration) *durationpb.Duration {
+	return durationpb.New(d)
+}
+
+// DurationFromProto converts d, returning 0 for nil
+func DurationFromProto(d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return 0, err
+	}
+	return d.AsDuration(), nil
+}
+
+// GeoPointToProto converts p
+func GeoPointToProto(p types.GeoPoint) *corePb.GeoPoint {
+	return &corePb.GeoPoint{Lat: p.Lat, Lng: p.Lng}
+}
+
+// GeoPointFromProto converts and validates p; nil is the zero point
+func GeoPointFromProto(p *corePb.GeoPoint) (types.GeoPoint, error) {
+	point := types.GeoPoint{Lat: p.GetLat(), Lng: p.GetLng()}
+	return point, point.Validate()
+}
+
+// GeoRadiusFromProto converts and validates a radius query
+func GeoRadiusFromProto(r *corePb.GeoRadius) (types.GeoRadius, error) {
+	radius := types.GeoRadius{
+		Center:       types.GeoPoint{Lat: r.GetCenter().GetLat(), Lng: r.GetCenter().GetLng()},
+		RadiusMeters: r.GetRadiusMeters(),
+	}
+	return radius, radius.Validate()
+}
+
+// GeoBoundingBoxFromProto converts and validates a bounding box query
+func GeoBoundingBoxFromProto(b *corePb.GeoBoundingBox) (types.GeoBoundingBox, error) {
+	box := types.GeoBoundingBox{MinLat: b.GetMinLat(), MinLng: b.GetMinLng(), MaxLat: b.GetMaxLat(), MaxLng: b.GetMaxLng()}
+	return box, box.Validate()
+}
+
+// ValueToProto converts core value types to a structpb.Value, e.g. for filter maps.
+// Decimals, dates, zoned times and durations become strings; timestamps become RFC 3339 strings.
+func ValueToProto(v interface{}) (*structpb.Value, error) {
+	switch val := v.(type) {
+	case types.Decimal:
+		return structpb.NewStringValue(val.String()), nil
+	case types.Date:
+		return structpb.NewStringValue(DateToProto(val)), nil
+	case types.ZonedTime:
+		return structpb.NewStringValue(val.String()), nil
+	case time.Duration:
+		return structpb.NewStringValue(val.String()), nil
+	case time.Time:
+		return structpb.NewStringValue(val.Format(time.RFC3339Nano)), nil
+	default:
+		return structpb.NewValue(v)
+	}
+}
+
+// DecimalFromValue reads a decimal from a structpb string or number value
+func DecimalFromValue(v *structpb.Value) (types.Decimal, error) {
+	switch kind := v.GetKind().(type) {
+	case nil, *structpb.Value_NullValue:
+		return types.Decimal{}, nil
+	case *structpb.Value_StringValue:
+		return DecimalFromProto(kind.StringValue)
+	case *structpb.Value_NumberValue:
+		return types.DecimalFromFloat(kind.NumberValue)
+	default:
+		return types.Decimal{}, fmt.Errorf("cannot convert %T to decimal", kind)
+	}
+}
+
+// DurationFromValue reads a duration from a structpb string ("1h30m") or number of seconds
+func DurationFromValue(v *structpb.Value) (time.Duration, error) {
+	switch kind := v.GetKind().(type) {
+	case nil, *structpb.Value_NullValue:
+		return 0, nil
+	case *structpb.Value_StringValue:
+		return time.ParseDuration(kind.StringValue)
+	case *structpb.Value_NumberValue:
+		return time.Duration(kind.NumberValue * float64(time.Second)), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to duration", kind)
+	}
+}
+
+func init() {
+	// Decimals
+	RegisterConverter(func(d types.Decimal) (string, error) { return DecimalToProto(d), nil })
+	RegisterConverter(DecimalFromProto)
+	RegisterConverter(func(d types.Decimal) (*wrapperspb.StringValue, error) { return wrapperspb.String(d.String()), nil })
+	RegisterConverter(func(w *wrapperspb.StringValue) (types.Decimal, error) { return DecimalFromProto(w.GetValue()) })
+	RegisterConverter(func(d types.Decimal) (*structpb.Value, error) { return ValueToProto(d) })
+	RegisterConverter(DecimalFromValue)
+
+	// Civil dates
+	RegisterConverter(func(d types.Date) (string, error) { return DateToProto(d), nil })
+	RegisterConverter(DateFromProto)
+	RegisterConverter(func(d types.Date) (*wrapperspb.StringValue, error) {
+		if d.IsZero() {
+			return nil, nil
+		}
+		return wrapperspb.String(d.String()), nil
+	})
+	RegisterConverter(func(w *wrapperspb.StringValue) (types.Date, error) { return DateFromProto(w.GetValue()) })
+	RegisterConverter(func(d types.Date) (*structpb.Value, error) { return ValueToProto(d) })
+	RegisterConverter(func(v *structpb.Value) (types.Date, error) { return DateFromProto(v.GetStringValue()) })
+
+	// Zoned timestamps
+	RegisterConverter(func(z types.ZonedTime) (string, error) { return ZonedTimeToProto(z), nil })
+	RegisterConverter(ZonedTimeFromProto)
+	RegisterConverter(func(z types.ZonedTime) (*structpb.Value, error) { return ValueToProto(z) })
+	RegisterConverter(func(v *structpb.Value) (types.ZonedTime, error) { return ZonedTimeFromProto(v.GetStringValue()) })
+	// A google.protobuf.Timestamp carries no zone, so the instant is kept in UTC
+	RegisterConverter(func(z types.ZonedTime) (*timestamppb.Timestamp, error) { return TimestampToProto(z.Time), nil })
+	RegisterConverter(func(ts *timestamppb.Timestamp) (types.ZonedTime, error) {
+		t, err := TimestampFromProto(ts, time.UTC)
+		return types.ZonedTime{Time: t}, err
+	})
+
+	// Timestamps and durations
+	RegisterConverter(func(t time.Time) (*timestamppb.Timestamp, error) { return TimestampToProto(t), nil })
+	RegisterConverter(func(ts *timestamppb.Timestamp) (time.Time, error) { return TimestampFromProto(ts, time.UTC) })
+	RegisterConverter(func(d time.Duration) (*durationpb.Duration, error) { return DurationToProto(d), nil })
+	RegisterConverter(DurationFromProto)
+	RegisterConverter(func(d time.Duration) (*structpb.Value, error) { return ValueToProto(d) })
+	RegisterConverter(DurationFromValue)
+
+	// Locations
+	RegisterConverter(func(p types.GeoPoint) (*corePb.GeoPoint, error) { return GeoPointToProto(p), nil })
+	RegisterConverter(GeoPointFromProto)
+
+	// Wrappers for optional scalars
+	RegisterConverter(func(s string) (*wrapperspb.StringValue, error) { return wrapperspb.String(s), nil })
+	RegisterConverter(func(w *wrapperspb.StringValue) (string, error) { return w.GetValue(), nil })
+	RegisterConverter(func(i int64) (*wrapperspb.Int64Value, error) { return wrapperspb.Int64(i), nil })
+	RegisterConverter(func(w *wrapperspb.Int64Value) (int64, error) { return w.GetValue(), nil })
+	RegisterConverter(func(i int32) (*wrapperspb.Int32Value, error) { return wrapperspb.Int32(i), nil })
+	RegisterConverter(func(w *wrapperspb.Int32Value) (int32, error) { return w.GetValue(), nil })
+	RegisterConverter(func(f float64) (*wrapperspb.DoubleValue, error) { return wrapperspb.Double(f), nil })
+	RegisterConverter(func(w *wrapperspb.DoubleValue) (float64, error) { return w.GetValue(), nil })
+	RegisterConverter(func(b bool) (*wrapperspb.BoolValue, error) { return wrapperspb.Bool(b), nil })
+	RegisterConverter(func(w *wrapperspb.BoolValue) (bool, error) { return w.GetValue(), nil })
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -13,6 +14,14 @@ var validate *validator.Validate
 // init initializes the package-level validator instance.
 func init() {
 	validate = validator.New()
+	// Report fields by their JSON name, which is also the proto field name clients send
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
 	// Optional: Register custom validation functions here if needed
 	// validate.RegisterValidation("customTag", customValidationFunc)
 }
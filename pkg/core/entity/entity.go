@@ -16,12 +16,21 @@ type Entity interface {
 	GetDeletedAt() *time.Time
 }
 
+// Filterable is implemented by entities that limit the fields list queries may filter, sort and
+// group on, so that callers cannot probe secret columns such as password hashes. Entities without
+// it may be queried on any column.
+type Filterable interface {
+	FilterableFields() []string
+}
+
 // BaseEntity struct to be embedded in other structs
 type BaseEntity struct {
 	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;"`
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+
+	updateColumns []string // Columns the next repository update writes, see SetUpdateColumns
 }
 
 // GetID returns the entity ID
@@ -53,6 +62,18 @@ func (base BaseEntity) GetDeletedAt() *time.Time {
 	return base.DeletedAt
 }
 
+// SetUpdateColumns limits the repository updates of the entity to the given columns, which are
+// then written even when they hold zero values (e.g. the fields of an update mask). Without
+// columns, an update writes the non-zero fields only.
+func (base *BaseEntity) SetUpdateColumns(columns ...string) {
+	base.updateColumns = columns
+}
+
+// UpdateColumns returns the columns set by SetUpdateColumns
+func (base *BaseEntity) UpdateColumns() []string {
+	return base.updateColumns
+}
+
 // BeforeCreate hook to set the ID before creating a new record
 func (base *BaseEntity) BeforeCreate(tx *gorm.DB) (err error) {
 	if base.ID == uuid.Nil {
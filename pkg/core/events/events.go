@@ -0,0 +1,189 @@
+// Package events provides a small in-process event bus that use cases publish domain events to
+// (e.g. "user.created"). Subscribers such as the webhooks dispatcher turn them into side effects.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+)
+
+// Event is a domain event. Data must be JSON-serializable.
+type Event struct {
+	ID            uuid.UUID   `json:"id"`
+	Type          string      `json:"type"`
+	OccurredAt    time.Time   `json:"occurred_at"`
+	Data          interface{} `json:"data"`
+	SchemaVersion int         `json:"schema_version,omitempty"` // Version of the Data schema; set on publish when the type has one
+}
+
+// NewEvent creates an event with a fresh ID and the current time
+func NewEvent(eventType string, data interface{}) Event {
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// Handler handles a published event
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher publishes domain events
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Bus publishes events to subscribers
+type Bus interface {
+	Publisher
+	// Subscribe registers a handler for an event type; "*" receives every event
+	Subscribe(eventType string, handler Handler)
+	// SubscribeAs registers a handler under a consumer name. Failed events of named consumers are
+	// reported to the bus's failure handler and can be redelivered to them.
+	SubscribeAs(consumer, eventType string, handler Handler)
+}
+
+// FailureHandler is told about an event a named consumer failed to handle
+type FailureHandler func(ctx context.Context, consumer string, event Event, err error)
+
+// ErrUnknownConsumer is returned by Redeliver when no handler of the consumer receives the event type
+var ErrUnknownConsumer = errors.New("no handler of the consumer receives the event type")
+
+// subscription is a handler and the consumer it belongs to; the consumer is empty for Subscribe
+type subscription struct {
+	consumer string
+	handler  Handler
+}
+
+// InMemoryBus delivers events synchronously to handlers in the publishing goroutine.
+// Handler errors are logged and do not fail the publisher, so handlers should only do
+// quick, local work (e.g. enqueueing a webhook delivery) and defer anything slow.
+type InMemoryBus struct {
+	mu         sync.RWMutex
+	handlers   map[string][]subscription
+	onFailure  FailureHandler
+	schemas    *SchemaRegistry
+	schemaMode SchemaMode
+	logger     logger.Logger
+}
+
+// NewInMemoryBus creates a new in-process event bus
+func NewInMemoryBus(logger logger.Logger) *InMemoryBus {
+	return &InMemoryBus{
+		handlers: make(map[string][]subscription),
+		logger:   logger,
+	}
+}
+
+// Subscribe implements Bus
+func (b *InMemoryBus) Subscribe(eventType string, handler Handler) {
+	b.SubscribeAs("", eventType, handler)
+}
+
+// SubscribeAs implements Bus
+func (b *InMemoryBus) SubscribeAs(consumer, eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], subscription{consumer: consumer, handler: handler})
+}
+
+// OnFailure sets the handler told about the events named consumers fail to handle, e.g. a
+// dead-letter store. Failures of unnamed handlers are only logged.
+func (b *InMemoryBus) OnFailure(handler FailureHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onFailure = handler
+}
+
+// UseSchemas validates published events against registry, handling mismatches as mode says
+func (b *InMemoryBus) UseSchemas(registry *SchemaRegistry, mode SchemaMode) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.schemas = registry
+	b.schemaMode = mode
+}
+
+// Publish implements Publisher. With a schema registry, the event gets the version of its schema
+// and, unless validation is off, a mismatching event is rejected or logged before any handler runs.
+func (b *InMemoryBus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	subs := append(append([]subscription{}, b.handlers[event.Type]...), b.handlers["*"]...)
+	onFailure, schemas, schemaMode := b.onFailure, b.schemas, b.schemaMode
+	b.mu.RUnlock()
+
+	if schemas != nil {
+		var err error
+		if event, err = b.applySchema(schemas, schemaMode, event); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range subs {
+		if err := sub.handler(ctx, event); err != nil {
+			b.logger.Error("Event handler failed", "event_type", event.Type, "event_id", event.ID, "consumer", sub.consumer, "error", err)
+			if onFailure != nil && sub.consumer != "" {
+				onFailure(ctx, sub.consumer, event, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applySchema sets the schema version of event and validates its data
+func (b *InMemoryBus) applySchema(schemas *SchemaRegistry, mode SchemaMode, event Event) (Event, error) {
+	if mode == SchemaOff {
+		if schema, ok := schemas.Lookup(event.Type, event.SchemaVersion); ok {
+			event.SchemaVersion = schema.Version
+		}
+		return event, nil
+	}
+	version, err := schemas.Validate(event)
+	if err != nil {
+		if mode == SchemaEnforce {
+			return event, err
+		}
+		b.logger.Warn("Published event does not match its schema", "event_type", event.Type, "event_id", event.ID, "error", err)
+		return event, nil
+	}
+	event.SchemaVersion = version
+	return event, nil
+}
+
+// Redeliver hands an event to the handlers of one consumer only and returns the first error,
+// without reporting it to the failure handler. It is how dead-lettered events are replayed.
+func (b *InMemoryBus) Redeliver(ctx context.Context, consumer string, event Event) error {
+	b.mu.RLock()
+	var handlers []Handler
+	for _, sub := range append(append([]subscription{}, b.handlers[event.Type]...), b.handlers["*"]...) {
+		if sub.consumer == consumer {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	if consumer == "" || len(handlers) == 0 {
+		return fmt.Errorf("%w: consumer %q, event type %q", ErrUnknownConsumer, consumer, event.Type)
+	}
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NopPublisher discards events; used when a service has no subscribers configured
+type NopPublisher struct{}
+
+// Publish implements Publisher
+func (NopPublisher) Publish(context.Context, Event) error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// SequencedEvent is an event numbered in the order the Feed received it
+type SequencedEvent struct {
+	Sequence uint64
+	Event
+}
+
+// Resource returns the resource type of the event, i.e. the part of the type before the first dot ("user.created" -> "user")
+func (e Event) Resource() string {
+	resource, _, _ := strings.Cut(e.Type, ".")
+	return resource
+}
+
+// Feed keeps the most recent events in a ring buffer and fans them out to live subscribers,
+// so clients of a change feed can resume from the last sequence they saw.
+// Sequences restart when the process restarts; clients asking for a sequence ahead of the
+// feed, or one that has been evicted, receive the whole buffer.
+type Feed struct {
+	mu          sync.Mutex
+	buffer      []SequencedEvent
+	size        int
+	next        uint64
+	subscribers map[chan SequencedEvent]struct{}
+}
+
+// NewFeed creates a feed retaining up to size events for resumption
+func NewFeed(size int) *Feed {
+	if size <= 0 {
+		size = 1000
+	}
+	return &Feed{
+		size:        size,
+		next:        1,
+		subscribers: make(map[chan SequencedEvent]struct{}),
+	}
+}
+
+// Attach subscribes the feed to every event on the bus
+func (f *Feed) Attach(bus Bus) {
+	bus.Subscribe("*", func(_ context.Context, event Event) error {
+		f.Append(event)
+		return nil
+	})
+}
+
+// Append records an event and delivers it to live subscribers. Subscribers that are not keeping
+// up miss the event rather than blocking the publisher; they can resume using the sequence.
+func (f *Feed) Append(event Event) SequencedEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	se := SequencedEvent{Sequence: f.next, Event: event}
+	f.next++
+	if len(f.buffer) == f.size {
+		f.buffer = append(f.buffer[:0:0], f.buffer[1:]...)
+	}
+	f.buffer = append(f.buffer, se)
+
+	for ch := range f.subscribers {
+		select {
+		case ch <- se:
+		default:
+		}
+	}
+	return se
+}
+
+// Subscribe returns the buffered events after the given sequence (0 for none) and a channel of
+// new events. The returned function must be called to unsubscribe.
+func (f *Feed) Subscribe(after uint64) ([]SequencedEvent, <-chan SequencedEvent, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var backlog []SequencedEvent
+	if after > 0 {
+		// A sequence from before a restart (ahead of the feed) replays everything still buffered
+		if after >= f.next {
+			after = 0
+		}
+		for _, se := range f.buffer {
+			if se.Sequence > after {
+				backlog = append(backlog, se)
+			}
+		}
+	}
+
+	ch := make(chan SequencedEvent, 64)
+	f.subscribers[ch] = struct{}{}
+	return backlog, ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subscribers[ch]; ok {
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+}
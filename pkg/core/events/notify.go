@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// EventNotification is the type of events carrying a Notification
+const EventNotification = "notification.created"
+
+// Notification tells a user about something done on their behalf, such as a finished export
+type Notification struct {
+	UserID  uuid.UUID              `json:"user_id"`
+	Type    string                 `json:"type"` // e.g. "data_export.ready"
+	Message string                 `json:"message"`
+	Link    string                 `json:"link,omitempty"` // Gateway path of the related resource
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// PublisherNotifier delivers notifications as notification.created events, so they reach users
+// through the webhooks subscribed to that type
+type PublisherNotifier struct {
+	publisher Publisher
+}
+
+// NewPublisherNotifier creates a notifier publishing on publisher
+func NewPublisherNotifier(publisher Publisher) *PublisherNotifier {
+	return &PublisherNotifier{publisher: publisher}
+}
+
+// Notify implements Notifier
+func (n *PublisherNotifier) Notify(ctx context.Context, notification Notification) error {
+	return n.publisher.Publish(ctx, NewEvent(EventNotification, notification))
+}
@@ -0,0 +1,237 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"golang-microservices-boilerplate/pkg/utils"
+	core_pb "golang-microservices-boilerplate/proto/core"
+)
+
+// SchemaMode controls what the bus does with events that do not match their schema
+type SchemaMode string
+
+const (
+	// SchemaEnforce rejects such events: Publish returns the error and no handler sees the event
+	SchemaEnforce SchemaMode = "enforce"
+	// SchemaWarn logs them and publishes them anyway
+	SchemaWarn SchemaMode = "warn"
+	// SchemaOff skips validation; events still get the version of their schema
+	SchemaOff SchemaMode = "off"
+)
+
+// SchemaModeFromEnv reads EVENT_SCHEMA_VALIDATION (enforce, warn or off; default enforce)
+func SchemaModeFromEnv() (SchemaMode, error) {
+	mode := SchemaMode(strings.ToLower(utils.GetEnv("EVENT_SCHEMA_VALIDATION", string(SchemaEnforce))))
+	switch mode {
+	case SchemaEnforce, SchemaWarn, SchemaOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid EVENT_SCHEMA_VALIDATION %q (expected enforce, warn or off)", mode)
+	}
+}
+
+var (
+	// ErrSchemaViolation is returned for event data that does not match its schema
+	ErrSchemaViolation = errors.New("event data does not match its schema")
+	// ErrIncompatibleSchema is returned when registering a version that breaks the previous one
+	ErrIncompatibleSchema = errors.New("schema is incompatible with the previous version")
+)
+
+// Schema is version Version of the data of events of type Type, described by a protobuf message.
+// The data is checked against the message's JSON form, so Go structs whose JSON names match the
+// proto field names can be published as they are.
+type Schema struct {
+	Type    string
+	Version int
+	Message proto.Message
+}
+
+// Descriptor returns the descriptor of the schema's message
+func (s Schema) Descriptor() protoreflect.MessageDescriptor {
+	return s.Message.ProtoReflect().Descriptor()
+}
+
+// CoreSchemas returns the schemas of the events published by pkg/core
+func CoreSchemas() []Schema {
+	return []Schema{
+		{Type: EventNotification, Version: 1, Message: &core_pb.NotificationCreatedV1{}},
+	}
+}
+
+// SchemaRegistry holds the versioned payload schemas of event types. Versions of a type are
+// registered in order, and each must be compatible with the one before, so consumers built against
+// an older version keep decoding newer events. Schemas are embedded in the binary as generated
+// protobuf messages.
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string][]Schema // By event type, ordered by version
+}
+
+// NewSchemaRegistry creates an empty registry
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: make(map[string][]Schema)}
+}
+
+// Register adds schemas. A version must be the next one of its type and compatible with the
+// previous version (see CheckCompatibility); registration stops at the first schema that is not.
+func (r *SchemaRegistry) Register(schemas ...Schema) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, schema := range schemas {
+		if schema.Type == "" || schema.Message == nil {
+			return errors.New("schema needs an event type and a message")
+		}
+		versions := r.schemas[schema.Type]
+		if schema.Version != len(versions)+1 {
+			return fmt.Errorf("schema of %s: expected version %d, got %d", schema.Type, len(versions)+1, schema.Version)
+		}
+		if len(versions) > 0 {
+			previous := versions[len(versions)-1]
+			if err := CheckCompatibility(previous.Descriptor(), schema.Descriptor()); err != nil {
+				return fmt.Errorf("schema of %s version %d: %w", schema.Type, schema.Version, err)
+			}
+		}
+		r.schemas[schema.Type] = append(versions, schema)
+	}
+	return nil
+}
+
+// Lookup returns a version of the schema of an event type; version 0 is the latest
+func (r *SchemaRegistry) Lookup(eventType string, version int) (Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := r.schemas[eventType]
+	if len(versions) == 0 || version < 0 || version > len(versions) {
+		return Schema{}, false
+	}
+	if version == 0 {
+		version = len(versions)
+	}
+	return versions[version-1], true
+}
+
+// Types returns the registered event types, sorted
+func (r *SchemaRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.schemas))
+	for eventType := range r.schemas {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Validate checks the data of event against its schema: the version the event names, or the
+// latest. It returns the version checked, or 0 without an error for types with no schema. Data
+// with fields the schema does not have fails, so a producer cannot add a field without adding it
+// to the schema first.
+func (r *SchemaRegistry) Validate(event Event) (int, error) {
+	schema, ok := r.Lookup(event.Type, event.SchemaVersion)
+	if !ok {
+		if event.SchemaVersion != 0 {
+			return 0, fmt.Errorf("%w: %s has no schema version %d", ErrSchemaViolation, event.Type, event.SchemaVersion)
+		}
+		return 0, nil
+	}
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSchemaViolation, err)
+	}
+	if err := protojson.Unmarshal(raw, schema.Message.ProtoReflect().New().Interface()); err != nil {
+		return 0, fmt.Errorf("%w: %s version %d: %v", ErrSchemaViolation, event.Type, schema.Version, err)
+	}
+	return schema.Version, nil
+}
+
+// DecodeData decodes the data of event into message, the consumer's schema of the event. Fields
+// the message does not know, added by newer versions, are ignored. It accepts the data as
+// published (a struct or a proto message) and as decoded from JSON, e.g. by dead-letter replays.
+func DecodeData(event Event, message proto.Message) error {
+	if data, ok := event.Data.(proto.Message); ok && data.ProtoReflect().Descriptor().FullName() == message.ProtoReflect().Descriptor().FullName() {
+		proto.Reset(message)
+		proto.Merge(message, data)
+		return nil
+	}
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event data: %w", event.Type, err)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(raw, message); err != nil {
+		return fmt.Errorf("failed to decode %s event data: %w", event.Type, err)
+	}
+	return nil
+}
+
+// CheckCompatibility reports how next breaks consumers of previous. Data is exchanged as JSON, so
+// a field keeps its name as well as its number and type, and a removed field's number and name
+// are reserved so they cannot come back with another meaning. Adding fields is compatible.
+func CheckCompatibility(previous, next protoreflect.MessageDescriptor) error {
+	var problems []string
+	checkMessage(previous, next, string(previous.Name()), map[protoreflect.FullName]bool{}, &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrIncompatibleSchema, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkMessage appends the incompatibilities of next with previous to problems, naming fields by
+// their path from the top-level message
+func checkMessage(previous, next protoreflect.MessageDescriptor, path string, seen map[protoreflect.FullName]bool, problems *[]string) {
+	if seen[previous.FullName()+"|"+next.FullName()] {
+		return
+	}
+	seen[previous.FullName()+"|"+next.FullName()] = true
+
+	fields := previous.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		old := fields.Get(i)
+		fieldPath := path + "." + string(old.Name())
+		current := next.Fields().ByNumber(old.Number())
+		if current == nil {
+			if !next.ReservedRanges().Has(old.Number()) || !next.ReservedNames().Has(old.Name()) {
+				*problems = append(*problems, fmt.Sprintf("%s (%d) was removed without reserving its number and name", fieldPath, old.Number()))
+			}
+			continue
+		}
+		switch {
+		case current.Name() != old.Name():
+			*problems = append(*problems, fmt.Sprintf("%s (%d) was renamed to %s", fieldPath, old.Number(), current.Name()))
+		case current.Kind() != old.Kind():
+			*problems = append(*problems, fmt.Sprintf("%s changed type from %s to %s", fieldPath, old.Kind(), current.Kind()))
+		case current.IsList() != old.IsList() || current.IsMap() != old.IsMap():
+			*problems = append(*problems, fmt.Sprintf("%s changed cardinality", fieldPath))
+		case old.Kind() == protoreflect.MessageKind || old.Kind() == protoreflect.GroupKind:
+			oldMessage, currentMessage := old.Message(), current.Message()
+			// Well-known types such as Timestamp have their own JSON form and cannot be compared by fields
+			if isWellKnown(oldMessage) || isWellKnown(currentMessage) {
+				if oldMessage.FullName() != currentMessage.FullName() {
+					*problems = append(*problems, fmt.Sprintf("%s changed type from %s to %s", fieldPath, oldMessage.FullName(), currentMessage.FullName()))
+				}
+				continue
+			}
+			checkMessage(oldMessage, currentMessage, fieldPath, seen, problems)
+		case old.Kind() == protoreflect.EnumKind:
+			oldValues, currentValues := old.Enum().Values(), current.Enum().Values()
+			for j := 0; j < oldValues.Len(); j++ {
+				value := oldValues.Get(j)
+				if match := currentValues.ByNumber(value.Number()); match == nil || match.Name() != value.Name() {
+					*problems = append(*problems, fmt.Sprintf("%s lost enum value %s", fieldPath, value.Name()))
+				}
+			}
+		}
+	}
+}
+
+func isWellKnown(message protoreflect.MessageDescriptor) bool {
+	return strings.HasPrefix(string(message.FullName()), "google.protobuf.")
+}
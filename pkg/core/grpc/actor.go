@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/middleware"
+)
+
+// ActorUnaryInterceptor stores the caller described by the forwarded access token in the context
+// (see usecase.ActorFromContext). Authentication itself is enforced by the API gateway, so requests
+// without a valid token proceed without an actor rather than being rejected here.
+func ActorUnaryInterceptor(accessSecret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(contextWithActor(ctx, accessSecret), req)
+	}
+}
+
+// ActorStreamInterceptor is the streaming counterpart of ActorUnaryInterceptor
+func ActorStreamInterceptor(accessSecret string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &actorServerStream{ServerStream: ss, ctx: contextWithActor(ss.Context(), accessSecret)})
+	}
+}
+
+// actorServerStream replaces the context of a server stream
+type actorServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func contextWithActor(ctx context.Context, accessSecret string) context.Context {
+	header := firstMetadataValueFromContext(ctx, "authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return ctx
+	}
+	claims, err := middleware.ValidateAccessToken(token, accessSecret)
+	if err != nil {
+		return ctx
+	}
+	typed, err := claims.Claims()
+	if err != nil {
+		return ctx
+	}
+	actor := usecase.Actor{ID: typed.UserID.String(), Email: typed.Email, Role: typed.Role}
+	if typed.TenantID != uuid.Nil {
+		actor.TenantID, actor.OrgRole = typed.TenantID.String(), typed.OrgRole
+	}
+	return usecase.WithActor(ctx, actor)
+}
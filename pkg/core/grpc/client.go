@@ -19,7 +19,8 @@ type GrpcClientConfig struct {
 	DialTimeout            time.Duration
 	KeepAlive              time.Duration
 	KeepAliveTimeout       time.Duration
-	AllowInsecureTransport bool // Should be false in production
+	CallTimeout            time.Duration // Deadline for unary calls whose context has none; zero disables it
+	AllowInsecureTransport bool          // Should be false in production
 }
 
 // DefaultGrpcClientConfig provides sensible defaults for gRPC client configuration
@@ -31,6 +32,7 @@ func DefaultGrpcClientConfig(serviceName string, serviceHost string, servicePort
 		DialTimeout:            5 * time.Second,
 		KeepAlive:              30 * time.Second,
 		KeepAliveTimeout:       10 * time.Second,
+		CallTimeout:            30 * time.Second,
 		AllowInsecureTransport: true, // Defaulting to true for easier local dev/testing
 	}
 }
@@ -42,17 +44,20 @@ type BaseGrpcClient struct {
 	Logger logger.Logger
 }
 
-// NewBaseGrpcClient creates a new gRPC client connection
-func NewBaseGrpcClient(logger logger.Logger, config *GrpcClientConfig) (*BaseGrpcClient, error) {
+// NewBaseGrpcClient creates a new gRPC client connection. Calls on it forward the metadata and
+// deadline of the incoming request (see PropagationUnaryClientInterceptor).
+// Extra dial options (e.g. interceptors) are appended to the defaults and run after propagation.
+func NewBaseGrpcClient(logger logger.Logger, config *GrpcClientConfig, extraOpts ...grpc.DialOption) (*BaseGrpcClient, error) {
 	dialOptions := []grpc.DialOption{
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                config.KeepAlive,
 			Timeout:             config.KeepAliveTimeout,
 			PermitWithoutStream: true,
 		}),
-		// Add interceptors if needed (e.g., logging, tracing)
-		// grpc.WithUnaryInterceptor(...),
+		grpc.WithChainUnaryInterceptor(PropagationUnaryClientInterceptor(config.CallTimeout)),
+		grpc.WithChainStreamInterceptor(PropagationStreamClientInterceptor()),
 	}
+	dialOptions = append(dialOptions, extraOpts...)
 
 	// Handle transport security
 	if config.AllowInsecureTransport {
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// GzipUnaryInterceptor compresses responses with gzip for clients that advertise support for it.
+// Importing this package registers the gzip codec, so gzip-compressed requests are always accepted.
+func GzipUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		setGzipCompressor(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// GzipStreamInterceptor is the streaming counterpart of GzipUnaryInterceptor
+func GzipStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		setGzipCompressor(ss.Context())
+		return handler(srv, ss)
+	}
+}
+
+// setGzipCompressor switches the response compressor to gzip when the client accepts it
+func setGzipCompressor(ctx context.Context) {
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(supported, gzip.Name) {
+		return
+	}
+	_ = grpc.SetSendCompressor(ctx, gzip.Name)
+}
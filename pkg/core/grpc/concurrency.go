@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConcurrencyLimiter bounds the calls a server handles at once. A call beyond the limit waits in a
+// queue for a free slot; it is rejected with ResourceExhausted when the queue is full or the wait
+// exceeds the queue timeout, so a saturated server sheds load instead of slowing every call down.
+type ConcurrencyLimiter struct {
+	slots        chan struct{}
+	maxQueued    int64
+	queueTimeout time.Duration
+	queued       atomic.Int64
+}
+
+// NewConcurrencyLimiter creates a limiter allowing maxInFlight calls at once and up to maxQueued
+// waiting calls, each for at most queueTimeout. It returns nil (no limit) when maxInFlight <= 0.
+func NewConcurrencyLimiter(maxInFlight, maxQueued int, queueTimeout time.Duration) *ConcurrencyLimiter {
+	if maxInFlight <= 0 {
+		return nil
+	}
+	if queueTimeout <= 0 {
+		maxQueued = 0
+	}
+	return &ConcurrencyLimiter{
+		slots:        make(chan struct{}, maxInFlight),
+		maxQueued:    int64(max(maxQueued, 0)),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// Acquire takes a slot, waiting in the queue if none is free, and returns the function releasing it
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	if l.queued.Add(1) > l.maxQueued {
+		l.queued.Add(-1)
+		return nil, status.Error(codes.ResourceExhausted, "server is at capacity, retry later")
+	}
+	defer l.queued.Add(-1)
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-timer.C:
+		return nil, status.Error(codes.ResourceExhausted, "server is at capacity, retry later")
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (l *ConcurrencyLimiter) release() {
+	<-l.slots
+}
+
+// InFlight returns the number of calls holding a slot
+func (l *ConcurrencyLimiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Queued returns the number of calls waiting for a slot
+func (l *ConcurrencyLimiter) Queued() int {
+	return int(l.queued.Load())
+}
+
+// isInfrastructureMethod reports whether a full gRPC method name belongs to the grpc.* services
+// (health, reflection), LogLevelServiceName or DiagnosticsServiceName
+func isInfrastructureMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.") || strings.HasPrefix(fullMethod, "/"+LogLevelServiceName+"/") ||
+		strings.HasPrefix(fullMethod, "/"+DiagnosticsServiceName+"/")
+}
+
+// ConcurrencyUnaryInterceptor limits unary calls with limiter. Infrastructure methods such as health
+// checks are never limited, so a busy server is not reported unhealthy.
+func ConcurrencyUnaryInterceptor(limiter *ConcurrencyLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if limiter == nil || isInfrastructureMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		release, err := limiter.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// ConcurrencyStreamInterceptor is ConcurrencyUnaryInterceptor for streaming methods. A stream holds
+// its slot until it ends.
+func ConcurrencyStreamInterceptor(limiter *ConcurrencyLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if limiter == nil || isInfrastructureMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		release, err := limiter.Acquire(ss.Context())
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
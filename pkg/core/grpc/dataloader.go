@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"golang-microservices-boilerplate/pkg/core/dataloader"
+)
+
+// DataLoaderUnaryInterceptor gives every request its own dataloader registry, so loaders obtained
+// with dataloader.For batch and cache for the lifetime of the request only
+func DataLoaderUnaryInterceptor(config dataloader.Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(dataloader.WithLoaders(ctx, config), req)
+	}
+}
+
+// DataLoaderStreamInterceptor is the streaming counterpart of DataLoaderUnaryInterceptor
+func DataLoaderStreamInterceptor(config dataloader.Config) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &actorServerStream{ServerStream: ss, ctx: dataloader.WithLoaders(ss.Context(), config)})
+	}
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"golang-microservices-boilerplate/pkg/core/diagnostics"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// DiagnosticsServiceName is the gRPC service every BaseGrpcServer exposes to take goroutine and
+// heap dumps of a running instance. Like LogLevelServiceName it has no .proto:
+//
+//	DumpGoroutines(google.protobuf.Empty) returns (google.protobuf.BytesValue) // text stacks
+//	DumpHeap(google.protobuf.Empty) returns (google.protobuf.BytesValue)       // pprof heap profile
+const DiagnosticsServiceName = "core.DiagnosticsService"
+
+// maxDumpSize bounds the dumps DumpGoroutines and DumpHeap accept, above the default 4MB limit
+const maxDumpSize = 64 << 20
+
+// diagnosticsService is the handler type of DiagnosticsServiceName
+type diagnosticsService interface {
+	dump(ctx context.Context, kind string) (*wrapperspb.BytesValue, error)
+}
+
+// diagnosticsServer takes dumps for callers with one of the roles in DIAGNOSTICS_ROLES
+type diagnosticsServer struct {
+	logger logger.Logger
+	roles  []string
+}
+
+func newDiagnosticsServer(log logger.Logger) *diagnosticsServer {
+	return &diagnosticsServer{logger: log, roles: diagnostics.LoadConfigFromEnv().Roles}
+}
+
+func (s *diagnosticsServer) dump(ctx context.Context, kind string) (*wrapperspb.BytesValue, error) {
+	actor, ok := usecase.ActorFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !slices.Contains(s.roles, actor.Role) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if kind == "heap" {
+		err = diagnostics.WriteHeapDump(&buf)
+	} else {
+		err = diagnostics.WriteGoroutineDump(&buf)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to write %s dump: %v", kind, err)
+	}
+	logger.FromContext(ctx, s.logger).Info("Diagnostics dump taken", "kind", kind, "bytes", buf.Len())
+	return wrapperspb.Bytes(buf.Bytes()), nil
+}
+
+// diagnosticsMethod describes a dump method of DiagnosticsServiceName
+func diagnosticsMethod(name, kind string) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(emptypb.Empty)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(diagnosticsService).dump(ctx, kind)
+			}
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + DiagnosticsServiceName + "/" + name}, handler)
+		},
+	}
+}
+
+var diagnosticsServiceDesc = grpc.ServiceDesc{
+	ServiceName: DiagnosticsServiceName,
+	HandlerType: (*diagnosticsService)(nil),
+	Methods: []grpc.MethodDesc{
+		diagnosticsMethod("DumpGoroutines", "goroutines"),
+		diagnosticsMethod("DumpHeap", "heap"),
+	},
+	Metadata: "pkg/core/grpc/diagnostics.go",
+}
+
+// DumpGoroutines returns the goroutine stacks of the service instance behind conn. The caller's
+// authorization must be in the outgoing metadata of ctx.
+func DumpGoroutines(ctx context.Context, conn grpc.ClientConnInterface) ([]byte, error) {
+	return invokeDump(ctx, conn, "DumpGoroutines")
+}
+
+// DumpHeap returns a heap profile of the service instance behind conn, for `go tool pprof`
+func DumpHeap(ctx context.Context, conn grpc.ClientConnInterface) ([]byte, error) {
+	return invokeDump(ctx, conn, "DumpHeap")
+}
+
+func invokeDump(ctx context.Context, conn grpc.ClientConnInterface, method string) ([]byte, error) {
+	out := new(wrapperspb.BytesValue)
+	if err := conn.Invoke(ctx, "/"+DiagnosticsServiceName+"/"+method, &emptypb.Empty{}, out, grpc.MaxCallRecvMsgSize(maxDumpSize)); err != nil {
+		return nil, err
+	}
+	return out.GetValue(), nil
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// DryRunMetadataKey is the request metadata (forwarded by the gateway from the X-Dry-Run header)
+// that asks for a request's writes to be rolled back. Responses to such requests carry it too.
+const DryRunMetadataKey = "x-dry-run"
+
+// DryRunUnaryInterceptor flags requests carrying "x-dry-run: true" with usecase.WithDryRun and
+// echoes the flag in the response header, so clients can tell that nothing was saved.
+func DryRunUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if firstMetadataValueFromContext(ctx, DryRunMetadataKey) == "true" {
+			ctx = usecase.WithDryRun(ctx)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(DryRunMetadataKey, "true"))
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+
+	"golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// ExplainMetadataKey is the request metadata (forwarded by the gateway from the X-Debug-Explain
+// header) that asks for the plans of the request's list queries to be logged
+const ExplainMetadataKey = "x-debug-explain"
+
+// ExplainUnaryInterceptor flags requests carrying "x-debug-explain: true" with repository.WithExplain
+// when config.AllowRequests is set and the caller's role is in config.RequestRoles. Other requests
+// are served without the flag; the metadata is never an error.
+func ExplainUnaryInterceptor(config repository.ExplainConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if config.AllowRequests && firstMetadataValueFromContext(ctx, ExplainMetadataKey) == "true" {
+			if actor, ok := usecase.ActorFromContext(ctx); ok && slices.Contains(config.RequestRoles, actor.Role) {
+				ctx = repository.WithExplain(ctx)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,278 @@
+package grpc
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Idempotency classifies what repeating a call does, and so whether it may be retried without the
+// caller asking for it
+type Idempotency int
+
+const (
+	// Unsafe calls may have a side effect every time they run (e.g. creating a record or issuing
+	// tokens). They are never retried automatically.
+	Unsafe Idempotency = iota
+	// Idempotent calls have the same effect whether they run once or several times (e.g. setting
+	// fields or deleting by ID)
+	Idempotent
+	// NoSideEffects calls only read
+	NoSideEffects
+)
+
+// String returns the name of the level as written in proto files
+func (i Idempotency) String() string {
+	switch i {
+	case Idempotent:
+		return "IDEMPOTENT"
+	case NoSideEffects:
+		return "NO_SIDE_EFFECTS"
+	default:
+		return "UNSAFE"
+	}
+}
+
+// RetrySafe reports whether a call may be sent again after a failure whose outcome is unknown
+func (i Idempotency) RetrySafe() bool {
+	return i == Idempotent || i == NoSideEffects
+}
+
+var (
+	idempotencyMu        sync.RWMutex
+	idempotencyOverrides = map[string]Idempotency{}
+	idempotencyCache     sync.Map // Full method -> Idempotency
+
+	httpRoutesOnce sync.Once
+	httpRoutes     []httpRoute
+)
+
+// SetMethodIdempotency overrides the classification of a method, keyed by full method name
+// ("/userservice.UserService/Login"), e.g. for services whose proto files cannot be changed
+func SetMethodIdempotency(fullMethod string, level Idempotency) {
+	idempotencyMu.Lock()
+	defer idempotencyMu.Unlock()
+	idempotencyOverrides[fullMethod] = level
+	idempotencyCache.Delete(fullMethod)
+}
+
+// MethodIdempotency classifies a method by its full name ("/userservice.UserService/Update"). The
+// first of these that applies wins:
+//   - an override set with SetMethodIdempotency
+//   - the method's idempotency_level option, e.g. option idempotency_level = IDEMPOTENT;
+//   - the verb of its google.api.http binding: GET is NoSideEffects, PUT and DELETE are Idempotent
+//
+// Anything else, including methods whose descriptor is not linked into the binary, is Unsafe.
+func MethodIdempotency(fullMethod string) Idempotency {
+	if level, ok := idempotencyCache.Load(fullMethod); ok {
+		return level.(Idempotency)
+	}
+	idempotencyMu.RLock()
+	level, ok := idempotencyOverrides[fullMethod]
+	idempotencyMu.RUnlock()
+	if !ok {
+		level = Unsafe
+		if method := findMethod(fullMethod); method != nil {
+			level = methodDescriptorIdempotency(method)
+		}
+	}
+	idempotencyCache.Store(fullMethod, level)
+	return level
+}
+
+// findMethod looks up the descriptor of a full method name in the global registry
+func findMethod(fullMethod string) protoreflect.MethodDescriptor {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	return sd.Methods().ByName(protoreflect.Name(method))
+}
+
+// methodDescriptorIdempotency reads the idempotency_level option, falling back to the HTTP verb
+func methodDescriptorIdempotency(method protoreflect.MethodDescriptor) Idempotency {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return Unsafe
+	}
+	switch opts.GetIdempotencyLevel() {
+	case descriptorpb.MethodOptions_NO_SIDE_EFFECTS:
+		return NoSideEffects
+	case descriptorpb.MethodOptions_IDEMPOTENT:
+		return Idempotent
+	}
+	if rule, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule); ok && rule != nil {
+		return httpVerbIdempotency(httpRuleMethod(rule))
+	}
+	return Unsafe
+}
+
+// httpVerbIdempotency classifies an HTTP method by its RFC 9110 semantics
+func httpVerbIdempotency(method string) Idempotency {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return NoSideEffects
+	case http.MethodPut, http.MethodDelete:
+		return Idempotent
+	default:
+		return Unsafe
+	}
+}
+
+// httpRoute is an HTTP binding of a gRPC method
+type httpRoute struct {
+	method     string   // HTTP method
+	segments   []string // Path template segments; "" matches any single segment
+	literals   int      // Number of literal segments, to prefer /users/stats over /users/{id}
+	fullMethod string
+}
+
+// RouteIdempotency classifies an HTTP request by the gRPC method its google.api.http binding maps
+// it to, as the gateway forwards it. Requests that match no binding (the gateway's own endpoints)
+// are classified by the HTTP method alone.
+func RouteIdempotency(httpMethod, path string) Idempotency {
+	if fullMethod, ok := MethodForRoute(httpMethod, path); ok {
+		return MethodIdempotency(fullMethod)
+	}
+	return httpVerbIdempotency(httpMethod)
+}
+
+// MethodForRoute returns the full name of the gRPC method bound to an HTTP method and path, such as
+// "/userservice.UserService/GetByID" for GET /api/v1/users/42. The most specific binding wins.
+func MethodForRoute(httpMethod, path string) (string, bool) {
+	httpRoutesOnce.Do(loadHTTPRoutes)
+	httpMethod = strings.ToUpper(httpMethod)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var best *httpRoute
+	for i := range httpRoutes {
+		route := &httpRoutes[i]
+		if route.method == httpMethod && route.matches(parts) && (best == nil || route.literals > best.literals) {
+			best = route
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	return best.fullMethod, true
+}
+
+// matches reports whether the path segments fit the route template
+func (r *httpRoute) matches(parts []string) bool {
+	if len(parts) != len(r.segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if segment == "" {
+			if parts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// loadHTTPRoutes collects the HTTP bindings of every service in the global registry
+func loadHTTPRoutes() {
+	protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				opts, ok := method.Options().(*descriptorpb.MethodOptions)
+				if !ok || opts == nil {
+					continue
+				}
+				rule, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+				if !ok || rule == nil {
+					continue
+				}
+				fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+				for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+					if route, ok := newHTTPRoute(r, fullMethod); ok {
+						httpRoutes = append(httpRoutes, route)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// newHTTPRoute parses the path template of a binding; variables such as {id} or {name=*} match
+// one segment
+func newHTTPRoute(rule *annotations.HttpRule, fullMethod string) (httpRoute, bool) {
+	method := httpRuleMethod(rule)
+	template := httpRulePath(rule)
+	if method == "" || template == "" {
+		return httpRoute{}, false
+	}
+	route := httpRoute{method: method, fullMethod: fullMethod}
+	for _, segment := range strings.Split(strings.Trim(template, "/"), "/") {
+		if strings.HasPrefix(segment, "{") || segment == "*" {
+			route.segments = append(route.segments, "")
+			continue
+		}
+		route.segments = append(route.segments, segment)
+		route.literals++
+	}
+	return route, true
+}
+
+// httpRuleMethod returns the HTTP method of a binding
+func httpRuleMethod(rule *annotations.HttpRule) string {
+	switch rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet
+	case *annotations.HttpRule_Put:
+		return http.MethodPut
+	case *annotations.HttpRule_Post:
+		return http.MethodPost
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(rule.GetCustom().GetKind())
+	default:
+		return ""
+	}
+}
+
+// httpRulePath returns the path template of a binding
+func httpRulePath(rule *annotations.HttpRule) string {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return pattern.Get
+	case *annotations.HttpRule_Put:
+		return pattern.Put
+	case *annotations.HttpRule_Post:
+		return pattern.Post
+	case *annotations.HttpRule_Delete:
+		return pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetPath()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"sort"
+
+	"google.golang.org/grpc"
+)
+
+// Interceptor priorities. Interceptors run in ascending priority order (the lowest is outermost);
+// interceptors with equal priority run in the order they were registered.
+const (
+	PriorityTags       = 100 // ctxtags: request-scoped tags for logging
+	PriorityValidation = 200 // Request Validate() methods
+	PriorityRecovery   = 300 // Panic recovery
+	PriorityActor      = 400 // Caller identity from the forwarded access token
+	PriorityDefault    = 500 // Service interceptors without an explicit priority
+)
+
+// prioritized is an interceptor with its position in the chain
+type prioritized[T any] struct {
+	priority    int
+	interceptor T
+}
+
+// serverOptions collects ServerOption values
+type serverOptions struct {
+	unary      []prioritized[grpc.UnaryServerInterceptor]
+	stream     []prioritized[grpc.StreamServerInterceptor]
+	grpcServer []grpc.ServerOption
+}
+
+// ServerOption customizes the BaseGrpcServer
+type ServerOption func(*serverOptions)
+
+// WithUnaryInterceptors adds unary interceptors at PriorityDefault, after the built-in chain
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return WithUnaryInterceptorsAt(PriorityDefault, interceptors...)
+}
+
+// WithUnaryInterceptorsAt adds unary interceptors at the given priority, e.g.
+// PriorityRecovery+1 to run inside panic recovery but before the actor is resolved
+func WithUnaryInterceptorsAt(priority int, interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		for _, i := range interceptors {
+			o.unary = append(o.unary, prioritized[grpc.UnaryServerInterceptor]{priority, i})
+		}
+	}
+}
+
+// WithStreamInterceptors adds stream interceptors at PriorityDefault, after the built-in chain
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return WithStreamInterceptorsAt(PriorityDefault, interceptors...)
+}
+
+// WithStreamInterceptorsAt adds stream interceptors at the given priority
+func WithStreamInterceptorsAt(priority int, interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		for _, i := range interceptors {
+			o.stream = append(o.stream, prioritized[grpc.StreamServerInterceptor]{priority, i})
+		}
+	}
+}
+
+// WithGrpcServerOptions passes additional options to grpc.NewServer
+func WithGrpcServerOptions(opts ...grpc.ServerOption) ServerOption {
+	return func(o *serverOptions) {
+		o.grpcServer = append(o.grpcServer, opts...)
+	}
+}
+
+// ordered returns the interceptors sorted by priority, keeping registration order for ties
+func ordered[T any](items []prioritized[T]) []T {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].priority < items[j].priority })
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[i] = item.interceptor
+	}
+	return out
+}
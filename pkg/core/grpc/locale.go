@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"golang-microservices-boilerplate/pkg/core/i18n"
+)
+
+// Metadata keys carrying the caller's locale. The gateway sets them from Accept-Language and
+// X-Timezone, replacing whatever the client sent, and they travel on to downstream calls.
+const (
+	LocaleMetadataKey   = "x-locale"
+	TimezoneMetadataKey = "x-timezone"
+)
+
+// LocaleUnaryInterceptor stores the caller's locale in the context (see i18n.FromContext). Values
+// that do not name a catalog language or a known time zone fall back to the defaults.
+func LocaleUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(contextWithLocale(ctx), req)
+	}
+}
+
+// LocaleStreamInterceptor is the streaming counterpart of LocaleUnaryInterceptor
+func LocaleStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &actorServerStream{ServerStream: ss, ctx: contextWithLocale(ss.Context())})
+	}
+}
+
+func contextWithLocale(ctx context.Context) context.Context {
+	language := firstMetadataValueFromContext(ctx, LocaleMetadataKey)
+	timezone := firstMetadataValueFromContext(ctx, TimezoneMetadataKey)
+	if language == "" && timezone == "" {
+		return ctx
+	}
+	locale := i18n.Locale{Language: i18n.MatchLanguage(language), Location: time.UTC}
+	if loc, err := i18n.ParseTimezone(timezone); err == nil {
+		locale.Location = loc
+	}
+	return i18n.WithLocale(ctx, locale)
+}
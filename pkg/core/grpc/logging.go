@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// RequestIDMetadataKey is the metadata key carrying the request ID (see RequestIDMetadataKey)
+const RequestIDMetadataKey = "x-request-id"
+
+// LoggerUnaryInterceptor stores a logger with the request_id, user_id and method of the call in the
+// context (see logger.FromContext). The request ID comes from the x-request-id metadata forwarded by
+// the gateway; calls without one get a new ID. A W3C traceparent adds trace_id and span_id. It must
+// run after the actor is resolved.
+func LoggerUnaryInterceptor(base logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(contextWithLogger(ctx, base, info.FullMethod), req)
+	}
+}
+
+// LoggerStreamInterceptor is the streaming counterpart of LoggerUnaryInterceptor
+func LoggerStreamInterceptor(base logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &actorServerStream{ServerStream: ss, ctx: contextWithLogger(ss.Context(), base, info.FullMethod)})
+	}
+}
+
+func contextWithLogger(ctx context.Context, base logger.Logger, method string) context.Context {
+	requestID := firstMetadataValueFromContext(ctx, RequestIDMetadataKey)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	args := []interface{}{"request_id", requestID, "method", method}
+	args = append(args, logger.TraceFields(firstMetadataValueFromContext(ctx, "traceparent"))...)
+	if actor, ok := usecase.ActorFromContext(ctx); ok && actor.ID != "" {
+		args = append(args, "user_id", actor.ID)
+	}
+	ctx = logger.WithRequestID(ctx, requestID)
+	return logger.WithContext(ctx, base.With(args...))
+}
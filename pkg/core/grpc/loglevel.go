@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// LogLevelServiceName is the gRPC service every BaseGrpcServer exposes to read and change its log
+// level at runtime. It has no .proto: requests and responses are well-known wrapper types.
+//
+//	GetLogLevel(google.protobuf.Empty) returns (google.protobuf.StringValue)
+//	SetLogLevel(google.protobuf.StringValue) returns (google.protobuf.StringValue)
+const LogLevelServiceName = "core.LogLevelService"
+
+// logLevelService is the handler type of LogLevelServiceName
+type logLevelService interface {
+	getLogLevel(ctx context.Context) (*wrapperspb.StringValue, error)
+	setLogLevel(ctx context.Context, level string) (*wrapperspb.StringValue, error)
+}
+
+// logLevelServer changes the level of the server's logger; callers need one of the roles in
+// LOG_LEVEL_ADMIN_ROLES (comma separated, default "admin")
+type logLevelServer struct {
+	logger logger.Logger
+	roles  []string
+}
+
+func newLogLevelServer(log logger.Logger) *logLevelServer {
+	var roles []string
+	for _, role := range strings.Split(utils.GetEnv("LOG_LEVEL_ADMIN_ROLES", "admin"), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return &logLevelServer{logger: log, roles: roles}
+}
+
+// controller returns the logger's level controller, or Unimplemented for loggers without one
+func (s *logLevelServer) controller(ctx context.Context) (logger.LevelController, error) {
+	actor, ok := usecase.ActorFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !slices.Contains(s.roles, actor.Role) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	controller, ok := s.logger.(logger.LevelController)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "log level cannot be changed at runtime")
+	}
+	return controller, nil
+}
+
+func (s *logLevelServer) getLogLevel(ctx context.Context) (*wrapperspb.StringValue, error) {
+	controller, err := s.controller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.String(string(controller.Level())), nil
+}
+
+func (s *logLevelServer) setLogLevel(ctx context.Context, value string) (*wrapperspb.StringValue, error) {
+	controller, err := s.controller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	level, err := logger.ParseLogLevel(value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	previous := controller.Level()
+	if err := controller.SetLevel(level); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.FromContext(ctx, s.logger).Warn("Log level changed", "from", previous, "to", level)
+	return wrapperspb.String(string(level)), nil
+}
+
+var logLevelServiceDesc = grpc.ServiceDesc{
+	ServiceName: LogLevelServiceName,
+	HandlerType: (*logLevelService)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogLevel",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(logLevelService).getLogLevel(ctx)
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + LogLevelServiceName + "/GetLogLevel"}, handler)
+			},
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(wrapperspb.StringValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(logLevelService).setLogLevel(ctx, req.(*wrapperspb.StringValue).GetValue())
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + LogLevelServiceName + "/SetLogLevel"}, handler)
+			},
+		},
+	},
+	Metadata: "pkg/core/grpc/loglevel.go",
+}
+
+// GetLogLevel returns the log level of the service behind conn. The caller's authorization must be
+// in the outgoing metadata of ctx.
+func GetLogLevel(ctx context.Context, conn grpc.ClientConnInterface) (string, error) {
+	out := new(wrapperspb.StringValue)
+	if err := conn.Invoke(ctx, "/"+LogLevelServiceName+"/GetLogLevel", &emptypb.Empty{}, out); err != nil {
+		return "", err
+	}
+	return out.GetValue(), nil
+}
+
+// SetLogLevel changes the log level of the service behind conn and returns the new level
+func SetLogLevel(ctx context.Context, conn grpc.ClientConnInterface, level string) (string, error) {
+	out := new(wrapperspb.StringValue)
+	if err := conn.Invoke(ctx, "/"+LogLevelServiceName+"/SetLogLevel", wrapperspb.String(level), out); err != nil {
+		return "", err
+	}
+	return out.GetValue(), nil
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/maintenance"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// readOnlyMethods lists full method names ("/pkg.Service/Method") that count as read-only although
+// their proto definition does not say so; set them with MAINTENANCE_READ_ONLY_METHODS (comma separated)
+var readOnlyMethods = methodSet(utils.GetEnv("MAINTENANCE_READ_ONLY_METHODS", ""))
+
+// maintenanceAllowedMethods lists the methods served during maintenance even though they write, so that
+// users can still sign in; override them with MAINTENANCE_ALLOWED_METHODS (comma separated)
+var maintenanceAllowedMethods = methodSet(utils.GetEnv("MAINTENANCE_ALLOWED_METHODS",
+	"/userservice.UserService/Login,/userservice.UserService/Refresh,/userservice.UserService/Introspect,/userservice.UserService/Logout"))
+
+// methodSet parses a comma-separated list of full method names
+func methodSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, method := range strings.Split(list, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			set[method] = true
+		}
+	}
+	return set
+}
+
+// IsReadOnlyMethod reports whether a full gRPC method name ("/pkg.Service/GetByID") does not write:
+// its proto definition declares idempotency_level = NO_SIDE_EFFECTS or binds it to HTTP GET (see
+// MethodIdempotency), or it is listed in MAINTENANCE_READ_ONLY_METHODS. Methods of the grpc.* services
+// (health, reflection), LogLevelServiceName and DiagnosticsServiceName always count as read-only.
+func IsReadOnlyMethod(fullMethod string) bool {
+	if isInfrastructureMethod(fullMethod) || readOnlyMethods[fullMethod] {
+		return true
+	}
+	return MethodIdempotency(fullMethod) == NoSideEffects
+}
+
+// servedInMaintenance reports whether a method is served while maintenance mode is on
+func servedInMaintenance(fullMethod string) bool {
+	return maintenanceAllowedMethods[fullMethod] || IsReadOnlyMethod(fullMethod)
+}
+
+// maintenanceError is returned for mutating methods while maintenance mode is on
+func maintenanceError(state maintenance.State) error {
+	return status.Error(codes.Unavailable, state.Message)
+}
+
+// MaintenanceUnaryInterceptor rejects mutating methods with Unavailable while maintenance mode is on,
+// except for those listed in MAINTENANCE_ALLOWED_METHODS (by default the authentication methods)
+func MaintenanceUnaryInterceptor(sw *maintenance.Switch) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !servedInMaintenance(info.FullMethod) {
+			if state := sw.State(ctx); state.Enabled {
+				return nil, maintenanceError(state)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// MaintenanceStreamInterceptor is MaintenanceUnaryInterceptor for streaming methods
+func MaintenanceStreamInterceptor(sw *maintenance.Switch) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !servedInMaintenance(info.FullMethod) {
+			if state := sw.State(ss.Context()); state.Enabled {
+				return maintenanceError(state)
+			}
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ClientInfo describes the caller of an incoming gRPC request.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// trustedProxies lists the networks of the proxies in front of the service, such as the API gateway
+// and load balancers; set them with GRPC_TRUSTED_PROXIES (comma-separated CIDRs or IPs). Invalid
+// entries are ignored, which leaves those addresses untrusted.
+var trustedProxies = parsePrefixes(utils.GetEnv("GRPC_TRUSTED_PROXIES",
+	"127.0.0.0/8,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,::1/128,fc00::/7"))
+
+// parsePrefixes parses a comma-separated list of CIDRs, accepting bare IPs as single hosts
+func parsePrefixes(list string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(v); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(v); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return prefixes
+}
+
+// isTrustedProxy reports whether addr belongs to one of the trusted proxy networks
+func isTrustedProxy(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientInfoFromContext extracts the caller IP and User-Agent from incoming gRPC metadata.
+// Requests proxied by the API gateway carry X-Forwarded-For and grpcgateway-user-agent;
+// direct gRPC calls fall back to the peer address and the native user-agent header.
+//
+// X-Forwarded-For and X-Real-IP are only honoured when the peer is a trusted proxy (see
+// GRPC_TRUSTED_PROXIES), since any caller can send them. X-Forwarded-For is walked from the right,
+// where each proxy appends the address it received the request from, and the first entry that is
+// not a trusted proxy is the client; entries to its left may be forged.
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info := ClientInfo{}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var remote netip.Addr
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		info.IPAddress = host
+		remote, _ = netip.ParseAddr(host)
+	}
+	if remote.IsValid() && isTrustedProxy(remote) {
+		if ip := forwardedClient(md); ip != "" {
+			info.IPAddress = ip
+		}
+	}
+
+	if v := firstMetadataValue(md, "grpcgateway-user-agent"); v != "" {
+		info.UserAgent = v
+	} else {
+		info.UserAgent = firstMetadataValue(md, "user-agent")
+	}
+
+	return info
+}
+
+// forwardedClient returns the right-most X-Forwarded-For entry that is not a trusted proxy, or
+// X-Real-IP without X-Forwarded-For; "" when neither names a client
+func forwardedClient(md metadata.MD) string {
+	var forwarded []string
+	for _, v := range md.Get("x-forwarded-for") {
+		forwarded = append(forwarded, strings.Split(v, ",")...)
+	}
+	if len(forwarded) == 0 {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(firstMetadataValue(md, "x-real-ip"))); err == nil {
+			return addr.Unmap().String()
+		}
+		return ""
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			return "" // A malformed hop cannot be checked, so nothing to its left is trusted
+		}
+		if !isTrustedProxy(addr) {
+			return addr.Unmap().String()
+		}
+	}
+	return ""
+}
+
+// firstMetadataValueFromContext returns the first incoming metadata value for key, or "" if absent
+func firstMetadataValueFromContext(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return firstMetadataValue(md, key)
+}
+
+// firstMetadataValue returns the first value for key, or "" if absent
+func firstMetadataValue(md metadata.MD, key string) string {
+	if md == nil {
+		return ""
+	}
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// PermissionUnaryInterceptor requires the permission mapped to a method, keyed by full method name
+// ("/user.UserService/Delete"), from the caller's role. Methods without an entry are not checked.
+// Install it after the actor interceptor; calls to a mapped method without an actor are rejected.
+func PermissionUnaryInterceptor(rules map[string]permissions.Permission) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkPermission(ctx, rules, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PermissionStreamInterceptor is the streaming counterpart of PermissionUnaryInterceptor
+func PermissionStreamInterceptor(rules map[string]permissions.Permission) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkPermission(ss.Context(), rules, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkPermission(ctx context.Context, rules map[string]permissions.Permission, method string) error {
+	required, ok := rules[method]
+	if !ok {
+		return nil
+	}
+	actor, ok := usecase.ActorFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !actor.HasPermission(required) {
+		return status.Errorf(codes.PermissionDenied, "missing permission %s", required)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// UserIDMetadataKey is the metadata key carrying the ID of the acting user between services.
+// The gateway drops it from client requests, so it is only set by trusted callers.
+const UserIDMetadataKey = "x-user-id"
+
+// PropagatedMetadataKeys are copied from the incoming request to outgoing calls: credentials,
+// correlation and W3C trace context, the caller's locale, and the original client's address and user agent
+var PropagatedMetadataKeys = []string{
+	"authorization",
+	RequestIDMetadataKey,
+	UserIDMetadataKey,
+	LocaleMetadataKey,
+	TimezoneMetadataKey,
+	"traceparent",
+	"tracestate",
+	"baggage",
+	"x-forwarded-for",
+	"grpcgateway-user-agent",
+}
+
+// PropagationUnaryClientInterceptor forwards PropagatedMetadataKeys from the incoming server
+// context to outgoing calls, so a call made while handling a request keeps its caller's
+// credentials, request ID and trace. Keys the caller set on the outgoing context win. The request
+// ID falls back to the one LoggerUnaryInterceptor assigned, or a new ID, and x-user-id to the
+// actor in the context. The deadline travels with ctx; calls without one get defaultTimeout
+// (zero leaves them unbounded).
+func PropagationUnaryClientInterceptor(defaultTimeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && defaultTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+			defer cancel()
+		}
+		return invoker(PropagateMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// PropagationStreamClientInterceptor is the streaming counterpart of PropagationUnaryClientInterceptor.
+// Streams are often long-lived, so no default timeout is applied; the incoming deadline still is.
+func PropagationStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(PropagateMetadata(ctx), desc, cc, method, opts...)
+	}
+}
+
+// PropagateMetadata returns ctx with PropagatedMetadataKeys copied into its outgoing metadata,
+// for calls made without the interceptors (e.g. through a connection the caller dialed itself)
+func PropagateMetadata(ctx context.Context) context.Context {
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	outgoing, _ := metadata.FromOutgoingContext(ctx)
+	outgoing = outgoing.Copy()
+
+	for _, key := range PropagatedMetadataKeys {
+		if len(outgoing.Get(key)) > 0 {
+			continue
+		}
+		if vals := incoming.Get(key); len(vals) > 0 {
+			outgoing.Set(key, vals...)
+		}
+	}
+	if len(outgoing.Get(RequestIDMetadataKey)) == 0 {
+		requestID := logger.RequestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		outgoing.Set(RequestIDMetadataKey, requestID)
+	}
+	if len(outgoing.Get(UserIDMetadataKey)) == 0 {
+		if actor, ok := usecase.ActorFromContext(ctx); ok && actor.ID != "" {
+			outgoing.Set(UserIDMetadataKey, actor.ID)
+		}
+	}
+
+	return metadata.NewOutgoingContext(ctx, outgoing)
+}
@@ -1,18 +1,25 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"net"
-	"net/http"
 	"time"
 
+	"golang-microservices-boilerplate/pkg/core/dataloader"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/maintenance"
+	"golang-microservices-boilerplate/pkg/core/watchdog"
+	"golang-microservices-boilerplate/pkg/middleware"
 	"golang-microservices-boilerplate/pkg/utils"
 
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	grpc_validator "github.com/grpc-ecosystem/go-grpc-middleware/validator"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
@@ -27,6 +34,23 @@ type GrpcServerConfig struct {
 	MaxConnectionAgeGrace time.Duration
 	KeepAliveTime         time.Duration
 	KeepAliveTimeout      time.Duration
+	MaxRecvMsgSize        int  // Largest request accepted, in bytes
+	MaxSendMsgSize        int  // Largest response sent, in bytes
+	Gzip                  bool // Compress responses with gzip when the client accepts it
+	GzipLevel             int  // gzip level 1-9; 0 keeps the default
+	// MaxConcurrentStreams bounds the concurrent calls per client connection; 0 keeps the gRPC default
+	MaxConcurrentStreams uint32
+	// MaxInFlight and MaxInFlightStreams bound the unary and streaming calls the server handles at
+	// once; 0 means unlimited. Calls beyond them wait for up to QueueTimeout in a queue of at most
+	// MaxQueued calls (per kind) and then fail with ResourceExhausted.
+	MaxInFlight        int
+	MaxInFlightStreams int
+	MaxQueued          int
+	QueueTimeout       time.Duration
+	// Maintenance rejects mutating methods while it is on; nil uses maintenance.NewSwitchFromEnv
+	Maintenance *maintenance.Switch
+	// Watchdog flags slow calls; nil uses watchdog.NewFromEnv
+	Watchdog *watchdog.Watchdog
 }
 
 // DefaultGrpcServerConfig provides sensible defaults for gRPC server configuration
@@ -39,6 +63,15 @@ func DefaultGrpcServerConfig() *GrpcServerConfig {
 		MaxConnectionAgeGrace: 5 * time.Second,
 		KeepAliveTime:         5 * time.Minute,
 		KeepAliveTimeout:      20 * time.Second,
+		MaxRecvMsgSize:        utils.GetEnvAsInt("GRPC_MAX_RECV_MSG_SIZE", 4<<20),
+		MaxSendMsgSize:        utils.GetEnvAsInt("GRPC_MAX_SEND_MSG_SIZE", math.MaxInt32),
+		Gzip:                  utils.GetEnv("GRPC_GZIP", "false") == "true",
+		GzipLevel:             utils.GetEnvAsInt("GRPC_GZIP_LEVEL", 0),
+		MaxConcurrentStreams:  uint32(max(utils.GetEnvAsInt("GRPC_MAX_CONCURRENT_STREAMS", 0), 0)),
+		MaxInFlight:           utils.GetEnvAsInt("GRPC_MAX_IN_FLIGHT", 0),
+		MaxInFlightStreams:    utils.GetEnvAsInt("GRPC_MAX_IN_FLIGHT_STREAMS", 0),
+		MaxQueued:             utils.GetEnvAsInt("GRPC_MAX_QUEUED", 100),
+		QueueTimeout:          utils.GetEnvDuration("GRPC_QUEUE_TIMEOUT", 500*time.Millisecond),
 	}
 }
 
@@ -51,24 +84,70 @@ type BaseGrpcServer struct {
 }
 
 // NewBaseGrpcServer creates a new base gRPC server with default config
-func NewBaseGrpcServer(logger logger.Logger) *BaseGrpcServer {
-	return NewBaseGrpcServerWithConfig(logger, DefaultGrpcServerConfig())
+func NewBaseGrpcServer(logger logger.Logger, options ...ServerOption) *BaseGrpcServer {
+	return NewBaseGrpcServerWithConfig(logger, DefaultGrpcServerConfig(), options...)
 }
 
-// NewBaseGrpcServerWithConfig creates a new base gRPC server with custom config
-func NewBaseGrpcServerWithConfig(logger logger.Logger, config *GrpcServerConfig) *BaseGrpcServer {
+// NewBaseGrpcServerWithConfig creates a new base gRPC server with custom config.
+// Services add interceptors with WithUnaryInterceptors/WithStreamInterceptors (or the ...At
+// variants to place them relative to the built-in chain).
+func NewBaseGrpcServerWithConfig(logger logger.Logger, config *GrpcServerConfig, options ...ServerOption) *BaseGrpcServer {
 	// Set up server interceptors
 	recoveryHandler := func(p interface{}) (err error) {
 		logger.Error("Recovered from panic in gRPC handler", "panic", p)
-		return status.Errorf(http.StatusInternalServerError, "internal server error: %v", p)
+		return status.Errorf(codes.Internal, "internal server error: %v", p)
 	}
 
 	opts := []grpc_recovery.Option{
 		grpc_recovery.WithRecoveryHandler(recoveryHandler),
 	}
 
+	if config.Maintenance == nil {
+		config.Maintenance = maintenance.NewSwitchFromEnv(logger)
+	}
+	if config.Watchdog == nil {
+		config.Watchdog = watchdog.NewFromEnv(logger)
+	}
+
+	// Built-in chain; service options are applied on top
+	loaderConfig := dataloader.LoadConfigFromEnv()
+	o := &serverOptions{}
+	// Saturated servers reject calls before any other work is done for them
+	WithUnaryInterceptorsAt(PriorityTags, ConcurrencyUnaryInterceptor(NewConcurrencyLimiter(config.MaxInFlight, config.MaxQueued, config.QueueTimeout)))(o)
+	WithUnaryInterceptorsAt(PriorityTags, grpc_ctxtags.UnaryServerInterceptor())(o)
+	WithUnaryInterceptorsAt(PriorityValidation, grpc_validator.UnaryServerInterceptor())(o) // Make sure request types have `Validate() error` method
+	WithUnaryInterceptorsAt(PriorityRecovery, grpc_recovery.UnaryServerInterceptor(opts...))(o)
+	WithUnaryInterceptorsAt(PriorityActor, ActorUnaryInterceptor(middleware.DefaultJWTConfig.AccessTokenSecret))(o)
+	WithUnaryInterceptorsAt(PriorityActor, LocaleUnaryInterceptor())(o)
+	WithUnaryInterceptorsAt(PriorityActor, LoggerUnaryInterceptor(logger))(o)
+	WithUnaryInterceptorsAt(PriorityActor, SlowRequestUnaryInterceptor(config.Watchdog))(o)
+	WithUnaryInterceptorsAt(PriorityActor, DataLoaderUnaryInterceptor(loaderConfig))(o)
+	WithUnaryInterceptorsAt(PriorityActor, DryRunUnaryInterceptor())(o)
+	WithUnaryInterceptorsAt(PriorityActor, MaintenanceUnaryInterceptor(config.Maintenance))(o)
+	WithStreamInterceptorsAt(PriorityTags, ConcurrencyStreamInterceptor(NewConcurrencyLimiter(config.MaxInFlightStreams, config.MaxQueued, config.QueueTimeout)))(o)
+	WithStreamInterceptorsAt(PriorityTags, grpc_ctxtags.StreamServerInterceptor())(o)
+	WithStreamInterceptorsAt(PriorityValidation, grpc_validator.StreamServerInterceptor())(o)
+	WithStreamInterceptorsAt(PriorityRecovery, grpc_recovery.StreamServerInterceptor(opts...))(o)
+	WithStreamInterceptorsAt(PriorityActor, ActorStreamInterceptor(middleware.DefaultJWTConfig.AccessTokenSecret))(o)
+	WithStreamInterceptorsAt(PriorityActor, LocaleStreamInterceptor())(o)
+	WithStreamInterceptorsAt(PriorityActor, LoggerStreamInterceptor(logger))(o)
+	WithStreamInterceptorsAt(PriorityActor, DataLoaderStreamInterceptor(loaderConfig))(o)
+	WithStreamInterceptorsAt(PriorityActor, MaintenanceStreamInterceptor(config.Maintenance))(o)
+	if config.Gzip {
+		if config.GzipLevel != 0 {
+			if err := gzip.SetLevel(config.GzipLevel); err != nil {
+				logger.Warn("Invalid gzip level, using the default", "level", config.GzipLevel, "error", err)
+			}
+		}
+		WithUnaryInterceptorsAt(PriorityTags, GzipUnaryInterceptor())(o)
+		WithStreamInterceptorsAt(PriorityTags, GzipStreamInterceptor())(o)
+	}
+	for _, option := range options {
+		option(o)
+	}
+
 	// Create gRPC server with middleware
-	server := grpc.NewServer(
+	serverOpts := append([]grpc.ServerOption{
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     config.MaxConnectionIdle,
 			MaxConnectionAge:      config.MaxConnectionAge,
@@ -76,22 +155,18 @@ func NewBaseGrpcServerWithConfig(logger logger.Logger, config *GrpcServerConfig)
 			Time:                  config.KeepAliveTime,
 			Timeout:               config.KeepAliveTimeout,
 		}),
-		grpc.ChainUnaryInterceptor(
-			grpc_ctxtags.UnaryServerInterceptor(),
-			grpc_validator.UnaryServerInterceptor(), // Make sure request types have `Validate() error` method
-			grpc_recovery.UnaryServerInterceptor(opts...),
-			// TODO: Add custom interceptors (logging, auth, etc.) here
-		),
-		grpc.ChainStreamInterceptor(
-			grpc_ctxtags.StreamServerInterceptor(),
-			grpc_validator.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(opts...),
-			// TODO: Add custom interceptors (logging, auth, etc.) here
-		),
-	)
+		grpc.MaxRecvMsgSize(config.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(config.MaxSendMsgSize),
+		grpc.MaxConcurrentStreams(config.MaxConcurrentStreams),
+		grpc.ChainUnaryInterceptor(ordered(o.unary)...),
+		grpc.ChainStreamInterceptor(ordered(o.stream)...),
+	}, o.grpcServer...)
+	server := grpc.NewServer(serverOpts...)
 
 	// Enable reflection for debugging & tools like grpc_cli
 	reflection.Register(server)
+	server.RegisterService(&logLevelServiceDesc, newLogLevelServer(logger))
+	server.RegisterService(&diagnosticsServiceDesc, newDiagnosticsServer(logger))
 
 	return &BaseGrpcServer{
 		server: server,
@@ -132,6 +207,25 @@ func (s *BaseGrpcServer) Stop() {
 	s.Logger.Info("gRPC server stopped.")
 }
 
+// Shutdown stops the server gracefully, waiting for in-flight calls until ctx is done and then
+// closing the remaining connections
+func (s *BaseGrpcServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Logger.Warn("gRPC server did not drain in time, closing remaining connections")
+		s.server.Stop()
+		<-stopped
+		return ctx.Err()
+	}
+}
+
 // Server returns the underlying grpc.Server instance
 func (s *BaseGrpcServer) Server() *grpc.Server {
 	return s.server
@@ -0,0 +1,23 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/watchdog"
+)
+
+// SlowRequestUnaryInterceptor reports unary calls exceeding their latency threshold to the watchdog.
+// It must run after LoggerUnaryInterceptor so slow calls are logged with the request ID, method and user.
+// Streams are not watched: their duration is their lifetime, not their latency.
+func SlowRequestUnaryInterceptor(w *watchdog.Watchdog) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := w.Begin(ctx, watchdog.Request{Kind: watchdog.KindGRPC, Route: info.FullMethod, RequestID: logger.RequestIDFromContext(ctx)})
+		resp, err := handler(ctx, req)
+		done(ctx, status.Code(err).String())
+		return resp, err
+	}
+}
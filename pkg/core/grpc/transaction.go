@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// TransactionUnaryInterceptor runs each unary request matching match in a database transaction
+// stored with repository.WithTx, so all repositories called by the handler share it. The transaction
+// commits when the handler succeeds and rolls back when it returns an error, panics or is a dry run.
+// A nil match covers every method that IsReadOnlyMethod does not classify as read-only.
+//
+// Functions the handler registers with repository.AfterCommit, such as the publishing of change
+// events, run once the transaction commits and are dropped when it rolls back. Events published
+// directly are not withheld. The transaction holds a connection for the whole request. Streaming
+// methods are not covered.
+func TransactionUnaryInterceptor(db *gorm.DB, log logger.Logger, match func(fullMethod string) bool) grpc.UnaryServerInterceptor {
+	if match == nil {
+		match = func(fullMethod string) bool { return !IsReadOnlyMethod(fullMethod) }
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if !match(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if _, ok := repository.TxFromContext(ctx); ok {
+			return handler(ctx, req)
+		}
+
+		ctx, finish := repository.WithCommitHooks(ctx)
+		tx := db.WithContext(ctx).Begin()
+		if tx.Error != nil {
+			finish(false)
+			logger.FromContext(ctx, log).Error("Failed to begin request transaction", "method", info.FullMethod, "error", tx.Error)
+			return nil, status.Error(codes.Unavailable, "failed to begin transaction")
+		}
+		committed := false
+		defer func() {
+			// Also reached when the handler panics; recovery further out turns the panic into an error
+			if !committed {
+				finish(false)
+				if rbErr := tx.Rollback().Error; rbErr != nil {
+					logger.FromContext(ctx, log).Warn("Failed to roll back request transaction", "method", info.FullMethod, "error", rbErr)
+				}
+			}
+		}()
+
+		resp, err = handler(repository.WithTx(ctx, tx), req)
+		if err != nil || usecase.IsDryRun(ctx) {
+			return resp, err
+		}
+		if commitErr := tx.Commit().Error; commitErr != nil {
+			committed = true // A failed commit cannot be rolled back
+			finish(false)
+			logger.FromContext(ctx, log).Error("Failed to commit request transaction", "method", info.FullMethod, "error", commitErr)
+			return nil, status.Error(codes.Aborted, "failed to commit transaction")
+		}
+		committed = true
+		finish(true)
+		return resp, nil
+	}
+}
@@ -0,0 +1,75 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker stops requests to a host after repeated failures, letting a single trial request
+// through once the cooldown has elapsed
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// allow reports whether a request may proceed
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	// Open: allow exactly one trial request after the cooldown
+	if !b.trial && time.Since(b.openedAt) >= b.cooldown {
+		b.trial = true
+		return true
+	}
+	return false
+}
+
+// record updates the breaker with the outcome of a request
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// breakers holds one breaker per host
+type breakers struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*breaker
+}
+
+func newBreakers(threshold int, cooldown time.Duration) *breakers {
+	return &breakers{threshold: threshold, cooldown: cooldown, hosts: make(map[string]*breaker)}
+}
+
+func (b *breakers) get(host string) *breaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.hosts[host]
+	if !ok {
+		br = &breaker{threshold: b.threshold, cooldown: b.cooldown}
+		b.hosts[host] = br
+	}
+	return br
+}
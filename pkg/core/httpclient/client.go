@@ -0,0 +1,242 @@
+// Package httpclient builds *http.Client values for calling third-party REST APIs (OIDC providers,
+// SMS gateways, ...). Requests get timeouts, retries with backoff for idempotent calls, a circuit
+// breaker per host, the request ID and trace headers of the request being handled, and metrics.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ErrCircuitOpen is returned, wrapped, for requests to a host whose circuit is open
+var ErrCircuitOpen = errors.New("circuit open")
+
+// Config configures a client
+type Config struct {
+	Name             string        // Identifies the client in metrics and errors, e.g. "oidc"
+	Timeout          time.Duration // Bound on a whole call, including retries and reading the body
+	AttemptTimeout   time.Duration // Bound on waiting for the response headers of one attempt
+	MaxRetries       int           // Extra attempts after a failed idempotent request
+	RetryBackoff     time.Duration // Initial backoff, doubled after every attempt
+	MaxRetryWait     time.Duration // Longest Retry-After honoured; longer waits return the response instead
+	BreakerThreshold int           // Consecutive failures that open a host's circuit; zero disables it
+	BreakerCooldown  time.Duration // Time a circuit stays open before a trial request is allowed
+}
+
+// LoadConfigFromEnv reads the HTTP_CLIENT_* variables; name identifies the client
+func LoadConfigFromEnv(name string) Config {
+	return Config{
+		Name:             name,
+		Timeout:          utils.GetEnvDuration("HTTP_CLIENT_TIMEOUT", 30*time.Second),
+		AttemptTimeout:   utils.GetEnvDuration("HTTP_CLIENT_ATTEMPT_TIMEOUT", 10*time.Second),
+		MaxRetries:       utils.GetEnvAsInt("HTTP_CLIENT_MAX_RETRIES", 2),
+		RetryBackoff:     utils.GetEnvDuration("HTTP_CLIENT_RETRY_BACKOFF", 200*time.Millisecond),
+		MaxRetryWait:     utils.GetEnvDuration("HTTP_CLIENT_MAX_RETRY_WAIT", 5*time.Second),
+		BreakerThreshold: utils.GetEnvAsInt("HTTP_CLIENT_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  utils.GetEnvDuration("HTTP_CLIENT_BREAKER_COOLDOWN", 30*time.Second),
+	}
+}
+
+// New creates a client. metrics may be nil; share one Metrics between the clients of a service.
+func New(config Config, metrics *Metrics) *http.Client {
+	base := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		ResponseHeaderTimeout: config.AttemptTimeout,
+	}
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: NewTransport(base, config, metrics),
+	}
+}
+
+// Transport is the http.RoundTripper behind clients created by New
+type Transport struct {
+	base     http.RoundTripper
+	config   Config
+	metrics  *Metrics
+	breakers *breakers
+}
+
+// NewTransport wraps base, for callers that need their own transport settings (e.g. client certificates)
+func NewTransport(base http.RoundTripper, config Config, metrics *Metrics) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:     base,
+		config:   config,
+		metrics:  metrics,
+		breakers: newBreakers(config.BreakerThreshold, config.BreakerCooldown),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	host := req.URL.Host
+	breaker := t.breakers.get(host)
+	attempts := 1
+	if isIdempotent(req) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil) {
+		attempts += t.config.MaxRetries
+	}
+
+	wait := t.config.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		if !breaker.allow() {
+			t.metrics.rejected(t.config.Name, host)
+			return nil, fmt.Errorf("%s: %w for %s", t.config.Name, ErrCircuitOpen, host)
+		}
+
+		outgoing, err := t.prepare(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		resp, err := t.base.RoundTrip(outgoing)
+		t.metrics.observe(t.config.Name, host, req.Method, statusLabel(resp, err), time.Since(start))
+
+		// Failures of the remote host count towards its circuit; cancellations by the caller do not
+		failed := (err != nil && ctx.Err() == nil) || (resp != nil && resp.StatusCode >= http.StatusInternalServerError)
+		breaker.record(failed)
+
+		if attempt >= attempts || !retryable(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+		delay := wait
+		if after, ok := retryAfter(resp); ok {
+			if after > t.config.MaxRetryWait {
+				return resp, err
+			}
+			delay = after
+		}
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		t.metrics.retried(t.config.Name, host)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// prepare returns the request for one attempt: a copy carrying the tracing headers, with the
+// body rewound for retries
+func (t *Transport) prepare(req *http.Request, attempt int) (*http.Request, error) {
+	outgoing := req.Clone(req.Context())
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		outgoing.Body = body
+	}
+	setTraceHeaders(outgoing)
+	return outgoing, nil
+}
+
+// setTraceHeaders adds the request ID and W3C trace context of the gRPC request being handled,
+// unless the caller set them. Credentials are never forwarded to third parties.
+func setTraceHeaders(req *http.Request) {
+	ctx := req.Context()
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	if req.Header.Get("X-Request-ID") == "" {
+		requestID := logger.RequestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = firstValue(incoming, "x-request-id")
+		}
+		if requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+	}
+	for _, key := range []string{"traceparent", "tracestate"} {
+		if req.Header.Get(key) == "" {
+			if v := firstValue(incoming, key); v != "" {
+				req.Header.Set(key, v)
+			}
+		}
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// isIdempotent reports whether req may be sent more than once: idempotent methods, and any
+// request carrying an Idempotency-Key header
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryable reports whether an attempt failed in a way another attempt may fix
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads the Retry-After header, in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// statusLabel is the code label of an attempt: the status code, or "error" when no response arrived
+func statusLabel(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return "error"
+	}
+	return strconv.Itoa(resp.StatusCode)
+}
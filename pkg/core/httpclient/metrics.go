@@ -0,0 +1,188 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// requestDurationBuckets are the histogram upper bounds in seconds
+var requestDurationBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// requestKey identifies one series: the client, the host it called, the method and the outcome
+type requestKey struct {
+	client string
+	host   string
+	method string
+	code   string
+}
+
+// hostKey identifies the retry and circuit counters of one host
+type hostKey struct {
+	client string
+	host   string
+}
+
+// requestSeries holds the measurements of one series
+type requestSeries struct {
+	buckets []uint64 // Cumulative counts per requestDurationBuckets entry
+	count   uint64
+	sum     float64
+}
+
+// hostSeries holds the counters of one host
+type hostSeries struct {
+	retries  uint64
+	rejected uint64
+}
+
+// Metrics aggregates outgoing request durations per status code, retries and requests rejected by
+// an open circuit. It implements http.Handler, serving the Prometheus text exposition format.
+// A nil *Metrics records nothing.
+type Metrics struct {
+	mu       sync.Mutex
+	requests map[requestKey]*requestSeries
+	hosts    map[hostKey]*hostSeries
+}
+
+// NewMetrics creates an empty metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{requests: make(map[requestKey]*requestSeries), hosts: make(map[hostKey]*hostSeries)}
+}
+
+// observe records one attempt
+func (m *Metrics) observe(client, host, method, code string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := requestKey{client: client, host: host, method: method, code: code}
+	s, ok := m.requests[key]
+	if !ok {
+		s = &requestSeries{buckets: make([]uint64, len(requestDurationBuckets))}
+		m.requests[key] = s
+	}
+	seconds := duration.Seconds()
+	for i, upper := range requestDurationBuckets {
+		if seconds <= upper {
+			s.buckets[i]++
+		}
+	}
+	s.count++
+	s.sum += seconds
+}
+
+func (m *Metrics) retried(client, host string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.host(client, host).retries++
+}
+
+func (m *Metrics) rejected(client, host string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.host(client, host).rejected++
+}
+
+// host returns the counters of a host; m.mu must be held
+func (m *Metrics) host(client, host string) *hostSeries {
+	key := hostKey{client: client, host: host}
+	s, ok := m.hosts[key]
+	if !ok {
+		s = &hostSeries{}
+		m.hosts[key] = s
+	}
+	return s
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	requests := make(map[requestKey]requestSeries, len(m.requests))
+	for k, s := range m.requests {
+		requestKeys = append(requestKeys, k)
+		copied := *s
+		copied.buckets = append([]uint64(nil), s.buckets...)
+		requests[k] = copied
+	}
+	hostKeys := make([]hostKey, 0, len(m.hosts))
+	hosts := make(map[hostKey]hostSeries, len(m.hosts))
+	for k, s := range m.hosts {
+		hostKeys = append(hostKeys, k)
+		hosts[k] = *s
+	}
+	m.mu.Unlock()
+
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, b := requestKeys[i], requestKeys[j]
+		if a.client != b.client {
+			return a.client < b.client
+		}
+		if a.host != b.host {
+			return a.host < b.host
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.code < b.code
+	})
+	sort.Slice(hostKeys, func(i, j int) bool {
+		if hostKeys[i].client != hostKeys[j].client {
+			return hostKeys[i].client < hostKeys[j].client
+		}
+		return hostKeys[i].host < hostKeys[j].host
+	})
+
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("# HELP http_client_request_duration_seconds Duration of outgoing HTTP requests, per attempt.\n# TYPE http_client_request_duration_seconds histogram\n")
+	for _, k := range requestKeys {
+		s := requests[k]
+		labels := fmt.Sprintf(`client=%q,host=%q,method=%q,code=%q`, k.client, k.host, k.method, k.code)
+		for i, upper := range requestDurationBuckets {
+			printf("http_client_request_duration_seconds_bucket{%s,le=%q} %d\n", labels, strconv.FormatFloat(upper, 'g', -1, 64), s.buckets[i])
+		}
+		printf("http_client_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, s.count)
+		printf("http_client_request_duration_seconds_sum{%s} %g\n", labels, s.sum)
+		printf("http_client_request_duration_seconds_count{%s} %d\n", labels, s.count)
+	}
+
+	counters := []struct {
+		name, help string
+		value      func(hostSeries) uint64
+	}{
+		{"http_client_retries_total", "Outgoing HTTP requests retried after a failed attempt.", func(s hostSeries) uint64 { return s.retries }},
+		{"http_client_circuit_rejected_total", "Outgoing HTTP requests rejected because the host's circuit was open.", func(s hostSeries) uint64 { return s.rejected }},
+	}
+	for _, c := range counters {
+		printf("# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, k := range hostKeys {
+			printf("%s{client=%q,host=%q} %d\n", c.name, k.client, k.host, c.value(hosts[k]))
+		}
+	}
+	return err
+}
+
+// ServeHTTP serves the metrics for scraping
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
@@ -0,0 +1,136 @@
+// Package i18n holds the message catalogs used to localize error messages.
+//
+// Errors carry a stable message ID (e.g. "user.not_found") plus parameters; the English text is used
+// inside services and logs, and the gateway re-renders it in the caller's language based on Accept-Language.
+// Use cases that render text themselves (notifications, exports) use the caller's Locale from the context.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is used when the caller's language has no catalog or lacks a message
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalog maps language -> message ID -> template. Templates use {name} placeholders.
+var (
+	mu      sync.RWMutex
+	catalog = map[string]map[string]string{}
+)
+
+func init() {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, e := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic("i18n: invalid catalog " + e.Name() + ": " + err.Error())
+		}
+		Register(strings.TrimSuffix(e.Name(), ".json"), messages)
+	}
+}
+
+// Register adds or overrides messages for a language, so services can ship their own catalogs
+func Register(lang string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	lang = strings.ToLower(lang)
+	if catalog[lang] == nil {
+		catalog[lang] = map[string]string{}
+	}
+	for id, msg := range messages {
+		catalog[lang][id] = msg
+	}
+}
+
+// Languages returns the languages that have a catalog
+func Languages() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	langs := make([]string, 0, len(catalog))
+	for lang := range catalog {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// HasMessage reports whether id exists in the default catalog
+func HasMessage(id string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := catalog[DefaultLanguage][id]
+	return ok
+}
+
+// Translate renders message id in lang, falling back to the base language (vi-VN -> vi),
+// then to DefaultLanguage, then to the ID itself.
+func Translate(lang, id string, params map[string]string) string {
+	mu.RLock()
+	tmpl, ok := lookup(strings.ToLower(lang), id)
+	mu.RUnlock()
+	if !ok {
+		return id
+	}
+	for k, v := range params {
+		tmpl = strings.ReplaceAll(tmpl, "{"+k+"}", v)
+	}
+	return tmpl
+}
+
+// lookup finds the template for id; caller must hold the read lock
+func lookup(lang, id string) (string, bool) {
+	base, _, _ := strings.Cut(lang, "-")
+	for _, l := range []string{lang, base, DefaultLanguage} {
+		if msg, ok := catalog[l][id]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// MatchLanguage picks the supported language with the highest weight from an Accept-Language header
+func MatchLanguage(acceptLanguage string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	best, bestQ := DefaultLanguage, -1.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		base, _, _ := strings.Cut(tag, "-")
+		for _, candidate := range []string{tag, base} {
+			if _, ok := catalog[candidate]; ok && q > bestQ {
+				best, bestQ = candidate, q
+				break
+			}
+		}
+	}
+	return best
+}
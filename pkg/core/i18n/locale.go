@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Catalog IDs of the time layouts used by Locale.FormatDate and Locale.FormatDateTime
+const (
+	DateFormatID     = "format.date"
+	DateTimeFormatID = "format.datetime"
+)
+
+// Locale is the caller's language and time zone. The gateway derives them from Accept-Language and
+// X-Timezone and forwards them as metadata; the gRPC server stores them in the request context.
+type Locale struct {
+	Language string
+	Location *time.Location
+}
+
+// DefaultLocale is used for calls that carry no locale (background jobs, internal callers)
+var DefaultLocale = Locale{Language: DefaultLanguage, Location: time.UTC}
+
+type localeKey struct{}
+
+// WithLocale returns a context carrying locale
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the caller's locale, or DefaultLocale
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// NewLocale builds a Locale from a language tag and an IANA time zone name. The language is matched
+// against the catalogs like Accept-Language; an empty zone means UTC, an unknown one is an error.
+func NewLocale(language, timezone string) (Locale, error) {
+	loc, err := ParseTimezone(timezone)
+	if err != nil {
+		return DefaultLocale, err
+	}
+	return Locale{Language: MatchLanguage(language), Location: loc}, nil
+}
+
+// ParseTimezone loads an IANA time zone ("Asia/Ho_Chi_Minh", "UTC"); empty means UTC. "Local" is
+// rejected, since the server's zone means nothing to the caller.
+func ParseTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	switch name {
+	case "":
+		return time.UTC, nil
+	case "Local":
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// T translates message id in the locale's language
+func (l Locale) T(id string, params map[string]string) string {
+	return Translate(l.language(), id, params)
+}
+
+// In converts t to the locale's time zone
+func (l Locale) In(t time.Time) time.Time {
+	if l.Location == nil {
+		return t.UTC()
+	}
+	return t.In(l.Location)
+}
+
+// Format renders t in the locale's time zone using a Go time layout
+func (l Locale) Format(t time.Time, layout string) string {
+	return l.In(t).Format(layout)
+}
+
+// FormatDate renders the date of t in the locale's time zone and date layout
+func (l Locale) FormatDate(t time.Time) string {
+	return l.Format(t, l.T(DateFormatID, nil))
+}
+
+// FormatDateTime renders t in the locale's time zone and date-time layout
+func (l Locale) FormatDateTime(t time.Time) string {
+	return l.Format(t, l.T(DateTimeFormatID, nil))
+}
+
+func (l Locale) language() string {
+	if l.Language == "" {
+		return DefaultLanguage
+	}
+	return l.Language
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain identifies ErrorInfo details whose Reason is a catalog message ID
+const ErrorDomain = "i18n"
+
+// FieldParam is the ErrorInfo metadata key holding the field a violation belongs to
+const FieldParam = "field"
+
+// LocalizeStatus re-renders a status message and its field violations in lang.
+// The message ID stays available to clients as the ErrorInfo reason.
+func LocalizeStatus(st *status.Status, lang string) *status.Status {
+	var info *errdetails.ErrorInfo
+	violationParams := map[string]map[string]string{}
+	var badRequest *errdetails.BadRequest
+
+	for _, d := range st.Details() {
+		switch detail := d.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.Domain != ErrorDomain {
+				continue
+			}
+			if field, ok := detail.Metadata[FieldParam]; ok {
+				violationParams[field] = detail.Metadata
+			} else if info == nil {
+				info = detail
+			}
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+	if info == nil {
+		return st
+	}
+
+	localized := st.Proto()
+	localized.Message = Translate(lang, info.Reason, info.Metadata)
+	if badRequest != nil {
+		for _, v := range badRequest.FieldViolations {
+			if v.Reason != "" {
+				v.Description = Translate(lang, v.Reason, violationParams[v.Field])
+			}
+		}
+		// Replace the BadRequest detail with the localized copy
+		details := make([]protoadapt.MessageV1, 0, len(st.Details()))
+		for _, d := range st.Details() {
+			if _, ok := d.(*errdetails.BadRequest); ok {
+				details = append(details, badRequest)
+			} else if m, ok := d.(protoadapt.MessageV1); ok {
+				details = append(details, m)
+			}
+		}
+		out, err := status.New(st.Code(), localized.Message).WithDetails(details...)
+		if err == nil {
+			return out
+		}
+	}
+	return status.FromProto(localized)
+}
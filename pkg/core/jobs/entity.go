@@ -0,0 +1,47 @@
+// Package jobs runs background work outside the request that asked for it. Jobs are stored in the
+// database, claimed by workers of any instance and retried with exponential backoff, so they
+// survive restarts and never run twice at the same time.
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// Status is the state of a job
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Waiting for its first or next attempt
+	StatusSucceeded Status = "succeeded" // The handler returned without error
+	StatusFailed    Status = "failed"    // All attempts were used up, or the handler gave up
+)
+
+// Job is a unit of background work of a registered type, with its attempt log
+type Job struct {
+	entity.BaseEntity
+	Type        string     `json:"type" gorm:"size:128;not null;index"`
+	Payload     string     `json:"payload" gorm:"type:text;not null"` // JSON handed to the handler
+	Status      Status     `json:"status" gorm:"size:16;not null;index"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	RunAt       time.Time  `json:"run_at" gorm:"index"` // When the next attempt is due
+	LastError   string     `json:"last_error,omitempty" gorm:"type:text"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// Decode unmarshals the job's payload into v
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal([]byte(j.Payload), v)
+}
+
+// Models returns the entities to auto-migrate
+func Models() []interface{} {
+	return []interface{}{&Job{}}
+}
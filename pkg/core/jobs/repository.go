@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+)
+
+// Repository defines persistence operations for jobs
+type Repository interface {
+	core_repo.BaseRepository[Job]
+
+	// ClaimDue returns up to limit pending jobs of the given types whose next attempt is due and
+	// pushes their RunAt forward by lease, so concurrent workers do not run the same job twice
+	ClaimDue(ctx context.Context, types []string, limit int, lease time.Duration) ([]*Job, error)
+	// RecordAttempt stores the outcome of an attempt, including zero values such as a cleared error
+	RecordAttempt(ctx context.Context, job *Job) error
+}
+
+type gormRepository struct {
+	*core_repo.GormBaseRepository[Job]
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[Job](db),
+	}
+}
+
+// ClaimDue implements Repository
+func (r *gormRepository) ClaimDue(ctx context.Context, types []string, limit int, lease time.Duration) ([]*Job, error) {
+	var jobs []*Job
+	err := r.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND type IN ? AND run_at <= ? AND deleted_at IS NULL", StatusPending, types, now).
+			Order("run_at").
+			Limit(limit).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(jobs))
+		for i, j := range jobs {
+			ids[i] = j.ID
+		}
+		return tx.Model(&Job{}).Where("id IN ?", ids).Update("run_at", now.Add(lease)).Error
+	})
+	return jobs, err
+}
+
+// RecordAttempt implements Repository
+func (r *gormRepository) RecordAttempt(ctx context.Context, job *Job) error {
+	return r.Conn(ctx).Model(job).
+		Select("status", "attempts", "run_at", "last_error", "completed_at").
+		Updates(job).Error
+}
@@ -0,0 +1,214 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Config controls how jobs are run
+type Config struct {
+	PollInterval time.Duration // How often the worker looks for due jobs
+	BatchSize    int           // Jobs claimed per poll
+	Timeout      time.Duration // Upper bound for one attempt
+	MaxAttempts  int           // Attempts before a job is marked failed
+	BackoffBase  time.Duration // Delay after the first failed attempt; doubles after each further failure
+	BackoffMax   time.Duration // Upper bound for the retry delay
+}
+
+// LoadConfigFromEnv reads the JOB_* settings
+func LoadConfigFromEnv() Config {
+	return Config{
+		PollInterval: utils.GetEnvDuration("JOB_POLL_INTERVAL", 2*time.Second),
+		BatchSize:    utils.GetEnvAsInt("JOB_BATCH_SIZE", 10),
+		Timeout:      utils.GetEnvDuration("JOB_TIMEOUT", 5*time.Minute),
+		MaxAttempts:  utils.GetEnvAsInt("JOB_MAX_ATTEMPTS", 5),
+		BackoffBase:  utils.GetEnvDuration("JOB_BACKOFF_BASE", 10*time.Second),
+		BackoffMax:   utils.GetEnvDuration("JOB_BACKOFF_MAX", time.Hour),
+	}
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts
+func (c Config) Backoff(attempts int) time.Duration {
+	delay := c.BackoffBase
+	for i := 1; i < attempts && delay < c.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > c.BackoffMax {
+		delay = c.BackoffMax
+	}
+	return delay
+}
+
+// Handler runs one attempt of a job. Returning an error schedules a retry unless it is Permanent.
+type Handler func(ctx context.Context, job *Job) error
+
+// FailureHandler is called once a job of its type has failed for good, e.g. to update the record
+// the job was working on
+type FailureHandler func(ctx context.Context, job *Job, err error)
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the job is marked failed without further attempts
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Queue enqueues jobs for the workers of any instance
+type Queue struct {
+	repo Repository
+}
+
+// NewQueue creates a new Queue
+func NewQueue(repo Repository) *Queue {
+	return &Queue{repo: repo}
+}
+
+// Enqueue stores a job of jobType that runs as soon as a worker is free. The payload is stored as JSON.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) (*Job, error) {
+	return q.EnqueueAt(ctx, jobType, payload, time.Now())
+}
+
+// EnqueueAt stores a job of jobType that runs no earlier than runAt
+func (q *Queue) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) (*Job, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s job payload: %w", jobType, err)
+	}
+	job := &Job{Type: jobType, Payload: string(body), Status: StatusPending, RunAt: runAt}
+	if err := q.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Worker runs due jobs of the types it has handlers for, retrying failures with exponential backoff
+type Worker struct {
+	repo     Repository
+	config   Config
+	logger   logger.Logger
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	failures map[string]FailureHandler
+}
+
+// NewWorker creates a worker without handlers; register them with Handle before Run
+func NewWorker(repo Repository, config Config, logger logger.Logger) *Worker {
+	return &Worker{repo: repo, config: config, logger: logger, handlers: make(map[string]Handler), failures: make(map[string]FailureHandler)}
+}
+
+// Handle registers the handler of jobType
+func (w *Worker) Handle(jobType string, handler Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[jobType] = handler
+}
+
+// OnFailed registers a callback for jobs of jobType that failed permanently
+func (w *Worker) OnFailed(jobType string, handler FailureHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.failures[jobType] = handler
+}
+
+// types returns the job types with a handler
+func (w *Worker) types() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	types := make([]string, 0, len(w.handlers))
+	for jobType := range w.handlers {
+		types = append(types, jobType)
+	}
+	return types
+}
+
+// Run polls for due jobs until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	w.logger.Info("Job worker started", "poll_interval", w.config.PollInterval, "types", w.types())
+	for {
+		w.ProcessDue(ctx)
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Job worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue runs one batch of due jobs
+func (w *Worker) ProcessDue(ctx context.Context) {
+	types := w.types()
+	if len(types) == 0 {
+		return
+	}
+	// Lease claimed jobs for longer than an attempt can take
+	due, err := w.repo.ClaimDue(ctx, types, w.config.BatchSize, 2*w.config.Timeout)
+	if err != nil {
+		w.logger.Error("Failed to claim jobs", "error", err)
+		return
+	}
+	for _, job := range due {
+		w.attempt(ctx, job)
+	}
+}
+
+// attempt runs a job once and records the outcome
+func (w *Worker) attempt(ctx context.Context, job *Job) {
+	w.mu.RLock()
+	handler, onFailed := w.handlers[job.Type], w.failures[job.Type]
+	w.mu.RUnlock()
+
+	job.Attempts++
+	runErr := w.run(ctx, handler, job)
+
+	var permanent *permanentError
+	switch {
+	case runErr == nil:
+		now := time.Now()
+		job.Status = StatusSucceeded
+		job.CompletedAt = &now
+		job.LastError = ""
+	case errors.As(runErr, &permanent) || job.Attempts >= w.config.MaxAttempts:
+		now := time.Now()
+		job.Status = StatusFailed
+		job.CompletedAt = &now
+		job.LastError = runErr.Error()
+		w.logger.Warn("Job failed permanently", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "error", runErr)
+		if onFailed != nil {
+			onFailed(ctx, job, runErr)
+		}
+	default:
+		job.LastError = runErr.Error()
+		job.RunAt = time.Now().Add(w.config.Backoff(job.Attempts))
+		w.logger.Debug("Job failed, will retry", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "run_at", job.RunAt, "error", runErr)
+	}
+	if err := w.repo.RecordAttempt(ctx, job); err != nil {
+		w.logger.Error("Failed to record job attempt", "job_id", job.ID, "error", err)
+	}
+}
+
+// run calls the handler with the attempt timeout; a panic fails the attempt instead of the worker
+func (w *Worker) run(ctx context.Context, handler Handler, job *Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
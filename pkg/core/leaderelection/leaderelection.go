@@ -0,0 +1,244 @@
+// Package leaderelection designates a single replica of a service to run singleton background
+// work (schedulers, outbox publishers) using client-go's Lease-based leader election.
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Config holds the leader election configuration
+type Config struct {
+	// Enabled turns on election through the Kubernetes API. When disabled (local development,
+	// single replica) the elector leads immediately and never loses leadership.
+	Enabled       bool
+	LeaseName     string // Name of the Lease object, shared by all replicas of a service
+	Namespace     string
+	Identity      string // Unique per replica; defaults to the pod name
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// LoadConfigFromEnv reads the leader election configuration from environment variables.
+// POD_NAME and POD_NAMESPACE are expected to be set through the downward API.
+func LoadConfigFromEnv(leaseName string) Config {
+	hostname, _ := os.Hostname()
+	return Config{
+		Enabled:       utils.GetEnv("LEADER_ELECTION_ENABLED", "false") == "true",
+		LeaseName:     utils.GetEnv("LEADER_ELECTION_LEASE_NAME", leaseName),
+		Namespace:     utils.GetEnv("POD_NAMESPACE", "default"),
+		Identity:      utils.GetEnv("POD_NAME", hostname),
+		LeaseDuration: utils.GetEnvDuration("LEADER_ELECTION_LEASE_DURATION", 15*time.Second),
+		RenewDeadline: utils.GetEnvDuration("LEADER_ELECTION_RENEW_DEADLINE", 10*time.Second),
+		RetryPeriod:   utils.GetEnvDuration("LEADER_ELECTION_RETRY_PERIOD", 2*time.Second),
+	}
+}
+
+// Callbacks are invoked as leadership changes
+type Callbacks struct {
+	// OnStartedLeading runs when this replica becomes leader. ctx is cancelled when leadership
+	// is lost, so leader-only work must stop when it is done.
+	OnStartedLeading func(ctx context.Context)
+	// OnStoppedLeading runs after leadership is lost or released
+	OnStoppedLeading func()
+	// OnNewLeader runs when a (possibly different) replica is observed as leader
+	OnNewLeader func(identity string)
+}
+
+// Status describes the elector's view of the election, for health and metrics endpoints
+type Status struct {
+	Identity     string    `json:"identity"`
+	Leader       string    `json:"leader"`
+	IsLeader     bool      `json:"is_leader"`
+	LeadingSince time.Time `json:"leading_since,omitempty"`
+	Transitions  int       `json:"transitions"` // Times this replica gained leadership
+}
+
+// Elector runs leader election for one lease
+type Elector struct {
+	cfg       Config
+	callbacks Callbacks
+	logger    logger.Logger
+	lock      resourcelock.Interface
+	watchdog  *leaderelection.HealthzAdaptor
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// New creates an elector. With election enabled it connects to the Kubernetes API, using the
+// in-cluster configuration and falling back to the local kubeconfig.
+func New(cfg Config, callbacks Callbacks, log logger.Logger) (*Elector, error) {
+	if cfg.Identity == "" {
+		return nil, fmt.Errorf("leader election identity is required")
+	}
+	e := &Elector{
+		cfg:       cfg,
+		callbacks: callbacks,
+		logger:    log,
+		status:    Status{Identity: cfg.Identity},
+	}
+	if !cfg.Enabled {
+		return e, nil
+	}
+	if cfg.LeaseName == "" {
+		return nil, fmt.Errorf("leader election lease name is required")
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", clientcmd.RecommendedHomeFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubernetes config: %w", err)
+		}
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	e.lock = &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: cfg.LeaseName, Namespace: cfg.Namespace},
+		Client:     client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: cfg.Identity},
+	}
+	// Tolerate one missed renewal before reporting the leader as unhealthy
+	e.watchdog = leaderelection.NewLeaderHealthzAdaptor(cfg.RenewDeadline)
+	return e, nil
+}
+
+// Run takes part in the election until ctx is cancelled. A replica that loses leadership
+// rejoins the election as a candidate.
+func (e *Elector) Run(ctx context.Context) {
+	if !e.cfg.Enabled {
+		e.logger.Info("Leader election disabled, leading unconditionally", "identity", e.cfg.Identity)
+		e.onNewLeader(e.cfg.Identity)
+		go e.onStartedLeading(ctx) // Asynchronous, as with client-go
+		<-ctx.Done()
+		e.onStoppedLeading()
+		return
+	}
+
+	for ctx.Err() == nil {
+		le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            e.lock,
+			LeaseDuration:   e.cfg.LeaseDuration,
+			RenewDeadline:   e.cfg.RenewDeadline,
+			RetryPeriod:     e.cfg.RetryPeriod,
+			ReleaseOnCancel: true,
+			WatchDog:        e.watchdog,
+			Name:            e.cfg.LeaseName,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: e.onStartedLeading,
+				OnStoppedLeading: e.onStoppedLeading,
+				OnNewLeader:      e.onNewLeader,
+			},
+		})
+		if err != nil {
+			// Only invalid timing configuration gets here, which retrying cannot fix
+			e.logger.Error("Invalid leader election configuration", "lease", e.cfg.LeaseName, "error", err)
+			return
+		}
+		le.Run(ctx)
+	}
+}
+
+// IsLeader reports whether this replica currently holds the lease
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status.IsLeader
+}
+
+// Status returns the current election status
+func (e *Elector) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status
+}
+
+// Check returns an error if this replica believes it leads but has failed to renew its lease,
+// which means leader-only work may be running on two replicas. Followers are always healthy.
+func (e *Elector) Check() error {
+	if e.watchdog == nil {
+		return nil
+	}
+	return e.watchdog.Check(nil)
+}
+
+// WritePrometheus writes the election status as gauges in the Prometheus text format, so that
+// alerts can catch a lease with no leader or with more than one
+func (e *Elector) WritePrometheus(w io.Writer) error {
+	status := e.Status()
+	isLeader := 0
+	if status.IsLeader {
+		isLeader = 1
+	}
+	_, err := fmt.Fprintf(w, "# HELP leader_election_is_leader Whether this replica holds the lease (1) or not (0).\n"+
+		"# TYPE leader_election_is_leader gauge\n"+
+		"leader_election_is_leader{lease=%q,identity=%q} %d\n"+
+		"# HELP leader_election_transitions_total Times this replica gained leadership.\n"+
+		"# TYPE leader_election_transitions_total counter\n"+
+		"leader_election_transitions_total{lease=%q} %d\n",
+		e.cfg.LeaseName, e.cfg.Identity, isLeader, e.cfg.LeaseName, status.Transitions)
+	return err
+}
+
+func (e *Elector) onStartedLeading(ctx context.Context) {
+	e.mu.Lock()
+	e.status.IsLeader = true
+	e.status.Leader = e.cfg.Identity
+	e.status.LeadingSince = time.Now()
+	e.status.Transitions++
+	e.mu.Unlock()
+
+	e.logger.Info("Acquired leadership", "lease", e.cfg.LeaseName, "identity", e.cfg.Identity)
+	if e.callbacks.OnStartedLeading != nil {
+		e.callbacks.OnStartedLeading(ctx)
+	}
+}
+
+func (e *Elector) onStoppedLeading() {
+	e.mu.Lock()
+	wasLeader := e.status.IsLeader
+	e.status.IsLeader = false
+	e.status.LeadingSince = time.Time{}
+	e.mu.Unlock()
+
+	// client-go calls this on every exit from Run, including when leadership was never acquired
+	if !wasLeader {
+		return
+	}
+	e.logger.Info("Lost leadership", "lease", e.cfg.LeaseName, "identity", e.cfg.Identity)
+	if e.callbacks.OnStoppedLeading != nil {
+		e.callbacks.OnStoppedLeading()
+	}
+}
+
+func (e *Elector) onNewLeader(identity string) {
+	e.mu.Lock()
+	e.status.Leader = identity
+	e.mu.Unlock()
+
+	if identity != e.cfg.Identity {
+		e.logger.Info("Observed new leader", "lease", e.cfg.LeaseName, "leader", identity)
+	}
+	if e.callbacks.OnNewLeader != nil {
+		e.callbacks.OnNewLeader(identity)
+	}
+}
@@ -0,0 +1,224 @@
+// Package lifecycle runs the long-lived components of a service (servers, workers, publishers)
+// and shuts them down in order when the process is asked to stop, so no goroutine outlives it
+// unnoticed.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Component is a long-lived part of a service
+type Component struct {
+	Name string
+	// Start runs the component. It may block until ctx is cancelled (workers, Fiber's Listen) or
+	// return once the component is running (BaseGrpcServer.Start). An error stops the service.
+	// Nil for components started elsewhere that only need stopping.
+	Start func(ctx context.Context) error
+	// Stop stops the component before ctx's deadline. Nil for components that stop when the
+	// context passed to Start is cancelled.
+	Stop func(ctx context.Context) error
+}
+
+// StopFailure is a component that did not stop cleanly
+type StopFailure struct {
+	Name string
+	Err  error
+}
+
+// ShutdownError lists the components that failed to stop or were still running at the deadline
+type ShutdownError struct {
+	Failures []StopFailure
+}
+
+func (e *ShutdownError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		parts[i] = f.Name + ": " + f.Err.Error()
+	}
+	return "components failed to stop: " + strings.Join(parts, "; ")
+}
+
+// running is a registered component with its state once started
+type running struct {
+	Component
+	cancel context.CancelFunc
+	done   chan struct{} // Closed when Start returned
+}
+
+// Manager starts components together and stops them in reverse registration order, so register
+// dependencies first: the probe server, then workers, then the servers taking traffic.
+type Manager struct {
+	logger          logger.Logger
+	shutdownTimeout time.Duration
+	signals         []os.Signal
+
+	mu         sync.Mutex
+	components []*running
+	started    bool
+	stopping   bool
+	failure    error         // First error returned by a Start
+	stop       chan struct{} // Closed by Stop to end Run
+	stopOnce   sync.Once
+}
+
+// New creates a manager stopping on SIGINT and SIGTERM within SHUTDOWN_TIMEOUT (default 15s)
+func New(log logger.Logger) *Manager {
+	return &Manager{
+		logger:          log,
+		shutdownTimeout: utils.GetEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		signals:         []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		stop:            make(chan struct{}),
+	}
+}
+
+// Add registers a component. Components cannot be added once Run was called.
+func (m *Manager) Add(c Component) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		panic(fmt.Sprintf("lifecycle: component %q added after Run", c.Name))
+	}
+	m.components = append(m.components, &running{Component: c})
+}
+
+// Go registers a worker that runs until its context is cancelled, such as jobs.Worker.Run
+func (m *Manager) Go(name string, run func(ctx context.Context)) {
+	m.Add(Component{Name: name, Start: func(ctx context.Context) error {
+		run(ctx)
+		return nil
+	}})
+}
+
+// Run starts every component and blocks until ctx is cancelled, a termination signal arrives,
+// Stop is called or a component fails. It then shuts the components down and returns the failure
+// and any ShutdownError.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		return errors.New("lifecycle: Run called twice")
+	}
+	m.started = true
+	components := m.components
+	m.mu.Unlock()
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, c := range components {
+		var componentCtx context.Context
+		componentCtx, c.cancel = context.WithCancel(groupCtx)
+		c.done = make(chan struct{})
+		group.Go(func() error {
+			defer close(c.done)
+			if c.Start == nil {
+				return nil
+			}
+			if err := c.Start(componentCtx); err != nil && !m.isStopping() {
+				err = fmt.Errorf("%s: %w", c.Name, err)
+				m.fail(err)
+				return err
+			}
+			return nil
+		})
+	}
+	m.logger.Info("Service started", "components", len(components))
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, m.signals...)
+	defer signal.Stop(signals)
+	select {
+	case sig := <-signals:
+		m.logger.Info("Shutting down", "signal", sig.String())
+	case <-groupCtx.Done():
+		if err := m.firstFailure(); err != nil {
+			m.logger.Error("Shutting down after a component failed", "error", err)
+		} else {
+			m.logger.Info("Shutting down", "reason", context.Cause(groupCtx))
+		}
+	case <-m.stop:
+		m.logger.Info("Shutting down", "reason", "stop requested")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+	shutdownErr := m.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		m.logger.Error("Shutdown incomplete", "error", shutdownErr)
+	} else {
+		_ = group.Wait() // Every Start returned; releases the group's context
+		m.logger.Info("Service stopped")
+	}
+	return errors.Join(m.firstFailure(), shutdownErr)
+}
+
+// Stop makes Run shut the components down and return, e.g. from a handler or a test
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// Shutdown stops the components in reverse registration order: it cancels the component's
+// context, calls its Stop and waits for its Start to return. All of it shares ctx's deadline;
+// components that fail or are still running then are reported in a *ShutdownError. Run calls
+// Shutdown itself.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.stopping = true
+	components := m.components
+	m.mu.Unlock()
+
+	var failures []StopFailure
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		if c.done == nil {
+			continue // Never started
+		}
+		c.cancel()
+		if c.Stop != nil {
+			if err := c.Stop(ctx); err != nil {
+				failures = append(failures, StopFailure{Name: c.Name, Err: err})
+				continue
+			}
+		}
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			failures = append(failures, StopFailure{Name: c.Name, Err: fmt.Errorf("still running: %w", ctx.Err())})
+		}
+	}
+	if len(failures) > 0 {
+		return &ShutdownError{Failures: failures}
+	}
+	return nil
+}
+
+func (m *Manager) isStopping() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stopping
+}
+
+func (m *Manager) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failure == nil {
+		m.failure = err
+	}
+}
+
+func (m *Manager) firstFailure() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failure
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+)
+
+// contextKey is the context key of the request-scoped logger
+type contextKey struct{}
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being handled, which
+// outgoing calls forward for correlation
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithContext returns a copy of ctx carrying l. The gRPC server and the API gateway store a logger
+// enriched with the request_id, user_id and method of each request, so code further down logs
+// correlated entries without threading these fields through.
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx by WithContext, or fallback when there is none
+// (e.g. in background jobs); components pass their own logger as the fallback.
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+			return l
+		}
+	}
+	return fallback
+}
+
+// WithFields adds fields to the logger stored in ctx, e.g. the user_id once the caller is known.
+// It returns ctx unchanged when no logger is stored.
+func WithFields(ctx context.Context, args ...interface{}) context.Context {
+	l, ok := ctx.Value(contextKey{}).(Logger)
+	if !ok {
+		return ctx
+	}
+	return WithContext(ctx, l.With(args...))
+}
+
+// TraceFields returns the trace_id and span_id fields of a W3C traceparent header
+// ("00-<trace-id>-<parent-id>-<flags>"), or nil when it is missing or malformed
+func TraceFields(traceparent string) []interface{} {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 || !isHex(parts[1]) || !isHex(parts[2]) {
+		return nil
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return nil
+	}
+	return []interface{}{"trace_id", parts[1], "span_id", parts[2]}
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelController is implemented by loggers whose level can be changed at runtime
+type LevelController interface {
+	Level() LogLevel
+	SetLevel(level LogLevel) error
+}
+
+// ParseLogLevel parses a level name such as "debug" or "WARN"
+func ParseLogLevel(s string) (LogLevel, error) {
+	level := LogLevel(strings.ToLower(strings.TrimSpace(s)))
+	if _, err := level.zapLevel(); err != nil {
+		return "", err
+	}
+	return level, nil
+}
+
+// zapLevel converts the level to its zap counterpart
+func (l LogLevel) zapLevel() (zapcore.Level, error) {
+	switch l {
+	case LogLevelDebug:
+		return zapcore.DebugLevel, nil
+	case LogLevelInfo:
+		return zapcore.InfoLevel, nil
+	case LogLevelWarn:
+		return zapcore.WarnLevel, nil
+	case LogLevelError:
+		return zapcore.ErrorLevel, nil
+	case LogLevelFatal:
+		return zapcore.FatalLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("unknown log level %q (want debug, info, warn, error or fatal)", string(l))
+	}
+}
@@ -1,7 +1,6 @@
 package logger
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"time"
@@ -9,6 +8,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
+
+	"golang-microservices-boilerplate/pkg/utils"
 )
 
 // LogLevel defines the level of logging
@@ -30,11 +31,13 @@ const (
 // LogConfig contains configuration for the logger
 type LogConfig struct {
 	Level      LogLevel
-	Format     string // json or console
-	OutputPath string // stdout, stderr, or a file path
+	Format     string    // json or console
+	Schema     LogSchema // Field layout: default, ecs or otel (always JSON)
+	OutputPath string    // stdout, stderr, or a file path
 	AppName    string
 	AppEnv     string
 	FileConfig *LogFileConfig
+	RedactKeys []string // Key patterns whose values are masked (see Redactor); empty disables redaction
 }
 
 // LogFileConfig contains configuration for file logging
@@ -50,6 +53,7 @@ func DefaultLogConfig() *LogConfig {
 	return &LogConfig{
 		Level:      LogLevelInfo,
 		Format:     "console",
+		Schema:     LogSchemaDefault,
 		OutputPath: "stdout",
 		AppName:    "service",
 		AppEnv:     "development",
@@ -59,6 +63,7 @@ func DefaultLogConfig() *LogConfig {
 			MaxAge:     28,
 			Compress:   true,
 		},
+		RedactKeys: append([]string(nil), DefaultRedactKeys...),
 	}
 }
 
@@ -66,50 +71,29 @@ func DefaultLogConfig() *LogConfig {
 func LoadLogConfigFromEnv() *LogConfig {
 	config := DefaultLogConfig()
 
-	if level := os.Getenv("LOG_LEVEL"); level != "" {
-		config.Level = LogLevel(strings.ToLower(level))
-	}
-
-	if format := os.Getenv("LOG_FORMAT"); format != "" {
-		config.Format = strings.ToLower(format)
-	}
-
-	if output := os.Getenv("LOG_OUTPUT"); output != "" {
-		config.OutputPath = output
-	}
-
-	if name := os.Getenv("APP_NAME"); name != "" {
-		config.AppName = name
-	} else if name := os.Getenv("SERVER_APP_NAME"); name != "" {
-		config.AppName = name
-	}
+	config.Level = LogLevel(strings.ToLower(utils.GetEnv("LOG_LEVEL", string(config.Level))))
+	config.Format = strings.ToLower(utils.GetEnv("LOG_FORMAT", config.Format))
+	config.Schema = LogSchema(strings.ToLower(utils.GetEnv("LOG_SCHEMA", string(config.Schema))))
+	config.OutputPath = utils.GetEnv("LOG_OUTPUT", config.OutputPath)
+	config.AppName = utils.GetEnv("APP_NAME", utils.GetEnv("SERVER_APP_NAME", config.AppName))
+	config.AppEnv = utils.GetEnv("APP_ENV", config.AppEnv)
 
-	if env := os.Getenv("APP_ENV"); env != "" {
-		config.AppEnv = env
+	// LOG_REDACT_KEYS replaces the default patterns; set it empty to turn redaction off
+	if keys, ok := os.LookupEnv("LOG_REDACT_KEYS"); ok {
+		config.RedactKeys = strings.Split(keys, ",")
 	}
 
-	// File logging settings
-	if maxSizeStr := os.Getenv("LOG_FILE_MAX_SIZE"); maxSizeStr != "" {
-		if maxSize, err := fmt.Sscanf(maxSizeStr, "%d", &config.FileConfig.MaxSize); err != nil || maxSize <= 0 {
-			config.FileConfig.MaxSize = 100
-		}
+	// File logging settings; invalid values keep the defaults
+	if maxSize := utils.GetEnvInt("LOG_FILE_MAX_SIZE", config.FileConfig.MaxSize); maxSize > 0 {
+		config.FileConfig.MaxSize = maxSize
 	}
-
-	if maxBackupsStr := os.Getenv("LOG_FILE_MAX_BACKUPS"); maxBackupsStr != "" {
-		if maxBackups, err := fmt.Sscanf(maxBackupsStr, "%d", &config.FileConfig.MaxBackups); err != nil || maxBackups < 0 {
-			config.FileConfig.MaxBackups = 3
-		}
+	if maxBackups := utils.GetEnvInt("LOG_FILE_MAX_BACKUPS", config.FileConfig.MaxBackups); maxBackups >= 0 {
+		config.FileConfig.MaxBackups = maxBackups
 	}
-
-	if maxAgeStr := os.Getenv("LOG_FILE_MAX_AGE"); maxAgeStr != "" {
-		if maxAge, err := fmt.Sscanf(maxAgeStr, "%d", &config.FileConfig.MaxAge); err != nil || maxAge < 0 {
-			config.FileConfig.MaxAge = 28
-		}
-	}
-
-	if compressStr := os.Getenv("LOG_FILE_COMPRESS"); compressStr != "" {
-		config.FileConfig.Compress = compressStr == "true" || compressStr == "1"
+	if maxAge := utils.GetEnvInt("LOG_FILE_MAX_AGE", config.FileConfig.MaxAge); maxAge >= 0 {
+		config.FileConfig.MaxAge = maxAge
 	}
+	config.FileConfig.Compress = utils.GetEnvBool("LOG_FILE_COMPRESS", config.FileConfig.Compress)
 
 	return config
 }
@@ -128,12 +112,18 @@ type Logger interface {
 // ZapLogger implements the Logger interface using zap
 type ZapLogger struct {
 	logger *zap.SugaredLogger
+	level  zap.AtomicLevel
 }
 
 // NewLogger creates a new logger with the specified configuration
 func NewLogger(config *LogConfig) (Logger, error) {
 	var zapLogger *zap.Logger
 
+	schema, err := ParseLogSchema(string(config.Schema))
+	if err != nil {
+		return nil, err
+	}
+
 	// Create encoder config
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = "timestamp"
@@ -141,27 +131,12 @@ func NewLogger(config *LogConfig) (Logger, error) {
 		enc.AppendString(t.Format(time.RFC3339))
 	}
 
-	// Determine log level
-	var level zapcore.Level
-	switch config.Level {
-	case LogLevelDebug:
-		level = zapcore.DebugLevel
-	case LogLevelInfo:
-		level = zapcore.InfoLevel
-	case LogLevelWarn:
-		level = zapcore.WarnLevel
-	case LogLevelError:
-		level = zapcore.ErrorLevel
-	case LogLevelFatal:
-		level = zapcore.FatalLevel
-	default:
+	// The level is shared by every logger derived from this one and can be changed at runtime (see SetLevel)
+	level, err := config.Level.zapLevel()
+	if err != nil {
 		level = zapcore.InfoLevel
 	}
-
-	// Create a level enabler
-	levelEnabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= level
-	})
+	levelEnabler := zap.NewAtomicLevelAt(level)
 
 	// Setup output
 	var cores []zapcore.Core
@@ -170,6 +145,9 @@ func NewLogger(config *LogConfig) (Logger, error) {
 	if config.Format == "console" || config.OutputPath == "stdout" || config.OutputPath == "stderr" {
 		// Console encoder
 		consoleEncoder := zapcore.NewConsoleEncoder(encoderConfig)
+		if schema != LogSchemaDefault {
+			consoleEncoder = newSchemaEncoder(schema)
+		}
 
 		// Console output
 		var consoleOutput zapcore.WriteSyncer
@@ -187,6 +165,9 @@ func NewLogger(config *LogConfig) (Logger, error) {
 	if config.OutputPath != "stdout" && config.OutputPath != "stderr" && config.OutputPath != "" {
 		// JSON encoder for files
 		fileEncoder := zapcore.NewJSONEncoder(encoderConfig)
+		if schema != LogSchemaDefault {
+			fileEncoder = newSchemaEncoder(schema)
+		}
 
 		// File output with rotation
 		fileOutput := zapcore.AddSync(&lumberjack.Logger{
@@ -203,6 +184,9 @@ func NewLogger(config *LogConfig) (Logger, error) {
 
 	// Create a tee with all cores
 	core := zapcore.NewTee(cores...)
+	if redactor := NewRedactor(config.RedactKeys); redactor != nil {
+		core = &redactingCore{Core: core, redactor: redactor}
+	}
 
 	// Create logger with the tee
 	zapLogger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
@@ -213,7 +197,7 @@ func NewLogger(config *LogConfig) (Logger, error) {
 		zap.String("environment", config.AppEnv),
 	)
 
-	return &ZapLogger{logger: zapLogger.Sugar()}, nil
+	return &ZapLogger{logger: zapLogger.Sugar(), level: levelEnabler}, nil
 }
 
 // NewLoggerFromEnv creates a new logger with configuration from environment
@@ -248,10 +232,25 @@ func (l *ZapLogger) Fatal(msg string, args ...interface{}) {
 
 // With adds context fields to the logger
 func (l *ZapLogger) With(args ...interface{}) Logger {
-	return &ZapLogger{logger: l.logger.With(args...)}
+	return &ZapLogger{logger: l.logger.With(args...), level: l.level}
 }
 
 // Named adds a sub-scope to the logger
 func (l *ZapLogger) Named(name string) Logger {
-	return &ZapLogger{logger: l.logger.Named(name)}
+	return &ZapLogger{logger: l.logger.Named(name), level: l.level}
+}
+
+// Level returns the current minimum level
+func (l *ZapLogger) Level() LogLevel {
+	return LogLevel(l.level.Level().String())
+}
+
+// SetLevel changes the minimum level of this logger and of every logger derived from the same root
+func (l *ZapLogger) SetLevel(level LogLevel) error {
+	zapLevel, err := level.zapLevel()
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(zapLevel)
+	return nil
 }
@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultRedactKeys are the key patterns whose values never reach the logs unless LOG_REDACT_KEYS says otherwise
+var DefaultRedactKeys = []string{"password", "token", "secret", "authorization"}
+
+// RedactedValue replaces redacted values
+const RedactedValue = "[REDACTED]"
+
+// maxRedactDepth bounds the walk through nested values
+const maxRedactDepth = 32
+
+// Redactor masks the values of keys that contain one of its patterns (case-insensitive), in
+// structured fields, nested maps, slices and structs, and key=value or key: value pairs in text
+type Redactor struct {
+	keys []string
+	text *regexp.Regexp
+}
+
+// NewRedactor returns a redactor for the given key patterns, or nil (no redaction) when there are none
+func NewRedactor(keys []string) *Redactor {
+	var patterns, quoted []string
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			patterns = append(patterns, key)
+			quoted = append(quoted, regexp.QuoteMeta(key))
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	// The key, an optional closing quote, = or :, an optional opening quote or auth scheme, then the value
+	text := regexp.MustCompile(`(?i)([\w.-]*(?:` + strings.Join(quoted, "|") + `)[\w.-]*["']?\s*[:=]\s*["']?(?:(?:bearer|basic)\s+)?)([^\s"',;&}\]]+)`)
+	return &Redactor{keys: patterns, text: text}
+}
+
+// matchKey reports whether values of key must be redacted
+func (r *Redactor) matchKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactString masks the values of sensitive key=value or key: value pairs in s
+func (r *Redactor) RedactString(s string) string {
+	if !r.matchKey(s) {
+		return s
+	}
+	return r.text.ReplaceAllString(s, "${1}"+RedactedValue)
+}
+
+// Redact returns a copy of v with sensitive values masked. Maps, slices and pointers are walked;
+// structs are walked through their JSON form, so json tags decide the key names.
+func (r *Redactor) Redact(v interface{}) interface{} {
+	return r.redact(reflect.ValueOf(v), 0)
+}
+
+func (r *Redactor) redact(rv reflect.Value, depth int) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	if depth > maxRedactDepth {
+		return RedactedValue
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return r.redact(rv.Elem(), depth+1)
+	case reflect.String:
+		return r.RedactString(rv.String())
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return rv.Interface()
+		}
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if r.matchKey(key) {
+				out[key] = RedactedValue
+			} else {
+				out[key] = r.redact(iter.Value(), depth+1)
+			}
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface()
+		}
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = r.redact(rv.Index(i), depth+1)
+		}
+		return out
+	case reflect.Struct:
+		data, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return rv.Interface()
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return rv.Interface()
+		}
+		return r.redact(reflect.ValueOf(decoded), depth+1)
+	default:
+		return rv.Interface()
+	}
+}
+
+// field masks a field whose key is sensitive and redacts the text and nested values of the others
+func (r *Redactor) field(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.NamespaceType, zapcore.SkipType:
+		return f
+	}
+	if r.matchKey(f.Key) {
+		return zap.String(f.Key, RedactedValue)
+	}
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = r.RedactString(f.String)
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return zap.String(f.Key, r.RedactString(err.Error()))
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok {
+			return zap.String(f.Key, r.RedactString(s.String()))
+		}
+	case zapcore.ReflectType:
+		return zap.Any(f.Key, r.Redact(f.Interface))
+	}
+	return f
+}
+
+// redactingCore redacts entries before the wrapped core encodes them
+type redactingCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactingCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = c.redactor.field(f)
+	}
+	return out
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactFields(fields)), redactor: c.redactor}
+}
+
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.redactor.RedactString(ent.Message)
+	return c.Core.Write(ent, c.redactFields(fields))
+}
@@ -0,0 +1,252 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogSchema selects the layout of emitted log entries, so they can be shipped to an observability
+// stack as-is and correlated with traces
+type LogSchema string
+
+const (
+	// LogSchemaDefault keeps zap's own layout
+	LogSchemaDefault LogSchema = "default"
+	// LogSchemaECS emits Elastic Common Schema JSON
+	LogSchemaECS LogSchema = "ecs"
+	// LogSchemaOTel emits OpenTelemetry log records in the OTLP JSON encoding
+	LogSchemaOTel LogSchema = "otel"
+)
+
+// ecsVersion is the Elastic Common Schema version of the ECS layout
+const ecsVersion = "8.11.0"
+
+// ParseLogSchema parses a LOG_SCHEMA value; an empty value is the default schema
+func ParseLogSchema(s string) (LogSchema, error) {
+	switch schema := LogSchema(strings.ToLower(strings.TrimSpace(s))); schema {
+	case "":
+		return LogSchemaDefault, nil
+	case LogSchemaDefault, LogSchemaECS, LogSchemaOTel:
+		return schema, nil
+	default:
+		return "", fmt.Errorf("unknown log schema %q (want default, ecs or otel)", s)
+	}
+}
+
+// ecsFieldNames maps the field names used across the code base to their ECS names
+var ecsFieldNames = map[string]string{
+	"service":     "service.name",
+	"environment": "service.environment",
+	"trace_id":    "trace.id",
+	"span_id":     "span.id",
+	"request_id":  "http.request.id",
+	"user_id":     "user.id",
+	"error":       "error.message",
+}
+
+// otelResourceNames are the fields that describe the emitting service, with their resource attribute names
+var otelResourceNames = map[string]string{
+	"service":     "service.name",
+	"environment": "deployment.environment",
+}
+
+// otelFieldNames maps field names to OpenTelemetry semantic convention attribute names
+var otelFieldNames = map[string]string{
+	"user_id": "enduser.id",
+	"error":   "exception.message",
+}
+
+// otelSeverity is the OpenTelemetry severity number of each level
+var otelSeverity = map[zapcore.Level]int{
+	zapcore.DebugLevel:  5,
+	zapcore.InfoLevel:   9,
+	zapcore.WarnLevel:   13,
+	zapcore.ErrorLevel:  17,
+	zapcore.DPanicLevel: 21,
+	zapcore.PanicLevel:  21,
+	zapcore.FatalLevel:  21,
+}
+
+var schemaBufferPool = buffer.NewPool()
+
+// schemaEncoder collects an entry's fields in a map and writes them in the layout of its schema,
+// one JSON object per line
+type schemaEncoder struct {
+	*zapcore.MapObjectEncoder
+	schema LogSchema
+}
+
+func newSchemaEncoder(schema LogSchema) zapcore.Encoder {
+	return &schemaEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), schema: schema}
+}
+
+// Clone copies the fields added through With
+func (e *schemaEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+	return &schemaEncoder{MapObjectEncoder: clone, schema: e.schema}
+}
+
+// EncodeEntry renders the entry with its own and the accumulated fields
+func (e *schemaEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	all := e.Clone().(*schemaEncoder)
+	for _, field := range fields {
+		field.AddTo(all.MapObjectEncoder)
+	}
+
+	var record map[string]interface{}
+	if e.schema == LogSchemaOTel {
+		record = otelRecord(ent, all.Fields)
+	} else {
+		record = ecsRecord(ent, all.Fields)
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		// A reflected value that cannot be marshalled is logged as its string form
+		for k, v := range record {
+			if _, err := json.Marshal(v); err != nil {
+				record[k] = fmt.Sprintf("%+v", v)
+			}
+		}
+		if line, err = json.Marshal(record); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := schemaBufferPool.Get()
+	_, _ = buf.Write(line)
+	buf.AppendByte('\n')
+	return buf, nil
+}
+
+// ecsRecord lays out an entry as an ECS document with dotted field names
+func ecsRecord(ent zapcore.Entry, fields map[string]interface{}) map[string]interface{} {
+	record := map[string]interface{}{
+		"@timestamp":  ent.Time.UTC().Format(time.RFC3339Nano),
+		"log.level":   ent.Level.String(),
+		"message":     ent.Message,
+		"ecs.version": ecsVersion,
+	}
+	if ent.LoggerName != "" {
+		record["log.logger"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		record["log.origin.file.name"] = filepath.Base(ent.Caller.File)
+		record["log.origin.file.line"] = ent.Caller.Line
+	}
+	if ent.Stack != "" {
+		record["error.stack_trace"] = ent.Stack
+	}
+	for k, v := range fields {
+		if name, ok := ecsFieldNames[k]; ok {
+			k = name
+		}
+		record[k] = v
+	}
+	return record
+}
+
+// otelRecord lays out an entry as an OTLP JSON log record together with its resource
+func otelRecord(ent zapcore.Entry, fields map[string]interface{}) map[string]interface{} {
+	record := map[string]interface{}{
+		"timeUnixNano":   strconv.FormatInt(ent.Time.UnixNano(), 10),
+		"severityNumber": otelSeverity[ent.Level],
+		"severityText":   ent.Level.CapitalString(),
+		"body":           otelValue(ent.Message),
+	}
+	if ent.LoggerName != "" {
+		record["scope"] = map[string]interface{}{"name": ent.LoggerName}
+	}
+
+	resource := map[string]interface{}{}
+	attributes := map[string]interface{}{}
+	for k, v := range fields {
+		switch k {
+		case "trace_id":
+			record["traceId"] = v
+		case "span_id":
+			record["spanId"] = v
+		default:
+			if name, ok := otelResourceNames[k]; ok {
+				resource[name] = v
+			} else if name, ok := otelFieldNames[k]; ok {
+				attributes[name] = v
+			} else {
+				attributes[k] = v
+			}
+		}
+	}
+	if ent.Caller.Defined {
+		attributes["code.filepath"] = ent.Caller.File
+		attributes["code.lineno"] = ent.Caller.Line
+	}
+	if ent.Stack != "" {
+		attributes["exception.stacktrace"] = ent.Stack
+	}
+	record["resource"] = map[string]interface{}{"attributes": otelKeyValues(resource)}
+	if len(attributes) > 0 {
+		record["attributes"] = otelKeyValues(attributes)
+	}
+	return record
+}
+
+// otelKeyValues converts fields to OTLP key/value pairs, sorted by key
+func otelKeyValues(fields map[string]interface{}) []map[string]interface{} {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]map[string]interface{}, len(keys))
+	for i, k := range keys {
+		values[i] = map[string]interface{}{"key": k, "value": otelValue(fields[k])}
+	}
+	return values
+}
+
+// otelValue converts a field value to an OTLP AnyValue
+func otelValue(v interface{}) map[string]interface{} {
+	switch t := v.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": t}
+	case bool:
+		return map[string]interface{}{"boolValue": t}
+	case time.Time:
+		return map[string]interface{}{"stringValue": t.Format(time.RFC3339Nano)}
+	case time.Duration:
+		return map[string]interface{}{"stringValue": t.String()}
+	case map[string]interface{}:
+		return map[string]interface{}{"kvlistValue": map[string]interface{}{"values": otelKeyValues(t)}}
+	case []interface{}:
+		values := make([]map[string]interface{}, len(t))
+		for i, item := range t {
+			values[i] = otelValue(item)
+		}
+		return map[string]interface{}{"arrayValue": map[string]interface{}{"values": values}}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(rv.Int(), 10)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"intValue": strconv.FormatUint(rv.Uint(), 10)}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"doubleValue": rv.Float()}
+	}
+	if data, err := json.Marshal(v); err == nil {
+		return map[string]interface{}{"stringValue": string(data)}
+	}
+	return map[string]interface{}{"stringValue": fmt.Sprintf("%+v", v)}
+}
@@ -0,0 +1,132 @@
+// Package maintenance holds the maintenance mode switch shared by the gateway and the services.
+// While it is on, the gateway answers non-allowlisted routes with 503 and gRPC servers reject
+// mutating methods with Unavailable, so schema migrations can run without concurrent writes.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/cache"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// stateKey is the cache key of the shared state; it is not prefixed so every service sees it
+const stateKey = "maintenance:state"
+
+// State describes the maintenance mode
+type State struct {
+	Enabled    bool      `json:"enabled"`
+	Message    string    `json:"message,omitempty"`     // Shown to clients, e.g. "Upgrading the database"
+	RetryAfter int       `json:"retry_after,omitempty"` // Seconds clients should wait before retrying
+	Since      time.Time `json:"since,omitempty"`
+}
+
+// Config holds the maintenance settings
+type Config struct {
+	Enabled         bool          // Forces maintenance mode on regardless of the shared state
+	Message         string        // Default message
+	RetryAfter      int           // Default Retry-After, in seconds
+	RefreshInterval time.Duration // How long a replica reuses the shared state before reading it again
+}
+
+// LoadConfigFromEnv reads MAINTENANCE_MODE, MAINTENANCE_MESSAGE, MAINTENANCE_RETRY_AFTER (default 300)
+// and MAINTENANCE_REFRESH_INTERVAL (default 5s)
+func LoadConfigFromEnv() Config {
+	return Config{
+		Enabled:         utils.GetEnv("MAINTENANCE_MODE", "false") == "true",
+		Message:         utils.GetEnv("MAINTENANCE_MESSAGE", "The service is undergoing maintenance, please try again later"),
+		RetryAfter:      utils.GetEnvAsInt("MAINTENANCE_RETRY_AFTER", 300),
+		RefreshInterval: utils.GetEnvDuration("MAINTENANCE_REFRESH_INTERVAL", 5*time.Second),
+	}
+}
+
+// Switch reads and flips the maintenance mode. The state is kept in the shared cache (Redis when
+// CACHE_DRIVER=redis), so flipping it on one replica reaches every gateway and service within
+// RefreshInterval. MAINTENANCE_MODE=true turns it on independently of the cache.
+type Switch struct {
+	cache  cache.Cache
+	config Config
+	logger logger.Logger
+
+	mu       sync.Mutex
+	current  State
+	loadedAt time.Time
+}
+
+// NewSwitch creates a switch storing its state in c
+func NewSwitch(c cache.Cache, config Config, logger logger.Logger) *Switch {
+	return &Switch{cache: c, config: config, logger: logger}
+}
+
+// NewSwitchFromEnv creates a switch on the CACHE_* store, falling back to an in-memory store
+// (where only MAINTENANCE_MODE and this replica's Set calls apply)
+func NewSwitchFromEnv(logger logger.Logger) *Switch {
+	cacheConfig := cache.LoadConfigFromEnv()
+	cacheConfig.Prefix = "" // Shared by all services regardless of their key prefix
+	c, err := cache.NewFromConfig(cacheConfig)
+	if err != nil {
+		logger.Error("Maintenance state cache unavailable, falling back to in-memory", "error", err)
+		c = cache.New(cache.NewMemoryStore(cache.LoadMemoryConfigFromEnv()), "")
+	}
+	return NewSwitch(c, LoadConfigFromEnv(), logger)
+}
+
+// State returns the current maintenance state, read from the cache at most once per RefreshInterval.
+// If the cache cannot be read the last known state is kept.
+func (s *Switch) State(ctx context.Context) State {
+	if s.config.Enabled {
+		return State{Enabled: true, Message: s.config.Message, RetryAfter: s.config.RetryAfter}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.loadedAt.IsZero() && time.Since(s.loadedAt) < s.config.RefreshInterval {
+		return s.current
+	}
+	state, err := cache.GetJSON[State](ctx, s.cache, stateKey)
+	switch {
+	case errors.Is(err, cache.ErrMiss):
+		s.current = State{}
+	case err != nil:
+		s.logger.Warn("Failed to read maintenance state, keeping the last known state", "error", err)
+	default:
+		s.current = state
+	}
+	s.loadedAt = time.Now()
+	return s.current
+}
+
+// Enabled reports whether maintenance mode is on
+func (s *Switch) Enabled(ctx context.Context) bool {
+	return s.State(ctx).Enabled
+}
+
+// Set stores a new state for every replica. Empty Message and RetryAfter take the configured defaults.
+func (s *Switch) Set(ctx context.Context, state State) (State, error) {
+	if state.Enabled {
+		if state.Message == "" {
+			state.Message = s.config.Message
+		}
+		if state.RetryAfter <= 0 {
+			state.RetryAfter = s.config.RetryAfter
+		}
+		if state.Since.IsZero() {
+			state.Since = time.Now().UTC()
+		}
+	} else {
+		state = State{}
+	}
+	if err := cache.SetJSON(ctx, s.cache, stateKey, state, 0); err != nil {
+		return State{}, err
+	}
+
+	s.mu.Lock()
+	s.current, s.loadedAt = state, time.Now()
+	s.mu.Unlock()
+	s.logger.Warn("Maintenance mode changed", "enabled", state.Enabled, "message", state.Message)
+	return state, nil
+}
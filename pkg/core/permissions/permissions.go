@@ -0,0 +1,224 @@
+// Package permissions maps roles to what they may do. A permission is an action on a resource
+// ("users:delete"); roles grant permissions and inherit the permissions of other roles, so checks
+// ask for the permission an operation needs instead of listing every role allowed to perform it.
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"sigs.k8s.io/yaml"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Any matches every resource or every action
+const Any = "*"
+
+// Permission is an action on a resource, written "resource:action"
+type Permission struct {
+	Resource string
+	Action   string
+}
+
+// New creates a permission
+func New(resource, action string) Permission {
+	return Permission{Resource: strings.ToLower(resource), Action: strings.ToLower(action)}
+}
+
+// Parse parses "resource:action"
+func Parse(s string) (Permission, error) {
+	resource, action, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || resource == "" || action == "" {
+		return Permission{}, fmt.Errorf("invalid permission %q, expected resource:action", s)
+	}
+	return New(resource, action), nil
+}
+
+// MustParse is Parse for permissions declared in code; it panics on an invalid permission
+func MustParse(s string) Permission {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns "resource:action"
+func (p Permission) String() string {
+	return p.Resource + ":" + p.Action
+}
+
+// IsZero reports whether p is the zero Permission, i.e. no permission is required
+func (p Permission) IsZero() bool {
+	return p == Permission{}
+}
+
+// Covers reports whether holding p grants required, taking wildcards into account
+func (p Permission) Covers(required Permission) bool {
+	return (p.Resource == Any || p.Resource == required.Resource) && (p.Action == Any || p.Action == required.Action)
+}
+
+// RoleDefinition declares the permissions of a role
+type RoleDefinition struct {
+	Inherits    []string `json:"inherits,omitempty"` // Roles whose permissions this role also has
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// Config declares the roles by name
+type Config struct {
+	Roles map[string]RoleDefinition `json:"roles"`
+}
+
+// DefaultConfig mirrors the gateway's route policies: officers and managers read users and manage
+// their own exports and reports, admins may do everything
+var DefaultConfig = Config{Roles: map[string]RoleDefinition{
+	"officer": {Permissions: []string{"users:read", "exports:create", "exports:read", "reports:create", "reports:read", "events:read"}},
+	"manager": {Inherits: []string{"officer"}},
+	"admin":   {Inherits: []string{"manager"}, Permissions: []string{"*:*"}},
+}}
+
+// Matrix holds the resolved permissions of every role. It is built once and read-only, so it is
+// safe for concurrent use.
+type Matrix struct {
+	roles map[string][]Permission
+}
+
+// NewMatrix resolves the inheritance of config. It fails on invalid permissions, unknown inherited
+// roles and inheritance cycles.
+func NewMatrix(config Config) (*Matrix, error) {
+	defs := make(map[string]RoleDefinition, len(config.Roles))
+	for name, def := range config.Roles {
+		defs[strings.ToLower(name)] = def
+	}
+
+	m := &Matrix{roles: make(map[string][]Permission, len(defs))}
+	var resolve func(role string, path []string) ([]Permission, error)
+	resolve = func(role string, path []string) ([]Permission, error) {
+		if resolved, ok := m.roles[role]; ok {
+			return resolved, nil
+		}
+		if slices.Contains(path, role) {
+			return nil, fmt.Errorf("role inheritance cycle: %s", strings.Join(append(path, role), " -> "))
+		}
+		def, ok := defs[role]
+		if !ok {
+			return nil, fmt.Errorf("role %q inherits unknown role %q", path[len(path)-1], role)
+		}
+
+		var resolved []Permission
+		for _, s := range def.Permissions {
+			p, err := Parse(s)
+			if err != nil {
+				return nil, fmt.Errorf("role %q: %w", role, err)
+			}
+			resolved = append(resolved, p)
+		}
+		for _, parent := range def.Inherits {
+			inherited, err := resolve(strings.ToLower(parent), append(path, role))
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, inherited...)
+		}
+		sort.Slice(resolved, func(i, j int) bool { return resolved[i].String() < resolved[j].String() })
+		resolved = slices.Compact(resolved)
+		m.roles[role] = resolved
+		return resolved, nil
+	}
+	for role := range defs {
+		if _, err := resolve(role, nil); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Has reports whether role grants the permission. Unknown roles have no permissions.
+func (m *Matrix) Has(role string, required Permission) bool {
+	for _, p := range m.roles[strings.ToLower(role)] {
+		if p.Covers(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the permissions of role, including inherited ones, sorted
+func (m *Matrix) Permissions(role string) []Permission {
+	return slices.Clone(m.roles[strings.ToLower(role)])
+}
+
+// Roles returns the names of the defined roles, sorted
+func (m *Matrix) Roles() []string {
+	roles := make([]string, 0, len(m.roles))
+	for role := range m.roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// LoadFromEnv builds the matrix from the YAML or JSON file in PERMISSIONS_FILE, or from
+// DefaultConfig when it is not set
+func LoadFromEnv() (*Matrix, error) {
+	path := utils.GetEnv("PERMISSIONS_FILE", "")
+	if path == "" {
+		return NewMatrix(DefaultConfig)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permissions file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid permissions file %s: %w", path, err)
+	}
+	if len(config.Roles) == 0 {
+		return nil, errors.New("permissions file declares no roles")
+	}
+	return NewMatrix(config)
+}
+
+// UseFromEnv loads the matrix (see LoadFromEnv) and installs it with SetDefault. It has the
+// shape of a preflight check, so an invalid file is reported with the other configuration problems.
+func UseFromEnv(context.Context) error {
+	m, err := LoadFromEnv()
+	if err != nil {
+		return err
+	}
+	SetDefault(m)
+	return nil
+}
+
+var active atomic.Pointer[Matrix]
+
+// Default returns the matrix installed with SetDefault, or the one of DefaultConfig
+func Default() *Matrix {
+	if m := active.Load(); m != nil {
+		return m
+	}
+	m, err := NewMatrix(DefaultConfig)
+	if err != nil {
+		panic(err) // DefaultConfig is valid
+	}
+	active.CompareAndSwap(nil, m)
+	return active.Load()
+}
+
+// SetDefault installs the matrix used by Has and the permission checks of the middleware,
+// interceptors and use cases
+func SetDefault(m *Matrix) {
+	active.Store(m)
+}
+
+// Has reports whether role grants the permission in the default matrix
+func Has(role string, required Permission) bool {
+	return Default().Has(role, required)
+}
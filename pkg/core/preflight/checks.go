@@ -0,0 +1,99 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+)
+
+// MinSecretLength is the shortest secret accepted in production, which rules out placeholders
+// such as "changeme"
+const MinSecretLength = 32
+
+// RequiredEnv fails for each of names that is not set or empty
+func RequiredEnv(names ...string) Check {
+	return func(context.Context) error {
+		var errs []error
+		for _, name := range names {
+			if os.Getenv(name) == "" {
+				errs = append(errs, fmt.Errorf("%s is not set", name))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Secret checks the secret in the environment variable name: it must be set, differ from
+// developmentDefault and be at least MinSecretLength long. Outside production the problems are
+// warnings.
+func Secret(name, developmentDefault string) Check {
+	return func(context.Context) error {
+		return secretError(name, developmentDefault)
+	}
+}
+
+func secretError(name, developmentDefault string) error {
+	value := os.Getenv(name)
+	var err error
+	switch {
+	case value == "":
+		err = fmt.Errorf("%s is not set, the development default is used", name)
+	case developmentDefault != "" && value == developmentDefault:
+		err = fmt.Errorf("%s is set to the development default", name)
+	case len(value) < MinSecretLength:
+		err = fmt.Errorf("%s is shorter than %d characters", name, MinSecretLength)
+	default:
+		return nil
+	}
+	if !IsProduction() {
+		return Warning(err)
+	}
+	return err
+}
+
+// JWTSecrets checks ACCESS_TOKEN_SECRET and REFRESH_TOKEN_SECRET, which sign every token: with the
+// development defaults anyone can forge tokens. The two must also differ, or a refresh token
+// would be accepted as an access token.
+func JWTSecrets(context.Context) error {
+	errs := []error{
+		secretError("ACCESS_TOKEN_SECRET", middleware.DevelopmentAccessTokenSecret),
+		secretError("REFRESH_TOKEN_SECRET", middleware.DevelopmentRefreshTokenSecret),
+	}
+	if access := os.Getenv("ACCESS_TOKEN_SECRET"); access != "" && access == os.Getenv("REFRESH_TOKEN_SECRET") {
+		errs = append(errs, errors.New("ACCESS_TOKEN_SECRET and REFRESH_TOKEN_SECRET are the same"))
+	}
+	return errors.Join(errs...)
+}
+
+// PortAvailable fails when addr (e.g. ":9090") cannot be listened on, because another process
+// holds the port or the address is invalid. Register it before the server starts.
+func PortAvailable(addr string) Check {
+	return func(context.Context) error {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("cannot listen on %s: %w", addr, err)
+		}
+		return listener.Close()
+	}
+}
+
+// Reachable checks a TCP dependency at host:port. A host that does not resolve is a configuration
+// error; a dependency that resolves but does not answer is a warning, since it may still be
+// starting and the service retries it.
+func Reachable(host string, port int) Check {
+	return func(ctx context.Context) error {
+		if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+			return fmt.Errorf("host %q does not resolve: %w", host, err)
+		}
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, fmt.Sprint(port)))
+		if err != nil {
+			return Warning(fmt.Errorf("%s:%d is not reachable yet: %w", host, port, err))
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,145 @@
+// Package preflight validates a service's configuration before it starts: required settings,
+// secrets that must not keep their development defaults, free listen ports and resolvable
+// dependencies. Every check runs and the problems are reported together, instead of the service
+// failing later, one problem at a time, with an ambiguous runtime error.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Check validates one part of the configuration. Errors joined with errors.Join are reported as
+// separate problems; errors wrapped with Warning are reported without stopping the service.
+type Check func(ctx context.Context) error
+
+// Problem is a failed check
+type Problem struct {
+	Check   string
+	Err     error
+	Warning bool
+}
+
+func (p Problem) String() string {
+	return p.Check + ": " + p.Err.Error()
+}
+
+// Error lists the problems that stop the service from starting
+type Error struct {
+	Problems []Problem
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration, %d problem(s):", len(e.Problems))
+	for _, p := range e.Problems {
+		b.WriteString("\n  - " + p.String())
+	}
+	return b.String()
+}
+
+// warning marks an error as not fatal
+type warning struct{ err error }
+
+func (w *warning) Error() string { return w.err.Error() }
+func (w *warning) Unwrap() error { return w.err }
+
+// Warning marks err as not fatal: it is reported but the service still starts
+func Warning(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &warning{err: err}
+}
+
+// IsProduction reports whether APP_ENV is "production", where development defaults are errors
+func IsProduction() bool {
+	return strings.EqualFold(utils.GetEnv("APP_ENV", "development"), "production")
+}
+
+// Checker runs the registered checks and reports their problems in one log entry
+type Checker struct {
+	logger  logger.Logger
+	timeout time.Duration
+	names   []string
+	checks  []Check
+}
+
+// New creates a checker bounding each check by PREFLIGHT_CHECK_TIMEOUT (default 5s)
+func New(log logger.Logger) *Checker {
+	return &Checker{logger: log, timeout: utils.GetEnvDuration("PREFLIGHT_CHECK_TIMEOUT", 5*time.Second)}
+}
+
+// Check registers a check
+func (c *Checker) Check(name string, check Check) *Checker {
+	c.names = append(c.names, name)
+	c.checks = append(c.checks, check)
+	return c
+}
+
+// Run runs every check and logs the problems found. It returns an *Error listing the fatal
+// problems, or nil when there are only warnings. PREFLIGHT_ENABLED=false skips the checks.
+func (c *Checker) Run(ctx context.Context) error {
+	if utils.GetEnv("PREFLIGHT_ENABLED", "true") != "true" {
+		c.logger.Warn("Preflight checks disabled")
+		return nil
+	}
+
+	var problems []Problem
+	for i, check := range c.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := check(checkCtx)
+		cancel()
+		for _, err := range split(err) {
+			var w *warning
+			if errors.As(err, &w) {
+				problems = append(problems, Problem{Check: c.names[i], Err: w.err, Warning: true})
+			} else {
+				problems = append(problems, Problem{Check: c.names[i], Err: err})
+			}
+		}
+	}
+
+	var fatal []Problem
+	var warnings, errs []string
+	for _, p := range problems {
+		if p.Warning {
+			warnings = append(warnings, p.String())
+		} else {
+			fatal = append(fatal, p)
+			errs = append(errs, p.String())
+		}
+	}
+	switch {
+	case len(fatal) > 0:
+		c.logger.Error("Preflight checks failed", "checks", len(c.checks), "errors", errs, "warnings", warnings)
+		return &Error{Problems: fatal}
+	case len(warnings) > 0:
+		c.logger.Warn("Preflight checks passed with warnings", "checks", len(c.checks), "warnings", warnings)
+	default:
+		c.logger.Info("Preflight checks passed", "checks", len(c.checks))
+	}
+	return nil
+}
+
+// split returns the errors joined in err, each a problem of its own
+func split(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, split(err)...)
+	}
+	return errs
+}
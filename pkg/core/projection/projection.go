@@ -0,0 +1,202 @@
+// Package projection maintains read models: denormalized, query-optimized tables kept up to date
+// from domain events, for dashboard and list endpoints that are too expensive to serve from the
+// normalized tables. A Projection applies events to its tables; a Projector feeds it events from a
+// bus, records a checkpoint per projection and rebuilds projections from a replay Source.
+package projection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"golang-microservices-boilerplate/pkg/core/events"
+	"golang-microservices-boilerplate/pkg/core/logger"
+)
+
+// Projection maintains one read model
+type Projection interface {
+	// Name identifies the projection in checkpoints and logs; it must be unique per service
+	Name() string
+	// EventTypes lists the events the projection consumes
+	EventTypes() []string
+	// Apply updates the read model for one event, using tx for every write
+	Apply(ctx context.Context, tx *gorm.DB, event events.Event) error
+	// Reset empties the read model before a rebuild
+	Reset(ctx context.Context, tx *gorm.DB) error
+}
+
+// Source replays events for a rebuild by calling emit for each one, in order, and stops at the
+// first error emit returns. Sources typically read the current state of the write tables and emit
+// synthetic events for it (e.g. one "user.changed" create per user).
+type Source func(ctx context.Context, emit func(event events.Event) error) error
+
+// Checkpoint records the last event applied to a projection
+type Checkpoint struct {
+	Name        string     `json:"name" gorm:"primaryKey;size:100"`
+	LastEventID *uuid.UUID `json:"last_event_id,omitempty" gorm:"type:uuid"`
+	LastEventAt *time.Time `json:"last_event_at,omitempty"`
+	Applied     int64      `json:"applied" gorm:"not null;default:0"` // Events applied since the last rebuild
+	// LastError is set when an event failed to apply; the read model may be stale until it is rebuilt
+	LastError string     `json:"last_error,omitempty" gorm:"type:text"`
+	RebuiltAt *time.Time `json:"rebuilt_at,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (Checkpoint) TableName() string {
+	return "projection_checkpoints"
+}
+
+// Models returns the models of the projection tables, for database.RegisterModels
+func Models() []interface{} {
+	return []interface{}{&Checkpoint{}}
+}
+
+// Projector runs projections against a database
+type Projector struct {
+	db          *gorm.DB
+	logger      logger.Logger
+	mu          sync.RWMutex
+	projections map[string]*registered
+}
+
+// registered is a projection and the lock that keeps live events out while it is rebuilt
+type registered struct {
+	projection Projection
+	mu         sync.Mutex
+}
+
+// NewProjector creates a projector writing read models and checkpoints to db
+func NewProjector(db *gorm.DB, logger logger.Logger) *Projector {
+	return &Projector{
+		db:          db,
+		logger:      logger,
+		projections: make(map[string]*registered),
+	}
+}
+
+// Register adds projections; registering a name twice replaces the earlier projection
+func (p *Projector) Register(projections ...Projection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, projection := range projections {
+		p.projections[projection.Name()] = &registered{projection: projection}
+	}
+}
+
+// ConsumerPrefix is prepended to a projection's name to form its bus consumer name
+const ConsumerPrefix = "projection:"
+
+// Attach subscribes every registered projection to its event types on the bus. Events are applied
+// in the publishing goroutine; a failure is logged and recorded in the checkpoint.
+func (p *Projector) Attach(bus events.Bus) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, r := range p.projections {
+		for _, eventType := range r.projection.EventTypes() {
+			bus.SubscribeAs(ConsumerPrefix+r.projection.Name(), eventType, p.handler(r))
+		}
+	}
+}
+
+// handler applies live events to one projection
+func (p *Projector) handler(r *registered) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if err := p.apply(ctx, p.db, r.projection, event); err != nil {
+			p.recordFailure(ctx, r.projection.Name(), err)
+			return fmt.Errorf("projection %s: %w", r.projection.Name(), err)
+		}
+		return nil
+	}
+}
+
+// apply runs Apply and advances the checkpoint in one transaction
+func (p *Projector) apply(ctx context.Context, db *gorm.DB, projection Projection, event events.Event) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := projection.Apply(ctx, tx, event); err != nil {
+			return err
+		}
+		eventID, occurredAt := event.ID, event.OccurredAt
+		checkpoint := Checkpoint{Name: projection.Name(), LastEventID: &eventID, LastEventAt: &occurredAt, Applied: 1}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"last_event_id": eventID,
+				"last_event_at": occurredAt,
+				"applied":       gorm.Expr("projection_checkpoints.applied + 1"),
+				"last_error":    "",
+				"updated_at":    time.Now().UTC(),
+			}),
+		}).Create(&checkpoint).Error
+	})
+}
+
+// recordFailure stores the error of a failed event in the projection's checkpoint
+func (p *Projector) recordFailure(ctx context.Context, name string, cause error) {
+	checkpoint := Checkpoint{Name: name, LastError: cause.Error()}
+	err := p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_error", "updated_at"}),
+	}).Create(&checkpoint).Error
+	if err != nil {
+		p.logger.Error("Failed to record projection failure", "projection", name, "error", err)
+	}
+}
+
+// Rebuild empties a projection and replays source into it in a single transaction, so readers
+// see either the old or the rebuilt read model. Live events for the projection wait until the
+// rebuild finished.
+func (p *Projector) Rebuild(ctx context.Context, name string, source Source) error {
+	p.mu.RLock()
+	r, ok := p.projections[name]
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("projection %s is not registered", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start := time.Now()
+	var replayed int64
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.projection.Reset(ctx, tx); err != nil {
+			return fmt.Errorf("reset: %w", err)
+		}
+		if err := tx.Where("name = ?", name).Delete(&Checkpoint{}).Error; err != nil {
+			return err
+		}
+		err := source(ctx, func(event events.Event) error {
+			replayed++
+			return p.apply(ctx, tx, r.projection, event)
+		})
+		if err != nil {
+			return fmt.Errorf("replay: %w", err)
+		}
+		rebuiltAt := time.Now().UTC()
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rebuilt_at", "updated_at"}),
+		}).Create(&Checkpoint{Name: name, RebuiltAt: &rebuiltAt}).Error
+	})
+	if err != nil {
+		p.logger.Error("Projection rebuild failed", "projection", name, "error", err)
+		return fmt.Errorf("projection %s: %w", name, err)
+	}
+	p.logger.Info("Projection rebuilt", "projection", name, "events", replayed, "duration", time.Since(start))
+	return nil
+}
+
+// Checkpoints returns the checkpoints of all projections that applied an event or were rebuilt
+func (p *Projector) Checkpoints(ctx context.Context) ([]Checkpoint, error) {
+	var checkpoints []Checkpoint
+	err := p.db.WithContext(ctx).Order("name").Find(&checkpoints).Error
+	return checkpoints, err
+}
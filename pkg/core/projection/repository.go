@@ -0,0 +1,29 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// ReadRepository queries a read model table. It has no write methods: a read model is only
+// changed by its projection.
+type ReadRepository[T entity.Entity] interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*T, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*T, error)
+	FindAll(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error)
+	FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error)
+	FindOneWithFilter(ctx context.Context, filter map[string]interface{}) (*T, error)
+	Count(ctx context.Context, filter map[string]interface{}) (int64, error)
+}
+
+// NewReadRepository creates a read repository for the read model T, with the filtering,
+// sorting and pagination of repository.GormBaseRepository
+func NewReadRepository[T entity.Entity](db *gorm.DB) ReadRepository[T] {
+	return repository.NewGormBaseRepository[T](db)
+}
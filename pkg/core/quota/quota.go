@@ -0,0 +1,266 @@
+// Package quota enforces usage limits per subject, where a subject is whatever a limit applies to:
+// a tenant, a user, or Global for service-wide limits. A quota either counts usage itself in
+// windows of a fixed period ("max 5 uploads per day") or measures it from the data with a Count
+// function ("max 10k users per tenant").
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// Global is the subject of service-wide quotas
+const Global = "global"
+
+// Definition describes one quota
+type Definition struct {
+	Name  string
+	Limit int64 // Hard limit; usage beyond it is refused. 0 means unlimited.
+	// Soft is a warning threshold below Limit: usage above it is allowed, logged and flagged in reports
+	Soft int64
+	// Period is the length of the counting window (e.g. 24h); counters start from zero in each window.
+	// Zero counts for the lifetime of the subject.
+	Period time.Duration
+	// Count measures the current usage from the data instead of a counter, e.g. by counting rows.
+	// Such quotas are checked, not counted: Enforce does not record anything and Release does nothing.
+	// With a store implementing Locker, Enforce takes the quota's lock before counting, so call it
+	// in the transaction that writes the counted rows: concurrent callers then wait until it ends
+	// and count its rows, instead of passing the limit together.
+	Count func(ctx context.Context, subject string) (int64, error)
+	// Overrides replaces Limit for individual subjects
+	Overrides map[string]int64
+}
+
+// LimitFor returns the hard limit of a subject
+func (d Definition) LimitFor(subject string) int64 {
+	if limit, ok := d.Overrides[subject]; ok {
+		return limit
+	}
+	return d.Limit
+}
+
+// window returns the start of the counting window containing t
+func (d Definition) window(t time.Time) time.Time {
+	if d.Period <= 0 {
+		return time.Time{}
+	}
+	return t.UTC().Truncate(d.Period)
+}
+
+// Usage reports the state of one quota for a subject
+type Usage struct {
+	Name     string
+	Subject  string
+	Used     int64
+	Limit    int64 // 0 means unlimited
+	Soft     int64
+	Period   time.Duration
+	ResetsAt *time.Time // End of the current window; nil for quotas without a period
+}
+
+// OverSoft reports whether usage passed the soft threshold
+func (u Usage) OverSoft() bool {
+	return u.Soft > 0 && u.Used > u.Soft
+}
+
+// Remaining returns the usage left before the hard limit, or -1 when unlimited
+func (u Usage) Remaining() int64 {
+	if u.Limit <= 0 {
+		return -1
+	}
+	return max(u.Limit-u.Used, 0)
+}
+
+// Manager enforces a set of quota definitions
+type Manager struct {
+	store       Store
+	logger      logger.Logger
+	now         func() time.Time
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// NewManager creates a manager keeping its counters in store
+func NewManager(store Store, logger logger.Logger) *Manager {
+	return &Manager{
+		store:       store,
+		logger:      logger,
+		now:         time.Now,
+		definitions: make(map[string]Definition),
+	}
+}
+
+// Define adds quotas; defining a name twice replaces the earlier definition
+func (m *Manager) Define(definitions ...Definition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range definitions {
+		m.definitions[d.Name] = d
+	}
+}
+
+// Pruner is implemented by stores that can delete the counters of windows that started before a
+// time, such as GormStore
+type Pruner interface {
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RunPruner deletes, every interval, the counters of windows that have ended, until ctx is
+// cancelled. Windows of the longest period are kept, so no current window is lost. One replica is
+// enough, e.g. the leader (see pkg/core/leaderelection). It returns at once if the store cannot
+// prune.
+func (m *Manager) RunPruner(ctx context.Context, interval time.Duration) {
+	pruner, ok := m.store.(Pruner)
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if longest := m.longestPeriod(); longest > 0 {
+			pruned, err := pruner.Prune(ctx, m.now().UTC().Add(-longest))
+			if err != nil && ctx.Err() == nil {
+				m.logger.Error("Failed to prune quota counters", "error", err)
+			} else if pruned > 0 {
+				m.logger.Info("Pruned quota counters", "counters", pruned)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// longestPeriod returns the longest period of the counted quotas, or 0 if none has one
+func (m *Manager) longestPeriod() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var longest time.Duration
+	for _, d := range m.definitions {
+		longest = max(longest, d.Period)
+	}
+	return longest
+}
+
+// definition returns the named quota
+func (m *Manager) definition(name string) (Definition, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.definitions[name]
+	return d, ok
+}
+
+// Enforce accounts for n more units of the named quota used by subject. Counted quotas are
+// incremented atomically, so concurrent callers cannot overshoot the limit together. When the
+// limit would be exceeded nothing is recorded and a use case error is returned: ErrResourceExhausted
+// for quotas with a period (retry in the next window) and ErrConflict for lifetime quotas. Unknown
+// quotas are not enforced. Dry runs only check the limit. Quotas measured with Count are checked
+// under the store's lock (see Locker), held until the transaction of ctx ends.
+func (m *Manager) Enforce(ctx context.Context, name, subject string, n int64) error {
+	d, ok := m.definition(name)
+	if !ok || n <= 0 {
+		return nil
+	}
+	limit := d.LimitFor(subject)
+
+	var used int64
+	allowed := true
+	switch {
+	case d.Count != nil:
+		if locker, ok := m.store.(Locker); ok && !usecase.IsDryRun(ctx) {
+			if err := locker.Lock(ctx, Key{Quota: name, Subject: subject}); err != nil {
+				m.logger.Error("Failed to lock quota", "quota", name, "subject", subject, "error", err)
+				return usecase.NewUseCaseError(usecase.ErrInternal, "failed to check quota")
+			}
+		}
+		current, err := d.Count(ctx, subject)
+		if err != nil {
+			m.logger.Error("Failed to measure quota usage", "quota", name, "subject", subject, "error", err)
+			return usecase.NewUseCaseError(usecase.ErrInternal, "failed to check quota")
+		}
+		used, allowed = current+n, limit <= 0 || current+n <= limit
+	case usecase.IsDryRun(ctx):
+		current, err := m.store.Get(ctx, Key{Quota: name, Subject: subject, Window: d.window(m.now())})
+		if err != nil {
+			m.logger.Error("Failed to read quota counter", "quota", name, "subject", subject, "error", err)
+			return usecase.NewUseCaseError(usecase.ErrInternal, "failed to check quota")
+		}
+		used, allowed = current+n, limit <= 0 || current+n <= limit
+	default:
+		var err error
+		used, allowed, err = m.store.Increment(ctx, Key{Quota: name, Subject: subject, Window: d.window(m.now())}, n, limit)
+		if err != nil {
+			m.logger.Error("Failed to increment quota counter", "quota", name, "subject", subject, "error", err)
+			return usecase.NewUseCaseError(usecase.ErrInternal, "failed to check quota")
+		}
+	}
+
+	if !allowed {
+		m.logger.Warn("Quota exceeded", "quota", name, "subject", subject, "requested", n, "limit", limit)
+		errorType := usecase.ErrConflict
+		if d.Period > 0 {
+			errorType = usecase.ErrResourceExhausted
+		}
+		return usecase.NewLocalizedError(errorType, "quota.exceeded", map[string]string{
+			"quota": name,
+			"limit": strconv.FormatInt(limit, 10),
+		})
+	}
+	if d.Soft > 0 && used > d.Soft {
+		m.logger.Warn("Soft quota exceeded", "quota", name, "subject", subject, "used", used, "soft", d.Soft, "limit", limit)
+	}
+	return nil
+}
+
+// Release gives back n units of a counted quota, e.g. after the write they were enforced for failed
+// or the counted resource was deleted. It does nothing for quotas measured with Count.
+func (m *Manager) Release(ctx context.Context, name, subject string, n int64) {
+	d, ok := m.definition(name)
+	if !ok || d.Count != nil || n <= 0 || usecase.IsDryRun(ctx) {
+		return
+	}
+	if err := m.store.Decrement(ctx, Key{Quota: name, Subject: subject, Window: d.window(m.now())}, n); err != nil {
+		m.logger.Warn("Failed to release quota", "quota", name, "subject", subject, "amount", n, "error", err)
+	}
+}
+
+// Usage reports every defined quota for subject, sorted by name
+func (m *Manager) Usage(ctx context.Context, subject string) ([]Usage, error) {
+	m.mu.RLock()
+	definitions := make([]Definition, 0, len(m.definitions))
+	for _, d := range m.definitions {
+		definitions = append(definitions, d)
+	}
+	m.mu.RUnlock()
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+
+	now := m.now()
+	usages := make([]Usage, 0, len(definitions))
+	for _, d := range definitions {
+		usage := Usage{Name: d.Name, Subject: subject, Limit: d.LimitFor(subject), Soft: d.Soft, Period: d.Period}
+		var err error
+		if d.Count != nil {
+			usage.Used, err = d.Count(ctx, subject)
+		} else {
+			usage.Used, err = m.store.Get(ctx, Key{Quota: d.Name, Subject: subject, Window: d.window(now)})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("quota %s: %w", d.Name, err)
+		}
+		if d.Period > 0 {
+			resetsAt := d.window(now).Add(d.Period)
+			usage.ResetsAt = &resetsAt
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
@@ -0,0 +1,222 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"golang-microservices-boilerplate/pkg/core/repository"
+)
+
+// Key identifies one usage counter
+type Key struct {
+	Quota   string
+	Subject string
+	Window  time.Time // Start of the counting window; zero for quotas without a period
+}
+
+// Store keeps usage counters
+type Store interface {
+	// Increment adds n to the counter unless the result would exceed limit (0 means unlimited). It
+	// returns the counter after the increment, or the requested total when it was refused.
+	Increment(ctx context.Context, key Key, n, limit int64) (used int64, allowed bool, err error)
+	// Decrement subtracts n from the counter, stopping at zero
+	Decrement(ctx context.Context, key Key, n int64) error
+	// Get returns the counter, or zero if it does not exist
+	Get(ctx context.Context, key Key) (int64, error)
+}
+
+// Locker is implemented by stores that can serialize the checks of a quota measured with Count,
+// such as GormStore
+type Locker interface {
+	// Lock blocks until no other transaction holds the lock of key, and holds it until the
+	// transaction of ctx (see repository.WithTx) ends
+	Lock(ctx context.Context, key Key) error
+}
+
+// ErrNoTransaction is returned by GormStore.Lock when ctx carries no transaction to hold the lock
+var ErrNoTransaction = errors.New("quota lock requires a transaction")
+
+// Counter is a usage counter row
+type Counter struct {
+	Quota       string    `json:"quota" gorm:"primaryKey;size:100"`
+	Subject     string    `json:"subject" gorm:"primaryKey;size:255"`
+	WindowStart time.Time `json:"window_start" gorm:"primaryKey"`
+	Used        int64     `json:"used" gorm:"not null;default:0"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (Counter) TableName() string {
+	return "quota_counters"
+}
+
+// Models returns the models of the quota tables, for database.RegisterModels
+func Models() []interface{} {
+	return []interface{}{&Counter{}}
+}
+
+// GormStore keeps counters in the quota_counters table, shared by every replica
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a store on db
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// Increment implements Store with a single conditional upsert, so concurrent increments cannot
+// pass the limit together. Databases without INSERT ... ON CONFLICT ... RETURNING (MySQL) lock
+// the counter row instead.
+func (s *GormStore) Increment(ctx context.Context, key Key, n, limit int64) (int64, bool, error) {
+	if limit > 0 && n > limit {
+		current, err := s.Get(ctx, key)
+		return current + n, false, err
+	}
+	if name := s.db.Dialector.Name(); name != "postgres" && name != "sqlite" {
+		return s.lockedIncrement(ctx, key, n, limit)
+	}
+	query := `INSERT INTO quota_counters (quota, subject, window_start, used, updated_at) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (quota, subject, window_start) DO UPDATE SET used = quota_counters.used + EXCLUDED.used, updated_at = EXCLUDED.updated_at`
+	args := []interface{}{key.Quota, key.Subject, key.Window, n, time.Now().UTC()}
+	if limit > 0 {
+		query += ` WHERE quota_counters.used + EXCLUDED.used <= ?`
+		args = append(args, limit)
+	}
+	query += ` RETURNING used`
+
+	var used []int64
+	if err := s.db.WithContext(ctx).Raw(query, args...).Scan(&used).Error; err != nil {
+		return 0, false, err
+	}
+	if len(used) == 0 { // The WHERE clause refused the update
+		current, err := s.Get(ctx, key)
+		return current + n, false, err
+	}
+	return used[0], true, nil
+}
+
+// lockedIncrement is Increment for databases without a conditional upsert: it makes sure the row
+// exists, then reads and updates it under a row lock
+func (s *GormStore) lockedIncrement(ctx context.Context, key Key, n, limit int64) (int64, bool, error) {
+	var used int64
+	allowed := true
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		counter := Counter{Quota: key.Quota, Subject: key.Subject, WindowStart: key.Window, UpdatedAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&counter).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("quota = ? AND subject = ? AND window_start = ?", key.Quota, key.Subject, key.Window).
+			First(&counter).Error; err != nil {
+			return err
+		}
+		used = counter.Used + n
+		if limit > 0 && used > limit {
+			allowed = false
+			return nil
+		}
+		return tx.Model(&Counter{}).
+			Where("quota = ? AND subject = ? AND window_start = ?", key.Quota, key.Subject, key.Window).
+			Updates(map[string]interface{}{"used": used, "updated_at": now}).Error
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return used, allowed, nil
+}
+
+// Lock implements Locker by locking the counter row of key in the transaction of ctx, creating it
+// if needed
+func (s *GormStore) Lock(ctx context.Context, key Key) error {
+	tx, ok := repository.TxFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+	tx = tx.WithContext(ctx)
+	counter := Counter{Quota: key.Quota, Subject: key.Subject, WindowStart: key.Window, UpdatedAt: time.Now().UTC()}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&counter).Error; err != nil {
+		return err
+	}
+	var locked Counter
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("quota = ? AND subject = ? AND window_start = ?", key.Quota, key.Subject, key.Window).
+		First(&locked).Error
+}
+
+// Decrement implements Store
+func (s *GormStore) Decrement(ctx context.Context, key Key, n int64) error {
+	return s.db.WithContext(ctx).Model(&Counter{}).
+		Where("quota = ? AND subject = ? AND window_start = ?", key.Quota, key.Subject, key.Window).
+		Updates(map[string]interface{}{
+			"used":       gorm.Expr("CASE WHEN used > ? THEN used - ? ELSE 0 END", n, n), // GREATEST is not portable
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// Get implements Store
+func (s *GormStore) Get(ctx context.Context, key Key) (int64, error) {
+	var used []int64
+	err := s.db.WithContext(ctx).Model(&Counter{}).
+		Where("quota = ? AND subject = ? AND window_start = ?", key.Quota, key.Subject, key.Window).
+		Pluck("used", &used).Error
+	if err != nil || len(used) == 0 {
+		return 0, err
+	}
+	return used[0], nil
+}
+
+// Prune deletes the counters of windows that started before the given time; lifetime counters are kept
+func (s *GormStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("window_start > ? AND window_start < ?", time.Time{}, before).
+		Delete(&Counter{})
+	return result.RowsAffected, result.Error
+}
+
+// MemoryStore keeps counters in process memory, for tests and single-replica deployments
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[Key]int64
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[Key]int64)}
+}
+
+// Increment implements Store
+func (s *MemoryStore) Increment(_ context.Context, key Key, n, limit int64) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key.Window = key.Window.UTC()
+	used := s.counters[key] + n
+	if limit > 0 && used > limit {
+		return used, false, nil
+	}
+	s.counters[key] = used
+	return used, true, nil
+}
+
+// Decrement implements Store
+func (s *MemoryStore) Decrement(_ context.Context, key Key, n int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key.Window = key.Window.UTC()
+	s.counters[key] = max(s.counters[key]-n, 0)
+	return nil
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(_ context.Context, key Key) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key.Window = key.Window.UTC()
+	return s.counters[key], nil
+}
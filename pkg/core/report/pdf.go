@@ -0,0 +1,218 @@
+package report
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// PDF layout: A4 landscape in points, with the standard Helvetica fonts every reader has built in.
+// Those fonts cover Latin-1; other characters are printed as '?'.
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 36.0
+	pdfFontSize   = 9.0
+	pdfLineHeight = 13.0
+	// pdfCharWidth approximates the average Helvetica glyph width, in ems, for truncating cells
+	pdfCharWidth = 0.55
+)
+
+// pdfPage accumulates the content stream of one page
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+// pdfLayout places text on pages top to bottom, starting a new page when one is full
+type pdfLayout struct {
+	pages []*pdfPage
+	y     float64
+}
+
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, &pdfPage{})
+	l.y = pdfPageHeight - pdfMargin
+}
+
+// ensure starts a new page unless height points are left above the bottom margin (and the footer)
+func (l *pdfLayout) ensure(height float64) bool {
+	if len(l.pages) == 0 || l.y-height < pdfMargin+pdfLineHeight {
+		l.newPage()
+		return true
+	}
+	return false
+}
+
+// text writes s at x on the current line
+func (l *pdfLayout) text(x float64, s string, size float64, bold bool) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	page := l.pages[len(l.pages)-1]
+	fmt.Fprintf(&page.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, l.y, pdfString(s))
+}
+
+// rule draws a horizontal line below the current line
+func (l *pdfLayout) rule() {
+	page := l.pages[len(l.pages)-1]
+	y := l.y - 3
+	fmt.Fprintf(&page.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y, pdfPageWidth-pdfMargin, y)
+}
+
+// renderPDF lays out the title and tables and writes the PDF file
+func renderPDF(w io.Writer, doc *Document) error {
+	layout := &pdfLayout{}
+	layout.newPage()
+
+	layout.y -= 16
+	layout.text(pdfMargin, doc.Title, 16, true)
+	layout.y -= pdfLineHeight
+	if doc.Subtitle != "" {
+		layout.y -= 4
+		layout.text(pdfMargin, doc.Subtitle, 10, false)
+		layout.y -= pdfLineHeight
+	}
+	if !doc.GeneratedAt.IsZero() {
+		layout.text(pdfMargin, "Generated "+formatCell(doc.GeneratedAt)+" UTC", 8, false)
+		layout.y -= pdfLineHeight
+	}
+
+	for _, table := range doc.Tables {
+		layout.y -= pdfLineHeight
+		// Keep a table heading with its column headers and first row
+		layout.ensure(4 * pdfLineHeight)
+		layout.text(pdfMargin, table.Name, 12, true)
+		layout.y -= pdfLineHeight + 4
+		writeTableHeader(layout, table.Columns)
+		for _, row := range table.Rows {
+			if layout.ensure(pdfLineHeight) {
+				writeTableHeader(layout, table.Columns)
+			}
+			writeTableRow(layout, table.Columns, row, false)
+		}
+		if len(table.Rows) == 0 {
+			layout.ensure(pdfLineHeight)
+			layout.text(pdfMargin, "No data", pdfFontSize, false)
+			layout.y -= pdfLineHeight
+		}
+	}
+
+	return writePDF(w, layout.pages)
+}
+
+func writeTableHeader(layout *pdfLayout, columns []string) {
+	header := make([]interface{}, len(columns))
+	for i, c := range columns {
+		header[i] = c
+	}
+	writeTableRow(layout, columns, header, true)
+	layout.y += pdfLineHeight
+	layout.rule()
+	layout.y -= pdfLineHeight
+}
+
+// writeTableRow writes one row in equal-width columns, truncating cells that do not fit
+func writeTableRow(layout *pdfLayout, columns []string, row []interface{}, bold bool) {
+	if len(columns) == 0 {
+		return
+	}
+	width := (pdfPageWidth - 2*pdfMargin) / float64(len(columns))
+	maxChars := int((width - 6) / (pdfFontSize * pdfCharWidth))
+	for i := range columns {
+		if i >= len(row) {
+			break
+		}
+		layout.text(pdfMargin+float64(i)*width, truncate(formatCell(row[i]), maxChars), pdfFontSize, bold)
+	}
+	layout.y -= pdfLineHeight
+}
+
+// truncate shortens s to at most n characters, marking the cut with "..."
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	if n <= 3 {
+		return strings.Repeat(".", max(n, 0))
+	}
+	return string([]rune(s)[:n-3]) + "..."
+}
+
+// winAnsiPunctuation maps common typographic characters outside Latin-1 to their WinAnsiEncoding codes
+var winAnsiPunctuation = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// pdfString encodes s for a literal string in WinAnsiEncoding
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			// Latin-1 supplement has the same codes in WinAnsiEncoding
+			fmt.Fprintf(&b, "\\%03o", r)
+		case winAnsiPunctuation[r] != 0:
+			fmt.Fprintf(&b, "\\%03o", winAnsiPunctuation[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// writePDF writes the file structure: catalog, page tree, fonts, then each page and its
+// compressed content stream, followed by the cross-reference table
+func writePDF(w io.Writer, pages []*pdfPage) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	const firstPage = 5 // Objects 1-4 are the catalog, page tree and fonts; each page adds two
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		fmt.Fprintf(&page.content, "BT /F1 8.0 Tf %.2f %.2f Td (Page %d of %d) Tj ET\n", pdfPageWidth-pdfMargin-60, pdfMargin-10, i+1, len(pages))
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
@@ -0,0 +1,144 @@
+// Package report renders tabular reports as PDF or XLSX files. Services describe a report as a
+// Document built by a named Template; rendering needs no third-party libraries.
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is the file format of a rendered report
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat returns the format named s (case-insensitive)
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatPDF, FormatXLSX:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown report format %q", s)
+	}
+}
+
+// ContentType returns the MIME type of files in the format
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/pdf"
+}
+
+// Document is the content of a report: a title and one or more tables
+type Document struct {
+	Title       string
+	Subtitle    string // e.g. the period covered
+	GeneratedAt time.Time
+	Tables      []Table
+}
+
+// Table is a section of a report: a heading in PDFs and a worksheet in XLSX files.
+// Cells may be strings, numbers, booleans, time.Time or nil; other values are formatted with fmt.
+type Table struct {
+	Name    string
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Render writes doc to w in the given format
+func Render(w io.Writer, doc *Document, format Format) error {
+	switch format {
+	case FormatPDF:
+		return renderPDF(w, doc)
+	case FormatXLSX:
+		return renderXLSX(w, doc)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// Request carries what a template needs to build a report
+type Request struct {
+	OwnerID string            // User the report is generated for
+	Params  map[string]string // Template-specific parameters, e.g. a date range
+}
+
+// Template builds the document of one kind of report
+type Template struct {
+	Name        string
+	Description string
+	Roles       []string // Roles allowed to request the report; empty allows every authenticated user
+	// Validate checks the parameters when the report is requested, so mistakes are reported to
+	// the caller instead of failing the background job; optional
+	Validate func(params map[string]string) error
+	Build    func(ctx context.Context, req Request) (*Document, error)
+}
+
+// Registry holds the templates a service offers
+type Registry struct {
+	mu        sync.RWMutex
+	templates map[string]Template
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{templates: make(map[string]Template)}
+}
+
+// Register adds t, replacing any template of the same name
+func (r *Registry) Register(t Template) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[t.Name] = t
+}
+
+// Get returns the template named name
+func (r *Registry) Get(name string) (Template, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[name]
+	return t, ok
+}
+
+// Names returns the registered template names in alphabetical order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.templates))
+	for name := range r.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatCell renders a cell as text for formats without typed cells
+func formatCell(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.UTC().Format("2006-01-02 15:04")
+	case *time.Time:
+		if val == nil {
+			return ""
+		}
+		return formatCell(*val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// GroupCount is the number of entities sharing one value of a grouping column
+type GroupCount struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// matching returns a query over the entities matching the filters and conditions of opts.
+// Paging and sorting are ignored.
+func (r *GormBaseRepository[T]) matching(ctx context.Context, opts types.FilterOptions) *gorm.DB {
+	db := r.Conn(ctx).Model(reflect.New(r.ModelType).Interface())
+	if !opts.IncludeDeleted {
+		db = db.Where("deleted_at IS NULL")
+	}
+	return r.applyFilterOptions(db, types.FilterOptions{
+		Filters:        opts.Filters,
+		Conditions:     opts.Conditions,
+		IncludeDeleted: opts.IncludeDeleted,
+	})
+}
+
+// CountMatching counts the entities matching the filters and conditions of opts. Unlike Count,
+// it supports operator conditions.
+func (r *GormBaseRepository[T]) CountMatching(ctx context.Context, opts types.FilterOptions) (int64, error) {
+	var count int64
+	err := r.matching(ctx, opts).Count(&count).Error
+	return count, err
+}
+
+// CountBy counts the entities matching opts per value of column, ordered by value. NULL values
+// are counted under an empty key.
+func (r *GormBaseRepository[T]) CountBy(ctx context.Context, column string, opts types.FilterOptions) ([]GroupCount, error) {
+	if err := r.checkGroupField(ctx, column); err != nil {
+		return nil, err
+	}
+	return r.countGroups(ctx, column, opts)
+}
+
+// CountByDay counts the entities matching opts per UTC day (YYYY-MM-DD) of the timestamp column,
+// oldest first. Days without entities are left out.
+func (r *GormBaseRepository[T]) CountByDay(ctx context.Context, column string, opts types.FilterOptions) ([]GroupCount, error) {
+	if err := r.checkGroupField(ctx, column); err != nil {
+		return nil, err
+	}
+	var expr string
+	switch dialect := r.Conn(ctx).Dialector.Name(); dialect {
+	case "postgres":
+		expr = "to_char(" + column + " AT TIME ZONE 'UTC', 'YYYY-MM-DD')"
+	case "mysql":
+		expr = "DATE_FORMAT(" + column + ", '%Y-%m-%d')"
+	case "sqlite":
+		expr = "strftime('%Y-%m-%d', " + column + ")"
+	default:
+		return nil, fmt.Errorf("counting by day is not supported on %s", dialect)
+	}
+	return r.countGroups(ctx, expr, opts)
+}
+
+// checkGroupField checks that the entities can be grouped on column: it must be filterable and
+// not encrypted, since the group keys would be ciphertexts
+func (r *GormBaseRepository[T]) checkGroupField(ctx context.Context, column string) error {
+	if err := checkField(filterableFields(r.ModelType), column); err != nil {
+		return err
+	}
+	fields, err := r.encryptedFields(r.Conn(ctx))
+	if err != nil {
+		return err
+	}
+	if _, ok := fields[column]; ok {
+		return fmt.Errorf("%w: field %q is encrypted and cannot be grouped on", types.ErrValidation, column)
+	}
+	return nil
+}
+
+// countGroups runs SELECT expr, COUNT(*) ... GROUP BY expr over the entities matching opts
+func (r *GormBaseRepository[T]) countGroups(ctx context.Context, expr string, opts types.FilterOptions) ([]GroupCount, error) {
+	var rows []struct {
+		GroupKey   sql.NullString
+		GroupCount int64
+	}
+	err := r.matching(ctx, opts).
+		Select(expr + " AS group_key, COUNT(*) AS group_count").
+		Group(expr).
+		Order(expr).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]GroupCount, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, GroupCount{Key: row.GroupKey.String, Count: row.GroupCount})
+	}
+	return groups, nil
+}
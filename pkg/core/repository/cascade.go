@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// ErrInvalidCascade is returned for malformed cascade rules
+var ErrInvalidCascade = errors.New("invalid cascade rule")
+
+// CascadeAction is what soft-deleting a parent does to the rows referencing it
+type CascadeAction string
+
+const (
+	CascadeSoftDelete CascadeAction = "soft_delete" // Marks the children deleted too
+	CascadeDetach     CascadeAction = "detach"      // Clears the children's foreign key, which must be nullable
+)
+
+// IsValid reports whether a is a known action
+func (a CascadeAction) IsValid() bool {
+	return a == CascadeSoftDelete || a == CascadeDetach
+}
+
+// CascadeRule applies Action to the rows of Table whose ForeignKey column holds the ID of a
+// soft-deleted parent. Child tables are entity tables, with id, updated_at and deleted_at columns.
+type CascadeRule struct {
+	Table      string
+	ForeignKey string
+	Action     CascadeAction
+}
+
+// identifierPattern matches the table and column names a rule may use
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks the rule's names and action
+func (r CascadeRule) Validate() error {
+	if !identifierPattern.MatchString(r.Table) || !identifierPattern.MatchString(r.ForeignKey) {
+		return fmt.Errorf("%w: bad table or column name in %s.%s", ErrInvalidCascade, r.Table, r.ForeignKey)
+	}
+	if !r.Action.IsValid() {
+		return fmt.Errorf("%w: unknown cascade action %q (expected soft_delete or detach)", ErrInvalidCascade, r.Action)
+	}
+	return nil
+}
+
+// ParseCascadeRules parses comma-separated "table.foreign_key:action" rules, e.g.
+// "memberships.user_id:soft_delete,invitations.user_id:soft_delete". An empty spec has no rules.
+func ParseCascadeRules(spec string) ([]CascadeRule, error) {
+	var rules []CascadeRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		target, action, ok := strings.Cut(part, ":")
+		table, column, hasColumn := strings.Cut(target, ".")
+		if !ok || !hasColumn {
+			return nil, fmt.Errorf("%w: %q (expected table.foreign_key:action)", ErrInvalidCascade, part)
+		}
+		rule := CascadeRule{Table: table, ForeignKey: column, Action: CascadeAction(action)}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// CascadeRecord remembers a child changed by a cascade, so that restoring the parent reverts the
+// children it changed and leaves alone those deleted or detached on their own
+type CascadeRecord struct {
+	entity.BaseEntity
+	ParentTable string        `json:"parent_table" gorm:"size:128;not null;index:idx_cascade_records_parent"`
+	ParentID    uuid.UUID     `json:"parent_id" gorm:"type:uuid;not null;index:idx_cascade_records_parent"`
+	ChildTable  string        `json:"child_table" gorm:"size:128;not null"`
+	ChildID     uuid.UUID     `json:"child_id" gorm:"type:uuid;not null"`
+	ForeignKey  string        `json:"foreign_key" gorm:"size:128;not null"`
+	Action      CascadeAction `json:"action" gorm:"size:16;not null"`
+}
+
+// TableName overrides the table name
+func (CascadeRecord) TableName() string {
+	return "cascade_records"
+}
+
+// CascadeModels returns the model of the cascade_records table, for database.RegisterModels; a
+// service whose repositories have Cascade rules must register it
+func CascadeModels() []interface{} {
+	return []interface{}{&CascadeRecord{}}
+}
+
+// tableName returns the table of the repository's entity
+func (r *GormBaseRepository[T]) tableName(db *gorm.DB) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(reflect.New(r.ModelType).Interface()); err != nil {
+		return "", fmt.Errorf("failed to parse model: %w", err)
+	}
+	return stmt.Schema.Table, nil
+}
+
+// cascadeDelete applies the cascade rules to the children of the parents soft-deleted at, on db,
+// and records what it changed
+func (r *GormBaseRepository[T]) cascadeDelete(db *gorm.DB, parentIDs []uuid.UUID, at time.Time) error {
+	parentTable, err := r.tableName(db)
+	if err != nil {
+		return err
+	}
+	var records []CascadeRecord
+	for _, rule := range r.Cascade {
+		foreignKey := clause.Column{Name: rule.ForeignKey}
+		query := db.Table(rule.Table).Select("id, ? AS parent_id", foreignKey).Where("? IN ?", foreignKey, parentIDs)
+		update := map[string]interface{}{"updated_at": at}
+		if rule.Action == CascadeSoftDelete {
+			query = query.Where("deleted_at IS NULL")
+			update["deleted_at"] = at
+		} else {
+			update[rule.ForeignKey] = nil
+		}
+		var children []struct {
+			ID       uuid.UUID
+			ParentID uuid.UUID
+		}
+		if err := query.Scan(&children).Error; err != nil {
+			return fmt.Errorf("failed to cascade to %s: %w", rule.Table, err)
+		}
+		if len(children) == 0 {
+			continue
+		}
+		childIDs := make([]uuid.UUID, 0, len(children))
+		for _, child := range children {
+			childIDs = append(childIDs, child.ID)
+			records = append(records, CascadeRecord{
+				ParentTable: parentTable,
+				ParentID:    child.ParentID,
+				ChildTable:  rule.Table,
+				ChildID:     child.ID,
+				ForeignKey:  rule.ForeignKey,
+				Action:      rule.Action,
+			})
+		}
+		if err := db.Table(rule.Table).Where("id IN ?", childIDs).Updates(update).Error; err != nil {
+			return fmt.Errorf("failed to cascade to %s: %w", rule.Table, err)
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(&records, DefaultBatchSize()).Error
+}
+
+// cascadeRestore reverts, on db, what cascadeDelete did to the children of a restored parent.
+// Soft-deleted children are restored unless they were restored already; detached children are
+// attached again unless they were attached elsewhere in the meantime.
+func (r *GormBaseRepository[T]) cascadeRestore(db *gorm.DB, parentID uuid.UUID) error {
+	parentTable, err := r.tableName(db)
+	if err != nil {
+		return err
+	}
+	var records []CascadeRecord
+	if err := db.Where("parent_table = ? AND parent_id = ?", parentTable, parentID).Find(&records).Error; err != nil {
+		return fmt.Errorf("failed to read cascade records: %w", err)
+	}
+	type target struct {
+		table, foreignKey string
+		action            CascadeAction
+	}
+	children := make(map[target][]uuid.UUID)
+	var order []target
+	for _, record := range records {
+		t := target{table: record.ChildTable, foreignKey: record.ForeignKey, action: record.Action}
+		if _, ok := children[t]; !ok {
+			order = append(order, t)
+		}
+		children[t] = append(children[t], record.ChildID)
+	}
+
+	now := time.Now().UTC()
+	for _, t := range order {
+		query := db.Table(t.table).Where("id IN ?", children[t])
+		update := map[string]interface{}{"updated_at": now}
+		if t.action == CascadeSoftDelete {
+			query = query.Where("deleted_at IS NOT NULL")
+			update["deleted_at"] = nil
+		} else {
+			query = query.Where("? IS NULL", clause.Column{Name: t.foreignKey})
+			update[t.foreignKey] = parentID
+		}
+		if err := query.Updates(update).Error; err != nil {
+			return fmt.Errorf("failed to restore %s: %w", t.table, err)
+		}
+	}
+	return r.forgetCascade(db, parentTable, []uuid.UUID{parentID})
+}
+
+// forgetCascade drops the cascade records of the given parents
+func (r *GormBaseRepository[T]) forgetCascade(db *gorm.DB, parentTable string, parentIDs []uuid.UUID) error {
+	err := db.Where("parent_table = ? AND parent_id IN ?", parentTable, parentIDs).Delete(&CascadeRecord{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to drop cascade records: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// columnPattern restricts condition fields to plain column names, since they are interpolated into SQL
+var columnPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// filterableFields returns the fields the entity type allows in queries (see entity.Filterable), or
+// nil when it allows every column
+func filterableFields(modelType reflect.Type) []string {
+	if filterable, ok := reflect.New(modelType).Interface().(entity.Filterable); ok {
+		return filterable.FilterableFields()
+	}
+	return nil
+}
+
+// CheckFilterable rejects filters, conditions and sort fields the entity type does not allow (see
+// entity.Filterable). Errors wrap types.ErrValidation.
+func CheckFilterable(modelType reflect.Type, opts types.FilterOptions) error {
+	allowed := filterableFields(modelType)
+	if opts.SortBy != "" {
+		if err := checkField(allowed, opts.SortBy); err != nil {
+			return err
+		}
+	}
+	for field := range opts.Filters {
+		if err := checkField(allowed, field); err != nil {
+			return err
+		}
+	}
+	return checkConditionFields(allowed, opts.Conditions)
+}
+
+// checkConditionFields checks the fields of conditions and of their AnyOf groups
+func checkConditionFields(allowed []string, conditions []types.FilterCondition) error {
+	for _, c := range conditions {
+		if c.Any != nil {
+			if err := checkConditionFields(allowed, c.Any); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := checkField(allowed, c.Field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkField checks a single field name against the allowlist, if there is one
+func checkField(allowed []string, field string) error {
+	if !columnPattern.MatchString(field) {
+		return fmt.Errorf("%w: invalid filter field %q", types.ErrValidation, field)
+	}
+	if allowed != nil && !slices.Contains(allowed, field) {
+		return fmt.Errorf("%w: field %q cannot be filtered or sorted on", types.ErrValidation, field)
+	}
+	return nil
+}
+
+// ApplyConditions adds a WHERE clause per condition. Invalid conditions are recorded as query
+// errors wrapping types.ErrValidation, so they surface when the query runs. Repositories that do not
+// embed GormBaseRepository use it to accept the same operators.
+func ApplyConditions(db *gorm.DB, conditions []types.FilterCondition) *gorm.DB {
+	for _, c := range conditions {
+		clause, args, err := conditionClause(c, db.Dialector.Name())
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		db = db.Where(clause, args...)
+	}
+	return db
+}
+
+// conditionClause renders one condition as a SQL fragment with placeholders for the named GORM dialect
+func conditionClause(c types.FilterCondition, dialect string) (string, []interface{}, error) {
+	if c.Any != nil {
+		return anyClause(c.Any, dialect)
+	}
+	if !columnPattern.MatchString(c.Field) {
+		return "", nil, fmt.Errorf("%w: invalid filter field %q", types.ErrValidation, c.Field)
+	}
+	column := c.Field
+
+	switch c.Operator {
+	case types.OpEq, "":
+		if c.Value == nil {
+			return column + " IS NULL", nil, nil
+		}
+		return column + " = ?", []interface{}{c.Value}, nil
+	case types.OpNe:
+		if c.Value == nil {
+			return column + " IS NOT NULL", nil, nil
+		}
+		return column + " <> ?", []interface{}{c.Value}, nil
+	case types.OpGt:
+		return column + " > ?", []interface{}{c.Value}, nil
+	case types.OpGte:
+		return column + " >= ?", []interface{}{c.Value}, nil
+	case types.OpLt:
+		return column + " < ?", []interface{}{c.Value}, nil
+	case types.OpLte:
+		return column + " <= ?", []interface{}{c.Value}, nil
+	case types.OpIn, types.OpNotIn:
+		values := reflect.ValueOf(c.Value)
+		if c.Value == nil || (values.Kind() != reflect.Slice && values.Kind() != reflect.Array) {
+			return "", nil, fmt.Errorf("%w: %s on %s requires a list", types.ErrValidation, c.Operator, column)
+		}
+		if values.Len() == 0 {
+			// IN () is invalid SQL; an empty list matches nothing (or everything for NOT IN)
+			if c.Operator == types.OpIn {
+				return "1 = 0", nil, nil
+			}
+			return "1 = 1", nil, nil
+		}
+		if c.Operator == types.OpIn {
+			return column + " IN ?", []interface{}{c.Value}, nil
+		}
+		return column + " NOT IN ?", []interface{}{c.Value}, nil
+	case types.OpContains, types.OpStartsWith:
+		s, ok := c.Value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s on %s requires a string", types.ErrValidation, c.Operator, column)
+		}
+		pattern := escapeLike(s) + "%"
+		if c.Operator == types.OpContains {
+			pattern = "%" + pattern
+		}
+		return "LOWER(" + column + ") LIKE LOWER(?)" + likeEscape(dialect), []interface{}{pattern}, nil
+	case types.OpIsNull:
+		isNull, ok := c.Value.(bool)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s on %s requires a boolean", types.ErrValidation, c.Operator, column)
+		}
+		if isNull {
+			return column + " IS NULL", nil, nil
+		}
+		return column + " IS NOT NULL", nil, nil
+	case types.OpWithinRadius, types.OpWithinBox:
+		return geoClause(c, column, dialect)
+	default:
+		return "", nil, fmt.Errorf("%w: unknown filter operator %q", types.ErrValidation, c.Operator)
+	}
+}
+
+// anyClause renders a condition group as its conditions joined with OR
+func anyClause(conditions []types.FilterCondition, dialect string) (string, []interface{}, error) {
+	if len(conditions) == 0 {
+		return "1 = 0", nil, nil
+	}
+	clauses := make([]string, 0, len(conditions))
+	var args []interface{}
+	for _, c := range conditions {
+		clause, clauseArgs, err := conditionClause(c, dialect)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, "("+clause+")")
+		args = append(args, clauseArgs...)
+	}
+	return strings.Join(clauses, " OR "), args, nil
+}
+
+// geoClause renders a geospatial condition with PostGIS functions. Radius distances are measured
+// on the spheroid, which is why GeoPoint columns are geography rather than geometry.
+func geoClause(c types.FilterCondition, column, dialect string) (string, []interface{}, error) {
+	if dialect != "postgres" {
+		return "", nil, fmt.Errorf("%s on %s requires PostgreSQL with PostGIS", c.Operator, column)
+	}
+	if c.Operator == types.OpWithinRadius {
+		r, err := types.GeoRadiusOf(c.Value)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s on %s: %w", c.Operator, column, err)
+		}
+		return "ST_DWithin(" + column + "::geography, ST_SetSRID(ST_MakePoint(?, ?), ?)::geography, ?)",
+			[]interface{}{r.Center.Lng, r.Center.Lat, types.GeoSRID, r.RadiusMeters}, nil
+	}
+	b, err := types.GeoBoundingBoxOf(c.Value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s on %s: %w", c.Operator, column, err)
+	}
+	return "ST_Intersects(" + column + "::geometry, ST_MakeEnvelope(?, ?, ?, ?, ?))",
+		[]interface{}{b.MinLng, b.MinLat, b.MaxLng, b.MaxLat, types.GeoSRID}, nil
+}
+
+// likeEscape returns the ESCAPE clause that makes backslash the LIKE escape character. PostgreSQL
+// and MySQL use it by default (and MySQL would need it doubled in a literal); SQLite has none.
+func likeEscape(dialect string) string {
+	if dialect == "sqlite" {
+		return ` ESCAPE '\'`
+	}
+	return ""
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// Columns using the serializers of database.EncryptedSerializer hold ciphertexts, so filters and
+// bulk updates given in plaintext are translated here. Equality on a searchable column becomes a
+// match against its ciphertexts under every key; other comparisons, and any condition on a
+// randomized column, cannot be evaluated by the database and are rejected.
+
+// encryptedFields returns the fields of the entity stored through an encrypted serializer, by
+// column name
+func (r *GormBaseRepository[T]) encryptedFields(db *gorm.DB) (map[string]*schema.Field, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(reflect.New(r.ModelType).Interface()); err != nil {
+		return nil, fmt.Errorf("failed to parse model: %w", err)
+	}
+	var fields map[string]*schema.Field
+	for _, field := range stmt.Schema.Fields {
+		switch field.TagSettings["SERIALIZER"] {
+		case database.EncryptedSerializer, database.SearchableEncryptedSerializer:
+			if fields == nil {
+				fields = make(map[string]*schema.Field)
+			}
+			fields[field.DBName] = field
+		}
+	}
+	return fields, nil
+}
+
+// isSearchable reports whether an encrypted field can be matched exactly
+func isSearchable(field *schema.Field) bool {
+	return field.TagSettings["SERIALIZER"] == database.SearchableEncryptedSerializer
+}
+
+// encryptFilterOptions returns opts with the filters and conditions on encrypted columns rewritten
+// to match their stored ciphertexts. Errors wrap types.ErrValidation.
+func (r *GormBaseRepository[T]) encryptFilterOptions(db *gorm.DB, opts types.FilterOptions) (types.FilterOptions, error) {
+	fields, err := r.encryptedFields(db)
+	if err != nil || fields == nil {
+		return opts, err
+	}
+	if _, ok := fields[opts.SortBy]; ok {
+		return opts, fmt.Errorf("%w: field %q is encrypted and cannot be sorted on", types.ErrValidation, opts.SortBy)
+	}
+	if opts.Filters, err = encryptFilters(fields, opts.Filters); err != nil {
+		return opts, err
+	}
+	opts.Conditions, err = encryptConditions(fields, opts.Conditions)
+	return opts, err
+}
+
+// encryptFilter rewrites an equality filter map like encryptFilterOptions does
+func (r *GormBaseRepository[T]) encryptFilter(db *gorm.DB, filter map[string]interface{}) (map[string]interface{}, error) {
+	opts, err := r.encryptFilterOptions(db, types.FilterOptions{Filters: filter})
+	return opts.Filters, err
+}
+
+// encryptFilters rewrites equality filters on encrypted columns, copying the map only if needed
+func encryptFilters(fields map[string]*schema.Field, filters map[string]interface{}) (map[string]interface{}, error) {
+	var rewritten map[string]interface{}
+	for column, value := range filters {
+		field, ok := fields[column]
+		if !ok {
+			continue
+		}
+		if !isSearchable(field) {
+			return nil, fmt.Errorf("%w: field %q is encrypted and cannot be filtered on", types.ErrValidation, column)
+		}
+		values, err := storedValues(column, value)
+		if err != nil {
+			return nil, err
+		}
+		if rewritten == nil {
+			rewritten = copyMap(filters)
+		}
+		rewritten[column] = values // A slice renders as IN
+	}
+	if rewritten == nil {
+		return filters, nil
+	}
+	return rewritten, nil
+}
+
+// encryptConditions rewrites conditions on encrypted columns, including those of AnyOf groups,
+// into a new slice
+func encryptConditions(fields map[string]*schema.Field, conditions []types.FilterCondition) ([]types.FilterCondition, error) {
+	if len(conditions) == 0 {
+		return conditions, nil
+	}
+	rewritten := make([]types.FilterCondition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Any != nil {
+			group, err := encryptConditions(fields, c.Any)
+			if err != nil {
+				return nil, err
+			}
+			c.Any = group
+			rewritten = append(rewritten, c)
+			continue
+		}
+		field, ok := fields[c.Field]
+		if !ok || c.Operator == types.OpIsNull {
+			rewritten = append(rewritten, c)
+			continue
+		}
+		if !isSearchable(field) {
+			return nil, fmt.Errorf("%w: field %q is encrypted and cannot be filtered on", types.ErrValidation, c.Field)
+		}
+		encrypted, err := encryptCondition(c)
+		if err != nil {
+			return nil, err
+		}
+		rewritten = append(rewritten, encrypted)
+	}
+	return rewritten, nil
+}
+
+// encryptCondition rewrites a condition on a searchable encrypted column. Only (in)equality and
+// (not) in are supported, since ciphertexts do not preserve order or substrings.
+func encryptCondition(c types.FilterCondition) (types.FilterCondition, error) {
+	switch c.Operator {
+	case types.OpEq, "", types.OpNe:
+		if c.Value == nil {
+			return c, nil // IS (NOT) NULL
+		}
+		values, err := storedValues(c.Field, c.Value)
+		if err != nil {
+			return c, err
+		}
+		operator := types.OpIn
+		if c.Operator == types.OpNe {
+			operator = types.OpNotIn
+		}
+		return types.NewCondition(c.Field, operator, values), nil
+	case types.OpIn, types.OpNotIn:
+		list := reflect.ValueOf(c.Value)
+		if c.Value == nil || (list.Kind() != reflect.Slice && list.Kind() != reflect.Array) {
+			return c, fmt.Errorf("%w: %s on %s requires a list", types.ErrValidation, c.Operator, c.Field)
+		}
+		var values []string
+		for i := 0; i < list.Len(); i++ {
+			stored, err := storedValues(c.Field, list.Index(i).Interface())
+			if err != nil {
+				return c, err
+			}
+			values = append(values, stored...)
+		}
+		return types.NewCondition(c.Field, c.Operator, values), nil
+	default:
+		return c, fmt.Errorf("%w: field %q is encrypted and only supports eq, ne, in, not_in and is_null", types.ErrValidation, c.Field)
+	}
+}
+
+// storedValues returns the values a searchable encrypted column may hold for a plaintext value.
+// Empty strings are stored unencrypted.
+func storedValues(column string, value interface{}) ([]string, error) {
+	plaintext, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: filter on %s requires a string", types.ErrValidation, column)
+	}
+	if plaintext == "" {
+		return []string{""}, nil
+	}
+	values, err := database.SearchableValues(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt filter on %s: %w", column, err)
+	}
+	return values, nil
+}
+
+// encryptUpdates returns updates with the values of encrypted columns encrypted like the entity's
+// serializer does on save, copying the map only if needed
+func encryptUpdates(ctx context.Context, fields map[string]*schema.Field, updates map[string]interface{}) (map[string]interface{}, error) {
+	var rewritten map[string]interface{}
+	for column, value := range updates {
+		field, ok := fields[column]
+		if !ok {
+			continue
+		}
+		encrypted, err := field.Serializer.Value(ctx, field, reflect.Value{}, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt %s: %w", column, err)
+		}
+		if rewritten == nil {
+			rewritten = copyMap(updates)
+		}
+		rewritten[column] = encrypted
+	}
+	if rewritten == nil {
+		return updates, nil
+	}
+	return rewritten, nil
+}
+
+// copyMap returns a shallow copy of m
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ExplainConfig controls EXPLAIN ANALYZE logging of list queries. It is a debugging aid:
+// EXPLAIN ANALYZE runs the query a second time, so it is never enabled in production.
+type ExplainConfig struct {
+	All           bool     // Explain every list query
+	AllowRequests bool     // Explain list queries of requests flagged with WithExplain
+	RequestRoles  []string // Caller roles allowed to flag their requests (see grpc.ExplainUnaryInterceptor)
+}
+
+// LoadExplainConfigFromEnv reads DB_EXPLAIN_QUERIES (default false), DB_EXPLAIN_ON_REQUEST
+// (default false) and DB_EXPLAIN_ROLES (comma separated, default "admin"). DB_EXPLAIN_QUERIES and
+// DB_EXPLAIN_ON_REQUEST are forced off when APP_ENV is "production".
+func LoadExplainConfigFromEnv() ExplainConfig {
+	var roles []string
+	for _, role := range strings.Split(utils.GetEnv("DB_EXPLAIN_ROLES", "admin"), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	if strings.EqualFold(utils.GetEnv("APP_ENV", "development"), "production") {
+		return ExplainConfig{RequestRoles: roles}
+	}
+	return ExplainConfig{
+		All:           utils.GetEnv("DB_EXPLAIN_QUERIES", "false") == "true",
+		AllowRequests: utils.GetEnv("DB_EXPLAIN_ON_REQUEST", "false") == "true",
+		RequestRoles:  roles,
+	}
+}
+
+type explainContextKey struct{}
+
+// WithExplain flags the request so its list queries are explained (when AllowRequests is set)
+func WithExplain(ctx context.Context) context.Context {
+	return context.WithValue(ctx, explainContextKey{}, true)
+}
+
+// ExplainRequested reports whether ctx was flagged with WithExplain
+func ExplainRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(explainContextKey{}).(bool)
+	return requested
+}
+
+// Explainer logs the plans of list queries built by GormBaseRepository
+type Explainer struct {
+	config ExplainConfig
+	logger logger.Logger
+}
+
+// NewExplainer creates an explainer logging through log
+func NewExplainer(config ExplainConfig, log logger.Logger) *Explainer {
+	return &Explainer{config: config, logger: log}
+}
+
+// explainer is the process-wide explainer; nil disables explaining
+var explainer atomic.Pointer[Explainer]
+
+// SetExplainer installs the explainer used by every GormBaseRepository
+func SetExplainer(e *Explainer) {
+	explainer.Store(e)
+}
+
+// enabled reports whether queries on ctx should be explained
+func (e *Explainer) enabled(ctx context.Context) bool {
+	return e != nil && (e.config.All || (e.config.AllowRequests && ExplainRequested(ctx)))
+}
+
+// explainFind runs EXPLAIN ANALYZE for the query db.Find(dest) would execute and logs the plan.
+// Failures are logged and never affect the query itself.
+func (e *Explainer) explainFind(ctx context.Context, db *gorm.DB, dest interface{}) {
+	log := logger.FromContext(ctx, e.logger)
+	if db.Dialector.Name() == "sqlite" {
+		log.Debug("EXPLAIN ANALYZE is not supported by SQLite", "table", db.Statement.Table)
+		return
+	}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Find(dest).Statement
+	query := stmt.SQL.String()
+
+	rows, err := stmt.ConnPool.QueryContext(ctx, "EXPLAIN ANALYZE "+query, stmt.Vars...)
+	if err != nil {
+		log.Warn("EXPLAIN ANALYZE failed", "table", stmt.Table, "sql", query, "error", err)
+		return
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			log.Warn("Failed to read query plan", "table", stmt.Table, "error", err)
+			return
+		}
+		plan = append(plan, line)
+	}
+	if err := rows.Err(); err != nil {
+		log.Warn("Failed to read query plan", "table", stmt.Table, "error", err)
+		return
+	}
+	log.Info("Query plan", "table", stmt.Table, "sql", query, "plan", strings.Join(plan, "\n"))
+}
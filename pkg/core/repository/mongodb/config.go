@@ -0,0 +1,182 @@
+//go:build mongo
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Config contains the MongoDB connection settings
+type Config struct {
+	URI            string
+	Database       string
+	ConnectTimeout time.Duration
+	MaxPoolSize    uint64
+}
+
+// LoadConfigFromEnv reads the MONGO_* settings
+func LoadConfigFromEnv() Config {
+	return Config{
+		URI:            utils.GetEnv("MONGO_URI", "mongodb://localhost:27017"),
+		Database:       utils.GetEnv("MONGO_DATABASE", "microservices"),
+		ConnectTimeout: utils.GetEnvDuration("MONGO_CONNECT_TIMEOUT", 10*time.Second),
+		MaxPoolSize:    uint64(utils.GetEnvAsInt("MONGO_MAX_POOL_SIZE", 100)),
+	}
+}
+
+// Connect opens a client with the package's BSON registry and checks that the server answers.
+// Repositories rely on that registry, so clients created elsewhere must use Registry() too.
+func Connect(ctx context.Context, cfg Config) (*mongo.Client, *mongo.Database, error) {
+	opts := options.Client().
+		ApplyURI(cfg.URI).
+		SetRegistry(Registry()).
+		SetConnectTimeout(cfg.ConnectTimeout).
+		SetMaxPoolSize(cfg.MaxPoolSize)
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to mongodb: %w", err)
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancel()
+	if err := client.Ping(pingCtx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, nil, fmt.Errorf("failed to reach mongodb: %w", err)
+	}
+	return client, client.Database(cfg.Database), nil
+}
+
+// registry is built once; see Registry
+var registry = newRegistry()
+
+// Registry returns the BSON registry used by the repositories. It maps entities the way GORM maps
+// them to columns, so filters and sort fields are the same on both backends:
+//   - document keys are the GORM column names (snake_case, or the column tag), and the ID is _id
+//   - embedded structs such as entity.BaseEntity are inlined
+//   - uuid.UUID values are stored as strings
+//   - types.GeoPoint values are stored as GeoJSON points, which 2dsphere indexes and geo filters use
+//
+// Fields tagged gorm:"-" are not stored.
+func Registry() *bsoncodec.Registry {
+	return registry
+}
+
+func newRegistry() *bsoncodec.Registry {
+	reg := bson.NewRegistry()
+	structCodec, err := bsoncodec.NewStructCodec(bsoncodec.StructTagParserFunc(structTags))
+	if err != nil {
+		panic(fmt.Sprintf("mongodb: failed to create struct codec: %v", err))
+	}
+	reg.RegisterKindEncoder(reflect.Struct, structCodec)
+	reg.RegisterKindDecoder(reflect.Struct, structCodec)
+
+	uuidType := reflect.TypeOf(uuid.UUID{})
+	reg.RegisterTypeEncoder(uuidType, bsoncodec.ValueEncoderFunc(encodeUUID))
+	reg.RegisterTypeDecoder(uuidType, bsoncodec.ValueDecoderFunc(decodeUUID))
+
+	pointType := reflect.TypeOf(types.GeoPoint{})
+	reg.RegisterTypeEncoder(pointType, bsoncodec.ValueEncoderFunc(encodeGeoPoint))
+	reg.RegisterTypeDecoder(pointType, bsoncodec.ValueDecoderFunc(decodeGeoPoint))
+	return reg
+}
+
+// structTags derives the BSON key of a struct field from its GORM mapping
+func structTags(sf reflect.StructField) (bsoncodec.StructTags, error) {
+	if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+		return bsoncodec.StructTags{Name: sf.Name, Inline: true}, nil
+	}
+	name, skip := fieldKey(sf)
+	return bsoncodec.StructTags{Name: name, Skip: skip}, nil
+}
+
+func encodeUUID(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	id, ok := val.Interface().(uuid.UUID)
+	if !ok {
+		return bsoncodec.ValueEncoderError{Name: "UUIDEncodeValue", Types: []reflect.Type{reflect.TypeOf(uuid.UUID{})}, Received: val}
+	}
+	return vw.WriteString(id.String())
+}
+
+// decodeUUID reads UUIDs stored as strings, and as binary (subtype 4) by other clients
+func decodeUUID(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	var id uuid.UUID
+	switch vr.Type() {
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		if id, err = uuid.Parse(s); err != nil {
+			return err
+		}
+	case bsontype.Binary:
+		data, _, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+		if id, err = uuid.FromBytes(data); err != nil {
+			return err
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot decode %v into a UUID", vr.Type())
+	}
+	val.Set(reflect.ValueOf(id))
+	return nil
+}
+
+// geoJSONPoint is the stored form of a types.GeoPoint: {type: "Point", coordinates: [lng, lat]}
+type geoJSONPoint struct {
+	Type        string
+	Coordinates []float64
+}
+
+var geoJSONPointType = reflect.TypeOf(geoJSONPoint{})
+
+func encodeGeoPoint(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	p, ok := val.Interface().(types.GeoPoint)
+	if !ok {
+		return bsoncodec.ValueEncoderError{Name: "GeoPointEncodeValue", Types: []reflect.Type{reflect.TypeOf(types.GeoPoint{})}, Received: val}
+	}
+	enc, err := ec.LookupEncoder(geoJSONPointType)
+	if err != nil {
+		return err
+	}
+	return enc.EncodeValue(ec, vw, reflect.ValueOf(geoJSONPoint{Type: "Point", Coordinates: []float64{p.Lng, p.Lat}}))
+}
+
+func decodeGeoPoint(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if vr.Type() == bsontype.Null {
+		val.Set(reflect.ValueOf(types.GeoPoint{}))
+		return vr.ReadNull()
+	}
+	dec, err := dc.LookupDecoder(geoJSONPointType)
+	if err != nil {
+		return err
+	}
+	var point geoJSONPoint
+	if err := dec.DecodeValue(dc, vr, reflect.ValueOf(&point).Elem()); err != nil {
+		return err
+	}
+	if point.Type != "Point" || len(point.Coordinates) < 2 {
+		return fmt.Errorf("cannot decode GeoJSON %q with %d coordinates into a GeoPoint", point.Type, len(point.Coordinates))
+	}
+	val.Set(reflect.ValueOf(types.GeoPoint{Lat: point.Coordinates[1], Lng: point.Coordinates[0]}))
+	return nil
+}
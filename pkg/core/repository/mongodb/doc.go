@@ -0,0 +1,7 @@
+// Package mongodb implements repository.BaseRepository on MongoDB, so document-oriented services
+// keep the same use case and controller layers as the GORM-backed ones.
+//
+// The package is compiled only with the mongo build tag (go build -tags mongo), so services that do
+// not use MongoDB carry no driver dependency. Add the driver once with
+// go get go.mongodb.org/mongo-driver@v1.
+package mongodb
@@ -0,0 +1,143 @@
+package mongodb
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// notDeleted matches documents that are not soft-deleted; a missing deleted_at counts as null
+var notDeleted = map[string]interface{}{"deleted_at": nil}
+
+// filterDocument translates equality filters and operator conditions into a MongoDB query,
+// combined with AND. Invalid conditions return an error wrapping types.ErrValidation.
+func filterDocument(filters map[string]interface{}, conditions []types.FilterCondition, includeDeleted bool) (map[string]interface{}, error) {
+	var clauses []interface{}
+	for column, value := range filters {
+		if !isColumnName(column) {
+			return nil, fmt.Errorf("%w: invalid filter field %q", types.ErrValidation, column)
+		}
+		clauses = append(clauses, map[string]interface{}{documentKey(column): value})
+	}
+	for _, c := range conditions {
+		clause, err := conditionDocument(c)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	if !includeDeleted {
+		clauses = append(clauses, notDeleted)
+	}
+
+	switch len(clauses) {
+	case 0:
+		return map[string]interface{}{}, nil
+	case 1:
+		return clauses[0].(map[string]interface{}), nil
+	default:
+		return map[string]interface{}{"$and": clauses}, nil
+	}
+}
+
+// conditionDocument renders one condition, mirroring the SQL semantics of repository conditions
+func conditionDocument(c types.FilterCondition) (map[string]interface{}, error) {
+	if c.Any != nil {
+		if len(c.Any) == 0 {
+			// $or rejects an empty list; an empty group matches nothing
+			return map[string]interface{}{"_id": map[string]interface{}{"$in": []interface{}{}}}, nil
+		}
+		clauses := make([]interface{}, 0, len(c.Any))
+		for _, sub := range c.Any {
+			clause, err := conditionDocument(sub)
+			if err != nil {
+				return nil, err
+			}
+			clauses = append(clauses, clause)
+		}
+		return map[string]interface{}{"$or": clauses}, nil
+	}
+	if !isColumnName(c.Field) {
+		return nil, fmt.Errorf("%w: invalid filter field %q", types.ErrValidation, c.Field)
+	}
+	key := documentKey(c.Field)
+	match := func(op string, value interface{}) map[string]interface{} {
+		return map[string]interface{}{key: map[string]interface{}{op: value}}
+	}
+
+	switch c.Operator {
+	case types.OpEq, "":
+		return match("$eq", c.Value), nil
+	case types.OpNe:
+		return match("$ne", c.Value), nil
+	case types.OpGt:
+		return match("$gt", c.Value), nil
+	case types.OpGte:
+		return match("$gte", c.Value), nil
+	case types.OpLt:
+		return match("$lt", c.Value), nil
+	case types.OpLte:
+		return match("$lte", c.Value), nil
+	case types.OpIn, types.OpNotIn:
+		values := reflect.ValueOf(c.Value)
+		if c.Value == nil || (values.Kind() != reflect.Slice && values.Kind() != reflect.Array) {
+			return nil, fmt.Errorf("%w: %s on %s requires a list", types.ErrValidation, c.Operator, c.Field)
+		}
+		if c.Operator == types.OpIn {
+			return match("$in", c.Value), nil
+		}
+		return match("$nin", c.Value), nil
+	case types.OpContains, types.OpStartsWith:
+		s, ok := c.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s on %s requires a string", types.ErrValidation, c.Operator, c.Field)
+		}
+		pattern := regexp.QuoteMeta(s)
+		if c.Operator == types.OpStartsWith {
+			pattern = "^" + pattern
+		}
+		return map[string]interface{}{key: map[string]interface{}{"$regex": pattern, "$options": "i"}}, nil
+	case types.OpIsNull:
+		isNull, ok := c.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s on %s requires a boolean", types.ErrValidation, c.Operator, c.Field)
+		}
+		if isNull {
+			return match("$eq", nil), nil
+		}
+		return match("$ne", nil), nil
+	case types.OpWithinRadius:
+		r, err := types.GeoRadiusOf(c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s on %s: %w", c.Operator, c.Field, err)
+		}
+		center := []interface{}{r.Center.Lng, r.Center.Lat}
+		return match("$geoWithin", map[string]interface{}{"$centerSphere": []interface{}{center, r.RadiusMeters / earthRadiusMeters}}), nil
+	case types.OpWithinBox:
+		b, err := types.GeoBoundingBoxOf(c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s on %s: %w", c.Operator, c.Field, err)
+		}
+		return match("$geoWithin", map[string]interface{}{"$geometry": boxPolygon(b)}), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown filter operator %q", types.ErrValidation, c.Operator)
+	}
+}
+
+// earthRadiusMeters converts distances to the radians $centerSphere expects
+const earthRadiusMeters = 6378100.0
+
+// boxPolygon returns the GeoJSON polygon of a bounding box. $box only works on legacy coordinate
+// pairs, while GeoPoint fields are stored as GeoJSON points.
+func boxPolygon(b types.GeoBoundingBox) map[string]interface{} {
+	ring := [][]float64{
+		{b.MinLng, b.MinLat},
+		{b.MaxLng, b.MinLat},
+		{b.MaxLng, b.MaxLat},
+		{b.MinLng, b.MaxLat},
+		{b.MinLng, b.MinLat},
+	}
+	return map[string]interface{}{"type": "Polygon", "coordinates": [][][]float64{ring}}
+}
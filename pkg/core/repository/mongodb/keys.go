@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+// idKey is the document key of the entity ID
+const idKey = "_id"
+
+// naming derives keys and collection names the way GORM derives columns and tables
+var naming = schema.NamingStrategy{}
+
+// fieldKey returns the document key of a struct field: its GORM column name, or _id for the ID.
+// skip is set for fields GORM does not persist (gorm:"-").
+func fieldKey(sf reflect.StructField) (key string, skip bool) {
+	if sf.PkgPath != "" { // Unexported
+		return "", true
+	}
+	settings := schema.ParseTagSetting(sf.Tag.Get("gorm"), ";")
+	if _, ok := settings["-"]; ok || sf.Tag.Get("gorm") == "-" {
+		return "", true
+	}
+	key = settings["COLUMN"]
+	if key == "" {
+		key = naming.ColumnName("", sf.Name)
+	}
+	if key == "id" {
+		key = idKey
+	}
+	return key, false
+}
+
+// documentKey maps a column name used in filters and sorting to its document key
+func documentKey(column string) string {
+	if column == "id" {
+		return idKey
+	}
+	return column
+}
+
+// tabler is implemented by entities overriding their table name (GORM's schema.Tabler)
+type tabler interface {
+	TableName() string
+}
+
+// collectionName returns the entity's table name, which is used as the collection name
+func collectionName(modelType reflect.Type) string {
+	if t, ok := reflect.New(modelType).Interface().(tabler); ok {
+		return t.TableName()
+	}
+	return naming.TableName(modelType.Name())
+}
+
+// documentKeys returns the keys of the fields stored for modelType, including inlined structs
+var documentKeys = func() func(reflect.Type) map[string]bool {
+	var cache sync.Map
+	var collect func(t reflect.Type, keys map[string]bool)
+	collect = func(t reflect.Type, keys map[string]bool) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				collect(sf.Type, keys)
+				continue
+			}
+			if key, skip := fieldKey(sf); !skip {
+				keys[key] = true
+			}
+		}
+	}
+	return func(t reflect.Type) map[string]bool {
+		if keys, ok := cache.Load(t); ok {
+			return keys.(map[string]bool)
+		}
+		keys := make(map[string]bool)
+		collect(t, keys)
+		cache.Store(t, keys)
+		return keys
+	}
+}()
+
+// isColumnName reports whether name looks like a plain column name
+func isColumnName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "$.") && name[0] != '_'
+}
@@ -0,0 +1,488 @@
+//go:build mongo
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// Ensure MongoBaseRepository implements repository.BaseRepository
+var _ repository.BaseRepository[entity.BaseEntity] = (*MongoBaseRepository[entity.BaseEntity])(nil)
+
+// MongoBaseRepository implements repository.BaseRepository on a MongoDB collection. Documents use
+// the keys described at Registry, and soft deletes set deleted_at like the GORM repository.
+type MongoBaseRepository[T entity.Entity] struct {
+	Collection *mongo.Collection
+	ModelType  reflect.Type
+	// UpdatableFields restricts the fields UpdateWhere may set; nil allows every field except protectedKeys
+	UpdatableFields []string
+	session         mongo.Session // Set on the repository passed to Transaction callbacks
+}
+
+// NewMongoBaseRepository creates a repository on the collection named after T's table name
+func NewMongoBaseRepository[T entity.Entity](db *mongo.Database) *MongoBaseRepository[T] {
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	return &MongoBaseRepository[T]{
+		Collection: db.Collection(collectionName(modelType)),
+		ModelType:  modelType,
+	}
+}
+
+// opCtx binds ctx to the repository's transaction, if any
+func (r *MongoBaseRepository[T]) opCtx(ctx context.Context) context.Context {
+	if r.session == nil {
+		return ctx
+	}
+	return mongo.NewSessionContext(ctx, r.session)
+}
+
+// stamp sets the ID and timestamps GORM would set on create (create) or update
+func (r *MongoBaseRepository[T]) stamp(e *T, create bool) {
+	v := reflect.ValueOf(e).Elem()
+	now := time.Now().UTC()
+	if create {
+		if id := v.FieldByName("ID"); id.IsValid() && id.CanSet() && id.Interface() == uuid.Nil {
+			id.Set(reflect.ValueOf(uuid.New()))
+		}
+		if createdAt := v.FieldByName("CreatedAt"); createdAt.IsValid() && createdAt.CanSet() && createdAt.Interface().(time.Time).IsZero() {
+			createdAt.Set(reflect.ValueOf(now))
+		}
+	}
+	if updatedAt := v.FieldByName("UpdatedAt"); updatedAt.IsValid() && updatedAt.CanSet() {
+		updatedAt.Set(reflect.ValueOf(now))
+	}
+}
+
+// Create adds a new entity to the collection
+func (r *MongoBaseRepository[T]) Create(ctx context.Context, e *T) error {
+	r.stamp(e, true)
+	_, err := r.Collection.InsertOne(r.opCtx(ctx), e)
+	return err
+}
+
+// FindByID retrieves an entity by its ID, including soft-deleted ones like the GORM repository
+func (r *MongoBaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	return r.findOne(ctx, bson.M{idKey: id})
+}
+
+// findOne decodes the first document matching filter
+func (r *MongoBaseRepository[T]) findOne(ctx context.Context, filter interface{}) (*T, error) {
+	e := reflect.New(r.ModelType).Interface().(*T)
+	err := r.Collection.FindOne(r.opCtx(ctx), filter).Decode(e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// FindByIDs retrieves the entities with the given IDs in one query, keyed by ID
+func (r *MongoBaseRepository[T]) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*T, error) {
+	if len(ids) == 0 {
+		return map[uuid.UUID]*T{}, nil
+	}
+	entities, err := r.find(ctx, bson.M{idKey: bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*T, len(entities))
+	for _, e := range entities {
+		byID[(*e).GetID()] = e
+	}
+	return byID, nil
+}
+
+// find decodes every document matching filter
+func (r *MongoBaseRepository[T]) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]*T, error) {
+	ctx = r.opCtx(ctx)
+	cursor, err := r.Collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*T{}
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// ExistsByID reports whether an entity with the ID exists
+func (r *MongoBaseRepository[T]) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.exists(ctx, bson.M{idKey: id})
+}
+
+// Exists reports whether a non-deleted entity matches the filter
+func (r *MongoBaseRepository[T]) Exists(ctx context.Context, filter map[string]interface{}) (bool, error) {
+	query, err := filterDocument(filter, nil, false)
+	if err != nil {
+		return false, err
+	}
+	return r.exists(ctx, query)
+}
+
+// exists counts at most one matching document
+func (r *MongoBaseRepository[T]) exists(ctx context.Context, filter interface{}) (bool, error) {
+	n, err := r.Collection.CountDocuments(r.opCtx(ctx), filter, options.Count().SetLimit(1))
+	return n > 0, err
+}
+
+// FindAll retrieves the entities matching the filter options, with the total count for pagination
+func (r *MongoBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	if err := repository.CheckFilterable(r.ModelType, opts); err != nil {
+		return nil, err
+	}
+	query, err := filterDocument(opts.Filters, opts.Conditions, opts.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.Collection.CountDocuments(r.opCtx(ctx), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	findOpts := options.Find()
+	if opts.SortBy != "" {
+		if !isColumnName(opts.SortBy) {
+			return nil, fmt.Errorf("%w: invalid sort field %q", types.ErrValidation, opts.SortBy)
+		}
+		direction := 1
+		if opts.SortDesc {
+			direction = -1
+		}
+		findOpts.SetSort(bson.D{{Key: documentKey(opts.SortBy), Value: direction}})
+	}
+	if opts.Limit > 0 {
+		findOpts.SetLimit(int64(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		findOpts.SetSkip(int64(opts.Offset))
+	}
+	entities, err := r.find(ctx, query, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find items: %w", err)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := max(opts.Offset, 0)
+	return &types.PaginationResult[T]{
+		Items:      entities,
+		TotalItems: total,
+		Limit:      limit,
+		Offset:     offset,
+	}, nil
+}
+
+// FindWithFilter retrieves entities that match the provided filter criteria
+func (r *MongoBaseRepository[T]) FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	opts = opts.Clone() // Never write to the caller's Filters map
+	for k, v := range filter {
+		opts.Filters[k] = v
+	}
+	return r.FindAll(ctx, opts)
+}
+
+// Update replaces the stored fields of an entity, except its ID and creation time; ErrNotFound
+// means no document has its ID. Unlike the GORM repository, zero values are written too.
+func (r *MongoBaseRepository[T]) Update(ctx context.Context, e *T) error {
+	id := (*e).GetID()
+	if id == uuid.Nil {
+		return errors.New("entity must have a valid ID for update")
+	}
+	r.stamp(e, false)
+	fields, err := r.setFields(e)
+	if err != nil {
+		return err
+	}
+	result, err := r.Collection.UpdateOne(r.opCtx(ctx), bson.M{idKey: id}, bson.M{"$set": fields})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// setFields encodes e as the $set document of an update
+func (r *MongoBaseRepository[T]) setFields(e *T) (bson.M, error) {
+	data, err := bson.MarshalWithRegistry(Registry(), e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode entity: %w", err)
+	}
+	var fields bson.M
+	if err := bson.UnmarshalWithRegistry(Registry(), data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode entity: %w", err)
+	}
+	delete(fields, idKey)
+	delete(fields, "created_at")
+	return fields, nil
+}
+
+// FindOneWithFilter retrieves the first non-deleted entity matching the filter
+func (r *MongoBaseRepository[T]) FindOneWithFilter(ctx context.Context, filter map[string]interface{}) (*T, error) {
+	query, err := filterDocument(filter, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, query)
+}
+
+// Delete removes an entity by ID, or marks it deleted; ErrNotFound means no document has the ID
+func (r *MongoBaseRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+	if hardDelete {
+		result, err := r.Collection.DeleteOne(r.opCtx(ctx), bson.M{idKey: id})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return repository.ErrNotFound
+		}
+		return nil
+	}
+	result, err := r.Collection.UpdateOne(r.opCtx(ctx), bson.M{idKey: id}, softDelete())
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Restore clears the deletion mark of a soft-deleted entity; ErrNotFound means no deleted document
+// has the ID. Cascade rules are a GORM repository feature and do not apply.
+func (r *MongoBaseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	filter := bson.M{idKey: id, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{"$set": bson.M{"deleted_at": nil, "updated_at": time.Now().UTC()}}
+	result, err := r.Collection.UpdateOne(r.opCtx(ctx), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// softDelete is the update that marks documents deleted
+func softDelete() bson.M {
+	now := time.Now().UTC()
+	return bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}}
+}
+
+// Count returns the number of non-deleted entities matching the filter
+func (r *MongoBaseRepository[T]) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
+	if err := repository.CheckFilterable(r.ModelType, types.FilterOptions{Filters: filter}); err != nil {
+		return 0, err
+	}
+	query, err := filterDocument(filter, nil, false)
+	if err != nil {
+		return 0, err
+	}
+	return r.Collection.CountDocuments(r.opCtx(ctx), query)
+}
+
+// Transaction runs fn in a multi-document transaction; MongoDB only supports them on replica sets
+// and sharded clusters. Inside a transaction, fn's repository reuses it instead of nesting.
+func (r *MongoBaseRepository[T]) Transaction(ctx context.Context, fn func(txRepo repository.BaseRepository[T]) error) error {
+	if r.session != nil {
+		return fn(r)
+	}
+	session, err := r.Collection.Database().Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		txRepo := &MongoBaseRepository[T]{
+			Collection:      r.Collection,
+			ModelType:       r.ModelType,
+			UpdatableFields: r.UpdatableFields,
+			session:         session,
+		}
+		return nil, fn(txRepo)
+	})
+	return err
+}
+
+// WithinTransaction runs fn in a multi-document transaction like Transaction. The context passed to
+// fn carries the session, so the operations of every repository called with it join the
+// transaction; a context that already carries one is reused.
+func (r *MongoBaseRepository[T]) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.session != nil {
+		return fn(r.opCtx(ctx))
+	}
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	session, err := r.Collection.Database().Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// --- Bulk Operations Implementation ---
+
+// CreateMany inserts the entities in one unordered InsertMany per DefaultBatchSize documents, so
+// every valid document is written and the others are reported in the result
+func (r *MongoBaseRepository[T]) CreateMany(ctx context.Context, entities []*T) (*types.BulkResult, error) {
+	opts := types.DefaultBatchOptions()
+	opts.ContinueOnError = true
+	report, err := r.CreateInBatches(ctx, entities, opts)
+	if err != nil {
+		return nil, err
+	}
+	return types.BulkResultFromReport(report, len(entities)), nil
+}
+
+// CreateInBatches inserts entities opts.BatchSize documents at a time. MongoDB inserts are not
+// atomic per batch: with an ordered insert the documents before a failure are kept, and with
+// opts.ContinueOnError the batch is inserted unordered so every valid document is written.
+// Failed documents are reported by index either way.
+func (r *MongoBaseRepository[T]) CreateInBatches(ctx context.Context, entities []*T, opts types.BatchOptions) (*types.BatchReport[T], error) {
+	report := &types.BatchReport[T]{Succeeded: make([]*T, 0, len(entities))}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = repository.DefaultBatchSize()
+	}
+
+	for start := 0; start < len(entities); start += batchSize {
+		end := min(start+batchSize, len(entities))
+		batch := entities[start:end]
+		docs := make([]interface{}, len(batch))
+		for i, e := range batch {
+			r.stamp(e, true)
+			docs[i] = e
+		}
+
+		_, err := r.Collection.InsertMany(r.opCtx(ctx), docs, options.InsertMany().SetOrdered(!opts.ContinueOnError))
+		if err == nil {
+			report.Succeeded = append(report.Succeeded, batch...)
+			continue
+		}
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) {
+			for i := start; i < end; i++ {
+				report.Failed = append(report.Failed, types.BatchFailure{Index: i, Reason: err.Error()})
+			}
+			return report, fmt.Errorf("failed to create batch %d-%d: %w", start, end-1, err)
+		}
+
+		reasons := make(map[int]string, len(bulkErr.WriteErrors))
+		firstFailure := len(batch)
+		for _, we := range bulkErr.WriteErrors {
+			reasons[we.Index] = we.Message
+			firstFailure = min(firstFailure, we.Index)
+		}
+		for i, e := range batch {
+			switch reason, failed := reasons[i]; {
+			case failed:
+				report.Failed = append(report.Failed, types.BatchFailure{Index: start + i, Reason: reason})
+			case !opts.ContinueOnError && i > firstFailure:
+				// An ordered insert stops at the first failure
+				report.Failed = append(report.Failed, types.BatchFailure{Index: start + i, Reason: "not inserted after an earlier failure"})
+			default:
+				report.Succeeded = append(report.Succeeded, e)
+			}
+		}
+		if !opts.ContinueOnError {
+			return report, fmt.Errorf("failed to create batch %d-%d: %w", start, end-1, err)
+		}
+	}
+	return report, nil
+}
+
+// UpdateMany updates each entity (see Update). An entity without an ID, or whose update fails, is
+// reported in the result and the others are still updated.
+func (r *MongoBaseRepository[T]) UpdateMany(ctx context.Context, entities []*T) (*types.BulkResult, error) {
+	result := types.NewBulkResult(len(entities))
+	for i, e := range entities {
+		if e == nil || (*e).GetID() == uuid.Nil {
+			result.FailWith(i, repository.ErrMissingID)
+			continue
+		}
+		if err := r.Update(ctx, e); err != nil {
+			result.FailWith(i, err)
+			continue
+		}
+		result.Succeed((*e).GetID())
+	}
+	return result, nil
+}
+
+// protectedKeys are never set by UpdateWhere
+var protectedKeys = map[string]bool{"id": true, idKey: true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+// UpdateWhere sets fields on every non-deleted entity matching filter. Keys are column names as for
+// the GORM repository; update keys must be updatable and filter must not be empty.
+func (r *MongoBaseRepository[T]) UpdateWhere(ctx context.Context, filter map[string]interface{}, updates map[string]interface{}) (int64, error) {
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("%w: UpdateWhere requires a filter", types.ErrValidation)
+	}
+	if len(updates) == 0 {
+		return 0, nil
+	}
+	keys := documentKeys(r.ModelType)
+	for column := range filter {
+		if !keys[documentKey(column)] {
+			return 0, fmt.Errorf("%w: unknown filter field %q", types.ErrValidation, column)
+		}
+	}
+	set := bson.M{"updated_at": time.Now().UTC()}
+	for column, value := range updates {
+		if !keys[column] || protectedKeys[column] || (r.UpdatableFields != nil && !slices.Contains(r.UpdatableFields, column)) {
+			return 0, fmt.Errorf("%w: field %q cannot be updated", types.ErrValidation, column)
+		}
+		set[column] = value
+	}
+
+	query, err := filterDocument(filter, nil, false)
+	if err != nil {
+		return 0, err
+	}
+	result, err := r.Collection.UpdateMany(r.opCtx(ctx), query, bson.M{"$set": set})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update entities: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// DeleteMany removes, or marks deleted, the entities with the given IDs one by one (see Delete).
+// IDs that match no document, or whose delete fails, are reported in the result.
+func (r *MongoBaseRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) (*types.BulkResult, error) {
+	result := types.NewBulkResult(len(ids))
+	for i, id := range ids {
+		if err := r.Delete(ctx, id, hardDelete); err != nil {
+			result.FailWith(i, err)
+			continue
+		}
+		result.Succeed(id)
+	}
+	return result, nil
+}
@@ -5,31 +5,50 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"golang-microservices-boilerplate/pkg/core/dataloader"
 	"golang-microservices-boilerplate/pkg/core/entity"
 	"golang-microservices-boilerplate/pkg/core/types"
 )
 
+// ErrNotFound is returned when no entity matches the ID or filter of a lookup, update or delete
+var ErrNotFound = errors.New("entity not found")
+
+// ErrAlreadyExists is returned when a create or update violates a unique constraint
+var ErrAlreadyExists = errors.New("entity already exists")
+
+// ErrMissingID is reported for an item of a bulk write that needs an ID but has none
+var ErrMissingID = errors.New("entity is missing an ID")
+
 // BaseRepository defines common database operations for all repositories
 // Operates on pointers to entities (*T) where T implements entity.Entity
 type BaseRepository[T entity.Entity] interface {
 	Create(ctx context.Context, entity *T) error
 	FindByID(ctx context.Context, id uuid.UUID) (*T, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*T, error)
+	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
+	Exists(ctx context.Context, filter map[string]interface{}) (bool, error)
 	FindAll(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error)
 	Update(ctx context.Context, entity *T) error
 	Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error
+	Restore(ctx context.Context, id uuid.UUID) error
 	FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error)
 	FindOneWithFilter(ctx context.Context, filter map[string]interface{}) (*T, error)
 	Count(ctx context.Context, filter map[string]interface{}) (int64, error)
 	Transaction(ctx context.Context, fn func(txRepo BaseRepository[T]) error) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 
 	// Bulk Operations
-	CreateMany(ctx context.Context, entities []*T) ([]*T, error)
-	UpdateMany(ctx context.Context, entities []*T) ([]*T, error)
-	DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error
+	CreateMany(ctx context.Context, entities []*T) (*types.BulkResult, error)
+	CreateInBatches(ctx context.Context, entities []*T, opts types.BatchOptions) (*types.BatchReport[T], error)
+	UpdateMany(ctx context.Context, entities []*T) (*types.BulkResult, error)
+	UpdateWhere(ctx context.Context, filter map[string]interface{}, updates map[string]interface{}) (int64, error)
+	DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) (*types.BulkResult, error)
 }
 
 // GormBaseRepository implements the BaseRepository interface using GORM
@@ -37,6 +56,11 @@ type BaseRepository[T entity.Entity] interface {
 type GormBaseRepository[T entity.Entity] struct {
 	DB        *gorm.DB
 	ModelType reflect.Type
+	// UpdatableFields restricts the columns UpdateWhere may set; nil allows every column except protectedColumns
+	UpdatableFields []string
+	// Cascade lists what soft deletes do to the rows referencing the deleted entities; Restore
+	// reverts it. Hard deletes leave those rows to the database's foreign keys.
+	Cascade []CascadeRule
 }
 
 // NewGormBaseRepository creates a new GORM-based repository
@@ -53,27 +77,95 @@ func NewGormBaseRepository[T entity.Entity](db *gorm.DB) *GormBaseRepository[T]
 
 // Create adds a new entity to the database
 func (r *GormBaseRepository[T]) Create(ctx context.Context, entity *T) error {
-	return r.DB.WithContext(ctx).Create(entity).Error
+	return translateError(r.Conn(ctx).Create(entity).Error)
+}
+
+// translateError reports unique violations as ErrAlreadyExists
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrAlreadyExists
+	}
+	return err
 }
 
 // FindByID retrieves an entity by its ID
 func (r *GormBaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
 	entityPtr := reflect.New(r.ModelType).Interface().(*T)
-	result := r.DB.WithContext(ctx).Where("id = ?", id).First(entityPtr)
+	result := r.Conn(ctx).Where("id = ?", id).First(entityPtr)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("entity not found")
+			return nil, ErrNotFound
 		}
 		return nil, result.Error
 	}
 	return entityPtr, nil
 }
 
+// LoaderByID returns the request's batched loader of entities by ID (see dataloader.For).
+// Use it when resolving related entities item by item to issue one query per batch.
+func (r *GormBaseRepository[T]) LoaderByID(ctx context.Context) *dataloader.Loader[uuid.UUID, *T] {
+	return dataloader.For(ctx, "repository:"+r.ModelType.String()+":id", r.FindByIDs)
+}
+
+// FindByIDs retrieves the entities with the given IDs in one query, keyed by ID.
+// IDs without an entity are absent from the map.
+func (r *GormBaseRepository[T]) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*T, error) {
+	if len(ids) == 0 {
+		return map[uuid.UUID]*T{}, nil
+	}
+	var entities []*T
+	if err := r.Conn(ctx).Where("id IN ?", ids).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*T, len(entities))
+	for _, e := range entities {
+		byID[(*e).GetID()] = e
+	}
+	return byID, nil
+}
+
+// ExistsByID reports whether an entity with the ID exists, like FindByID but without loading it
+func (r *GormBaseRepository[T]) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	modelInstance := reflect.New(r.ModelType).Interface()
+	return r.exists(ctx, r.Conn(ctx).Model(modelInstance).Where("id = ?", id))
+}
+
+// Exists reports whether a non-deleted entity matches the filter
+func (r *GormBaseRepository[T]) Exists(ctx context.Context, filter map[string]interface{}) (bool, error) {
+	modelInstance := reflect.New(r.ModelType).Interface()
+	db := r.Conn(ctx).Model(modelInstance)
+	filter, err := r.encryptFilter(db, filter)
+	if err != nil {
+		return false, err
+	}
+	if len(filter) > 0 {
+		db = db.Where(filter)
+	}
+	return r.exists(ctx, db.Where("deleted_at IS NULL"))
+}
+
+// exists runs SELECT EXISTS over the query, which stops at the first matching row
+func (r *GormBaseRepository[T]) exists(ctx context.Context, query *gorm.DB) (bool, error) {
+	var exists bool
+	err := r.Conn(ctx).Raw("SELECT EXISTS (?)", query.Select("1")).Scan(&exists).Error
+	return exists, err
+}
+
 // applyFilterOptions applies the provided filter options to a GORM query
 func (r *GormBaseRepository[T]) applyFilterOptions(db *gorm.DB, opts types.FilterOptions) *gorm.DB {
+	if err := CheckFilterable(r.ModelType, opts); err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+	opts, err := r.encryptFilterOptions(db, opts)
+	if err != nil {
+		_ = db.AddError(err)
+		return db
+	}
 	if len(opts.Filters) > 0 {
 		db = db.Where(opts.Filters)
 	}
+	db = ApplyConditions(db, opts.Conditions)
 
 	sortDirection := "ASC"
 	if opts.SortDesc {
@@ -101,19 +193,20 @@ func (r *GormBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOp
 	var totalCount int64
 
 	modelInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Model(modelInstance)
+	db := r.Conn(ctx).Model(modelInstance)
 
 	if !opts.IncludeDeleted {
 		db = db.Where("deleted_at IS NULL")
 	}
 
 	// Apply filters/search for counting total items (without pagination)
-	countDB := r.DB.WithContext(ctx).Model(modelInstance)
+	countDB := r.Conn(ctx).Model(modelInstance)
 	if !opts.IncludeDeleted {
 		countDB = countDB.Where("deleted_at IS NULL")
 	}
 	countOpts := types.FilterOptions{
 		Filters:        opts.Filters,
+		Conditions:     opts.Conditions,
 		IncludeDeleted: opts.IncludeDeleted,
 	}
 	countDB = r.applyFilterOptions(countDB, countOpts)
@@ -123,6 +216,10 @@ func (r *GormBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOp
 
 	// Apply all options for fetching items
 	queryDB := r.applyFilterOptions(db, opts)
+	if e := explainer.Load(); e.enabled(ctx) {
+		var plan []*T
+		e.explainFind(ctx, queryDB, &plan)
+	}
 	if err := queryDB.Find(&entities).Error; err != nil {
 		return nil, fmt.Errorf("failed to find items: %w", err)
 	}
@@ -146,29 +243,39 @@ func (r *GormBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOp
 
 // FindWithFilter retrieves entities that match the provided filter criteria
 func (r *GormBaseRepository[T]) FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error) {
-	if opts.Filters == nil {
-		opts.Filters = make(map[string]interface{})
-	}
+	opts = opts.Clone() // Never write to the caller's Filters map
 	for k, v := range filter {
 		opts.Filters[k] = v
 	}
 	return r.FindAll(ctx, opts)
 }
 
-// Update modifies an existing entity
+// Update modifies an existing entity from its non-zero fields, or from the columns it names with
+// SetUpdateColumns; ErrNotFound means no row has its ID
 func (r *GormBaseRepository[T]) Update(ctx context.Context, entity *T) error {
 	id := (*entity).GetID()
 	if id == uuid.Nil {
 		return errors.New("entity must have a valid ID for update")
 	}
-	return r.DB.WithContext(ctx).Model(entity).Where("id = ?", id).Updates(entity).Error
+	result := updateScope(r.Conn(ctx).Model(entity).Where("id = ?", id), entity).Updates(entity)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 // FindOneWithFilter retrieves the first entity that matches the provided filter criteria
 func (r *GormBaseRepository[T]) FindOneWithFilter(ctx context.Context, filter map[string]interface{}) (*T, error) {
 	entityPtr := reflect.New(r.ModelType).Interface().(*T)
-	db := r.DB.WithContext(ctx).Model(reflect.New(r.ModelType).Interface())
+	db := r.Conn(ctx).Model(reflect.New(r.ModelType).Interface())
 
+	filter, err := r.encryptFilter(db, filter)
+	if err != nil {
+		return nil, err
+	}
 	if len(filter) > 0 {
 		db = db.Where(filter)
 	}
@@ -177,134 +284,335 @@ func (r *GormBaseRepository[T]) FindOneWithFilter(ctx context.Context, filter ma
 	result := db.First(entityPtr)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("entity not found")
+			return nil, ErrNotFound
 		}
 		return nil, result.Error
 	}
 	return entityPtr, nil
 }
 
-// Delete removes an entity from the database by ID
+// Delete removes an entity from the database by ID, or marks it deleted; ErrNotFound means no row
+// (for a soft delete, no row that is not deleted yet) has the ID
 func (r *GormBaseRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
-	entityInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Where("id = ?", id)
+	return r.cascading(ctx, func(db *gorm.DB) error {
+		deleted, err := r.deleteRows(db, []uuid.UUID{id}, hardDelete)
+		if err == nil && deleted == 0 {
+			return ErrNotFound
+		}
+		return err
+	})
+}
 
-	var result *gorm.DB
+// Restore clears the deletion mark of a soft-deleted entity and reverts what the Cascade rules did
+// to the rows referencing it, in one transaction; ErrNotFound means no deleted row has the ID
+func (r *GormBaseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.cascading(ctx, func(db *gorm.DB) error {
+		modelInstance := reflect.New(r.ModelType).Interface()
+		// UpdateColumns skips the entity hooks, which would validate or rewrite the stored fields
+		result := db.Model(modelInstance).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			UpdateColumns(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now().UTC()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if len(r.Cascade) == 0 {
+			return nil
+		}
+		return r.cascadeRestore(db, id)
+	})
+}
+
+// cascading runs fn on the request's connection, in a transaction when there are Cascade rules so
+// that the children change with their parents
+func (r *GormBaseRepository[T]) cascading(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if len(r.Cascade) == 0 {
+		return fn(r.Conn(ctx))
+	}
+	return InTransaction(ctx, r.Conn(ctx), fn)
+}
+
+// deleteRows removes, or marks deleted, the rows with the given IDs and applies the Cascade rules
+// to their children. The IDs are those of rows not deleted yet (see DeleteMany), or a single ID.
+// Returns the number of deleted rows.
+func (r *GormBaseRepository[T]) deleteRows(db *gorm.DB, ids []uuid.UUID, hardDelete bool) (int64, error) {
+	modelInstance := reflect.New(r.ModelType).Interface()
 	if hardDelete {
-		result = db.Unscoped().Delete(entityInstance)
-	} else {
-		result = db.Delete(entityInstance)
+		result := db.Unscoped().Where("id IN ?", ids).Delete(modelInstance)
+		if result.Error != nil || result.RowsAffected == 0 || len(r.Cascade) == 0 {
+			return result.RowsAffected, result.Error
+		}
+		parentTable, err := r.tableName(db)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected, r.forgetCascade(db, parentTable, ids)
+	}
+
+	// DeletedAt is a plain timestamp rather than gorm.DeletedAt, so GORM's Delete would remove the
+	// row; the mark is set explicitly, skipping the entity hooks like UpdateColumns does
+	now := time.Now().UTC()
+	result := db.Model(modelInstance).
+		Where("id IN ? AND deleted_at IS NULL", ids).
+		UpdateColumns(map[string]interface{}{"deleted_at": now, "updated_at": now})
+	if result.Error != nil || result.RowsAffected == 0 || len(r.Cascade) == 0 {
+		return result.RowsAffected, result.Error
 	}
-	return result.Error
+	return result.RowsAffected, r.cascadeDelete(db, ids, now)
 }
 
 // Count returns the count of entities matching the filter
 func (r *GormBaseRepository[T]) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
 	var count int64
 	modelInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Model(modelInstance)
+	db := r.Conn(ctx).Model(modelInstance)
 
+	if err := CheckFilterable(r.ModelType, types.FilterOptions{Filters: filter}); err != nil {
+		return 0, err
+	}
+	filter, err := r.encryptFilter(db, filter)
+	if err != nil {
+		return 0, err
+	}
 	if len(filter) > 0 {
 		db = db.Where(filter)
 	}
 	db = db.Where("deleted_at IS NULL")
 
-	err := db.Count(&count).Error
+	err = db.Count(&count).Error
 	return count, err
 }
 
 // Transaction runs a function within a database transaction
 func (r *GormBaseRepository[T]) Transaction(ctx context.Context, fn func(txRepo BaseRepository[T]) error) error {
-	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
 		txRepo := &GormBaseRepository[T]{
-			DB:        tx,
-			ModelType: r.ModelType,
+			DB:              tx,
+			ModelType:       r.ModelType,
+			UpdatableFields: r.UpdatableFields,
+			Cascade:         r.Cascade,
 		}
 		return fn(txRepo)
 	})
 }
 
+// WithinTransaction runs fn in a transaction (a savepoint when ctx already carries one) and passes
+// it a context carrying the transaction, so every repository called with that context joins it
+func (r *GormBaseRepository[T]) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
+		return fn(WithTx(tx.Statement.Context, tx))
+	})
+}
+
 // --- Bulk Operations Implementation ---
 
-// CreateMany adds multiple entities to the database in a single batch.
-// Returns the slice of created entities with DB-generated fields populated.
-func (r *GormBaseRepository[T]) CreateMany(ctx context.Context, entities []*T) ([]*T, error) {
-	if len(entities) == 0 {
-		return entities, nil // Return empty slice, no error
-	}
-	err := r.DB.WithContext(ctx).Create(entities).Error
+// CreateMany adds multiple entities to the database in INSERT statements of
+// DefaultBatchOptions().BatchSize rows. The rows of a failed statement are retried one by one, so
+// the result names exactly which entities could not be created and why. The created entities have
+// their DB-generated fields populated.
+func (r *GormBaseRepository[T]) CreateMany(ctx context.Context, entities []*T) (*types.BulkResult, error) {
+	opts := types.DefaultBatchOptions()
+	opts.ContinueOnError = true
+	report, err := r.CreateInBatches(ctx, entities, opts)
 	if err != nil {
-		return nil, err // Return nil slice on error
+		return nil, err
 	}
-	return entities, nil // Return the input slice, now populated by GORM
+	return types.BulkResultFromReport(report, len(entities)), nil
 }
 
-// UpdateMany updates multiple entities within a transaction based on the non-zero fields in the input entities.
-// It then fetches and returns the full entities from the database after the update.
-func (r *GormBaseRepository[T]) UpdateMany(ctx context.Context, entities []*T) ([]*T, error) {
-	if len(entities) == 0 {
-		return entities, nil // Return empty slice, no error
+// CreateInBatches inserts entities opts.BatchSize rows at a time, each batch in its own transaction
+// (a savepoint when r runs inside a transaction). On a failed batch it stops and returns the error,
+// unless opts.ContinueOnError is set: then the batch's items are retried one by one so the report
+// names exactly which ones failed and why. The report is returned in both cases.
+func (r *GormBaseRepository[T]) CreateInBatches(ctx context.Context, entities []*T, opts types.BatchOptions) (*types.BatchReport[T], error) {
+	report := &types.BatchReport[T]{Succeeded: make([]*T, 0, len(entities))}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize()
 	}
-
-	updatedIDs := make([]uuid.UUID, 0, len(entities))
-
-	// Perform updates within a transaction
-	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, entity := range entities {
-			id := (*entity).GetID()
-			if id == uuid.Nil {
-				return fmt.Errorf("entity in bulk update list missing ID")
-			}
-			// Perform partial update based on the fields present in the input entity
-			// Note: GORM's Updates only updates non-zero fields by default for structs.
-			// If you need to update specific fields to zero values, use map[string]interface{} or Select.
-			if err := tx.Model(entity).Where("id = ?", id).Updates(entity).Error; err != nil {
-				return fmt.Errorf("failed to update entity with ID %s during bulk update: %w", id, err)
+	db := r.Conn(ctx)
+
+	for start := 0; start < len(entities); start += batchSize {
+		end := min(start+batchSize, len(entities))
+		batch := entities[start:end]
+
+		err := InTransaction(ctx, db, func(tx *gorm.DB) error {
+			return tx.Create(batch).Error
+		})
+		if err == nil {
+			report.Succeeded = append(report.Succeeded, batch...)
+			continue
+		}
+		if !opts.ContinueOnError {
+			for i := start; i < end; i++ {
+				report.Failed = append(report.Failed, types.NewBatchFailure(i, err))
 			}
-			updatedIDs = append(updatedIDs, id) // Collect ID for re-fetching
+			return report, fmt.Errorf("failed to create batch %d-%d: %w", start, end-1, err)
 		}
-		return nil
-	})
 
-	if err != nil {
-		return nil, err // Return nil slice on transaction error
+		// Isolate the failing rows
+		for i, entity := range batch {
+			err := InTransaction(ctx, db, func(tx *gorm.DB) error {
+				return tx.Create(entity).Error
+			})
+			if err != nil {
+				report.Failed = append(report.Failed, types.NewBatchFailure(start+i, err))
+				continue
+			}
+			report.Succeeded = append(report.Succeeded, entity)
+		}
 	}
+	return report, nil
+}
+
+// DefaultBatchSize is the number of rows per INSERT for bulk creates (DB_BATCH_SIZE, default 500)
+func DefaultBatchSize() int {
+	return types.DefaultBatchOptions().BatchSize
+}
 
-	// If updates were successful, fetch the full entities
-	if len(updatedIDs) > 0 {
-		var updatedEntities []*T
-		if err := r.DB.WithContext(ctx).Where("id IN (?)", updatedIDs).Find(&updatedEntities).Error; err != nil {
-			// Log the error, but perhaps still return the original entities or handle differently?
-			// Returning an error here might be confusing if the update itself succeeded.
-			// For now, let's return the fetch error.
-			return nil, fmt.Errorf("updates succeeded, but failed to fetch updated entities: %w", err)
+// UpdateMany updates each entity like Update does, each in its own transaction (a savepoint when
+// r runs inside a transaction). An entity without an ID, with no stored row (ErrNotFound) or whose
+// update fails is reported in the result and the others are still updated.
+func (r *GormBaseRepository[T]) UpdateMany(ctx context.Context, entities []*T) (*types.BulkResult, error) {
+	result := types.NewBulkResult(len(entities))
+	db := r.Conn(ctx)
+	for i, entity := range entities {
+		if entity == nil || (*entity).GetID() == uuid.Nil {
+			result.FailWith(i, ErrMissingID)
+			continue
 		}
-		return updatedEntities, nil
+		id := (*entity).GetID()
+		// Only non-zero fields are written, unless the entity names its update columns
+		err := InTransaction(ctx, db, func(tx *gorm.DB) error {
+			update := updateScope(tx.Model(entity).Where("id = ?", id), entity).Updates(entity)
+			if update.Error == nil && update.RowsAffected == 0 {
+				return ErrNotFound
+			}
+			return update.Error
+		})
+		if err != nil {
+			result.FailWith(i, err)
+			continue
+		}
+		result.Succeed(id)
 	}
+	return result, nil
+}
 
-	// Should not happen if input entities slice was not empty, but return empty slice just in case.
-	return []*T{}, nil
+// updateScope selects the update columns of entity (see entity.BaseEntity.SetUpdateColumns) on
+// db, so that zero values in them are written too. updated_at is still set automatically.
+func updateScope(db *gorm.DB, entity interface{}) *gorm.DB {
+	if masked, ok := entity.(interface{ UpdateColumns() []string }); ok {
+		if columns := masked.UpdateColumns(); len(columns) > 0 {
+			return db.Select(columns)
+		}
+	}
+	return db
 }
 
-// DeleteMany removes multiple entities matching the provided IDs.
-func (r *GormBaseRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error {
-	if len(ids) == 0 {
-		return nil
+// protectedColumns are never set by UpdateWhere
+var protectedColumns = map[string]bool{"id": true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+// UpdateWhere sets columns on every non-deleted entity matching filter without loading them,
+// e.g. deactivating all users of a role. Filter and update keys are column names; update keys must
+// be updatable (see UpdatableFields) and filter must not be empty. Returns the number of updated rows.
+func (r *GormBaseRepository[T]) UpdateWhere(ctx context.Context, filter map[string]interface{}, updates map[string]interface{}) (int64, error) {
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("%w: UpdateWhere requires a filter", types.ErrValidation)
+	}
+	if len(updates) == 0 {
+		return 0, nil
 	}
 
 	modelInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Where("id IN (?)", ids)
+	stmt := &gorm.Statement{DB: r.DB}
+	if err := stmt.Parse(modelInstance); err != nil {
+		return 0, fmt.Errorf("failed to parse model: %w", err)
+	}
+	for column := range filter {
+		if _, ok := stmt.Schema.FieldsByDBName[column]; !ok {
+			return 0, fmt.Errorf("%w: unknown filter field %q", types.ErrValidation, column)
+		}
+	}
+	for column := range updates {
+		if _, ok := stmt.Schema.FieldsByDBName[column]; !ok || !r.isUpdatable(column) {
+			return 0, fmt.Errorf("%w: field %q cannot be updated", types.ErrValidation, column)
+		}
+	}
 
-	var result *gorm.DB
-	if hardDelete {
-		result = db.Unscoped().Delete(modelInstance)
-	} else {
-		result = db.Delete(modelInstance)
+	fields, err := r.encryptedFields(r.DB)
+	if err != nil {
+		return 0, err
+	}
+	if filter, err = encryptFilters(fields, filter); err != nil {
+		return 0, err
+	}
+	// The entity's hooks would run on a blank model and reject it, so they are skipped and
+	// updated_at, which GORM only sets along with them, is set here
+	updates = copyMap(updates)
+	updates["updated_at"] = time.Now().UTC()
+	if updates, err = encryptUpdates(ctx, fields, updates); err != nil {
+		return 0, err
 	}
 
+	result := r.Conn(ctx).Session(&gorm.Session{SkipHooks: true}).Model(modelInstance).
+		Where(filter).
+		Where("deleted_at IS NULL").
+		Updates(updates)
 	if result.Error != nil {
-		return fmt.Errorf("failed during bulk delete: %w", result.Error)
+		return 0, fmt.Errorf("failed to update entities: %w", result.Error)
 	}
+	return result.RowsAffected, nil
+}
 
-	return nil
+// isUpdatable reports whether UpdateWhere may set column
+func (r *GormBaseRepository[T]) isUpdatable(column string) bool {
+	if protectedColumns[column] {
+		return false
+	}
+	return r.UpdatableFields == nil || slices.Contains(r.UpdatableFields, column)
+}
+
+// DeleteMany removes, or marks deleted, the entities with the given IDs in one statement, applying
+// the Cascade rules like Delete. IDs that match no entity (or, for a soft delete, only an already
+// deleted one) are reported as not found. An error means the statement failed and nothing was deleted.
+func (r *GormBaseRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) (*types.BulkResult, error) {
+	result := types.NewBulkResult(len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	modelInstance := reflect.New(r.ModelType).Interface()
+	err := InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
+		query := tx.Model(modelInstance).Where("id IN (?)", ids)
+		if !hardDelete {
+			query = query.Where("deleted_at IS NULL")
+		}
+		var existing []uuid.UUID
+		if err := query.Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		found := make(map[uuid.UUID]bool, len(existing))
+		for _, id := range existing {
+			found[id] = true
+		}
+		for i, id := range ids {
+			if found[id] {
+				result.Succeed(id)
+			} else {
+				result.FailWith(i, ErrNotFound)
+			}
+		}
+		if len(existing) == 0 {
+			return nil
+		}
+		_, err := r.deleteRows(tx, existing, hardDelete)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed during bulk delete: %w", err)
+	}
+	return result, nil
 }
@@ -0,0 +1,311 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	dbtest "golang-microservices-boilerplate/pkg/core/database/testing"
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// widget is the entity the repository tests store
+type widget struct {
+	entity.BaseEntity
+	Name     string  `gorm:"size:64;not null"`
+	Category string  `gorm:"size:32"`
+	Price    int     `gorm:"not null;default:0"`
+	Note     *string `gorm:"size:255"`
+	Secret   string  `gorm:"size:64"` // Not filterable
+}
+
+// FilterableFields implements entity.Filterable
+func (widget) FilterableFields() []string {
+	return []string{"id", "name", "category", "price", "note", "created_at", "updated_at"}
+}
+
+// startRepository starts the test database and returns a repository bound to a transaction that
+// is rolled back when the test ends
+func startRepository(t *testing.T) *repository.GormBaseRepository[widget] {
+	t.Helper()
+	pc := dbtest.MustStartPostgres(t, &widget{})
+	tx := pc.Conn.DB.Begin()
+	if tx.Error != nil {
+		t.Fatalf("failed to begin test transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	return repository.NewGormBaseRepository[widget](tx)
+}
+
+// createWidgets stores n widgets named widget-00, widget-01, ... with increasing prices
+func createWidgets(t *testing.T, repo *repository.GormBaseRepository[widget], n int) []*widget {
+	t.Helper()
+	widgets := make([]*widget, 0, n)
+	for i := 0; i < n; i++ {
+		category := "even"
+		if i%2 == 1 {
+			category = "odd"
+		}
+		widgets = append(widgets, &widget{Name: fmt.Sprintf("widget-%02d", i), Category: category, Price: i * 10})
+	}
+	result, err := repo.CreateMany(context.Background(), widgets)
+	if err != nil || len(result.Failed) > 0 {
+		t.Fatalf("failed to create widgets: %v %+v", err, result)
+	}
+	return widgets
+}
+
+func TestFindAllPagination(t *testing.T) {
+	repo := startRepository(t)
+	ctx := context.Background()
+	createWidgets(t, repo, 25)
+
+	var names []string
+	for offset := 0; offset < 30; offset += 10 {
+		page, err := repo.FindAll(ctx, types.FilterOptions{Limit: 10, Offset: offset, SortBy: "name"})
+		if err != nil {
+			t.Fatalf("FindAll(offset %d): %v", offset, err)
+		}
+		if page.TotalItems != 25 {
+			t.Errorf("offset %d: TotalItems = %d, want 25", offset, page.TotalItems)
+		}
+		if page.Limit != 10 || page.Offset != offset {
+			t.Errorf("offset %d: Limit, Offset = %d, %d", offset, page.Limit, page.Offset)
+		}
+		for _, w := range page.Items {
+			names = append(names, w.Name)
+		}
+	}
+	if len(names) != 25 {
+		t.Fatalf("pages returned %d widgets, want 25", len(names))
+	}
+	for i, name := range names {
+		if want := fmt.Sprintf("widget-%02d", i); name != want {
+			t.Fatalf("widget %d = %s, want %s: pages overlap or are out of order", i, name, want)
+		}
+	}
+
+	page, err := repo.FindAll(ctx, types.FilterOptions{Limit: 5, SortBy: "price", SortDesc: true, Filters: map[string]interface{}{"category": "odd"}})
+	if err != nil {
+		t.Fatalf("FindAll(odd): %v", err)
+	}
+	if page.TotalItems != 12 || len(page.Items) != 5 || page.Items[0].Name != "widget-23" {
+		t.Errorf("odd widgets: total %d, %d items, first %s; want 12, 5, widget-23", page.TotalItems, len(page.Items), page.Items[0].Name)
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	repo := startRepository(t)
+	ctx := context.Background()
+	widgets := createWidgets(t, repo, 3)
+	deleted := widgets[1].ID
+
+	if err := repo.Delete(ctx, deleted, false); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	// FindByID still loads the marked row; Exists and Count skip it
+	if w, err := repo.FindByID(ctx, deleted); err != nil || w.DeletedAt == nil {
+		t.Errorf("FindByID after soft delete = %+v, %v; want the row with DeletedAt set", w, err)
+	}
+	if err := repo.Delete(ctx, deleted, false); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("second Delete: err = %v, want ErrNotFound", err)
+	}
+	if exists, err := repo.Exists(ctx, map[string]interface{}{"id": deleted}); err != nil || exists {
+		t.Errorf("Exists after soft delete = %v, %v; want false", exists, err)
+	}
+	if count, err := repo.Count(ctx, nil); err != nil || count != 2 {
+		t.Errorf("Count after soft delete = %d, %v; want 2", count, err)
+	}
+
+	page, err := repo.FindAll(ctx, types.FilterOptions{})
+	if err != nil || page.TotalItems != 2 {
+		t.Fatalf("FindAll after soft delete: %v, total %d; want 2", err, page.TotalItems)
+	}
+	page, err = repo.FindAll(ctx, types.FilterOptions{IncludeDeleted: true})
+	if err != nil || page.TotalItems != 3 {
+		t.Fatalf("FindAll including deleted: %v, total %d; want 3", err, page.TotalItems)
+	}
+	for _, w := range page.Items {
+		if (w.ID == deleted) != (w.DeletedAt != nil) {
+			t.Errorf("widget %s: DeletedAt = %v", w.Name, w.DeletedAt)
+		}
+	}
+
+	if err := repo.Restore(ctx, deleted); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if err := repo.Restore(ctx, deleted); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("second Restore: err = %v, want ErrNotFound", err)
+	}
+	if w, err := repo.FindByID(ctx, deleted); err != nil || w.DeletedAt != nil {
+		t.Errorf("FindByID after restore = %+v, %v", w, err)
+	}
+
+	if err := repo.Delete(ctx, deleted, true); err != nil {
+		t.Fatalf("hard Delete: %v", err)
+	}
+	if err := repo.Restore(ctx, deleted); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Restore after hard delete: err = %v, want ErrNotFound", err)
+	}
+	if page, _ := repo.FindAll(ctx, types.FilterOptions{IncludeDeleted: true}); page.TotalItems != 2 {
+		t.Errorf("FindAll including deleted after hard delete: total %d, want 2", page.TotalItems)
+	}
+}
+
+func TestUpdateMany(t *testing.T) {
+	repo := startRepository(t)
+	ctx := context.Background()
+	widgets := createWidgets(t, repo, 4)
+	before := widgets[0].UpdatedAt
+	time.Sleep(10 * time.Millisecond)
+
+	note := "restocked"
+	masked := &widget{Name: widgets[2].Name, Price: 0}
+	masked.ID = widgets[2].ID
+	masked.SetUpdateColumns("price") // Writes the zero price
+	updates := []*widget{
+		{BaseEntity: entity.BaseEntity{ID: widgets[0].ID}, Price: 999, Note: &note},
+		{Name: "no-id"},
+		masked,
+		{BaseEntity: entity.BaseEntity{ID: widgets[3].ID}, Name: strings.Repeat("x", 100)}, // Too long for the column
+		{BaseEntity: entity.BaseEntity{ID: uuid.New()}, Name: "unknown"},
+	}
+	result, err := repo.UpdateMany(ctx, updates)
+	if err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+	if result.Total != 5 || len(result.Succeeded) != 2 || len(result.Failed) != 3 {
+		t.Fatalf("UpdateMany result = %+v; want 2 succeeded and 3 failed of 5", result)
+	}
+	wantFailures := []struct {
+		index int
+		err   error // Nil for a database error
+	}{{1, repository.ErrMissingID}, {3, nil}, {4, repository.ErrNotFound}}
+	for i, want := range wantFailures {
+		got := result.Failed[i]
+		if got.Index != want.index || got.Err == nil {
+			t.Errorf("failure %d = %+v; want index %d with its error", i, got, want.index)
+		} else if want.err != nil && !errors.Is(got.Err, want.err) {
+			t.Errorf("failure %d: err = %v, want %v", i, got.Err, want.err)
+		}
+	}
+
+	// Each item is written in its own savepoint, so the failure did not undo the others
+	first, err := repo.FindByID(ctx, widgets[0].ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if first.Price != 999 || first.Note == nil || *first.Note != note || first.Name != widgets[0].Name {
+		t.Errorf("updated widget = %+v; want price 999, the note and the old name", first)
+	}
+	if !first.UpdatedAt.After(before) {
+		t.Errorf("UpdatedAt = %v, not after %v", first.UpdatedAt, before)
+	}
+	if third, _ := repo.FindByID(ctx, widgets[2].ID); third == nil || third.Price != 0 {
+		t.Errorf("masked update did not write the zero price: %+v", third)
+	}
+	if fourth, _ := repo.FindByID(ctx, widgets[3].ID); fourth == nil || fourth.Name != widgets[3].Name {
+		t.Errorf("failed update changed the widget: %+v", fourth)
+	}
+}
+
+// dryRunRepository returns a repository whose statements are rendered for PostgreSQL and recorded,
+// with their placeholders, instead of being sent to a database
+func dryRunRepository(t *testing.T) (*repository.GormBaseRepository[widget], *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open dry-run database: %v", err)
+	}
+	var statements []string
+	err = db.Callback().Query().After("gorm:query").Register("test:record_sql", func(db *gorm.DB) {
+		statements = append(statements, strings.TrimSpace(db.Statement.SQL.String()))
+	})
+	if err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+	return repository.NewGormBaseRepository[widget](db), &statements
+}
+
+func TestFilterSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts types.FilterOptions
+		want string // Query of the items, after the count query
+	}{
+		{
+			name: "equality filters and paging",
+			opts: types.FilterOptions{Limit: 10, Offset: 20, SortBy: "name", Filters: map[string]interface{}{"category": "odd"}},
+			want: `SELECT * FROM "widgets" WHERE deleted_at IS NULL AND "category" = $1 ORDER BY name ASC LIMIT $2 OFFSET $3`,
+		},
+		{
+			name: "comparison operators",
+			opts: types.FilterOptions{SortBy: "price", SortDesc: true, Conditions: []types.FilterCondition{
+				types.NewCondition("price", types.OpGte, 10),
+				types.NewCondition("price", types.OpLt, 100),
+				types.NewCondition("category", types.OpNe, "odd"),
+			}},
+			want: `SELECT * FROM "widgets" WHERE deleted_at IS NULL AND price >= $1 AND price < $2 AND category <> $3 ORDER BY price DESC`,
+		},
+		{
+			name: "lists, null checks and patterns",
+			opts: types.FilterOptions{Conditions: []types.FilterCondition{
+				types.NewCondition("category", types.OpIn, []string{"odd", "even"}),
+				types.NewCondition("name", types.OpNotIn, []string{}),
+				types.NewCondition("note", types.OpIsNull, true),
+				types.NewCondition("name", types.OpContains, "50%_off"),
+			}},
+			want: `SELECT * FROM "widgets" WHERE deleted_at IS NULL AND category IN ($1,$2) AND 1 = 1 AND note IS NULL AND LOWER(name) LIKE LOWER($3)`,
+		},
+		{
+			name: "any group",
+			opts: types.FilterOptions{IncludeDeleted: true, Conditions: []types.FilterCondition{
+				types.AnyOf(types.NewCondition("category", types.OpEq, "odd"), types.NewCondition("price", types.OpGt, 50)),
+			}},
+			want: `SELECT * FROM "widgets" WHERE (category = $1) OR (price > $2)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, statements := dryRunRepository(t)
+			if _, err := repo.FindAll(context.Background(), tt.opts); err != nil {
+				t.Fatalf("FindAll: %v", err)
+			}
+			if len(*statements) != 2 {
+				t.Fatalf("statements = %q; want a count and a query", *statements)
+			}
+			if !strings.HasPrefix((*statements)[0], `SELECT count(*) FROM "widgets"`) {
+				t.Errorf("count = %s", (*statements)[0])
+			}
+			// GORM 1.30 qualifies the columns of map conditions with the table, earlier versions do not
+			if got := strings.ReplaceAll((*statements)[1], `"widgets".`, ""); got != tt.want {
+				t.Errorf("query =\n  %s\nwant\n  %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRejectsUnlistedFields(t *testing.T) {
+	for _, opts := range []types.FilterOptions{
+		{SortBy: "secret"},
+		{Filters: map[string]interface{}{"secret": "x"}},
+		{Conditions: []types.FilterCondition{types.AnyOf(types.NewCondition("secret", types.OpStartsWith, "a"))}},
+		{Conditions: []types.FilterCondition{types.NewCondition("name; DROP TABLE widgets", types.OpEq, "x")}},
+	} {
+		repo, _ := dryRunRepository(t)
+		if _, err := repo.FindAll(context.Background(), opts); !errors.Is(err, types.ErrValidation) {
+			t.Errorf("FindAll(%+v): err = %v, want ErrValidation", opts, err)
+		}
+	}
+}
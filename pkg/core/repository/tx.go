@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+type txContextKey struct{}
+
+// WithTx returns a context carrying tx. Repositories embedding GormBaseRepository run their
+// queries on tx when called with that context, so the writes of several repositories commit or
+// roll back together (see grpc.TransactionUnaryInterceptor).
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction stored by WithTx, if any
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// Conn returns the connection a query of the repository should use: the request's transaction
+// when ctx carries one, else the repository's DB. A repository already bound to a transaction,
+// such as the one passed to Transaction callbacks, keeps using it, along with its commit hooks.
+func (r *GormBaseRepository[T]) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok && !inTransaction(r.DB) {
+		return tx.WithContext(inheritCommitHooks(ctx, tx))
+	}
+	return r.DB.WithContext(inheritCommitHooks(ctx, r.DB))
+}
+
+// inTransaction reports whether db is bound to an open transaction
+func inTransaction(db *gorm.DB) bool {
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
+
+type commitHooksKey struct{}
+
+// commitHooks collects the functions registered with AfterCommit during a transaction
+type commitHooks struct {
+	mu     sync.Mutex
+	parent *commitHooks // Hooks of the enclosing transaction, which the collected ones move to
+	fns    []func()
+	done   bool
+}
+
+// add collects fn, or runs it when the transaction has already ended
+func (h *commitHooks) add(fn func()) {
+	h.mu.Lock()
+	if !h.done {
+		h.fns = append(h.fns, fn)
+		h.mu.Unlock()
+		return
+	}
+	h.mu.Unlock()
+	fn()
+}
+
+// finish ends the transaction: the collected functions move to the enclosing transaction or run
+// when it committed, and are dropped when it rolled back
+func (h *commitHooks) finish(committed bool) {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns, h.done = nil, true
+	h.mu.Unlock()
+	if !committed {
+		return
+	}
+	for _, fn := range fns {
+		if h.parent != nil {
+			h.parent.add(fn)
+		} else {
+			fn()
+		}
+	}
+}
+
+// WithCommitHooks returns a context collecting the functions passed to AfterCommit, for code that
+// begins and commits a transaction itself (see grpc.TransactionUnaryInterceptor). Call finish once
+// the transaction ended: the functions run when it committed and are dropped when it rolled back.
+// Inside another transaction with commit hooks they move to that one instead of running.
+func WithCommitHooks(ctx context.Context) (hooksCtx context.Context, finish func(committed bool)) {
+	parent, _ := ctx.Value(commitHooksKey{}).(*commitHooks)
+	hooks := &commitHooks{parent: parent}
+	return context.WithValue(ctx, commitHooksKey{}, hooks), hooks.finish
+}
+
+// inheritCommitHooks adds the commit hooks of the transaction db is bound to, if any, to a ctx
+// without its own
+func inheritCommitHooks(ctx context.Context, db *gorm.DB) context.Context {
+	if !inTransaction(db) || ctx.Value(commitHooksKey{}) != nil {
+		return ctx
+	}
+	if hooks, ok := db.Statement.Context.Value(commitHooksKey{}).(*commitHooks); ok {
+		return context.WithValue(ctx, commitHooksKey{}, hooks)
+	}
+	return ctx
+}
+
+// AfterCommit runs fn once the transaction ctx belongs to commits, and drops it when the
+// transaction rolls back. Transactions started with InTransaction, or by the request transaction
+// interceptor, qualify; without one, fn runs right away.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		hooks.add(fn)
+		return
+	}
+	fn()
+}
+
+// InTransaction runs fn in a transaction of db like db.Transaction, a savepoint when db already
+// is in one, with commit hooks (see WithCommitHooks) on the context of the tx passed to fn. When db
+// is bound to a transaction begun without commit hooks, they run once the savepoint is released.
+func InTransaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	ctx, finish := WithCommitHooks(inheritCommitHooks(ctx, db))
+	err := db.WithContext(ctx).Transaction(fn)
+	finish(err == nil)
+	return err
+}
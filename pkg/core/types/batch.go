@@ -0,0 +1,152 @@
+package types
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/utils"
+	corePb "golang-microservices-boilerplate/proto/core"
+)
+
+// BatchOptions controls chunked bulk writes
+type BatchOptions struct {
+	BatchSize       int  // Rows per INSERT statement
+	ContinueOnError bool // Keep going after a failed batch, reporting the items that failed
+}
+
+// DefaultBatchOptions reads the batch size from DB_BATCH_SIZE (default 500) and stops on the first error
+func DefaultBatchOptions() BatchOptions {
+	return BatchOptions{
+		BatchSize:       utils.GetEnvAsInt("DB_BATCH_SIZE", 500),
+		ContinueOnError: false,
+	}
+}
+
+// BatchFailure is an input item that could not be written. Reason is sent to callers, so the
+// use cases replace the repositories' reasons, which may quote the database, with safe ones.
+type BatchFailure struct {
+	Index  int    `json:"index"`  // Position of the item in the input slice
+	Reason string `json:"reason"` // Why the item was not written
+	Err    error  `json:"-"`      // Error returned for the item, if any
+}
+
+// NewBatchFailure reports the item at index as failed with err
+func NewBatchFailure(index int, err error) BatchFailure {
+	return BatchFailure{Index: index, Reason: err.Error(), Err: err}
+}
+
+// BatchFailuresToProto converts failed items to their proto messages
+func BatchFailuresToProto(failures []BatchFailure) []*corePb.BatchFailure {
+	out := make([]*corePb.BatchFailure, 0, len(failures))
+	for _, f := range failures {
+		out = append(out, &corePb.BatchFailure{Index: int32(f.Index), Reason: f.Reason})
+	}
+	return out
+}
+
+// BatchReport is the outcome of a chunked bulk write
+type BatchReport[E entity.Entity] struct {
+	Succeeded []*E           `json:"succeeded"` // Written items, with DB-generated fields populated
+	Failed    []BatchFailure `json:"failed"`    // Items that were not written, in input order
+}
+
+// HasFailures reports whether any item failed
+func (r *BatchReport[E]) HasFailures() bool {
+	return r != nil && len(r.Failed) > 0
+}
+
+// BulkResult is the outcome of a bulk create, update or delete. Items are written independently,
+// so a failed item is reported by its position in the input instead of failing the whole call.
+type BulkResult struct {
+	Total     int            `json:"total"`     // Number of items in the input
+	Succeeded []uuid.UUID    `json:"succeeded"` // IDs of the written items, in input order
+	Failed    []BatchFailure `json:"failed"`    // Items that were not written, in input order
+}
+
+// NewBulkResult creates an empty result for total input items
+func NewBulkResult(total int) *BulkResult {
+	return &BulkResult{Total: total, Succeeded: make([]uuid.UUID, 0, total)}
+}
+
+// BulkResultFromReport converts the report of a chunked bulk create of total items
+func BulkResultFromReport[E entity.Entity](report *BatchReport[E], total int) *BulkResult {
+	result := NewBulkResult(total)
+	for _, e := range report.Succeeded {
+		result.Succeeded = append(result.Succeeded, (*e).GetID())
+	}
+	result.Failed = append(result.Failed, report.Failed...)
+	return result
+}
+
+// Succeed records a written item
+func (r *BulkResult) Succeed(id uuid.UUID) {
+	r.Succeeded = append(r.Succeeded, id)
+}
+
+// Fail records an item that was not written
+func (r *BulkResult) Fail(index int, reason string) {
+	r.Failed = append(r.Failed, BatchFailure{Index: index, Reason: reason})
+}
+
+// FailWith records an item that was not written because of err
+func (r *BulkResult) FailWith(index int, err error) {
+	r.Failed = append(r.Failed, NewBatchFailure(index, err))
+}
+
+// SucceededCount returns the number of written items
+func (r *BulkResult) SucceededCount() int {
+	return len(r.Succeeded)
+}
+
+// FailedCount returns the number of items that were not written
+func (r *BulkResult) FailedCount() int {
+	return len(r.Failed)
+}
+
+// HasFailures reports whether any item failed
+func (r *BulkResult) HasFailures() bool {
+	return r != nil && len(r.Failed) > 0
+}
+
+// Merge adds the result of writing a subset of the input, where item i of the subset is item
+// indices[i] of the input, e.g. the items left after dropping those that failed validation
+func (r *BulkResult) Merge(sub *BulkResult, indices []int) {
+	r.Succeeded = append(r.Succeeded, sub.Succeeded...)
+	for _, f := range sub.Failed {
+		f.Index = indices[f.Index]
+		r.Failed = append(r.Failed, f)
+	}
+	sort.SliceStable(r.Failed, func(i, j int) bool { return r.Failed[i].Index < r.Failed[j].Index })
+}
+
+// SucceededItems returns the input items that did not fail, in input order
+func SucceededItems[T any](r *BulkResult, items []T) []T {
+	failed := make(map[int]bool, len(r.Failed))
+	for _, f := range r.Failed {
+		failed[f.Index] = true
+	}
+	out := make([]T, 0, max(len(items)-len(failed), 0))
+	for i, item := range items {
+		if !failed[i] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// BulkResultToProto converts a bulk result to its proto message
+func BulkResultToProto(r *BulkResult) *corePb.BulkResult {
+	ids := make([]string, 0, len(r.Succeeded))
+	for _, id := range r.Succeeded {
+		ids = append(ids, id.String())
+	}
+	return &corePb.BulkResult{
+		Total:          int32(r.Total),
+		SucceededCount: int32(r.SucceededCount()),
+		FailedCount:    int32(r.FailedCount()),
+		SucceededIds:   ids,
+		Failed:         BatchFailuresToProto(r.Failed),
+	}
+}
@@ -11,6 +11,7 @@ type FilterOptions struct {
 	SortBy         string                 `json:"sort_by"`         // Field to sort by
 	SortDesc       bool                   `json:"sort_desc"`       // True for descending order
 	Filters        map[string]interface{} `json:"filters"`         // Key-value pairs for filtering
+	Conditions     []FilterCondition      `json:"conditions"`      // Operator conditions, combined with AND
 	IncludeDeleted bool                   `json:"include_deleted"` // Whether to include soft-deleted records
 }
 
@@ -0,0 +1,215 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the ISO 8601 calendar date format used by Date
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without a time or time zone, e.g. a birthday or a billing day.
+// The zero value is treated as "no date" when stored or encoded.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date t falls on in t's location
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses a YYYY-MM-DD string
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	return DateOf(t), nil
+}
+
+// String formats the date as YYYY-MM-DD
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether the date is unset
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the start of the date in loc
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Before reports whether d is before o
+func (d Date) Before(o Date) bool {
+	return d.In(time.UTC).Before(o.In(time.UTC))
+}
+
+// AddDays returns the date n days later (or earlier for negative n)
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// MarshalJSON encodes the date as "YYYY-MM-DD", or null when unset
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a "YYYY-MM-DD" string
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value stores the date in a DATE column
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// Scan reads a DATE column
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.UnmarshalJSON([]byte(v))
+	case []byte:
+		return d.UnmarshalJSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Date", value)
+	}
+}
+
+// GormDataType tells GORM migrations to use a DATE column
+func (Date) GormDataType() string {
+	return "date"
+}
+
+// ZonedTime is an instant together with the IANA time zone it was expressed in, e.g. a meeting scheduled
+// for 09:00 Asia/Ho_Chi_Minh. Unlike time.Time, the zone name survives JSON and protobuf round trips.
+// Its text form is RFC 3339 followed by the zone in brackets: 2026-10-15T09:00:00+07:00[Asia/Ho_Chi_Minh].
+type ZonedTime struct {
+	time.Time
+}
+
+// NewZonedTime returns t in the named IANA zone
+func NewZonedTime(t time.Time, zone string) (ZonedTime, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return ZonedTime{}, fmt.Errorf("invalid time zone %q: %w", zone, err)
+	}
+	return ZonedTime{Time: t.In(loc)}, nil
+}
+
+// ParseZonedTime parses the text form produced by String. A plain RFC 3339 timestamp is accepted and keeps its fixed offset.
+func ParseZonedTime(s string) (ZonedTime, error) {
+	ts, zone, hasZone := strings.Cut(s, "[")
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ZonedTime{}, fmt.Errorf("invalid zoned time %q: %w", s, err)
+	}
+	if !hasZone {
+		return ZonedTime{Time: t}, nil
+	}
+	return NewZonedTime(t, strings.TrimSuffix(zone, "]"))
+}
+
+// ZoneName returns the IANA zone name, e.g. "Asia/Ho_Chi_Minh"
+func (z ZonedTime) ZoneName() string {
+	return z.Location().String()
+}
+
+// String returns the RFC 3339 timestamp followed by the zone name
+func (z ZonedTime) String() string {
+	if z.IsZero() {
+		return ""
+	}
+	s := z.Format(time.RFC3339Nano)
+	// Fixed offsets (from a plain RFC 3339 timestamp) have no IANA name to append
+	if zone := z.ZoneName(); zone != "" && zone != "Local" && !strings.HasPrefix(zone, "+") && !strings.HasPrefix(zone, "-") {
+		s += "[" + zone + "]"
+	}
+	return s
+}
+
+// MarshalJSON encodes the zoned time as its text form, or null when unset
+func (z ZonedTime) MarshalJSON() ([]byte, error) {
+	if z.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(z.String())
+}
+
+// UnmarshalJSON decodes the text form
+func (z *ZonedTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*z = ZonedTime{}
+		return nil
+	}
+	parsed, err := ParseZonedTime(s)
+	if err != nil {
+		return err
+	}
+	*z = parsed
+	return nil
+}
+
+// Value stores the zoned time as text so the zone name is preserved
+func (z ZonedTime) Value() (driver.Value, error) {
+	if z.IsZero() {
+		return nil, nil
+	}
+	return z.String(), nil
+}
+
+// Scan reads a column written by Value
+func (z *ZonedTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*z = ZonedTime{}
+		return nil
+	case time.Time:
+		*z = ZonedTime{Time: v}
+		return nil
+	case string:
+		return z.UnmarshalJSON([]byte(v))
+	case []byte:
+		return z.UnmarshalJSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ZonedTime", value)
+	}
+}
+
+// GormDataType tells GORM migrations to use a text column
+func (ZonedTime) GormDataType() string {
+	return "text"
+}
@@ -0,0 +1,204 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimal is an arbitrary-precision decimal number for money and other values that must not be
+// rounded through float64. It is stored as an unscaled integer and a number of fractional digits,
+// so "10.50" keeps its scale of 2. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(1050, 2) is 10.50
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))}
+	}
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// ParseDecimal parses a plain decimal string such as "-12.340"
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok || strings.ContainsAny(fracPart, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(len(fracPart))}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input; intended for constants and tests
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromFloat converts f using the shortest representation that round-trips
+func DecimalFromFloat(f float64) (Decimal, error) {
+	return ParseDecimal(big.NewFloat(f).Text('f', -1))
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Scale returns the number of fractional digits
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// String formats the decimal with its scale, e.g. "10.50"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.int().Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	split := len(digits) - int(d.scale)
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// Rat returns the exact rational value
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(d.scale))
+}
+
+// Float64 returns the nearest float64; use only for display or approximate math
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Rescale returns d with the given number of fractional digits, rounding half away from zero
+func (d Decimal) Rescale(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{unscaled: new(big.Int).Mul(d.int(), pow10(scale-d.scale)), scale: scale}
+	}
+	divisor := pow10(d.scale - scale)
+	q, r := new(big.Int).QuoRem(d.int(), divisor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(divisor) >= 0 {
+		q.Add(q, big.NewInt(int64(d.int().Sign())))
+	}
+	return Decimal{unscaled: q, scale: scale}
+}
+
+// Add returns d + o with the larger of the two scales
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{unscaled: new(big.Int).Add(a.int(), b.int()), scale: a.scale}
+}
+
+// Sub returns d - o with the larger of the two scales
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{unscaled: new(big.Int).Sub(a.int(), b.int()), scale: a.scale}
+}
+
+// Mul returns d * o with the sum of the scales
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Cmp compares d and o numerically, ignoring scale
+func (d Decimal) Cmp(o Decimal) int {
+	a, b := align(d, o)
+	return a.int().Cmp(b.int())
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// MarshalJSON encodes the decimal as a JSON string to avoid float precision loss in clients
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts both JSON strings and numbers
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value stores the decimal as a string, which PostgreSQL converts into NUMERIC losslessly
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan reads a NUMERIC column
+func (d *Decimal) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case string:
+		return d.UnmarshalJSON([]byte(v))
+	case []byte:
+		return d.UnmarshalJSON(v)
+	case int64:
+		*d = NewDecimal(v, 0)
+		return nil
+	case float64:
+		parsed, err := DecimalFromFloat(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", value)
+	}
+}
+
+// GormDataType tells GORM migrations to use a NUMERIC column
+func (Decimal) GormDataType() string {
+	return "numeric"
+}
+
+func align(a, b Decimal) (Decimal, Decimal) {
+	if a.scale < b.scale {
+		return a.Rescale(b.scale), b
+	}
+	if b.scale < a.scale {
+		return a, b.Rescale(a.scale)
+	}
+	return a, b
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,158 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// FilterOptionsBuilder builds FilterOptions step by step:
+//
+//	opts, err := types.NewFilterOptionsBuilder().
+//		WithLimit(20).
+//		WithSort("username", false).
+//		WithFilter("role", "officer").
+//		WithSearch(term, "username", "email").
+//		Build()
+//
+// Every With method returns a new builder and leaves the receiver unchanged, so a builder can be
+// shared between goroutines and used as a template for several queries. The first invalid argument
+// is reported by Build.
+type FilterOptionsBuilder struct {
+	opts FilterOptions
+	err  error
+}
+
+// NewFilterOptionsBuilder starts from DefaultFilterOptions
+func NewFilterOptionsBuilder() FilterOptionsBuilder {
+	return FilterOptionsBuilder{opts: DefaultFilterOptions()}
+}
+
+// FilterOptionsBuilderFrom starts from a copy of opts, e.g. the options of a request
+func FilterOptionsBuilderFrom(opts FilterOptions) FilterOptionsBuilder {
+	return FilterOptionsBuilder{opts: opts.Clone()}
+}
+
+// WithLimit sets the page size
+func (b FilterOptionsBuilder) WithLimit(limit int) FilterOptionsBuilder {
+	b.opts = b.opts.Clone()
+	b.opts.Limit = limit
+	return b
+}
+
+// WithOffset sets the number of items to skip
+func (b FilterOptionsBuilder) WithOffset(offset int) FilterOptionsBuilder {
+	b.opts = b.opts.Clone()
+	b.opts.Offset = offset
+	return b
+}
+
+// WithSort sets the sort field and direction; an empty field leaves the results unsorted
+func (b FilterOptionsBuilder) WithSort(field string, desc bool) FilterOptionsBuilder {
+	b.opts = b.opts.Clone()
+	b.opts.SortBy, b.opts.SortDesc = field, desc
+	return b
+}
+
+// WithDefaultSort sets the sort field only when none is set yet, keeping the direction, e.g. to page
+// through results in a stable order
+func (b FilterOptionsBuilder) WithDefaultSort(field string) FilterOptionsBuilder {
+	if b.opts.SortBy != "" {
+		return b
+	}
+	return b.WithSort(field, b.opts.SortDesc)
+}
+
+// WithFilter adds an equality filter on a field, replacing an earlier one on the same field
+func (b FilterOptionsBuilder) WithFilter(field string, value interface{}) FilterOptionsBuilder {
+	if field == "" {
+		return b.fail(errors.New("filter field must not be empty"))
+	}
+	b.opts = b.opts.Clone()
+	b.opts.Filters[field] = value
+	return b
+}
+
+// WithFilters adds several equality filters
+func (b FilterOptionsBuilder) WithFilters(filters map[string]interface{}) FilterOptionsBuilder {
+	for field, value := range filters {
+		b = b.WithFilter(field, value)
+	}
+	return b
+}
+
+// WithConditions adds operator conditions, combined with AND
+func (b FilterOptionsBuilder) WithConditions(conditions ...FilterCondition) FilterOptionsBuilder {
+	for _, c := range conditions {
+		if c.Any == nil && (c.Field == "" || c.Operator == "") {
+			return b.fail(fmt.Errorf("condition %+v needs a field and an operator", c))
+		}
+	}
+	b.opts = b.opts.Clone()
+	b.opts.Conditions = append(b.opts.Conditions, conditions...)
+	return b
+}
+
+// WithSearch matches entities whose columns contain term, ignoring case; any one column matching is
+// enough. A blank term adds nothing.
+func (b FilterOptionsBuilder) WithSearch(term string, columns ...string) FilterOptionsBuilder {
+	if term = strings.TrimSpace(term); term == "" {
+		return b
+	}
+	if len(columns) == 0 {
+		return b.fail(errors.New("search needs at least one column"))
+	}
+	matches := make([]FilterCondition, 0, len(columns))
+	for _, column := range columns {
+		matches = append(matches, NewCondition(column, OpContains, term))
+	}
+	return b.WithConditions(AnyOf(matches...))
+}
+
+// WithIncludeDeleted sets whether soft-deleted entities are included
+func (b FilterOptionsBuilder) WithIncludeDeleted(include bool) FilterOptionsBuilder {
+	b.opts = b.opts.Clone()
+	b.opts.IncludeDeleted = include
+	return b
+}
+
+// Build returns the options, checked against CurrentPaginationLimits. Errors wrap ErrValidation.
+func (b FilterOptionsBuilder) Build() (FilterOptions, error) {
+	return b.BuildWithLimits(CurrentPaginationLimits())
+}
+
+// BuildUnlimited checks the options without pagination limits, for queries a service runs on its
+// own behalf, such as exports that page through a whole table
+func (b FilterOptionsBuilder) BuildUnlimited() (FilterOptions, error) {
+	return b.BuildWithLimits(PaginationLimits{})
+}
+
+// BuildWithLimits is Build with explicit pagination limits
+func (b FilterOptionsBuilder) BuildWithLimits(limits PaginationLimits) (FilterOptions, error) {
+	opts := b.opts.Clone()
+	if b.err != nil {
+		return opts, fmt.Errorf("%w: %v", ErrValidation, b.err)
+	}
+	return opts, opts.Validate(limits)
+}
+
+// fail records the first error
+func (b FilterOptionsBuilder) fail(err error) FilterOptionsBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Clone returns a copy of o that shares no maps or slices with it; Filters is never nil
+func (o FilterOptions) Clone() FilterOptions {
+	if o.Filters == nil {
+		o.Filters = make(map[string]interface{})
+	} else {
+		o.Filters = maps.Clone(o.Filters)
+	}
+	o.Conditions = slices.Clone(o.Conditions)
+	return o
+}
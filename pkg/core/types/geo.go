@@ -0,0 +1,220 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GeoSRID is the spatial reference of stored points: WGS 84 longitude/latitude, as used by GPS
+const GeoSRID = 4326
+
+// GeoPoint is a WGS 84 location. It is stored in a PostGIS geography(Point,4326) column, which
+// measures distances in meters on the spheroid. Use *GeoPoint for an optional location.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Validate checks that the coordinates are within range
+func (p GeoPoint) Validate() error {
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("%w: latitude %v is out of range [-90, 90]", ErrValidation, p.Lat)
+	}
+	if math.IsNaN(p.Lng) || p.Lng < -180 || p.Lng > 180 {
+		return fmt.Errorf("%w: longitude %v is out of range [-180, 180]", ErrValidation, p.Lng)
+	}
+	return nil
+}
+
+// String formats the point as EWKT, e.g. "SRID=4326;POINT(106.7 10.8)" (longitude first)
+func (p GeoPoint) String() string {
+	return fmt.Sprintf("SRID=%d;POINT(%v %v)", GeoSRID, p.Lng, p.Lat)
+}
+
+// Value stores the point as EWKT, which PostGIS parses into the column type
+func (p GeoPoint) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan reads a PostGIS point (hex EWKB, as PostGIS returns it) or a (E)WKT string
+func (p *GeoPoint) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*p = GeoPoint{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into GeoPoint", value)
+	}
+	if strings.Contains(strings.ToUpper(s), "POINT") {
+		return p.parseWKT(s)
+	}
+	return p.parseEWKB(s)
+}
+
+// parseWKT reads "POINT(lng lat)" with an optional "SRID=n;" prefix
+func (p *GeoPoint) parseWKT(s string) error {
+	if _, rest, ok := strings.Cut(s, ";"); ok {
+		s = rest
+	}
+	wkt := strings.Replace(strings.ToUpper(strings.TrimSpace(s)), "POINT (", "POINT(", 1)
+	var lng, lat float64
+	if _, err := fmt.Sscanf(wkt, "POINT(%g %g)", &lng, &lat); err != nil {
+		return fmt.Errorf("invalid point %q: %w", s, err)
+	}
+	*p = GeoPoint{Lat: lat, Lng: lng}
+	return nil
+}
+
+// EWKB flags of the geometry type word
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// parseEWKB reads a hex-encoded (E)WKB point; Z and M coordinates are ignored
+func (p *GeoPoint) parseEWKB(s string) error {
+	data, err := hex.DecodeString(s)
+	if err != nil || len(data) < 5 {
+		return fmt.Errorf("invalid point %q", s)
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if data[0] == 1 {
+		order = binary.LittleEndian
+	}
+	geomType := order.Uint32(data[1:5])
+	offset := 5
+	if geomType&ewkbSRID != 0 {
+		offset += 4
+	}
+	if geomType&^(ewkbZ|ewkbM|ewkbSRID) != 1 {
+		return fmt.Errorf("geometry type %d is not a point", geomType&^(ewkbZ|ewkbM|ewkbSRID))
+	}
+	if len(data) < offset+16 {
+		return fmt.Errorf("truncated point %q", s)
+	}
+	*p = GeoPoint{
+		Lng: math.Float64frombits(order.Uint64(data[offset:])),
+		Lat: math.Float64frombits(order.Uint64(data[offset+8:])),
+	}
+	return nil
+}
+
+// GormDataType tells GORM migrations to use a PostGIS geography column
+func (GeoPoint) GormDataType() string {
+	return "geography(Point,4326)"
+}
+
+// GeoRadius is the value of an OpWithinRadius condition: points at most RadiusMeters from Center
+type GeoRadius struct {
+	Center       GeoPoint `json:"center"`
+	RadiusMeters float64  `json:"radius_meters"`
+}
+
+// Validate checks the center and that the radius is positive
+func (r GeoRadius) Validate() error {
+	if err := r.Center.Validate(); err != nil {
+		return err
+	}
+	if !(r.RadiusMeters > 0) || math.IsInf(r.RadiusMeters, 0) {
+		return fmt.Errorf("%w: radius must be a positive number of meters", ErrValidation)
+	}
+	return nil
+}
+
+// GeoBoundingBox is the value of an OpWithinBox condition: points between the south-west and
+// north-east corners. Boxes crossing the antimeridian are not supported.
+type GeoBoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// Validate checks the corners and their order
+func (b GeoBoundingBox) Validate() error {
+	if err := (GeoPoint{Lat: b.MinLat, Lng: b.MinLng}).Validate(); err != nil {
+		return err
+	}
+	if err := (GeoPoint{Lat: b.MaxLat, Lng: b.MaxLng}).Validate(); err != nil {
+		return err
+	}
+	if b.MinLat > b.MaxLat || b.MinLng > b.MaxLng {
+		return fmt.Errorf("%w: bounding box minimum is above its maximum", ErrValidation)
+	}
+	return nil
+}
+
+// WithinRadius creates a condition matching points of field within meters of center
+func WithinRadius(field string, center GeoPoint, meters float64) FilterCondition {
+	return FilterCondition{Field: field, Operator: OpWithinRadius, Value: GeoRadius{Center: center, RadiusMeters: meters}}
+}
+
+// WithinBox creates a condition matching points of field inside box
+func WithinBox(field string, box GeoBoundingBox) FilterCondition {
+	return FilterCondition{Field: field, Operator: OpWithinBox, Value: box}
+}
+
+// GeoRadiusOf reads the value of an OpWithinRadius condition: a GeoRadius, or the equivalent map
+// decoded from JSON or a proto Value ({"center": {"lat": .., "lng": ..}, "radius_meters": ..})
+func GeoRadiusOf(value interface{}) (GeoRadius, error) {
+	var r GeoRadius
+	if err := decodeGeoValue(value, &r); err != nil {
+		return r, err
+	}
+	return r, r.Validate()
+}
+
+// GeoBoundingBoxOf reads the value of an OpWithinBox condition: a GeoBoundingBox, or the
+// equivalent map ({"min_lat": .., "min_lng": .., "max_lat": .., "max_lng": ..})
+func GeoBoundingBoxOf(value interface{}) (GeoBoundingBox, error) {
+	var b GeoBoundingBox
+	if err := decodeGeoValue(value, &b); err != nil {
+		return b, err
+	}
+	return b, b.Validate()
+}
+
+// decodeGeoValue converts a condition value (a geo struct or a map) into target through its JSON
+// form. Unknown keys are rejected, so a radius is not mistaken for a box or the other way round.
+func decodeGeoValue(value interface{}, target interface{}) error {
+	if value == nil {
+		return fmt.Errorf("%w: geo filter requires an object value", ErrValidation)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid geo filter value: %v", ErrValidation, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("%w: invalid geo filter value: %v", ErrValidation, err)
+	}
+	return nil
+}
+
+// geoValueMap returns the map form of geo condition values, which structpb can encode
+func geoValueMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case GeoRadius:
+		return map[string]interface{}{
+			"center":        map[string]interface{}{"lat": v.Center.Lat, "lng": v.Center.Lng},
+			"radius_meters": v.RadiusMeters,
+		}, true
+	case GeoBoundingBox:
+		return map[string]interface{}{"min_lat": v.MinLat, "min_lng": v.MinLng, "max_lat": v.MaxLat, "max_lng": v.MaxLng}, true
+	default:
+		return nil, false
+	}
+}
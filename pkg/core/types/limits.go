@@ -0,0 +1,56 @@
+package types
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// PaginationLimits bounds the pages a client may request, so a single call cannot dump a whole table
+type PaginationLimits struct {
+	MaxLimit  int // Largest page size
+	MaxOffset int // Deepest offset; clients paging further should narrow their filters or use cursors
+}
+
+// LoadPaginationLimitsFromEnv reads PAGINATION_MAX_LIMIT (default 500) and PAGINATION_MAX_OFFSET (default 10000)
+func LoadPaginationLimitsFromEnv() PaginationLimits {
+	return PaginationLimits{
+		MaxLimit:  utils.GetEnvAsInt("PAGINATION_MAX_LIMIT", 500),
+		MaxOffset: utils.GetEnvAsInt("PAGINATION_MAX_OFFSET", 10000),
+	}
+}
+
+var paginationLimits atomic.Pointer[PaginationLimits]
+
+// SetPaginationLimits overrides the limits enforced by FilterOptionsFromProto for this service
+func SetPaginationLimits(limits PaginationLimits) {
+	paginationLimits.Store(&limits)
+}
+
+// CurrentPaginationLimits returns the limits set with SetPaginationLimits, or the env defaults
+func CurrentPaginationLimits() PaginationLimits {
+	if limits := paginationLimits.Load(); limits != nil {
+		return *limits
+	}
+	limits := LoadPaginationLimitsFromEnv()
+	paginationLimits.CompareAndSwap(nil, &limits)
+	return limits
+}
+
+// Validate checks the page requested by opts against limits; errors wrap ErrValidation
+func (o FilterOptions) Validate(limits PaginationLimits) error {
+	if o.Limit < 1 {
+		return fmt.Errorf("%w: limit must be at least 1", ErrValidation)
+	}
+	if limits.MaxLimit > 0 && o.Limit > limits.MaxLimit {
+		return fmt.Errorf("%w: limit %d exceeds the maximum of %d", ErrValidation, o.Limit, limits.MaxLimit)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrValidation)
+	}
+	if limits.MaxOffset > 0 && o.Offset > limits.MaxOffset {
+		return fmt.Errorf("%w: offset %d exceeds the maximum of %d", ErrValidation, o.Offset, limits.MaxOffset)
+	}
+	return nil
+}
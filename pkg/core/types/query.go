@@ -0,0 +1,257 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	corePb "golang-microservices-boilerplate/proto/core"
+)
+
+// SortDirection is the order of a sorted list
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortDirectionFromProto converts a proto sort direction; unspecified returns ""
+func SortDirectionFromProto(d corePb.SortDirection) SortDirection {
+	switch d {
+	case corePb.SortDirection_SORT_DIRECTION_ASC:
+		return SortAsc
+	case corePb.SortDirection_SORT_DIRECTION_DESC:
+		return SortDesc
+	default:
+		return ""
+	}
+}
+
+// ToProto converts the sort direction to its proto enum
+func (d SortDirection) ToProto() corePb.SortDirection {
+	switch d {
+	case SortAsc:
+		return corePb.SortDirection_SORT_DIRECTION_ASC
+	case SortDesc:
+		return corePb.SortDirection_SORT_DIRECTION_DESC
+	default:
+		return corePb.SortDirection_SORT_DIRECTION_UNSPECIFIED
+	}
+}
+
+// FilterOperator is the comparison applied by a FilterCondition
+type FilterOperator string
+
+const (
+	OpEq         FilterOperator = "eq"
+	OpNe         FilterOperator = "ne"
+	OpGt         FilterOperator = "gt"
+	OpGte        FilterOperator = "gte"
+	OpLt         FilterOperator = "lt"
+	OpLte        FilterOperator = "lte"
+	OpIn         FilterOperator = "in"
+	OpNotIn      FilterOperator = "not_in"
+	OpContains   FilterOperator = "contains"
+	OpStartsWith FilterOperator = "starts_with"
+	OpIsNull     FilterOperator = "is_null"
+
+	// Geospatial operators on GeoPoint fields; see GeoRadius and GeoBoundingBox for the values
+	OpWithinRadius FilterOperator = "within_radius"
+	OpWithinBox    FilterOperator = "within_box"
+)
+
+// filterOperatorsByProto maps proto operators to FilterOperator; unspecified means equality
+var filterOperatorsByProto = map[corePb.FilterOperator]FilterOperator{
+	corePb.FilterOperator_FILTER_OPERATOR_UNSPECIFIED:   OpEq,
+	corePb.FilterOperator_FILTER_OPERATOR_EQ:            OpEq,
+	corePb.FilterOperator_FILTER_OPERATOR_NE:            OpNe,
+	corePb.FilterOperator_FILTER_OPERATOR_GT:            OpGt,
+	corePb.FilterOperator_FILTER_OPERATOR_GTE:           OpGte,
+	corePb.FilterOperator_FILTER_OPERATOR_LT:            OpLt,
+	corePb.FilterOperator_FILTER_OPERATOR_LTE:           OpLte,
+	corePb.FilterOperator_FILTER_OPERATOR_IN:            OpIn,
+	corePb.FilterOperator_FILTER_OPERATOR_NOT_IN:        OpNotIn,
+	corePb.FilterOperator_FILTER_OPERATOR_CONTAINS:      OpContains,
+	corePb.FilterOperator_FILTER_OPERATOR_STARTS_WITH:   OpStartsWith,
+	corePb.FilterOperator_FILTER_OPERATOR_IS_NULL:       OpIsNull,
+	corePb.FilterOperator_FILTER_OPERATOR_WITHIN_RADIUS: OpWithinRadius,
+	corePb.FilterOperator_FILTER_OPERATOR_WITHIN_BOX:    OpWithinBox,
+}
+
+// FilterOperatorFromProto converts a proto filter operator
+func FilterOperatorFromProto(op corePb.FilterOperator) (FilterOperator, error) {
+	if o, ok := filterOperatorsByProto[op]; ok {
+		return o, nil
+	}
+	return "", fmt.Errorf("unknown filter operator %v", op)
+}
+
+// ToProto converts the operator to its proto enum
+func (o FilterOperator) ToProto() corePb.FilterOperator {
+	for p, op := range filterOperatorsByProto {
+		if op == o && p != corePb.FilterOperator_FILTER_OPERATOR_UNSPECIFIED {
+			return p
+		}
+	}
+	return corePb.FilterOperator_FILTER_OPERATOR_UNSPECIFIED
+}
+
+// FilterCondition compares a field with a value, e.g. {Field: "age", Operator: OpGte, Value: 18}.
+// A condition with Any set is a group instead, matching when any of its conditions matches.
+type FilterCondition struct {
+	Field    string            `json:"field,omitempty"`
+	Operator FilterOperator    `json:"operator,omitempty"`
+	Value    interface{}       `json:"value,omitempty"`
+	Any      []FilterCondition `json:"any,omitempty"`
+}
+
+// NewCondition creates a filter condition
+func NewCondition(field string, op FilterOperator, value interface{}) FilterCondition {
+	return FilterCondition{Field: field, Operator: op, Value: value}
+}
+
+// AnyOf creates a condition group that matches when any of the conditions matches, e.g. a
+// search over several columns
+func AnyOf(conditions ...FilterCondition) FilterCondition {
+	return FilterCondition{Any: conditions}
+}
+
+// FilterConditionFromProto converts a proto filter condition
+func FilterConditionFromProto(c *corePb.FilterCondition) (FilterCondition, error) {
+	op, err := FilterOperatorFromProto(c.GetOperator())
+	if err != nil {
+		return FilterCondition{}, err
+	}
+	var value interface{}
+	if c.GetValue() != nil {
+		value = c.GetValue().AsInterface()
+	}
+	return FilterCondition{Field: c.GetField(), Operator: op, Value: value}, nil
+}
+
+// ToProto converts the condition to its proto message
+func (c FilterCondition) ToProto() (*corePb.FilterCondition, error) {
+	if c.Any != nil {
+		return nil, fmt.Errorf("condition groups have no proto form")
+	}
+	raw := c.Value
+	if m, ok := geoValueMap(raw); ok {
+		raw = m
+	}
+	value, err := structpb.NewValue(raw)
+	if err != nil {
+		return nil, fmt.Errorf("condition on %s: %w", c.Field, err)
+	}
+	return &corePb.FilterCondition{Field: c.Field, Operator: c.Operator.ToProto(), Value: value}, nil
+}
+
+// FilterOptionsFromProto converts proto filter options, starting from DefaultFilterOptions
+// for anything the request leaves unset. The page is checked against CurrentPaginationLimits.
+func FilterOptionsFromProto(p *corePb.FilterOptions) (FilterOptions, error) {
+	b := NewFilterOptionsBuilder()
+	if p == nil {
+		return b.Build()
+	}
+
+	if p.Limit != nil {
+		b = b.WithLimit(int(*p.Limit))
+	}
+	if p.Offset != nil {
+		b = b.WithOffset(int(*p.Offset))
+	}
+	sortBy, sortDesc := b.opts.SortBy, b.opts.SortDesc
+	if p.SortBy != nil {
+		sortBy = *p.SortBy
+	}
+	if p.SortDesc != nil {
+		sortDesc = *p.SortDesc
+	}
+	if dir := SortDirectionFromProto(p.GetSortDirection()); dir != "" {
+		sortDesc = dir == SortDesc
+	}
+	b = b.WithSort(sortBy, sortDesc)
+	if p.IncludeDeleted != nil {
+		b = b.WithIncludeDeleted(*p.IncludeDeleted)
+	}
+	for k, v := range p.GetFilters() {
+		b = b.WithFilter(k, v.AsInterface())
+	}
+	for _, c := range p.GetConditions() {
+		condition, err := FilterConditionFromProto(c)
+		if err != nil {
+			return b.opts.Clone(), err
+		}
+		b = b.WithConditions(condition)
+	}
+	return b.Build()
+}
+
+// PaginationInfoToProto returns the proto pagination metadata of a result page
+func PaginationInfoToProto[E entity.Entity](result *PaginationResult[E]) *corePb.PaginationInfo {
+	if result == nil {
+		return &corePb.PaginationInfo{}
+	}
+	return &corePb.PaginationInfo{
+		TotalItems: result.TotalItems,
+		Limit:      int32(result.Limit),
+		Offset:     int32(result.Offset),
+	}
+}
+
+// Cursor identifies the last item of a page for keyset pagination: the value of the sort
+// column and the ID that breaks ties between equal sort values
+type Cursor struct {
+	SortValue interface{} `json:"s,omitempty"`
+	ID        string      `json:"id"`
+}
+
+// Encode returns the cursor as an opaque page token
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a page token; an empty token returns a nil cursor (first page)
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page token", ErrValidation)
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("%w: invalid page token", ErrValidation)
+	}
+	return &c, nil
+}
+
+// CursorPageInfoToProto builds cursor pagination metadata; next is nil on the last page
+func CursorPageInfoToProto(pageSize int, next *Cursor) *corePb.CursorPageInfo {
+	info := &corePb.CursorPageInfo{PageSize: int32(pageSize)}
+	if next != nil {
+		info.NextPageToken = next.Encode()
+		info.HasMore = true
+	}
+	return info
+}
+
+// NewFieldViolation creates a proto field violation
+func NewFieldViolation(field, reason, description string) *corePb.FieldViolation {
+	return &corePb.FieldViolation{Field: field, Reason: reason, Description: description}
+}
+
+// NewErrorDetail creates the standard proto error body
+func NewErrorDetail(code int, reason, message string, violations ...*corePb.FieldViolation) *corePb.ErrorDetail {
+	return &corePb.ErrorDetail{
+		Code:       int32(code),
+		Reason:     reason,
+		Message:    message,
+		Violations: violations,
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Actor is the authenticated caller of a use case, populated from the access token by the gRPC server
+type Actor struct {
+	ID       string
+	Email    string
+	Role     string
+	TenantID string // Organization the token is bound to; empty when it is not
+	OrgRole  string // Role within TenantID
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a context carrying the actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor of the request, if it was authenticated
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(Actor)
+	return actor, ok
+}
+
+// HasRole reports whether the actor has one of the roles (case-insensitive)
+func (a Actor) HasRole(roles ...string) bool {
+	return slices.ContainsFunc(roles, func(r string) bool { return strings.EqualFold(r, a.Role) })
+}
+
+// HasPermission reports whether the actor's role grants the permission (see permissions.Default)
+func (a Actor) HasPermission(required permissions.Permission) bool {
+	return permissions.Has(a.Role, required)
+}
+
+// RequirePermission returns ErrForbidden unless the actor in ctx has the permission
+func RequirePermission(ctx context.Context, required permissions.Permission) error {
+	if actor, ok := ActorFromContext(ctx); ok && actor.HasPermission(required) {
+		return nil
+	}
+	return NewLocalizedError(ErrForbidden, "auth.resource_forbidden", nil)
+}
+
+// DeletedRecordsPolicy decides who may list soft-deleted records via FilterOptions.IncludeDeleted
+type DeletedRecordsPolicy struct {
+	// Roles allowed to include deleted records; requests from other actors are rejected with ErrForbidden
+	Roles []string
+}
+
+// DefaultDeletedRecordsPolicy reads the privileged roles from SOFT_DELETE_VISIBLE_ROLES (comma separated, default "admin")
+func DefaultDeletedRecordsPolicy() *DeletedRecordsPolicy {
+	var roles []string
+	for _, r := range strings.Split(utils.GetEnv("SOFT_DELETE_VISIBLE_ROLES", "admin"), ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return &DeletedRecordsPolicy{Roles: roles}
+}
+
+// Allows reports whether the actor in ctx may see deleted records
+func (p *DeletedRecordsPolicy) Allows(ctx context.Context) bool {
+	actor, ok := ActorFromContext(ctx)
+	return ok && actor.HasRole(p.Roles...)
+}
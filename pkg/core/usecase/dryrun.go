@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"golang-microservices-boilerplate/pkg/core/repository"
+)
+
+type dryRunContextKey struct{}
+
+// errDryRunRollback aborts the transaction of a dry run once the write succeeded
+var errDryRunRollback = errors.New("dry run rollback")
+
+// WithDryRun flags the request as a dry run: the write operations of BaseUseCaseImpl (Create, Update,
+// Delete and their bulk variants) run their checks and the write inside a transaction that is
+// rolled back, and after hooks are skipped
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, true)
+}
+
+// IsDryRun reports whether ctx was flagged with WithDryRun. Services overriding use case methods
+// check it before side effects such as publishing events.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey{}).(bool)
+	return dryRun
+}
+
+// write runs fn against the repository, or in a dry run against a transaction that is rolled back
+// afterwards. Entities written by fn keep the values the database returned (IDs, timestamps).
+func (uc *BaseUseCaseImpl[T]) write(ctx context.Context, fn func(repo repository.BaseRepository[T]) error) error {
+	if !IsDryRun(ctx) {
+		return fn(uc.Repository)
+	}
+	err := uc.Repository.Transaction(ctx, func(tx repository.BaseRepository[T]) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return errDryRunRollback
+	})
+	if errors.Is(err, errDryRunRollback) {
+		return nil
+	}
+	return err
+}
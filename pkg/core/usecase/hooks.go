@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// Lifecycle hooks. BaseUseCaseImpl checks its Hooks value for each interface and calls the ones it
+// implements, so a service can attach side effects without overriding the use case methods:
+//
+//	base := usecase.NewBaseUseCase(repo, logger)
+//	base.Hooks = &userHooks{mailer: mailer} // implements AfterCreateHook[entity.User]
+//
+// Before hooks run ahead of the write and abort it by returning an error. After hooks run once the
+// write succeeded; their errors are logged and do not fail the operation. Dry runs skip after hooks.
+
+// BeforeCreateHook is called before an entity is created, e.g. to fill defaults or validate it
+type BeforeCreateHook[T entity.Entity] interface {
+	BeforeCreate(ctx context.Context, entity *T) error
+}
+
+// AfterCreateHook is called after an entity was created, with its generated fields populated
+type AfterCreateHook[T entity.Entity] interface {
+	AfterCreate(ctx context.Context, entity *T) error
+}
+
+// BeforeUpdateHook is called before an entity is saved by Update or UpdateMany
+type BeforeUpdateHook[T entity.Entity] interface {
+	BeforeUpdate(ctx context.Context, entity *T) error
+}
+
+// AfterDeleteHook is called after an entity was deleted by Delete or DeleteMany
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context, id uuid.UUID, hardDelete bool) error
+}
+
+// beforeCreate runs the BeforeCreate hook on each entity, stopping at the first error
+func (uc *BaseUseCaseImpl[T]) beforeCreate(ctx context.Context, entities ...*T) error {
+	hook, ok := uc.Hooks.(BeforeCreateHook[T])
+	if !ok {
+		return nil
+	}
+	for _, entityPtr := range entities {
+		if err := hook.BeforeCreate(ctx, entityPtr); err != nil {
+			return asValidationError(err)
+		}
+	}
+	return nil
+}
+
+// afterCreate runs the AfterCreate hook on each created entity
+func (uc *BaseUseCaseImpl[T]) afterCreate(ctx context.Context, entities ...*T) {
+	hook, ok := uc.Hooks.(AfterCreateHook[T])
+	if !ok || IsDryRun(ctx) {
+		return
+	}
+	for _, entityPtr := range entities {
+		if err := hook.AfterCreate(ctx, entityPtr); err != nil {
+			uc.log(ctx).Warn("AfterCreate hook failed", "entityType", fmt.Sprintf("%T", entityPtr), "id", (*entityPtr).GetID(), "error", err)
+		}
+	}
+}
+
+// beforeUpdate runs the BeforeUpdate hook on each entity, stopping at the first error
+func (uc *BaseUseCaseImpl[T]) beforeUpdate(ctx context.Context, entities ...*T) error {
+	hook, ok := uc.Hooks.(BeforeUpdateHook[T])
+	if !ok {
+		return nil
+	}
+	for _, entityPtr := range entities {
+		if err := hook.BeforeUpdate(ctx, entityPtr); err != nil {
+			return asValidationError(err)
+		}
+	}
+	return nil
+}
+
+// afterDelete runs the AfterDelete hook for each deleted ID
+func (uc *BaseUseCaseImpl[T]) afterDelete(ctx context.Context, hardDelete bool, ids ...uuid.UUID) {
+	hook, ok := uc.Hooks.(AfterDeleteHook)
+	if !ok || IsDryRun(ctx) {
+		return
+	}
+	for _, id := range ids {
+		if err := hook.AfterDelete(ctx, id, hardDelete); err != nil {
+			uc.log(ctx).Warn("AfterDelete hook failed", "entityType", fmt.Sprintf("%T", *new(T)), "id", id, "error", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/repository"
+)
+
+// OwnershipPolicy decides whether an actor may access an entity. BaseUseCaseImpl consults it in
+// GetByID and GetByIDs (CanRead) and in Update, Delete and their bulk variants (CanWrite, against
+// the stored entity) and returns ErrForbidden when it refuses; GetByIDs leaves refused entities
+// out instead. Requests without an actor are checked with the zero Actor.
+type OwnershipPolicy[T entity.Entity] interface {
+	CanRead(actor Actor, entity *T) bool
+	CanWrite(actor Actor, entity *T) bool
+}
+
+// OwnerPolicy restricts entities to their owners. Actors with one of AdminRoles may access every
+// entity; other actors may write only entities whose Owner is their ID, and read them too unless
+// PublicRead is set.
+//
+//	base.Ownership = &usecase.OwnerPolicy[entity.User]{
+//		Owner:      func(u *entity.User) string { return u.ID.String() }, // users edit only their own profile
+//		AdminRoles: []string{"admin"},
+//		PublicRead: true,
+//	}
+type OwnerPolicy[T entity.Entity] struct {
+	Owner      func(entity *T) string // ID of the actor owning the entity
+	AdminRoles []string
+	PublicRead bool
+}
+
+// CanRead implements OwnershipPolicy
+func (p *OwnerPolicy[T]) CanRead(actor Actor, entity *T) bool {
+	return p.PublicRead || p.CanWrite(actor, entity)
+}
+
+// CanWrite implements OwnershipPolicy
+func (p *OwnerPolicy[T]) CanWrite(actor Actor, entity *T) bool {
+	if actor.HasRole(p.AdminRoles...) {
+		return true
+	}
+	return actor.ID != "" && p.Owner(entity) == actor.ID
+}
+
+// authorizeOwnership checks the actor in ctx against the Ownership policy, if one is set
+func (uc *BaseUseCaseImpl[T]) authorizeOwnership(ctx context.Context, entityPtr *T, write bool, operation string) error {
+	if uc.Ownership == nil {
+		return nil
+	}
+	actor, _ := ActorFromContext(ctx)
+	allowed := uc.Ownership.CanRead(actor, entityPtr)
+	if write {
+		allowed = uc.Ownership.CanWrite(actor, entityPtr)
+	}
+	if allowed {
+		return nil
+	}
+	uc.log(ctx).Warn("Denied access to entity", "operation", operation, "entityType", fmt.Sprintf("%T", *new(T)),
+		"id", (*entityPtr).GetID(), "actor_id", actor.ID, "actor_role", actor.Role)
+	return NewLocalizedError(ErrForbidden, "auth.resource_forbidden", nil)
+}
+
+// authorizeStoredWrite loads the stored entity and checks CanWrite on it, so an update cannot pass the
+// check by changing the owner field. It does nothing without an Ownership policy.
+func (uc *BaseUseCaseImpl[T]) authorizeStoredWrite(ctx context.Context, id uuid.UUID, operation string) error {
+	if uc.Ownership == nil {
+		return nil
+	}
+	stored, err := uc.Repository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return NewLocalizedError(ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+		}
+		uc.log(ctx).Error("Failed to load entity for ownership check", "id", id, "error", err)
+		return err // Return original repository error
+	}
+	return uc.authorizeOwnership(ctx, stored, true, operation)
+}
+
+// refusedWrites loads the stored entities with ids and checks CanWrite on each, returning the
+// error of every refused ID. IDs that match no entity are left to the repository to report. It
+// returns nil without an Ownership policy.
+func (uc *BaseUseCaseImpl[T]) refusedWrites(ctx context.Context, ids []uuid.UUID, operation string) (map[uuid.UUID]error, error) {
+	if uc.Ownership == nil || len(ids) == 0 {
+		return nil, nil
+	}
+	stored, err := uc.Repository.FindByIDs(ctx, ids)
+	if err != nil {
+		uc.log(ctx).Error("Failed to load entities for ownership check", "count", len(ids), "error", err)
+		return nil, err // Return original repository error
+	}
+	refused := make(map[uuid.UUID]error)
+	for id, entityPtr := range stored {
+		if err := uc.authorizeOwnership(ctx, entityPtr, true, operation); err != nil {
+			refused[id] = err
+		}
+	}
+	return refused, nil
+}
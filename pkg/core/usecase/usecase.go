@@ -2,11 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 
+	"golang-microservices-boilerplate/pkg/core/dto"
 	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/i18n"
 	"golang-microservices-boilerplate/pkg/core/logger"
 	"golang-microservices-boilerplate/pkg/core/repository"
 	"golang-microservices-boilerplate/pkg/core/types"
@@ -16,22 +20,34 @@ import (
 type BaseUseCase[T entity.Entity] interface {
 	Create(ctx context.Context, entity *T) error
 	GetByID(ctx context.Context, id uuid.UUID) (*T, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*T, error)
+	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
+	Exists(ctx context.Context, filter map[string]interface{}) (bool, error)
 	List(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error)
 	Update(ctx context.Context, entity *T) error
 	Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error
+	Restore(ctx context.Context, id uuid.UUID) error
 	FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error)
 	Count(ctx context.Context, filter map[string]interface{}) (int64, error)
 
 	// Bulk Operations
-	CreateMany(ctx context.Context, entities []*T) ([]*T, error)
-	UpdateMany(ctx context.Context, entities []*T) ([]*T, error)
-	DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error
+	CreateMany(ctx context.Context, entities []*T) (*types.BulkResult, error)
+	CreateInBatches(ctx context.Context, entities []*T, opts types.BatchOptions) (*types.BatchReport[T], error)
+	UpdateMany(ctx context.Context, entities []*T) (*types.BulkResult, error)
+	UpdateWhere(ctx context.Context, filter map[string]interface{}, updates map[string]interface{}) (int64, error)
+	DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) (*types.BulkResult, error)
 }
 
 // BaseUseCaseImpl implements the BaseUseCase interface for entity pointers (*T)
 type BaseUseCaseImpl[T entity.Entity] struct {
 	Repository repository.BaseRepository[T]
 	Logger     logger.Logger
+	// DeletedRecords restricts FilterOptions.IncludeDeleted to privileged roles
+	DeletedRecords *DeletedRecordsPolicy
+	// Ownership, when set, restricts reads, updates and deletes by ID to the entities the actor may access
+	Ownership OwnershipPolicy[T]
+	// Hooks receives lifecycle callbacks for the hook interfaces it implements (see BeforeCreateHook)
+	Hooks any
 }
 
 // NewBaseUseCase creates a new use case implementation for entity pointers (*T)
@@ -40,24 +56,41 @@ func NewBaseUseCase[T entity.Entity](
 	logger logger.Logger,
 ) *BaseUseCaseImpl[T] {
 	return &BaseUseCaseImpl[T]{
-		Repository: repository,
-		Logger:     logger,
+		Repository:     repository,
+		Logger:         logger,
+		DeletedRecords: DefaultDeletedRecordsPolicy(),
 	}
 }
 
+// log returns the request-scoped logger stored in ctx (see logger.FromContext), or uc.Logger
+func (uc *BaseUseCaseImpl[T]) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.Logger)
+}
+
 // Create processes a creation request using the provided entity pointer
 func (uc *BaseUseCaseImpl[T]) Create(ctx context.Context, entityPtr *T) error {
 	// Validation should now happen before calling this method, or rely on entity hooks (e.g., BeforeCreate)
 	// Mapping from external data (e.g., proto) should also happen before calling this method.
 
+	if err := uc.beforeCreate(ctx, entityPtr); err != nil {
+		return err
+	}
+
 	// Create entity in repository
-	if err := uc.Repository.Create(ctx, entityPtr); err != nil {
-		uc.Logger.Error("Failed to create entity in repository", "entityType", fmt.Sprintf("%T", entityPtr), "error", err)
+	if err := uc.write(ctx, func(repo repository.BaseRepository[T]) error { return repo.Create(ctx, entityPtr) }); err != nil {
+		if isValidationError(err) {
+			return NewValidationError(err) // Rejected by the entity's BeforeCreate hook
+		}
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return NewLocalizedError(ErrConflict, "resource.already_exists", nil)
+		}
+		uc.log(ctx).Error("Failed to create entity in repository", "entityType", fmt.Sprintf("%T", entityPtr), "error", err)
 		// Consider checking for specific DB errors (e.g., unique constraint)
 		return err // Return original repository error
 	}
 
 	// The entityPtr is modified in place by the repository (e.g., ID set)
+	uc.afterCreate(ctx, entityPtr)
 	return nil
 }
 
@@ -65,22 +98,81 @@ func (uc *BaseUseCaseImpl[T]) Create(ctx context.Context, entityPtr *T) error {
 func (uc *BaseUseCaseImpl[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
 	entityPtr, err := uc.Repository.FindByID(ctx, id)
 	if err != nil {
-		if err.Error() == "entity not found" { // Example error string check
-			return nil, NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found", id))
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, NewLocalizedError(ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
 		}
-		uc.Logger.Error("Failed to get entity by ID", "id", id, "error", err)
+		uc.log(ctx).Error("Failed to get entity by ID", "id", id, "error", err)
 		return nil, err // Return original repository error
 	}
+	if err := uc.authorizeOwnership(ctx, entityPtr, false, "GetByID"); err != nil {
+		return nil, err
+	}
 	return entityPtr, nil
 }
 
+// GetByIDs retrieves the entities with the given IDs in one query, keyed by ID; unknown IDs and
+// entities the Ownership policy refuses to the actor are absent
+func (uc *BaseUseCaseImpl[T]) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*T, error) {
+	entities, err := uc.Repository.FindByIDs(ctx, ids)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get entities by IDs", "count", len(ids), "error", err)
+		return nil, err // Return original repository error
+	}
+	for id, entityPtr := range entities {
+		if uc.authorizeOwnership(ctx, entityPtr, false, "GetByIDs") != nil {
+			delete(entities, id)
+		}
+	}
+	return entities, nil
+}
+
+// ExistsByID reports whether an entity with the ID exists without loading it
+func (uc *BaseUseCaseImpl[T]) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	exists, err := uc.Repository.ExistsByID(ctx, id)
+	if err != nil {
+		uc.log(ctx).Error("Failed to check entity existence", "id", id, "error", err)
+		return false, err // Return original repository error
+	}
+	return exists, nil
+}
+
+// Exists reports whether a non-deleted entity matches the filter
+func (uc *BaseUseCaseImpl[T]) Exists(ctx context.Context, filter map[string]interface{}) (bool, error) {
+	exists, err := uc.Repository.Exists(ctx, filter)
+	if err != nil {
+		uc.log(ctx).Error("Failed to check entity existence", "error", err)
+		return false, err // Return original repository error
+	}
+	return exists, nil
+}
+
+// RequireExists returns a NotFound error when no entity has the ID. Use it instead of GetByID
+// when the entity itself is not needed.
+func (uc *BaseUseCaseImpl[T]) RequireExists(ctx context.Context, id uuid.UUID) error {
+	exists, err := uc.ExistsByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NewLocalizedError(ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+	}
+	return nil
+}
+
 // List retrieves all entities with pagination
 func (uc *BaseUseCaseImpl[T]) List(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	if err := uc.authorizeIncludeDeleted(ctx, opts, "List"); err != nil {
+		return nil, err
+	}
 	result, err := uc.Repository.FindAll(ctx, opts)
 	if err != nil {
-		uc.Logger.Error("Failed to list entities", "error", err)
+		if errors.Is(err, types.ErrValidation) {
+			return nil, NewUseCaseError(ErrInvalidInput, err.Error())
+		}
+		uc.log(ctx).Error("Failed to list entities", "error", err)
 		return nil, err // Return original repository error
 	}
+	uc.auditDeletedAccess(ctx, opts, "List", result)
 	return result, nil
 }
 
@@ -92,23 +184,35 @@ func (uc *BaseUseCaseImpl[T]) Update(ctx context.Context, entityPtr *T) error {
 	// Ensure the entity pointer is valid and has an ID before proceeding
 	var entityID uuid.UUID
 	if entityPtr == nil {
-		uc.Logger.Warn("Update called with nil entity pointer")
+		uc.log(ctx).Warn("Update called with nil entity pointer")
 		return NewUseCaseError(ErrInvalidInput, "cannot update nil entity")
 	}
 	entityID = (*entityPtr).GetID()
 	if entityID == uuid.Nil {
-		uc.Logger.Warn("Update called with entity having nil ID")
+		uc.log(ctx).Warn("Update called with entity having nil ID")
 		return NewUseCaseError(ErrInvalidInput, "cannot update entity with nil ID")
 	}
+	if err := uc.authorizeStoredWrite(ctx, entityID, "Update"); err != nil {
+		return err
+	}
+	if err := uc.beforeUpdate(ctx, entityPtr); err != nil {
+		return err
+	}
 
 	// Save the updated entity using Update()
 	// Repository's Update should handle finding the record by ID from entityPtr and updating it.
-	if err := uc.Repository.Update(ctx, entityPtr); err != nil {
-		if err.Error() == "entity not found" { // Example check if repository.Update returns not found
-			uc.Logger.Warn("Attempted to update non-existent entity", "id", entityID.String())
+	if err := uc.write(ctx, func(repo repository.BaseRepository[T]) error { return repo.Update(ctx, entityPtr) }); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			uc.log(ctx).Warn("Attempted to update non-existent entity", "id", entityID.String())
 			return NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found for update", entityID.String()))
 		}
-		uc.Logger.Error("Failed to update entity in repository", "id", entityID.String(), "error", err)
+		if isValidationError(err) {
+			return NewValidationError(err) // Rejected by the entity's BeforeUpdate hook
+		}
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return NewLocalizedError(ErrConflict, "resource.already_exists", nil)
+		}
+		uc.log(ctx).Error("Failed to update entity in repository", "id", entityID.String(), "error", err)
 		// Consider checking for specific DB errors
 		return err // Return original repository error
 	}
@@ -119,22 +223,37 @@ func (uc *BaseUseCaseImpl[T]) Update(ctx context.Context, entityPtr *T) error {
 
 // Delete soft-deletes or hard-deletes an entity based on the flag
 func (uc *BaseUseCaseImpl[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
-	// Check if entity exists first to provide a NotFound error if it doesn't
-	_, err := uc.Repository.FindByID(ctx, id)
-	if err != nil {
-		if err.Error() == "entity not found" { // Example error string check
+	if err := uc.authorizeStoredWrite(ctx, id, "Delete"); err != nil {
+		return err
+	}
+
+	// Perform delete (soft or hard); the repository reports ErrNotFound when no row matched
+	if err := uc.write(ctx, func(repo repository.BaseRepository[T]) error { return repo.Delete(ctx, id, hardDelete) }); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			return NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found for deletion", id))
 		}
-		uc.Logger.Error("Failed to find entity for deletion", "id", id, "hardDelete", hardDelete, "error", err)
+		uc.log(ctx).Error("Failed to delete entity", "id", id, "hardDelete", hardDelete, "error", err)
 		return err // Return original repository error
 	}
 
-	// Perform delete (soft or hard)
-	if err := uc.Repository.Delete(ctx, id, hardDelete); err != nil {
-		uc.Logger.Error("Failed to delete entity", "id", id, "hardDelete", hardDelete, "error", err)
-		return err // Return original repository error
+	uc.afterDelete(ctx, hardDelete, id)
+	return nil
+}
+
+// Restore undoes the soft delete of an entity, with the checks of Delete; the repository reverts
+// its cascade rules (see repository.CascadeRule)
+func (uc *BaseUseCaseImpl[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := uc.authorizeStoredWrite(ctx, id, "Restore"); err != nil {
+		return err
 	}
 
+	if err := uc.write(ctx, func(repo repository.BaseRepository[T]) error { return repo.Restore(ctx, id) }); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return NewUseCaseError(ErrNotFound, fmt.Sprintf("no deleted resource with ID %s to restore", id))
+		}
+		uc.log(ctx).Error("Failed to restore entity", "id", id, "error", err)
+		return err // Return original repository error
+	}
 	return nil
 }
 
@@ -144,19 +263,50 @@ func (uc *BaseUseCaseImpl[T]) FindWithFilter(
 	filter map[string]interface{},
 	opts types.FilterOptions,
 ) (*types.PaginationResult[T], error) {
+	if err := uc.authorizeIncludeDeleted(ctx, opts, "FindWithFilter"); err != nil {
+		return nil, err
+	}
 	result, err := uc.Repository.FindWithFilter(ctx, filter, opts)
 	if err != nil {
-		uc.Logger.Error("Failed to find entities with filter", "error", err)
+		if errors.Is(err, types.ErrValidation) {
+			return nil, NewUseCaseError(ErrInvalidInput, err.Error())
+		}
+		uc.log(ctx).Error("Failed to find entities with filter", "error", err)
 		return nil, err // Return original repository error
 	}
+	uc.auditDeletedAccess(ctx, opts, "FindWithFilter", result)
 	return result, nil
 }
 
+// authorizeIncludeDeleted rejects IncludeDeleted for actors outside the DeletedRecords policy
+func (uc *BaseUseCaseImpl[T]) authorizeIncludeDeleted(ctx context.Context, opts types.FilterOptions, operation string) error {
+	if !opts.IncludeDeleted || uc.DeletedRecords == nil || uc.DeletedRecords.Allows(ctx) {
+		return nil
+	}
+	actor, _ := ActorFromContext(ctx)
+	uc.log(ctx).Warn("Denied access to deleted records", "operation", operation, "entityType", fmt.Sprintf("%T", *new(T)),
+		"actor_id", actor.ID, "actor_role", actor.Role)
+	return NewLocalizedError(ErrForbidden, "auth.include_deleted_forbidden", nil)
+}
+
+// auditDeletedAccess records who listed soft-deleted records
+func (uc *BaseUseCaseImpl[T]) auditDeletedAccess(ctx context.Context, opts types.FilterOptions, operation string, result *types.PaginationResult[T]) {
+	if !opts.IncludeDeleted || result == nil {
+		return
+	}
+	actor, _ := ActorFromContext(ctx)
+	uc.log(ctx).Info("Audit: deleted records accessed", "operation", operation, "entityType", fmt.Sprintf("%T", *new(T)),
+		"actor_id", actor.ID, "actor_role", actor.Role, "returned", len(result.Items), "total", result.TotalItems)
+}
+
 // Count returns the count of entities matching the filter
 func (uc *BaseUseCaseImpl[T]) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
 	count, err := uc.Repository.Count(ctx, filter)
 	if err != nil {
-		uc.Logger.Error("Failed to count entities", "error", err)
+		if errors.Is(err, types.ErrValidation) {
+			return 0, NewUseCaseError(ErrInvalidInput, err.Error())
+		}
+		uc.log(ctx).Error("Failed to count entities", "error", err)
 		return 0, err // Return original repository error
 	}
 	return count, nil
@@ -164,65 +314,198 @@ func (uc *BaseUseCaseImpl[T]) Count(ctx context.Context, filter map[string]inter
 
 // --- Bulk Operations Implementation ---
 
-// CreateMany processes a bulk creation request using the provided entity pointers
-// Returns the created entities (with IDs populated)
-func (uc *BaseUseCaseImpl[T]) CreateMany(ctx context.Context, entities []*T) ([]*T, error) {
-	if len(entities) == 0 {
-		return entities, nil
+// CreateMany creates each entity independently. Entities rejected by the BeforeCreate hook or by
+// the repository are reported in the result and the others are still created, with their IDs
+// populated. An error means the call failed as a whole.
+func (uc *BaseUseCaseImpl[T]) CreateMany(ctx context.Context, entities []*T) (*types.BulkResult, error) {
+	result := types.NewBulkResult(len(entities))
+	valid, indices := make([]*T, 0, len(entities)), make([]int, 0, len(entities))
+	for i, entityPtr := range entities {
+		if err := uc.beforeCreate(ctx, entityPtr); err != nil {
+			result.FailWith(i, err)
+			continue
+		}
+		valid, indices = append(valid, entityPtr), append(indices, i)
+	}
+	if len(valid) == 0 {
+		uc.reportFailures(ctx, "create", result.Failed)
+		return result, nil
 	}
-	// Validation should happen before calling, or rely on entity hooks.
 
-	// Create entities in repository, capture the returned slice
-	createdEntities, err := uc.Repository.CreateMany(ctx, entities)
+	var created *types.BulkResult
+	err := uc.write(ctx, func(repo repository.BaseRepository[T]) (err error) {
+		created, err = repo.CreateMany(ctx, valid)
+		return err
+	})
 	if err != nil {
-		uc.Logger.Error("Failed to bulk create entities", "count", len(entities), "error", err)
-		return nil, err // Return nil slice on error
+		uc.log(ctx).Error("Failed to bulk create entities", "count", len(entities), "error", err)
+		return nil, err // Return original repository error
+	}
+	uc.afterCreate(ctx, types.SucceededItems(created, valid)...)
+	result.Merge(created, indices)
+	uc.reportFailures(ctx, "create", result.Failed)
+	if result.HasFailures() {
+		uc.log(ctx).Warn("Some entities failed to be created", "count", len(entities), "failed", result.FailedCount())
 	}
+	return result, nil
+}
 
-	// Return the entities populated by the repository
-	return createdEntities, nil
+// CreateInBatches creates entities in chunks; with opts.ContinueOnError the report lists the
+// items that failed (index and reason) instead of aborting on the first failed batch
+func (uc *BaseUseCaseImpl[T]) CreateInBatches(ctx context.Context, entities []*T, opts types.BatchOptions) (*types.BatchReport[T], error) {
+	if err := uc.beforeCreate(ctx, entities...); err != nil {
+		return nil, err
+	}
+	var report *types.BatchReport[T]
+	err := uc.write(ctx, func(repo repository.BaseRepository[T]) (err error) {
+		report, err = repo.CreateInBatches(ctx, entities, opts)
+		return err
+	})
+	if report != nil {
+		uc.afterCreate(ctx, report.Succeeded...)
+		uc.reportFailures(ctx, "create", report.Failed)
+	}
+	if err != nil {
+		if isValidationError(err) {
+			return report, NewValidationError(err)
+		}
+		uc.log(ctx).Error("Failed to create entities in batches", "count", len(entities), "error", err)
+		return report, err // Return original repository error with the partial report
+	}
+	if report.HasFailures() {
+		uc.log(ctx).Warn("Some entities failed to be created", "count", len(entities), "failed", len(report.Failed))
+	}
+	return report, nil
 }
 
-// UpdateMany processes a bulk update request using the provided entity pointers.
-// Returns the fully updated entities fetched from the repository after the update.
-func (uc *BaseUseCaseImpl[T]) UpdateMany(ctx context.Context, entities []*T) ([]*T, error) {
-	if len(entities) == 0 {
-		return entities, nil // Nothing to update
+// UpdateMany updates each entity independently. Entities without an ID, refused by the Ownership
+// policy, rejected by the BeforeUpdate hook or failing in the repository are reported in the
+// result and the others are still updated. An error means the call failed as a whole.
+func (uc *BaseUseCaseImpl[T]) UpdateMany(ctx context.Context, entities []*T) (*types.BulkResult, error) {
+	ids := make([]uuid.UUID, 0, len(entities))
+	for _, entityPtr := range entities {
+		if entityPtr != nil && (*entityPtr).GetID() != uuid.Nil {
+			ids = append(ids, (*entityPtr).GetID())
+		}
 	}
-	// Validation should happen before calling, or rely on entity hooks.
-	// Ensure entities are valid before passing them?
+	refused, err := uc.refusedWrites(ctx, ids, "UpdateMany")
+	if err != nil {
+		return nil, err
+	}
+
+	result := types.NewBulkResult(len(entities))
+	valid, indices := make([]*T, 0, len(entities)), make([]int, 0, len(entities))
 	for i, entityPtr := range entities {
 		if entityPtr == nil || (*entityPtr).GetID() == uuid.Nil {
-			uc.Logger.Warn("UpdateMany called with nil entity or entity with nil ID", "index", i)
-			return nil, NewUseCaseError(ErrInvalidInput, fmt.Sprintf("invalid entity at index %d for bulk update", i))
+			result.FailWith(i, repository.ErrMissingID)
+			continue
+		}
+		if err := refused[(*entityPtr).GetID()]; err != nil {
+			result.FailWith(i, err)
+			continue
 		}
+		if err := uc.beforeUpdate(ctx, entityPtr); err != nil {
+			result.FailWith(i, err)
+			continue
+		}
+		valid, indices = append(valid, entityPtr), append(indices, i)
+	}
+	if len(valid) == 0 {
+		uc.reportFailures(ctx, "update", result.Failed)
+		return result, nil
 	}
 
-	// Call repository's UpdateMany, capture the returned updated entities
-	updatedEntities, err := uc.Repository.UpdateMany(ctx, entities)
+	var updated *types.BulkResult
+	err = uc.write(ctx, func(repo repository.BaseRepository[T]) (err error) {
+		updated, err = repo.UpdateMany(ctx, valid)
+		return err
+	})
 	if err != nil {
-		uc.Logger.Error("Failed to bulk update entities in repository", "count", len(entities), "error", err)
-		return nil, err // Return nil slice on error
+		uc.log(ctx).Error("Failed to bulk update entities in repository", "count", len(entities), "error", err)
+		return nil, err // Return original repository error
 	}
+	result.Merge(updated, indices)
+	uc.reportFailures(ctx, "update", result.Failed)
+	if result.HasFailures() {
+		uc.log(ctx).Warn("Some entities failed to be updated", "count", len(entities), "failed", result.FailedCount())
+	}
+	return result, nil
+}
 
-	return updatedEntities, nil
+// UpdateWhere sets columns on every entity matching filter without loading them and returns the
+// number of updated rows. Unknown or protected fields are rejected as invalid input.
+func (uc *BaseUseCaseImpl[T]) UpdateWhere(ctx context.Context, filter map[string]interface{}, updates map[string]interface{}) (int64, error) {
+	var affected int64
+	err := uc.write(ctx, func(repo repository.BaseRepository[T]) (err error) {
+		affected, err = repo.UpdateWhere(ctx, filter, updates)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, types.ErrValidation) {
+			return 0, NewUseCaseError(ErrInvalidInput, err.Error())
+		}
+		uc.log(ctx).Error("Failed to update entities by filter", "filter", filter, "error", err)
+		return 0, err // Return original repository error
+	}
+	return affected, nil
 }
 
-// DeleteMany soft-deletes or hard-deletes entities matching the provided IDs.
-func (uc *BaseUseCaseImpl[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error {
+// DeleteMany soft-deletes or hard-deletes the entities with the provided IDs. IDs that match no
+// entity or that the Ownership policy refuses are reported in the result; the others are still
+// deleted.
+func (uc *BaseUseCaseImpl[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) (*types.BulkResult, error) {
 	if len(ids) == 0 {
-		return nil // Nothing to delete
+		return types.NewBulkResult(0), nil // Nothing to delete
+	}
+	refused, err := uc.refusedWrites(ctx, ids, "DeleteMany")
+	if err != nil {
+		return nil, err
 	}
 
-	// We might want to check if all IDs exist first, but that could be expensive.
-	// The repository level might handle non-existent IDs gracefully (e.g., deleting those that exist).
-	// Alternatively, add a check here if strict existence is required.
+	result := types.NewBulkResult(len(ids))
+	allowed, indices := make([]uuid.UUID, 0, len(ids)), make([]int, 0, len(ids))
+	for i, id := range ids {
+		if err := refused[id]; err != nil {
+			result.FailWith(i, err)
+			continue
+		}
+		allowed, indices = append(allowed, id), append(indices, i)
+	}
+	if len(allowed) == 0 {
+		uc.reportFailures(ctx, "delete", result.Failed)
+		return result, nil
+	}
 
-	if err := uc.Repository.DeleteMany(ctx, ids, hardDelete); err != nil {
-		uc.Logger.Error("Failed to bulk delete entities", "count", len(ids), "hardDelete", hardDelete, "error", err)
-		return err // Return original repository error
+	var deleted *types.BulkResult
+	err = uc.write(ctx, func(repo repository.BaseRepository[T]) (err error) {
+		deleted, err = repo.DeleteMany(ctx, allowed, hardDelete)
+		return err
+	})
+	if err != nil {
+		uc.log(ctx).Error("Failed to bulk delete entities", "count", len(ids), "hardDelete", hardDelete, "error", err)
+		return nil, err // Return original repository error
+	}
+	result.Merge(deleted, indices)
+	uc.afterDelete(ctx, hardDelete, deleted.Succeeded...)
+	uc.reportFailures(ctx, "delete", result.Failed)
+	return result, nil
+}
+
+// reportFailures replaces the reasons of failed bulk items with FailureReason, logging the errors
+// that are not reported as they are. Items without an error keep their reason.
+func (uc *BaseUseCaseImpl[T]) reportFailures(ctx context.Context, operation string, failures []types.BatchFailure) {
+	for i := range failures {
+		f := &failures[i]
+		if f.Err == nil {
+			continue
+		}
+		f.Err = asValidationError(f.Err) // Rejected by an entity hook
+		var ucErr *UseCaseError
+		if !errors.As(f.Err, &ucErr) && !errors.Is(f.Err, repository.ErrNotFound) && !errors.Is(f.Err, repository.ErrMissingID) {
+			uc.log(ctx).Warn("Bulk item failed", "operation", operation, "index", f.Index, "error", f.Err)
+		}
+		f.Reason = FailureReason(ctx, f.Err)
 	}
-	return nil
 }
 
 // UseCaseErrorType defines the type of error
@@ -235,12 +518,26 @@ const (
 	ErrForbidden    UseCaseErrorType = "forbidden"
 	ErrConflict     UseCaseErrorType = "conflict"
 	ErrInternal     UseCaseErrorType = "internal_error"
+	// ErrResourceExhausted reports a limit that frees up over time, e.g. a daily quota
+	ErrResourceExhausted UseCaseErrorType = "resource_exhausted"
 )
 
-// UseCaseError represents an error from a use case
+// UseCaseError represents an error from a use case.
+// MessageID and Params are set for localizable errors; Message then holds the English rendering.
 type UseCaseError struct {
-	Type    UseCaseErrorType
-	Message string
+	Type       UseCaseErrorType
+	Message    string
+	MessageID  string
+	Params     map[string]string
+	Violations []FieldViolation
+}
+
+// FieldViolation describes a single invalid field of a request
+type FieldViolation struct {
+	Field     string
+	MessageID string
+	Params    map[string]string
+	Message   string
 }
 
 // Error returns the error message
@@ -255,3 +552,84 @@ func NewUseCaseError(errorType UseCaseErrorType, message string) error {
 		Message: message,
 	}
 }
+
+// NewLocalizedError creates a use case error identified by a catalog message ID, so the gateway can
+// render it in the caller's language
+func NewLocalizedError(errorType UseCaseErrorType, messageID string, params map[string]string) error {
+	return &UseCaseError{
+		Type:      errorType,
+		Message:   i18n.Translate(i18n.DefaultLanguage, messageID, params),
+		MessageID: messageID,
+		Params:    params,
+	}
+}
+
+// FailureReason returns the reason reported to the caller for a bulk item that failed with err, in
+// the caller's language (see i18n.FromContext). Use case errors keep their message, or list their
+// field violations, and unknown or missing IDs have messages of their own. Other errors may quote
+// the database, so they are reported as a generic failure; log them before calling this.
+func FailureReason(ctx context.Context, err error) string {
+	locale := i18n.FromContext(ctx)
+	var ucErr *UseCaseError
+	switch {
+	case errors.As(err, &ucErr):
+		if len(ucErr.Violations) > 0 {
+			messages := make([]string, len(ucErr.Violations))
+			for i, v := range ucErr.Violations {
+				messages[i] = locale.T(v.MessageID, v.Params)
+			}
+			return strings.Join(messages, "; ")
+		}
+		if ucErr.MessageID != "" {
+			return locale.T(ucErr.MessageID, ucErr.Params)
+		}
+		return ucErr.Message
+	case errors.Is(err, repository.ErrNotFound):
+		return locale.T("bulk.not_found", nil)
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return locale.T("resource.already_exists", nil)
+	case errors.Is(err, repository.ErrMissingID):
+		return locale.T("bulk.missing_id", nil)
+	default:
+		return locale.T("bulk.failed", nil)
+	}
+}
+
+// isValidationError reports whether err carries a dto.Validate error, e.g. from an entity hook
+func isValidationError(err error) bool {
+	var validationErrs dto.ValidationErrors
+	return errors.As(err, &validationErrs)
+}
+
+// asValidationError converts err with NewValidationError when it carries a dto.Validate error and
+// returns it unchanged otherwise
+func asValidationError(err error) error {
+	if isValidationError(err) {
+		return NewValidationError(err)
+	}
+	return err
+}
+
+// NewValidationError converts a dto.Validate error into an invalid input error with one violation per field
+func NewValidationError(err error) error {
+	var validationErrs dto.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return NewUseCaseError(ErrInvalidInput, err.Error())
+	}
+
+	ucErr := NewLocalizedError(ErrInvalidInput, "validation.failed", nil).(*UseCaseError)
+	for _, fe := range validationErrs.GetErrors() {
+		messageID := "validation." + fe.Tag()
+		if !i18n.HasMessage(messageID) {
+			messageID = "validation.invalid"
+		}
+		params := map[string]string{"field": fe.Field(), "param": fe.Param()}
+		ucErr.Violations = append(ucErr.Violations, FieldViolation{
+			Field:     fe.Field(),
+			MessageID: messageID,
+			Params:    params,
+			Message:   i18n.Translate(i18n.DefaultLanguage, messageID, params),
+		})
+	}
+	return ucErr
+}
@@ -0,0 +1,134 @@
+package watchdog
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// routeKey identifies the counters of one route
+type routeKey struct {
+	kind  string
+	route string
+}
+
+// routeSeries holds the counters of one route
+type routeSeries struct {
+	slow   uint64
+	alerts uint64
+}
+
+// Metrics counts slow requests and alerts per route, and the requests currently running past their
+// threshold. It implements http.Handler, serving the Prometheus text exposition format. A nil
+// *Metrics records nothing.
+type Metrics struct {
+	mu      sync.Mutex
+	routes  map[routeKey]*routeSeries
+	overdue map[string]int64 // Running past their threshold, by kind
+}
+
+// NewMetrics creates an empty metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{routes: make(map[routeKey]*routeSeries), overdue: make(map[string]int64)}
+}
+
+func (m *Metrics) slow(kind, route string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.route(kind, route).slow++
+}
+
+func (m *Metrics) alerted(kind, route string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.route(kind, route).alerts++
+}
+
+func (m *Metrics) running(kind string, delta int64) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overdue[kind] += delta
+}
+
+// route returns the counters of a route; m.mu must be held
+func (m *Metrics) route(kind, route string) *routeSeries {
+	key := routeKey{kind: kind, route: route}
+	s, ok := m.routes[key]
+	if !ok {
+		s = &routeSeries{}
+		m.routes[key] = s
+	}
+	return s
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	keys := make([]routeKey, 0, len(m.routes))
+	routes := make(map[routeKey]routeSeries, len(m.routes))
+	for k, s := range m.routes {
+		keys = append(keys, k)
+		routes[k] = *s
+	}
+	kinds := make([]string, 0, len(m.overdue))
+	overdue := make(map[string]int64, len(m.overdue))
+	for k, v := range m.overdue {
+		kinds = append(kinds, k)
+		overdue[k] = v
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].route < keys[j].route
+	})
+	sort.Strings(kinds)
+
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	counters := []struct {
+		name, help string
+		value      func(routeSeries) uint64
+	}{
+		{"slow_requests_total", "Requests that took longer than their latency threshold.", func(s routeSeries) uint64 { return s.slow }},
+		{"slow_request_alerts_total", "Alerts raised for routes with sustained slow requests.", func(s routeSeries) uint64 { return s.alerts }},
+	}
+	for _, c := range counters {
+		printf("# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, k := range keys {
+			printf("%s{kind=%q,route=%q} %d\n", c.name, k.kind, k.route, c.value(routes[k]))
+		}
+	}
+	printf("# HELP slow_requests_running Requests currently running past their latency threshold.\n# TYPE slow_requests_running gauge\n")
+	for _, kind := range kinds {
+		printf("slow_requests_running{kind=%q} %d\n", kind, overdue[kind])
+	}
+	return err
+}
+
+// ServeHTTP serves the metrics for scraping
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
@@ -0,0 +1,85 @@
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/httpclient"
+)
+
+// Alert reports a route that stayed slow
+type Alert struct {
+	Service   string
+	Kind      string
+	Route     string
+	Count     int // Slow requests within Window
+	Window    time.Duration
+	Threshold time.Duration
+	Latest    time.Duration // Duration of the request that raised the alert
+	RequestID string        // ID of that request, to find it and its user in the logs
+}
+
+// Notifier delivers slow request alerts, e.g. to a chat channel or a pager
+type Notifier interface {
+	NotifySlow(ctx context.Context, alert Alert) error
+}
+
+// NotifierFunc adapts a function to Notifier
+type NotifierFunc func(ctx context.Context, alert Alert) error
+
+// NotifySlow implements Notifier
+func (f NotifierFunc) NotifySlow(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// SlackNotifier posts alerts to a Slack incoming webhook
+type SlackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackNotifier creates a notifier posting to the incoming webhook url
+func NewSlackNotifier(url string) *SlackNotifier {
+	config := httpclient.LoadConfigFromEnv("slack")
+	config.MaxRetries = 0 // Webhook posts are not idempotent; a lost alert is re-raised after the cooldown
+	return &SlackNotifier{url: url, client: httpclient.New(config, nil)}
+}
+
+// NotifySlow implements Notifier
+func (n *SlackNotifier) NotifySlow(ctx context.Context, alert Alert) error {
+	service := alert.Service
+	if service == "" {
+		service = "service"
+	}
+	text := fmt.Sprintf(":snail: *%s*: %d %s requests to `%s` took longer than %s in the last %s (latest %s",
+		service, alert.Count, alert.Kind, alert.Route, alert.Threshold, alert.Window, alert.Latest.Round(time.Millisecond))
+	if alert.RequestID != "" {
+		text += ", request ID `" + alert.RequestID + "`"
+	}
+	text += ")"
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
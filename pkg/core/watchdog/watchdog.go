@@ -0,0 +1,269 @@
+// Package watchdog flags requests that exceed a latency threshold. It is shared by the gateway and
+// the gRPC services: a request still running past its threshold is logged while it runs, a slow
+// request is logged with its route, method and user when it finishes and counted for alerting, and
+// a route that stays slow is reported to a Notifier such as a Slack channel.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Request kinds, used as the kind label of the metrics
+const (
+	KindHTTP = "http"
+	KindGRPC = "grpc"
+)
+
+// RouteThreshold overrides the default threshold for routes starting with Prefix; zero disables
+// the watchdog for them (e.g. exports or long polling)
+type RouteThreshold struct {
+	Prefix    string
+	Threshold time.Duration
+}
+
+// Config holds the watchdog settings
+type Config struct {
+	Enabled       bool
+	Service       string           // Named in alerts; APP_NAME
+	Threshold     time.Duration    // Default latency threshold
+	Routes        []RouteThreshold // The longest matching prefix wins
+	AlertCount    int              // Slow requests of one route within AlertWindow that raise an alert; zero disables alerts
+	AlertWindow   time.Duration
+	AlertCooldown time.Duration // Minimum time between two alerts for the same route
+	SlackWebhook  string        // Incoming webhook URL alerts are posted to; empty only logs them
+}
+
+// LoadConfigFromEnv reads the watchdog configuration from environment variables. Routes are HTTP
+// route patterns ("GET /api/v1/users/{id}") or full gRPC method names, matched by prefix.
+//
+//	SLOW_REQUEST_ENABLED=true
+//	SLOW_REQUEST_THRESHOLD=2s
+//	SLOW_REQUEST_ROUTES="POST /api/v1/users/bulk|threshold=10s;/userservice.UserService/Export|threshold=0"
+//	SLOW_REQUEST_ALERT_COUNT=10  SLOW_REQUEST_ALERT_WINDOW=5m  SLOW_REQUEST_ALERT_COOLDOWN=30m
+//	SLOW_REQUEST_SLACK_WEBHOOK_URL=https://hooks.slack.com/services/...
+func LoadConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Enabled:       utils.GetEnv("SLOW_REQUEST_ENABLED", "true") == "true",
+		Service:       utils.GetEnv("APP_NAME", ""),
+		AlertCount:    utils.GetEnvAsInt("SLOW_REQUEST_ALERT_COUNT", 10),
+		SlackWebhook:  utils.GetEnv("SLOW_REQUEST_SLACK_WEBHOOK_URL", ""),
+		AlertWindow:   utils.GetEnvDuration("SLOW_REQUEST_ALERT_WINDOW", 5*time.Minute),
+		AlertCooldown: utils.GetEnvDuration("SLOW_REQUEST_ALERT_COOLDOWN", 30*time.Minute),
+	}
+	var err error
+	if cfg.Threshold, err = time.ParseDuration(utils.GetEnv("SLOW_REQUEST_THRESHOLD", "2s")); err != nil {
+		return Config{}, fmt.Errorf("SLOW_REQUEST_THRESHOLD: %w", err)
+	}
+
+	for _, group := range strings.Split(utils.GetEnv("SLOW_REQUEST_ROUTES", ""), ";") {
+		if strings.TrimSpace(group) == "" {
+			continue
+		}
+		parts := strings.Split(group, "|")
+		route := RouteThreshold{Prefix: strings.TrimSpace(parts[0]), Threshold: cfg.Threshold}
+		if route.Prefix == "" {
+			return Config{}, fmt.Errorf("slow request route %q has no prefix", group)
+		}
+		for _, part := range parts[1:] {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok || strings.TrimSpace(key) != "threshold" {
+				return Config{}, fmt.Errorf("slow request route %q: expected threshold=<duration>, got %q", group, part)
+			}
+			if route.Threshold, err = time.ParseDuration(strings.TrimSpace(value)); err != nil {
+				return Config{}, fmt.Errorf("slow request route %q: threshold: %w", group, err)
+			}
+		}
+		cfg.Routes = append(cfg.Routes, route)
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate rejects negative durations
+func (c Config) Validate() error {
+	if c.Threshold < 0 {
+		return fmt.Errorf("negative slow request threshold %s", c.Threshold)
+	}
+	for _, r := range c.Routes {
+		if r.Threshold < 0 {
+			return fmt.Errorf("negative slow request threshold %s for %q", r.Threshold, r.Prefix)
+		}
+	}
+	if c.AlertCount > 0 && c.AlertWindow <= 0 {
+		return fmt.Errorf("slow request alert window must be positive")
+	}
+	return nil
+}
+
+// Request describes the request being watched
+type Request struct {
+	Kind      string // KindHTTP or KindGRPC
+	Route     string // Route pattern or full gRPC method; keep it low-cardinality, it is a metric label
+	RequestID string
+}
+
+// routeState tracks the recent slow requests of a route for alerting
+type routeState struct {
+	slow      []time.Time // Completion times within the alert window, oldest first
+	lastAlert time.Time
+}
+
+// Watchdog watches requests against their thresholds. A nil *Watchdog watches nothing.
+type Watchdog struct {
+	config   Config
+	logger   logger.Logger
+	notifier Notifier
+	metrics  *Metrics
+
+	mu     sync.Mutex
+	routes map[string]*routeState
+}
+
+// New creates a watchdog. notifier may be nil to only log and count alerts.
+func New(config Config, notifier Notifier, log logger.Logger) (*Watchdog, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.Routes = append([]RouteThreshold(nil), config.Routes...)
+	sort.SliceStable(config.Routes, func(i, j int) bool { return len(config.Routes[i].Prefix) > len(config.Routes[j].Prefix) })
+	return &Watchdog{
+		config:   config,
+		logger:   log,
+		notifier: notifier,
+		metrics:  NewMetrics(),
+		routes:   make(map[string]*routeState),
+	}, nil
+}
+
+// NewFromEnv creates a watchdog from LoadConfigFromEnv, posting alerts to SLOW_REQUEST_SLACK_WEBHOOK_URL
+// when it is set. An invalid configuration is logged and the watchdog is disabled.
+func NewFromEnv(log logger.Logger) *Watchdog {
+	config, err := LoadConfigFromEnv()
+	var notifier Notifier
+	if err == nil && config.SlackWebhook != "" {
+		notifier = NewSlackNotifier(config.SlackWebhook)
+	}
+	var w *Watchdog
+	if err == nil {
+		w, err = New(config, notifier, log)
+	}
+	if err != nil {
+		log.Error("Invalid slow request configuration, the watchdog is disabled", "error", err)
+		w, _ = New(Config{}, nil, log)
+	}
+	return w
+}
+
+// Metrics returns the watchdog's counters, to serve on the metrics endpoint
+func (w *Watchdog) Metrics() *Metrics {
+	if w == nil {
+		return nil
+	}
+	return w.metrics
+}
+
+// threshold returns the threshold of a route
+func (w *Watchdog) threshold(route string) time.Duration {
+	for _, r := range w.config.Routes {
+		if strings.HasPrefix(route, r.Prefix) {
+			return r.Threshold
+		}
+	}
+	return w.config.Threshold
+}
+
+// Begin starts watching a request and returns the function to call when it finishes, with its
+// status (HTTP status code or gRPC code). Both take the request's context, whose logger (see
+// logger.FromContext) adds the method, path and user to the log entries; the one passed when the
+// request finishes also has the fields added while it ran, such as the user.
+func (w *Watchdog) Begin(ctx context.Context, req Request) func(ctx context.Context, status string) {
+	if w == nil || !w.config.Enabled {
+		return func(context.Context, string) {}
+	}
+	threshold := w.threshold(req.Route)
+	if threshold <= 0 {
+		return func(context.Context, string) {}
+	}
+
+	start := time.Now()
+	timer := time.AfterFunc(threshold, func() {
+		w.metrics.running(req.Kind, 1)
+		logger.FromContext(ctx, w.logger).Warn("Request is still running past its latency threshold", "kind", req.Kind, "route", req.Route, "threshold", threshold)
+	})
+
+	return func(ctx context.Context, status string) {
+		elapsed := time.Since(start)
+		if !timer.Stop() {
+			// The timer fired, so the request was counted as running past its threshold
+			w.metrics.running(req.Kind, -1)
+		}
+		if elapsed <= threshold {
+			return
+		}
+		logger.FromContext(ctx, w.logger).Warn("Slow request", "kind", req.Kind, "route", req.Route, "status", status, "duration", elapsed, "threshold", threshold)
+		w.metrics.slow(req.Kind, req.Route)
+		w.recordSlow(req, elapsed, threshold)
+	}
+}
+
+// recordSlow adds a slow request to its route's window and raises an alert once the window holds
+// AlertCount of them, at most once per AlertCooldown
+func (w *Watchdog) recordSlow(req Request, elapsed, threshold time.Duration) {
+	if w.config.AlertCount <= 0 {
+		return
+	}
+	now := time.Now()
+	w.mu.Lock()
+	state, ok := w.routes[req.Route]
+	if !ok {
+		state = &routeState{}
+		w.routes[req.Route] = state
+	}
+	cutoff := now.Add(-w.config.AlertWindow)
+	kept := state.slow[:0]
+	for _, t := range state.slow {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	state.slow = append(kept, now)
+	count := len(state.slow)
+	raise := count >= w.config.AlertCount && (state.lastAlert.IsZero() || now.Sub(state.lastAlert) >= w.config.AlertCooldown)
+	if raise {
+		state.lastAlert = now
+	}
+	w.mu.Unlock()
+	if !raise {
+		return
+	}
+
+	alert := Alert{
+		Service:   w.config.Service,
+		Kind:      req.Kind,
+		Route:     req.Route,
+		Count:     count,
+		Window:    w.config.AlertWindow,
+		Threshold: threshold,
+		Latest:    elapsed,
+		RequestID: req.RequestID,
+	}
+	w.metrics.alerted(req.Kind, req.Route)
+	w.logger.Warn("Sustained slow requests", "kind", alert.Kind, "route", alert.Route, "count", alert.Count, "window", alert.Window, "threshold", alert.Threshold)
+	if w.notifier == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := w.notifier.NotifySlow(ctx, alert); err != nil {
+			w.logger.Error("Failed to send slow request alert", "route", alert.Route, "error", err)
+		}
+	}()
+}
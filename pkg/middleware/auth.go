@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/permissions"
 	"golang-microservices-boilerplate/pkg/utils"
 	"net/http"
 	"slices"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // UserClaims represents the custom claims in the JWT
@@ -30,10 +33,17 @@ type JWTConfig struct {
 	ErrorHandler       fiber.ErrorHandler
 }
 
+// Development secrets used when ACCESS_TOKEN_SECRET or REFRESH_TOKEN_SECRET is not set. Anyone can
+// sign tokens with them; preflight.JWTSecrets refuses them in production.
+const (
+	DevelopmentAccessTokenSecret  = "access_token_secret_wqim"
+	DevelopmentRefreshTokenSecret = "refresh_token_secret_KMT"
+)
+
 // DefaultJWTConfig is the default JWT auth configuration
 var DefaultJWTConfig = JWTConfig{
-	AccessTokenSecret:  utils.GetEnv("ACCESS_TOKEN_SECRET", "access_token_secret_wqim"),
-	RefreshTokenSecret: utils.GetEnv("REFRESH_TOKEN_SECRET", "refresh_token_secret_KMT"), // CHANGE THIS!
+	AccessTokenSecret:  utils.GetEnv("ACCESS_TOKEN_SECRET", DevelopmentAccessTokenSecret),
+	RefreshTokenSecret: utils.GetEnv("REFRESH_TOKEN_SECRET", DevelopmentRefreshTokenSecret), // CHANGE THIS!
 	TokenLookup:        "header:Authorization",
 	TokenHeadName:      "Bearer",
 	ContextKey:         "user",
@@ -52,17 +62,25 @@ func GenerateToken(customClaims map[string]interface{}, expirationTime time.Dura
 	// Optionally, remove "sub" from customClaims if you don't want it duplicated in Data
 	// delete(customClaims, "sub")
 
+	validation := currentTokenValidation()
 	claims := UserClaims{
 		Data: customClaims,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),                    // Lets the token be revoked before it expires
 			Subject:   subject,                             // Use 'sub' claim for User ID extracted from map
 			Issuer:    utils.GetEnv("APP_NAME", "wqimKMT"), // Consider making this configurable
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expirationTime)),
 		},
 	}
+	if validation.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{validation.Audience}
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if validation.SigningKID != "" {
+		token.Header["kid"] = validation.SigningKID
+	}
 	return token.SignedString([]byte(secret))
 }
 
@@ -115,44 +133,62 @@ func AuthMiddleware(config ...JWTConfig) fiber.Handler {
 		}
 
 		// Parse the token using the primary Secret (for access tokens)
-		claims := &UserClaims{}
-		parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-			// Validate the algorithm
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("unexpected signing method")
-			}
-			// Use the primary secret for access token validation
-			return []byte(cfg.AccessTokenSecret), nil
-		})
-
+		claims, err := validateAccessToken(token, cfg.AccessTokenSecret)
 		if err != nil {
-			if errors.Is(err, jwt.ErrTokenExpired) {
-				return cfg.ErrorHandler(c, errors.New("token expired"))
-			} else if errors.Is(err, jwt.ErrSignatureInvalid) {
-				return cfg.ErrorHandler(c, errors.New("invalid token signature"))
-			}
-			return cfg.ErrorHandler(c, errors.New("invalid token"))
-		}
-
-		if !parsedToken.Valid {
-			return cfg.ErrorHandler(c, errors.New("invalid token"))
-		}
-
-		// Check if token is expired
-		if claims.ExpiresAt != nil {
-			if claims.ExpiresAt.Time.Before(time.Now()) {
-				return cfg.ErrorHandler(c, errors.New("token expired"))
-			}
+			return cfg.ErrorHandler(c, err)
 		}
 
 		// Store user information in context
 		c.Locals(cfg.ContextKey, claims)
+		c.SetUserContext(logger.WithFields(c.UserContext(), "user_id", claims.Subject))
 
 		// Proceed to the next middleware or handler
 		return c.Next()
 	}
 }
 
+// ValidateAccessToken validates an access token outside of Fiber, e.g. in gRPC interceptors of backend services
+func ValidateAccessToken(tokenString string, accessSecret string) (*UserClaims, error) {
+	return validateAccessToken(tokenString, accessSecret)
+}
+
+// validateAccessToken parses and validates an access token. HS256 tokens are verified with secret, or
+// with the shared secret named by their kid header; RS256/ES256 tokens with the JWKS key named by kid.
+// Issuer and audience are checked when configured (see LoadTokenValidationConfigFromEnv).
+func validateAccessToken(token, secret string) (*UserClaims, error) {
+	validation := currentTokenValidation()
+	claims := &UserClaims{}
+	parsedToken, err := jwt.ParseWithClaims(token, claims, validation.keyFunc(secret), jwt.WithValidMethods(validation.Algorithms))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New("token expired")
+		} else if errors.Is(err, jwt.ErrSignatureInvalid) {
+			return nil, errors.New("invalid token signature")
+		}
+		return nil, errors.New("invalid token")
+	}
+
+	if !parsedToken.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if err := validation.checkClaims(claims); err != nil {
+		return nil, err
+	}
+	if isRevoked(context.Background(), claims.ID) {
+		return nil, errors.New("token revoked")
+	}
+
+	// Check if token is expired
+	if claims.ExpiresAt != nil {
+		if claims.ExpiresAt.Time.Before(time.Now()) {
+			return nil, errors.New("token expired")
+		}
+	}
+
+	return claims, nil
+}
+
 // extractToken extracts the token from the request based on the lookup configuration
 func extractToken(c *fiber.Ctx, config JWTConfig) (string, error) {
 	parts := strings.Split(config.TokenLookup, ":")
@@ -248,17 +284,16 @@ func RequireRole(roles []string, contextKey ...string) fiber.Handler {
 			})
 		}
 
-		// Look for role in the Data map
-		roleClaim, ok := claims.Data["role"].(string) // Assuming role is stored as a string with key "role"
-		if !ok {
+		typed, err := claims.Claims()
+		if err != nil || typed.Role == "" {
 			// Role claim missing or not a string
 			return c.Status(http.StatusForbidden).JSON(fiber.Map{
-				"error": fmt.Sprintln("role claim missing or invalid format in token"),
+				"error": "role claim missing or invalid format in token",
 			})
 		}
 
 		// lowercase the roleClaim
-		roleClaim = strings.ToLower(roleClaim)
+		roleClaim := strings.ToLower(typed.Role)
 		// lowercase the roles
 		for i, role := range roles {
 			roles[i] = strings.ToLower(role)
@@ -274,6 +309,26 @@ func RequireRole(roles []string, contextKey ...string) fiber.Handler {
 	}
 }
 
+// RequirePermission middleware ensures the authenticated user's role grants the permission
+// (see permissions.Default)
+func RequirePermission(required permissions.Permission, contextKey ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := GetClaims(c, contextKey...)
+		if claims == nil {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+				"error": "authentication required",
+			})
+		}
+		typed, err := claims.Claims()
+		if err != nil || !permissions.Has(typed.Role, required) {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"error": "insufficient permissions",
+			})
+		}
+		return c.Next()
+	}
+}
+
 // --- Refresh Token Specific Logic (Example Placeholder) ---
 
 // ValidateRefreshToken specifically validates a refresh token using the refresh secret.
@@ -300,8 +355,9 @@ func ValidateRefreshToken(tokenString string, refreshSecret string) (*UserClaims
 	if !parsedToken.Valid {
 		return nil, errors.New("invalid refresh token")
 	}
-
-	// Optional: Check against a revocation list here if implementing
+	if isRevoked(context.Background(), claims.ID) {
+		return nil, errors.New("refresh token revoked")
+	}
 
 	return claims, nil
 }
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Names of the custom claims in the token's "data" object. Issuers and readers go through
+// Claims.Encode and UserClaims.Claims, so the names only live here.
+const (
+	ClaimUserID   = "sub"
+	ClaimEmail    = "email"
+	ClaimRole     = "role"
+	ClaimTenantID = "tenant_id"
+	ClaimOrgRole  = "org_role"
+	ClaimScopes   = "scopes"
+)
+
+// Claims is the typed view of the custom claims carried by access and refresh tokens
+type Claims struct {
+	UserID   uuid.UUID
+	Email    string
+	Role     string
+	TenantID uuid.UUID // uuid.Nil when the token is not bound to a tenant
+	OrgRole  string    // Role within the tenant, if bound to one
+	Scopes   []string
+}
+
+// Encode returns the claims map passed to GenerateToken and GenerateTokenPair
+func (c Claims) Encode() map[string]interface{} {
+	data := map[string]interface{}{
+		ClaimUserID: c.UserID.String(),
+		ClaimEmail:  c.Email,
+		ClaimRole:   c.Role,
+	}
+	if c.TenantID != uuid.Nil {
+		data[ClaimTenantID] = c.TenantID.String()
+		if c.OrgRole != "" {
+			data[ClaimOrgRole] = c.OrgRole
+		}
+	}
+	if len(c.Scopes) > 0 {
+		data[ClaimScopes] = c.Scopes
+	}
+	return data
+}
+
+// HasScope reports whether the token grants scope
+func (c Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// DecodeClaims parses a claims map. A missing or malformed user ID, or a claim of the wrong type,
+// is an error rather than a silently empty value.
+func DecodeClaims(data map[string]interface{}) (Claims, error) {
+	var c Claims
+
+	sub, err := stringClaim(data, ClaimUserID)
+	if err != nil {
+		return Claims{}, err
+	}
+	if c.UserID, err = uuid.Parse(sub); err != nil || c.UserID == uuid.Nil {
+		return Claims{}, fmt.Errorf("claim %q: invalid user ID", ClaimUserID)
+	}
+	if c.Email, err = stringClaim(data, ClaimEmail); err != nil {
+		return Claims{}, err
+	}
+	if c.Role, err = stringClaim(data, ClaimRole); err != nil {
+		return Claims{}, err
+	}
+
+	tenant, err := stringClaim(data, ClaimTenantID)
+	if err != nil {
+		return Claims{}, err
+	}
+	if tenant != "" {
+		if c.TenantID, err = uuid.Parse(tenant); err != nil {
+			return Claims{}, fmt.Errorf("claim %q: invalid tenant ID", ClaimTenantID)
+		}
+	}
+	if c.OrgRole, err = stringClaim(data, ClaimOrgRole); err != nil {
+		return Claims{}, err
+	}
+
+	switch scopes := data[ClaimScopes].(type) {
+	case nil:
+	case string: // OAuth style space separated list
+		c.Scopes = strings.Fields(scopes)
+	case []string:
+		c.Scopes = scopes
+	case []interface{}: // What encoding/json produces for a decoded token
+		for _, scope := range scopes {
+			s, ok := scope.(string)
+			if !ok {
+				return Claims{}, fmt.Errorf("claim %q: expected a list of strings", ClaimScopes)
+			}
+			c.Scopes = append(c.Scopes, s)
+		}
+	default:
+		return Claims{}, fmt.Errorf("claim %q: expected a list of strings", ClaimScopes)
+	}
+	return c, nil
+}
+
+// stringClaim returns an optional string claim, failing when it has another type
+func stringClaim(data map[string]interface{}, name string) (string, error) {
+	switch v := data[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("claim %q: expected a string", name)
+	}
+}
+
+// Claims decodes the token's custom claims, falling back to the registered "sub" claim for the user ID
+func (c *UserClaims) Claims() (Claims, error) {
+	data := c.Data
+	if _, ok := data[ClaimUserID]; !ok && c.Subject != "" {
+		data = make(map[string]interface{}, len(c.Data)+1)
+		for k, v := range c.Data {
+			data[k] = v
+		}
+		data[ClaimUserID] = c.Subject
+	}
+	return DecodeClaims(data)
+}
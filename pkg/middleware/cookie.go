@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TokenCookieConfig controls delivering JWTs as HttpOnly cookies instead of in response bodies
+type TokenCookieConfig struct {
+	Enabled           bool
+	AccessCookieName  string
+	RefreshCookieName string
+	CSRFCookieName    string
+	Domain            string
+	Path              string
+	Secure            bool
+	SameSite          http.SameSite
+	RefreshMaxAge     time.Duration // Lifetime of the refresh cookie; should match the refresh token duration
+}
+
+// LoadTokenCookieConfigFromEnv reads the cookie token mode configuration from environment variables
+func LoadTokenCookieConfigFromEnv() TokenCookieConfig {
+	return TokenCookieConfig{
+		Enabled:           utils.GetEnv("AUTH_COOKIE_MODE", "false") == "true",
+		AccessCookieName:  utils.GetEnv("AUTH_ACCESS_COOKIE_NAME", DefaultCSRFConfig.AuthCookieName),
+		RefreshCookieName: utils.GetEnv("AUTH_REFRESH_COOKIE_NAME", "refresh_token"),
+		CSRFCookieName:    utils.GetEnv("AUTH_CSRF_COOKIE_NAME", DefaultCSRFConfig.CookieName),
+		Domain:            utils.GetEnv("AUTH_COOKIE_DOMAIN", ""),
+		Path:              utils.GetEnv("AUTH_COOKIE_PATH", "/"),
+		Secure:            utils.GetEnv("AUTH_COOKIE_SECURE", "true") != "false",
+		SameSite:          parseSameSite(utils.GetEnv("AUTH_COOKIE_SAMESITE", "Strict")),
+		RefreshMaxAge:     utils.GetEnvDuration("AUTH_REFRESH_COOKIE_MAX_AGE", 30*24*time.Hour),
+	}
+}
+
+// SetTokenCookies writes the access/refresh token cookies plus a fresh CSRF cookie.
+// An empty refreshToken leaves the existing refresh cookie untouched.
+func (cfg TokenCookieConfig) SetTokenCookies(w http.ResponseWriter, accessToken, refreshToken string, accessExpiresAt time.Time) error {
+	http.SetCookie(w, cfg.cookie(cfg.AccessCookieName, accessToken, accessExpiresAt, true))
+	if refreshToken != "" {
+		http.SetCookie(w, cfg.cookie(cfg.RefreshCookieName, refreshToken, time.Now().Add(cfg.RefreshMaxAge), true))
+	}
+
+	csrfToken, err := GenerateCSRFToken()
+	if err != nil {
+		return err
+	}
+	// The CSRF cookie must be readable by the client so it can be echoed in the header
+	http.SetCookie(w, cfg.cookie(cfg.CSRFCookieName, csrfToken, time.Now().Add(cfg.RefreshMaxAge), false))
+	return nil
+}
+
+// ClearTokenCookies expires all auth cookies
+func (cfg TokenCookieConfig) ClearTokenCookies(w http.ResponseWriter) {
+	for _, c := range cfg.ExpiredTokenCookies() {
+		http.SetCookie(w, c)
+	}
+}
+
+// ExpiredTokenCookies returns cookies that remove all auth cookies, for handlers without an http.ResponseWriter
+func (cfg TokenCookieConfig) ExpiredTokenCookies() []*http.Cookie {
+	var cookies []*http.Cookie
+	for _, name := range []string{cfg.AccessCookieName, cfg.RefreshCookieName, cfg.CSRFCookieName} {
+		c := cfg.cookie(name, "", time.Unix(0, 0), name != cfg.CSRFCookieName)
+		c.MaxAge = -1
+		cookies = append(cookies, c)
+	}
+	return cookies
+}
+
+func (cfg TokenCookieConfig) cookie(name, value string, expires time.Time, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     cfg.Path,
+		Domain:   cfg.Domain,
+		Expires:  expires,
+		Secure:   cfg.Secure,
+		HttpOnly: httpOnly,
+		SameSite: cfg.SameSite,
+	}
+}
+
+// CookieToAuthHeader copies the access token cookie into the Authorization header when no header
+// was sent, so downstream auth (gateway and gRPC services) works unchanged in cookie mode.
+func CookieToAuthHeader(cfg TokenCookieConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) == "" {
+			if token := c.Cookies(cfg.AccessCookieName); token != "" {
+				c.Request().Header.Set(fiber.HeaderAuthorization, DefaultJWTConfig.TokenHeadName+" "+token)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// parseSameSite converts a config string into http.SameSite, defaulting to Strict
+func parseSameSite(v string) http.SameSite {
+	switch strings.ToLower(v) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
@@ -1,27 +1,193 @@
 package middleware
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-// CORSMiddleware handles Cross-Origin Resource Sharing (CORS) requests
-func CORSMiddleware() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		c.Set("Access-Control-Allow-Origin", "*")
-		c.Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// CORSPolicy is the Cross-Origin Resource Sharing policy of a group of routes
+type CORSPolicy struct {
+	AllowOrigins     []string // Exact origins, "https://*.example.com" for subdomains, or "*" for any
+	AllowMethods     []string
+	AllowHeaders     []string // Empty allows the headers a preflight asks for
+	ExposeHeaders    []string // Response headers scripts may read
+	AllowCredentials bool     // Allow cookies and Authorization; not allowed with "*"
+	MaxAge           time.Duration
+}
+
+// CORSRoute overrides the default policy under PathPrefix
+type CORSRoute struct {
+	PathPrefix string
+	Policy     CORSPolicy
+}
+
+// CORSConfig holds the default policy and the per-route overrides
+type CORSConfig struct {
+	Default CORSPolicy
+	Routes  []CORSRoute
+}
+
+// LoadCORSConfigFromEnv reads the CORS configuration from environment variables. Routes override
+// the lists they name and inherit the rest from the default policy.
+//
+//	CORS_ALLOW_ORIGINS="https://app.example.com,https://*.example.com"   (default *)
+//	CORS_ALLOW_METHODS="GET,POST,PUT,DELETE"                             (default GET,POST,HEAD,PUT,DELETE,PATCH)
+//	CORS_ALLOW_HEADERS="Content-Type,Authorization"                      (default: whatever the preflight asks for)
+//	CORS_EXPOSE_HEADERS="X-Total-Count"                                  (default X-Total-Count,Link,X-API-Version,X-Request-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,Retry-After,Idempotent-Replayed)
+//	CORS_ALLOW_CREDENTIALS=true                                          (default false)
+//	CORS_MAX_AGE=10m                                                     (default 0, the browser's default)
+//	CORS_ROUTES="/admin|origins=https://admin.example.com|credentials=true;/api/v1/public|origins=*|credentials=false"
+func LoadCORSConfigFromEnv() (CORSConfig, error) {
+	credentials, err := strconv.ParseBool(utils.GetEnv("CORS_ALLOW_CREDENTIALS", "false"))
+	if err != nil {
+		return CORSConfig{}, fmt.Errorf("CORS_ALLOW_CREDENTIALS: %w", err)
+	}
+	maxAge, err := time.ParseDuration(utils.GetEnv("CORS_MAX_AGE", "0s"))
+	if err != nil {
+		return CORSConfig{}, fmt.Errorf("CORS_MAX_AGE: %w", err)
+	}
+	cfg := CORSConfig{Default: CORSPolicy{
+		AllowOrigins:     splitAndTrim(utils.GetEnv("CORS_ALLOW_ORIGINS", "*"), ","),
+		AllowMethods:     splitAndTrim(utils.GetEnv("CORS_ALLOW_METHODS", "GET,POST,HEAD,PUT,DELETE,PATCH"), ","),
+		AllowHeaders:     splitAndTrim(utils.GetEnv("CORS_ALLOW_HEADERS", ""), ","),
+		ExposeHeaders:    splitAndTrim(utils.GetEnv("CORS_EXPOSE_HEADERS", "X-Total-Count,Link,X-API-Version,X-Request-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,Retry-After,Idempotent-Replayed"), ","),
+		AllowCredentials: credentials,
+		MaxAge:           maxAge,
+	}}
+
+	for _, group := range splitAndTrim(utils.GetEnv("CORS_ROUTES", ""), ";") {
+		parts := strings.Split(group, "|")
+		route := CORSRoute{PathPrefix: strings.TrimSpace(parts[0]), Policy: cfg.Default}
+		if route.PathPrefix == "" {
+			return CORSConfig{}, fmt.Errorf("cors route %q has no path prefix", group)
+		}
+		for _, part := range parts[1:] {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return CORSConfig{}, fmt.Errorf("cors route %q: expected key=value, got %q", group, part)
+			}
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(key) {
+			case "origins":
+				route.Policy.AllowOrigins = splitAndTrim(value, ",")
+			case "methods":
+				route.Policy.AllowMethods = splitAndTrim(value, ",")
+			case "headers":
+				route.Policy.AllowHeaders = splitAndTrim(value, ",")
+			case "expose":
+				route.Policy.ExposeHeaders = splitAndTrim(value, ",")
+			case "credentials":
+				if route.Policy.AllowCredentials, err = strconv.ParseBool(value); err != nil {
+					return CORSConfig{}, fmt.Errorf("cors route %q: credentials: %w", group, err)
+				}
+			case "max_age":
+				if route.Policy.MaxAge, err = time.ParseDuration(value); err != nil {
+					return CORSConfig{}, fmt.Errorf("cors route %q: max_age: %w", group, err)
+				}
+			default:
+				return CORSConfig{}, fmt.Errorf("cors route %q: unknown setting %q", group, key)
+			}
+		}
+		cfg.Routes = append(cfg.Routes, route)
+	}
+
+	return cfg, nil
+}
+
+// Validate rejects policies browsers would refuse or that would be unsafe: credentials with a
+// wildcard origin, malformed origins, and "*" mixed with other origins
+func (p CORSPolicy) Validate() error {
+	if len(p.AllowOrigins) == 0 {
+		return fmt.Errorf("no allowed origins")
+	}
+	for _, origin := range p.AllowOrigins {
+		if origin == "*" {
+			if len(p.AllowOrigins) > 1 {
+				return fmt.Errorf("wildcard origin \"*\" cannot be combined with other origins")
+			}
+			if p.AllowCredentials {
+				return fmt.Errorf("credentials cannot be allowed for the wildcard origin \"*\"")
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return err
+		}
+	}
+	if p.MaxAge < 0 {
+		return fmt.Errorf("negative max age %s", p.MaxAge)
+	}
+	return nil
+}
+
+// validateOrigin checks that origin is a scheme and host, with an optional "*." subdomain wildcard
+func validateOrigin(origin string) error {
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		strings.Contains(u.Host, "*") || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid origin %q: expected scheme://host[:port]", origin)
+	}
+	return nil
+}
+
+// corsRoute is a route override with its handler
+type corsRoute struct {
+	prefix  string
+	handler fiber.Handler
+}
+
+// CORS applies the default policy, or the override with the longest matching path prefix
+type CORS struct {
+	routes   []corsRoute // sorted by prefix length, longest first
+	fallback fiber.Handler
+}
 
-		// Handle preflight requests
-		if c.Method() == fiber.MethodOptions {
-			return c.SendStatus(fiber.StatusNoContent)
+// NewCORS validates the configuration and creates the middleware
+func NewCORS(cfg CORSConfig) (*CORS, error) {
+	if err := cfg.Default.Validate(); err != nil {
+		return nil, fmt.Errorf("cors: %w", err)
+	}
+	c := &CORS{fallback: cors.New(cfg.Default.fiberConfig())}
+	for _, r := range cfg.Routes {
+		if err := r.Policy.Validate(); err != nil {
+			return nil, fmt.Errorf("cors %s: %w", r.PathPrefix, err)
 		}
+		c.routes = append(c.routes, corsRoute{prefix: r.PathPrefix, handler: cors.New(r.Policy.fiberConfig())})
+	}
+	sort.SliceStable(c.routes, func(i, j int) bool { return len(c.routes[i].prefix) > len(c.routes[j].prefix) })
+	return c, nil
+}
 
-		return c.Next()
+// Middleware returns the Fiber handler applying the policies
+func (c *CORS) Middleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		path := ctx.Path()
+		for _, r := range c.routes {
+			if strings.HasPrefix(path, r.prefix) {
+				return r.handler(ctx)
+			}
+		}
+		return c.fallback(ctx)
 	}
 }
 
-// how to add above cors to allow https
-// add the following to the middleware
-// c.Set("Access-Control-Allow-Origin", "*")
-// c.Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-// c.Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// fiberConfig converts a validated policy to the configuration of Fiber's CORS middleware
+func (p CORSPolicy) fiberConfig() cors.Config {
+	return cors.Config{
+		AllowOrigins:     strings.Join(p.AllowOrigins, ","),
+		AllowMethods:     strings.Join(p.AllowMethods, ","),
+		AllowHeaders:     strings.Join(p.AllowHeaders, ","),
+		ExposeHeaders:    strings.Join(p.ExposeHeaders, ","),
+		AllowCredentials: p.AllowCredentials,
+		MaxAge:           int(p.MaxAge / time.Second),
+	}
+}
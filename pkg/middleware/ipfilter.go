@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang-microservices-boilerplate/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IPFilterRule restricts requests under PathPrefix by client IP.
+// Deny ranges always win; if Allow is non-empty the client must match one of its ranges.
+type IPFilterRule struct {
+	PathPrefix string
+	Allow      []string // CIDRs or single IPs, e.g. "10.0.0.0/8", "192.168.1.10"
+	Deny       []string
+}
+
+// IPFilterConfig holds the rules and the proxies whose X-Forwarded-For header is trusted.
+type IPFilterConfig struct {
+	Rules          []IPFilterRule
+	TrustedProxies []string
+}
+
+// LoadIPFilterConfigFromEnv reads the IP filter configuration from environment variables.
+//
+//	IP_FILTER_RULES="/api/v1/admin|allow=10.0.0.0/8,192.168.0.0/16|deny=10.0.5.0/24;/metrics|allow=127.0.0.1"
+//	IP_FILTER_TRUSTED_PROXIES="10.0.0.0/8"
+func LoadIPFilterConfigFromEnv() (IPFilterConfig, error) {
+	cfg := IPFilterConfig{
+		TrustedProxies: splitAndTrim(utils.GetEnv("IP_FILTER_TRUSTED_PROXIES", ""), ","),
+	}
+
+	for _, group := range splitAndTrim(utils.GetEnv("IP_FILTER_RULES", ""), ";") {
+		parts := strings.Split(group, "|")
+		rule := IPFilterRule{PathPrefix: strings.TrimSpace(parts[0])}
+		if rule.PathPrefix == "" {
+			return IPFilterConfig{}, fmt.Errorf("ip filter rule %q has no path prefix", group)
+		}
+		for _, part := range parts[1:] {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return IPFilterConfig{}, fmt.Errorf("ip filter rule %q: expected allow=... or deny=...", group)
+			}
+			switch strings.TrimSpace(key) {
+			case "allow":
+				rule.Allow = append(rule.Allow, splitAndTrim(value, ",")...)
+			case "deny":
+				rule.Deny = append(rule.Deny, splitAndTrim(value, ",")...)
+			default:
+				return IPFilterConfig{}, fmt.Errorf("ip filter rule %q: unknown list %q", group, key)
+			}
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+
+	return cfg, nil
+}
+
+// compiledIPRule is an IPFilterRule with parsed networks
+type compiledIPRule struct {
+	prefix string // Lowercased, as Fiber routes paths case-insensitively
+	allow  []*net.IPNet
+	deny   []*net.IPNet
+}
+
+// IPFilter enforces CIDR allow/deny lists per route group. Rules can be swapped at runtime with Reload.
+type IPFilter struct {
+	mu      sync.RWMutex
+	rules   []compiledIPRule // sorted by prefix length, longest first
+	trusted []*net.IPNet
+}
+
+// NewIPFilter creates a new IPFilter from the given configuration.
+func NewIPFilter(cfg IPFilterConfig) (*IPFilter, error) {
+	f := &IPFilter{}
+	if err := f.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload validates and atomically replaces the active rules. On error the previous rules stay in effect.
+func (f *IPFilter) Reload(cfg IPFilterConfig) error {
+	rules := make([]compiledIPRule, 0, len(cfg.Rules))
+	for _, r := range cfg.Rules {
+		allow, err := parseCIDRs(r.Allow)
+		if err != nil {
+			return fmt.Errorf("ip filter %s: %w", r.PathPrefix, err)
+		}
+		deny, err := parseCIDRs(r.Deny)
+		if err != nil {
+			return fmt.Errorf("ip filter %s: %w", r.PathPrefix, err)
+		}
+		rules = append(rules, compiledIPRule{prefix: strings.ToLower(r.PathPrefix), allow: allow, deny: deny})
+	}
+	// Longest prefix wins so that /api/v1/admin/x is governed by /api/v1/admin rather than /api
+	sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].prefix) > len(rules[j].prefix) })
+
+	trusted, err := parseCIDRs(cfg.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("ip filter trusted proxies: %w", err)
+	}
+
+	f.mu.Lock()
+	f.rules = rules
+	f.trusted = trusted
+	f.mu.Unlock()
+	return nil
+}
+
+// Middleware returns the Fiber handler enforcing the filter.
+func (f *IPFilter) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		f.mu.RLock()
+		rule := f.matchRule(strings.ToLower(c.Path()))
+		trusted := f.trusted
+		f.mu.RUnlock()
+
+		if rule == nil {
+			return c.Next()
+		}
+
+		ip := clientIP(c, trusted)
+		if ip == nil || !rule.permits(ip) {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"error": "access denied from this address",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// ClientIP returns the real client address of a request, honouring X-Forwarded-For from the
+// trusted proxies like the filter itself
+func (f *IPFilter) ClientIP(c *fiber.Ctx) net.IP {
+	f.mu.RLock()
+	trusted := f.trusted
+	f.mu.RUnlock()
+	return clientIP(c, trusted)
+}
+
+// matchRule returns the most specific rule for the lowercased path; caller must hold the read lock
+func (f *IPFilter) matchRule(path string) *compiledIPRule {
+	for i := range f.rules {
+		if hasPathPrefix(path, f.rules[i].prefix) {
+			return &f.rules[i]
+		}
+	}
+	return nil
+}
+
+// hasPathPrefix reports whether path is prefix or lies below it, matching whole segments, so that
+// the prefix /api/v1/admin covers /api/v1/admin/users but not /api/v1/administrators
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
+
+// permits reports whether ip may access the rule's route group
+func (r *compiledIPRule) permits(ip net.IP) bool {
+	if containsIP(r.deny, ip) {
+		return false
+	}
+	if len(r.allow) == 0 {
+		return true
+	}
+	return containsIP(r.allow, ip)
+}
+
+// clientIP resolves the real client address. X-Forwarded-For is only honoured when the direct peer
+// is a trusted proxy, and is walked right-to-left skipping further trusted hops.
+func clientIP(c *fiber.Ctx, trusted []*net.IPNet) net.IP {
+	remote := net.ParseIP(c.Context().RemoteIP().String())
+	if remote == nil || !containsIP(trusted, remote) {
+		return remote
+	}
+
+	forwarded := strings.Split(c.Get(fiber.HeaderXForwardedFor), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			break
+		}
+		if !containsIP(trusted, ip) {
+			return ip
+		}
+	}
+	return remote
+}
+
+// parseCIDRs parses CIDR strings, accepting bare IPs as single-host networks
+func parseCIDRs(values []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(values))
+	for _, v := range values {
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP %q", v)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", v, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAndTrim splits s by sep, trimming whitespace and dropping empty entries
+func splitAndTrim(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestIPFilterMatchesPathCaseInsensitively checks that a rule cannot be bypassed by changing the
+// case of the path, which Fiber still routes to the same handler
+func TestIPFilterMatchesPathCaseInsensitively(t *testing.T) {
+	filter, err := NewIPFilter(IPFilterConfig{Rules: []IPFilterRule{{PathPrefix: "/Admin", Allow: []string{"10.0.0.1"}}}})
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+	app := fiber.New()
+	app.Use(filter.Middleware())
+	app.Get("/admin/x", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+	app.Get("/public", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	for path, want := range map[string]int{
+		"/admin/x": http.StatusForbidden,
+		"/ADMIN/x": http.StatusForbidden,
+		"/Admin/X": http.StatusForbidden,
+		"/public":  http.StatusOK,
+	} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("GET %s returned %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/httpclient"
+)
+
+// jwksMinRefreshInterval limits refetches triggered by unknown key IDs, so tokens with made-up
+// kids cannot make the service hammer the JWKS endpoint
+const jwksMinRefreshInterval = time.Minute
+
+// JWKSKeySet serves public keys fetched from a JWKS URL, cached for a TTL and refreshed early
+// when a token names a key ID that is not cached yet (the issuer rotated its keys).
+type JWKSKeySet struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	fetchedAt   time.Time // Last successful fetch
+	lastAttempt time.Time // Last fetch, successful or not
+}
+
+// NewJWKSKeySet creates a key set for url; keys are fetched on first use
+func NewJWKSKeySet(url string, ttl time.Duration) *JWKSKeySet {
+	config := httpclient.LoadConfigFromEnv("jwks")
+	config.Timeout = 5 * time.Second // Token validation waits on the fetch
+	return &JWKSKeySet{
+		url:    url,
+		ttl:    ttl,
+		client: httpclient.New(config, nil),
+		keys:   make(map[string]interface{}),
+	}
+}
+
+// Key returns the public key (*rsa.PublicKey or *ecdsa.PublicKey) with the given key ID
+func (s *JWKSKeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	s.mu.RLock()
+	key, ok := s.keys[kid]
+	fresh := time.Since(s.fetchedAt) < s.ttl
+	throttled := time.Since(s.lastAttempt) < jwksMinRefreshInterval
+	s.mu.RUnlock()
+
+	if ok && (fresh || throttled) {
+		return key, nil
+	}
+	if !ok && throttled {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		if ok {
+			return key, nil // Keep serving the cached key while the endpoint is unavailable
+		}
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// jwk is one JSON Web Key; only the members needed for RSA and EC public keys are decoded
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refresh fetches the key set and replaces the cache
+func (s *JWKSKeySet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	s.lastAttempt = time.Now()
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid JWKS URL: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("invalid JWKS document: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue // Skip key types we cannot use rather than rejecting the whole set
+		}
+		keys[k.Kid] = key
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+// publicKey decodes an RSA or EC JWK
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeJWKInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJWKInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeJWKInt decodes a base64url big-endian integer
+func decodeJWKInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK integer: %w", err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
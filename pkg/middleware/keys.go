@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// TokenValidationConfig controls which access tokens are accepted beyond a valid signature
+type TokenValidationConfig struct {
+	Issuers    []string          // Accepted "iss" values; empty accepts any issuer
+	Audiences  []string          // Accepted "aud" values, any one must match; empty skips the check
+	Algorithms []string          // Accepted signing algorithms (HS256, RS256, ES256, ...)
+	SigningKID string            // Key ID written to issued tokens; names the current ACCESS_TOKEN_SECRET
+	HMACKeys   map[string][]byte // Additional shared secrets by key ID, e.g. the previous secret during rotation
+	JWKS       *JWKSKeySet       // Public keys for asymmetric tokens; nil when JWT_JWKS_URL is unset
+	Audience   string            // "aud" written to issued tokens
+}
+
+// LoadTokenValidationConfigFromEnv reads the token validation settings.
+//
+//	JWT_ISSUERS=https://auth.example.com,wqimKMT   accepted issuers (default: any)
+//	JWT_AUDIENCES=api                               accepted audiences (default: any)
+//	JWT_AUDIENCE=api                                audience of issued tokens (default: first of JWT_AUDIENCES)
+//	JWT_ALGORITHMS=HS256,RS256                      accepted algorithms (default HS256, plus RS256/ES256 with a JWKS URL)
+//	JWT_SIGNING_KID=2024-06                         kid header of issued tokens (default none)
+//	JWT_ACCESS_TOKEN_KEYS=2024-01=oldsecret         extra HS256 secrets by kid, comma separated
+//	JWT_JWKS_URL=https://auth.example.com/.well-known/jwks.json
+//	JWT_JWKS_CACHE_TTL=10m
+func LoadTokenValidationConfigFromEnv() TokenValidationConfig {
+	cfg := TokenValidationConfig{
+		Issuers:    splitList(utils.GetEnv("JWT_ISSUERS", "")),
+		Audiences:  splitList(utils.GetEnv("JWT_AUDIENCES", "")),
+		SigningKID: utils.GetEnv("JWT_SIGNING_KID", ""),
+		HMACKeys:   make(map[string][]byte),
+	}
+	cfg.Audience = utils.GetEnv("JWT_AUDIENCE", "")
+	if cfg.Audience == "" && len(cfg.Audiences) > 0 {
+		cfg.Audience = cfg.Audiences[0]
+	}
+
+	for _, pair := range splitList(utils.GetEnv("JWT_ACCESS_TOKEN_KEYS", "")) {
+		if kid, secret, ok := strings.Cut(pair, "="); ok && kid != "" && secret != "" {
+			cfg.HMACKeys[kid] = []byte(secret)
+		}
+	}
+
+	defaultAlgorithms := "HS256"
+	if url := utils.GetEnv("JWT_JWKS_URL", ""); url != "" {
+		cfg.JWKS = NewJWKSKeySet(url, utils.GetEnvDuration("JWT_JWKS_CACHE_TTL", 10*time.Minute))
+		defaultAlgorithms = "HS256,RS256,ES256"
+	}
+	cfg.Algorithms = splitList(utils.GetEnv("JWT_ALGORITHMS", defaultAlgorithms))
+	return cfg
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+var (
+	tokenValidationMu  sync.RWMutex
+	tokenValidation    TokenValidationConfig
+	tokenValidationSet bool
+)
+
+// SetTokenValidationConfig replaces the settings used by access token validation and issuance.
+// Without a call, they are loaded from the environment on first use.
+func SetTokenValidationConfig(cfg TokenValidationConfig) {
+	tokenValidationMu.Lock()
+	defer tokenValidationMu.Unlock()
+	tokenValidation, tokenValidationSet = cfg, true
+}
+
+// currentTokenValidation returns the active settings
+func currentTokenValidation() TokenValidationConfig {
+	tokenValidationMu.RLock()
+	if tokenValidationSet {
+		defer tokenValidationMu.RUnlock()
+		return tokenValidation
+	}
+	tokenValidationMu.RUnlock()
+
+	tokenValidationMu.Lock()
+	defer tokenValidationMu.Unlock()
+	if !tokenValidationSet {
+		tokenValidation, tokenValidationSet = LoadTokenValidationConfigFromEnv(), true
+	}
+	return tokenValidation
+}
+
+// keyFunc selects the verification key of a token: by kid among the shared secrets (no kid or the
+// signing kid meaning secret), or from the JWKS for RSA and ECDSA tokens
+func (cfg TokenValidationConfig) keyFunc(secret string) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if kid == "" || kid == cfg.SigningKID {
+				return []byte(secret), nil
+			}
+			if key, ok := cfg.HMACKeys[kid]; ok {
+				return key, nil
+			}
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			if cfg.JWKS == nil {
+				return nil, errors.New("asymmetric tokens require JWT_JWKS_URL")
+			}
+			key, err := cfg.JWKS.Key(context.Background(), kid)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case *rsa.PublicKey:
+				if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+					return key, nil
+				}
+			case *ecdsa.PublicKey:
+				if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+					return key, nil
+				}
+			}
+			return nil, errors.New("signing method does not match key type")
+
+		default:
+			return nil, errors.New("unexpected signing method")
+		}
+	}
+}
+
+// checkClaims validates issuer and audience
+func (cfg TokenValidationConfig) checkClaims(claims *UserClaims) error {
+	if len(cfg.Issuers) > 0 && !slices.Contains(cfg.Issuers, claims.Issuer) {
+		return errors.New("invalid token issuer")
+	}
+	if len(cfg.Audiences) > 0 && !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(cfg.Audiences, aud)
+	}) {
+		return errors.New("invalid token audience")
+	}
+	return nil
+}
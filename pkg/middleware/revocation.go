@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// RevocationList reports tokens revoked before their expiry, by JWT ID (e.g. *cache.TokenBlacklist)
+type RevocationList interface {
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+var revocationList atomic.Pointer[RevocationList]
+
+// SetRevocationList makes token validation reject tokens whose ID is on list. Without a list, or for
+// tokens without an ID, only the signature and expiry are checked.
+func SetRevocationList(list RevocationList) {
+	if list == nil {
+		revocationList.Store(nil)
+		return
+	}
+	revocationList.Store(&list)
+}
+
+// isRevoked reports whether the token with the given ID was revoked. A failing lookup counts as not
+// revoked, so an unavailable cache does not sign everybody out; revocation only shortens lifetimes.
+func isRevoked(ctx context.Context, tokenID string) bool {
+	list := revocationList.Load()
+	if list == nil || tokenID == "" {
+		return false
+	}
+	revoked, err := (*list).IsRevoked(ctx, tokenID)
+	return err == nil && revoked
+}
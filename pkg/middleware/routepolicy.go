@@ -0,0 +1,318 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/permissions"
+)
+
+// RoutePolicy declares who may call a route. Path segments written as {param} match any single segment.
+// A policy is either Public, or requires a valid access token and, if Roles is non-empty, one of those
+// roles and, if Permission is set, a role granting it.
+type RoutePolicy struct {
+	Method     string
+	Path       string
+	Public     bool
+	Roles      []string
+	Permission permissions.Permission
+	// Params declares the format of path parameters, e.g. {"id": ParamUUID}. Requests whose
+	// parameters do not match are rejected with 400 instead of being forwarded.
+	Params map[string]string
+	// Passthrough skips the gateway's schema validation of the request body and query, for
+	// routes whose body is not a single JSON document (e.g. streamed uploads)
+	Passthrough bool
+	// StreamResponse sends the response to the client while the service produces it instead of
+	// buffering it first, for server-streaming RPCs
+	StreamResponse bool
+	// Owner names the path parameter holding the ID of the user the resource belongs to. That user
+	// may access the route even without one of Roles or the Permission.
+	Owner string
+}
+
+// Path parameter formats for RoutePolicy.Params
+const (
+	ParamUUID = "uuid" // RFC 4122 UUID
+	ParamInt  = "int"  // Base 10 integer
+	ParamDate = "date" // Date as YYYY-MM-DD
+)
+
+// paramFormats validates the values of each path parameter format and describes it for error messages
+var paramFormats = map[string]struct {
+	valid       func(string) bool
+	description string
+}{
+	ParamUUID: {func(v string) bool { _, err := uuid.Parse(v); return err == nil }, "a valid UUID"},
+	ParamInt:  {func(v string) bool { _, err := strconv.ParseInt(v, 10, 64); return err == nil }, "an integer"},
+	ParamDate: {func(v string) bool { _, err := time.Parse(time.DateOnly, v); return err == nil }, "a date (YYYY-MM-DD)"},
+}
+
+// ParamError reports a path parameter that does not match its declared format
+type ParamError struct {
+	Name   string
+	Value  string
+	Format string
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("invalid path parameter %q: %q is not %s", e.Name, e.Value, paramFormats[e.Format].description)
+}
+
+// ValidateParams checks the parameters of a request path matching the policy against their declared formats
+func (p *RoutePolicy) ValidateParams(path string) error {
+	if len(p.Params) == 0 {
+		return nil
+	}
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range strings.Split(strings.Trim(p.Path, "/"), "/") {
+		if !isPathParam(part) || i >= len(pathParts) {
+			continue
+		}
+		name := strings.Trim(part, "{}")
+		format, ok := p.Params[name]
+		if !ok {
+			continue
+		}
+		value, err := url.PathUnescape(pathParts[i])
+		if err != nil {
+			value = pathParts[i]
+		}
+		if err != nil || !paramFormats[format].valid(value) {
+			return &ParamError{Name: name, Value: value, Format: format}
+		}
+	}
+	return nil
+}
+
+// OwnedBy reports whether the request path matching the policy names userID in its Owner parameter
+func (p *RoutePolicy) OwnedBy(path string, userID uuid.UUID) bool {
+	if p.Owner == "" || userID == uuid.Nil {
+		return false
+	}
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range strings.Split(strings.Trim(p.Path, "/"), "/") {
+		if part != "{"+p.Owner+"}" || i >= len(pathParts) {
+			continue
+		}
+		value, err := url.PathUnescape(pathParts[i])
+		if err != nil {
+			return false
+		}
+		owner, err := uuid.Parse(value)
+		return err == nil && owner == userID
+	}
+	return false
+}
+
+// AllowsRole reports whether a caller with the given role satisfies the policy
+func (p *RoutePolicy) AllowsRole(role string) bool {
+	if p.Public {
+		return true
+	}
+	if len(p.Roles) > 0 && !slices.Contains(p.Roles, strings.ToLower(role)) {
+		return false
+	}
+	return p.Permission.IsZero() || permissions.Has(role, p.Permission)
+}
+
+// Route identifies a registered HTTP route
+type Route struct {
+	Method string
+	Path   string
+}
+
+// String returns the route as "METHOD /path"
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// RoutePolicyTable is an ordered set of route policies. The first policy matching a request wins.
+type RoutePolicyTable struct {
+	policies []RoutePolicy
+}
+
+// NewRoutePolicyTable creates a new RoutePolicyTable from the given policies. It panics if a policy
+// declares a parameter its path does not have, or a format other than ParamUUID, ParamInt or ParamDate.
+func NewRoutePolicyTable(policies ...RoutePolicy) *RoutePolicyTable {
+	t := &RoutePolicyTable{}
+	for _, p := range policies {
+		if p.Owner != "" && !strings.Contains(p.Path, "{"+p.Owner+"}") {
+			panic(fmt.Sprintf("route policy %s %s declares unknown owner parameter %q", p.Method, p.Path, p.Owner))
+		}
+		for name, format := range p.Params {
+			if !strings.Contains(p.Path, "{"+name+"}") {
+				panic(fmt.Sprintf("route policy %s %s declares unknown path parameter %q", p.Method, p.Path, name))
+			}
+			if _, ok := paramFormats[format]; !ok {
+				panic(fmt.Sprintf("route policy %s %s declares unknown format %q for %q", p.Method, p.Path, format, name))
+			}
+		}
+		p.Method = strings.ToUpper(p.Method)
+		roles := make([]string, len(p.Roles))
+		for i, role := range p.Roles {
+			roles[i] = strings.ToLower(role)
+		}
+		p.Roles = roles
+		t.policies = append(t.policies, p)
+	}
+	return t
+}
+
+// Match returns the policy that applies to method and path, or nil if none does
+func (t *RoutePolicyTable) Match(method, path string) *RoutePolicy {
+	method = strings.ToUpper(method)
+	for i := range t.policies {
+		if t.policies[i].Method == method && matchPathPattern(t.policies[i].Path, path) {
+			return &t.policies[i]
+		}
+	}
+	return nil
+}
+
+// Uncovered returns the routes that have no explicit policy in the table
+func (t *RoutePolicyTable) Uncovered(routes []Route) []Route {
+	var missing []Route
+	for _, r := range routes {
+		if t.matchPattern(r.Method, r.Path) == nil {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// matchPattern finds the policy declared for a route template such as /api/v1/users/{id}
+func (t *RoutePolicyTable) matchPattern(method, pattern string) *RoutePolicy {
+	method = strings.ToUpper(method)
+	for i := range t.policies {
+		if t.policies[i].Method == method && samePathPattern(t.policies[i].Path, pattern) {
+			return &t.policies[i]
+		}
+	}
+	return nil
+}
+
+// RoutePolicyMiddleware enforces the policy table. Requests to routes without a policy are denied,
+// so forgetting to declare a new endpoint fails closed.
+func RoutePolicyMiddleware(table *RoutePolicyTable, config ...JWTConfig) fiber.Handler {
+	cfg := DefaultJWTConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return func(c *fiber.Ctx) error {
+		policy := table.Match(c.Method(), c.Path())
+		if policy == nil {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"error": "no access policy for route",
+			})
+		}
+		if policy.Public {
+			return validatePathParams(c, policy)
+		}
+
+		token, err := extractToken(c, cfg)
+		if err != nil {
+			return cfg.ErrorHandler(c, err)
+		}
+		claims, err := validateAccessToken(token, cfg.AccessTokenSecret)
+		if err != nil {
+			return cfg.ErrorHandler(c, err)
+		}
+		c.Locals(cfg.ContextKey, claims)
+
+		typed, err := claims.Claims()
+		if err != nil {
+			return cfg.ErrorHandler(c, err)
+		}
+		c.SetUserContext(logger.WithFields(c.UserContext(), "user_id", claims.Subject))
+		if !policy.AllowsRole(typed.Role) && !policy.OwnedBy(c.Path(), typed.UserID) {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"error": "insufficient permissions",
+			})
+		}
+		return validatePathParams(c, policy)
+	}
+}
+
+// validatePathParams rejects a request whose path parameters do not match the policy's formats,
+// so the backend never sees them, and otherwise passes it on
+func validatePathParams(c *fiber.Ctx, policy *RoutePolicy) error {
+	var paramErr *ParamError
+	if err := policy.ValidateParams(c.Path()); errors.As(err, &paramErr) {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error":  paramErr.Error(),
+			"param":  paramErr.Name,
+			"format": paramErr.Format,
+		})
+	}
+	return c.Next()
+}
+
+// ValidateRouteCoverage returns an error listing every route that lacks an explicit policy
+func ValidateRouteCoverage(table *RoutePolicyTable, routes []Route) error {
+	missing := table.Uncovered(routes)
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, len(missing))
+	for i, r := range missing {
+		names[i] = r.String()
+	}
+	return fmt.Errorf("%w: %s", ErrRouteWithoutPolicy, strings.Join(names, ", "))
+}
+
+// ErrRouteWithoutPolicy is returned when a registered route has no declared policy
+var ErrRouteWithoutPolicy = errors.New("routes without access policy")
+
+// matchPathPattern reports whether a concrete request path matches a policy pattern
+func matchPathPattern(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if isPathParam(part) {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// samePathPattern compares two route templates, treating all {param} segments as equal
+func samePathPattern(a, b string) bool {
+	aParts := strings.Split(strings.Trim(a, "/"), "/")
+	bParts := strings.Split(strings.Trim(b, "/"), "/")
+	if len(aParts) != len(bParts) {
+		return false
+	}
+	for i := range aParts {
+		if isPathParam(aParts[i]) && isPathParam(bParts[i]) {
+			continue
+		}
+		if aParts[i] != bParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TestRoutePolicyAllowsOwner checks that an Owner route admits the user named in the path as well
+// as the policy's roles, and nobody else
+func TestRoutePolicyAllowsOwner(t *testing.T) {
+	table := NewRoutePolicyTable(RoutePolicy{Method: "GET", Path: "/users/{userId}/events", Roles: []string{"admin"}, Owner: "userId"})
+	app := fiber.New()
+	app.Use(RoutePolicyMiddleware(table))
+	app.Get("/users/:userId/events", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	self, other := uuid.New(), uuid.New()
+	token := func(id uuid.UUID, role string) string {
+		t.Helper()
+		claims := Claims{UserID: id, Email: "user@example.com", Role: role}
+		token, err := GenerateToken(claims.Encode(), time.Minute, DefaultJWTConfig.AccessTokenSecret)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		return token
+	}
+
+	for _, tt := range []struct {
+		name  string
+		token string
+		path  uuid.UUID
+		want  int
+	}{
+		{"own events", token(self, "user"), self, http.StatusOK},
+		{"another user's events", token(self, "user"), other, http.StatusForbidden},
+		{"admin", token(self, "admin"), other, http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+tt.path.String()+"/events", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, resp.StatusCode, tt.want)
+		}
+	}
+}
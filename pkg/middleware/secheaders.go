@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SwaggerPathPrefix is where the gateway serves the Swagger UI, which gets the relaxed profile
+const SwaggerPathPrefix = "/swagger"
+
+// Default Content-Security-Policy values: API responses are JSON and load nothing, while the
+// Swagger UI needs its own scripts, inline styles and data: images
+const (
+	defaultAPIContentSecurityPolicy     = "default-src 'none'; frame-ancestors 'none'"
+	defaultSwaggerContentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'self'"
+)
+
+// SecurityHeadersPolicy lists the security headers of a group of routes; empty values are not sent
+type SecurityHeadersPolicy struct {
+	HSTSMaxAge            time.Duration // Strict-Transport-Security max-age; zero disables HSTS
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	ContentSecurityPolicy string
+	FrameOptions          string // X-Frame-Options: DENY or SAMEORIGIN
+	ReferrerPolicy        string
+	PermissionsPolicy     string
+}
+
+// SecurityHeadersRoute overrides the default policy under PathPrefix
+type SecurityHeadersRoute struct {
+	PathPrefix string
+	Policy     SecurityHeadersPolicy
+}
+
+// SecurityHeadersConfig holds the default policy and the per-route overrides
+type SecurityHeadersConfig struct {
+	Enabled bool
+	Default SecurityHeadersPolicy
+	Routes  []SecurityHeadersRoute
+}
+
+// DefaultSecurityHeadersConfig returns the built-in configuration: HSTS for a year including
+// subdomains, a CSP loading nothing, no framing, no referrer and no camera, microphone or
+// location access. The Swagger UI under SwaggerPathPrefix gets the relaxed CSP and SAMEORIGIN framing.
+func DefaultSecurityHeadersConfig() SecurityHeadersConfig {
+	policy := SecurityHeadersPolicy{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: defaultAPIContentSecurityPolicy,
+		FrameOptions:          "DENY",
+		ReferrerPolicy:        "no-referrer",
+		PermissionsPolicy:     "camera=(), microphone=(), geolocation=()",
+	}
+	return SecurityHeadersConfig{Enabled: true, Default: policy, Routes: []SecurityHeadersRoute{swaggerSecurityHeaders(policy, defaultSwaggerContentSecurityPolicy)}}
+}
+
+// swaggerSecurityHeaders derives the Swagger UI profile from the default policy
+func swaggerSecurityHeaders(policy SecurityHeadersPolicy, csp string) SecurityHeadersRoute {
+	policy.ContentSecurityPolicy = csp
+	policy.FrameOptions = "SAMEORIGIN"
+	return SecurityHeadersRoute{PathPrefix: SwaggerPathPrefix, Policy: policy}
+}
+
+// LoadSecurityHeadersConfigFromEnv reads the security headers configuration from environment
+// variables, falling back to DefaultSecurityHeadersConfig for unset ones.
+//
+//	SECURITY_HEADERS_ENABLED=true
+//	SECURITY_HSTS_MAX_AGE=8760h  SECURITY_HSTS_INCLUDE_SUBDOMAINS=true  SECURITY_HSTS_PRELOAD=false
+//	SECURITY_CSP="default-src 'none'; frame-ancestors 'none'"
+//	SECURITY_SWAGGER_CSP="default-src 'self'; script-src 'self' 'unsafe-inline'; ..."
+//	SECURITY_FRAME_OPTIONS=DENY  SECURITY_REFERRER_POLICY=no-referrer
+//	SECURITY_PERMISSIONS_POLICY="camera=(), microphone=(), geolocation=()"
+func LoadSecurityHeadersConfigFromEnv() (SecurityHeadersConfig, error) {
+	defaults := DefaultSecurityHeadersConfig()
+	cfg := SecurityHeadersConfig{Default: defaults.Default}
+	var err error
+	bools := []struct {
+		key    string
+		target *bool
+		def    bool
+	}{
+		{"SECURITY_HEADERS_ENABLED", &cfg.Enabled, defaults.Enabled},
+		{"SECURITY_HSTS_INCLUDE_SUBDOMAINS", &cfg.Default.HSTSIncludeSubdomains, defaults.Default.HSTSIncludeSubdomains},
+		{"SECURITY_HSTS_PRELOAD", &cfg.Default.HSTSPreload, defaults.Default.HSTSPreload},
+	}
+	for _, b := range bools {
+		if *b.target, err = strconv.ParseBool(utils.GetEnv(b.key, strconv.FormatBool(b.def))); err != nil {
+			return SecurityHeadersConfig{}, fmt.Errorf("%s: %w", b.key, err)
+		}
+	}
+	if cfg.Default.HSTSMaxAge, err = time.ParseDuration(utils.GetEnv("SECURITY_HSTS_MAX_AGE", defaults.Default.HSTSMaxAge.String())); err != nil {
+		return SecurityHeadersConfig{}, fmt.Errorf("SECURITY_HSTS_MAX_AGE: %w", err)
+	}
+	cfg.Default.ContentSecurityPolicy = utils.GetEnv("SECURITY_CSP", defaults.Default.ContentSecurityPolicy)
+	cfg.Default.FrameOptions = strings.ToUpper(utils.GetEnv("SECURITY_FRAME_OPTIONS", defaults.Default.FrameOptions))
+	cfg.Default.ReferrerPolicy = utils.GetEnv("SECURITY_REFERRER_POLICY", defaults.Default.ReferrerPolicy)
+	cfg.Default.PermissionsPolicy = utils.GetEnv("SECURITY_PERMISSIONS_POLICY", defaults.Default.PermissionsPolicy)
+	cfg.Routes = []SecurityHeadersRoute{swaggerSecurityHeaders(cfg.Default, utils.GetEnv("SECURITY_SWAGGER_CSP", defaultSwaggerContentSecurityPolicy))}
+
+	return cfg, nil
+}
+
+// Validate rejects values browsers would ignore or misread
+func (p SecurityHeadersPolicy) Validate() error {
+	if p.HSTSMaxAge < 0 {
+		return fmt.Errorf("negative HSTS max age %s", p.HSTSMaxAge)
+	}
+	if p.HSTSPreload && (p.HSTSMaxAge < 365*24*time.Hour || !p.HSTSIncludeSubdomains) {
+		return fmt.Errorf("HSTS preload requires a max age of at least one year and includeSubDomains")
+	}
+	switch p.FrameOptions {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		return fmt.Errorf("invalid X-Frame-Options %q: expected DENY or SAMEORIGIN", p.FrameOptions)
+	}
+	for name, value := range map[string]string{"Content-Security-Policy": p.ContentSecurityPolicy, "Referrer-Policy": p.ReferrerPolicy, "Permissions-Policy": p.PermissionsPolicy} {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("%s contains a line break", name)
+		}
+	}
+	return nil
+}
+
+// headers returns the header values of the policy, in a fixed order
+func (p SecurityHeadersPolicy) headers() [][2]string {
+	headers := [][2]string{{fiber.HeaderXContentTypeOptions, "nosniff"}}
+	if p.HSTSMaxAge > 0 {
+		hsts := "max-age=" + strconv.FormatInt(int64(p.HSTSMaxAge/time.Second), 10)
+		if p.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if p.HSTSPreload {
+			hsts += "; preload"
+		}
+		headers = append(headers, [2]string{fiber.HeaderStrictTransportSecurity, hsts})
+	}
+	for _, h := range [][2]string{
+		{fiber.HeaderContentSecurityPolicy, p.ContentSecurityPolicy},
+		{fiber.HeaderXFrameOptions, p.FrameOptions},
+		{fiber.HeaderReferrerPolicy, p.ReferrerPolicy},
+		{fiber.HeaderPermissionsPolicy, p.PermissionsPolicy},
+	} {
+		if h[1] != "" {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+// securityHeadersRoute is a route override with its rendered headers
+type securityHeadersRoute struct {
+	prefix  string
+	headers [][2]string
+}
+
+// SecurityHeaders sets the default policy's headers, or those of the override with the longest
+// matching path prefix, on every response. Handlers may still replace them.
+type SecurityHeaders struct {
+	enabled  bool
+	routes   []securityHeadersRoute // sorted by prefix length, longest first
+	fallback [][2]string
+}
+
+// NewSecurityHeaders validates the configuration and creates the middleware
+func NewSecurityHeaders(cfg SecurityHeadersConfig) (*SecurityHeaders, error) {
+	if err := cfg.Default.Validate(); err != nil {
+		return nil, fmt.Errorf("security headers: %w", err)
+	}
+	s := &SecurityHeaders{enabled: cfg.Enabled, fallback: cfg.Default.headers()}
+	for _, r := range cfg.Routes {
+		if err := r.Policy.Validate(); err != nil {
+			return nil, fmt.Errorf("security headers %s: %w", r.PathPrefix, err)
+		}
+		s.routes = append(s.routes, securityHeadersRoute{prefix: r.PathPrefix, headers: r.Policy.headers()})
+	}
+	sort.SliceStable(s.routes, func(i, j int) bool { return len(s.routes[i].prefix) > len(s.routes[j].prefix) })
+	return s, nil
+}
+
+// DefaultSecurityHeaders creates the middleware with DefaultSecurityHeadersConfig
+func DefaultSecurityHeaders() *SecurityHeaders {
+	s, err := NewSecurityHeaders(DefaultSecurityHeadersConfig())
+	if err != nil {
+		panic(err) // The built-in defaults are valid
+	}
+	return s
+}
+
+// Middleware returns the Fiber handler setting the headers
+func (s *SecurityHeaders) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !s.enabled {
+			return c.Next()
+		}
+		headers := s.fallback
+		path := c.Path()
+		for _, r := range s.routes {
+			if strings.HasPrefix(path, r.prefix) {
+				headers = r.headers
+				break
+			}
+		}
+		for _, h := range headers {
+			c.Set(h[0], h[1])
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// TransformRule is a config-driven request/response transformation for routes under PathPrefix.
+// Field renames and envelopes apply to top-level fields of JSON bodies.
+type TransformRule struct {
+	Method          string            `json:"method,omitempty"` // Empty matches any method
+	PathPrefix      string            `json:"path_prefix"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`  // Set before the request is proxied
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"` // Set on the response
+	RequestRename   map[string]string `json:"request_rename,omitempty"`   // Old field name -> new field name
+	ResponseRename  map[string]string `json:"response_rename,omitempty"`
+	UnwrapRequest   string            `json:"unwrap_request,omitempty"` // Replace the body with body[UnwrapRequest]
+	WrapResponse    string            `json:"wrap_response,omitempty"`  // Replace the body with {WrapResponse: body}
+}
+
+// TransformHook is a Go-defined transformation. Either function may be nil.
+// TransformRequest runs before the request reaches the handler; TransformResponse after it returns.
+type TransformHook struct {
+	Method            string
+	PathPrefix        string
+	TransformRequest  func(c *fiber.Ctx) error
+	TransformResponse func(c *fiber.Ctx) error
+}
+
+// LoadTransformRules reads rules from a YAML or JSON file (a list of TransformRule)
+func LoadTransformRules(path string) ([]TransformRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transform rules %s: %w", path, err)
+	}
+	var rules []TransformRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse transform rules %s: %w", path, err)
+	}
+	for i, r := range rules {
+		if r.PathPrefix == "" {
+			return nil, fmt.Errorf("transform rule %d in %s has no path_prefix", i, path)
+		}
+	}
+	return rules, nil
+}
+
+// Transformer applies transformation rules and hooks to matching routes
+type Transformer struct {
+	mu    sync.RWMutex
+	rules []TransformRule
+	hooks []TransformHook
+}
+
+// NewTransformer creates a new Transformer with the given config rules
+func NewTransformer(rules ...TransformRule) *Transformer {
+	return &Transformer{rules: rules}
+}
+
+// AddHook registers a Go-defined transformation
+func (t *Transformer) AddHook(hook TransformHook) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.hooks = append(t.hooks, hook)
+}
+
+// Middleware returns the Fiber handler applying the transformations.
+// Request transformations run in declaration order, response transformations in reverse order.
+func (t *Transformer) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rules, hooks := t.match(c.Method(), c.Path())
+		if len(rules) == 0 && len(hooks) == 0 {
+			return c.Next()
+		}
+
+		for _, r := range rules {
+			if err := applyRequestRule(c, r); err != nil {
+				return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		for _, h := range hooks {
+			if h.TransformRequest != nil {
+				if err := h.TransformRequest(c); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		for i := len(hooks) - 1; i >= 0; i-- {
+			if hooks[i].TransformResponse != nil {
+				if err := hooks[i].TransformResponse(c); err != nil {
+					return err
+				}
+			}
+		}
+		for i := len(rules) - 1; i >= 0; i-- {
+			if err := applyResponseRule(c, rules[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// match returns the rules and hooks for a request
+func (t *Transformer) match(method, path string) ([]TransformRule, []TransformHook) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var rules []TransformRule
+	for _, r := range t.rules {
+		if routeMatches(r.Method, r.PathPrefix, method, path) {
+			rules = append(rules, r)
+		}
+	}
+	var hooks []TransformHook
+	for _, h := range t.hooks {
+		if routeMatches(h.Method, h.PathPrefix, method, path) {
+			hooks = append(hooks, h)
+		}
+	}
+	return rules, hooks
+}
+
+func routeMatches(ruleMethod, prefix, method, path string) bool {
+	return (ruleMethod == "" || strings.EqualFold(ruleMethod, method)) && strings.HasPrefix(path, prefix)
+}
+
+// applyRequestRule applies the request side of a rule
+func applyRequestRule(c *fiber.Ctx, r TransformRule) error {
+	for k, v := range r.RequestHeaders {
+		c.Request().Header.Set(k, v)
+	}
+	if len(r.RequestRename) == 0 && r.UnwrapRequest == "" {
+		return nil
+	}
+	if len(c.Body()) == 0 || !strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEApplicationJSON) {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(c.Body(), &m); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	// Unwrap first so renames apply to the payload the backend receives
+	if r.UnwrapRequest != "" {
+		inner, ok := m[r.UnwrapRequest].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("request body must contain object %q", r.UnwrapRequest)
+		}
+		m = inner
+	}
+	renameFields(m, r.RequestRename)
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	c.Request().SetBody(body)
+	return nil
+}
+
+// applyResponseRule applies the response side of a rule
+func applyResponseRule(c *fiber.Ctx, r TransformRule) error {
+	for k, v := range r.ResponseHeaders {
+		c.Set(k, v)
+	}
+	if len(r.ResponseRename) == 0 && r.WrapResponse == "" {
+		return nil
+	}
+	// Streamed responses are not a single document, and reading them would buffer the stream
+	if c.Response().IsBodyStream() || !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(c.Response().Body(), &m); err != nil {
+		// Leave non-object responses untouched
+		return nil
+	}
+	renameFields(m, r.ResponseRename)
+
+	var out interface{} = m
+	if r.WrapResponse != "" {
+		out = map[string]interface{}{r.WrapResponse: m}
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	c.Response().SetBody(body)
+	return nil
+}
+
+// renameFields renames top-level fields of a JSON object in place
+func renameFields(m map[string]interface{}, rename map[string]string) {
+	for from, to := range rename {
+		if v, ok := m[from]; ok {
+			delete(m, from)
+			m[to] = v
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package testkit
+
+import (
+	"context"
+	"fmt"
+
+	user_pb "golang-microservices-boilerplate/proto/user-service"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyUserServiceContract runs the behaviour that callers of the user service rely on against
+// client. Run it against both the fake and a real deployment to keep them in sync.
+// The client must be allowed to create and delete users.
+func VerifyUserServiceContract(ctx context.Context, client user_pb.UserServiceClient) error {
+	fixture := NewUserBuilder()
+	req := fixture.CreateRequest()
+
+	created, err := client.Create(ctx, req)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	id := created.GetUser().GetId()
+	if id == "" || created.GetUser().GetEmail() != req.Email {
+		return fmt.Errorf("create: unexpected user %v", created.GetUser())
+	}
+
+	if _, err := client.Create(ctx, req); !hasCode(err, codes.AlreadyExists) {
+		return fmt.Errorf("create duplicate: expected %s, got %v", codes.AlreadyExists, err)
+	}
+
+	got, err := client.GetByID(ctx, &user_pb.GetUserByIDRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("get by id: %w", err)
+	}
+	if got.GetUser().GetUsername() != req.Username {
+		return fmt.Errorf("get by id: expected username %q, got %q", req.Username, got.GetUser().GetUsername())
+	}
+
+	if _, err := client.GetByID(ctx, &user_pb.GetUserByIDRequest{Id: uuid.NewString()}); !hasCode(err, codes.NotFound) {
+		return fmt.Errorf("get missing: expected %s, got %v", codes.NotFound, err)
+	}
+	if _, err := client.GetByID(ctx, &user_pb.GetUserByIDRequest{Id: "not-a-uuid"}); !hasCode(err, codes.InvalidArgument) {
+		return fmt.Errorf("get invalid id: expected %s, got %v", codes.InvalidArgument, err)
+	}
+
+	login, err := client.Login(ctx, &user_pb.LoginRequest{Email: req.Email, Password: req.Password})
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	if login.GetAccessToken() == "" || login.GetRefreshToken() == "" {
+		return fmt.Errorf("login: missing tokens")
+	}
+	if _, err := client.Login(ctx, &user_pb.LoginRequest{Email: req.Email, Password: req.Password + "x"}); !hasCode(err, codes.Unauthenticated) {
+		return fmt.Errorf("login wrong password: expected %s, got %v", codes.Unauthenticated, err)
+	}
+
+	refreshed, err := client.Refresh(ctx, &user_pb.RefreshRequest{RefreshToken: login.GetRefreshToken()})
+	if err != nil {
+		return fmt.Errorf("refresh: %w", err)
+	}
+	if refreshed.GetAccessToken() == "" {
+		return fmt.Errorf("refresh: missing access token")
+	}
+
+	if _, err := client.Delete(ctx, &user_pb.DeleteUserRequest{Id: id, HardDelete: true}); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	if _, err := client.GetByID(ctx, &user_pb.GetUserByIDRequest{Id: id}); !hasCode(err, codes.NotFound) {
+		return fmt.Errorf("get deleted: expected %s, got %v", codes.NotFound, err)
+	}
+
+	return nil
+}
+
+// hasCode reports whether err carries the given gRPC status code
+func hasCode(err error, code codes.Code) bool {
+	return err != nil && status.Code(err) == code
+}
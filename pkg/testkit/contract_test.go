@@ -0,0 +1,22 @@
+package testkit_test
+
+import (
+	"context"
+	"testing"
+
+	"golang-microservices-boilerplate/pkg/testkit"
+)
+
+// TestFakeUserServiceContract keeps the fake in line with the behaviour callers rely on; the user
+// service runs the same contract against the real implementation.
+func TestFakeUserServiceContract(t *testing.T) {
+	_, client, cleanup, err := testkit.StartFakeUserService()
+	if err != nil {
+		t.Fatalf("failed to start fake user service: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	if err := testkit.VerifyUserServiceContract(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+}
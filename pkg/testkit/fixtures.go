@@ -0,0 +1,103 @@
+package testkit
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	user_pb "golang-microservices-boilerplate/proto/user-service"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultFixturePassword is the password given to users built by UserBuilder unless overridden
+const DefaultFixturePassword = "password123"
+
+var fixtureSeq atomic.Int64
+
+// UserBuilder builds user fixtures with unique, valid defaults
+type UserBuilder struct {
+	user     *user_pb.User
+	password string
+}
+
+// NewUserBuilder creates a builder for an active officer with a unique username and email
+func NewUserBuilder() *UserBuilder {
+	n := fixtureSeq.Add(1)
+	now := timestamppb.New(time.Now())
+	return &UserBuilder{
+		user: &user_pb.User{
+			Id:        uuid.NewString(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Username:  fmt.Sprintf("user%d", n),
+			Email:     fmt.Sprintf("user%d@example.com", n),
+			FirstName: "Test",
+			LastName:  fmt.Sprintf("User%d", n),
+			Role:      "officer",
+			IsActive:  true,
+		},
+		password: DefaultFixturePassword,
+	}
+}
+
+// WithID sets the user ID
+func (b *UserBuilder) WithID(id string) *UserBuilder {
+	b.user.Id = id
+	return b
+}
+
+// WithEmail sets the email address
+func (b *UserBuilder) WithEmail(email string) *UserBuilder {
+	b.user.Email = email
+	return b
+}
+
+// WithUsername sets the username
+func (b *UserBuilder) WithUsername(username string) *UserBuilder {
+	b.user.Username = username
+	return b
+}
+
+// WithRole sets the role (admin, manager or officer)
+func (b *UserBuilder) WithRole(role string) *UserBuilder {
+	b.user.Role = role
+	return b
+}
+
+// WithPassword sets the password accepted by the fake Login
+func (b *UserBuilder) WithPassword(password string) *UserBuilder {
+	b.password = password
+	return b
+}
+
+// Inactive marks the user as inactive
+func (b *UserBuilder) Inactive() *UserBuilder {
+	b.user.IsActive = false
+	return b
+}
+
+// Admin is shorthand for WithRole("admin")
+func (b *UserBuilder) Admin() *UserBuilder {
+	return b.WithRole("admin")
+}
+
+// Build returns the user fixture
+func (b *UserBuilder) Build() *user_pb.User {
+	return b.user
+}
+
+// CreateRequest returns a CreateUserRequest matching the fixture
+func (b *UserBuilder) CreateRequest() *user_pb.CreateUserRequest {
+	return &user_pb.CreateUserRequest{
+		Username:  b.user.Username,
+		Email:     b.user.Email,
+		Password:  b.password,
+		FirstName: b.user.FirstName,
+		LastName:  b.user.LastName,
+		Role:      b.user.Role,
+		IsActive:  proto.Bool(b.user.IsActive),
+	}
+}
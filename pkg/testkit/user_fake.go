@@ -0,0 +1,478 @@
+package testkit
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/dto"
+	"golang-microservices-boilerplate/pkg/middleware"
+	core_pb "golang-microservices-boilerplate/proto/core"
+	user_pb "golang-microservices-boilerplate/proto/user-service"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FakeUserService is an in-memory implementation of the user service gRPC API.
+// It mirrors the real service's status codes (HTTP-style codes via status.Error) and issues
+// real JWTs signed with middleware.DefaultJWTConfig, so gateway auth accepts them.
+type FakeUserService struct {
+	user_pb.UnimplementedUserServiceServer
+
+	mu        sync.RWMutex
+	users     map[string]*user_pb.User
+	passwords map[string]string // user ID -> password
+	revoked   map[string]bool   // Refresh token IDs revoked by Logout
+}
+
+// NewFakeUserService creates a new FakeUserService seeded with the given fixtures
+func NewFakeUserService(fixtures ...*UserBuilder) *FakeUserService {
+	f := &FakeUserService{
+		users:     make(map[string]*user_pb.User),
+		passwords: make(map[string]string),
+		revoked:   make(map[string]bool),
+	}
+	for _, b := range fixtures {
+		f.Seed(b)
+	}
+	return f
+}
+
+// Seed stores a fixture directly, bypassing validation
+func (f *FakeUserService) Seed(b *UserBuilder) *user_pb.User {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u := proto.Clone(b.user).(*user_pb.User)
+	f.users[u.Id] = u
+	f.passwords[u.Id] = b.password
+	return proto.Clone(u).(*user_pb.User)
+}
+
+// Register attaches the fake to a gRPC server
+func (f *FakeUserService) Register(s *grpc.Server) {
+	user_pb.RegisterUserServiceServer(s, f)
+}
+
+// StartFakeUserService starts the fake on an in-memory server and returns a connected client.
+// The returned cleanup function closes the connection and the server.
+func StartFakeUserService(fixtures ...*UserBuilder) (*FakeUserService, user_pb.UserServiceClient, func(), error) {
+	fake := NewFakeUserService(fixtures...)
+	srv := NewBufconnServer(fake.Register)
+	conn, err := srv.Dial()
+	if err != nil {
+		srv.Close()
+		return nil, nil, nil, err
+	}
+	cleanup := func() {
+		_ = conn.Close()
+		srv.Close()
+	}
+	return fake, user_pb.NewUserServiceClient(conn), cleanup, nil
+}
+
+// Create creates a user, rejecting duplicate emails/usernames like the real service
+func (f *FakeUserService) Create(ctx context.Context, req *user_pb.CreateUserRequest) (*user_pb.CreateUserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, err := f.createLocked(req)
+	if err != nil {
+		return nil, err
+	}
+	return &user_pb.CreateUserResponse{User: proto.Clone(u).(*user_pb.User)}, nil
+}
+
+func (f *FakeUserService) createLocked(req *user_pb.CreateUserRequest) (*user_pb.User, error) {
+	if req.Email == "" || req.Username == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "username, email and password are required")
+	}
+	for _, existing := range f.users {
+		if strings.EqualFold(existing.Email, req.Email) || existing.Username == req.Username {
+			return nil, status.Error(codes.AlreadyExists, "user with this email or username already exists")
+		}
+	}
+
+	now := timestamppb.New(time.Now())
+	u := &user_pb.User{
+		Id:        uuid.NewString(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Username:  req.Username,
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Role:      req.Role,
+		IsActive:  req.IsActive == nil || *req.IsActive,
+		Phone:     req.GetPhone(),
+		Address:   req.GetAddress(),
+		Age:       req.GetAge(),
+	}
+	if u.Role == "" {
+		u.Role = "officer"
+	}
+	f.users[u.Id] = u
+	f.passwords[u.Id] = req.Password
+	return u, nil
+}
+
+// GetByID returns a user by ID
+func (f *FakeUserService) GetByID(ctx context.Context, req *user_pb.GetUserByIDRequest) (*user_pb.GetUserByIDResponse, error) {
+	if _, err := uuid.Parse(req.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	u, ok := f.users[req.Id]
+	if !ok || u.DeletedAt != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &user_pb.GetUserByIDResponse{User: proto.Clone(u).(*user_pb.User)}, nil
+}
+
+// List returns a page of non-deleted users
+func (f *FakeUserService) List(ctx context.Context, req *user_pb.ListUsersRequest) (*user_pb.ListUsersResponse, error) {
+	users, info := f.page(req.Options)
+	return &user_pb.ListUsersResponse{Users: users, PaginationInfo: info}, nil
+}
+
+// FindWithFilter returns users whose fields equal the given filters
+func (f *FakeUserService) FindWithFilter(ctx context.Context, req *user_pb.FindUsersWithFilterRequest) (*user_pb.FindUsersWithFilterResponse, error) {
+	users, info := f.page(req.Options)
+	return &user_pb.FindUsersWithFilterResponse{Users: users, PaginationInfo: info}, nil
+}
+
+// Update applies the fields selected by the update mask of req to a user, or its non-empty fields
+// when there is no mask
+func (f *FakeUserService) Update(ctx context.Context, req *user_pb.UpdateUserRequest) (*user_pb.UpdateUserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[req.Id]
+	if !ok || u.DeletedAt != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	paths, err := dto.UpdateMaskPaths(req, req.GetUpdateMask(), "id", "update_mask")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fields := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "password" {
+			f.passwords[u.Id] = req.Password
+			continue
+		}
+		fields = append(fields, path)
+	}
+	if len(fields) > 0 {
+		if err := dto.ApplyFieldMask(req, &fieldmaskpb.FieldMask{Paths: fields}, u); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	u.UpdatedAt = timestamppb.New(time.Now())
+
+	return &user_pb.UpdateUserResponse{User: proto.Clone(u).(*user_pb.User)}, nil
+}
+
+// Delete soft or hard deletes a user
+func (f *FakeUserService) Delete(ctx context.Context, req *user_pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.deleteLocked(req.Id, req.HardDelete); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (f *FakeUserService) deleteLocked(id string, hard bool) error {
+	u, ok := f.users[id]
+	if !ok || u.DeletedAt != nil {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if hard {
+		delete(f.users, id)
+		delete(f.passwords, id)
+		return nil
+	}
+	u.DeletedAt = timestamppb.New(time.Now())
+	return nil
+}
+
+// Restore undoes a soft delete
+func (f *FakeUserService) Restore(ctx context.Context, req *user_pb.RestoreUserRequest) (*user_pb.RestoreUserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[req.Id]
+	if !ok || u.DeletedAt == nil {
+		return nil, status.Error(codes.NotFound, "no deleted user with this ID")
+	}
+	u.DeletedAt = nil
+	u.UpdatedAt = timestamppb.New(time.Now())
+	return &user_pb.RestoreUserResponse{User: proto.Clone(u).(*user_pb.User)}, nil
+}
+
+// CreateMany creates each user independently, reporting the ones that fail
+func (f *FakeUserService) CreateMany(ctx context.Context, req *user_pb.CreateUsersRequest) (*user_pb.CreateUsersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &user_pb.CreateUsersResponse{Users: []*user_pb.User{}, Result: &core_pb.BulkResult{Total: int32(len(req.Users))}}
+	for i, r := range req.Users {
+		u, err := f.createLocked(r)
+		if err != nil {
+			resp.Result.Failed = append(resp.Result.Failed, &core_pb.BatchFailure{Index: int32(i), Reason: err.Error()})
+			continue
+		}
+		resp.Users = append(resp.Users, proto.Clone(u).(*user_pb.User))
+		resp.Result.SucceededIds = append(resp.Result.SucceededIds, u.Id)
+	}
+	resp.Result.SucceededCount, resp.Result.FailedCount = int32(len(resp.Result.SucceededIds)), int32(len(resp.Result.Failed))
+	return resp, nil
+}
+
+// CreateUsersStream creates the streamed users one by one, reporting the ones that fail
+func (f *FakeUserService) CreateUsersStream(stream grpc.ClientStreamingServer[user_pb.CreateUserRequest, user_pb.CreateUsersStreamResponse]) error {
+	resp := &user_pb.CreateUsersStreamResponse{}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+		index := resp.Received
+		resp.Received++
+
+		f.mu.Lock()
+		u, err := f.createLocked(req)
+		f.mu.Unlock()
+		if err != nil {
+			resp.Failures = append(resp.Failures, &core_pb.BatchFailure{Index: index, Reason: err.Error()})
+			continue
+		}
+		resp.CreatedIds = append(resp.CreatedIds, u.Id)
+	}
+}
+
+// DeleteMany deletes each given user, reporting unknown IDs
+func (f *FakeUserService) DeleteMany(ctx context.Context, req *user_pb.DeleteUsersRequest) (*user_pb.DeleteUsersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := &core_pb.BulkResult{Total: int32(len(req.Ids))}
+	for i, id := range req.Ids {
+		if err := f.deleteLocked(id, req.HardDelete); err != nil {
+			result.Failed = append(result.Failed, &core_pb.BatchFailure{Index: int32(i), Reason: err.Error()})
+			continue
+		}
+		result.SucceededIds = append(result.SucceededIds, id)
+	}
+	result.SucceededCount, result.FailedCount = int32(len(result.SucceededIds)), int32(len(result.Failed))
+	return &user_pb.DeleteUsersResponse{Result: result}, nil
+}
+
+// Login checks credentials and issues a token pair
+func (f *FakeUserService) Login(ctx context.Context, req *user_pb.LoginRequest) (*user_pb.LoginResponse, error) {
+	f.mu.RLock()
+	var u *user_pb.User
+	for _, candidate := range f.users {
+		if candidate.DeletedAt == nil && strings.EqualFold(candidate.Email, req.Email) {
+			u = candidate
+			break
+		}
+	}
+	var password string
+	if u != nil {
+		password = f.passwords[u.Id]
+	}
+	f.mu.RUnlock()
+
+	if u == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if !u.IsActive {
+		return nil, status.Error(codes.Unauthenticated, "user account is inactive")
+	}
+	if password != req.Password {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	access, refresh, expiresAt, err := issueTokens(u)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate authentication tokens")
+	}
+	return &user_pb.LoginResponse{User: proto.Clone(u).(*user_pb.User), AccessToken: access, RefreshToken: refresh, ExpiresAt: expiresAt}, nil
+}
+
+// Refresh validates a refresh token and issues a new access token
+func (f *FakeUserService) Refresh(ctx context.Context, req *user_pb.RefreshRequest) (*user_pb.RefreshResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "refresh token cannot be empty")
+	}
+	claims, err := middleware.ValidateRefreshToken(req.RefreshToken, middleware.DefaultJWTConfig.RefreshTokenSecret)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	f.mu.RLock()
+	u, ok := f.users[claims.Subject]
+	revoked := f.revoked[claims.ID]
+	f.mu.RUnlock()
+	if !ok || u.DeletedAt != nil || revoked {
+		return nil, status.Error(codes.Unauthenticated, "invalid user session")
+	}
+	if !u.IsActive {
+		return nil, status.Error(codes.Unauthenticated, "user account is inactive")
+	}
+
+	access, _, expiresAt, err := issueTokens(u)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to refresh access token")
+	}
+	return &user_pb.RefreshResponse{AccessToken: access, RefreshToken: req.RefreshToken, ExpiresAt: expiresAt}, nil
+}
+
+// Logout revokes a refresh token
+func (f *FakeUserService) Logout(ctx context.Context, req *user_pb.LogoutRequest) (*emptypb.Empty, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "refresh token cannot be empty")
+	}
+	claims, err := middleware.ValidateRefreshToken(req.RefreshToken, middleware.DefaultJWTConfig.RefreshTokenSecret)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user session")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revoked[claims.ID] = true
+	return &emptypb.Empty{}, nil
+}
+
+// Introspect reports whether an access or refresh token is active; tokens of unknown, deleted or
+// inactive users and revoked refresh tokens are inactive
+func (f *FakeUserService) Introspect(ctx context.Context, req *user_pb.IntrospectRequest) (*user_pb.IntrospectResponse, error) {
+	if req.Token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "token cannot be empty")
+	}
+	tokenType := "access_token"
+	claims, err := middleware.ValidateAccessToken(req.Token, middleware.DefaultJWTConfig.AccessTokenSecret)
+	if err != nil {
+		tokenType = "refresh_token"
+		if claims, err = middleware.ValidateRefreshToken(req.Token, middleware.DefaultJWTConfig.RefreshTokenSecret); err != nil {
+			return &user_pb.IntrospectResponse{Active: false}, nil
+		}
+	}
+	typed, err := claims.Claims()
+	if err != nil {
+		return &user_pb.IntrospectResponse{Active: false}, nil
+	}
+
+	f.mu.RLock()
+	u, ok := f.users[claims.Subject]
+	revoked := f.revoked[claims.ID]
+	f.mu.RUnlock()
+	if !ok || u.DeletedAt != nil || revoked || !u.IsActive {
+		return &user_pb.IntrospectResponse{Active: false}, nil
+	}
+
+	resp := &user_pb.IntrospectResponse{
+		Active:    true,
+		TokenType: tokenType,
+		UserId:    typed.UserID.String(),
+		Email:     typed.Email,
+		Role:      typed.Role,
+		OrgRole:   typed.OrgRole,
+		Scopes:    typed.Scopes,
+		TokenId:   claims.ID,
+		Issuer:    claims.Issuer,
+		Audience:  claims.Audience,
+	}
+	if typed.TenantID != uuid.Nil {
+		resp.TenantId = typed.TenantID.String()
+	}
+	if claims.IssuedAt != nil {
+		resp.IssuedAt = claims.IssuedAt.Unix()
+	}
+	if claims.ExpiresAt != nil {
+		resp.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	return resp, nil
+}
+
+// page applies equality filters, sorting and pagination to the non-deleted users
+func (f *FakeUserService) page(opts *core_pb.FilterOptions) ([]*user_pb.User, *core_pb.PaginationInfo) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	matched := make([]*user_pb.User, 0, len(f.users))
+	for _, u := range f.users {
+		if u.DeletedAt == nil && matchesFilters(u, opts.GetFilters()) {
+			matched = append(matched, u)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		less := matched[i].CreatedAt.AsTime().Before(matched[j].CreatedAt.AsTime())
+		if opts.GetSortDesc() {
+			return !less
+		}
+		return less
+	})
+
+	limit, offset := int(opts.GetLimit()), int(opts.GetOffset())
+	if limit <= 0 {
+		limit = 10
+	}
+	info := &core_pb.PaginationInfo{TotalItems: int64(len(matched)), Limit: int32(limit), Offset: int32(offset)}
+
+	users := make([]*user_pb.User, 0, limit)
+	for i := offset; i < len(matched) && len(users) < limit; i++ {
+		users = append(users, proto.Clone(matched[i]).(*user_pb.User))
+	}
+	return users, info
+}
+
+// matchesFilters compares the supported string/bool fields against the filter values
+func matchesFilters(u *user_pb.User, filters map[string]*structpb.Value) bool {
+	for key, v := range filters {
+		switch key {
+		case "email":
+			if !strings.EqualFold(u.Email, v.GetStringValue()) {
+				return false
+			}
+		case "username":
+			if u.Username != v.GetStringValue() {
+				return false
+			}
+		case "role":
+			if u.Role != v.GetStringValue() {
+				return false
+			}
+		case "is_active":
+			if u.IsActive != v.GetBoolValue() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// issueTokens creates a token pair with the same claims shape as the real service
+func issueTokens(u *user_pb.User) (string, string, int64, error) {
+	id, err := uuid.Parse(u.Id)
+	if err != nil {
+		return "", "", 0, err
+	}
+	claims := middleware.Claims{UserID: id, Email: u.Email, Role: u.Role}.Encode()
+	cfg := middleware.DefaultJWTConfig
+	return middleware.GenerateTokenPair(claims, cfg.ExpirationTime, 7*24*time.Hour, cfg.AccessTokenSecret, cfg.RefreshTokenSecret)
+}
@@ -1,8 +1,14 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,31 +22,203 @@ func LoadEnv(path ...string) error {
 	return godotenv.Load(path...)
 }
 
+// ReloadEnv re-reads environment variables from a file path (default .env), overriding values already set.
+// Used for runtime config reloads (e.g. on SIGHUP).
+func ReloadEnv(path ...string) error {
+	if len(path) == 0 {
+		path = []string{".env"}
+	}
+	return godotenv.Overload(path...)
+}
+
 // GetEnv retrieves an environment variable or returns a default value
 func GetEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
+	value, exists := os.LookupEnv(key)
+	recordEnvRead(key, value, defaultValue, exists, nil)
+	if exists {
 		return value
 	}
 	return defaultValue
 }
 
-// GetEnvAsInt retrieves an environment variable as an integer or returns a default value
-func GetEnvAsInt(key string, defaultValue int) int {
-	if value, exists := os.LookupEnv(key); exists {
-		var result int
-		if _, err := fmt.Sscanf(value, "%d", &result); err == nil {
-			return result
-		}
+// MustGetEnv retrieves a required environment variable and panics when it is not set or empty
+func MustGetEnv(key string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		recordEnvRead(key, "", "", false, errors.New("required but not set"))
+		panic(fmt.Sprintf("required environment variable %s is not set", key))
 	}
-	return defaultValue
+	recordEnvRead(key, value, "", true, nil)
+	return value
+}
+
+// GetEnvInt retrieves an environment variable as an integer. An unset, empty or invalid value
+// returns the default; invalid values are listed by EnvSnapshot.
+func GetEnvInt(key string, defaultValue int) int {
+	return getEnvAs(key, defaultValue, strconv.Atoi)
+}
+
+// GetEnvAsInt is GetEnvInt under its older name
+func GetEnvAsInt(key string, defaultValue int) int {
+	return GetEnvInt(key, defaultValue)
+}
+
+// GetEnvBool retrieves an environment variable as a boolean: 1, t, true, 0, f, false in any case
+func GetEnvBool(key string, defaultValue bool) bool {
+	return getEnvAs(key, defaultValue, strconv.ParseBool)
 }
 
 // GetEnvDuration gets a duration from environment variable or returns the default value
 func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
-	if value, exists := os.LookupEnv(key); exists {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
+	return getEnvAs(key, defaultValue, time.ParseDuration)
+}
+
+// GetEnvURL retrieves an environment variable as an absolute URL, falling back to defaultValue.
+// It returns nil when neither is a valid URL, e.g. with an empty default.
+func GetEnvURL(key, defaultValue string) *url.URL {
+	raw := getEnvAs(key, defaultValue, func(value string) (string, error) {
+		_, err := parseAbsoluteURL(value)
+		return value, err
+	})
+	u, err := parseAbsoluteURL(raw)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+func parseAbsoluteURL(value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", value)
+	}
+	return u, nil
+}
+
+// getEnvAs parses an environment variable, returning defaultValue when it is unset, empty or invalid
+func getEnvAs[T any](key string, defaultValue T, parse func(string) (T, error)) T {
+	value := os.Getenv(key)
+	if value == "" {
+		recordEnvRead(key, "", fmt.Sprint(defaultValue), false, nil)
+		return defaultValue
+	}
+	parsed, err := parse(strings.TrimSpace(value))
+	recordEnvRead(key, value, fmt.Sprint(defaultValue), true, err)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// EnvScope reads variables sharing a prefix, e.g. NewEnvScope("DB_CONNECT").Int("ATTEMPTS", 10)
+// reads DB_CONNECT_ATTEMPTS
+type EnvScope struct {
+	prefix string
+}
+
+// NewEnvScope creates a scope for variables named <prefix>_<name>
+func NewEnvScope(prefix string) EnvScope {
+	return EnvScope{prefix: strings.TrimSuffix(prefix, "_")}
+}
+
+// Key returns the full name of a variable of the scope
+func (s EnvScope) Key(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "_" + name
+}
+
+// Scope returns a nested scope, e.g. NewEnvScope("API").Scope("V1") for API_V1_*
+func (s EnvScope) Scope(name string) EnvScope {
+	return EnvScope{prefix: s.Key(name)}
+}
+
+// Get is GetEnv within the scope
+func (s EnvScope) Get(name, defaultValue string) string {
+	return GetEnv(s.Key(name), defaultValue)
+}
+
+// Must is MustGetEnv within the scope
+func (s EnvScope) Must(name string) string {
+	return MustGetEnv(s.Key(name))
+}
+
+// Int is GetEnvInt within the scope
+func (s EnvScope) Int(name string, defaultValue int) int {
+	return GetEnvInt(s.Key(name), defaultValue)
+}
+
+// Bool is GetEnvBool within the scope
+func (s EnvScope) Bool(name string, defaultValue bool) bool {
+	return GetEnvBool(s.Key(name), defaultValue)
+}
+
+// Duration is GetEnvDuration within the scope
+func (s EnvScope) Duration(name string, defaultValue time.Duration) time.Duration {
+	return GetEnvDuration(s.Key(name), defaultValue)
+}
+
+// URL is GetEnvURL within the scope
+func (s EnvScope) URL(name, defaultValue string) *url.URL {
+	return GetEnvURL(s.Key(name), defaultValue)
+}
+
+// EnvRead is an environment variable read through this package
+type EnvRead struct {
+	Key     string `json:"key"`
+	Set     bool   `json:"set"`
+	Value   string `json:"value,omitempty"`   // Redacted for secrets
+	Default string `json:"default,omitempty"` // Redacted for secrets
+	Invalid string `json:"invalid,omitempty"` // Why the value was rejected in favour of the default
+}
+
+// sensitiveEnvKeys are the name fragments of variables whose values EnvSnapshot hides
+var sensitiveEnvKeys = []string{"SECRET", "PASSWORD", "TOKEN", "KEY", "CREDENTIAL", "URI", "DSN", "WEBHOOK"}
+
+var envReads = struct {
+	sync.Mutex
+	reads map[string]EnvRead
+}{reads: map[string]EnvRead{}}
+
+func recordEnvRead(key, value, defaultValue string, set bool, err error) {
+	read := EnvRead{Key: key, Set: set, Value: value, Default: defaultValue}
+	if err != nil {
+		read.Invalid = err.Error()
+	}
+	upper := strings.ToUpper(key)
+	for _, fragment := range sensitiveEnvKeys {
+		if strings.Contains(upper, fragment) {
+			read.Value, read.Default, read.Invalid = redactEnv(read.Value), redactEnv(read.Default), redactEnv(read.Invalid)
+			break
 		}
 	}
-	return defaultValue
+
+	envReads.Lock()
+	envReads.reads[key] = read
+	envReads.Unlock()
+}
+
+func redactEnv(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// EnvSnapshot lists the environment variables the process has read through this package, sorted
+// by name, with the latest value read and whether it fell back to the default. Secret values are
+// redacted. The diagnostics server serves it at /debug/env.
+func EnvSnapshot() []EnvRead {
+	envReads.Lock()
+	reads := make([]EnvRead, 0, len(envReads.reads))
+	for _, read := range envReads.reads {
+		reads = append(reads, read)
+	}
+	envReads.Unlock()
+	sort.Slice(reads, func(i, j int) bool { return reads[i].Key < reads[j].Key })
+	return reads
 }
@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// Subscription is an external endpoint that receives events of the listed types
+type Subscription struct {
+	entity.BaseEntity
+	URL string `json:"url" gorm:"size:2048;not null"`
+	// Secret signs every delivery (see Sign); it is only returned when the subscription is created
+	Secret string `json:"-" gorm:"size:128;not null"`
+	// EventTypes is a comma-separated list such as "user.created,user.deleted"; "*" subscribes to all events
+	EventTypes  string `json:"event_types" gorm:"type:text;not null"`
+	Description string `json:"description,omitempty" gorm:"size:255"`
+	Active      bool   `json:"active" gorm:"not null;default:true"`
+}
+
+// TableName overrides the table name
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Events returns the subscribed event types
+func (s *Subscription) Events() []string {
+	var types []string
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// Matches reports whether the subscription receives events of eventType
+func (s *Subscription) Matches(eventType string) bool {
+	events := s.Events()
+	return slices.Contains(events, "*") || slices.Contains(events, eventType)
+}
+
+// DeliveryStatus is the state of a delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // Waiting for its first or next attempt
+	DeliverySucceeded DeliveryStatus = "succeeded" // The endpoint answered with a 2xx status
+	DeliveryFailed    DeliveryStatus = "failed"    // All attempts were used up
+)
+
+// Delivery is one event sent to one subscription, together with its attempt log
+type Delivery struct {
+	entity.BaseEntity
+	SubscriptionID uuid.UUID      `json:"subscription_id" gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID      `json:"event_id" gorm:"type:uuid;not null;index"`
+	EventType      string         `json:"event_type" gorm:"size:128;not null"`
+	Payload        string         `json:"payload" gorm:"type:text;not null"` // JSON body sent to the endpoint
+	Status         DeliveryStatus `json:"status" gorm:"size:16;not null;index"`
+	Attempts       int            `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at" gorm:"index"`
+	LastStatusCode int            `json:"last_status_code,omitempty"`
+	LastError      string         `json:"last_error,omitempty" gorm:"type:text"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Models returns the entities to auto-migrate
+func Models() []interface{} {
+	return []interface{}{&Subscription{}, &Delivery{}}
+}
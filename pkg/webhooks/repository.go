@@ -0,0 +1,106 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// SubscriptionRepository defines persistence operations for webhook subscriptions
+type SubscriptionRepository interface {
+	core_repo.BaseRepository[Subscription]
+
+	// FindActive returns all active subscriptions
+	FindActive(ctx context.Context) ([]*Subscription, error)
+	// SetActive pauses or resumes a subscription
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+}
+
+type gormSubscriptionRepository struct {
+	*core_repo.GormBaseRepository[Subscription]
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepository
+func NewSubscriptionRepository(db *gorm.DB) SubscriptionRepository {
+	return &gormSubscriptionRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[Subscription](db),
+	}
+}
+
+// FindActive implements SubscriptionRepository
+func (r *gormSubscriptionRepository) FindActive(ctx context.Context) ([]*Subscription, error) {
+	var subs []*Subscription
+	err := r.Conn(ctx).Where("active = ? AND deleted_at IS NULL", true).Find(&subs).Error
+	return subs, err
+}
+
+// SetActive implements SubscriptionRepository. Updates() skips false, so the column is set explicitly.
+func (r *gormSubscriptionRepository) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	return r.Conn(ctx).Model(&Subscription{}).Where("id = ?", id).Update("active", active).Error
+}
+
+// DeliveryRepository defines persistence operations for webhook deliveries
+type DeliveryRepository interface {
+	core_repo.BaseRepository[Delivery]
+
+	// ClaimDue returns up to limit pending deliveries whose next attempt is due and pushes their
+	// NextAttemptAt forward by lease, so concurrent workers do not send the same delivery twice.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error)
+	// FindBySubscription returns the delivery log of a subscription
+	FindBySubscription(ctx context.Context, subscriptionID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[Delivery], error)
+	// RecordAttempt stores the outcome of an attempt, including zero values such as a cleared error
+	RecordAttempt(ctx context.Context, delivery *Delivery) error
+}
+
+type gormDeliveryRepository struct {
+	*core_repo.GormBaseRepository[Delivery]
+}
+
+// NewDeliveryRepository creates a new DeliveryRepository
+func NewDeliveryRepository(db *gorm.DB) DeliveryRepository {
+	return &gormDeliveryRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[Delivery](db),
+	}
+}
+
+// ClaimDue implements DeliveryRepository
+func (r *gormDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error) {
+	var deliveries []*Delivery
+	err := r.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ? AND deleted_at IS NULL", DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, d := range deliveries {
+			ids[i] = d.ID
+		}
+		return tx.Model(&Delivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+// FindBySubscription implements DeliveryRepository
+func (r *gormDeliveryRepository) FindBySubscription(ctx context.Context, subscriptionID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[Delivery], error) {
+	return r.FindWithFilter(ctx, map[string]interface{}{"subscription_id": subscriptionID}, opts)
+}
+
+// RecordAttempt implements DeliveryRepository
+func (r *gormDeliveryRepository) RecordAttempt(ctx context.Context, delivery *Delivery) error {
+	return r.Conn(ctx).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+		Updates(delivery).Error
+}
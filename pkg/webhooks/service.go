@@ -0,0 +1,88 @@
+package webhooks
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// Service implements the webhook management operations exposed over gRPC
+type Service struct {
+	*core_usecase.BaseUseCaseImpl[Subscription]
+	subscriptions SubscriptionRepository
+	deliveries    DeliveryRepository
+}
+
+// NewService creates a new webhook management service
+func NewService(subscriptions SubscriptionRepository, deliveries DeliveryRepository, logger logger.Logger) *Service {
+	return &Service{
+		BaseUseCaseImpl: core_usecase.NewBaseUseCase[Subscription](subscriptions, logger),
+		subscriptions:   subscriptions,
+		deliveries:      deliveries,
+	}
+}
+
+// Subscribe creates a subscription with a freshly generated signing secret.
+// The returned subscription is the only place the secret is exposed.
+func (s *Service) Subscribe(ctx context.Context, endpoint string, eventTypes []string, description string) (*Subscription, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "webhook url must be an absolute http(s) URL")
+	}
+	var normalized []string
+	for _, t := range eventTypes {
+		if t = strings.TrimSpace(t); t != "" {
+			normalized = append(normalized, t)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "at least one event type is required")
+	}
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, err.Error())
+	}
+	sub := &Subscription{
+		URL:         endpoint,
+		Secret:      secret,
+		EventTypes:  strings.Join(normalized, ","),
+		Description: description,
+		Active:      true,
+	}
+	if err := s.Create(ctx, sub); err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to create webhook subscription")
+	}
+	return sub, nil
+}
+
+// SetActive pauses or resumes a subscription
+func (s *Service) SetActive(ctx context.Context, id uuid.UUID, active bool) (*Subscription, error) {
+	if err := s.RequireExists(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.subscriptions.SetActive(ctx, id, active); err != nil {
+		s.Logger.Error("Failed to update webhook subscription", "id", id, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to update webhook subscription")
+	}
+	return s.GetByID(ctx, id)
+}
+
+// Deliveries returns the delivery log of a subscription, newest first by default
+func (s *Service) Deliveries(ctx context.Context, subscriptionID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[Delivery], error) {
+	if err := s.RequireExists(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	result, err := s.deliveries.FindBySubscription(ctx, subscriptionID, opts)
+	if err != nil {
+		s.Logger.Error("Failed to list webhook deliveries", "subscription_id", subscriptionID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to list webhook deliveries")
+	}
+	return result, nil
+}
@@ -0,0 +1,231 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/events"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Config controls delivery behaviour
+type Config struct {
+	PollInterval time.Duration // How often the worker looks for due deliveries
+	BatchSize    int           // Deliveries claimed per poll
+	Timeout      time.Duration // HTTP timeout per attempt
+	MaxAttempts  int           // Attempts before a delivery is marked failed
+	BackoffBase  time.Duration // Delay after the first failed attempt; doubles after each further failure
+	BackoffMax   time.Duration // Upper bound for the retry delay
+}
+
+// LoadConfigFromEnv reads the WEBHOOK_* settings
+func LoadConfigFromEnv() Config {
+	return Config{
+		PollInterval: utils.GetEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		BatchSize:    utils.GetEnvAsInt("WEBHOOK_BATCH_SIZE", 20),
+		Timeout:      utils.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		MaxAttempts:  utils.GetEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		BackoffBase:  utils.GetEnvDuration("WEBHOOK_BACKOFF_BASE", 30*time.Second),
+		BackoffMax:   utils.GetEnvDuration("WEBHOOK_BACKOFF_MAX", 6*time.Hour),
+	}
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts
+func (c Config) Backoff(attempts int) time.Duration {
+	delay := c.BackoffBase
+	for i := 1; i < attempts && delay < c.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > c.BackoffMax {
+		delay = c.BackoffMax
+	}
+	return delay
+}
+
+// payload is the JSON body posted to subscribers
+type payload struct {
+	ID            uuid.UUID   `json:"id"`
+	Type          string      `json:"type"`
+	OccurredAt    time.Time   `json:"occurred_at"`
+	Data          interface{} `json:"data"`
+	SchemaVersion int         `json:"schema_version,omitempty"`
+}
+
+// Dispatcher turns published events into pending deliveries for every matching subscription.
+// Deliveries are stored first and sent by the Worker, so a slow or unavailable endpoint never
+// blocks the use case that published the event.
+type Dispatcher struct {
+	subscriptions SubscriptionRepository
+	deliveries    DeliveryRepository
+	logger        logger.Logger
+}
+
+// NewDispatcher creates a new Dispatcher
+func NewDispatcher(subscriptions SubscriptionRepository, deliveries DeliveryRepository, logger logger.Logger) *Dispatcher {
+	return &Dispatcher{subscriptions: subscriptions, deliveries: deliveries, logger: logger}
+}
+
+// ConsumerName is the bus consumer name of the dispatcher, under which its failed events are dead-lettered
+const ConsumerName = "webhooks"
+
+// Attach subscribes the dispatcher to every event on the bus
+func (d *Dispatcher) Attach(bus events.Bus) {
+	bus.SubscribeAs(ConsumerName, "*", d.Handle)
+}
+
+// Handle enqueues a delivery of event to each matching subscription
+func (d *Dispatcher) Handle(ctx context.Context, event events.Event) error {
+	subs, err := d.subscriptions.FindActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+
+	var body []byte
+	for _, sub := range subs {
+		if !sub.Matches(event.Type) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(payload(event)); err != nil {
+				return fmt.Errorf("failed to encode event %s: %w", event.Type, err)
+			}
+		}
+		delivery := &Delivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(body),
+			Status:         DeliveryPending,
+			NextAttemptAt:  time.Now(),
+		}
+		if err := d.deliveries.Create(ctx, delivery); err != nil {
+			d.logger.Error("Failed to enqueue webhook delivery", "subscription_id", sub.ID, "event_type", event.Type, "error", err)
+		}
+	}
+	return nil
+}
+
+// Worker sends pending deliveries, retrying failures with exponential backoff
+type Worker struct {
+	subscriptions SubscriptionRepository
+	deliveries    DeliveryRepository
+	client        *http.Client
+	config        Config
+	logger        logger.Logger
+}
+
+// NewWorker creates a new delivery worker
+func NewWorker(subscriptions SubscriptionRepository, deliveries DeliveryRepository, config Config, logger logger.Logger) *Worker {
+	return &Worker{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        &http.Client{Timeout: config.Timeout},
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// Run polls for due deliveries until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	w.logger.Info("Webhook delivery worker started", "poll_interval", w.config.PollInterval, "max_attempts", w.config.MaxAttempts)
+	for {
+		w.ProcessDue(ctx)
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Webhook delivery worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue sends one batch of due deliveries
+func (w *Worker) ProcessDue(ctx context.Context) {
+	// Lease claimed deliveries for longer than an attempt can take
+	due, err := w.deliveries.ClaimDue(ctx, w.config.BatchSize, 2*w.config.Timeout)
+	if err != nil {
+		w.logger.Error("Failed to claim webhook deliveries", "error", err)
+		return
+	}
+	for _, delivery := range due {
+		w.attempt(ctx, delivery)
+	}
+}
+
+// attempt sends a delivery once and records the outcome
+func (w *Worker) attempt(ctx context.Context, delivery *Delivery) {
+	sub, err := w.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	if err != nil || !sub.Active || sub.DeletedAt != nil {
+		delivery.Status = DeliveryFailed
+		delivery.LastError = "subscription is no longer active"
+		w.save(ctx, delivery)
+		return
+	}
+
+	delivery.Attempts++
+	statusCode, sendErr := w.send(ctx, sub, delivery)
+	delivery.LastStatusCode = statusCode
+
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		delivery.Status = DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	case delivery.Attempts >= w.config.MaxAttempts:
+		delivery.Status = DeliveryFailed
+		delivery.LastError = sendErr.Error()
+		w.logger.Warn("Webhook delivery failed permanently", "delivery_id", delivery.ID, "subscription_id", sub.ID, "attempts", delivery.Attempts, "error", sendErr)
+	default:
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = time.Now().Add(w.config.Backoff(delivery.Attempts))
+		w.logger.Debug("Webhook delivery failed, will retry", "delivery_id", delivery.ID, "attempts", delivery.Attempts, "next_attempt_at", delivery.NextAttemptAt, "error", sendErr)
+	}
+	w.save(ctx, delivery)
+}
+
+// send posts the signed payload and returns the response status code
+func (w *Worker) send(ctx context.Context, sub *Subscription, delivery *Delivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderEventID, delivery.EventID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (w *Worker) save(ctx context.Context, delivery *Delivery) {
+	if err := w.deliveries.RecordAttempt(ctx, delivery); err != nil {
+		w.logger.Error("Failed to record webhook delivery attempt", "delivery_id", delivery.ID, "error", err)
+	}
+}
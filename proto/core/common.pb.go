@@ -40,8 +40,12 @@ type FilterOptions struct {
 	Filters map[string]*structpb.Value `protobuf:"bytes,5,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Whether to include soft-deleted records in the results.
 	IncludeDeleted *bool `protobuf:"varint,8,opt,name=include_deleted,json=includeDeleted,proto3,oneof" json:"include_deleted,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Sort direction; takes precedence over sort_desc when specified.
+	SortDirection *SortDirection `protobuf:"varint,9,opt,name=sort_direction,json=sortDirection,proto3,enum=core.SortDirection,oneof" json:"sort_direction,omitempty"`
+	// Field conditions with comparison operators, combined with AND alongside filters.
+	Conditions    []*FilterCondition `protobuf:"bytes,10,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FilterOptions) Reset() {
@@ -116,6 +120,20 @@ func (x *FilterOptions) GetIncludeDeleted() bool {
 	return false
 }
 
+func (x *FilterOptions) GetSortDirection() SortDirection {
+	if x != nil && x.SortDirection != nil {
+		return *x.SortDirection
+	}
+	return SortDirection_SORT_DIRECTION_UNSPECIFIED
+}
+
+func (x *FilterOptions) GetConditions() []*FilterCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
 // Represents common pagination metadata included in list responses.
 // Based on pkg/core/types/common.go PaginationResult struct (metadata fields only).
 // Specific list responses should include this alongside their repeated items field.
@@ -186,14 +204,20 @@ var File_proto_core_common_proto protoreflect.FileDescriptor
 
 const file_proto_core_common_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/core/common.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc5\a\n" +
+	"\x17proto/core/common.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\x1a\x16proto/core/query.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xb6\n" +
+	"\n" +
 	"\rFilterOptions\x12S\n" +
 	"\x05limit\x18\x01 \x01(\x05B8\x92A52+Maximum number of items to return per page.:\x0250J\x0250H\x00R\x05limit\x88\x01\x01\x12{\n" +
 	"\x06offset\x18\x02 \x01(\x05B^\x92A[2SNumber of items to skip before starting to collect the result set (for pagination).:\x010J\x010H\x01R\x06offset\x88\x01\x01\x12~\n" +
 	"\asort_by\x18\x03 \x01(\tB`\x92A]2?Field name to sort the results by (e.g., 'created_at', 'name').:\f\"created_at\"J\f\"created_at\"H\x02R\x06sortBy\x88\x01\x01\x12[\n" +
 	"\tsort_desc\x18\x04 \x01(\bB9\x92A62(Set to true to sort in descending order.:\x04trueJ\x04trueH\x03R\bsortDesc\x88\x01\x01\x12\xef\x01\n" +
 	"\afilters\x18\x05 \x03(\v2 .core.FilterOptions.FiltersEntryB\xb2\x01\x92A\xae\x012\x8e\x01Key-value pairs for specific field filtering. Values should correspond to google.protobuf.Value structure (e.g., {\"email\": \"user@gmail.com\"}).J\x1b{\"email\": \"user@gmail.com\"}R\afilters\x12|\n" +
-	"\x0finclude_deleted\x18\b \x01(\bBN\x92AK2;Set to true to include soft-deleted records in the results.:\x05falseJ\x05falseH\x04R\x0eincludeDeleted\x88\x01\x01\x1aR\n" +
+	"\x0finclude_deleted\x18\b \x01(\bBN\x92AK2;Set to true to include soft-deleted records in the results.:\x05falseJ\x05falseH\x04R\x0eincludeDeleted\x88\x01\x01\x12\x8a\x01\n" +
+	"\x0esort_direction\x18\t \x01(\x0e2\x13.core.SortDirectionBI\x92AF2-Sort direction. Overrides sort_desc when set.J\x15\"SORT_DIRECTION_DESC\"H\x05R\rsortDirection\x88\x01\x01\x12\xce\x01\n" +
+	"\n" +
+	"conditions\x18\n" +
+	" \x03(\v2\x15.core.FilterConditionB\x96\x01\x92A\x92\x012\x8f\x01Field conditions with operators, combined with AND (e.g., [{\"field\": \"created_at\", \"operator\": \"FILTER_OPERATOR_GTE\", \"value\": \"2026-01-01\"}]).R\n" +
+	"conditions\x1aR\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
 	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01B\b\n" +
@@ -203,7 +227,8 @@ const file_proto_core_common_proto_rawDesc = "" +
 	"\b_sort_byB\f\n" +
 	"\n" +
 	"_sort_descB\x12\n" +
-	"\x10_include_deleted\"\xbb\x02\n" +
+	"\x10_include_deletedB\x11\n" +
+	"\x0f_sort_direction\"\xbb\x02\n" +
 	"\x0ePaginationInfo\x12o\n" +
 	"\vtotal_items\x18\x01 \x01(\x03BN\x92AK2CTotal number of items matching the query criteria across all pages.J\x041234R\n" +
 	"totalItems\x12S\n" +
@@ -225,19 +250,23 @@ func file_proto_core_common_proto_rawDescGZIP() []byte {
 
 var file_proto_core_common_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_proto_core_common_proto_goTypes = []any{
-	(*FilterOptions)(nil),  // 0: core.FilterOptions
-	(*PaginationInfo)(nil), // 1: core.PaginationInfo
-	nil,                    // 2: core.FilterOptions.FiltersEntry
-	(*structpb.Value)(nil), // 3: google.protobuf.Value
+	(*FilterOptions)(nil),   // 0: core.FilterOptions
+	(*PaginationInfo)(nil),  // 1: core.PaginationInfo
+	nil,                     // 2: core.FilterOptions.FiltersEntry
+	(SortDirection)(0),      // 3: core.SortDirection
+	(*FilterCondition)(nil), // 4: core.FilterCondition
+	(*structpb.Value)(nil),  // 5: google.protobuf.Value
 }
 var file_proto_core_common_proto_depIdxs = []int32{
 	2, // 0: core.FilterOptions.filters:type_name -> core.FilterOptions.FiltersEntry
-	3, // 1: core.FilterOptions.FiltersEntry.value:type_name -> google.protobuf.Value
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 1: core.FilterOptions.sort_direction:type_name -> core.SortDirection
+	4, // 2: core.FilterOptions.conditions:type_name -> core.FilterCondition
+	5, // 3: core.FilterOptions.FiltersEntry.value:type_name -> google.protobuf.Value
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_core_common_proto_init() }
@@ -245,6 +274,7 @@ func file_proto_core_common_proto_init() {
 	if File_proto_core_common_proto != nil {
 		return
 	}
+	file_proto_core_query_proto_init()
 	file_proto_core_common_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -0,0 +1,162 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/core/events.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Data of notification.created events (pkg/core/events.Notification)
+type NotificationCreatedV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // User the notification is for (UUID)
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                   // e.g. "data_export.ready"
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Link          string                 `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"` // Gateway path of the related resource
+	Data          *structpb.Struct       `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // Details specific to the notification type
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationCreatedV1) Reset() {
+	*x = NotificationCreatedV1{}
+	mi := &file_proto_core_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationCreatedV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationCreatedV1) ProtoMessage() {}
+
+func (x *NotificationCreatedV1) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationCreatedV1.ProtoReflect.Descriptor instead.
+func (*NotificationCreatedV1) Descriptor() ([]byte, []int) {
+	return file_proto_core_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NotificationCreatedV1) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NotificationCreatedV1) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NotificationCreatedV1) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *NotificationCreatedV1) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *NotificationCreatedV1) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_proto_core_events_proto protoreflect.FileDescriptor
+
+const file_proto_core_events_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/core/events.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\"\x9f\x01\n" +
+	"\x15NotificationCreatedV1\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x12\n" +
+	"\x04link\x18\x04 \x01(\tR\x04link\x12+\n" +
+	"\x04data\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04dataB-Z+golang-microservices-boilerplate/proto/coreb\x06proto3"
+
+var (
+	file_proto_core_events_proto_rawDescOnce sync.Once
+	file_proto_core_events_proto_rawDescData []byte
+)
+
+func file_proto_core_events_proto_rawDescGZIP() []byte {
+	file_proto_core_events_proto_rawDescOnce.Do(func() {
+		file_proto_core_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_core_events_proto_rawDesc), len(file_proto_core_events_proto_rawDesc)))
+	})
+	return file_proto_core_events_proto_rawDescData
+}
+
+var file_proto_core_events_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_core_events_proto_goTypes = []any{
+	(*NotificationCreatedV1)(nil), // 0: core.NotificationCreatedV1
+	(*structpb.Struct)(nil),       // 1: google.protobuf.Struct
+}
+var file_proto_core_events_proto_depIdxs = []int32{
+	1, // 0: core.NotificationCreatedV1.data:type_name -> google.protobuf.Struct
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_core_events_proto_init() }
+func file_proto_core_events_proto_init() {
+	if File_proto_core_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_core_events_proto_rawDesc), len(file_proto_core_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_core_events_proto_goTypes,
+		DependencyIndexes: file_proto_core_events_proto_depIdxs,
+		MessageInfos:      file_proto_core_events_proto_msgTypes,
+	}.Build()
+	File_proto_core_events_proto = out.File
+	file_proto_core_events_proto_goTypes = nil
+	file_proto_core_events_proto_depIdxs = nil
+}
@@ -0,0 +1,618 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/core/query.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Sort direction for list queries.
+type SortDirection int32
+
+const (
+	SortDirection_SORT_DIRECTION_UNSPECIFIED SortDirection = 0 // Use the endpoint's default
+	SortDirection_SORT_DIRECTION_ASC         SortDirection = 1
+	SortDirection_SORT_DIRECTION_DESC        SortDirection = 2
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "SORT_DIRECTION_UNSPECIFIED",
+		1: "SORT_DIRECTION_ASC",
+		2: "SORT_DIRECTION_DESC",
+	}
+	SortDirection_value = map[string]int32{
+		"SORT_DIRECTION_UNSPECIFIED": 0,
+		"SORT_DIRECTION_ASC":         1,
+		"SORT_DIRECTION_DESC":        2,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_core_query_proto_enumTypes[0].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_proto_core_query_proto_enumTypes[0]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{0}
+}
+
+// Comparison operator of a filter condition.
+// Based on pkg/core/types/query.go FilterOperator.
+type FilterOperator int32
+
+const (
+	FilterOperator_FILTER_OPERATOR_UNSPECIFIED   FilterOperator = 0 // Treated as EQ
+	FilterOperator_FILTER_OPERATOR_EQ            FilterOperator = 1
+	FilterOperator_FILTER_OPERATOR_NE            FilterOperator = 2
+	FilterOperator_FILTER_OPERATOR_GT            FilterOperator = 3
+	FilterOperator_FILTER_OPERATOR_GTE           FilterOperator = 4
+	FilterOperator_FILTER_OPERATOR_LT            FilterOperator = 5
+	FilterOperator_FILTER_OPERATOR_LTE           FilterOperator = 6
+	FilterOperator_FILTER_OPERATOR_IN            FilterOperator = 7  // value is a list
+	FilterOperator_FILTER_OPERATOR_NOT_IN        FilterOperator = 8  // value is a list
+	FilterOperator_FILTER_OPERATOR_CONTAINS      FilterOperator = 9  // Case-insensitive substring match on text columns
+	FilterOperator_FILTER_OPERATOR_STARTS_WITH   FilterOperator = 10 // Case-insensitive prefix match on text columns
+	FilterOperator_FILTER_OPERATOR_IS_NULL       FilterOperator = 11 // value is a bool: true for IS NULL, false for IS NOT NULL
+	FilterOperator_FILTER_OPERATOR_WITHIN_RADIUS FilterOperator = 12 // value is {"center": {"lat", "lng"}, "radius_meters"}, see GeoRadius
+	FilterOperator_FILTER_OPERATOR_WITHIN_BOX    FilterOperator = 13 // value is {"min_lat", "min_lng", "max_lat", "max_lng"}, see GeoBoundingBox
+)
+
+// Enum value maps for FilterOperator.
+var (
+	FilterOperator_name = map[int32]string{
+		0:  "FILTER_OPERATOR_UNSPECIFIED",
+		1:  "FILTER_OPERATOR_EQ",
+		2:  "FILTER_OPERATOR_NE",
+		3:  "FILTER_OPERATOR_GT",
+		4:  "FILTER_OPERATOR_GTE",
+		5:  "FILTER_OPERATOR_LT",
+		6:  "FILTER_OPERATOR_LTE",
+		7:  "FILTER_OPERATOR_IN",
+		8:  "FILTER_OPERATOR_NOT_IN",
+		9:  "FILTER_OPERATOR_CONTAINS",
+		10: "FILTER_OPERATOR_STARTS_WITH",
+		11: "FILTER_OPERATOR_IS_NULL",
+		12: "FILTER_OPERATOR_WITHIN_RADIUS",
+		13: "FILTER_OPERATOR_WITHIN_BOX",
+	}
+	FilterOperator_value = map[string]int32{
+		"FILTER_OPERATOR_UNSPECIFIED":   0,
+		"FILTER_OPERATOR_EQ":            1,
+		"FILTER_OPERATOR_NE":            2,
+		"FILTER_OPERATOR_GT":            3,
+		"FILTER_OPERATOR_GTE":           4,
+		"FILTER_OPERATOR_LT":            5,
+		"FILTER_OPERATOR_LTE":           6,
+		"FILTER_OPERATOR_IN":            7,
+		"FILTER_OPERATOR_NOT_IN":        8,
+		"FILTER_OPERATOR_CONTAINS":      9,
+		"FILTER_OPERATOR_STARTS_WITH":   10,
+		"FILTER_OPERATOR_IS_NULL":       11,
+		"FILTER_OPERATOR_WITHIN_RADIUS": 12,
+		"FILTER_OPERATOR_WITHIN_BOX":    13,
+	}
+)
+
+func (x FilterOperator) Enum() *FilterOperator {
+	p := new(FilterOperator)
+	*p = x
+	return p
+}
+
+func (x FilterOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_core_query_proto_enumTypes[1].Descriptor()
+}
+
+func (FilterOperator) Type() protoreflect.EnumType {
+	return &file_proto_core_query_proto_enumTypes[1]
+}
+
+func (x FilterOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterOperator.Descriptor instead.
+func (FilterOperator) EnumDescriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{1}
+}
+
+// A single field comparison, e.g. {"field": "age", "operator": "FILTER_OPERATOR_GTE", "value": 18}.
+type FilterCondition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Operator      FilterOperator         `protobuf:"varint,2,opt,name=operator,proto3,enum=core.FilterOperator" json:"operator,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterCondition) Reset() {
+	*x = FilterCondition{}
+	mi := &file_proto_core_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterCondition) ProtoMessage() {}
+
+func (x *FilterCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterCondition.ProtoReflect.Descriptor instead.
+func (*FilterCondition) Descriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FilterCondition) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FilterCondition) GetOperator() FilterOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return FilterOperator_FILTER_OPERATOR_UNSPECIFIED
+}
+
+func (x *FilterCondition) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// A WGS 84 location in degrees.
+// Based on pkg/core/types/geo.go GeoPoint.
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng           float64                `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_proto_core_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GeoPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+// Points within a distance of a center, e.g. "stations within 5km of a point".
+type GeoRadius struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Center        *GeoPoint              `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusMeters  float64                `protobuf:"fixed64,2,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoRadius) Reset() {
+	*x = GeoRadius{}
+	mi := &file_proto_core_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoRadius) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoRadius) ProtoMessage() {}
+
+func (x *GeoRadius) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoRadius.ProtoReflect.Descriptor instead.
+func (*GeoRadius) Descriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GeoRadius) GetCenter() *GeoPoint {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *GeoRadius) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+// Points between a south-west and a north-east corner, e.g. the visible area of a map.
+type GeoBoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLat        float64                `protobuf:"fixed64,1,opt,name=min_lat,json=minLat,proto3" json:"min_lat,omitempty"`
+	MinLng        float64                `protobuf:"fixed64,2,opt,name=min_lng,json=minLng,proto3" json:"min_lng,omitempty"`
+	MaxLat        float64                `protobuf:"fixed64,3,opt,name=max_lat,json=maxLat,proto3" json:"max_lat,omitempty"`
+	MaxLng        float64                `protobuf:"fixed64,4,opt,name=max_lng,json=maxLng,proto3" json:"max_lng,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoBoundingBox) Reset() {
+	*x = GeoBoundingBox{}
+	mi := &file_proto_core_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoBoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoBoundingBox) ProtoMessage() {}
+
+func (x *GeoBoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoBoundingBox.ProtoReflect.Descriptor instead.
+func (*GeoBoundingBox) Descriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GeoBoundingBox) GetMinLat() float64 {
+	if x != nil {
+		return x.MinLat
+	}
+	return 0
+}
+
+func (x *GeoBoundingBox) GetMinLng() float64 {
+	if x != nil {
+		return x.MinLng
+	}
+	return 0
+}
+
+func (x *GeoBoundingBox) GetMaxLat() float64 {
+	if x != nil {
+		return x.MaxLat
+	}
+	return 0
+}
+
+func (x *GeoBoundingBox) GetMaxLng() float64 {
+	if x != nil {
+		return x.MaxLng
+	}
+	return 0
+}
+
+// Cursor (keyset) pagination request. Pass the next_page_token of the previous response to continue.
+type CursorPageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CursorPageRequest) Reset() {
+	*x = CursorPageRequest{}
+	mi := &file_proto_core_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CursorPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CursorPageRequest) ProtoMessage() {}
+
+func (x *CursorPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CursorPageRequest.ProtoReflect.Descriptor instead.
+func (*CursorPageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CursorPageRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *CursorPageRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Cursor pagination metadata included in list responses.
+type CursorPageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NextPageToken string                 `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CursorPageInfo) Reset() {
+	*x = CursorPageInfo{}
+	mi := &file_proto_core_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CursorPageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CursorPageInfo) ProtoMessage() {}
+
+func (x *CursorPageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CursorPageInfo.ProtoReflect.Descriptor instead.
+func (*CursorPageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_core_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CursorPageInfo) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *CursorPageInfo) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *CursorPageInfo) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_proto_core_query_proto protoreflect.FileDescriptor
+
+const file_proto_core_query_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/core/query.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\"\x87\x01\n" +
+	"\x0fFilterCondition\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x120\n" +
+	"\boperator\x18\x02 \x01(\x0e2\x14.core.FilterOperatorR\boperator\x12,\n" +
+	"\x05value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05value\".\n" +
+	"\bGeoPoint\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x02 \x01(\x01R\x03lng\"X\n" +
+	"\tGeoRadius\x12&\n" +
+	"\x06center\x18\x01 \x01(\v2\x0e.core.GeoPointR\x06center\x12#\n" +
+	"\rradius_meters\x18\x02 \x01(\x01R\fradiusMeters\"t\n" +
+	"\x0eGeoBoundingBox\x12\x17\n" +
+	"\amin_lat\x18\x01 \x01(\x01R\x06minLat\x12\x17\n" +
+	"\amin_lng\x18\x02 \x01(\x01R\x06minLng\x12\x17\n" +
+	"\amax_lat\x18\x03 \x01(\x01R\x06maxLat\x12\x17\n" +
+	"\amax_lng\x18\x04 \x01(\x01R\x06maxLng\"O\n" +
+	"\x11CursorPageRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"p\n" +
+	"\x0eCursorPageInfo\x12&\n" +
+	"\x0fnext_page_token\x18\x01 \x01(\tR\rnextPageToken\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize*`\n" +
+	"\rSortDirection\x12\x1e\n" +
+	"\x1aSORT_DIRECTION_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SORT_DIRECTION_ASC\x10\x01\x12\x17\n" +
+	"\x13SORT_DIRECTION_DESC\x10\x02*\x96\x03\n" +
+	"\x0eFilterOperator\x12\x1f\n" +
+	"\x1bFILTER_OPERATOR_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12FILTER_OPERATOR_EQ\x10\x01\x12\x16\n" +
+	"\x12FILTER_OPERATOR_NE\x10\x02\x12\x16\n" +
+	"\x12FILTER_OPERATOR_GT\x10\x03\x12\x17\n" +
+	"\x13FILTER_OPERATOR_GTE\x10\x04\x12\x16\n" +
+	"\x12FILTER_OPERATOR_LT\x10\x05\x12\x17\n" +
+	"\x13FILTER_OPERATOR_LTE\x10\x06\x12\x16\n" +
+	"\x12FILTER_OPERATOR_IN\x10\a\x12\x1a\n" +
+	"\x16FILTER_OPERATOR_NOT_IN\x10\b\x12\x1c\n" +
+	"\x18FILTER_OPERATOR_CONTAINS\x10\t\x12\x1f\n" +
+	"\x1bFILTER_OPERATOR_STARTS_WITH\x10\n" +
+	"\x12\x1b\n" +
+	"\x17FILTER_OPERATOR_IS_NULL\x10\v\x12!\n" +
+	"\x1dFILTER_OPERATOR_WITHIN_RADIUS\x10\f\x12\x1e\n" +
+	"\x1aFILTER_OPERATOR_WITHIN_BOX\x10\rB-Z+golang-microservices-boilerplate/proto/coreb\x06proto3"
+
+var (
+	file_proto_core_query_proto_rawDescOnce sync.Once
+	file_proto_core_query_proto_rawDescData []byte
+)
+
+func file_proto_core_query_proto_rawDescGZIP() []byte {
+	file_proto_core_query_proto_rawDescOnce.Do(func() {
+		file_proto_core_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_core_query_proto_rawDesc), len(file_proto_core_query_proto_rawDesc)))
+	})
+	return file_proto_core_query_proto_rawDescData
+}
+
+var file_proto_core_query_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_core_query_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_core_query_proto_goTypes = []any{
+	(SortDirection)(0),        // 0: core.SortDirection
+	(FilterOperator)(0),       // 1: core.FilterOperator
+	(*FilterCondition)(nil),   // 2: core.FilterCondition
+	(*GeoPoint)(nil),          // 3: core.GeoPoint
+	(*GeoRadius)(nil),         // 4: core.GeoRadius
+	(*GeoBoundingBox)(nil),    // 5: core.GeoBoundingBox
+	(*CursorPageRequest)(nil), // 6: core.CursorPageRequest
+	(*CursorPageInfo)(nil),    // 7: core.CursorPageInfo
+	(*structpb.Value)(nil),    // 8: google.protobuf.Value
+}
+var file_proto_core_query_proto_depIdxs = []int32{
+	1, // 0: core.FilterCondition.operator:type_name -> core.FilterOperator
+	8, // 1: core.FilterCondition.value:type_name -> google.protobuf.Value
+	3, // 2: core.GeoRadius.center:type_name -> core.GeoPoint
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_core_query_proto_init() }
+func file_proto_core_query_proto_init() {
+	if File_proto_core_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_core_query_proto_rawDesc), len(file_proto_core_query_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_core_query_proto_goTypes,
+		DependencyIndexes: file_proto_core_query_proto_depIdxs,
+		EnumInfos:         file_proto_core_query_proto_enumTypes,
+		MessageInfos:      file_proto_core_query_proto_msgTypes,
+	}.Build()
+	File_proto_core_query_proto = out.File
+	file_proto_core_query_proto_goTypes = nil
+	file_proto_core_query_proto_depIdxs = nil
+}
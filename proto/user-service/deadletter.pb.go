@@ -0,0 +1,588 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/user-service/deadletter.proto
+
+package user_service
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// An event an event bus consumer failed to handle
+type DeadLetter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Consumer      string                 `protobuf:"bytes,2,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastFailedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_failed_at,json=lastFailedAt,proto3" json:"last_failed_at,omitempty"`
+	ReplayedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=replayed_at,json=replayedAt,proto3" json:"replayed_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetLastFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetReplayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Request for listing dead letters
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consumer      string                 `protobuf:"bytes,1,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Options       *core.FilterOptions    `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"` // Pagination and sorting options
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDeadLettersRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response containing a page of dead letters
+type ListDeadLettersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters    []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request identifying a dead letter
+type DeadLetterIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterIDRequest) Reset() {
+	*x = DeadLetterIDRequest{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterIDRequest) ProtoMessage() {}
+
+func (x *DeadLetterIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterIDRequest.ProtoReflect.Descriptor instead.
+func (*DeadLetterIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeadLetterIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Request for replaying dead letters
+type ReplayDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLettersRequest) Reset() {
+	*x = ReplayDeadLettersRequest{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLettersRequest) ProtoMessage() {}
+
+func (x *ReplayDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplayDeadLettersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// Outcome of replaying one dead letter
+type ReplayResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Replayed      bool                   `protobuf:"varint,2,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayResult) Reset() {
+	*x = ReplayResult{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayResult) ProtoMessage() {}
+
+func (x *ReplayResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayResult.ProtoReflect.Descriptor instead.
+func (*ReplayResult) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplayResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReplayResult) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+func (x *ReplayResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Response listing the outcome per message
+type ReplayDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ReplayResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLettersResponse) Reset() {
+	*x = ReplayDeadLettersResponse{}
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLettersResponse) ProtoMessage() {}
+
+func (x *ReplayDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_deadletter_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_deadletter_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReplayDeadLettersResponse) GetResults() []*ReplayResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_proto_user_service_deadletter_proto protoreflect.FileDescriptor
+
+const file_proto_user_service_deadletter_proto_rawDesc = "" +
+	"\n" +
+	"#proto/user-service/deadletter.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/core/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x9d\t\n" +
+	"\n" +
+	"DeadLetter\x12l\n" +
+	"\x02id\x18\x01 \x01(\tB\\\x92AY2/Unique identifier of the message (UUID format).J&\"e5f6a7b8-c9d0-1234-5678-90abcdef1234\"R\x02id\x12j\n" +
+	"\bconsumer\x18\x02 \x01(\tBN\x92AK2=Consumer that failed, e.g. 'webhooks' or 'projection:<name>'.J\n" +
+	"\"webhooks\"R\bconsumer\x127\n" +
+	"\bevent_id\x18\x03 \x01(\tB\x1c\x92A\x192\x17ID of the failed event.R\aeventId\x12M\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tB.\x92A+2\x19Type of the failed event.J\x0e\"user.created\"R\teventType\x12I\n" +
+	"\apayload\x18\x05 \x01(\tB/\x92A,2*The event as JSON, as it will be replayed.R\apayload\x12U\n" +
+	"\x06status\x18\x06 \x01(\tB=\x92A:2-Status: 'pending', 'replayed' or 'discarded'.J\t\"pending\"R\x06status\x12S\n" +
+	"\battempts\x18\a \x01(\x05B7\x92A42/Number of failed deliveries, including replays.J\x011R\battempts\x12F\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tB'\x92A$2\"Error of the last failed delivery.R\tlastError\x12e\n" +
+	"\x0elast_failed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB#\x92A 2\x1eWhen the last delivery failed.R\flastFailedAt\x12p\n" +
+	"\vreplayed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB3\x92A02.When the consumer handled the event on replay.R\n" +
+	"replayedAt\x12\\\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB!\x92A\x1e2\x1cWhen the event first failed.R\tcreatedAt:\xb6\x01\x92A\xb2\x01\n" +
+	"\xaf\x01*\vDead Letter2dA domain event a consumer failed to handle, with the reason, kept until it is replayed or discarded.\xd2\x01\x02id\xd2\x01\bconsumer\xd2\x01\bevent_id\xd2\x01\n" +
+	"event_type\xd2\x01\x06status\xd2\x01\battempts\"\x84\x02\n" +
+	"\x16ListDeadLettersRequest\x12L\n" +
+	"\bconsumer\x18\x01 \x01(\tB0\x92A-2\x1fOnly messages of this consumer.J\n" +
+	"\"webhooks\"R\bconsumer\x12m\n" +
+	"\x06status\x18\x02 \x01(\tBU\x92AR2EOnly messages with this status: 'pending', 'replayed' or 'discarded'.J\t\"pending\"R\x06status\x12-\n" +
+	"\aoptions\x18\x03 \x01(\v2\x13.core.FilterOptionsR\aoptions\"\x94\x01\n" +
+	"\x17ListDeadLettersResponse\x12:\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x17.userservice.DeadLetterR\vdeadLetters\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo\"Q\n" +
+	"\x13DeadLetterIDRequest\x12:\n" +
+	"\x02id\x18\x01 \x01(\tB*\x92A'2%The unique identifier of the message.R\x02id\"\xa0\x01\n" +
+	"\x18ReplayDeadLettersRequest\x12Z\n" +
+	"\x03ids\x18\x01 \x03(\tBH\x92AE2CIDs of the messages to replay, at most 100, replayed in this order.R\x03ids:(\x92A%\n" +
+	"#*\x1bReplay Dead Letters Request\xd2\x01\x03ids\"\xdb\x01\n" +
+	"\fReplayResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12H\n" +
+	"\breplayed\x18\x02 \x01(\bB,\x92A)2'Whether the consumer handled the event.R\breplayed\x12q\n" +
+	"\x05error\x18\x03 \x01(\tB[\x92AX2VWhy the message was not replayed. A message whose consumer failed again stays pending.R\x05error\"P\n" +
+	"\x19ReplayDeadLettersResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.userservice.ReplayResultR\aresults2\xee\a\n" +
+	"\x11DeadLetterService\x12\xe6\x01\n" +
+	"\x0fListDeadLetters\x12#.userservice.ListDeadLettersRequest\x1a$.userservice.ListDeadLettersResponse\"\x87\x01\x92Ah\n" +
+	"\fDead Letters\x12\x11List Dead Letters\x1aELists the events consumers failed to handle, newest first by default.\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/dead-letters\x12\xcd\x01\n" +
+	"\rGetDeadLetter\x12 .userservice.DeadLetterIDRequest\x1a\x17.userservice.DeadLetter\"\x80\x01\x92A\\\n" +
+	"\fDead Letters\x12\x0fGet Dead Letter\x1a;Returns a failed event with its payload and the last error.\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/dead-letters/{id}\x12\x93\x02\n" +
+	"\x11ReplayDeadLetters\x12%.userservice.ReplayDeadLettersRequest\x1a&.userservice.ReplayDeadLettersResponse\"\xae\x01\x92A\x84\x01\n" +
+	"\fDead Letters\x12\x13Replay Dead Letters\x1a_Hands the events to the consumers that failed them again. Each message reports its own outcome.\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/dead-letters/replay\x12\xca\x01\n" +
+	"\x11DiscardDeadLetter\x12 .userservice.DeadLetterIDRequest\x1a\x17.userservice.DeadLetter\"z\x92AK\n" +
+	"\fDead Letters\x12\x13Discard Dead Letter\x1a&Marks a message as not to be replayed.\x82\xd3\xe4\x93\x02#\"!/api/v1/dead-letters/{id}/discard\x90\x02\x02\x1a=\x92A:\x128Events that consumers failed to handle, and their replayB5Z3golang-microservices-boilerplate/proto/user-serviceb\x06proto3"
+
+var (
+	file_proto_user_service_deadletter_proto_rawDescOnce sync.Once
+	file_proto_user_service_deadletter_proto_rawDescData []byte
+)
+
+func file_proto_user_service_deadletter_proto_rawDescGZIP() []byte {
+	file_proto_user_service_deadletter_proto_rawDescOnce.Do(func() {
+		file_proto_user_service_deadletter_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_service_deadletter_proto_rawDesc), len(file_proto_user_service_deadletter_proto_rawDesc)))
+	})
+	return file_proto_user_service_deadletter_proto_rawDescData
+}
+
+var file_proto_user_service_deadletter_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_user_service_deadletter_proto_goTypes = []any{
+	(*DeadLetter)(nil),                // 0: userservice.DeadLetter
+	(*ListDeadLettersRequest)(nil),    // 1: userservice.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),   // 2: userservice.ListDeadLettersResponse
+	(*DeadLetterIDRequest)(nil),       // 3: userservice.DeadLetterIDRequest
+	(*ReplayDeadLettersRequest)(nil),  // 4: userservice.ReplayDeadLettersRequest
+	(*ReplayResult)(nil),              // 5: userservice.ReplayResult
+	(*ReplayDeadLettersResponse)(nil), // 6: userservice.ReplayDeadLettersResponse
+	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+	(*core.FilterOptions)(nil),        // 8: core.FilterOptions
+	(*core.PaginationInfo)(nil),       // 9: core.PaginationInfo
+}
+var file_proto_user_service_deadletter_proto_depIdxs = []int32{
+	7,  // 0: userservice.DeadLetter.last_failed_at:type_name -> google.protobuf.Timestamp
+	7,  // 1: userservice.DeadLetter.replayed_at:type_name -> google.protobuf.Timestamp
+	7,  // 2: userservice.DeadLetter.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: userservice.ListDeadLettersRequest.options:type_name -> core.FilterOptions
+	0,  // 4: userservice.ListDeadLettersResponse.dead_letters:type_name -> userservice.DeadLetter
+	9,  // 5: userservice.ListDeadLettersResponse.pagination_info:type_name -> core.PaginationInfo
+	5,  // 6: userservice.ReplayDeadLettersResponse.results:type_name -> userservice.ReplayResult
+	1,  // 7: userservice.DeadLetterService.ListDeadLetters:input_type -> userservice.ListDeadLettersRequest
+	3,  // 8: userservice.DeadLetterService.GetDeadLetter:input_type -> userservice.DeadLetterIDRequest
+	4,  // 9: userservice.DeadLetterService.ReplayDeadLetters:input_type -> userservice.ReplayDeadLettersRequest
+	3,  // 10: userservice.DeadLetterService.DiscardDeadLetter:input_type -> userservice.DeadLetterIDRequest
+	2,  // 11: userservice.DeadLetterService.ListDeadLetters:output_type -> userservice.ListDeadLettersResponse
+	0,  // 12: userservice.DeadLetterService.GetDeadLetter:output_type -> userservice.DeadLetter
+	6,  // 13: userservice.DeadLetterService.ReplayDeadLetters:output_type -> userservice.ReplayDeadLettersResponse
+	0,  // 14: userservice.DeadLetterService.DiscardDeadLetter:output_type -> userservice.DeadLetter
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_service_deadletter_proto_init() }
+func file_proto_user_service_deadletter_proto_init() {
+	if File_proto_user_service_deadletter_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_deadletter_proto_rawDesc), len(file_proto_user_service_deadletter_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_user_service_deadletter_proto_goTypes,
+		DependencyIndexes: file_proto_user_service_deadletter_proto_depIdxs,
+		MessageInfos:      file_proto_user_service_deadletter_proto_msgTypes,
+	}.Build()
+	File_proto_user_service_deadletter_proto = out.File
+	file_proto_user_service_deadletter_proto_goTypes = nil
+	file_proto_user_service_deadletter_proto_depIdxs = nil
+}
@@ -0,0 +1,612 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/user-service/organization.proto
+
+package user_service
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// An organization users belong to
+type Organization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// A user's membership of an organization
+type Member struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName      string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName       string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Role           string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	InvitedBy      string                 `protobuf:"bytes,7,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	JoinedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Member) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Member) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Member) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Member) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Member) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Member) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Member) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *Member) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+// Request to create an organization
+type CreateOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+// Request to add a user to an organization
+type InviteMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberRequest) Reset() {
+	*x = InviteMemberRequest{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberRequest) ProtoMessage() {}
+
+func (x *InviteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InviteMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// Request to list the members of an organization
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Options       *core.FilterOptions    `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"` // Pagination and sorting options
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListMembersRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response containing a page of members
+type ListMembersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Members        []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ListMembersResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request to change the role of a member
+type ChangeMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeMemberRoleRequest) Reset() {
+	*x = ChangeMemberRoleRequest{}
+	mi := &file_proto_user_service_organization_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeMemberRoleRequest) ProtoMessage() {}
+
+func (x *ChangeMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_organization_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*ChangeMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_organization_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChangeMemberRoleRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ChangeMemberRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangeMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+var File_proto_user_service_organization_proto protoreflect.FileDescriptor
+
+const file_proto_user_service_organization_proto_rawDesc = "" +
+	"\n" +
+	"%proto/user-service/organization.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/core/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x80\x06\n" +
+	"\fOrganization\x12q\n" +
+	"\x02id\x18\x01 \x01(\tBa\x92A^24Unique identifier of the organization (UUID format).J&\"c3d4e5f6-a7b8-9012-3456-7890abcdef12\"R\x02id\x12S\n" +
+	"\x04name\x18\x02 \x01(\tB?\x92A<2!Display name of the organization.J\x17\"Water Authority North\"R\x04name\x12[\n" +
+	"\x04slug\x18\x03 \x01(\tBG\x92AD2)Unique URL-safe name of the organization.J\x17\"water-authority-north\"R\x04slug\x12\x93\x01\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tBt\x92Aq2GID of the user who created the organization and became its first owner.J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\tcreatedBy\x12\x99\x01\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB^\x92A[2ATimestamp when the organization was created (RFC3339 UTC format).J\x16\"2023-01-15T10:30:00Z\"R\tcreatedAt:\x98\x01\x92A\x94\x01\n" +
+	"\x91\x01*\fOrganization2aA group of users. Tokens are bound to one organization, and user queries only return its members.\xd2\x01\x02id\xd2\x01\x04name\xd2\x01\x04slug\xd2\x01\n" +
+	"created_at\"\xf0\a\n" +
+	"\x06Member\x12{\n" +
+	"\x0forganization_id\x18\x01 \x01(\tBR\x92AO2%ID of the organization (UUID format).J&\"c3d4e5f6-a7b8-9012-3456-7890abcdef12\"R\x0eorganizationId\x12e\n" +
+	"\auser_id\x18\x02 \x01(\tBL\x92AI2\x1fID of the member (UUID format).J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x06userId\x12n\n" +
+	"\x05email\x18\x03 \x01(\tBX\x92AU2;Email address of the member; empty if the user was deleted.J\x16\"john.doe@example.com\"R\x05email\x12E\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tB&\x92A#2\x19First name of the member.J\x06\"John\"R\tfirstName\x12A\n" +
+	"\tlast_name\x18\x05 \x01(\tB$\x92A!2\x18Last name of the member.J\x05\"Doe\"R\blastName\x12X\n" +
+	"\x04role\x18\x06 \x01(\tBD\x92AA25Role within the organization: owner, admin or member.J\b\"member\"R\x04role\x12\x9b\x01\n" +
+	"\n" +
+	"invited_by\x18\a \x01(\tB|\x92Ay2OID of the user who added the member; empty for the creator of the organization.J&\"b2c3d4e5-f6a7-8901-2345-67890abcdef1\"R\tinvitedBy\x12\x93\x01\n" +
+	"\tjoined_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampBZ\x92AW2=Timestamp when the user became a member (RFC3339 UTC format).J\x16\"2023-01-16T09:00:00Z\"R\bjoinedAt:z\x92Aw\n" +
+	"u*\x06Member2<A member of an organization and the member's role within it.\xd2\x01\x0forganization_id\xd2\x01\auser_id\xd2\x01\x04role\xd2\x01\tjoined_at\"\xc4\x02\n" +
+	"\x10CreateOrgRequest\x12k\n" +
+	"\x04name\x18\x01 \x01(\tBW\x92AT29Display name of the organization, at most 100 characters.J\x17\"Water Authority North\"R\x04name\x12\x97\x01\n" +
+	"\x04slug\x18\x02 \x01(\tB\x82\x01\x92A\x7f2dUnique URL-safe name: lowercase letters, digits and single dashes. Derived from the name when empty.J\x17\"water-authority-north\"R\x04slug:)\x92A&\n" +
+	"$*\x1bCreate Organization Request\xd2\x01\x04name\"\xf2\x02\n" +
+	"\x13InviteMemberRequest\x12i\n" +
+	"\x06org_id\x18\x01 \x01(\tBR\x92AO2%ID of the organization (UUID format).J&\"c3d4e5f6-a7b8-9012-3456-7890abcdef12\"R\x05orgId\x12]\n" +
+	"\x05email\x18\x02 \x01(\tBG\x92AD2*Email address of the existing user to add.J\x16\"jane.doe@example.com\"R\x05email\x12b\n" +
+	"\x04role\x18\x03 \x01(\tBN\x92AK2?Role within the organization: owner, admin or member (default).J\b\"member\"R\x04role:-\x92A*\n" +
+	"(*\x15Invite Member Request\xd2\x01\x06org_id\xd2\x01\x05email\"\xae\x01\n" +
+	"\x12ListMembersRequest\x12i\n" +
+	"\x06org_id\x18\x01 \x01(\tBR\x92AO2%ID of the organization (UUID format).J&\"c3d4e5f6-a7b8-9012-3456-7890abcdef12\"R\x05orgId\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.core.FilterOptionsR\aoptions\"\x83\x01\n" +
+	"\x13ListMembersResponse\x12-\n" +
+	"\amembers\x18\x01 \x03(\v2\x13.userservice.MemberR\amembers\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo\"\xed\x02\n" +
+	"\x17ChangeMemberRoleRequest\x12i\n" +
+	"\x06org_id\x18\x01 \x01(\tBR\x92AO2%ID of the organization (UUID format).J&\"c3d4e5f6-a7b8-9012-3456-7890abcdef12\"R\x05orgId\x12e\n" +
+	"\auser_id\x18\x02 \x01(\tBL\x92AI2\x1fID of the member (UUID format).J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x06userId\x12C\n" +
+	"\x04role\x18\x03 \x01(\tB/\x92A,2!New role: owner, admin or member.J\a\"admin\"R\x04role:;\x92A8\n" +
+	"6*\x1aChange Member Role Request\xd2\x01\x06org_id\xd2\x01\auser_id\xd2\x01\x04role2\xd7\b\n" +
+	"\x13OrganizationService\x12\xf1\x01\n" +
+	"\tCreateOrg\x12\x1d.userservice.CreateOrgRequest\x1a\x19.userservice.Organization\"\xa9\x01\x92A\x8e\x01\n" +
+	"\rOrganizations\x12\x13Create Organization\x1ahCreates an organization with the caller as its owner. Log in again with its ID to bind the tokens to it.\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/api/v1/orgs\x12\xf7\x01\n" +
+	"\fInviteMember\x12 .userservice.InviteMemberRequest\x1a\x13.userservice.Member\"\xaf\x01\x92A\x83\x01\n" +
+	"\rOrganizations\x12\rInvite Member\x1acAdds an existing user to the organization. Owners may add any role, admins only admins and members.\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/orgs/{org_id}/members\x12\xd7\x01\n" +
+	"\vListMembers\x12\x1f.userservice.ListMembersRequest\x1a .userservice.ListMembersResponse\"\x84\x01\x92A\\\n" +
+	"\rOrganizations\x12\fList Members\x1a=Returns the members of an organization the caller belongs to.\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/orgs/{org_id}/members\x12\xb1\x02\n" +
+	"\x10ChangeMemberRole\x12$.userservice.ChangeMemberRoleRequest\x1a\x13.userservice.Member\"\xe1\x01\x92A\xa8\x01\n" +
+	"\rOrganizations\x12\x12Change Member Role\x1a\x82\x01Changes the role of a member. The change applies to the member's tokens when they are refreshed; the last owner cannot be demoted.\x82\xd3\xe4\x93\x02,:\x01*2'/api/v1/orgs/{org_id}/members/{user_id}\x90\x02\x02\x1aD\x92AA\x12?Organizations group users; owners and admins manage the membersB5Z3golang-microservices-boilerplate/proto/user-serviceb\x06proto3"
+
+var (
+	file_proto_user_service_organization_proto_rawDescOnce sync.Once
+	file_proto_user_service_organization_proto_rawDescData []byte
+)
+
+func file_proto_user_service_organization_proto_rawDescGZIP() []byte {
+	file_proto_user_service_organization_proto_rawDescOnce.Do(func() {
+		file_proto_user_service_organization_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_service_organization_proto_rawDesc), len(file_proto_user_service_organization_proto_rawDesc)))
+	})
+	return file_proto_user_service_organization_proto_rawDescData
+}
+
+var file_proto_user_service_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_user_service_organization_proto_goTypes = []any{
+	(*Organization)(nil),            // 0: userservice.Organization
+	(*Member)(nil),                  // 1: userservice.Member
+	(*CreateOrgRequest)(nil),        // 2: userservice.CreateOrgRequest
+	(*InviteMemberRequest)(nil),     // 3: userservice.InviteMemberRequest
+	(*ListMembersRequest)(nil),      // 4: userservice.ListMembersRequest
+	(*ListMembersResponse)(nil),     // 5: userservice.ListMembersResponse
+	(*ChangeMemberRoleRequest)(nil), // 6: userservice.ChangeMemberRoleRequest
+	(*timestamppb.Timestamp)(nil),   // 7: google.protobuf.Timestamp
+	(*core.FilterOptions)(nil),      // 8: core.FilterOptions
+	(*core.PaginationInfo)(nil),     // 9: core.PaginationInfo
+}
+var file_proto_user_service_organization_proto_depIdxs = []int32{
+	7, // 0: userservice.Organization.created_at:type_name -> google.protobuf.Timestamp
+	7, // 1: userservice.Member.joined_at:type_name -> google.protobuf.Timestamp
+	8, // 2: userservice.ListMembersRequest.options:type_name -> core.FilterOptions
+	1, // 3: userservice.ListMembersResponse.members:type_name -> userservice.Member
+	9, // 4: userservice.ListMembersResponse.pagination_info:type_name -> core.PaginationInfo
+	2, // 5: userservice.OrganizationService.CreateOrg:input_type -> userservice.CreateOrgRequest
+	3, // 6: userservice.OrganizationService.InviteMember:input_type -> userservice.InviteMemberRequest
+	4, // 7: userservice.OrganizationService.ListMembers:input_type -> userservice.ListMembersRequest
+	6, // 8: userservice.OrganizationService.ChangeMemberRole:input_type -> userservice.ChangeMemberRoleRequest
+	0, // 9: userservice.OrganizationService.CreateOrg:output_type -> userservice.Organization
+	1, // 10: userservice.OrganizationService.InviteMember:output_type -> userservice.Member
+	5, // 11: userservice.OrganizationService.ListMembers:output_type -> userservice.ListMembersResponse
+	1, // 12: userservice.OrganizationService.ChangeMemberRole:output_type -> userservice.Member
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_service_organization_proto_init() }
+func file_proto_user_service_organization_proto_init() {
+	if File_proto_user_service_organization_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_organization_proto_rawDesc), len(file_proto_user_service_organization_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_user_service_organization_proto_goTypes,
+		DependencyIndexes: file_proto_user_service_organization_proto_depIdxs,
+		MessageInfos:      file_proto_user_service_organization_proto_msgTypes,
+	}.Build()
+	File_proto_user_service_organization_proto = out.File
+	file_proto_user_service_organization_proto_goTypes = nil
+	file_proto_user_service_organization_proto_depIdxs = nil
+}
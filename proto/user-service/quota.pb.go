@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/user-service/quota.proto
+
+package user_service
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request for the quota usage of a subject
+type ListQuotaUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotaUsageRequest) Reset() {
+	*x = ListQuotaUsageRequest{}
+	mi := &file_proto_user_service_quota_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotaUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotaUsageRequest) ProtoMessage() {}
+
+func (x *ListQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_quota_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*ListQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_quota_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListQuotaUsageRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// Usage of one quota by a subject
+type QuotaUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Used          int64                  `protobuf:"varint,3,opt,name=used,proto3" json:"used,omitempty"`
+	Limit         int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	SoftLimit     int64                  `protobuf:"varint,5,opt,name=soft_limit,json=softLimit,proto3" json:"soft_limit,omitempty"`
+	Remaining     int64                  `protobuf:"varint,6,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	OverSoftLimit bool                   `protobuf:"varint,7,opt,name=over_soft_limit,json=overSoftLimit,proto3" json:"over_soft_limit,omitempty"`
+	Period        string                 `protobuf:"bytes,8,opt,name=period,proto3" json:"period,omitempty"`
+	ResetsAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_proto_user_service_quota_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUsage) ProtoMessage() {}
+
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_quota_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaUsage.ProtoReflect.Descriptor instead.
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_quota_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuotaUsage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetSoftLimit() int64 {
+	if x != nil {
+		return x.SoftLimit
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetOverSoftLimit() bool {
+	if x != nil {
+		return x.OverSoftLimit
+	}
+	return false
+}
+
+func (x *QuotaUsage) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetResetsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return nil
+}
+
+// Response listing the quotas of a subject
+type ListQuotaUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usages        []*QuotaUsage          `protobuf:"bytes,1,rep,name=usages,proto3" json:"usages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotaUsageResponse) Reset() {
+	*x = ListQuotaUsageResponse{}
+	mi := &file_proto_user_service_quota_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotaUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotaUsageResponse) ProtoMessage() {}
+
+func (x *ListQuotaUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_quota_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotaUsageResponse.ProtoReflect.Descriptor instead.
+func (*ListQuotaUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_quota_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListQuotaUsageResponse) GetUsages() []*QuotaUsage {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+var File_proto_user_service_quota_proto protoreflect.FileDescriptor
+
+const file_proto_user_service_quota_proto_rawDesc = "" +
+	"\n" +
+	"\x1eproto/user-service/quota.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x93\x01\n" +
+	"\x15ListQuotaUsageRequest\x12z\n" +
+	"\asubject\x18\x01 \x01(\tB`\x92A]2QTenant or user the quotas apply to; defaults to 'global' for service-wide quotas.J\b\"global\"R\asubject\"\xb0\x05\n" +
+	"\n" +
+	"QuotaUsage\x124\n" +
+	"\x04name\x18\x01 \x01(\tB \x92A\x1d2\x12Name of the quota.J\a\"users\"R\x04name\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x12\n" +
+	"\x04used\x18\x03 \x01(\x03R\x04used\x129\n" +
+	"\x05limit\x18\x04 \x01(\x03B#\x92A 2\x1eHard limit; 0 means unlimited.R\x05limit\x12D\n" +
+	"\n" +
+	"soft_limit\x18\x05 \x01(\x03B%\x92A\"2 Warning threshold; 0 means none.R\tsoftLimit\x12W\n" +
+	"\tremaining\x18\x06 \x01(\x03B9\x92A624Usage left before the hard limit; -1 when unlimited.R\tremaining\x12&\n" +
+	"\x0fover_soft_limit\x18\a \x01(\bR\roverSoftLimit\x12f\n" +
+	"\x06period\x18\b \x01(\tBN\x92AK2ILength of the counting window, e.g. '24h0m0s'; empty for lifetime quotas.R\x06period\x12v\n" +
+	"\tresets_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB=\x92A:28When the current window ends; unset for lifetime quotas.R\bresetsAt:\\\x92AY\n" +
+	"W*\vQuota Usage2(Current usage of a quota and its limits.\xd2\x01\x04name\xd2\x01\asubject\xd2\x01\x04used\xd2\x01\x05limit\"I\n" +
+	"\x16ListQuotaUsageResponse\x12/\n" +
+	"\x06usages\x18\x01 \x03(\v2\x17.userservice.QuotaUsageR\x06usages2\x8a\x02\n" +
+	"\fQuotaService\x12\xca\x01\n" +
+	"\x0eListQuotaUsage\x12\".userservice.ListQuotaUsageRequest\x1a#.userservice.ListQuotaUsageResponse\"o\x92AV\n" +
+	"\x06Quotas\x12\x10List Quota Usage\x1a:Returns the usage and limits of every quota for a subject.\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/quotas\x1a-\x92A*\x12(Usage limits per tenant, user or serviceB5Z3golang-microservices-boilerplate/proto/user-serviceb\x06proto3"
+
+var (
+	file_proto_user_service_quota_proto_rawDescOnce sync.Once
+	file_proto_user_service_quota_proto_rawDescData []byte
+)
+
+func file_proto_user_service_quota_proto_rawDescGZIP() []byte {
+	file_proto_user_service_quota_proto_rawDescOnce.Do(func() {
+		file_proto_user_service_quota_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_service_quota_proto_rawDesc), len(file_proto_user_service_quota_proto_rawDesc)))
+	})
+	return file_proto_user_service_quota_proto_rawDescData
+}
+
+var file_proto_user_service_quota_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_user_service_quota_proto_goTypes = []any{
+	(*ListQuotaUsageRequest)(nil),  // 0: userservice.ListQuotaUsageRequest
+	(*QuotaUsage)(nil),             // 1: userservice.QuotaUsage
+	(*ListQuotaUsageResponse)(nil), // 2: userservice.ListQuotaUsageResponse
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+}
+var file_proto_user_service_quota_proto_depIdxs = []int32{
+	3, // 0: userservice.QuotaUsage.resets_at:type_name -> google.protobuf.Timestamp
+	1, // 1: userservice.ListQuotaUsageResponse.usages:type_name -> userservice.QuotaUsage
+	0, // 2: userservice.QuotaService.ListQuotaUsage:input_type -> userservice.ListQuotaUsageRequest
+	2, // 3: userservice.QuotaService.ListQuotaUsage:output_type -> userservice.ListQuotaUsageResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_service_quota_proto_init() }
+func file_proto_user_service_quota_proto_init() {
+	if File_proto_user_service_quota_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_quota_proto_rawDesc), len(file_proto_user_service_quota_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_user_service_quota_proto_goTypes,
+		DependencyIndexes: file_proto_user_service_quota_proto_depIdxs,
+		MessageInfos:      file_proto_user_service_quota_proto_msgTypes,
+	}.Build()
+	File_proto_user_service_quota_proto = out.File
+	file_proto_user_service_quota_proto_goTypes = nil
+	file_proto_user_service_quota_proto_depIdxs = nil
+}
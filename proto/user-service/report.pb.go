@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/user-service/report.proto
+
+package user_service
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request to render a report
+type CreateReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReportRequest) Reset() {
+	*x = CreateReportRequest{}
+	mi := &file_proto_user_service_report_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReportRequest) ProtoMessage() {}
+
+func (x *CreateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_report_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReportRequest.ProtoReflect.Descriptor instead.
+func (*CreateReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateReportRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateReportRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *CreateReportRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// Request identifying a report of the caller
+type ReportIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportIDRequest) Reset() {
+	*x = ReportIDRequest{}
+	mi := &file_proto_user_service_report_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportIDRequest) ProtoMessage() {}
+
+func (x *ReportIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_report_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportIDRequest.ProtoReflect.Descriptor instead.
+func (*ReportIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReportIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// A report rendered in the background
+type Report struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	DownloadUrl   string                 `protobuf:"bytes,7,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_proto_user_service_report_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_report_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Report) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Report) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Report) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Report) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Report) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Report) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Report) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *Report) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Report) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Report) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Report) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+var File_proto_user_service_report_proto protoreflect.FileDescriptor
+
+const file_proto_user_service_report_proto_rawDesc = "" +
+	"\n" +
+	"\x1fproto/user-service/report.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x9e\x04\n" +
+	"\x13CreateReportRequest\x12\x93\x01\n" +
+	"\x04type\x18\x01 \x01(\tB\x7f\x92A|2iReport template: user_activity (the caller's security events) or user_directory (all users, admins only).J\x0f\"user_activity\"R\x04type\x12H\n" +
+	"\x06format\x18\x02 \x01(\tB0\x92A-2#File format: pdf (default) or xlsx.J\x06\"xlsx\"R\x06format\x12\xeb\x01\n" +
+	"\x06params\x18\x03 \x03(\v2,.userservice.CreateReportRequest.ParamsEntryB\xa4\x01\x92A\xa0\x012rTemplate parameters, e.g. from and to dates (YYYY-MM-DD) for user_activity, or role and active for user_directory.J*{\"from\": \"2023-01-01\", \"to\": \"2023-01-31\"}R\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
+	"\x0fReportIDRequest\x12\\\n" +
+	"\x02id\x18\x01 \x01(\tBL\x92AI2\x1fID of the report (UUID format).J&\"f6a7b8c9-d0e1-2345-6789-0abcdef12345\"R\x02id\"\xc6\n" +
+	"\n" +
+	"\x06Report\x12k\n" +
+	"\x02id\x18\x01 \x01(\tB[\x92AX2.Unique identifier of the report (UUID format).J&\"f6a7b8c9-d0e1-2345-6789-0abcdef12345\"R\x02id\x12:\n" +
+	"\x04type\x18\x02 \x01(\tB&\x92A#2\x10Report template.J\x0f\"user_activity\"R\x04type\x12=\n" +
+	"\x06format\x18\x03 \x01(\tB%\x92A\"2\x19File format: pdf or xlsx.J\x05\"pdf\"R\x06format\x127\n" +
+	"\x06params\x18\x04 \x03(\v2\x1f.userservice.Report.ParamsEntryR\x06params\x12j\n" +
+	"\x06status\x18\x05 \x01(\tBR\x92AO2Dpending while the report is rendered, then ready, failed or expired.J\a\"ready\"R\x06status\x12I\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03B*\x92A'2\x1cSize of the file once ready.J\a\"20480\"R\tsizeBytes\x12\xa1\x01\n" +
+	"\fdownload_url\x18\a \x01(\tB~\x92A{28Gateway path of the file; set while the report is ready.J?\"/api/v1/reports/f6a7b8c9-d0e1-2345-6789-0abcdef12345/download\"R\vdownloadUrl\x12;\n" +
+	"\x05error\x18\b \x01(\tB%\x92A\"2 Why rendering failed, if it did.R\x05error\x12\x95\x01\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampBZ\x92AW2=Timestamp when the report was requested (RFC3339 UTC format).J\x16\"2023-01-20T08:00:00Z\"R\tcreatedAt\x12\x9d\x01\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB^\x92A[2ATimestamp when rendering finished or failed (RFC3339 UTC format).J\x16\"2023-01-20T08:01:00Z\"R\vcompletedAt\x12\x97\x01\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB\\\x92AY2?Timestamp after which the file is deleted (RFC3339 UTC format).J\x16\"2023-01-27T08:01:00Z\"R\texpiresAt\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:v\x92As\n" +
+	"q*\x06Report2<A report requested by the caller and the status of its file.\xd2\x01\x02id\xd2\x01\x04type\xd2\x01\x06format\xd2\x01\x06status\xd2\x01\n" +
+	"created_at2\xba\x05\n" +
+	"\rReportService\x12\x81\x02\n" +
+	"\fCreateReport\x12 .userservice.CreateReportRequest\x1a\x13.userservice.Report\"\xb9\x01\x92A\x9b\x01\n" +
+	"\aReports\x12\rCreate Report\x1a\x80\x01Queues a report for rendering. A notification with the download link is sent once it is ready; poll Get Report Status otherwise.\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/reports\x12\xb0\x01\n" +
+	"\x0fGetReportStatus\x12\x1c.userservice.ReportIDRequest\x1a\x13.userservice.Report\"j\x92AK\n" +
+	"\aReports\x12\x11Get Report Status\x1a-Returns the status of a report of the caller.\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/reports/{id}\x12\xc8\x01\n" +
+	"\x0eDownloadReport\x12\x1c.userservice.ReportIDRequest\x1a\x14.google.api.HttpBody\"\x81\x01\x92AY\n" +
+	"\aReports\x12\x0fDownload Report\x1a=Returns the PDF or XLSX file of a ready report of the caller.\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/reports/{id}/download\x1a'\x92A$\x12\"Reports rendered from service dataB5Z3golang-microservices-boilerplate/proto/user-serviceb\x06proto3"
+
+var (
+	file_proto_user_service_report_proto_rawDescOnce sync.Once
+	file_proto_user_service_report_proto_rawDescData []byte
+)
+
+func file_proto_user_service_report_proto_rawDescGZIP() []byte {
+	file_proto_user_service_report_proto_rawDescOnce.Do(func() {
+		file_proto_user_service_report_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_service_report_proto_rawDesc), len(file_proto_user_service_report_proto_rawDesc)))
+	})
+	return file_proto_user_service_report_proto_rawDescData
+}
+
+var file_proto_user_service_report_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_user_service_report_proto_goTypes = []any{
+	(*CreateReportRequest)(nil),   // 0: userservice.CreateReportRequest
+	(*ReportIDRequest)(nil),       // 1: userservice.ReportIDRequest
+	(*Report)(nil),                // 2: userservice.Report
+	nil,                           // 3: userservice.CreateReportRequest.ParamsEntry
+	nil,                           // 4: userservice.Report.ParamsEntry
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*httpbody.HttpBody)(nil),     // 6: google.api.HttpBody
+}
+var file_proto_user_service_report_proto_depIdxs = []int32{
+	3, // 0: userservice.CreateReportRequest.params:type_name -> userservice.CreateReportRequest.ParamsEntry
+	4, // 1: userservice.Report.params:type_name -> userservice.Report.ParamsEntry
+	5, // 2: userservice.Report.created_at:type_name -> google.protobuf.Timestamp
+	5, // 3: userservice.Report.completed_at:type_name -> google.protobuf.Timestamp
+	5, // 4: userservice.Report.expires_at:type_name -> google.protobuf.Timestamp
+	0, // 5: userservice.ReportService.CreateReport:input_type -> userservice.CreateReportRequest
+	1, // 6: userservice.ReportService.GetReportStatus:input_type -> userservice.ReportIDRequest
+	1, // 7: userservice.ReportService.DownloadReport:input_type -> userservice.ReportIDRequest
+	2, // 8: userservice.ReportService.CreateReport:output_type -> userservice.Report
+	2, // 9: userservice.ReportService.GetReportStatus:output_type -> userservice.Report
+	6, // 10: userservice.ReportService.DownloadReport:output_type -> google.api.HttpBody
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_service_report_proto_init() }
+func file_proto_user_service_report_proto_init() {
+	if File_proto_user_service_report_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_report_proto_rawDesc), len(file_proto_user_service_report_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_user_service_report_proto_goTypes,
+		DependencyIndexes: file_proto_user_service_report_proto_depIdxs,
+		MessageInfos:      file_proto_user_service_report_proto_msgTypes,
+	}.Build()
+	File_proto_user_service_report_proto = out.File
+	file_proto_user_service_report_proto_goTypes = nil
+	file_proto_user_service_report_proto_depIdxs = nil
+}
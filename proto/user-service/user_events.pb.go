@@ -0,0 +1,575 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/user-service/user_events.proto
+
+package user_service
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Data of user.created and user.updated events
+type UserEventV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // User ID (UUID)
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName     string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEventV1) Reset() {
+	*x = UserEventV1{}
+	mi := &file_proto_user_service_user_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEventV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEventV1) ProtoMessage() {}
+
+func (x *UserEventV1) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEventV1.ProtoReflect.Descriptor instead.
+func (*UserEventV1) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserEventV1) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserEventV1) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserEventV1) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserEventV1) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UserEventV1) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UserEventV1) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserEventV1) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+// Data of user.deleted events
+type UserDeletedEventV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // User ID (UUID)
+	HardDelete    bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"` // Whether the row was removed rather than soft deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDeletedEventV1) Reset() {
+	*x = UserDeletedEventV1{}
+	mi := &file_proto_user_service_user_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeletedEventV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeletedEventV1) ProtoMessage() {}
+
+func (x *UserDeletedEventV1) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeletedEventV1.ProtoReflect.Descriptor instead.
+func (*UserDeletedEventV1) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserDeletedEventV1) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserDeletedEventV1) GetHardDelete() bool {
+	if x != nil {
+		return x.HardDelete
+	}
+	return false
+}
+
+// Data of user.erased events
+type UserErasedEventV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // User ID (UUID)
+	ErasedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserErasedEventV1) Reset() {
+	*x = UserErasedEventV1{}
+	mi := &file_proto_user_service_user_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserErasedEventV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserErasedEventV1) ProtoMessage() {}
+
+func (x *UserErasedEventV1) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserErasedEventV1.ProtoReflect.Descriptor instead.
+func (*UserErasedEventV1) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserErasedEventV1) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserErasedEventV1) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+// State of a users row in user.changed events, without the password hash
+type UserSnapshotV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName     string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Phone         string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address       string                 `protobuf:"bytes,9,opt,name=address,proto3" json:"address,omitempty"`
+	Age           int32                  `protobuf:"varint,10,opt,name=age,proto3" json:"age,omitempty"`
+	ProfilePic    string                 `protobuf:"bytes,11,opt,name=profile_pic,json=profilePic,proto3" json:"profile_pic,omitempty"`
+	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSnapshotV1) Reset() {
+	*x = UserSnapshotV1{}
+	mi := &file_proto_user_service_user_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSnapshotV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSnapshotV1) ProtoMessage() {}
+
+func (x *UserSnapshotV1) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSnapshotV1.ProtoReflect.Descriptor instead.
+func (*UserSnapshotV1) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserSnapshotV1) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UserSnapshotV1) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *UserSnapshotV1) GetProfilePic() string {
+	if x != nil {
+		return x.ProfilePic
+	}
+	return ""
+}
+
+func (x *UserSnapshotV1) GetLastLoginAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return nil
+}
+
+func (x *UserSnapshotV1) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserSnapshotV1) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserSnapshotV1) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// Data of user.changed events
+type UserChangedEventV1 struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Operation       string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`                              // create, update, delete or restore
+	Id              string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                                            // User ID (UUID)
+	Old             *UserSnapshotV1        `protobuf:"bytes,3,opt,name=old,proto3" json:"old,omitempty"`                                          // Unset for creates
+	New             *UserSnapshotV1        `protobuf:"bytes,4,opt,name=new,proto3" json:"new,omitempty"`                                          // Unset for hard deletes
+	ChangedFields   []string               `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // JSON names of the fields that differ
+	PasswordChanged bool                   `protobuf:"varint,6,opt,name=password_changed,json=passwordChanged,proto3" json:"password_changed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserChangedEventV1) Reset() {
+	*x = UserChangedEventV1{}
+	mi := &file_proto_user_service_user_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserChangedEventV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserChangedEventV1) ProtoMessage() {}
+
+func (x *UserChangedEventV1) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserChangedEventV1.ProtoReflect.Descriptor instead.
+func (*UserChangedEventV1) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserChangedEventV1) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *UserChangedEventV1) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserChangedEventV1) GetOld() *UserSnapshotV1 {
+	if x != nil {
+		return x.Old
+	}
+	return nil
+}
+
+func (x *UserChangedEventV1) GetNew() *UserSnapshotV1 {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+func (x *UserChangedEventV1) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *UserChangedEventV1) GetPasswordChanged() bool {
+	if x != nil {
+		return x.PasswordChanged
+	}
+	return false
+}
+
+var File_proto_user_service_user_events_proto protoreflect.FileDescriptor
+
+const file_proto_user_service_user_events_proto_rawDesc = "" +
+	"\n" +
+	"$proto/user-service/user_events.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbc\x01\n" +
+	"\vUserEventV1\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\"E\n" +
+	"\x12UserDeletedEventV1\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vhard_delete\x18\x02 \x01(\bR\n" +
+	"hardDelete\"\\\n" +
+	"\x11UserErasedEventV1\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\terased_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\berasedAt\"\x93\x04\n" +
+	"\x0eUserSnapshotV1\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x14\n" +
+	"\x05phone\x18\b \x01(\tR\x05phone\x12\x18\n" +
+	"\aaddress\x18\t \x01(\tR\aaddress\x12\x10\n" +
+	"\x03age\x18\n" +
+	" \x01(\x05R\x03age\x12\x1f\n" +
+	"\vprofile_pic\x18\v \x01(\tR\n" +
+	"profilePic\x12>\n" +
+	"\rlast_login_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xf2\x01\n" +
+	"\x12UserChangedEventV1\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12-\n" +
+	"\x03old\x18\x03 \x01(\v2\x1b.userservice.UserSnapshotV1R\x03old\x12-\n" +
+	"\x03new\x18\x04 \x01(\v2\x1b.userservice.UserSnapshotV1R\x03new\x12%\n" +
+	"\x0echanged_fields\x18\x05 \x03(\tR\rchangedFields\x12)\n" +
+	"\x10password_changed\x18\x06 \x01(\bR\x0fpasswordChangedB5Z3golang-microservices-boilerplate/proto/user-serviceb\x06proto3"
+
+var (
+	file_proto_user_service_user_events_proto_rawDescOnce sync.Once
+	file_proto_user_service_user_events_proto_rawDescData []byte
+)
+
+func file_proto_user_service_user_events_proto_rawDescGZIP() []byte {
+	file_proto_user_service_user_events_proto_rawDescOnce.Do(func() {
+		file_proto_user_service_user_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_service_user_events_proto_rawDesc), len(file_proto_user_service_user_events_proto_rawDesc)))
+	})
+	return file_proto_user_service_user_events_proto_rawDescData
+}
+
+var file_proto_user_service_user_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_user_service_user_events_proto_goTypes = []any{
+	(*UserEventV1)(nil),           // 0: userservice.UserEventV1
+	(*UserDeletedEventV1)(nil),    // 1: userservice.UserDeletedEventV1
+	(*UserErasedEventV1)(nil),     // 2: userservice.UserErasedEventV1
+	(*UserSnapshotV1)(nil),        // 3: userservice.UserSnapshotV1
+	(*UserChangedEventV1)(nil),    // 4: userservice.UserChangedEventV1
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_proto_user_service_user_events_proto_depIdxs = []int32{
+	5, // 0: userservice.UserErasedEventV1.erased_at:type_name -> google.protobuf.Timestamp
+	5, // 1: userservice.UserSnapshotV1.last_login_at:type_name -> google.protobuf.Timestamp
+	5, // 2: userservice.UserSnapshotV1.created_at:type_name -> google.protobuf.Timestamp
+	5, // 3: userservice.UserSnapshotV1.updated_at:type_name -> google.protobuf.Timestamp
+	5, // 4: userservice.UserSnapshotV1.deleted_at:type_name -> google.protobuf.Timestamp
+	3, // 5: userservice.UserChangedEventV1.old:type_name -> userservice.UserSnapshotV1
+	3, // 6: userservice.UserChangedEventV1.new:type_name -> userservice.UserSnapshotV1
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_service_user_events_proto_init() }
+func file_proto_user_service_user_events_proto_init() {
+	if File_proto_user_service_user_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_user_events_proto_rawDesc), len(file_proto_user_service_user_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_user_service_user_events_proto_goTypes,
+		DependencyIndexes: file_proto_user_service_user_events_proto_depIdxs,
+		MessageInfos:      file_proto_user_service_user_events_proto_msgTypes,
+	}.Build()
+	File_proto_user_service_user_events_proto = out.File
+	file_proto_user_service_user_events_proto_goTypes = nil
+	file_proto_user_service_user_events_proto_depIdxs = nil
+}
@@ -0,0 +1,750 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/user-service/webhook.proto
+
+package user_service
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A subscription of an external endpoint to domain events
+type WebhookSubscription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Active        bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	Secret        string                 `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscription) ProtoMessage() {}
+
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSubscription.ProtoReflect.Descriptor instead.
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebhookSubscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *WebhookSubscription) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// One event sent to one subscription
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId        string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts       int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastStatusCode int32                  `protobuf:"varint,6,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError      string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Request for creating a webhook subscription
+type CreateWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Request for listing webhook subscriptions
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"` // Pagination and sorting options
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListWebhooksRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response containing a page of webhook subscriptions
+type ListWebhooksResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks       []*WebhookSubscription `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*WebhookSubscription {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+func (x *ListWebhooksResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request identifying a webhook subscription
+type WebhookIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookIDRequest) Reset() {
+	*x = WebhookIDRequest{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookIDRequest) ProtoMessage() {}
+
+func (x *WebhookIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookIDRequest.ProtoReflect.Descriptor instead.
+func (*WebhookIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WebhookIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Request for pausing or resuming a webhook subscription
+type SetWebhookActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWebhookActiveRequest) Reset() {
+	*x = SetWebhookActiveRequest{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWebhookActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWebhookActiveRequest) ProtoMessage() {}
+
+func (x *SetWebhookActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWebhookActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetWebhookActiveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetWebhookActiveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetWebhookActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// Request for listing the delivery log of a subscription
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Options       *core.FilterOptions    `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"` // Pagination and sorting options
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response containing a page of webhook deliveries
+type ListWebhookDeliveriesResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries     []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_proto_user_service_webhook_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_webhook_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_webhook_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+var File_proto_user_service_webhook_proto protoreflect.FileDescriptor
+
+const file_proto_user_service_webhook_proto_rawDesc = "" +
+	"\n" +
+	" proto/user-service/webhook.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17proto/core/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc9\a\n" +
+	"\x13WebhookSubscription\x12q\n" +
+	"\x02id\x18\x01 \x01(\tBa\x92A^24Unique identifier of the subscription (UUID format).J&\"d4e5f6a7-b8c9-0123-4567-890abcdef123\"R\x02id\x12`\n" +
+	"\x03url\x18\x02 \x01(\tBN\x92AK2&Endpoint that receives the deliveries.J!\"https://example.com/hooks/users\"R\x03url\x12p\n" +
+	"\vevent_types\x18\x03 \x03(\tBO\x92AL2JSubscribed event types, e.g. 'user.created'; '*' subscribes to all events.R\n" +
+	"eventTypes\x12[\n" +
+	"\vdescription\x18\x04 \x01(\tB9\x92A62(Free-form description of the subscriber.J\n" +
+	"\"CRM sync\"R\vdescription\x12T\n" +
+	"\x06active\x18\x05 \x01(\bB<\x92A921Inactive subscriptions receive no new deliveries.J\x04trueR\x06active\x12y\n" +
+	"\x06secret\x18\x06 \x01(\tBa\x92A^2KHMAC-SHA256 signing secret. Only returned when the subscription is created.J\x0f\"whsec_3f2a...\"R\x06secret\x12\x99\x01\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB^\x92A[2ATimestamp when the subscription was created (RFC3339 UTC format).J\x16\"2023-01-15T10:30:00Z\"R\tcreatedAt:\xa0\x01\x92A\x9c\x01\n" +
+	"\x99\x01*\x14Webhook Subscription2RAn external endpoint that receives signed POST requests for the subscribed events.\xd2\x01\x02id\xd2\x01\x03url\xd2\x01\vevent_types\xd2\x01\x06active\xd2\x01\n" +
+	"created_at\"\xa5\b\n" +
+	"\x0fWebhookDelivery\x12E\n" +
+	"\x02id\x18\x01 \x01(\tB5\x92A220Unique identifier of the delivery (UUID format).R\x02id\x12`\n" +
+	"\bevent_id\x18\x02 \x01(\tBE\x92AB2@ID of the delivered event, also sent as the X-Webhook-ID header.R\aeventId\x12P\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tB1\x92A.2\x1cType of the delivered event.J\x0e\"user.created\"R\teventType\x12^\n" +
+	"\x06status\x18\x04 \x01(\tBF\x92AC24Delivery status: 'pending', 'succeeded' or 'failed'.J\v\"succeeded\"R\x06status\x12C\n" +
+	"\battempts\x18\x05 \x01(\x05B'\x92A$2\x1fNumber of attempts made so far.J\x011R\battempts\x12\x81\x01\n" +
+	"\x10last_status_code\x18\x06 \x01(\x05BW\x92AT2MHTTP status code of the last attempt, 0 if the endpoint could not be reached.J\x03200R\x0elastStatusCode\x12E\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tB&\x92A#2!Error of the last failed attempt.R\tlastError\x12\x86\x01\n" +
+	"\x0fnext_attempt_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampBB\x92A?2=When the next attempt is scheduled (pending deliveries only).R\rnextAttemptAt\x12l\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB-\x92A*2(When the endpoint accepted the delivery.R\vdeliveredAt\x12_\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB$\x92A!2\x1fWhen the delivery was enqueued.R\tcreatedAt:O\x92AL\n" +
+	"J*\x10Webhook Delivery26Delivery log entry of an event sent to a subscription.\"\x9e\x01\n" +
+	"\x14CreateWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription:1\x92A.\n" +
+	",*\x16Create Webhook Request\xd2\x01\x03url\xd2\x01\vevent_types\"D\n" +
+	"\x13ListWebhooksRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions\"\x93\x01\n" +
+	"\x14ListWebhooksResponse\x12<\n" +
+	"\bwebhooks\x18\x01 \x03(\v2 .userservice.WebhookSubscriptionR\bwebhooks\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo\"S\n" +
+	"\x10WebhookIDRequest\x12?\n" +
+	"\x02id\x18\x01 \x01(\tB/\x92A,2*The unique identifier of the subscription.R\x02id\"A\n" +
+	"\x17SetWebhookActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"\x8e\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12?\n" +
+	"\x02id\x18\x01 \x01(\tB/\x92A,2*The unique identifier of the subscription.R\x02id\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.core.FilterOptionsR\aoptions\"\x9c\x01\n" +
+	"\x1dListWebhookDeliveriesResponse\x12<\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1c.userservice.WebhookDeliveryR\n" +
+	"deliveries\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo2\xaa\b\n" +
+	"\x0eWebhookService\x12\xfa\x01\n" +
+	"\rCreateWebhook\x12!.userservice.CreateWebhookRequest\x1a .userservice.WebhookSubscription\"\xa3\x01\x92A\x84\x01\n" +
+	"\bWebhooks\x12\x0eCreate Webhook\x1ahSubscribes an endpoint to events. The response contains the signing secret, which is not returned again.\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/webhooks\x12\x89\x01\n" +
+	"\fListWebhooks\x12 .userservice.ListWebhooksRequest\x1a!.userservice.ListWebhooksResponse\"4\x92A\x19\n" +
+	"\bWebhooks\x12\rList Webhooks\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/webhooks\x12\xa5\x01\n" +
+	"\x10SetWebhookActive\x12$.userservice.SetWebhookActiveRequest\x1a .userservice.WebhookSubscription\"I\x92A#\n" +
+	"\bWebhooks\x12\x17Pause or Resume Webhook\x82\xd3\xe4\x93\x02\x1a:\x01*2\x15/api/v1/webhooks/{id}\x90\x02\x02\x12\x82\x01\n" +
+	"\rDeleteWebhook\x12\x1d.userservice.WebhookIDRequest\x1a\x16.google.protobuf.Empty\":\x92A\x1a\n" +
+	"\bWebhooks\x12\x0eDelete Webhook\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/webhooks/{id}\x12\x93\x02\n" +
+	"\x15ListWebhookDeliveries\x12).userservice.ListWebhookDeliveriesRequest\x1a*.userservice.ListWebhookDeliveriesResponse\"\xa2\x01\x92Aw\n" +
+	"\bWebhooks\x12\x17List Webhook Deliveries\x1aRReturns the delivery log of a subscription, including attempts and the last error.\x82\xd3\xe4\x93\x02\"\x12 /api/v1/webhooks/{id}/deliveries\x1aL\x92AI\x12GSubscriptions of external systems to domain events such as user.createdB5Z3golang-microservices-boilerplate/proto/user-serviceb\x06proto3"
+
+var (
+	file_proto_user_service_webhook_proto_rawDescOnce sync.Once
+	file_proto_user_service_webhook_proto_rawDescData []byte
+)
+
+func file_proto_user_service_webhook_proto_rawDescGZIP() []byte {
+	file_proto_user_service_webhook_proto_rawDescOnce.Do(func() {
+		file_proto_user_service_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_user_service_webhook_proto_rawDesc), len(file_proto_user_service_webhook_proto_rawDesc)))
+	})
+	return file_proto_user_service_webhook_proto_rawDescData
+}
+
+var file_proto_user_service_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_user_service_webhook_proto_goTypes = []any{
+	(*WebhookSubscription)(nil),           // 0: userservice.WebhookSubscription
+	(*WebhookDelivery)(nil),               // 1: userservice.WebhookDelivery
+	(*CreateWebhookRequest)(nil),          // 2: userservice.CreateWebhookRequest
+	(*ListWebhooksRequest)(nil),           // 3: userservice.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),          // 4: userservice.ListWebhooksResponse
+	(*WebhookIDRequest)(nil),              // 5: userservice.WebhookIDRequest
+	(*SetWebhookActiveRequest)(nil),       // 6: userservice.SetWebhookActiveRequest
+	(*ListWebhookDeliveriesRequest)(nil),  // 7: userservice.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 8: userservice.ListWebhookDeliveriesResponse
+	(*timestamppb.Timestamp)(nil),         // 9: google.protobuf.Timestamp
+	(*core.FilterOptions)(nil),            // 10: core.FilterOptions
+	(*core.PaginationInfo)(nil),           // 11: core.PaginationInfo
+	(*emptypb.Empty)(nil),                 // 12: google.protobuf.Empty
+}
+var file_proto_user_service_webhook_proto_depIdxs = []int32{
+	9,  // 0: userservice.WebhookSubscription.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: userservice.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: userservice.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: userservice.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	10, // 4: userservice.ListWebhooksRequest.options:type_name -> core.FilterOptions
+	0,  // 5: userservice.ListWebhooksResponse.webhooks:type_name -> userservice.WebhookSubscription
+	11, // 6: userservice.ListWebhooksResponse.pagination_info:type_name -> core.PaginationInfo
+	10, // 7: userservice.ListWebhookDeliveriesRequest.options:type_name -> core.FilterOptions
+	1,  // 8: userservice.ListWebhookDeliveriesResponse.deliveries:type_name -> userservice.WebhookDelivery
+	11, // 9: userservice.ListWebhookDeliveriesResponse.pagination_info:type_name -> core.PaginationInfo
+	2,  // 10: userservice.WebhookService.CreateWebhook:input_type -> userservice.CreateWebhookRequest
+	3,  // 11: userservice.WebhookService.ListWebhooks:input_type -> userservice.ListWebhooksRequest
+	6,  // 12: userservice.WebhookService.SetWebhookActive:input_type -> userservice.SetWebhookActiveRequest
+	5,  // 13: userservice.WebhookService.DeleteWebhook:input_type -> userservice.WebhookIDRequest
+	7,  // 14: userservice.WebhookService.ListWebhookDeliveries:input_type -> userservice.ListWebhookDeliveriesRequest
+	0,  // 15: userservice.WebhookService.CreateWebhook:output_type -> userservice.WebhookSubscription
+	4,  // 16: userservice.WebhookService.ListWebhooks:output_type -> userservice.ListWebhooksResponse
+	0,  // 17: userservice.WebhookService.SetWebhookActive:output_type -> userservice.WebhookSubscription
+	12, // 18: userservice.WebhookService.DeleteWebhook:output_type -> google.protobuf.Empty
+	8,  // 19: userservice.WebhookService.ListWebhookDeliveries:output_type -> userservice.ListWebhookDeliveriesResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_proto_user_service_webhook_proto_init() }
+func file_proto_user_service_webhook_proto_init() {
+	if File_proto_user_service_webhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_webhook_proto_rawDesc), len(file_proto_user_service_webhook_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_user_service_webhook_proto_goTypes,
+		DependencyIndexes: file_proto_user_service_webhook_proto_depIdxs,
+		MessageInfos:      file_proto_user_service_webhook_proto_msgTypes,
+	}.Build()
+	File_proto_user_service_webhook_proto = out.File
+	file_proto_user_service_webhook_proto_goTypes = nil
+	file_proto_user_service_webhook_proto_depIdxs = nil
+}
@@ -0,0 +1,675 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/water-quality-service/water_quality.proto
+
+package water_quality_service
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// One message of an upload stream: the filename, then the file type, then the file content in chunks
+type UploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadRequest_Filename
+	//	*UploadRequest_FileType
+	//	*UploadRequest_DataChunk
+	Payload       isUploadRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_water_quality_service_water_quality_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadRequest) GetPayload() isUploadRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadRequest) GetFilename() string {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadRequest_Filename); ok {
+			return x.Filename
+		}
+	}
+	return ""
+}
+
+func (x *UploadRequest) GetFileType() string {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadRequest_FileType); ok {
+			return x.FileType
+		}
+	}
+	return ""
+}
+
+func (x *UploadRequest) GetDataChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadRequest_DataChunk); ok {
+			return x.DataChunk
+		}
+	}
+	return nil
+}
+
+type isUploadRequest_Payload interface {
+	isUploadRequest_Payload()
+}
+
+type UploadRequest_Filename struct {
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3,oneof"` // Name of the uploaded file
+}
+
+type UploadRequest_FileType struct {
+	FileType string `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3,oneof"` // Format of the file; only csv is ingested
+}
+
+type UploadRequest_DataChunk struct {
+	DataChunk []byte `protobuf:"bytes,3,opt,name=data_chunk,json=dataChunk,proto3,oneof"` // Next part of the file content
+}
+
+func (*UploadRequest_Filename) isUploadRequest_Payload() {}
+
+func (*UploadRequest_FileType) isUploadRequest_Payload() {}
+
+func (*UploadRequest_DataChunk) isUploadRequest_Payload() {}
+
+// Outcome of an upload
+type UploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Rows          int64                  `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	Ingested      int64                  `protobuf:"varint,3,opt,name=ingested,proto3" json:"ingested,omitempty"`
+	Failures      []*core.BatchFailure   `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"` // Rejected rows; index is the line number in the file
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadResponse) Reset() {
+	*x = UploadResponse{}
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadResponse) ProtoMessage() {}
+
+func (x *UploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
+func (*UploadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_water_quality_service_water_quality_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadResponse) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetIngested() int64 {
+	if x != nil {
+		return x.Ingested
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetFailures() []*core.BatchFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// A single measurement
+type Measurement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     string                 `protobuf:"bytes,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Parameter     string                 `protobuf:"bytes,2,opt,name=parameter,proto3" json:"parameter,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	MeasuredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=measured_at,json=measuredAt,proto3" json:"measured_at,omitempty"`
+	Location      *core.GeoPoint         `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"` // Location of the station; unset when the uploaded file had none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Measurement) Reset() {
+	*x = Measurement{}
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Measurement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Measurement) ProtoMessage() {}
+
+func (x *Measurement) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Measurement.ProtoReflect.Descriptor instead.
+func (*Measurement) Descriptor() ([]byte, []int) {
+	return file_proto_water_quality_service_water_quality_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Measurement) GetStationId() string {
+	if x != nil {
+		return x.StationId
+	}
+	return ""
+}
+
+func (x *Measurement) GetParameter() string {
+	if x != nil {
+		return x.Parameter
+	}
+	return ""
+}
+
+func (x *Measurement) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Measurement) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Measurement) GetMeasuredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MeasuredAt
+	}
+	return nil
+}
+
+func (x *Measurement) GetLocation() *core.GeoPoint {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// Aggregate of the measurements of a station and parameter within one hour or day
+type MeasurementBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     string                 `protobuf:"bytes,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Parameter     string                 `protobuf:"bytes,2,opt,name=parameter,proto3" json:"parameter,omitempty"`
+	BucketStart   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=bucket_start,json=bucketStart,proto3" json:"bucket_start,omitempty"`
+	Avg           float64                `protobuf:"fixed64,4,opt,name=avg,proto3" json:"avg,omitempty"`
+	Min           float64                `protobuf:"fixed64,5,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,6,opt,name=max,proto3" json:"max,omitempty"`
+	Count         int64                  `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"` // Number of measurements in the bucket
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeasurementBucket) Reset() {
+	*x = MeasurementBucket{}
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeasurementBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeasurementBucket) ProtoMessage() {}
+
+func (x *MeasurementBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeasurementBucket.ProtoReflect.Descriptor instead.
+func (*MeasurementBucket) Descriptor() ([]byte, []int) {
+	return file_proto_water_quality_service_water_quality_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MeasurementBucket) GetStationId() string {
+	if x != nil {
+		return x.StationId
+	}
+	return ""
+}
+
+func (x *MeasurementBucket) GetParameter() string {
+	if x != nil {
+		return x.Parameter
+	}
+	return ""
+}
+
+func (x *MeasurementBucket) GetBucketStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BucketStart
+	}
+	return nil
+}
+
+func (x *MeasurementBucket) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+func (x *MeasurementBucket) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *MeasurementBucket) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *MeasurementBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Request to query the measurements of a time range
+type QueryMeasurementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	StationId     string                 `protobuf:"bytes,3,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Parameter     string                 `protobuf:"bytes,4,opt,name=parameter,proto3" json:"parameter,omitempty"`
+	Interval      string                 `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	WithinRadius  *core.GeoRadius        `protobuf:"bytes,7,opt,name=within_radius,json=withinRadius,proto3" json:"within_radius,omitempty"`
+	WithinBox     *core.GeoBoundingBox   `protobuf:"bytes,8,opt,name=within_box,json=withinBox,proto3" json:"within_box,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryMeasurementsRequest) Reset() {
+	*x = QueryMeasurementsRequest{}
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryMeasurementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryMeasurementsRequest) ProtoMessage() {}
+
+func (x *QueryMeasurementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryMeasurementsRequest.ProtoReflect.Descriptor instead.
+func (*QueryMeasurementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_water_quality_service_water_quality_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryMeasurementsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *QueryMeasurementsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *QueryMeasurementsRequest) GetStationId() string {
+	if x != nil {
+		return x.StationId
+	}
+	return ""
+}
+
+func (x *QueryMeasurementsRequest) GetParameter() string {
+	if x != nil {
+		return x.Parameter
+	}
+	return ""
+}
+
+func (x *QueryMeasurementsRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *QueryMeasurementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryMeasurementsRequest) GetWithinRadius() *core.GeoRadius {
+	if x != nil {
+		return x.WithinRadius
+	}
+	return nil
+}
+
+func (x *QueryMeasurementsRequest) GetWithinBox() *core.GeoBoundingBox {
+	if x != nil {
+		return x.WithinBox
+	}
+	return nil
+}
+
+// Response of a measurement query; measurements are set for raw queries and buckets otherwise
+type QueryMeasurementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Measurements  []*Measurement         `protobuf:"bytes,1,rep,name=measurements,proto3" json:"measurements,omitempty"`
+	Buckets       []*MeasurementBucket   `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Truncated     bool                   `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"` // Whether more results than limit matched
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryMeasurementsResponse) Reset() {
+	*x = QueryMeasurementsResponse{}
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryMeasurementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryMeasurementsResponse) ProtoMessage() {}
+
+func (x *QueryMeasurementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_water_quality_service_water_quality_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryMeasurementsResponse.ProtoReflect.Descriptor instead.
+func (*QueryMeasurementsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_water_quality_service_water_quality_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryMeasurementsResponse) GetMeasurements() []*Measurement {
+	if x != nil {
+		return x.Measurements
+	}
+	return nil
+}
+
+func (x *QueryMeasurementsResponse) GetBuckets() []*MeasurementBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *QueryMeasurementsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+var File_proto_water_quality_service_water_quality_proto protoreflect.FileDescriptor
+
+const file_proto_water_quality_service_water_quality_proto_rawDesc = "" +
+	"\n" +
+	"/proto/water-quality-service/water_quality.proto\x12\x13waterqualityservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17proto/core/errors.proto\x1a\x16proto/core/query.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"x\n" +
+	"\rUploadRequest\x12\x1c\n" +
+	"\bfilename\x18\x01 \x01(\tH\x00R\bfilename\x12\x1d\n" +
+	"\tfile_type\x18\x02 \x01(\tH\x00R\bfileType\x12\x1f\n" +
+	"\n" +
+	"data_chunk\x18\x03 \x01(\fH\x00R\tdataChunkB\t\n" +
+	"\apayload\"\xda\x04\n" +
+	"\x0eUploadResponse\x12\x88\x01\n" +
+	"\tupload_id\x18\x01 \x01(\tBk\x92Ah2>ID of the upload (UUID format); the raw file is kept under it.J&\"d4e5f6a7-b8c9-0123-4567-890abcdef123\"R\buploadId\x12L\n" +
+	"\x04rows\x18\x02 \x01(\x03B8\x92A52-Number of data rows read, without the header.J\x041440R\x04rows\x12\x98\x01\n" +
+	"\bingested\x18\x03 \x01(\x03B|\x92Ay2qNumber of measurements stored. A measurement already stored for the same station, parameter and time is replaced.J\x041438R\bingested\x12.\n" +
+	"\bfailures\x18\x04 \x03(\v2\x12.core.BatchFailureR\bfailures:\xa3\x01\x92A\x9f\x01\n" +
+	"\x9c\x01*\x0fUpload Response2kThe stored file and the measurements ingested from it. Rows that cannot be parsed are reported and skipped.\xd2\x01\tupload_id\xd2\x01\x04rows\xd2\x01\bingested\"\xe0\x03\n" +
+	"\vMeasurement\x12S\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\tB4\x92A12%Identifier of the monitoring station.J\b\"ST-001\"R\tstationId\x12<\n" +
+	"\tparameter\x18\x02 \x01(\tB\x1e\x92A\x1b2\x13Measured parameter.J\x04\"ph\"R\tparameter\x12/\n" +
+	"\x05value\x18\x03 \x01(\x01B\x19\x92A\x162\x0fMeasured value.J\x037.2R\x05value\x12W\n" +
+	"\x04unit\x18\x04 \x01(\tBC\x92A@26Unit of the value; empty for dimensionless parameters.J\x06\"mg/L\"R\x04unit\x12\x87\x01\n" +
+	"\vmeasured_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampBJ\x92AG2-Time of the measurement (RFC3339 UTC format).J\x16\"2024-03-01T08:15:00Z\"R\n" +
+	"measuredAt\x12*\n" +
+	"\blocation\x18\x06 \x01(\v2\x0e.core.GeoPointR\blocation\"\x99\x02\n" +
+	"\x11MeasurementBucket\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\tR\tstationId\x12\x1c\n" +
+	"\tparameter\x18\x02 \x01(\tR\tparameter\x12{\n" +
+	"\fbucket_start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB<\x92A92\x1fStart of the hour or day (UTC).J\x16\"2024-03-01T08:00:00Z\"R\vbucketStart\x12\x10\n" +
+	"\x03avg\x18\x04 \x01(\x01R\x03avg\x12\x10\n" +
+	"\x03min\x18\x05 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x06 \x01(\x01R\x03max\x12\x14\n" +
+	"\x05count\x18\a \x01(\x03R\x05count\"\xf7\b\n" +
+	"\x18QueryMeasurementsRequest\x12z\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampBJ\x92AG2-Start of the time range, inclusive (RFC3339).J\x16\"2024-03-01T00:00:00Z\"R\x04from\x12t\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampBH\x92AE2+End of the time range, exclusive (RFC3339).J\x16\"2024-03-08T00:00:00Z\"R\x02to\x12h\n" +
+	"\n" +
+	"station_id\x18\x03 \x01(\tBI\x92AF2:Only measurements of this station; empty for all stations.J\b\"ST-001\"R\tstationId\x12g\n" +
+	"\tparameter\x18\x04 \x01(\tBI\x92AF2>Only measurements of this parameter; empty for all parameters.J\x04\"ph\"R\tparameter\x12\xaf\x01\n" +
+	"\binterval\x18\x05 \x01(\tB\x92\x01\x92A\x8e\x012\x83\x01raw (default) returns the measurements; hour or day returns their average, minimum and maximum per station, parameter and interval.J\x06\"hour\"R\binterval\x12\x8b\x01\n" +
+	"\x05limit\x18\x06 \x01(\x05Bu\x92Ar2jMaximum number of measurements or buckets, oldest first; defaults to and is capped by the service's limit.J\x041000R\x05limit\x12\x9c\x01\n" +
+	"\rwithin_radius\x18\a \x01(\v2\x0f.core.GeoRadiusBf\x92Ac2aOnly measurements of stations within radius_meters of center, e.g. within 5000 meters of a point.R\fwithinRadius\x12\x87\x01\n" +
+	"\n" +
+	"within_box\x18\b \x01(\v2\x14.core.GeoBoundingBoxBR\x92AO2MOnly measurements of stations inside the box, e.g. the visible area of a map.R\twithinBox:-\x92A*\n" +
+	"(*\x1aQuery Measurements Request\xd2\x01\x04from\xd2\x01\x02to\"\xc1\x01\n" +
+	"\x19QueryMeasurementsResponse\x12D\n" +
+	"\fmeasurements\x18\x01 \x03(\v2 .waterqualityservice.MeasurementR\fmeasurements\x12@\n" +
+	"\abuckets\x18\x02 \x03(\v2&.waterqualityservice.MeasurementBucketR\abuckets\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated2\xfa\x03\n" +
+	"\x13WaterQualityService\x12Y\n" +
+	"\n" +
+	"UploadData\x12\".waterqualityservice.UploadRequest\x1a#.waterqualityservice.UploadResponse\"\x00(\x01\x12\xcd\x02\n" +
+	"\x11QueryMeasurements\x12-.waterqualityservice.QueryMeasurementsRequest\x1a..waterqualityservice.QueryMeasurementsResponse\"\xd8\x01\x92A\xa7\x01\n" +
+	"\rWater Quality\x12\x12Query Measurements\x1a\x81\x01Returns the measurements of a time range, or their hourly or daily averages, filtered by station, parameter and station location.\x82\xd3\xe4\x93\x02$\x12\"/api/v1/water-quality/measurements\x90\x02\x01\x1a8\x92A5\x123Upload measurement files and query the measurementsB\xa8\x02\x92A\xe6\x01\x12\\\n" +
+	"\x19Water Quality Service API\x12:API for uploading and querying water quality measurements.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZL\n" +
+	"J\n" +
+	"\n" +
+	"BearerAuth\x12<\b\x02\x12'JWT Bearer token (e.g., 'Bearer ey...')\x1a\rAuthorization \x02b\x10\n" +
+	"\x0e\n" +
+	"\n" +
+	"BearerAuth\x12\x00Z<golang-microservices-boilerplate/proto/water-quality-serviceb\x06proto3"
+
+var (
+	file_proto_water_quality_service_water_quality_proto_rawDescOnce sync.Once
+	file_proto_water_quality_service_water_quality_proto_rawDescData []byte
+)
+
+func file_proto_water_quality_service_water_quality_proto_rawDescGZIP() []byte {
+	file_proto_water_quality_service_water_quality_proto_rawDescOnce.Do(func() {
+		file_proto_water_quality_service_water_quality_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_water_quality_service_water_quality_proto_rawDesc), len(file_proto_water_quality_service_water_quality_proto_rawDesc)))
+	})
+	return file_proto_water_quality_service_water_quality_proto_rawDescData
+}
+
+var file_proto_water_quality_service_water_quality_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_water_quality_service_water_quality_proto_goTypes = []any{
+	(*UploadRequest)(nil),             // 0: waterqualityservice.UploadRequest
+	(*UploadResponse)(nil),            // 1: waterqualityservice.UploadResponse
+	(*Measurement)(nil),               // 2: waterqualityservice.Measurement
+	(*MeasurementBucket)(nil),         // 3: waterqualityservice.MeasurementBucket
+	(*QueryMeasurementsRequest)(nil),  // 4: waterqualityservice.QueryMeasurementsRequest
+	(*QueryMeasurementsResponse)(nil), // 5: waterqualityservice.QueryMeasurementsResponse
+	(*core.BatchFailure)(nil),         // 6: core.BatchFailure
+	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+	(*core.GeoPoint)(nil),             // 8: core.GeoPoint
+	(*core.GeoRadius)(nil),            // 9: core.GeoRadius
+	(*core.GeoBoundingBox)(nil),       // 10: core.GeoBoundingBox
+}
+var file_proto_water_quality_service_water_quality_proto_depIdxs = []int32{
+	6,  // 0: waterqualityservice.UploadResponse.failures:type_name -> core.BatchFailure
+	7,  // 1: waterqualityservice.Measurement.measured_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: waterqualityservice.Measurement.location:type_name -> core.GeoPoint
+	7,  // 3: waterqualityservice.MeasurementBucket.bucket_start:type_name -> google.protobuf.Timestamp
+	7,  // 4: waterqualityservice.QueryMeasurementsRequest.from:type_name -> google.protobuf.Timestamp
+	7,  // 5: waterqualityservice.QueryMeasurementsRequest.to:type_name -> google.protobuf.Timestamp
+	9,  // 6: waterqualityservice.QueryMeasurementsRequest.within_radius:type_name -> core.GeoRadius
+	10, // 7: waterqualityservice.QueryMeasurementsRequest.within_box:type_name -> core.GeoBoundingBox
+	2,  // 8: waterqualityservice.QueryMeasurementsResponse.measurements:type_name -> waterqualityservice.Measurement
+	3,  // 9: waterqualityservice.QueryMeasurementsResponse.buckets:type_name -> waterqualityservice.MeasurementBucket
+	0,  // 10: waterqualityservice.WaterQualityService.UploadData:input_type -> waterqualityservice.UploadRequest
+	4,  // 11: waterqualityservice.WaterQualityService.QueryMeasurements:input_type -> waterqualityservice.QueryMeasurementsRequest
+	1,  // 12: waterqualityservice.WaterQualityService.UploadData:output_type -> waterqualityservice.UploadResponse
+	5,  // 13: waterqualityservice.WaterQualityService.QueryMeasurements:output_type -> waterqualityservice.QueryMeasurementsResponse
+	12, // [12:14] is the sub-list for method output_type
+	10, // [10:12] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_proto_water_quality_service_water_quality_proto_init() }
+func file_proto_water_quality_service_water_quality_proto_init() {
+	if File_proto_water_quality_service_water_quality_proto != nil {
+		return
+	}
+	file_proto_water_quality_service_water_quality_proto_msgTypes[0].OneofWrappers = []any{
+		(*UploadRequest_Filename)(nil),
+		(*UploadRequest_FileType)(nil),
+		(*UploadRequest_DataChunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_water_quality_service_water_quality_proto_rawDesc), len(file_proto_water_quality_service_water_quality_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_water_quality_service_water_quality_proto_goTypes,
+		DependencyIndexes: file_proto_water_quality_service_water_quality_proto_depIdxs,
+		MessageInfos:      file_proto_water_quality_service_water_quality_proto_msgTypes,
+	}.Build()
+	File_proto_water_quality_service_water_quality_proto = out.File
+	file_proto_water_quality_service_water_quality_proto_goTypes = nil
+	file_proto_water_quality_service_water_quality_proto_depIdxs = nil
+}
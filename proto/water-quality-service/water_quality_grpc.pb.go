@@ -0,0 +1,159 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/water-quality-service/water_quality.proto
+
+package water_quality_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WaterQualityService_UploadData_FullMethodName        = "/waterqualityservice.WaterQualityService/UploadData"
+	WaterQualityService_QueryMeasurements_FullMethodName = "/waterqualityservice.WaterQualityService/QueryMeasurements"
+)
+
+// WaterQualityServiceClient is the client API for WaterQualityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Water quality measurements
+type WaterQualityServiceClient interface {
+	// Client-streaming upload; served over HTTP by the gateway's multipart handler at POST /api/v1/water-quality/upload
+	UploadData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadRequest, UploadResponse], error)
+	QueryMeasurements(ctx context.Context, in *QueryMeasurementsRequest, opts ...grpc.CallOption) (*QueryMeasurementsResponse, error)
+}
+
+type waterQualityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWaterQualityServiceClient(cc grpc.ClientConnInterface) WaterQualityServiceClient {
+	return &waterQualityServiceClient{cc}
+}
+
+func (c *waterQualityServiceClient) UploadData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadRequest, UploadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WaterQualityService_ServiceDesc.Streams[0], WaterQualityService_UploadData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadRequest, UploadResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WaterQualityService_UploadDataClient = grpc.ClientStreamingClient[UploadRequest, UploadResponse]
+
+func (c *waterQualityServiceClient) QueryMeasurements(ctx context.Context, in *QueryMeasurementsRequest, opts ...grpc.CallOption) (*QueryMeasurementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryMeasurementsResponse)
+	err := c.cc.Invoke(ctx, WaterQualityService_QueryMeasurements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WaterQualityServiceServer is the server API for WaterQualityService service.
+// All implementations must embed UnimplementedWaterQualityServiceServer
+// for forward compatibility.
+//
+// Water quality measurements
+type WaterQualityServiceServer interface {
+	// Client-streaming upload; served over HTTP by the gateway's multipart handler at POST /api/v1/water-quality/upload
+	UploadData(grpc.ClientStreamingServer[UploadRequest, UploadResponse]) error
+	QueryMeasurements(context.Context, *QueryMeasurementsRequest) (*QueryMeasurementsResponse, error)
+	mustEmbedUnimplementedWaterQualityServiceServer()
+}
+
+// UnimplementedWaterQualityServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWaterQualityServiceServer struct{}
+
+func (UnimplementedWaterQualityServiceServer) UploadData(grpc.ClientStreamingServer[UploadRequest, UploadResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadData not implemented")
+}
+func (UnimplementedWaterQualityServiceServer) QueryMeasurements(context.Context, *QueryMeasurementsRequest) (*QueryMeasurementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryMeasurements not implemented")
+}
+func (UnimplementedWaterQualityServiceServer) mustEmbedUnimplementedWaterQualityServiceServer() {}
+func (UnimplementedWaterQualityServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeWaterQualityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WaterQualityServiceServer will
+// result in compilation errors.
+type UnsafeWaterQualityServiceServer interface {
+	mustEmbedUnimplementedWaterQualityServiceServer()
+}
+
+func RegisterWaterQualityServiceServer(s grpc.ServiceRegistrar, srv WaterQualityServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWaterQualityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WaterQualityService_ServiceDesc, srv)
+}
+
+func _WaterQualityService_UploadData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WaterQualityServiceServer).UploadData(&grpc.GenericServerStream[UploadRequest, UploadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WaterQualityService_UploadDataServer = grpc.ClientStreamingServer[UploadRequest, UploadResponse]
+
+func _WaterQualityService_QueryMeasurements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMeasurementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaterQualityServiceServer).QueryMeasurements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WaterQualityService_QueryMeasurements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaterQualityServiceServer).QueryMeasurements(ctx, req.(*QueryMeasurementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WaterQualityService_ServiceDesc is the grpc.ServiceDesc for WaterQualityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WaterQualityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "waterqualityservice.WaterQualityService",
+	HandlerType: (*WaterQualityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "QueryMeasurements",
+			Handler:    _WaterQualityService_QueryMeasurements_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadData",
+			Handler:       _WaterQualityService_UploadData_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/water-quality-service/water_quality.proto",
+}
@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
+	"golang-microservices-boilerplate/pkg/core/lifecycle"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	"golang-microservices-boilerplate/pkg/core/preflight"
 	"golang-microservices-boilerplate/pkg/utils"
 	"golang-microservices-boilerplate/services/api-gateway/internal/gateway"
 	"golang-microservices-boilerplate/services/api-gateway/internal/infrastructure/adapter"
@@ -16,6 +19,9 @@ import (
 )
 
 func main() {
+	checkOnly := flag.Bool("check", false, "perform discovery, dial every service and validate swagger, then exit")
+	flag.Parse()
+
 	// Load environment variables
 	if err := utils.LoadEnv(); err != nil {
 		log.Printf("Warning: Could not load .env file: %v", err)
@@ -39,50 +45,83 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize Kubernetes service discovery
-	namespace := utils.GetEnv("K8S_NAMESPACE", "ride-sharing")
-
-	discovery, err := k8s.NewKubernetesDiscovery(
-		k8s.WithNamespace(namespace),
-		k8s.WithLogger(log.New(os.Stdout, "[K8S-DISCOVERY] ", log.LstdFlags)), // Keep using std logger for k8s for now
-	)
-	if err != nil {
-		appLogger.Fatal("Failed to initialize service discovery", "error", err)
+	// Check the configuration and discovery permissions, reporting every problem at once.
+	// Discovery is created by its check: it lists the services right away.
+	checkMode := *checkOnly || utils.GetEnv("GATEWAY_CHECK", "false") == "true"
+	port := utils.GetEnv("PORT", "8081")
+	var discovery *k8s.KubernetesDiscovery
+	checks := preflight.New(appLogger).
+		Check("jwt-secrets", preflight.JWTSecrets).
+		Check("permissions", permissions.UseFromEnv).
+		Check("discovery", func(ctx context.Context) error {
+			var err error
+			discovery, err = k8s.NewKubernetesDiscovery(
+				k8s.WithNamespace(utils.GetEnv("K8S_NAMESPACE", "ride-sharing")),
+				k8s.WithEndpointResolution(utils.GetEnv("K8S_RESOLVE_ENDPOINTS", "false") == "true"),
+				k8s.WithLogger(log.New(os.Stdout, "[K8S-DISCOVERY] ", log.LstdFlags)), // Keep using std logger for k8s for now
+			)
+			if err != nil {
+				return err
+			}
+			return discovery.CheckPermissions(ctx)
+		})
+	if !checkMode {
+		checks.Check("port", preflight.PortAvailable(":"+port))
+	}
+	if err := checks.Run(ctx); err != nil {
+		appLogger.Fatal("Invalid gateway configuration", "error", err)
 	}
-	defer discovery.Close()
 
 	// Initialize gateway
-	gw := gateway.NewGateway(
+	gw, err := gateway.NewGateway(
 		ctx,
 		discovery,
 		gateway.WithLogger(logger.Named("gateway")),
 	)
+	if err != nil {
+		discovery.Close()
+		appLogger.Fatal("Failed to create gateway", "error", err)
+	}
 
-	// Start server in a goroutine
-	port := utils.GetEnv("PORT", "8081")
-	go func() {
-		if err := gw.Start(port); err != nil {
-			appLogger.Fatal("Failed to start server", "error", err)
+	// In check mode, verify discovery, backends and swagger, then exit without serving
+	if checkMode {
+		if err := gw.Check(ctx); err != nil {
+			appLogger.Error("Gateway startup check failed", "error", err)
+			discovery.Close()
+			os.Exit(1)
 		}
-	}()
-
-	appLogger.Info("API Gateway listening", "port", port)
+		appLogger.Info("Gateway startup check passed")
+		discovery.Close()
+		return
+	}
 
-	// Wait for interrupt signal to gracefully shut down the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	// The gateway is registered last so it stops first, before discovery is closed
+	lc := lifecycle.New(appLogger)
+	lc.Add(lifecycle.Component{Name: "discovery", Stop: func(context.Context) error { return discovery.Close() }})
+	lc.Add(lifecycle.Component{
+		Name:  "gateway",
+		Start: func(context.Context) error { return gw.Start(port) },
+		Stop:  gw.Shutdown,
+	})
 
-	appLogger.Info("Shutting down API Gateway...")
+	appLogger.Info("API Gateway listening", "port", port)
 
-	// Create a context with timeout for shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer shutdownCancel()
+	// Reload runtime config (IP filter rules) on SIGHUP
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := utils.ReloadEnv(); err != nil {
+				appLogger.Warn("Could not reload .env file", "error", err)
+			}
+			if err := gw.ReloadIPFilter(); err != nil {
+				appLogger.Error("Failed to reload IP filter, keeping previous rules", "error", err)
+			}
+		}
+	}()
 
-	// Shutdown the gateway
-	if err := gw.Shutdown(shutdownCtx); err != nil {
-		appLogger.Fatal("Gateway shutdown failed", "error", err)
+	// Serve until SIGINT/SIGTERM, then shut down within SHUTDOWN_TIMEOUT
+	if err := lc.Run(ctx); err != nil {
+		appLogger.Fatal("API Gateway stopped with errors", "error", err)
 	}
-
-	appLogger.Info("API Gateway stopped")
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/api-gateway/internal/sdk"
+)
+
+// Generates the client SDKs from the gateway's merged OpenAPI definition and writes, for each
+// language, the sources under <out>/<language>/ and a <language>-sdk-<version>.tar.gz next to them.
+//
+//	go run ./services/api-gateway/cmd/sdkgen -spec http://localhost:8080/swagger/openapi.json -out sdk
+func main() {
+	if err := utils.LoadEnv(); err != nil {
+		log.Printf("Warning: .env file not found, using environment variables")
+	}
+
+	defaults := sdk.DefaultOptions()
+	spec := flag.String("spec", "http://localhost:8080/swagger/openapi.json", "merged OpenAPI definition: a file or an http(s) URL")
+	out := flag.String("out", "sdk", "directory the SDKs are written to")
+	version := flag.String("version", utils.GetEnv("SDK_VERSION", ""), "SDK version (derived from the definition when empty)")
+	goModule := flag.String("go-module", utils.GetEnv("SDK_GO_MODULE", defaults.GoModule), "module path of the Go SDK")
+	npmPackage := flag.String("npm-package", utils.GetEnv("SDK_NPM_PACKAGE", defaults.NPMPackage), "package name of the TypeScript SDK")
+	flag.Parse()
+
+	definition, err := readSpec(*spec)
+	if err != nil {
+		log.Fatalf("Failed to read OpenAPI definition: %v", err)
+	}
+	generated, err := sdk.Generate(definition, sdk.Options{Version: *version, GoModule: *goModule, NPMPackage: *npmPackage})
+	if err != nil {
+		log.Fatalf("Failed to generate SDKs: %v", err)
+	}
+
+	for _, language := range generated.Languages() {
+		files, _ := generated.Files(language)
+		for name, contents := range files {
+			target := filepath.Join(*out, language, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				log.Fatalf("Failed to create %s: %v", filepath.Dir(target), err)
+			}
+			if err := os.WriteFile(target, contents, 0o644); err != nil {
+				log.Fatalf("Failed to write %s: %v", target, err)
+			}
+		}
+
+		tarball, err := generated.Tarball(language)
+		if err != nil {
+			log.Fatalf("Failed to pack %s SDK: %v", language, err)
+		}
+		archive := filepath.Join(*out, generated.ArchiveName(language))
+		if err := os.WriteFile(archive, tarball, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", archive, err)
+		}
+		log.Printf("Generated %s SDK %s: %s", language, generated.Version, archive)
+	}
+}
+
+// readSpec loads the definition from a file or downloads it from a running gateway
+func readSpec(location string) (map[string]interface{}, error) {
+	var data []byte
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Get(location)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: %s", location, resp.Status)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(location); err != nil {
+			return nil, err
+		}
+	}
+
+	var definition map[string]interface{}
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI definition: %w", err)
+	}
+	return definition, nil
+}
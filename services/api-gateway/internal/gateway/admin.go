@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+)
+
+// setupAdminAPI registers the gateway's runtime administration endpoints under /admin. They
+// require an access token with the admin role; restrict /admin further with IP_FILTER_RULES.
+func (g *Gateway) setupAdminAPI() {
+	admin := g.app.Group("/admin", middleware.AuthMiddleware(), middleware.RequireRole([]string{"admin"}))
+	admin.Get("/canaries", g.listCanaries)
+	admin.Put("/canaries/:service", g.putCanary)
+	admin.Delete("/canaries/:service", g.deleteCanary)
+	admin.Get("/shadows", g.listShadows)
+	admin.Put("/shadows/:service", g.putShadow)
+	admin.Delete("/shadows/:service", g.deleteShadow)
+	admin.Get("/maintenance", g.getMaintenance)
+	admin.Put("/maintenance", g.putMaintenance)
+	admin.Delete("/maintenance", g.deleteMaintenance)
+	admin.Get("/log-level", g.getLogLevel)
+	admin.Put("/log-level", g.putLogLevel)
+	admin.Get("/log-level/:service", g.getServiceLogLevel)
+	admin.Put("/log-level/:service", g.putServiceLogLevel)
+	admin.Get("/diagnostics/:service/goroutines", g.getServiceGoroutines)
+	admin.Get("/diagnostics/:service/heap", g.getServiceHeap)
+}
+
+// listCanaries returns the active canary routes by service
+func (g *Gateway) listCanaries(c *fiber.Ctx) error {
+	return c.JSON(g.Canaries())
+}
+
+// putCanary sets the canary route of a service from a {"backend": ..., "weight": ...} body
+func (g *Gateway) putCanary(c *fiber.Ctx) error {
+	var route CanaryRoute
+	if err := c.BodyParser(&route); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if err := g.SetCanary(c.Params("service"), route); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(route)
+}
+
+// deleteCanary sends all traffic of a service back to its stable deployment
+func (g *Gateway) deleteCanary(c *fiber.Ctx) error {
+	if err := g.RemoveCanary(c.Params("service")); err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// listShadows returns the active shadow routes and their counters by service
+func (g *Gateway) listShadows(c *fiber.Ctx) error {
+	return c.JSON(g.Shadows())
+}
+
+// putShadow sets the shadow route of a service from a ShadowRoute body
+func (g *Gateway) putShadow(c *fiber.Ctx) error {
+	var route ShadowRoute
+	if err := c.BodyParser(&route); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if err := g.SetShadow(c.Params("service"), route); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(route)
+}
+
+// deleteShadow stops mirroring a service's traffic
+func (g *Gateway) deleteShadow(c *fiber.Ctx) error {
+	if err := g.RemoveShadow(c.Params("service")); err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
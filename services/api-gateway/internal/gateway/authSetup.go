@@ -1,16 +1,142 @@
 package gateway
 
 import (
-	"golang-microservices-boilerplate/pkg/core/logger"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/gofiber/fiber/v2"
+	"golang-microservices-boilerplate/pkg/middleware"
 )
 
-// setupAuthMiddleware configures and applies JWT authentication middleware selectively to API routes.
-func setupAuthMiddleware(app *fiber.App, logger logger.Logger) {
+// routePolicies declares the access policy of every route exposed through the gateway.
+// Routes without an entry are rejected, and Start refuses to run if a route in the swagger
+// definitions is missing here. Path parameters with a declared format are validated before
+// the request is forwarded.
+var routePolicies = middleware.NewRoutePolicyTable(
+	// Authentication
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/auth/login", Public: true},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/auth/refresh", Public: true},
+	// Validates the tokens itself, so clients with an expired access token can still revoke their refresh token
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/auth/logout", Public: true},
+	// Requires a valid access token of its own, so introspection cannot be used to probe tokens anonymously
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/auth/introspect"},
+	// Authenticated by the invite token in the body
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/auth/invitations/accept", Public: true},
 
-	// useage:
-	// app.Use("/api/v1/auth/refresh", middleware.AuthMiddleware())
-	_ = app
-	logger.Info("Auth middleware configured for apis")
+	// Users
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users"},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/search"},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/count"},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/stats"},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/stream", Roles: []string{"admin"}, StreamResponse: true},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/{id}", Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "PATCH", Path: "/api/v1/users/{id}", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "DELETE", Path: "/api/v1/users/{id}", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/{id}/restore", Roles: []string{"admin"}, Params: uuidParam("id")},
+	// Users read their own login and security history; admins read anyone's
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/{userId}/security-events", Roles: []string{"admin"}, Params: uuidParam("userId"), Owner: "userId"},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/{id}/history", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/{id}/history/as-of", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/{id}/history/diff", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/{id}/anonymize", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/invitations", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/{id}/invitation/resend", Roles: []string{"admin"}, Params: uuidParam("id")},
+	// Data exports of the caller; the user service only serves the caller's own exports
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/me/exports"},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/me/exports/{id}", Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/me/exports/{id}/download", Params: uuidParam("id")},
+	// Permissions of the caller's role, for clients to show only what the user may do
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/users/me/permissions"},
+
+	// Users (Bulk)
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/bulk/create", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/bulk/stream", Roles: []string{"admin"}, Passthrough: true},
+	middleware.RoutePolicy{Method: "PATCH", Path: "/api/v1/users/bulk/update", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/users/bulk/delete", Roles: []string{"admin"}},
+
+	// Change feed (SSE); each resource is further restricted by the policy of its list route
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/events"},
+
+	// Rate limits of the caller, reported by the gateway itself
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/limits"},
+
+	// Webhooks
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/webhooks", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/webhooks", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "PATCH", Path: "/api/v1/webhooks/{id}", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "DELETE", Path: "/api/v1/webhooks/{id}", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/webhooks/{id}/deliveries", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/dead-letters", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/dead-letters/replay", Roles: []string{"admin"}},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/dead-letters/{id}", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/dead-letters/{id}/discard", Roles: []string{"admin"}, Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/quotas", Roles: []string{"admin"}},
+
+	// Reports; the user service checks per-template roles and only serves the caller's own reports
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/reports"},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/reports/{id}", Params: uuidParam("id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/reports/{id}/download", Params: uuidParam("id")},
+
+	// Organizations; the user service checks the caller's role within the organization
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/orgs"},
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/orgs/{org_id}/members", Params: uuidParam("org_id")},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/orgs/{org_id}/members", Params: uuidParam("org_id")},
+	middleware.RoutePolicy{Method: "PATCH", Path: "/api/v1/orgs/{org_id}/members/{user_id}", Params: map[string]string{"org_id": middleware.ParamUUID, "user_id": middleware.ParamUUID}},
+
+	// Water quality; uploads are multipart forms streamed to the service by the gateway's upload handler
+	middleware.RoutePolicy{Method: "POST", Path: "/api/v1/water-quality/upload", Roles: []string{"admin"}, Passthrough: true},
+	middleware.RoutePolicy{Method: "GET", Path: "/api/v1/water-quality/measurements"},
+)
+
+// uuidParam declares that the path parameter name is a UUID
+func uuidParam(name string) map[string]string {
+	return map[string]string{name: middleware.ParamUUID}
+}
+
+// setupAuthMiddleware applies the route policy table to all API routes before they reach the gRPC-Gateway mux.
+func (g *Gateway) setupAuthMiddleware() {
+	g.app.Use("/api", middleware.RoutePolicyMiddleware(routePolicies))
+	g.logger.Info("Auth middleware configured for apis")
+}
+
+// checkRouteCoverage verifies that every route in the generated swagger definitions has a policy.
+func (g *Gateway) checkRouteCoverage(swaggerDir string) error {
+	routes, err := swaggerRoutes(filepath.Join(swaggerDir, "proto"))
+	if err != nil {
+		return err
+	}
+	return middleware.ValidateRouteCoverage(routePolicies, routes)
+}
+
+// swaggerRoutes collects the HTTP routes declared in all *.swagger.json files under dir
+func swaggerRoutes(dir string) ([]middleware.Route, error) {
+	var routes []middleware.Route
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".swagger.json") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var spec struct {
+			Paths map[string]map[string]json.RawMessage `json:"paths"`
+		}
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return err
+		}
+		for p, methods := range spec.Paths {
+			for method := range methods {
+				routes = append(routes, middleware.Route{Method: strings.ToUpper(method), Path: p})
+			}
+		}
+		return nil
+	})
+	return routes, err
 }
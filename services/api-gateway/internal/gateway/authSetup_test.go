@@ -0,0 +1,41 @@
+package gateway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+)
+
+// repoSwaggerDir is the generated swagger directory of the repository, relative to this package
+const repoSwaggerDir = "../../../../swagger"
+
+// TestRouteCoverage fails when a route in the generated swagger definitions has no RoutePolicy.
+// Start skips the same check when the gateway runs without the swagger directory, so a route
+// added without a policy would otherwise only be caught by deployments that ship the definitions.
+func TestRouteCoverage(t *testing.T) {
+	dir := filepath.Join(repoSwaggerDir, "proto")
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("swagger definitions not found: %v", err)
+	}
+	routes, err := swaggerRoutes(dir)
+	if err != nil {
+		t.Fatalf("failed to load swagger routes: %v", err)
+	}
+	if len(routes) == 0 {
+		t.Fatalf("no routes found in %s", dir)
+	}
+	for _, route := range routePolicies.Uncovered(routes) {
+		t.Errorf("%s has no RoutePolicy in authSetup.go", route)
+	}
+}
+
+// TestRouteCoverageReportsMissingPolicy checks that the coverage check fails on a route the
+// policy table does not know, so TestRouteCoverage cannot pass vacuously.
+func TestRouteCoverageReportsMissingPolicy(t *testing.T) {
+	routes := []middleware.Route{{Method: "DELETE", Path: "/api/v1/not-a-route/{id}"}}
+	if err := middleware.ValidateRouteCoverage(routePolicies, routes); err == nil {
+		t.Fatal("ValidateRouteCoverage accepted a route without a policy")
+	}
+}
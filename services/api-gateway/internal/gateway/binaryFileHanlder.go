@@ -9,9 +9,7 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure" // TODO: Use secure credentials
 	"google.golang.org/grpc/status"
 
 	waterPb "golang-microservices-boilerplate/proto/water-quality-service" // Adjust import path if needed
@@ -73,32 +71,24 @@ func grpcStatusToHTTP(code codes.Code) int {
 }
 
 // registerWaterQualityCustomHandlers registers custom handlers specific to the Water Quality service.
-// Currently, this only includes the binary file upload handler.
-func registerWaterQualityCustomHandlers(mux *runtime.ServeMux, service domain.Service) error {
-	// Get the target service address from the discovered service info
-	waterQualityServiceAddr := service.Endpoint
-	if waterQualityServiceAddr == "" {
-		return fmt.Errorf("cannot register custom handlers: endpoint missing for service %s", service.Name)
-	}
-
+// Currently, this only includes the binary file upload handler. The handlers call the service through
+// client, which shares the gateway's connection to it.
+func registerWaterQualityCustomHandlers(mux *runtime.ServeMux, service domain.Service, client waterPb.WaterQualityServiceClient) error {
 	uploadPath := "/api/v1/water-quality/upload"
 
 	// Register the custom handler for the specific upload path
-	err := mux.HandlePath("POST", uploadPath, handleWaterQualityUpload(waterQualityServiceAddr))
+	err := mux.HandlePath("POST", uploadPath, handleWaterQualityUpload(client))
 	if err != nil {
 		return fmt.Errorf("failed to register custom handler for path %s on service %s: %w", uploadPath, service.Name, err)
 	}
 
-	// Log success (optional, could be logged in the calling function)
-	// fmt.Printf("Registered custom handler for %s path on service %s\n", uploadPath, service.Name)
-
 	// Add more custom handlers for this service here if needed
 	return nil
 }
 
 // handleWaterQualityUpload returns the custom HTTP handler function for water quality file uploads.
 // This version waits for the gRPC upload to complete before sending the HTTP response.
-func handleWaterQualityUpload(waterQualityServiceAddr string) runtime.HandlerFunc {
+func handleWaterQualityUpload(client waterPb.WaterQualityServiceClient) runtime.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
 		// 1. Parse Multipart Form
 		if err := r.ParseMultipartForm(maxUploadSize); err != nil {
@@ -132,20 +122,7 @@ func handleWaterQualityUpload(waterQualityServiceAddr string) runtime.HandlerFun
 		ctx, cancel := context.WithTimeout(r.Context(), uploadTimeout)
 		defer cancel()
 
-		// 4. Establish gRPC Client Connection
-		opts := []grpc.DialOption{
-			grpc.WithTransportCredentials(insecure.NewCredentials()), // FIXME: Use secure credentials!
-		}
-		conn, err := grpc.NewClient(waterQualityServiceAddr, opts...)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to connect to water quality service (%s): %v", waterQualityServiceAddr, err), http.StatusInternalServerError)
-			return
-		}
-		defer conn.Close()
-
-		client := waterPb.NewWaterQualityServiceClient(conn)
-
-		// 5. Call Streaming RPC
+		// 4. Call Streaming RPC
 		stream, err := client.UploadData(ctx)
 		if err != nil {
 			st, _ := status.FromError(err)
@@ -153,7 +130,7 @@ func handleWaterQualityUpload(waterQualityServiceAddr string) runtime.HandlerFun
 			return
 		}
 
-		// 6. Send Metadata Messages
+		// 5. Send Metadata Messages
 		if err := stream.Send(&waterPb.UploadRequest{Payload: &waterPb.UploadRequest_Filename{Filename: filename}}); err != nil {
 			st, _ := status.FromError(err)
 			// Don't try CloseAndRecv here, the stream is likely broken. Report send error.
@@ -166,7 +143,7 @@ func handleWaterQualityUpload(waterQualityServiceAddr string) runtime.HandlerFun
 			return
 		}
 
-		// 7. Stream File Content
+		// 6. Stream File Content
 		buffer := make([]byte, chunkSize)
 		for {
 			n, readErr := file.Read(buffer)
@@ -195,7 +172,7 @@ func handleWaterQualityUpload(waterQualityServiceAddr string) runtime.HandlerFun
 			}
 		}
 
-		// 8. Close Stream and Get Response
+		// 7. Close Stream and Get Response
 		resp, err := stream.CloseAndRecv()
 		if err != nil {
 			// Check specifically for EOF, which might indicate the server closed
@@ -0,0 +1,221 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
+)
+
+// canaryHeader lets a client pick the deployment: "always" routes to the canary, "never" to stable
+const canaryHeader = "X-Canary"
+
+// canaryDrainDelay is how long a replaced canary connection stays open for calls still in flight
+const canaryDrainDelay = 30 * time.Second
+
+// CanaryRoute sends part of a service's traffic to a second deployment
+type CanaryRoute struct {
+	Backend string `json:"backend"` // Discovered service name or host:port of the canary deployment
+	Weight  int    `json:"weight"`  // Percentage of requests routed to the canary, 0-100
+}
+
+// canaryTarget is an active canary route and its connection
+type canaryTarget struct {
+	route    CanaryRoute
+	endpoint string
+	conn     *grpc.ClientConn
+}
+
+// routedConn sends each RPC of one service to its stable or canary deployment, and mirrors
+// sampled unary calls to its shadow deployment. Handlers are registered with clients built on it,
+// so canaries and shadows can be changed without re-registering them.
+type routedConn struct {
+	service string
+	stable  *grpc.ClientConn
+	canary  atomic.Pointer[canaryTarget]
+	shadow  atomic.Pointer[shadowTarget]
+}
+
+// pick chooses the deployment for one call, honouring the canary header of the HTTP request
+func (c *routedConn) pick(ctx context.Context) grpc.ClientConnInterface {
+	target := c.canary.Load()
+	if target == nil {
+		return c.stable
+	}
+	if r, ok := ctx.Value(requestContextKey{}).(*http.Request); ok {
+		switch strings.ToLower(r.Header.Get(canaryHeader)) {
+		case "always", "true":
+			return target.conn
+		case "never", "false":
+			return c.stable
+		}
+	}
+	if rand.IntN(100) < target.route.Weight {
+		return target.conn
+	}
+	return c.stable
+}
+
+// Invoke implements grpc.ClientConnInterface
+func (c *routedConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	err := c.pick(ctx).Invoke(ctx, method, args, reply, opts...)
+	if shadow := c.shadow.Load(); shadow != nil {
+		shadow.mirror(ctx, method, args, reply, err)
+	}
+	return err
+}
+
+// NewStream implements grpc.ClientConnInterface
+func (c *routedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.pick(ctx).NewStream(ctx, desc, method, opts...)
+}
+
+// close closes the connections of all deployments
+func (c *routedConn) close() error {
+	if target := c.canary.Swap(nil); target != nil {
+		target.conn.Close()
+	}
+	if target := c.shadow.Swap(nil); target != nil {
+		target.conn.Close()
+	}
+	return c.stable.Close()
+}
+
+// routedConnFor returns the routed connection of a discovered service, dialing it on first use.
+// A canary configured in the environment is applied when the connection is created, and so is a
+// shadow (see loadShadowRoute):
+//
+//	GATEWAY_CANARY_USER_SERVICE=user-service-canary   canary backend of user-service
+//	GATEWAY_CANARY_USER_SERVICE_WEIGHT=10             percentage of its traffic (default 0: header only)
+func (g *Gateway) routedConnFor(service domain.Service) (*routedConn, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if conn, ok := g.routedConns[service.Name]; ok {
+		return conn, nil
+	}
+	stable, err := grpc.NewClient(service.Endpoint, g.dialOptions(service.Name)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s (%s): %w", service.Name, service.Endpoint, err)
+	}
+	conn := &routedConn{service: service.Name, stable: stable}
+	g.routedConns[service.Name] = conn
+
+	prefix := "GATEWAY_CANARY_" + strings.ToUpper(strings.ReplaceAll(service.Name, "-", "_"))
+	if backend := utils.GetEnv(prefix, ""); backend != "" {
+		route := CanaryRoute{Backend: backend, Weight: utils.GetEnvAsInt(prefix+"_WEIGHT", 0)}
+		if err := g.applyCanary(conn, route); err != nil {
+			g.logger.Error("Invalid canary configuration, serving stable only", "service", service.Name, "error", err)
+		}
+	}
+	if route, ok := loadShadowRoute(service.Name); ok {
+		if err := g.applyShadow(conn, route); err != nil {
+			g.logger.Error("Invalid shadow configuration, not mirroring", "service", service.Name, "error", err)
+		}
+	}
+	return conn, nil
+}
+
+// SetCanary routes a share of a service's traffic to another deployment, replacing any previous canary
+func (g *Gateway) SetCanary(service string, route CanaryRoute) error {
+	conn, err := g.findRoutedConn(service)
+	if err != nil {
+		return err
+	}
+	return g.applyCanary(conn, route)
+}
+
+// RemoveCanary sends all traffic of a service back to its stable deployment
+func (g *Gateway) RemoveCanary(service string) error {
+	conn, err := g.findRoutedConn(service)
+	if err != nil {
+		return err
+	}
+	if old := conn.canary.Swap(nil); old != nil {
+		time.AfterFunc(canaryDrainDelay, func() { old.conn.Close() })
+		g.logger.Info("Canary removed", "service", conn.service, "backend", old.route.Backend)
+	}
+	return nil
+}
+
+// Canaries returns the active canary routes by service
+func (g *Gateway) Canaries() map[string]CanaryRoute {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	routes := make(map[string]CanaryRoute)
+	for name, conn := range g.routedConns {
+		if target := conn.canary.Load(); target != nil {
+			routes[name] = target.route
+		}
+	}
+	return routes
+}
+
+// findRoutedConn looks up the routed connection of a service with registered handlers
+func (g *Gateway) findRoutedConn(service string) (*routedConn, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for name, conn := range g.routedConns {
+		if sameServiceName(name, service) {
+			return conn, nil
+		}
+	}
+	return nil, fmt.Errorf("service %s has no registered handlers", service)
+}
+
+// applyCanary validates route, dials its backend unless it is already the active canary, and
+// swaps it in. The connection of a replaced canary is closed once in-flight calls had time to finish.
+func (g *Gateway) applyCanary(conn *routedConn, route CanaryRoute) error {
+	if route.Weight < 0 || route.Weight > 100 {
+		return fmt.Errorf("canary weight must be between 0 and 100, got %d", route.Weight)
+	}
+	endpoint, err := g.resolveBackend(route.Backend)
+	if err != nil {
+		return err
+	}
+
+	target := &canaryTarget{route: route, endpoint: endpoint}
+	old := conn.canary.Load()
+	if old != nil && old.endpoint == endpoint {
+		target.conn = old.conn
+	} else if target.conn, err = grpc.NewClient(endpoint, g.dialOptions(conn.service)...); err != nil {
+		return fmt.Errorf("failed to connect to canary %s (%s): %w", route.Backend, endpoint, err)
+	}
+
+	conn.canary.Store(target)
+	if old != nil && old.conn != target.conn {
+		time.AfterFunc(canaryDrainDelay, func() { old.conn.Close() })
+	}
+	g.logger.Info("Canary configured", "service", conn.service, "backend", route.Backend, "endpoint", endpoint, "weight", route.Weight)
+	return nil
+}
+
+// resolveBackend turns a canary backend into a gRPC endpoint: host:port is used as is, anything
+// else is looked up among the discovered services
+func (g *Gateway) resolveBackend(backend string) (string, error) {
+	if backend == "" {
+		return "", fmt.Errorf("canary backend is required")
+	}
+	if strings.Contains(backend, ":") {
+		return backend, nil
+	}
+	services, err := g.discovery.GetAllServices()
+	if err != nil {
+		return "", fmt.Errorf("failed to get services: %w", err)
+	}
+	for _, s := range services {
+		if sameServiceName(s.Name, backend) {
+			return s.Endpoint, nil
+		}
+	}
+	return "", fmt.Errorf("canary backend %s not discovered", backend)
+}
@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
+)
+
+// Check performs the startup work without binding the HTTP port: service discovery, handler
+// registration, a connection to every discovered service, and the swagger route coverage check and
+// merge. It returns every failure, so a deployment gate can run the gateway with --check and fail
+// the rollout before traffic reaches a broken instance.
+func (g *Gateway) Check(ctx context.Context) error {
+	var errs []error
+	if err := g.setupHandlers(); err != nil {
+		errs = append(errs, err)
+	}
+
+	services, err := g.discovery.GetAllServices()
+	switch {
+	case err != nil:
+		errs = append(errs, fmt.Errorf("failed to get services: %w", err))
+	case len(services) == 0:
+		errs = append(errs, errors.New("no services discovered"))
+	}
+	timeout := utils.GetEnvDuration("GATEWAY_CHECK_TIMEOUT", 10*time.Second)
+	for _, service := range services {
+		if err := g.dialCheck(ctx, service, timeout); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		g.logger.Info("Service reachable", "service", service.Name, "endpoint", service.Endpoint)
+	}
+
+	if swaggerDir := findSwaggerDir(); swaggerDir == "" {
+		g.logger.Warn("Swagger directory not found, skipping route policy and swagger checks")
+	} else {
+		if err := g.checkRouteCoverage(swaggerDir); err != nil {
+			errs = append(errs, err)
+		}
+		if config, err := loadSwaggerConfigFromEnv(); err != nil {
+			errs = append(errs, err)
+		} else if _, _, err := mergeSwaggerFiles(g, filepath.Join(swaggerDir, "proto"), config.MergeStrategy); err != nil {
+			errs = append(errs, fmt.Errorf("failed to merge swagger files: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dialCheck connects to a service and waits until the connection is ready
+func (g *Gateway) dialCheck(ctx context.Context, service domain.Service, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := grpc.NewClient(service.Endpoint, g.dialOptions(service.Name)...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s (%s): %w", service.Name, service.Endpoint, err)
+	}
+	defer conn.Close()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("service %s (%s) not reachable within %s: connection %s", service.Name, service.Endpoint, timeout, state)
+		}
+	}
+}
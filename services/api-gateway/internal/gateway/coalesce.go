@@ -0,0 +1,163 @@
+package gateway
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/sync/singleflight"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// coalesceVaryHeaders are the request headers, besides route, query and caller, that change the
+// response of a read; requests that differ in any of them are never coalesced
+var coalesceVaryHeaders = []string{fiber.HeaderAccept, "X-Locale", "X-Timezone", "X-Debug-Explain", "X-Dry-Run"}
+
+// coalesceOwnHeaders describe the request rather than the resource, so a follower keeps its own
+var coalesceOwnHeaders = map[string]bool{
+	"x-request-id":          true,
+	"x-ratelimit-limit":     true,
+	"x-ratelimit-remaining": true,
+	"x-ratelimit-reset":     true,
+	"retry-after":           true,
+	"set-cookie":            true,
+	"content-length":        true,
+	"date":                  true,
+	"server":                true,
+}
+
+// errNotShareable marks a leader response that followers cannot reuse, such as a streamed body
+var errNotShareable = errors.New("response cannot be shared")
+
+// coalescedResponse is a copy of the leader's response handed to the followers
+type coalescedResponse struct {
+	status  int
+	headers [][2]string // Name and value, in response order; repeated names are kept
+	body    []byte
+}
+
+// coalescer lets concurrent identical GET requests share one upstream call: the first request
+// (the leader) is forwarded and the others wait for its response instead of calling the service
+// themselves. Requests are identical when they have the same versioned path, query parameters (in
+// any order), caller and coalesceVaryHeaders.
+type coalescer struct {
+	routes []routePattern
+	group  singleflight.Group
+}
+
+// loadCoalescerFromEnv reads the routes to coalesce from GATEWAY_COALESCE_ROUTES (comma separated
+// patterns as for maintenance, default "GET /api/*"); an empty value turns coalescing off
+func loadCoalescerFromEnv() *coalescer {
+	return &coalescer{routes: parseRoutePatterns(strings.Split(utils.GetEnv("GATEWAY_COALESCE_ROUTES", "GET /api/*"), ","))}
+}
+
+// setupCoalescing installs request coalescing in front of the API muxes
+func (g *Gateway) setupCoalescing() {
+	coalescer := loadCoalescerFromEnv()
+	if len(coalescer.routes) == 0 {
+		return
+	}
+	g.app.Use("/api", g.coalesceMiddleware(coalescer))
+}
+
+// coalesceMiddleware forwards one request per key and copies its response to the requests that
+// arrived while it was in flight. When the leader fails or streams its body, each follower is
+// forwarded on its own, so errors are never shared.
+func (g *Gateway) coalesceMiddleware(co *coalescer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != http.MethodGet || !co.applies(c) {
+			return c.Next()
+		}
+
+		leader := false
+		result, err, _ := co.group.Do(coalesceKey(c), func() (interface{}, error) {
+			leader = true
+			if err := c.Next(); err != nil {
+				return nil, err
+			}
+			if c.Response().IsBodyStream() {
+				return nil, errNotShareable
+			}
+			res := &coalescedResponse{status: c.Response().StatusCode(), body: append([]byte(nil), c.Response().Body()...)}
+			c.Response().Header.VisitAll(func(key, value []byte) {
+				if !coalesceOwnHeaders[strings.ToLower(string(key))] {
+					res.headers = append(res.headers, [2]string{string(key), string(value)})
+				}
+			})
+			return res, nil
+		})
+		if leader {
+			if errors.Is(err, errNotShareable) {
+				return nil
+			}
+			return err
+		}
+		if err != nil {
+			return c.Next()
+		}
+		logger.FromContext(c.UserContext(), g.logger).Debug("Coalesced request with an identical one in flight", "path", c.Path())
+		result.(*coalescedResponse).writeTo(c)
+		return nil
+	}
+}
+
+// applies reports whether the request matches a coalesced route
+func (co *coalescer) applies(c *fiber.Ctx) bool {
+	for _, route := range co.routes {
+		if route.matches(c.Method(), c.Path()) {
+			return true
+		}
+	}
+	return false
+}
+
+// coalesceKey identifies the response a read would get: route, normalized query, caller and the
+// headers that vary the response
+func coalesceKey(c *fiber.Ctx) string {
+	var params []string
+	c.Request().URI().QueryArgs().VisitAll(func(key, value []byte) {
+		params = append(params, string(key)+"="+string(value))
+	})
+	sort.Strings(params)
+
+	var b strings.Builder
+	b.WriteString(c.Path())
+	b.WriteByte('?')
+	b.WriteString(strings.Join(params, "&"))
+	b.WriteByte(0)
+	if claims := middleware.GetClaims(c); claims != nil {
+		if typed, err := claims.Claims(); err == nil {
+			b.WriteString(typed.UserID.String())
+			b.WriteByte('/')
+			b.WriteString(typed.TenantID.String())
+		}
+	}
+	for _, name := range coalesceVaryHeaders {
+		b.WriteByte(0)
+		b.WriteString(c.Get(name))
+	}
+	return b.String()
+}
+
+// writeTo copies the shared response into a follower's response, keeping the follower's own
+// per-request headers
+func (r *coalescedResponse) writeTo(c *fiber.Ctx) {
+	resp := c.Response()
+	resp.SetStatusCode(r.status)
+	seen := map[string]bool{}
+	for _, h := range r.headers {
+		name := strings.ToLower(h[0])
+		if seen[name] {
+			resp.Header.Add(h[0], h[1])
+		} else {
+			seen[name] = true
+			resp.Header.Set(h[0], h[1])
+		}
+	}
+	resp.SetBody(r.body)
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/diagnostics"
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// setupDiagnostics serves the gateway's pprof profiles, expvar variables and dumps under /debug
+// when DIAGNOSTICS_ENABLED is set. They require an access token with one of DIAGNOSTICS_ROLES.
+func (g *Gateway) setupDiagnostics() {
+	config := diagnostics.LoadConfigFromEnv()
+	if !config.Enabled {
+		return
+	}
+	debug := g.app.Group("/debug", middleware.AuthMiddleware(), middleware.RequireRole(config.Roles))
+	debug.All("/*", adaptor.HTTPHandler(diagnostics.Handler()))
+	g.logger.Info("Diagnostics endpoints enabled", "path", "/debug", "roles", config.Roles)
+}
+
+// getServiceGoroutines returns the goroutine stacks of one instance of a backend
+func (g *Gateway) getServiceGoroutines(c *fiber.Ctx) error {
+	return g.callServiceDump(c, core_grpc.DumpGoroutines, func() {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	})
+}
+
+// getServiceHeap returns a heap profile of one instance of a backend, for `go tool pprof`
+func (g *Gateway) getServiceHeap(c *fiber.Ctx) error {
+	return g.callServiceDump(c, core_grpc.DumpHeap, func() {
+		c.Attachment(c.Params("service") + "-heap.pprof")
+		c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	})
+}
+
+// callServiceDump forwards the caller's authorization to the service named in the path and sends
+// the dump it returns, after setHeaders, or the gRPC error. The call reaches one instance behind
+// the service's address; use the service's diagnostics port to pick a pod.
+func (g *Gateway) callServiceDump(c *fiber.Ctx, dump func(ctx context.Context, conn grpc.ClientConnInterface) ([]byte, error), setHeaders func()) error {
+	conn, err := g.serviceConn(c.Params("service"))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), utils.GetEnvDuration("GATEWAY_ADMIN_TIMEOUT", 5*time.Second))
+	defer cancel()
+	if auth := c.Get(fiber.HeaderAuthorization); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+	data, err := dump(ctx, conn)
+	if err != nil {
+		st := status.Convert(err)
+		return c.Status(runtime.HTTPStatusFromCode(st.Code())).JSON(fiber.Map{"error": st.Message()})
+	}
+	setHeaders()
+	return c.Send(data)
+}
@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"golang-microservices-boilerplate/pkg/clients"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// dialConfig holds the gRPC client settings for one backend service. Zero values keep gRPC's defaults.
+type dialConfig struct {
+	MaxRecvMsgSize   int           // Largest response accepted, in bytes (gRPC default 4MB)
+	MaxSendMsgSize   int           // Largest request sent, in bytes
+	KeepaliveTime    time.Duration // Ping an idle connection after this long
+	KeepaliveTimeout time.Duration // Close the connection if a ping is not answered within this
+	UserAgent        string        // Prepended to the gRPC user agent
+	CallTimeout      time.Duration // Deadline for calls that arrive without one
+	MaxRetries       int           // Extra attempts after a transient failure of a retry-safe call
+	RetryBackoff     time.Duration // Initial backoff between attempts, doubled after every attempt
+	BreakerThreshold int           // Consecutive transient failures that open the circuit
+	BreakerCooldown  time.Duration // Time the circuit stays open before a trial call is allowed
+}
+
+// loadDialConfig reads the settings of a service; GATEWAY_GRPC_<SERVICE>_<SETTING> overrides GATEWAY_GRPC_<SETTING>.
+//
+//	GATEWAY_GRPC_MAX_RECV_MSG_SIZE=4194304
+//	GATEWAY_GRPC_WATER_QUALITY_SERVICE_MAX_RECV_MSG_SIZE=67108864
+//	GATEWAY_GRPC_MAX_SEND_MSG_SIZE, GATEWAY_GRPC_KEEPALIVE_TIME=30s, GATEWAY_GRPC_KEEPALIVE_TIMEOUT=10s,
+//	GATEWAY_GRPC_USER_AGENT=api-gateway, GATEWAY_GRPC_CALL_TIMEOUT=30s
+//	GATEWAY_GRPC_MAX_RETRIES=2, GATEWAY_GRPC_RETRY_BACKOFF=100ms (default when retries are on)
+//	GATEWAY_GRPC_BREAKER_THRESHOLD=5, GATEWAY_GRPC_BREAKER_COOLDOWN=30s (default when the breaker is on)
+func loadDialConfig(service string) dialConfig {
+	prefix := "GATEWAY_GRPC_" + strings.ToUpper(strings.ReplaceAll(service, "-", "_")) + "_"
+	str := func(name, def string) string {
+		return utils.GetEnv(prefix+name, utils.GetEnv("GATEWAY_GRPC_"+name, def))
+	}
+	num := func(name string) int {
+		return utils.GetEnvAsInt(prefix+name, utils.GetEnvAsInt("GATEWAY_GRPC_"+name, 0))
+	}
+	dur := func(name string) time.Duration {
+		return utils.GetEnvDuration(prefix+name, utils.GetEnvDuration("GATEWAY_GRPC_"+name, 0))
+	}
+
+	return dialConfig{
+		MaxRecvMsgSize:   num("MAX_RECV_MSG_SIZE"),
+		MaxSendMsgSize:   num("MAX_SEND_MSG_SIZE"),
+		KeepaliveTime:    dur("KEEPALIVE_TIME"),
+		KeepaliveTimeout: dur("KEEPALIVE_TIMEOUT"),
+		UserAgent:        str("USER_AGENT", "api-gateway"),
+		CallTimeout:      dur("CALL_TIMEOUT"),
+		MaxRetries:       num("MAX_RETRIES"),
+		RetryBackoff:     dur("RETRY_BACKOFF"),
+		BreakerThreshold: num("BREAKER_THRESHOLD"),
+		BreakerCooldown:  dur("BREAKER_COOLDOWN"),
+	}
+}
+
+// options converts the settings into dial options for a connection to service. Retries and the
+// circuit breaker only apply to unary calls; see core_grpc.MethodIdempotency for which are retried.
+func (c dialConfig) options(service string) []grpc.DialOption {
+	var callOpts []grpc.CallOption
+	if c.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(c.MaxSendMsgSize))
+	}
+
+	var opts []grpc.DialOption
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if c.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.KeepaliveTime,
+			Timeout:             c.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	if c.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(c.UserAgent))
+	}
+	// The call timeout runs first, so it bounds all attempts of a call together
+	if c.CallTimeout > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(callTimeoutInterceptor(c.CallTimeout)))
+	}
+	if c.MaxRetries > 0 {
+		backoff := c.RetryBackoff
+		if backoff <= 0 {
+			backoff = 100 * time.Millisecond
+		}
+		opts = append(opts, grpc.WithChainUnaryInterceptor(clients.RetryInterceptor(c.MaxRetries, backoff)))
+	}
+	if c.BreakerThreshold > 0 {
+		cooldown := c.BreakerCooldown
+		if cooldown <= 0 {
+			cooldown = 30 * time.Second
+		}
+		opts = append(opts, grpc.WithChainUnaryInterceptor(clients.NewCircuitBreaker(service, c.BreakerThreshold, cooldown).UnaryClientInterceptor()))
+	}
+	return opts
+}
+
+// callTimeoutInterceptor gives unary calls without a deadline (no grpc-timeout from the client) a default one
+func callTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// dialOptions returns the gateway's dial options with the overrides of service applied
+func (g *Gateway) dialOptions(service string) []grpc.DialOption {
+	return append(slices.Clone(g.opts), loadDialConfig(service).options(service)...)
+}
@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// errorEnvelope is the body of the errors rendered by the gateway itself (routing, middleware and
+// panics); errors returned by the services are rendered by defaultErrorHandler
+type errorEnvelope struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// isProduction reports whether APP_ENV is "production"
+func isProduction() bool {
+	return strings.EqualFold(utils.GetEnv("APP_ENV", "development"), "production")
+}
+
+// requestID returns the request's X-Request-Id, assigning a new one (also sent back in the
+// response and forwarded to the services) when the client did not send one
+func requestID(c *fiber.Ctx) string {
+	id := c.Get(fiber.HeaderXRequestID)
+	if id == "" {
+		id = uuid.NewString()
+		c.Request().Header.Set(fiber.HeaderXRequestID, id)
+	}
+	c.Set(fiber.HeaderXRequestID, id)
+	return id
+}
+
+// contextLoggerMiddleware stores a logger with the request_id, method, path and trace of the request
+// in its user context (see logger.FromContext); the route policy adds the user_id once it is known
+func (g *Gateway) contextLoggerMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		args := append([]interface{}{"request_id", requestID(c), "method", c.Method(), "path", c.Path()}, logger.TraceFields(c.Get("traceparent"))...)
+		l := g.logger.With(args...)
+		c.SetUserContext(logger.WithContext(c.UserContext(), l))
+		return c.Next()
+	}
+}
+
+// recoverMiddleware turns panics in handlers into 500 errors for fiberErrorHandler, logging the stack
+func (g *Gateway) recoverMiddleware() fiber.Handler {
+	return recover.New(recover.Config{
+		EnableStackTrace: true,
+		StackTraceHandler: func(c *fiber.Ctx, e interface{}) {
+			logger.FromContext(c.UserContext(), g.logger).Error("Recovered from panic in HTTP handler", "panic", fmt.Sprint(e), "stack", string(debug.Stack()))
+		},
+	})
+}
+
+// fiberErrorHandler renders errors returned by Fiber handlers as an errorEnvelope. The status of a
+// *fiber.Error is kept; any other error is a 500. Messages of 5xx errors are replaced by the
+// status text in production, so internal details never reach clients.
+func (g *Gateway) fiberErrorHandler(c *fiber.Ctx, err error) error {
+	code, message := http.StatusInternalServerError, err.Error()
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		code, message = fiberErr.Code, fiberErr.Message
+	}
+
+	id := requestID(c)
+	log := logger.FromContext(c.UserContext(), g.logger.With("request_id", id))
+	if code >= http.StatusInternalServerError {
+		log.Error("Fiber Error", "error", err, "status", code, "ip", c.IP())
+		if g.production {
+			message = http.StatusText(code)
+		}
+	} else {
+		log.Debug("Fiber Error", "error", err, "status", code, "ip", c.IP())
+	}
+
+	return c.Status(code).JSON(errorEnvelope{Code: code, Message: message, RequestID: id})
+}
@@ -0,0 +1,231 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+	user_pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// eventResources lists the resource types published on the change feed. A caller may watch a
+// resource only if the policy of its list route (GET /api/v1/<resource>s) admits the caller's role.
+var eventResources = []string{"user"}
+
+// sseEvent is the JSON payload written in the data field of each server-sent event
+type sseEvent struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Resource      string          `json:"resource"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+	SchemaVersion int32           `json:"schema_version,omitempty"`
+}
+
+// setupEventStream registers the /api/v1/events Server-Sent Events endpoint
+func (g *Gateway) setupEventStream() {
+	g.app.Get("/api/v1/events", g.handleEvents)
+}
+
+// handleEvents streams entity change events as Server-Sent Events.
+// Query parameters: resources (comma separated, defaults to every resource the caller may read).
+// Clients resume with the Last-Event-ID header (sent automatically by EventSource) or ?last_event_id=.
+func (g *Gateway) handleEvents(c *fiber.Ctx) error {
+	role := ""
+	if claims := middleware.GetClaims(c); claims != nil {
+		if typed, err := claims.Claims(); err == nil {
+			role = typed.Role
+		}
+	}
+	resources, err := watchableResources(role, c.Query("resources"))
+	if err != nil {
+		return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+	var after uint64
+	if lastEventID != "" {
+		if after, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid Last-Event-ID"})
+		}
+	}
+
+	conn, err := g.serviceConn("user-service")
+	if err != nil {
+		g.logger.Error("Change feed backend unavailable", "error", err)
+		return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "change feed unavailable"})
+	}
+
+	// The stream outlives this handler, so it is bound to the gateway's stream context and
+	// cancelled once the client goes away
+	ctx, cancel := context.WithCancel(g.streamCtx)
+	if auth := c.Get("Authorization"); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+	stream, err := user_pb.NewEventServiceClient(conn).WatchEvents(ctx, &user_pb.WatchEventsRequest{
+		AfterSequence: after,
+		Resources:     resources,
+	})
+	if err != nil {
+		cancel()
+		g.logger.Error("Failed to open change feed stream", "error", err)
+		return c.Status(http.StatusBadGateway).JSON(fiber.Map{"error": "change feed unavailable"})
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	keepalive := utils.GetEnvDuration("EVENT_STREAM_KEEPALIVE", 15*time.Second)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		received := make(chan *user_pb.ChangeEvent)
+		go func() {
+			defer close(received)
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					if ctx.Err() == nil {
+						g.logger.Warn("Change feed stream ended", "error", err)
+					}
+					return
+				}
+				select {
+				case received <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		// Tell EventSource clients how long to wait before reconnecting
+		fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+		if w.Flush() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(keepalive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+				if err := writeSSEEvent(w, msg); err != nil {
+					g.logger.Error("Failed to encode change event", "event_id", msg.GetId(), "error", err)
+					continue
+				}
+			case <-ticker.C:
+				fmt.Fprint(w, ": keepalive\n\n")
+			}
+			// A failed flush means the client disconnected
+			if w.Flush() != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// writeSSEEvent writes one change event in the text/event-stream format
+func writeSSEEvent(w *bufio.Writer, msg *user_pb.ChangeEvent) error {
+	data := json.RawMessage("{}")
+	if msg.GetData() != nil {
+		raw, err := protojson.Marshal(msg.GetData())
+		if err != nil {
+			return err
+		}
+		data = raw
+	}
+	payload, err := json.Marshal(sseEvent{
+		ID:            msg.GetId(),
+		Type:          msg.GetType(),
+		Resource:      msg.GetResource(),
+		OccurredAt:    msg.GetOccurredAt().AsTime(),
+		Data:          data,
+		SchemaVersion: msg.GetSchemaVersion(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.GetSequence(), msg.GetType(), payload)
+	return err
+}
+
+// watchableResources resolves the requested resources against what the role may read.
+// An empty request means every permitted resource; requesting a forbidden or unknown one is an error.
+func watchableResources(role, requested string) ([]string, error) {
+	var allowed []string
+	for _, resource := range eventResources {
+		policy := routePolicies.Match(http.MethodGet, "/api/v1/"+resource+"s")
+		if policy != nil && policy.AllowsRole(role) {
+			allowed = append(allowed, resource)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("no resources available to watch")
+	}
+
+	var resources []string
+	for _, resource := range strings.Split(requested, ",") {
+		resource = strings.ToLower(strings.TrimSpace(resource))
+		if resource == "" || slices.Contains(resources, resource) {
+			continue
+		}
+		if !slices.Contains(allowed, resource) {
+			return nil, fmt.Errorf("not permitted to watch resource %q", resource)
+		}
+		resources = append(resources, resource)
+	}
+	if len(resources) == 0 {
+		// An empty list would make the backend stream every resource
+		return allowed, nil
+	}
+	return resources, nil
+}
+
+// serviceConn returns a shared client connection to a discovered backend, dialing it on first use
+func (g *Gateway) serviceConn(name string) (*grpc.ClientConn, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if conn, ok := g.serviceConns[name]; ok {
+		return conn, nil
+	}
+
+	services, err := g.discovery.GetAllServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+	for _, s := range services {
+		if !sameServiceName(s.Name, name) {
+			continue
+		}
+		conn, err := grpc.NewClient(s.Endpoint, g.dialOptions(s.Name)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s (%s): %w", name, s.Endpoint, err)
+		}
+		g.serviceConns[name] = conn
+		return conn, nil
+	}
+	return nil, fmt.Errorf("service %s not discovered", name)
+}
@@ -10,31 +10,53 @@ import (
 	"sync"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/adaptor"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
 
+	"golang-microservices-boilerplate/pkg/core/cache"
+	"golang-microservices-boilerplate/pkg/core/i18n"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/maintenance"
+	"golang-microservices-boilerplate/pkg/core/watchdog"
 	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
 	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
 )
 
 // Gateway handles HTTP requests by translating them to gRPC calls using Fiber
 type Gateway struct {
-	ctx          context.Context
-	app          *fiber.App
-	gwMux        *runtime.ServeMux
-	logger       logger.Logger
-	stdLogger    *log.Logger // Standard logger adapter for compatibility
-	discovery    domain.ServiceDiscovery
-	serviceConns map[string]*grpc.ClientConn
-	opts         []grpc.DialOption
-	mu           sync.Mutex
+	ctx            context.Context
+	app            *fiber.App
+	versions       []*APIVersion
+	defaultVersion string
+	logger         logger.Logger
+	stdLogger      *log.Logger // Standard logger adapter for compatibility
+	discovery      domain.ServiceDiscovery
+	serviceConns   map[string]*grpc.ClientConn
+	routedConns    map[string]*routedConn // Connections of the registered handlers, by service, with their canaries
+	opts           []grpc.DialOption
+	mu             sync.Mutex
+	ipFilter       *middleware.IPFilter
+	cookieConfig   middleware.TokenCookieConfig
+	revokedTokens  *cache.TokenBlacklist // Access tokens revoked at logout
+	maintenance    *maintenance.Switch   // Shared maintenance mode, flipped through /admin/maintenance
+	headers        *headerPolicy         // Which request headers are forwarded as metadata
+	transformer    *middleware.Transformer
+	validator      *requestValidator  // Checks requests against the swagger schemas; nil when disabled
+	slowRequests   *watchdog.Watchdog // Flags requests exceeding their latency threshold
+	requestMetrics *requestMetrics    // Request durations per route class, served on /metrics
+	streamCtx      context.Context    // Parent of long-lived client streams such as the change feed
+	stopStreams    context.CancelFunc // Ends those streams so shutdown does not wait on them
+	production     bool               // APP_ENV=production: hide internal error messages from clients
 }
 
+// roundRobinServiceConfig spreads calls across all resolved addresses of a service instead of using
+// the first one (gRPC's default pick_first), which matters once endpoints resolve to individual pods
+const roundRobinServiceConfig = `{"loadBalancingConfig":[{"round_robin":{}}]}`
+
 // GatewayOption configures the Gateway
 type GatewayOption func(*Gateway)
 
@@ -47,6 +69,21 @@ func WithLogger(l logger.Logger) GatewayOption {
 	}
 }
 
+// WithIPFilter sets the IP allow/deny filter; by default it is loaded from IP_FILTER_* env variables
+func WithIPFilter(f *middleware.IPFilter) GatewayOption {
+	return func(g *Gateway) {
+		g.ipFilter = f
+	}
+}
+
+// WithTransformer sets the request/response transformer, e.g. one with Go hooks added.
+// By default rules are loaded from the file in TRANSFORM_RULES_FILE.
+func WithTransformer(t *middleware.Transformer) GatewayOption {
+	return func(g *Gateway) {
+		g.transformer = t
+	}
+}
+
 // stdLogAdapter adapts logger.Logger to io.Writer for standard logger
 type stdLogAdapter struct {
 	logger logger.Logger
@@ -70,28 +107,50 @@ func (a *stdLogAdapter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-// NewGateway creates a new Gateway using Fiber
+// NewGateway creates a new Gateway using Fiber. Invalid security configuration, such as IP filter
+// rules, is an error rather than a reason to start with weaker protection, and so are an invalid
+// API version configuration and invalid transform rules.
 func NewGateway(
 	ctx context.Context,
 	discovery domain.ServiceDiscovery,
 	opts ...GatewayOption,
-) *Gateway {
+) (*Gateway, error) {
 
 	// Create a temporary base logger first
 	tempBaseLogger, _ := logger.NewLogger(logger.DefaultLogConfig())
 	// Now create a named logger from the base instance
 	tempLogger := tempBaseLogger.Named("gateway-init")
 
+	cookieConfig := middleware.LoadTokenCookieConfigFromEnv()
+	headers := loadHeaderPolicyFromEnv()
+	muxOpts := []runtime.ServeMuxOption{
+		runtime.WithErrorHandler(defaultErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headers.Match),
+		runtime.WithForwardResponseOption(paginationHeaders),
+	}
+	muxOpts = append(muxOpts, streamMarshalerOptions()...)
+	if cookieConfig.Enabled {
+		muxOpts = append(muxOpts, runtime.WithForwardResponseOption(tokenCookieForwarder(cookieConfig)))
+	}
+	// Serving fewer versions than configured would break the clients of the missing ones
+	versions, defaultVersion, err := loadAPIVersions(muxOpts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API version configuration: %w", err)
+	}
+
+	streamCtx, stopStreams := context.WithCancel(ctx)
 	g := &Gateway{
-		ctx: ctx,
+		ctx:         ctx,
+		streamCtx:   streamCtx,
+		stopStreams: stopStreams,
 		// Fiber app initialized later after logger is finalized
-		gwMux: runtime.NewServeMux(
-			runtime.WithErrorHandler(defaultErrorHandler),
-			runtime.WithIncomingHeaderMatcher(headerMatcher),
-		),
+		cookieConfig: cookieConfig,
+		production:   isProduction(),
+		headers:      headers,
 		discovery:    discovery,
 		serviceConns: make(map[string]*grpc.ClientConn),
-		opts:         []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		routedConns:  make(map[string]*routedConn),
+		opts:         []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultServiceConfig(roundRobinServiceConfig)},
 		logger:       tempLogger, // Start with temp named logger
 		stdLogger:    log.New(&stdLogAdapter{logger: tempLogger}, "", 0),
 		mu:           sync.Mutex{},
@@ -102,6 +161,14 @@ func NewGateway(
 		opt(g)
 	}
 
+	g.revokedTokens = newTokenBlacklist(g.logger)
+	middleware.SetRevocationList(g.revokedTokens)
+	g.maintenance = maintenance.NewSwitchFromEnv(g.logger)
+	g.slowRequests = watchdog.NewFromEnv(g.logger)
+
+	g.versions = versions
+	g.defaultVersion = defaultVersion
+
 	// --- Configure components that depend on the FINAL logger ---
 
 	// Configure Fiber App with the final logger in the error handler
@@ -115,21 +182,117 @@ func NewGateway(
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(grpcStdLogger.Writer(), grpcStdLogger.Writer(), grpcStdLogger.Writer()))
 
 	// Add Fiber middleware
-	g.app.Use(cors.New())                    // CORS
+	g.app.Use(g.contextLoggerMiddleware())   // Request-scoped logger, see logger.FromContext
+	g.setupRequestMetrics()                  // Before everything that may reject a request, so those are measured too
+	g.app.Use(g.recoverMiddleware())         // Panics become 500 responses
+	g.setupCORS()                            // CORS_* policies
+	g.setupSecurityHeaders()                 // HSTS, CSP, X-Frame-Options, ...
 	g.app.Use(middleware.LoggerMiddleware()) // Call middleware without logger arg
+	if err := g.setupIPFilter(); err != nil {
+		return nil, err
+	}
+	g.app.Use("/api", g.maintenanceMiddleware())
+	g.app.Use("/api", g.headers.Middleware())
+	g.app.Use("/api", localeMiddleware())
+	g.app.Use("/api", g.negotiateVersion) // Before auth so policies see the versioned path
+	g.app.Use("/api", g.slowRequestMiddleware())
+	g.setupCookieAuth()
+	g.setupAuthMiddleware()
+	g.setupRateLimit()       // After auth, so per-user limits know the caller
+	g.setupIdempotencyKeys() // After auth, so keys are kept per user
+	g.setupEventStream()     // Before the transformer, which buffers response bodies
+	g.setupLogout()
+	g.setupAdminAPI()
+	g.setupDiagnostics()
 
-	setupAuthMiddleware(g.app, g.logger)
+	if err := g.setupTransformer(); err != nil {
+		return nil, err
+	}
+	g.app.Use("/api", g.requestValidationMiddleware()) // After the transformer, so rewritten bodies are checked
+	g.setupCoalescing()                                // Last, so followers share only the upstream call
+
+	// Mount one gRPC-Gateway mux per API version
+	g.mountVersions()
+
+	return g, nil
+}
+
+// setupIPFilter installs the CIDR allow/deny middleware, loading rules from env if none were
+// provided. Invalid rules are an error: starting without them would expose the routes they protect.
+func (g *Gateway) setupIPFilter() error {
+	if g.ipFilter == nil {
+		cfg, err := middleware.LoadIPFilterConfigFromEnv()
+		if err == nil {
+			g.ipFilter, err = middleware.NewIPFilter(cfg)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid IP filter configuration: %w", err)
+		}
+	}
+	g.app.Use(g.ipFilter.Middleware())
+	return nil
+}
+
+// setupCORS installs the CORS policies from env. An invalid configuration is logged and no CORS
+// headers are sent, so browsers refuse cross-origin requests rather than getting a looser policy.
+func (g *Gateway) setupCORS() {
+	cfg, err := middleware.LoadCORSConfigFromEnv()
+	var policies *middleware.CORS
+	if err == nil {
+		policies, err = middleware.NewCORS(cfg)
+	}
+	if err != nil {
+		g.logger.Error("Invalid CORS configuration, cross-origin requests are not allowed", "error", err)
+		return
+	}
+	g.app.Use(policies.Middleware())
+}
 
-	// Mount the gRPC-Gateway mux
-	g.app.Use("/api", adaptor.HTTPHandler(g.gwMux))
+// setupSecurityHeaders installs the security headers from env. An invalid configuration is logged
+// and the built-in defaults are used instead.
+func (g *Gateway) setupSecurityHeaders() {
+	cfg, err := middleware.LoadSecurityHeadersConfigFromEnv()
+	var headers *middleware.SecurityHeaders
+	if err == nil {
+		headers, err = middleware.NewSecurityHeaders(cfg)
+	}
+	if err != nil {
+		g.logger.Error("Invalid security headers configuration, using the defaults", "error", err)
+		headers = middleware.DefaultSecurityHeaders()
+	}
+	g.app.Use(headers.Middleware())
+}
 
-	return g
+// setupTransformer installs the request/response transformation middleware in front of the API
+// muxes. An invalid rules file is an error: the routes would be served without the rewrites
+// clients and backends depend on.
+func (g *Gateway) setupTransformer() error {
+	if g.transformer == nil {
+		g.transformer = middleware.NewTransformer()
+		if path := utils.GetEnv("TRANSFORM_RULES_FILE", ""); path != "" {
+			rules, err := middleware.LoadTransformRules(path)
+			if err != nil {
+				return fmt.Errorf("invalid transform rules: %w", err)
+			}
+			g.transformer = middleware.NewTransformer(rules...)
+			g.logger.Info("Loaded transform rules", "file", path, "rules", len(rules))
+		}
+	}
+	g.app.Use("/api", g.transformer.Middleware())
+	return nil
 }
 
-// fiberErrorHandler is the custom error handler for Fiber that uses the gateway's logger.
-func (g *Gateway) fiberErrorHandler(c *fiber.Ctx, err error) error {
-	g.logger.Error("Fiber Error", "error", err, "path", c.Path(), "method", c.Method(), "ip", c.IP())
-	return fiber.DefaultErrorHandler(c, err)
+// ReloadIPFilter re-reads the IP filter rules from env and swaps them in without a restart.
+func (g *Gateway) ReloadIPFilter() error {
+	cfg, err := middleware.LoadIPFilterConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	if err := g.ipFilter.Reload(cfg); err != nil {
+		return err
+	}
+	g.logger.Info("IP filter rules reloaded", "rules", len(cfg.Rules))
+	return nil
 }
 
 // Start initializes the gateway and starts the Fiber HTTP server
@@ -138,42 +301,67 @@ func (g *Gateway) Start(port string) error {
 		return err
 	}
 
-	swaggerDir := os.Getenv("SWAGGER_DIR")
+	swaggerDir := findSwaggerDir()
 	if swaggerDir == "" {
-		possiblePaths := []string{
-			"swagger",
-			"./swagger",
-		}
-		for _, path := range possiblePaths {
-			if _, err := os.Stat(path); err == nil {
-				g.logger.Info("Found swagger directory", "path", path)
-				swaggerDir = path
-				break
-			}
+		g.logger.Warn("Swagger directory not found, skipping Swagger UI setup, route policy check and request validation")
+		if loadRequestValidationConfigFromEnv().Enabled {
+			g.logger.Warn("REQUEST_VALIDATION_ENABLED is set but there are no schemas to validate against")
 		}
-	}
-
-	if swaggerDir == "" {
-		g.logger.Warn("Swagger directory not found, skipping Swagger UI setup")
 	} else {
-		g.RegisterSwaggerUI(swaggerDir)
+		g.logger.Info("Found swagger directory", "path", swaggerDir)
+		if err := g.checkRouteCoverage(swaggerDir); err != nil {
+			return err
+		}
+		if err := g.loadRequestValidator(swaggerDir); err != nil {
+			return err
+		}
+		if err := g.RegisterSwaggerUI(swaggerDir); err != nil {
+			return err
+		}
 	}
 
 	g.app.Get("/health", func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "healthy"})
 	})
+	g.app.Get("/metrics", g.serveMetrics)
 
 	g.logger.Info("Starting Fiber HTTP server", "port", port)
 	return g.app.Listen(fmt.Sprintf(":%s", port))
 }
 
+// findSwaggerDir returns SWAGGER_DIR or the first existing default location, or "" if there is none
+func findSwaggerDir() string {
+	if dir := os.Getenv("SWAGGER_DIR"); dir != "" {
+		return dir
+	}
+	for _, path := range []string{"swagger", "./swagger"} {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
 // Shutdown gracefully shuts down the Fiber server
 func (g *Gateway) Shutdown(ctx context.Context) error {
 	g.logger.Info("Shutting down Fiber server...")
+	g.stopStreams()
 	serverErr := g.app.Shutdown()
 
-	// Removed closing of gRPC connections previously managed by discovery
-	// The connections used by Register...FromEndpoint are managed internally by grpc-gateway/grpc
+	g.mu.Lock()
+	for name, conn := range g.serviceConns {
+		if err := conn.Close(); err != nil {
+			g.logger.Warn("Failed to close service connection", "service", name, "error", err)
+		}
+		delete(g.serviceConns, name)
+	}
+	for name, conn := range g.routedConns {
+		if err := conn.close(); err != nil {
+			g.logger.Warn("Failed to close service connection", "service", name, "error", err)
+		}
+		delete(g.routedConns, name)
+	}
+	g.mu.Unlock()
 
 	if serverErr != nil {
 		g.logger.Error("Failed to shutdown Fiber server", "error", serverErr)
@@ -187,17 +375,9 @@ func (g *Gateway) Shutdown(ctx context.Context) error {
 // defaultErrorHandler is the default gRPC-Gateway error handler.
 func defaultErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 	grpclog.Errorf("gRPC-Gateway Error: %v", err)
-	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
-}
-
-// headerMatcher remains the same.
-func headerMatcher(key string) (string, bool) {
-	key = strings.ToLower(key)
-	if key == "authorization" {
-		return key, true
+	// Render localizable errors in the caller's language
+	if st, ok := status.FromError(err); ok {
+		err = i18n.LocalizeStatus(st, i18n.MatchLanguage(r.Header.Get("Accept-Language"))).Err()
 	}
-	if strings.HasPrefix(key, "x-") {
-		return key, true
-	}
-	return runtime.DefaultHeaderMatcher(key)
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
 }
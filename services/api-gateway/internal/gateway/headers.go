@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// headerPolicy decides which incoming HTTP headers reach the backends as gRPC metadata.
+// Only allowlisted headers are forwarded under their own name, so clients cannot inject internal
+// metadata keys (e.g. x-user-id) that services might trust. Standard HTTP headers are still
+// forwarded with the grpc-gateway "grpcgateway-" prefix, which keeps them out of that namespace.
+type headerPolicy struct {
+	allowed  []string // Exact header names, lower case
+	prefixes []string // Header name prefixes, lower case
+	maxCount int      // Most forwarded headers per request; 0 disables the check
+	maxBytes int      // Largest total size (names and values) of forwarded headers; 0 disables the check
+}
+
+// defaultForwardedHeaders are the headers the services read from metadata
+const defaultForwardedHeaders = "authorization,x-request-id,traceparent,x-forwarded-for,x-real-ip,x-debug-explain,x-dry-run,x-locale,x-timezone"
+
+// loadHeaderPolicyFromEnv reads the forwarding rules.
+//
+//	GATEWAY_FORWARDED_HEADERS=authorization,x-request-id,...  exact names (default: the headers services read)
+//	GATEWAY_FORWARDED_HEADER_PREFIXES=x-client-               name prefixes (default none)
+//	GATEWAY_MAX_FORWARDED_HEADERS=32
+//	GATEWAY_MAX_FORWARDED_HEADER_BYTES=8192
+func loadHeaderPolicyFromEnv() *headerPolicy {
+	return &headerPolicy{
+		allowed:  splitHeaderList(utils.GetEnv("GATEWAY_FORWARDED_HEADERS", defaultForwardedHeaders)),
+		prefixes: splitHeaderList(utils.GetEnv("GATEWAY_FORWARDED_HEADER_PREFIXES", "")),
+		maxCount: utils.GetEnvAsInt("GATEWAY_MAX_FORWARDED_HEADERS", 32),
+		maxBytes: utils.GetEnvAsInt("GATEWAY_MAX_FORWARDED_HEADER_BYTES", 8192),
+	}
+}
+
+func splitHeaderList(s string) []string {
+	var out []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// allows reports whether a (lower case) header name is allowlisted
+func (p *headerPolicy) allows(key string) bool {
+	if slices.Contains(p.allowed, key) {
+		return true
+	}
+	return slices.ContainsFunc(p.prefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) })
+}
+
+// Match is the gRPC-Gateway incoming header matcher
+func (p *headerPolicy) Match(key string) (string, bool) {
+	key = strings.ToLower(key)
+	if p.allows(key) {
+		return key, true
+	}
+	// Grpc-Metadata-<key> would otherwise set <key> verbatim; apply the allowlist to <key>
+	if name, ok := strings.CutPrefix(key, strings.ToLower(runtime.MetadataHeaderPrefix)); ok {
+		return name, p.allows(name)
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// Middleware rejects requests whose forwarded headers exceed the count or size budget with 431
+func (p *headerPolicy) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if p.maxCount <= 0 && p.maxBytes <= 0 {
+			return c.Next()
+		}
+		count, size := 0, 0
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			if _, ok := p.Match(string(key)); ok {
+				count++
+				size += len(key) + len(value)
+			}
+		})
+
+		var reason string
+		switch {
+		case p.maxCount > 0 && count > p.maxCount:
+			reason = fmt.Sprintf("too many forwarded headers (%d, maximum %d)", count, p.maxCount)
+		case p.maxBytes > 0 && size > p.maxBytes:
+			reason = fmt.Sprintf("forwarded headers too large (%d bytes, maximum %d)", size, p.maxBytes)
+		default:
+			return c.Next()
+		}
+		return c.Status(http.StatusRequestHeaderFieldsTooLarge).JSON(fiber.Map{"error": reason})
+	}
+}
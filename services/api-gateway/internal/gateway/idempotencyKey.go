@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/core/cache"
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyMaxLength   = 255
+	// idempotencyLockTTL bounds how long a request holds its key; a gateway that dies mid-request
+	// frees the key after this long
+	idempotencyLockTTL = time.Minute
+)
+
+// storedIdempotentResponse is the response kept for an Idempotency-Key
+type storedIdempotentResponse struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status"`
+	Headers     [][2]string `json:"headers"`
+	Body        []byte      `json:"body"`
+}
+
+// setupIdempotencyKeys lets clients retry unsafe requests by sending an Idempotency-Key header.
+// GATEWAY_IDEMPOTENCY_TTL (default 24h) is how long a response is kept for its key; 0 turns
+// Idempotency-Key handling off.
+func (g *Gateway) setupIdempotencyKeys() {
+	ttl := utils.GetEnvDuration("GATEWAY_IDEMPOTENCY_TTL", 24*time.Hour)
+	if ttl <= 0 {
+		return
+	}
+	g.app.Use("/api", g.idempotencyKeyMiddleware(newSharedCache(g.logger, "Idempotency key"), ttl))
+}
+
+// idempotencyKeyMiddleware makes an unsafe request carrying an Idempotency-Key run at most once per
+// caller and key: the first response is stored and returned again, with Idempotent-Replayed: true,
+// to later requests with the same key. Reusing a key for a different request fails with 422, and
+// repeating it while the first request is still running fails with 409. Responses with a 5xx
+// status are not stored, so the client can retry them with the same key. Requests whose route is
+// retry-safe (see core_grpc.RouteIdempotency) can be repeated anyway and ignore the header.
+func (g *Gateway) idempotencyKeyMiddleware(store cache.Cache, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(idempotencyKeyHeader)
+		if key == "" || core_grpc.RouteIdempotency(c.Method(), c.Path()).RetrySafe() {
+			return c.Next()
+		}
+		if len(key) > idempotencyKeyMaxLength {
+			return fiber.NewError(http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+		}
+
+		ctx := c.UserContext()
+		log := logger.FromContext(ctx, g.logger)
+		cacheKey := "idempotency:" + g.idempotencyScope(c) + ":" + key
+		fingerprint := idempotencyFingerprint(c)
+
+		if data, err := store.Get(ctx, cacheKey); err == nil {
+			var stored storedIdempotentResponse
+			if err := json.Unmarshal(data, &stored); err == nil {
+				if stored.Fingerprint != fingerprint {
+					return fiber.NewError(http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+				}
+				log.Debug("Replayed response for Idempotency-Key", "path", c.Path())
+				(&coalescedResponse{status: stored.Status, headers: stored.Headers, body: stored.Body}).writeTo(c)
+				c.Set(idempotencyReplayedHeader, "true")
+				return nil
+			}
+		} else if !errors.Is(err, cache.ErrMiss) {
+			log.Warn("Idempotency key store unavailable, forwarding request without it", "error", err)
+			return c.Next()
+		}
+
+		lockKey := cacheKey + ":lock"
+		holders, err := store.Incr(ctx, lockKey, 1, idempotencyLockTTL)
+		if err != nil {
+			log.Warn("Idempotency key store unavailable, forwarding request without it", "error", err)
+			return c.Next()
+		}
+		if holders > 1 {
+			return fiber.NewError(http.StatusConflict, "a request with this Idempotency-Key is still in progress")
+		}
+		defer func() {
+			if err := store.Delete(ctx, lockKey); err != nil {
+				log.Warn("Failed to release Idempotency-Key", "error", err)
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		resp := c.Response()
+		if resp.StatusCode() >= http.StatusInternalServerError || resp.IsBodyStream() {
+			return nil
+		}
+		stored := storedIdempotentResponse{Fingerprint: fingerprint, Status: resp.StatusCode(), Body: resp.Body()}
+		resp.Header.VisitAll(func(name, value []byte) {
+			if !coalesceOwnHeaders[strings.ToLower(string(name))] {
+				stored.Headers = append(stored.Headers, [2]string{string(name), string(value)})
+			}
+		})
+		data, err := json.Marshal(stored)
+		if err == nil {
+			err = store.Set(ctx, cacheKey, data, ttl)
+		}
+		if err != nil {
+			log.Warn("Failed to store response for Idempotency-Key", "error", err)
+		}
+		return nil
+	}
+}
+
+// idempotencyScope keeps the keys of different callers apart: the user for authenticated requests,
+// the client IP otherwise
+func (g *Gateway) idempotencyScope(c *fiber.Ctx) string {
+	if claims := middleware.GetClaims(c); claims != nil {
+		if typed, err := claims.Claims(); err == nil {
+			return "user:" + typed.UserID.String()
+		}
+	}
+	if ip := g.ipFilter.ClientIP(c); ip != nil {
+		return "ip:" + ip.String()
+	}
+	return "ip:" + c.IP()
+}
+
+// idempotencyFingerprint identifies the request a key was first used for
+func idempotencyFingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method() + " " + c.Path() + "?" + string(c.Request().URI().QueryString()) + "\x00"))
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
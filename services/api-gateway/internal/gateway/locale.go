@@ -0,0 +1,29 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/i18n"
+)
+
+// localeMiddleware normalizes the caller's locale before the request is forwarded: X-Locale is set
+// to the catalog language matching Accept-Language and X-Timezone to the canonical IANA name of the
+// requested zone (UTC when absent). Client-sent X-Locale values are replaced, so services can rely on
+// both headers; an unknown time zone is rejected with 400.
+func localeMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		loc, err := i18n.ParseTimezone(c.Get(coreGrpc.TimezoneMetadataKey))
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, "invalid X-Timezone header: "+err.Error())
+		}
+		language := i18n.MatchLanguage(c.Get(fiber.HeaderAcceptLanguage))
+
+		c.Request().Header.Set(coreGrpc.LocaleMetadataKey, language)
+		c.Request().Header.Set(coreGrpc.TimezoneMetadataKey, loc.String())
+		c.Set(fiber.HeaderContentLanguage, language)
+		return c.Next()
+	}
+}
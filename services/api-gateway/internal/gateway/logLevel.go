@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// logLevelBody is the request and response body of the log level endpoints
+type logLevelBody struct {
+	Level string `json:"level"`
+}
+
+// getLogLevel returns the gateway's log level
+func (g *Gateway) getLogLevel(c *fiber.Ctx) error {
+	controller, ok := g.logger.(logger.LevelController)
+	if !ok {
+		return c.Status(http.StatusNotImplemented).JSON(fiber.Map{"error": "log level cannot be changed at runtime"})
+	}
+	return c.JSON(logLevelBody{Level: string(controller.Level())})
+}
+
+// putLogLevel changes the gateway's log level from a {"level": "debug"} body
+func (g *Gateway) putLogLevel(c *fiber.Ctx) error {
+	controller, ok := g.logger.(logger.LevelController)
+	if !ok {
+		return c.Status(http.StatusNotImplemented).JSON(fiber.Map{"error": "log level cannot be changed at runtime"})
+	}
+	var body logLevelBody
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	level, err := logger.ParseLogLevel(body.Level)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	previous := controller.Level()
+	if err := controller.SetLevel(level); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.FromContext(c.UserContext(), g.logger).Warn("Log level changed", "from", previous, "to", level)
+	return c.JSON(logLevelBody{Level: string(level)})
+}
+
+// getServiceLogLevel returns the log level of a backend through its gRPC log level service
+func (g *Gateway) getServiceLogLevel(c *fiber.Ctx) error {
+	return g.callServiceLogLevel(c, func(ctx context.Context, conn *grpc.ClientConn) (string, error) {
+		return core_grpc.GetLogLevel(ctx, conn)
+	})
+}
+
+// putServiceLogLevel changes the log level of a backend from a {"level": "debug"} body. The call
+// reaches one instance behind the service's address; call the service directly to pick a pod.
+func (g *Gateway) putServiceLogLevel(c *fiber.Ctx) error {
+	var body logLevelBody
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	return g.callServiceLogLevel(c, func(ctx context.Context, conn *grpc.ClientConn) (string, error) {
+		return core_grpc.SetLogLevel(ctx, conn, body.Level)
+	})
+}
+
+// callServiceLogLevel forwards the caller's authorization to the service named in the path, runs
+// call and renders the level or the gRPC error
+func (g *Gateway) callServiceLogLevel(c *fiber.Ctx, call func(ctx context.Context, conn *grpc.ClientConn) (string, error)) error {
+	service := c.Params("service")
+	conn, err := g.serviceConn(service)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), utils.GetEnvDuration("GATEWAY_ADMIN_TIMEOUT", 5*time.Second))
+	defer cancel()
+	if auth := c.Get(fiber.HeaderAuthorization); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+	level, err := call(ctx, conn)
+	if err != nil {
+		st := status.Convert(err)
+		return c.Status(runtime.HTTPStatusFromCode(st.Code())).JSON(fiber.Map{"error": st.Message()})
+	}
+	return c.JSON(logLevelBody{Level: level})
+}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/cache"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+	user_pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// logoutPath is handled by the gateway itself: it revokes the access token, which only the
+// gateway checks on every request, and asks the user service to revoke the refresh token
+const logoutPath = "/api/v1/auth/logout"
+
+// newTokenBlacklist creates the access token denylist from the CACHE_* settings. Use Redis
+// (CACHE_DRIVER=redis) when running several gateway replicas, so a logout applies to all of them.
+func newTokenBlacklist(log logger.Logger) *cache.TokenBlacklist {
+	return cache.NewTokenBlacklist(newSharedCache(log, "Token denylist"))
+}
+
+// newSharedCache creates a cache from the CACHE_* settings, falling back to process memory when
+// the configured store is unavailable
+func newSharedCache(log logger.Logger, purpose string) cache.Cache {
+	c, err := cache.NewFromConfig(cache.LoadConfigFromEnv())
+	if err != nil {
+		log.Error(purpose+" cache unavailable, falling back to in-memory", "error", err)
+		c = cache.New(cache.NewMemoryStore(cache.LoadMemoryConfigFromEnv()), "")
+	}
+	return c
+}
+
+// setupLogout registers the logout endpoint
+func (g *Gateway) setupLogout() {
+	g.app.Post(logoutPath, g.handleLogout)
+}
+
+// handleLogout ends the caller's session: the access token is denylisted until it expires, the
+// refresh token (from the body or, in cookie mode, the refresh cookie) is revoked by the user
+// service, and the auth cookies are cleared. Invalid or missing tokens are skipped, so logging out
+// twice, or with an expired access token, still succeeds.
+func (g *Gateway) handleLogout(c *fiber.Ctx) error {
+	if g.cookieConfig.Enabled {
+		for _, cookie := range g.cookieConfig.ExpiredTokenCookies() {
+			c.Append(fiber.HeaderSetCookie, cookie.String())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), utils.GetEnvDuration("GATEWAY_LOGOUT_TIMEOUT", 5*time.Second))
+	defer cancel()
+
+	cfg := middleware.DefaultJWTConfig
+	if token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), cfg.TokenHeadName+" "); ok && token != "" {
+		claims, err := middleware.ValidateAccessToken(token, cfg.AccessTokenSecret)
+		if err == nil && claims.ID != "" && claims.ExpiresAt != nil {
+			if err := g.revokedTokens.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+				logger.FromContext(ctx, g.logger).Error("Failed to revoke access token", "subject", claims.Subject, "error", err)
+				return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "logout unavailable"})
+			}
+		}
+	}
+
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if raw := c.Body(); len(raw) > 0 {
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+	if body.RefreshToken == "" && g.cookieConfig.Enabled {
+		body.RefreshToken = c.Cookies(g.cookieConfig.RefreshCookieName)
+	}
+	if body.RefreshToken != "" {
+		conn, err := g.serviceConn("user-service")
+		if err != nil {
+			logger.FromContext(ctx, g.logger).Error("User service unavailable for logout", "error", err)
+			return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "logout unavailable"})
+		}
+		_, err = user_pb.NewUserServiceClient(conn).Logout(ctx, &user_pb.LogoutRequest{RefreshToken: body.RefreshToken})
+		// An invalid or already revoked refresh token cannot be used anyway; anything else means it is still live
+		if err != nil && status.Code(err) != codes.Unauthenticated {
+			logger.FromContext(ctx, g.logger).Error("Failed to revoke refresh token", "error", err)
+			return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "logout unavailable"})
+		}
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/core/maintenance"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// routePattern matches HTTP requests. Method "*" matches any method and a trailing "*" in Path
+// matches any suffix.
+type routePattern struct {
+	Method string
+	Path   string
+}
+
+// parseRoutePatterns parses "METHOD /path" entries such as "GET /api/v1/users*" or "* /api/v1/auth/*";
+// a bare path matches every method
+func parseRoutePatterns(entries []string) []routePattern {
+	var routes []routePattern
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		switch len(fields) {
+		case 1:
+			routes = append(routes, routePattern{Method: "*", Path: fields[0]})
+		case 2:
+			routes = append(routes, routePattern{Method: strings.ToUpper(fields[0]), Path: fields[1]})
+		}
+	}
+	return routes
+}
+
+// loadMaintenanceRoutes reads the routes served during maintenance from GATEWAY_MAINTENANCE_ALLOWED_ROUTES
+// (comma separated), e.g. "GET /api/v1/users*,* /api/v1/auth/*"
+func loadMaintenanceRoutes() []routePattern {
+	return parseRoutePatterns(strings.Split(utils.GetEnv("GATEWAY_MAINTENANCE_ALLOWED_ROUTES", ""), ","))
+}
+
+// matches reports whether the pattern matches a request
+func (r routePattern) matches(method, path string) bool {
+	if r.Method != "*" && r.Method != method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == r.Path
+}
+
+// maintenanceMiddleware answers API requests with 503 and a JSON banner while maintenance mode is
+// on, except for the allowlisted routes
+func (g *Gateway) maintenanceMiddleware() fiber.Handler {
+	routes := loadMaintenanceRoutes()
+	return func(c *fiber.Ctx) error {
+		state := g.maintenance.State(c.UserContext())
+		if !state.Enabled {
+			return c.Next()
+		}
+		for _, route := range routes {
+			if route.matches(c.Method(), c.Path()) {
+				return c.Next()
+			}
+		}
+		if state.RetryAfter > 0 {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(state.RetryAfter))
+		}
+		return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{
+			"error":       "maintenance",
+			"message":     state.Message,
+			"retry_after": state.RetryAfter,
+			"since":       state.Since,
+		})
+	}
+}
+
+// getMaintenance returns the maintenance state
+func (g *Gateway) getMaintenance(c *fiber.Ctx) error {
+	return c.JSON(g.maintenance.State(c.UserContext()))
+}
+
+// putMaintenance turns maintenance mode on from a {"message": ..., "retry_after": ...} body, which may be empty
+func (g *Gateway) putMaintenance(c *fiber.Ctx) error {
+	var state maintenance.State
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&state); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+	state.Enabled = true
+	state, err := g.maintenance.Set(c.UserContext(), state)
+	if err != nil {
+		g.logger.Error("Failed to enable maintenance mode", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "failed to store maintenance state"})
+	}
+	return c.JSON(state)
+}
+
+// deleteMaintenance turns maintenance mode off
+func (g *Gateway) deleteMaintenance(c *fiber.Ctx) error {
+	if _, err := g.maintenance.Set(c.UserContext(), maintenance.State{}); err != nil {
+		g.logger.Error("Failed to disable maintenance mode", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "failed to store maintenance state"})
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	corePb "golang-microservices-boilerplate/proto/core"
+)
+
+// totalCountHeader reports the total number of items of a list response
+const totalCountHeader = "X-Total-Count"
+
+// defaultPaginationParamPrefix is the query prefix of core.FilterOptions in list requests (options.limit, ...)
+const defaultPaginationParamPrefix = "options."
+
+var paginationInfoName = (&corePb.PaginationInfo{}).ProtoReflect().Descriptor().FullName()
+
+// requestContextKey carries the incoming HTTP request to forward-response options, which only get the context
+type requestContextKey struct{}
+
+// withRequestInContext makes the request available to paginationHeaders
+func withRequestInContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestContextKey{}, r)))
+	})
+}
+
+// paginationHeaders adds X-Total-Count and RFC 8288 Link headers (first, prev, next, last) to responses
+// carrying a core.PaginationInfo. Links are only added to GET requests, whose pagination is in the query.
+func paginationHeaders(ctx context.Context, w http.ResponseWriter, msg proto.Message) error {
+	info := findPaginationInfo(msg)
+	if info == nil {
+		return nil
+	}
+	w.Header().Set(totalCountHeader, strconv.FormatInt(info.GetTotalItems(), 10))
+
+	r, ok := ctx.Value(requestContextKey{}).(*http.Request)
+	if !ok || r.Method != http.MethodGet || info.GetLimit() <= 0 {
+		return nil
+	}
+	if links := paginationLinks(r.URL, info); links != "" {
+		w.Header().Set("Link", links)
+	}
+	return nil
+}
+
+// findPaginationInfo returns the first top-level core.PaginationInfo field of msg
+func findPaginationInfo(msg proto.Message) *corePb.PaginationInfo {
+	if msg == nil {
+		return nil
+	}
+	var info *corePb.PaginationInfo
+	msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || fd.Message().FullName() != paginationInfoName {
+			return true
+		}
+		info, _ = v.Message().Interface().(*corePb.PaginationInfo)
+		return info == nil
+	})
+	return info
+}
+
+// paginationLinks builds the Link header value for the page described by info
+func paginationLinks(u *url.URL, info *corePb.PaginationInfo) string {
+	limit, offset, total := int64(info.GetLimit()), int64(info.GetOffset()), info.GetTotalItems()
+	prefix := paginationParamPrefix(u.Query())
+
+	link := func(rel string, offset int64) string {
+		query := u.Query()
+		query.Set(prefix+"limit", strconv.FormatInt(limit, 10))
+		query.Set(prefix+"offset", strconv.FormatInt(offset, 10))
+		target := url.URL{Path: u.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, target.String(), rel)
+	}
+
+	links := []string{link("first", 0)}
+	if offset > 0 {
+		links = append(links, link("prev", max(offset-limit, 0)))
+	}
+	if offset+limit < total {
+		links = append(links, link("next", offset+limit))
+	}
+	if total > 0 {
+		links = append(links, link("last", (total-1)/limit*limit))
+	}
+	return strings.Join(links, ", ")
+}
+
+// paginationParamPrefix finds how the request names its limit/offset parameters, e.g. "options." for
+// options.limit, falling back to the FilterOptions convention when the client sent neither
+func paginationParamPrefix(query url.Values) string {
+	for key := range query {
+		for _, suffix := range []string{"limit", "offset"} {
+			if prefix, ok := strings.CutSuffix(key, suffix); ok && (prefix == "" || strings.HasSuffix(prefix, ".")) {
+				return prefix
+			}
+		}
+	}
+	return defaultPaginationParamPrefix
+}
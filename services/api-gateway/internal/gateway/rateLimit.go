@@ -0,0 +1,259 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/core/cache"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// limitsPath reports the caller's rate limits; it is handled by the gateway itself and does not
+// count against them
+const limitsPath = "/api/v1/limits"
+
+// rateLimitLocalsKey holds the []rateLimitStatus of the request
+const rateLimitLocalsKey = "rateLimits"
+
+// rateLimitMode selects what happens to requests beyond a limit
+type rateLimitMode string
+
+const (
+	rateLimitOff     rateLimitMode = "off"     // Nothing is counted and no headers are sent
+	rateLimitSoft    rateLimitMode = "soft"    // Requests are counted and reported in headers, but never refused
+	rateLimitEnforce rateLimitMode = "enforce" // Requests beyond a limit are refused with 429
+)
+
+// Subjects a limit can count requests for
+const (
+	rateLimitSubjectIP   = "ip"
+	rateLimitSubjectUser = "user"
+)
+
+// Default limits per subject, in GATEWAY_RATE_LIMIT_<SUBJECT> syntax
+var defaultRateLimits = map[string]string{
+	rateLimitSubjectIP:   "600/1m",
+	rateLimitSubjectUser: "1200/1m",
+}
+
+// rateLimit allows Limit requests per fixed window of Period for each client IP or user
+type rateLimit struct {
+	name    string // Subject and window, e.g. "user-1m"
+	subject string
+	limit   int64
+	period  time.Duration
+	counter *cache.RateCounter
+}
+
+// rateLimitStatus is the state of one limit for the caller, as reported by /api/v1/limits
+type rateLimitStatus struct {
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	Period    string    `json:"period"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// rateLimiter counts API requests per client IP and per authenticated user. Counters live in the
+// shared cache, so with CACHE_DRIVER=redis the limits apply across all gateway replicas.
+type rateLimiter struct {
+	mode   rateLimitMode
+	limits []*rateLimit
+}
+
+// loadRateLimiterFromEnv reads the rate limits.
+//
+//	GATEWAY_RATE_LIMIT_MODE=soft               off, soft (headers only) or enforce (429 beyond a limit)
+//	GATEWAY_RATE_LIMIT_IP=600/1m               requests per window for each client IP
+//	GATEWAY_RATE_LIMIT_USER=1200/1m,20000/1h   requests per window for each user; several windows are comma separated
+func loadRateLimiterFromEnv(c cache.Cache) (*rateLimiter, error) {
+	mode := rateLimitMode(strings.ToLower(utils.GetEnv("GATEWAY_RATE_LIMIT_MODE", string(rateLimitSoft))))
+	switch mode {
+	case rateLimitOff, rateLimitSoft, rateLimitEnforce:
+	default:
+		return nil, fmt.Errorf("invalid GATEWAY_RATE_LIMIT_MODE %q (expected off, soft or enforce)", mode)
+	}
+
+	specs := make(map[string]string, len(defaultRateLimits))
+	for subject, def := range defaultRateLimits {
+		specs[subject] = utils.GetEnv("GATEWAY_RATE_LIMIT_"+strings.ToUpper(subject), def)
+	}
+	return newRateLimiter(mode, specs, c)
+}
+
+// newRateLimiter parses the limits of each subject, e.g. {"ip": "600/1m"}
+func newRateLimiter(mode rateLimitMode, specs map[string]string, c cache.Cache) (*rateLimiter, error) {
+	l := &rateLimiter{mode: mode}
+	seen := map[string]bool{}
+	for _, subject := range []string{rateLimitSubjectIP, rateLimitSubjectUser} {
+		for _, spec := range strings.Split(specs[subject], ",") {
+			if spec = strings.TrimSpace(spec); spec == "" {
+				continue
+			}
+			limit, err := parseRateLimit(subject, spec)
+			if err != nil {
+				return nil, fmt.Errorf("GATEWAY_RATE_LIMIT_%s: %w", strings.ToUpper(subject), err)
+			}
+			if seen[limit.name] {
+				return nil, fmt.Errorf("GATEWAY_RATE_LIMIT_%s: duplicate window %s", strings.ToUpper(subject), limit.name)
+			}
+			seen[limit.name] = true
+			limit.counter = cache.NewRateCounter(c, limit.period)
+			l.limits = append(l.limits, limit)
+		}
+	}
+	return l, nil
+}
+
+// parseRateLimit parses "<requests>/<window>", e.g. "600/1m"
+func parseRateLimit(subject, spec string) (*rateLimit, error) {
+	count, window, ok := strings.Cut(spec, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid limit %q (expected <requests>/<window>)", spec)
+	}
+	limit, err := strconv.ParseInt(strings.TrimSpace(count), 10, 64)
+	if err != nil || limit <= 0 {
+		return nil, fmt.Errorf("invalid request count in %q", spec)
+	}
+	window = strings.TrimSpace(window)
+	period, err := time.ParseDuration(window)
+	if err != nil || period < time.Second {
+		return nil, fmt.Errorf("invalid window in %q (expected a duration of at least 1s)", spec)
+	}
+	return &rateLimit{name: subject + "-" + window, subject: subject, limit: limit, period: period}, nil
+}
+
+// check counts a request against every limit that applies to the given subjects (subject -> key)
+// and returns their state. With count false the counters are only read. Limits whose counter
+// cannot be reached are skipped, so a cache outage does not block the API.
+func (l *rateLimiter) check(ctx context.Context, log logger.Logger, subjects map[string]string, count bool) []rateLimitStatus {
+	statuses := make([]rateLimitStatus, 0, len(l.limits))
+	for _, limit := range l.limits {
+		key, ok := subjects[limit.subject]
+		if !ok {
+			continue
+		}
+		key = limit.name + ":" + key
+		var used int64
+		var resetsAt time.Time
+		var err error
+		if count {
+			used, resetsAt, err = limit.counter.Hit(ctx, key)
+		} else {
+			used, resetsAt, err = limit.counter.Peek(ctx, key)
+		}
+		if err != nil {
+			log.Warn("Rate limit counter unavailable, skipping limit", "limit", limit.name, "error", err)
+			continue
+		}
+		statuses = append(statuses, rateLimitStatus{
+			Name:      limit.name,
+			Subject:   limit.subject,
+			Limit:     limit.limit,
+			Used:      used,
+			Remaining: max(limit.limit-used, 0),
+			Period:    limit.period.String(),
+			ResetsAt:  resetsAt.UTC(),
+		})
+	}
+	return statuses
+}
+
+// setupRateLimit installs the rate limiter on the API routes and the /api/v1/limits endpoint. It
+// runs after authentication so that per-user limits know the caller. An invalid configuration is
+// logged and the default limits are reported without being enforced.
+func (g *Gateway) setupRateLimit() {
+	counters := newSharedCache(g.logger, "Rate limit")
+	limiter, err := loadRateLimiterFromEnv(counters)
+	if err != nil {
+		g.logger.Error("Invalid rate limit configuration, using the default limits in soft mode", "error", err)
+		limiter, _ = newRateLimiter(rateLimitSoft, defaultRateLimits, counters)
+	}
+	g.app.Use("/api", g.rateLimitMiddleware(limiter))
+	g.app.Get(limitsPath, g.handleLimits(limiter))
+}
+
+// rateLimitMiddleware counts the request against the caller's limits and reports the tightest one in
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix time in seconds). In enforce
+// mode requests beyond a limit get 429 with Retry-After; in soft mode they proceed and the first one
+// of each window is logged.
+func (g *Gateway) rateLimitMiddleware(limiter *rateLimiter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if limiter.mode == rateLimitOff || len(limiter.limits) == 0 {
+			return c.Next()
+		}
+		log := logger.FromContext(c.UserContext(), g.logger)
+		counted := c.Path() != limitsPath
+		statuses := limiter.check(c.UserContext(), log, g.rateLimitSubjects(c), counted)
+		c.Locals(rateLimitLocalsKey, statuses)
+		if len(statuses) == 0 {
+			return c.Next()
+		}
+
+		tightest := statuses[0]
+		var exceeded *rateLimitStatus
+		for i, s := range statuses {
+			if s.Remaining < tightest.Remaining || (s.Remaining == tightest.Remaining && s.ResetsAt.After(tightest.ResetsAt)) {
+				tightest = s
+			}
+			if s.Used > s.Limit && (exceeded == nil || s.ResetsAt.After(exceeded.ResetsAt)) {
+				exceeded = &statuses[i]
+			}
+		}
+		c.Set("X-RateLimit-Limit", strconv.FormatInt(tightest.Limit, 10))
+		c.Set("X-RateLimit-Remaining", strconv.FormatInt(tightest.Remaining, 10))
+		c.Set("X-RateLimit-Reset", strconv.FormatInt(tightest.ResetsAt.Unix(), 10))
+
+		if exceeded == nil || !counted {
+			return c.Next()
+		}
+		if limiter.mode == rateLimitSoft {
+			if exceeded.Used == exceeded.Limit+1 {
+				log.Warn("Rate limit exceeded (soft mode, request allowed)", "limit", exceeded.Name, "used", exceeded.Used, "max", exceeded.Limit)
+			}
+			return c.Next()
+		}
+		retryAfter := max(int64(time.Until(exceeded.ResetsAt).Seconds()+0.5), 1)
+		c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+		return fiber.NewError(http.StatusTooManyRequests, fmt.Sprintf("rate limit %s exceeded (%d requests per %s)", exceeded.Name, exceeded.Limit, exceeded.Period))
+	}
+}
+
+// rateLimitSubjects returns the keys the request is counted under: the client IP, and the user ID
+// for authenticated requests
+func (g *Gateway) rateLimitSubjects(c *fiber.Ctx) map[string]string {
+	subjects := map[string]string{}
+	if ip := g.ipFilter.ClientIP(c); ip != nil {
+		subjects[rateLimitSubjectIP] = ip.String()
+	}
+	if claims := middleware.GetClaims(c); claims != nil {
+		if typed, err := claims.Claims(); err == nil {
+			subjects[rateLimitSubjectUser] = typed.UserID.String()
+		}
+	}
+	return subjects
+}
+
+// handleLimits reports the caller's usage of every rate limit that applies to it
+func (g *Gateway) handleLimits(limiter *rateLimiter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		statuses, _ := c.Locals(rateLimitLocalsKey).([]rateLimitStatus)
+		if statuses == nil {
+			statuses = []rateLimitStatus{}
+		}
+		return c.JSON(fiber.Map{
+			"mode":   limiter.mode,
+			"limits": statuses,
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// routeClass groups the gateway's routes for metrics, so that health checks, scrapes and Swagger
+// downloads do not skew the latency of the API
+type routeClass string
+
+const (
+	routeClassAPI    routeClass = "api"    // Requests forwarded to the services, and the gateway's own /api endpoints
+	routeClassSystem routeClass = "system" // Health, metrics, admin and debug endpoints
+	routeClassDocs   routeClass = "docs"   // Swagger UI, OpenAPI documents and SDKs
+)
+
+// routeClasses lists the classes in the order their metrics are written
+var routeClasses = []routeClass{routeClassAPI, routeClassSystem, routeClassDocs}
+
+// classifiedRoute assigns a class to the requests matching a pattern
+type classifiedRoute struct {
+	class   routeClass
+	pattern routePattern
+}
+
+// defaultClassifiedRoutes classifies the gateway's routes; the first match wins, and requests matching
+// none (e.g. unknown paths) are system requests
+var defaultClassifiedRoutes = []classifiedRoute{
+	{routeClassAPI, routePattern{Method: "*", Path: "/api/*"}},
+	{routeClassDocs, routePattern{Method: "*", Path: "/swagger*"}},
+	{routeClassDocs, routePattern{Method: "*", Path: "/sdk*"}},
+	{routeClassSystem, routePattern{Method: "*", Path: "/health"}},
+	{routeClassSystem, routePattern{Method: "*", Path: "/metrics"}},
+	{routeClassSystem, routePattern{Method: "*", Path: "/admin*"}},
+	{routeClassSystem, routePattern{Method: "*", Path: "/debug*"}},
+}
+
+// requestDurationBuckets are the histogram upper bounds in seconds
+var requestDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestMetricsConfig selects the requests that are measured
+type requestMetricsConfig struct {
+	ExcludeClasses map[routeClass]bool
+	ExcludeRoutes  []routePattern
+}
+
+// loadRequestMetricsConfigFromEnv reads GATEWAY_METRICS_EXCLUDE_CLASSES (comma separated classes,
+// e.g. "docs,system") and GATEWAY_METRICS_EXCLUDE_ROUTES (comma separated patterns as for
+// maintenance, e.g. "GET /health,GET /metrics"); both default to none
+func loadRequestMetricsConfigFromEnv() (requestMetricsConfig, error) {
+	cfg := requestMetricsConfig{ExcludeClasses: map[routeClass]bool{}}
+	for _, name := range strings.Split(utils.GetEnv("GATEWAY_METRICS_EXCLUDE_CLASSES", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		class := routeClass(name)
+		if class != routeClassAPI && class != routeClassSystem && class != routeClassDocs {
+			return cfg, fmt.Errorf("GATEWAY_METRICS_EXCLUDE_CLASSES: unknown route class %q (want api, system or docs)", name)
+		}
+		cfg.ExcludeClasses[class] = true
+	}
+	cfg.ExcludeRoutes = parseRoutePatterns(strings.Split(utils.GetEnv("GATEWAY_METRICS_EXCLUDE_ROUTES", ""), ","))
+	return cfg, nil
+}
+
+// requestSeriesKey identifies one series of a class histogram
+type requestSeriesKey struct {
+	route string
+	code  string
+}
+
+// requestSeries holds the measurements of one series
+type requestSeries struct {
+	buckets []uint64 // Cumulative counts per requestDurationBuckets entry
+	count   uint64
+	sum     float64
+}
+
+// requestMetrics keeps a request duration histogram per route class. A nil *requestMetrics
+// records nothing.
+type requestMetrics struct {
+	cfg     requestMetricsConfig
+	mu      sync.Mutex
+	classes map[routeClass]map[requestSeriesKey]*requestSeries
+}
+
+func newRequestMetrics(cfg requestMetricsConfig) *requestMetrics {
+	m := &requestMetrics{cfg: cfg, classes: make(map[routeClass]map[requestSeriesKey]*requestSeries)}
+	for _, class := range routeClasses {
+		m.classes[class] = make(map[requestSeriesKey]*requestSeries)
+	}
+	return m
+}
+
+// classify returns the class of a request and the route it is recorded under: the route policy
+// pattern for API requests, as for slow requests, and the matching pattern for the others
+func classify(method, path string) (routeClass, string) {
+	for _, r := range defaultClassifiedRoutes {
+		if !r.pattern.matches(method, path) {
+			continue
+		}
+		if r.class == routeClassAPI {
+			if policy := routePolicies.Match(method, path); policy != nil {
+				return routeClassAPI, method + " " + policy.Path
+			}
+			return routeClassAPI, method + " unmatched"
+		}
+		return r.class, method + " " + r.pattern.Path
+	}
+	return routeClassSystem, method + " unmatched"
+}
+
+// observe records one request unless its class or route is excluded
+func (m *requestMetrics) observe(method, path string, code int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	for _, r := range m.cfg.ExcludeRoutes {
+		if r.matches(method, path) {
+			return
+		}
+	}
+	class, route := classify(method, path)
+	if m.cfg.ExcludeClasses[class] {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := requestSeriesKey{route: route, code: strconv.Itoa(code)}
+	s, ok := m.classes[class][key]
+	if !ok {
+		s = &requestSeries{buckets: make([]uint64, len(requestDurationBuckets))}
+		m.classes[class][key] = s
+	}
+	seconds := duration.Seconds()
+	for i, upper := range requestDurationBuckets {
+		if seconds <= upper {
+			s.buckets[i]++
+		}
+	}
+	s.count++
+	s.sum += seconds
+}
+
+// WritePrometheus writes one histogram per class that is not excluded, named
+// gateway_<class>_request_duration_seconds, in the Prometheus text exposition format
+func (m *requestMetrics) WritePrometheus(w io.Writer) error {
+	if m == nil {
+		return nil
+	}
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	for _, class := range routeClasses {
+		if m.cfg.ExcludeClasses[class] {
+			continue
+		}
+		m.mu.Lock()
+		keys := make([]requestSeriesKey, 0, len(m.classes[class]))
+		series := make(map[requestSeriesKey]requestSeries, len(m.classes[class]))
+		for k, s := range m.classes[class] {
+			keys = append(keys, k)
+			copied := *s
+			copied.buckets = append([]uint64(nil), s.buckets...)
+			series[k] = copied
+		}
+		m.mu.Unlock()
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].route != keys[j].route {
+				return keys[i].route < keys[j].route
+			}
+			return keys[i].code < keys[j].code
+		})
+
+		name := "gateway_" + string(class) + "_request_duration_seconds"
+		printf("# HELP %s Duration of %s requests handled by the gateway.\n# TYPE %s histogram\n", name, class, name)
+		for _, k := range keys {
+			s := series[k]
+			labels := fmt.Sprintf(`route=%q,code=%q`, k.route, k.code)
+			for i, upper := range requestDurationBuckets {
+				printf("%s_bucket{%s,le=%q} %d\n", name, labels, strconv.FormatFloat(upper, 'g', -1, 64), s.buckets[i])
+			}
+			printf("%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, s.count)
+			printf("%s_sum{%s} %g\n", name, labels, s.sum)
+			printf("%s_count{%s} %d\n", name, labels, s.count)
+		}
+	}
+	return err
+}
+
+// setupRequestMetrics measures every request, including those rejected by later middleware
+func (g *Gateway) setupRequestMetrics() {
+	cfg, err := loadRequestMetricsConfigFromEnv()
+	if err != nil {
+		g.logger.Error("Invalid request metrics configuration, measuring every request", "error", err)
+		cfg = requestMetricsConfig{ExcludeClasses: map[routeClass]bool{}}
+	}
+	g.requestMetrics = newRequestMetrics(cfg)
+	g.app.Use(g.requestMetricsMiddleware())
+}
+
+// requestMetricsMiddleware records the duration and status of each request. The route is
+// classified once the request is done, so API requests are recorded under their versioned path.
+func (g *Gateway) requestMetricsMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		g.requestMetrics.observe(c.Method(), c.Path(), responseCode(c, err), time.Since(start))
+		return err
+	}
+}
+
+// responseCode returns the status a request ends with, including errors the error handler has not
+// rendered yet
+func responseCode(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,464 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// maxFieldViolations bounds the violations reported for one request
+const maxFieldViolations = 20
+
+// requestValidationConfig controls validation of request bodies and query parameters
+type requestValidationConfig struct {
+	Enabled       bool // Validate requests against the merged swagger definitions
+	RejectUnknown bool // Reject body fields the schema does not declare
+}
+
+// loadRequestValidationConfigFromEnv reads REQUEST_VALIDATION_ENABLED (default false) and
+// REQUEST_VALIDATION_REJECT_UNKNOWN_FIELDS (default true)
+func loadRequestValidationConfigFromEnv() requestValidationConfig {
+	return requestValidationConfig{
+		Enabled:       utils.GetEnv("REQUEST_VALIDATION_ENABLED", "false") == "true",
+		RejectUnknown: utils.GetEnv("REQUEST_VALIDATION_REJECT_UNKNOWN_FIELDS", "true") == "true",
+	}
+}
+
+// validatedOperation is a swagger operation with the parameters the gateway checks
+type validatedOperation struct {
+	method   string
+	segments []string                          // Path template segments; "" matches any segment
+	literals int                               // Non-parameter segments, to prefer /users/me over /users/{id}
+	query    map[string]map[string]interface{} // Normalized name -> parameter
+	body     map[string]interface{}            // Schema of the body, nil if the operation takes none
+}
+
+// requestValidator checks requests against the operations of the merged swagger definition
+type requestValidator struct {
+	definitions   map[string]interface{}
+	operations    []validatedOperation
+	rejectUnknown bool
+}
+
+// fieldViolation is one problem found in a request
+type fieldViolation struct {
+	field       string
+	description string
+}
+
+// newRequestValidator indexes the operations of a merged swagger definition. Operations listed in
+// skip ("<path> <method>") are left out, e.g. paths defined differently by several services.
+func newRequestValidator(swagger map[string]interface{}, skip map[string]bool, rejectUnknown bool) *requestValidator {
+	v := &requestValidator{rejectUnknown: rejectUnknown}
+	v.definitions, _ = swagger["definitions"].(map[string]interface{})
+	paths, _ := swagger["paths"].(map[string]interface{})
+	for path, itemRaw := range paths {
+		item, ok := itemRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for method, opRaw := range item {
+			op, ok := opRaw.(map[string]interface{})
+			if !ok || skip[path+" "+method] {
+				continue
+			}
+			operation := validatedOperation{method: strings.ToUpper(method), query: make(map[string]map[string]interface{})}
+			for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+				if strings.HasPrefix(segment, "{") {
+					segment = ""
+				} else {
+					operation.literals++
+				}
+				operation.segments = append(operation.segments, segment)
+			}
+			params, _ := op["parameters"].([]interface{})
+			for _, paramRaw := range params {
+				param, ok := paramRaw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := param["name"].(string)
+				switch param["in"] {
+				case "query":
+					operation.query[normalizeFieldName(name)] = param
+				case "body":
+					operation.body, _ = param["schema"].(map[string]interface{})
+				}
+			}
+			v.operations = append(v.operations, operation)
+		}
+	}
+	return v
+}
+
+// match returns the operation serving method and path, preferring the most specific template
+func (v *requestValidator) match(method, path string) *validatedOperation {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var best *validatedOperation
+	for i := range v.operations {
+		op := &v.operations[i]
+		if op.method != method || len(op.segments) != len(segments) {
+			continue
+		}
+		matches := true
+		for j, segment := range op.segments {
+			if segment != "" && segment != segments[j] {
+				matches = false
+				break
+			}
+		}
+		if matches && (best == nil || op.literals > best.literals) {
+			best = op
+		}
+	}
+	return best
+}
+
+// validate checks the query parameters and JSON body of a request
+func (v *requestValidator) validate(op *validatedOperation, query map[string][]string, contentType string, body []byte) []fieldViolation {
+	var violations []fieldViolation
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := query[name]
+		param, ok := op.query[normalizeFieldName(name)]
+		if !ok {
+			continue // grpc-gateway ignores unknown query parameters
+		}
+		if param["type"] == "array" {
+			items, _ := param["items"].(map[string]interface{})
+			for _, value := range values {
+				v.checkQueryValue(name, value, items, &violations)
+			}
+		} else if len(values) > 0 {
+			v.checkQueryValue(name, values[len(values)-1], param, &violations)
+		}
+	}
+
+	if op.body != nil && len(bytes.TrimSpace(body)) > 0 && (contentType == "" || strings.Contains(contentType, "json")) {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			violations = append(violations, fieldViolation{field: "body", description: "invalid JSON: " + err.Error()})
+		} else {
+			v.checkValue("", value, op.body, &violations)
+		}
+	}
+	if len(violations) > maxFieldViolations {
+		violations = violations[:maxFieldViolations]
+	}
+	return violations
+}
+
+// checkQueryValue checks a query parameter, which is always text, against its declared type
+func (v *requestValidator) checkQueryValue(name, value string, param map[string]interface{}, violations *[]fieldViolation) {
+	var typed interface{} = value
+	switch param["type"] {
+	case "integer", "number":
+		typed = json.Number(value)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			*violations = append(*violations, fieldViolation{field: name, description: "must be true or false"})
+			return
+		}
+		typed = b
+	}
+	v.checkValue(name, typed, param, violations)
+}
+
+// checkValue checks a decoded JSON value against a schema, following protojson's rules: null is
+// accepted anywhere, 64-bit integers and other numbers may be quoted, and enums may be numbers
+func (v *requestValidator) checkValue(field string, value interface{}, schema map[string]interface{}, violations *[]fieldViolation) {
+	if value == nil || len(*violations) > maxFieldViolations {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, ok := v.definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+		if !ok {
+			return // Unknown definitions are not validated
+		}
+		schema = resolved
+	}
+	fail := func(description string) {
+		*violations = append(*violations, fieldViolation{field: fieldOrBody(field), description: description})
+	}
+
+	typ, _ := schema["type"].(string)
+	if typ == "" && schema["properties"] != nil {
+		typ = "object"
+	}
+	format, _ := schema["format"].(string)
+	switch typ {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		v.checkObject(field, object, schema, violations)
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range list {
+			v.checkValue(fmt.Sprintf("%s[%d]", field, i), item, items, violations)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be true or false")
+		}
+	case "integer":
+		if !isInteger(value, format) {
+			fail(fmt.Sprintf("must be an integer (%s)", format))
+		}
+	case "number":
+		if !isNumber(value) {
+			fail("must be a number")
+		}
+	case "string":
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			if !isEnumValue(value, enum) {
+				fail(fmt.Sprintf("must be one of %v", enum))
+			}
+			return
+		}
+		switch format {
+		case "int64", "uint64", "fixed64", "sfixed64", "sint64":
+			if !isInteger(value, format) {
+				fail(fmt.Sprintf("must be an integer (%s)", format))
+			}
+			return
+		}
+		s, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		switch format {
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+				fail("must be an RFC 3339 timestamp, e.g. 2024-01-02T15:04:05Z")
+			}
+		case "byte":
+			if !isBase64(s) {
+				fail("must be base64 encoded")
+			}
+		}
+	}
+}
+
+// checkObject checks the fields of an object; protojson accepts both the JSON and the proto name of a field
+func (v *requestValidator) checkObject(field string, object map[string]interface{}, schema map[string]interface{}, violations *[]fieldViolation) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	byName := make(map[string]string, len(properties))
+	for name := range properties {
+		byName[normalizeFieldName(name)] = name
+	}
+	additional, hasAdditional := schema["additionalProperties"].(map[string]interface{})
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	present := make(map[string]bool, len(object))
+	for _, key := range keys {
+		value := object[key]
+		path := joinField(field, key)
+		if name, ok := byName[normalizeFieldName(key)]; ok {
+			present[name] = true
+			property, _ := properties[name].(map[string]interface{})
+			v.checkValue(path, value, property, violations)
+		} else if hasAdditional {
+			v.checkValue(path, value, additional, violations)
+		} else if v.rejectUnknown && len(properties) > 0 {
+			*violations = append(*violations, fieldViolation{field: path, description: "unknown field"})
+		}
+	}
+
+	required, _ := schema["required"].([]interface{})
+	for _, nameRaw := range required {
+		name, _ := nameRaw.(string)
+		if canonical, ok := byName[normalizeFieldName(name)]; ok && !present[canonical] {
+			*violations = append(*violations, fieldViolation{field: joinField(field, name), description: "is required"})
+		}
+	}
+}
+
+// normalizeFieldName folds the lowerCamelCase JSON name and the snake_case proto name of a field
+// (and of each part of a dotted query parameter) to the same key
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func fieldOrBody(field string) string {
+	if field == "" {
+		return "body"
+	}
+	return field
+}
+
+// isInteger reports whether value is an integer within the range of format, as a number or quoted
+func isInteger(value interface{}, format string) bool {
+	var text string
+	switch val := value.(type) {
+	case json.Number:
+		text = val.String()
+	case string:
+		text = val
+	default:
+		return false
+	}
+	bits := 64
+	if strings.HasSuffix(format, "32") {
+		bits = 32
+	}
+	if strings.HasPrefix(format, "uint") || strings.HasPrefix(format, "fixed") {
+		if _, err := strconv.ParseUint(text, 10, bits); err == nil {
+			return true
+		}
+	} else if _, err := strconv.ParseInt(text, 10, bits); err == nil {
+		return true
+	}
+	// protojson also accepts integral numbers in exponent form, e.g. 1e3
+	f, err := strconv.ParseFloat(text, 64)
+	return err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) && (f >= 0 || !strings.HasPrefix(format, "uint"))
+}
+
+// isNumber reports whether value is a number, a quoted number, or one of the special float values
+func isNumber(value interface{}) bool {
+	switch val := value.(type) {
+	case json.Number:
+		return true
+	case string:
+		if val == "NaN" || val == "Infinity" || val == "-Infinity" {
+			return true
+		}
+		_, err := strconv.ParseFloat(val, 64)
+		return err == nil
+	}
+	return false
+}
+
+// isEnumValue reports whether value names one of the enum values; numeric values are left to the service
+func isEnumValue(value interface{}, enum []interface{}) bool {
+	switch val := value.(type) {
+	case json.Number:
+		_, err := val.Int64()
+		return err == nil
+	case string:
+		for _, e := range enum {
+			if e == val {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isBase64 reports whether s is standard or URL-safe base64, with or without padding
+func isBase64(s string) bool {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if _, err := encoding.DecodeString(s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// loadRequestValidator builds the validator from the swagger files under swaggerDir. Definitions
+// defined differently by several services are kept apart, and paths defined differently are not
+// validated, since the request may reach either service.
+func (g *Gateway) loadRequestValidator(swaggerDir string) error {
+	config := loadRequestValidationConfigFromEnv()
+	if !config.Enabled {
+		return nil
+	}
+	merged, conflicts, err := mergeSwaggerFiles(g, filepath.Join(swaggerDir, "proto"), swaggerMergeStrategy{PrefixDefinitions: true})
+	if err != nil {
+		return fmt.Errorf("failed to load schemas for request validation: %w", err)
+	}
+	skip := make(map[string]bool)
+	for _, conflict := range conflicts {
+		if conflict.Kind == "path" {
+			skip[conflict.Name+" "+conflict.Method] = true
+		}
+	}
+	g.validator = newRequestValidator(merged, skip, config.RejectUnknown)
+	g.logger.Info("Request validation enabled", "operations", len(g.validator.operations), "reject_unknown_fields", config.RejectUnknown)
+	return nil
+}
+
+// requestValidationMiddleware rejects requests whose query parameters or JSON body do not match
+// the swagger schema of their route with a 400 listing every problem, in the same format as
+// validation errors returned by the services. Routes whose policy is Passthrough are skipped.
+func (g *Gateway) requestValidationMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		v := g.validator
+		if v == nil {
+			return c.Next()
+		}
+		if policy := routePolicies.Match(c.Method(), c.Path()); policy != nil && policy.Passthrough {
+			return c.Next()
+		}
+		op := v.match(c.Method(), c.Path())
+		if op == nil {
+			return c.Next()
+		}
+
+		query := make(map[string][]string)
+		c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+			query[string(key)] = append(query[string(key)], string(value))
+		})
+		violations := v.validate(op, query, c.Get(fiber.HeaderContentType), c.Body())
+		if len(violations) == 0 {
+			return c.Next()
+		}
+
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: violation.field, Description: violation.description})
+		}
+		st, err := status.New(codes.InvalidArgument, "request does not match the API schema").WithDetails(badRequest)
+		if err != nil {
+			return err
+		}
+		body, err := protojson.Marshal(st.Proto())
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Status(http.StatusBadRequest).Send(body)
+	}
+}
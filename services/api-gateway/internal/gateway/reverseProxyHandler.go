@@ -3,15 +3,17 @@ package gateway
 import (
 	"errors"
 	"fmt"
-	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
 	user_pb "golang-microservices-boilerplate/proto/user-service"
 	water_quality_pb "golang-microservices-boilerplate/proto/water-quality-service"
 	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
 )
 
-// setupHandlers registers gRPC-Gateway handlers for all services.
-// It attempts to register all discovered services and collects errors.
+// setupHandlers registers gRPC-Gateway handlers for every served API version.
+// Each version registers the services listed in versionRegistry, routed to the backend configured
+// for that version. It attempts all registrations and collects errors.
 // Returns a single error if one or more registrations fail.
 func (g *Gateway) setupHandlers() error {
 	services, err := g.discovery.GetAllServices()
@@ -21,30 +23,34 @@ func (g *Gateway) setupHandlers() error {
 
 	// Use a slice to collect registration errors
 	var registrationErrors []error
+	used := make(map[string]bool)
 
-	for _, service := range services {
-		var setupErr error
-		switch strings.ToLower(service.Name) {
-		case "user", "user-service":
-			setupErr = g.setupUserServiceHandlers(service)
-		case "water-quality", "water-quality-service":
-			setupErr = g.setupWaterQualityServiceHandlers(service)
-		// case "patient", "patient-service":
-		// 	setupErr = g.setupPatientServiceHandlers(service)
-		// case "appointment", "appointment-service":
-		// 	setupErr = g.setupAppointmentServiceHandlers(service)
-		// case "staff", "staff-service":
-		// 	setupErr = g.setupStaffServiceHandlers(service)
-		// Add cases for other services here
-		default:
-			g.logger.Warn("Unknown service discovered, skipping handler setup", "service_name", service.Name, "endpoint", service.Endpoint)
+	for _, version := range g.versions {
+		registrars, ok := versionRegistry[version.Name]
+		if !ok {
+			g.logger.Warn("No handlers registered for API version", "version", version.Name)
+			continue
+		}
+
+		for name, register := range registrars {
+			service, found := version.backendFor(name, services)
+			if !found {
+				g.logger.Warn("Backend for API version not discovered, skipping handler setup", "version", version.Name, "service", name)
+				continue
+			}
+			used[service.Name] = true
+
+			// If registration failed for this service, add it to the list and continue with the rest
+			if setupErr := register(g, version.Mux, service); setupErr != nil {
+				// The individual setup functions already log the detailed error
+				registrationErrors = append(registrationErrors, fmt.Errorf("failed to setup %s for %s: %w", service.Name, version.Name, setupErr))
+			}
 		}
+	}
 
-		// If setupErr occurred for this specific service, log it and add to the list
-		if setupErr != nil {
-			// The individual setup functions already log the detailed error
-			registrationErrors = append(registrationErrors, fmt.Errorf("failed to setup %s: %w", service.Name, setupErr))
-			// Continue to the next service instead of returning immediately
+	for _, service := range services {
+		if !used[service.Name] {
+			g.logger.Warn("Unknown service discovered, skipping handler setup", "service_name", service.Name, "endpoint", service.Endpoint)
 		}
 	}
 
@@ -63,21 +69,55 @@ func (g *Gateway) setupHandlers() error {
 }
 
 // setupUserServiceHandlers registers handlers for the user service
-func (g *Gateway) setupUserServiceHandlers(service domain.Service) error {
-	err := user_pb.RegisterUserServiceHandlerFromEndpoint(g.ctx, g.gwMux, service.Endpoint, g.opts)
+func (g *Gateway) setupUserServiceHandlers(mux *runtime.ServeMux, service domain.Service) error {
+	conn, err := g.routedConnFor(service)
+	if err != nil {
+		g.logger.Error("Failed to connect to user service", "endpoint", service.Endpoint, "error", err)
+		return err
+	}
+	err = user_pb.RegisterUserServiceHandlerClient(g.ctx, mux, user_pb.NewUserServiceClient(conn))
 	if err != nil {
 		g.logger.Error("Failed to register user service handler from endpoint", "endpoint", service.Endpoint, "error", err)
 		return fmt.Errorf("failed to register user service handler from endpoint %s: %w", service.Endpoint, err)
 	}
+	// Webhook management is served by the user service as well
+	if err := user_pb.RegisterWebhookServiceHandlerClient(g.ctx, mux, user_pb.NewWebhookServiceClient(conn)); err != nil {
+		g.logger.Error("Failed to register webhook service handler from endpoint", "endpoint", service.Endpoint, "error", err)
+		return fmt.Errorf("failed to register webhook service handler from endpoint %s: %w", service.Endpoint, err)
+	}
+	if err := user_pb.RegisterDeadLetterServiceHandlerClient(g.ctx, mux, user_pb.NewDeadLetterServiceClient(conn)); err != nil {
+		g.logger.Error("Failed to register dead letter service handler from endpoint", "endpoint", service.Endpoint, "error", err)
+		return fmt.Errorf("failed to register dead letter service handler from endpoint %s: %w", service.Endpoint, err)
+	}
+	if err := user_pb.RegisterQuotaServiceHandlerClient(g.ctx, mux, user_pb.NewQuotaServiceClient(conn)); err != nil {
+		g.logger.Error("Failed to register quota service handler from endpoint", "endpoint", service.Endpoint, "error", err)
+		return fmt.Errorf("failed to register quota service handler from endpoint %s: %w", service.Endpoint, err)
+	}
+	if err := user_pb.RegisterReportServiceHandlerClient(g.ctx, mux, user_pb.NewReportServiceClient(conn)); err != nil {
+		g.logger.Error("Failed to register report service handler from endpoint", "endpoint", service.Endpoint, "error", err)
+		return fmt.Errorf("failed to register report service handler from endpoint %s: %w", service.Endpoint, err)
+	}
+	if err := user_pb.RegisterOrganizationServiceHandlerClient(g.ctx, mux, user_pb.NewOrganizationServiceClient(conn)); err != nil {
+		g.logger.Error("Failed to register organization service handler from endpoint", "endpoint", service.Endpoint, "error", err)
+		return fmt.Errorf("failed to register organization service handler from endpoint %s: %w", service.Endpoint, err)
+	}
 
 	g.logger.Info("Registered gRPC-Gateway handlers via endpoint", "service", "user-service", "endpoint", service.Endpoint)
 	return nil
 }
 
 // setupWaterQualityServiceHandlers registers standard and custom handlers for the water quality service
-func (g *Gateway) setupWaterQualityServiceHandlers(service domain.Service) error {
+func (g *Gateway) setupWaterQualityServiceHandlers(mux *runtime.ServeMux, service domain.Service) error {
+	// Both the standard and the custom handlers call the service over the shared connection
+	conn, err := g.routedConnFor(service)
+	if err != nil {
+		g.logger.Error("Failed to connect to water quality service", "endpoint", service.Endpoint, "error", err)
+		return err
+	}
+	client := water_quality_pb.NewWaterQualityServiceClient(conn)
+
 	// 1. Register Standard Handlers for all methods (except potentially the upload path)
-	err := water_quality_pb.RegisterWaterQualityServiceHandlerFromEndpoint(g.ctx, g.gwMux, service.Endpoint, g.opts)
+	err = water_quality_pb.RegisterWaterQualityServiceHandlerClient(g.ctx, mux, client)
 	if err != nil {
 		g.logger.Error("Failed to register standard water quality service handler from endpoint", "endpoint", service.Endpoint, "error", err)
 		// Decide if failure here is critical. If other methods are needed, maybe return error.
@@ -87,7 +127,7 @@ func (g *Gateway) setupWaterQualityServiceHandlers(service domain.Service) error
 	}
 
 	// 2. Register Custom Handlers (e.g., for binary upload)
-	customErr := registerWaterQualityCustomHandlers(g.gwMux, service) // Call the function from binary_file_handler.go
+	customErr := registerWaterQualityCustomHandlers(mux, service, client) // Call the function from binary_file_handler.go
 	if customErr != nil {
 		g.logger.Error("Failed to register custom water quality service handlers", "endpoint", service.Endpoint, "error", customErr)
 		// Combine errors if both failed, or return only customErr if standard registration was okay or skipped erroring
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/api-gateway/internal/sdk"
+)
+
+// registerSDK generates the client SDKs from the merged swagger definition once and serves them:
+// GET /sdk lists the version and downloads, GET /sdk/:language returns a tarball. SDK_ENABLED=false
+// turns it off; SDK_VERSION, SDK_GO_MODULE and SDK_NPM_PACKAGE customise the generated packages.
+// The swagger access rules apply to /sdk as well.
+func (g *Gateway) registerSDK(definition map[string]interface{}, config swaggerConfig) {
+	if utils.GetEnv("SDK_ENABLED", "true") != "true" {
+		g.logger.Info("SDK downloads disabled")
+		return
+	}
+
+	defaults := sdk.DefaultOptions()
+	generated, err := sdk.Generate(definition, sdk.Options{
+		Version:    utils.GetEnv("SDK_VERSION", ""),
+		GoModule:   utils.GetEnv("SDK_GO_MODULE", defaults.GoModule),
+		NPMPackage: utils.GetEnv("SDK_NPM_PACKAGE", defaults.NPMPackage),
+	})
+	if err != nil {
+		g.logger.Error("Failed to generate client SDKs", "error", err)
+		return
+	}
+	tarballs := make(map[string][]byte)
+	downloads := fiber.Map{}
+	for _, language := range generated.Languages() {
+		tarball, err := generated.Tarball(language)
+		if err != nil {
+			g.logger.Error("Failed to pack client SDK", "language", language, "error", err)
+			return
+		}
+		tarballs[language] = tarball
+		downloads[language] = fiber.Map{"url": "/sdk/" + language, "file": generated.ArchiveName(language), "size": len(tarball)}
+	}
+
+	if config.RequireAuth {
+		g.app.Use("/sdk", middleware.AuthMiddleware())
+		if len(config.Roles) > 0 {
+			g.app.Use("/sdk", middleware.RequireRole(config.Roles))
+		}
+	}
+
+	etag := `"` + generated.Version + `"`
+	g.app.Get("/sdk", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"version": generated.Version, "downloads": downloads})
+	})
+	g.app.Get("/sdk/:language", func(c *fiber.Ctx) error {
+		language := c.Params("language")
+		tarball, ok := tarballs[language]
+		if !ok {
+			return fiber.NewError(http.StatusNotFound, "unknown SDK language: "+language)
+		}
+		c.Set(fiber.HeaderETag, etag)
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			return c.SendStatus(http.StatusNotModified)
+		}
+		c.Set(fiber.HeaderContentType, "application/gzip")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+generated.ArchiveName(language)+`"`)
+		return c.Send(tarball)
+	})
+	g.logger.Info("Registered client SDK downloads", "endpoint", "/sdk", "version", generated.Version, "languages", generated.Languages())
+}
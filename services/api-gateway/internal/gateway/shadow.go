@@ -0,0 +1,278 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// ShadowRoute mirrors a sample of a service's unary calls to a second deployment. Mirrored calls run
+// after the real one, their responses are only compared with the real response and then dropped.
+type ShadowRoute struct {
+	Backend string `json:"backend"` // Discovered service name or host:port of the shadow deployment
+	Percent int    `json:"percent"` // Percentage of matching requests mirrored, 0-100
+	// Routes limits mirroring to HTTP requests matching these "METHOD /path*" patterns; empty mirrors every route
+	Routes []string `json:"routes,omitempty"`
+	// MirrorWrites mirrors non-GET requests too. They are sent with x-dry-run, so a shadow running
+	// this repo's services rolls their writes back.
+	MirrorWrites bool `json:"mirror_writes"`
+	// IgnoreFields lists top-level response fields left out of the comparison, e.g. generated timestamps
+	IgnoreFields []string `json:"ignore_fields,omitempty"`
+}
+
+// ShadowStats counts the outcomes of mirrored calls
+type ShadowStats struct {
+	Mirrored   uint64 `json:"mirrored"`   // Calls sent to the shadow
+	Matched    uint64 `json:"matched"`    // Same status and response as the real call
+	Mismatched uint64 `json:"mismatched"` // Different status or response
+	Failed     uint64 `json:"failed"`     // Shadow calls that timed out or could not be sent
+	Dropped    uint64 `json:"dropped"`    // Sampled calls skipped because too many were in flight
+}
+
+// ShadowStatus is an active shadow route and its counters
+type ShadowStatus struct {
+	ShadowRoute
+	Stats ShadowStats `json:"stats"`
+}
+
+// shadowStats are the live counters of a shadow target
+type shadowStats struct {
+	mirrored, matched, mismatched, failed, dropped atomic.Uint64
+}
+
+// shadowTarget is an active shadow route and its connection
+type shadowTarget struct {
+	route    ShadowRoute
+	endpoint string
+	conn     *grpc.ClientConn
+	patterns []routePattern
+	timeout  time.Duration
+	inflight chan struct{} // Bounds the concurrent shadow calls
+	stats    shadowStats
+	logger   logger.Logger
+}
+
+// sampled decides whether the call of ctx is mirrored, and whether it is a write
+func (t *shadowTarget) sampled(ctx context.Context) (mirror, write bool) {
+	r, ok := ctx.Value(requestContextKey{}).(*http.Request)
+	if !ok {
+		return false, false
+	}
+	write = r.Method != http.MethodGet && r.Method != http.MethodHead
+	if write && !t.route.MirrorWrites {
+		return false, write
+	}
+	if len(t.patterns) > 0 && !slices.ContainsFunc(t.patterns, func(p routePattern) bool { return p.matches(r.Method, r.URL.Path) }) {
+		return false, write
+	}
+	return rand.IntN(100) < t.route.Percent, write
+}
+
+// mirror sends a copy of a finished call to the shadow in the background and records how its
+// outcome compares with the real one
+func (t *shadowTarget) mirror(ctx context.Context, method string, args, reply interface{}, callErr error) {
+	mirror, write := t.sampled(ctx)
+	if !mirror {
+		return
+	}
+	req, reqOK := args.(proto.Message)
+	resp, respOK := reply.(proto.Message)
+	if !reqOK || !respOK {
+		return
+	}
+	select {
+	case t.inflight <- struct{}{}:
+	default:
+		t.stats.dropped.Add(1)
+		return
+	}
+
+	req = proto.Clone(req)
+	resp = proto.Clone(resp)
+	// Keep the caller's metadata but not its cancellation: the real call has already returned
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), t.timeout)
+	if write {
+		ctx = metadata.AppendToOutgoingContext(ctx, core_grpc.DryRunMetadataKey, "true")
+	}
+	t.stats.mirrored.Add(1)
+
+	go func() {
+		defer func() { <-t.inflight }()
+		defer cancel()
+
+		start := time.Now()
+		shadowResp := resp.ProtoReflect().New().Interface()
+		shadowErr := t.conn.Invoke(ctx, method, req, shadowResp)
+		if shadowErr != nil && ctx.Err() != nil {
+			t.stats.failed.Add(1)
+			t.logger.Warn("Shadow call timed out", "method", method, "backend", t.route.Backend, "error", shadowErr)
+			return
+		}
+
+		primaryCode, shadowCode := status.Code(callErr), status.Code(shadowErr)
+		var diff []string
+		if primaryCode == shadowCode && callErr == nil {
+			diff = diffFields(resp, shadowResp, t.route.IgnoreFields)
+		}
+		if primaryCode == shadowCode && len(diff) == 0 {
+			t.stats.matched.Add(1)
+			return
+		}
+		t.stats.mismatched.Add(1)
+		t.logger.Warn("Shadow response differs",
+			"method", method,
+			"backend", t.route.Backend,
+			"primary_code", primaryCode.String(),
+			"shadow_code", shadowCode.String(),
+			"fields", diff,
+			"shadow_duration", time.Since(start))
+	}()
+}
+
+// diffFields lists the top-level fields whose values differ between two messages of the same type
+func diffFields(a, b proto.Message, ignore []string) []string {
+	ra, rb := a.ProtoReflect(), b.ProtoReflect()
+	fields := ra.Descriptor().Fields()
+	var diff []string
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		if slices.Contains(ignore, name) || slices.Contains(ignore, fd.JSONName()) {
+			continue
+		}
+		if ra.Has(fd) != rb.Has(fd) || !ra.Get(fd).Equal(rb.Get(fd)) {
+			diff = append(diff, name)
+		}
+	}
+	return diff
+}
+
+// shadowTimeout is how long a mirrored call may take, from GATEWAY_SHADOW_TIMEOUT (default 5s)
+func shadowTimeout() time.Duration {
+	return utils.GetEnvDuration("GATEWAY_SHADOW_TIMEOUT", 5*time.Second)
+}
+
+// loadShadowRoute reads the shadow route of a service from the environment:
+//
+//	GATEWAY_SHADOW_USER_SERVICE=user-service-next           shadow backend of user-service
+//	GATEWAY_SHADOW_USER_SERVICE_PERCENT=10                  share of matching requests mirrored (default 0)
+//	GATEWAY_SHADOW_USER_SERVICE_ROUTES=GET /api/v1/users*   patterns of the mirrored routes (default all)
+//	GATEWAY_SHADOW_USER_SERVICE_MIRROR_WRITES=true          mirror mutating requests as dry runs
+//	GATEWAY_SHADOW_USER_SERVICE_IGNORE_FIELDS=updated_at    response fields left out of the comparison
+func loadShadowRoute(service string) (ShadowRoute, bool) {
+	prefix := "GATEWAY_SHADOW_" + strings.ToUpper(strings.ReplaceAll(service, "-", "_"))
+	backend := utils.GetEnv(prefix, "")
+	if backend == "" {
+		return ShadowRoute{}, false
+	}
+	return ShadowRoute{
+		Backend:      backend,
+		Percent:      utils.GetEnvAsInt(prefix+"_PERCENT", 0),
+		Routes:       splitList(utils.GetEnv(prefix+"_ROUTES", "")),
+		MirrorWrites: utils.GetEnv(prefix+"_MIRROR_WRITES", "false") == "true",
+		IgnoreFields: splitList(utils.GetEnv(prefix+"_IGNORE_FIELDS", "")),
+	}, true
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SetShadow mirrors a share of a service's traffic to another deployment, replacing any previous shadow
+func (g *Gateway) SetShadow(service string, route ShadowRoute) error {
+	conn, err := g.findRoutedConn(service)
+	if err != nil {
+		return err
+	}
+	return g.applyShadow(conn, route)
+}
+
+// RemoveShadow stops mirroring a service's traffic
+func (g *Gateway) RemoveShadow(service string) error {
+	conn, err := g.findRoutedConn(service)
+	if err != nil {
+		return err
+	}
+	if old := conn.shadow.Swap(nil); old != nil {
+		time.AfterFunc(old.timeout, func() { old.conn.Close() })
+		g.logger.Info("Shadow removed", "service", conn.service, "backend", old.route.Backend)
+	}
+	return nil
+}
+
+// Shadows returns the active shadow routes and their counters by service
+func (g *Gateway) Shadows() map[string]ShadowStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	shadows := make(map[string]ShadowStatus)
+	for name, conn := range g.routedConns {
+		if target := conn.shadow.Load(); target != nil {
+			shadows[name] = ShadowStatus{
+				ShadowRoute: target.route,
+				Stats: ShadowStats{
+					Mirrored:   target.stats.mirrored.Load(),
+					Matched:    target.stats.matched.Load(),
+					Mismatched: target.stats.mismatched.Load(),
+					Failed:     target.stats.failed.Load(),
+					Dropped:    target.stats.dropped.Load(),
+				},
+			}
+		}
+	}
+	return shadows
+}
+
+// applyShadow validates route, dials its backend and swaps it in. Counters start from zero.
+func (g *Gateway) applyShadow(conn *routedConn, route ShadowRoute) error {
+	if route.Percent < 0 || route.Percent > 100 {
+		return fmt.Errorf("shadow percent must be between 0 and 100, got %d", route.Percent)
+	}
+	endpoint, err := g.resolveBackend(route.Backend)
+	if err != nil {
+		return err
+	}
+
+	target := &shadowTarget{
+		route:    route,
+		endpoint: endpoint,
+		patterns: parseRoutePatterns(route.Routes),
+		timeout:  shadowTimeout(),
+		inflight: make(chan struct{}, utils.GetEnvAsInt("GATEWAY_SHADOW_MAX_IN_FLIGHT", 100)),
+		logger:   g.logger.Named("shadow"),
+	}
+	old := conn.shadow.Load()
+	if old != nil && old.endpoint == endpoint {
+		target.conn = old.conn
+	} else if target.conn, err = grpc.NewClient(endpoint, g.dialOptions(conn.service)...); err != nil {
+		return fmt.Errorf("failed to connect to shadow %s (%s): %w", route.Backend, endpoint, err)
+	}
+
+	conn.shadow.Store(target)
+	if old != nil && old.conn != target.conn {
+		time.AfterFunc(old.timeout, func() { old.conn.Close() })
+	}
+	g.logger.Info("Shadow configured", "service", conn.service, "backend", route.Backend, "endpoint", endpoint, "percent", route.Percent)
+	return nil
+}
@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"golang-microservices-boilerplate/pkg/core/watchdog"
+)
+
+// slowRequestMiddleware reports API requests exceeding their latency threshold to the watchdog.
+// Requests are identified by the pattern of their route policy, e.g. "GET /api/v1/users/{id}",
+// so it runs after version negotiation; requests without a policy share "<method> unmatched".
+func (g *Gateway) slowRequestMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route := c.Method() + " unmatched"
+		if policy := routePolicies.Match(c.Method(), c.Path()); policy != nil {
+			route = c.Method() + " " + policy.Path
+		}
+		done := g.slowRequests.Begin(c.UserContext(), watchdog.Request{Kind: watchdog.KindHTTP, Route: route, RequestID: c.Get(fiber.HeaderXRequestID)})
+
+		err := c.Next()
+		done(c.UserContext(), strconv.Itoa(responseCode(c, err)))
+		return err
+	}
+}
+
+// serveMetrics serves the gateway's metrics in the Prometheus text exposition format. Restrict
+// access with IP_FILTER_RULES, e.g. "/metrics|allow=10.0.0.0/8".
+func (g *Gateway) serveMetrics(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+	if err := g.requestMetrics.WritePrometheus(c); err != nil {
+		return err
+	}
+	return g.slowRequests.Metrics().WritePrometheus(c)
+}
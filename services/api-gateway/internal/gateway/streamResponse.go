@@ -0,0 +1,284 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+)
+
+// mimeNDJSON selects newline-delimited JSON for server-streaming responses
+const mimeNDJSON = "application/x-ndjson"
+
+// streamMarshaler is the gateway's JSON marshaler. Unary responses are marshaled like the
+// grpc-gateway default; the messages of server-streaming responses are written bare instead of as
+// {"result": ...} chunks, and streamingResponseWriter frames them as a JSON array, or as NDJSON
+// when the client accepts application/x-ndjson.
+type streamMarshaler struct {
+	runtime.Marshaler
+	contentType string
+	delimiter   []byte
+}
+
+// streamMarshalerOptions registers the JSON array marshaler as the default and the NDJSON one for
+// Accept: application/x-ndjson
+func streamMarshalerOptions() []runtime.ServeMuxOption {
+	jsonPb := &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+	return []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &streamMarshaler{Marshaler: jsonPb, contentType: fiber.MIMEApplicationJSON}),
+		runtime.WithMarshalerOption(mimeNDJSON, &streamMarshaler{Marshaler: jsonPb, contentType: mimeNDJSON, delimiter: []byte("\n")}),
+	}
+}
+
+// Marshal unwraps the {"result": message} envelope of stream chunks; error chunks keep theirs
+func (m *streamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	if chunk, ok := v.(map[string]interface{}); ok && len(chunk) == 1 {
+		if result, ok := chunk["result"]; ok {
+			return m.Marshaler.Marshal(result)
+		}
+	}
+	return m.Marshaler.Marshal(v)
+}
+
+// Delimiter implements runtime.Delimited. JSON arrays are separated by streamingResponseWriter,
+// which knows which message is the first.
+func (m *streamMarshaler) Delimiter() []byte {
+	return m.delimiter
+}
+
+// StreamContentType implements runtime.StreamContentType
+func (m *streamMarshaler) StreamContentType(v interface{}) string {
+	if _, ok := v.(*httpbody.HttpBody); ok {
+		return m.Marshaler.ContentType(v)
+	}
+	return m.contentType
+}
+
+// muxHandler serves a version mux. Routes whose policy sets StreamResponse are written to the
+// client as the service produces them; all other responses are buffered by the Fiber adaptor.
+func (g *Gateway) muxHandler(h http.Handler) fiber.Handler {
+	buffered := adaptor.HTTPHandler(h)
+	return func(c *fiber.Ctx) error {
+		if policy := routePolicies.Match(c.Method(), c.Path()); policy == nil || !policy.StreamResponse {
+			return buffered(c)
+		}
+		return g.serveStreaming(c, h)
+	}
+}
+
+// serveStreaming runs h in its own goroutine and waits until it flushes or returns. A handler that
+// returns without flushing (e.g. an error before the first message) gets an ordinary response;
+// otherwise the status and headers are sent and the body follows as h writes it. When the client
+// goes away the request context is canceled, which ends the upstream stream.
+func (g *Gateway) serveStreaming(c *fiber.Ctx, h http.Handler) error {
+	req, err := adaptor.ConvertRequest(c, true)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(c.UserContext())
+	w := newStreamingResponseWriter(ctx, acceptsNDJSON(c.Get(fiber.HeaderAccept)))
+	log := logger.FromContext(c.UserContext(), g.logger)
+
+	go func() {
+		defer w.finish()
+		defer func() {
+			if p := recover(); p != nil {
+				log.Error("Recovered from panic in streaming handler", "panic", fmt.Sprint(p))
+			}
+		}()
+		h.ServeHTTP(w, req.WithContext(ctx))
+	}()
+	<-w.committed
+
+	resp := c.Response()
+	resp.SetStatusCode(w.status)
+	for name, values := range w.header {
+		if strings.EqualFold(name, fiber.HeaderTransferEncoding) || strings.EqualFold(name, fiber.HeaderContentLength) {
+			continue // fasthttp frames the body itself
+		}
+		for i, value := range values {
+			if i == 0 {
+				resp.Header.Set(name, value)
+			} else {
+				resp.Header.Add(name, value)
+			}
+		}
+	}
+	if !w.streaming {
+		cancel()
+		resp.SetBody(w.buf.Bytes())
+		return nil
+	}
+
+	c.Context().SetBodyStreamWriter(func(out *bufio.Writer) {
+		defer cancel()
+		for chunk := range w.chunks {
+			if _, err := out.Write(chunk); err != nil {
+				return
+			}
+			if err := out.Flush(); err != nil {
+				log.Debug("Client left a streamed response", "error", err)
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// acceptsNDJSON reports whether an Accept header asks for newline-delimited JSON
+func acceptsNDJSON(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == mimeNDJSON {
+			return true
+		}
+	}
+	return false
+}
+
+// streamingResponseWriter is the http.ResponseWriter of streamed routes. Writes are buffered until
+// the handler first flushes, then handed to the client as they happen. Server-streaming JSON
+// responses are framed as an array: "[" before the first message, "," between messages and "]"
+// once the handler returns.
+type streamingResponseWriter struct {
+	ctx       context.Context
+	ndjson    bool
+	header    http.Header
+	status    int
+	buf       bytes.Buffer
+	chunks    chan []byte
+	committed chan struct{}
+	commit    sync.Once
+	streaming bool // The handler flushed, so writes go to chunks
+	decided   bool // array has been decided
+	array     bool // Frame the writes as a JSON array
+	elements  int  // Messages written in array mode
+}
+
+func newStreamingResponseWriter(ctx context.Context, ndjson bool) *streamingResponseWriter {
+	return &streamingResponseWriter{
+		ctx:       ctx,
+		ndjson:    ndjson,
+		header:    http.Header{},
+		chunks:    make(chan []byte, 16),
+		committed: make(chan struct{}),
+	}
+}
+
+// Header implements http.ResponseWriter
+func (w *streamingResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *streamingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write implements http.ResponseWriter
+func (w *streamingResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if w.isArray() {
+		prefix := ","
+		if w.elements == 0 {
+			prefix = "["
+		}
+		w.elements++
+		if err := w.emit(append([]byte(prefix), p...)); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if err := w.emit(append([]byte(nil), p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush implements http.Flusher. The first flush sends the status and headers.
+func (w *streamingResponseWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.commit.Do(func() {
+		w.streaming = true
+		close(w.committed)
+		if w.buf.Len() > 0 {
+			_ = w.send(w.buf.Bytes())
+		}
+	})
+}
+
+// finish closes the array and ends the response once the handler has returned
+func (w *streamingResponseWriter) finish() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.isArray() {
+		closing := "]"
+		if w.elements == 0 {
+			closing = "[]"
+			w.header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		}
+		_ = w.emit([]byte(closing))
+	}
+	w.commit.Do(func() { close(w.committed) })
+	if w.streaming {
+		close(w.chunks)
+	}
+}
+
+// isArray decides on the first call whether this response is a JSON array: a successful
+// server-stream (grpc-gateway marks those as chunked) that is not NDJSON or a binary HttpBody
+func (w *streamingResponseWriter) isArray() bool {
+	if !w.decided {
+		w.decided = true
+		mediaType, _, _ := mime.ParseMediaType(w.header.Get(fiber.HeaderContentType))
+		w.array = !w.ndjson && w.status == http.StatusOK &&
+			strings.EqualFold(w.header.Get(fiber.HeaderTransferEncoding), "chunked") &&
+			(mediaType == "" || mediaType == fiber.MIMEApplicationJSON)
+	}
+	return w.array
+}
+
+// emit buffers p before the first flush and sends it to the client afterwards
+func (w *streamingResponseWriter) emit(p []byte) error {
+	if !w.streaming {
+		w.buf.Write(p)
+		return nil
+	}
+	return w.send(p)
+}
+
+// send hands a chunk to the client, giving up when the request is canceled
+func (w *streamingResponseWriter) send(p []byte) error {
+	select {
+	case w.chunks <- p:
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
@@ -0,0 +1,215 @@
+package gateway
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// swaggerMergeStrategy says how mergeSwaggerFiles resolves a path or definition that more than one
+// service defines differently. Identical copies (e.g. rpcStatus in every file) are not conflicts.
+type swaggerMergeStrategy struct {
+	PrefixDefinitions bool // Rename the later definition to "<service>.<name>" and rewrite its $refs
+	NamespacePaths    bool // Move the later operation under "/<service><path>"
+	FailOnConflict    bool // Abort the merge and report every conflict
+}
+
+// parseSwaggerMergeStrategy parses a comma separated list of "skip", "prefix", "namespace" and "fail"
+func parseSwaggerMergeStrategy(value string) (swaggerMergeStrategy, error) {
+	var strategy swaggerMergeStrategy
+	for _, name := range splitList(value) {
+		switch strings.ToLower(name) {
+		case "skip":
+		case "prefix":
+			strategy.PrefixDefinitions = true
+		case "namespace":
+			strategy.NamespacePaths = true
+		case "fail":
+			strategy.FailOnConflict = true
+		default:
+			return strategy, fmt.Errorf("unknown swagger merge strategy %q (want skip, prefix, namespace or fail)", name)
+		}
+	}
+	return strategy, nil
+}
+
+// String returns the strategy in the form parseSwaggerMergeStrategy accepts
+func (s swaggerMergeStrategy) String() string {
+	var names []string
+	if s.PrefixDefinitions {
+		names = append(names, "prefix")
+	}
+	if s.NamespacePaths {
+		names = append(names, "namespace")
+	}
+	if s.FailOnConflict {
+		names = append(names, "fail")
+	}
+	if len(names) == 0 {
+		return "skip"
+	}
+	return strings.Join(names, ",")
+}
+
+// Resolutions recorded on a swaggerConflict
+const (
+	conflictDropped    = "dropped"
+	conflictRenamed    = "renamed"
+	conflictNamespaced = "namespaced"
+)
+
+// swaggerConflict is a path operation or definition that a later swagger file defined differently
+type swaggerConflict struct {
+	Kind            string `json:"kind"`             // "path" or "definition"
+	Name            string `json:"name"`             // Path or definition name
+	Method          string `json:"method,omitempty"` // Operation key within the path item, for paths
+	Service         string `json:"service"`          // Service of the conflicting file
+	File            string `json:"file"`
+	ExistingService string `json:"existing_service"` // Service whose copy was merged first
+	Resolution      string `json:"resolution"`
+	RenamedTo       string `json:"renamed_to,omitempty"`
+}
+
+// swaggerConflictError is returned by mergeSwaggerFiles when the strategy is to fail on conflicts
+type swaggerConflictError struct {
+	Conflicts []swaggerConflict
+}
+
+func (e *swaggerConflictError) Error() string {
+	lines := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		item := c.Name
+		if c.Method != "" {
+			item = strings.ToUpper(c.Method) + " " + c.Name
+		}
+		lines = append(lines, fmt.Sprintf("%s %s in %s (%s) conflicts with %s", c.Kind, item, c.Service, c.File, c.ExistingService))
+	}
+	return fmt.Sprintf("%d swagger merge conflicts:\n  %s", len(e.Conflicts), strings.Join(lines, "\n  "))
+}
+
+// swaggerService names the service a swagger file belongs to: its first directory below protoDir
+func swaggerService(protoDir, file string) string {
+	if rel, err := filepath.Rel(protoDir, file); err == nil {
+		if parts := strings.Split(filepath.ToSlash(rel), "/"); len(parts) > 1 {
+			return parts[0]
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(file), ".swagger.json")
+}
+
+// swaggerMerger merges paths and definitions of successive files, remembering who defined what
+type swaggerMerger struct {
+	strategy    swaggerMergeStrategy
+	paths       map[string]interface{}
+	definitions map[string]interface{}
+	pathOwners  map[string]string // "<path> <method>" -> service
+	defOwners   map[string]string // definition -> service
+	conflicts   []swaggerConflict
+}
+
+func newSwaggerMerger(strategy swaggerMergeStrategy, paths, definitions map[string]interface{}) *swaggerMerger {
+	return &swaggerMerger{
+		strategy:    strategy,
+		paths:       paths,
+		definitions: definitions,
+		pathOwners:  make(map[string]string),
+		defOwners:   make(map[string]string),
+		conflicts:   []swaggerConflict{},
+	}
+}
+
+// merge adds the definitions and then the paths of one parsed swagger file
+func (m *swaggerMerger) merge(swagger map[string]interface{}, service, file string) {
+	definitions, _ := swagger["definitions"].(map[string]interface{})
+	renames := make(map[string]string)
+	for name, def := range definitions {
+		existing, exists := m.definitions[name]
+		if !exists {
+			m.definitions[name] = def
+			m.defOwners[name] = service
+			continue
+		}
+		if reflect.DeepEqual(existing, def) {
+			continue
+		}
+		conflict := swaggerConflict{Kind: "definition", Name: name, Service: service, File: file, ExistingService: m.defOwners[name], Resolution: conflictDropped}
+		renamed := service + "." + name
+		if _, taken := m.definitions[renamed]; m.strategy.PrefixDefinitions && !taken {
+			renames[name] = renamed
+			conflict.Resolution = conflictRenamed
+			conflict.RenamedTo = renamed
+		}
+		m.conflicts = append(m.conflicts, conflict)
+	}
+	// Point the file's own references at its renamed definitions before anything is merged
+	if len(renames) > 0 {
+		rewriteSwaggerRefs(swagger, renames)
+		for name, renamed := range renames {
+			m.definitions[renamed] = definitions[name]
+			m.defOwners[renamed] = service
+		}
+	}
+
+	paths, _ := swagger["paths"].(map[string]interface{})
+	for path, itemRaw := range paths {
+		item, ok := itemRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for method, operation := range item {
+			target := path
+			if existing, exists := m.operation(path, method); exists {
+				if reflect.DeepEqual(existing, operation) {
+					continue
+				}
+				conflict := swaggerConflict{Kind: "path", Name: path, Method: method, Service: service, File: file, ExistingService: m.pathOwners[path+" "+method], Resolution: conflictDropped}
+				target = "/" + service + path
+				if _, taken := m.operation(target, method); !m.strategy.NamespacePaths || taken {
+					m.conflicts = append(m.conflicts, conflict)
+					continue
+				}
+				conflict.Resolution = conflictNamespaced
+				conflict.RenamedTo = target
+				m.conflicts = append(m.conflicts, conflict)
+			}
+			merged, ok := m.paths[target].(map[string]interface{})
+			if !ok {
+				merged = make(map[string]interface{})
+				m.paths[target] = merged
+			}
+			merged[method] = operation
+			m.pathOwners[target+" "+method] = service
+		}
+	}
+}
+
+// operation returns the merged operation stored under a path and method
+func (m *swaggerMerger) operation(path, method string) (interface{}, bool) {
+	item, ok := m.paths[path].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	operation, exists := item[method]
+	return operation, exists
+}
+
+// rewriteSwaggerRefs replaces "#/definitions/<old>" references anywhere in value with their renamed target
+func rewriteSwaggerRefs(value interface{}, renames map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				if name, found := strings.CutPrefix(ref, "#/definitions/"); found && renames[name] != "" {
+					v[key] = "#/definitions/" + renames[name]
+				}
+				continue
+			}
+			rewriteSwaggerRefs(child, renames)
+		}
+	case []interface{}:
+		for _, child := range v {
+			rewriteSwaggerRefs(child, renames)
+		}
+	}
+}
@@ -1,43 +1,147 @@
 package gateway
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/filesystem"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/pkg/utils"
 )
 
-// RegisterSwaggerUI registers handlers for Swagger UI with the Fiber app
-func (g *Gateway) RegisterSwaggerUI(swaggerDir string) {
+// swaggerConfig controls who may read the API documentation under /swagger
+type swaggerConfig struct {
+	Enabled     bool          // Serve /swagger at all
+	RequireAuth bool          // Require a valid access token
+	Roles       []string      // Require one of these roles; implies RequireAuth
+	MaxAge      time.Duration // Cache-Control max-age of the merged openapi.json
+
+	MergeStrategy swaggerMergeStrategy // How paths and definitions defined by several services are merged
+}
+
+// loadSwaggerConfigFromEnv reads SWAGGER_ENABLED (default true, false when APP_ENV is "production"),
+// SWAGGER_REQUIRE_AUTH (default false), SWAGGER_ROLES (comma separated), SWAGGER_CACHE_MAX_AGE (default 5m)
+// and SWAGGER_MERGE_STRATEGY (default skip)
+func loadSwaggerConfigFromEnv() (swaggerConfig, error) {
+	strategy, err := parseSwaggerMergeStrategy(utils.GetEnv("SWAGGER_MERGE_STRATEGY", "skip"))
+	if err != nil {
+		return swaggerConfig{}, err
+	}
+	roles := splitList(utils.GetEnv("SWAGGER_ROLES", ""))
+	return swaggerConfig{
+		Enabled:       utils.GetEnv("SWAGGER_ENABLED", strconv.FormatBool(!isProduction())) == "true",
+		RequireAuth:   utils.GetEnv("SWAGGER_REQUIRE_AUTH", "false") == "true" || len(roles) > 0,
+		Roles:         roles,
+		MaxAge:        utils.GetEnvDuration("SWAGGER_CACHE_MAX_AGE", 5*time.Minute),
+		MergeStrategy: strategy,
+	}, nil
+}
+
+// serveSwaggerJSON serves a definition serialized once, with an ETag so clients revalidate with a 304
+func serveSwaggerJSON(definition map[string]interface{}, config swaggerConfig) (fiber.Handler, error) {
+	body, err := json.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(config.MaxAge.Seconds()))
+	if config.RequireAuth {
+		cacheControl = fmt.Sprintf("private, max-age=%d", int(config.MaxAge.Seconds()))
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderETag, etag)
+		c.Set(fiber.HeaderCacheControl, cacheControl)
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			return c.SendStatus(http.StatusNotModified)
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	}, nil
+}
+
+// etagMatches reports whether an If-None-Match header lists etag (weak comparison)
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterSwaggerUI registers handlers for Swagger UI with the Fiber app, unless SWAGGER_ENABLED is off.
+// It only fails on invalid configuration or, with the "fail" merge strategy, on merge conflicts.
+func (g *Gateway) RegisterSwaggerUI(swaggerDir string) error {
+	config, err := loadSwaggerConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	if !config.Enabled {
+		g.logger.Info("Swagger UI disabled")
+		return nil
+	}
+	if config.RequireAuth {
+		g.app.Use("/swagger", middleware.AuthMiddleware())
+		if len(config.Roles) > 0 {
+			g.app.Use("/swagger", middleware.RequireRole(config.Roles))
+		}
+		g.logger.Info("Swagger UI requires authentication", "roles", config.Roles)
+	}
+
 	// Ensure swaggerDir exists
 	if _, err := os.Stat(swaggerDir); os.IsNotExist(err) {
 		g.logger.Warn("Swagger directory not found", "path", swaggerDir)
-		return
+		return nil
 	}
 
 	// Create a merged swagger definition from the proto directory
 	protoDir := path.Join(swaggerDir, "proto")
 	if _, err := os.Stat(protoDir); !os.IsNotExist(err) {
 		// Create a merged swagger definition
-		mergedSwagger, err := mergeSwaggerFiles(g, protoDir)
+		mergedSwagger, conflicts, err := mergeSwaggerFiles(g, protoDir, config.MergeStrategy)
+		var conflictErr *swaggerConflictError
+		if errors.As(err, &conflictErr) {
+			return fmt.Errorf("failed to merge swagger files: %w", err)
+		}
 		if err != nil {
 			g.logger.Error("Failed to merge swagger files", "error", err)
 		} else {
+			// Report what the merge dropped, renamed or namespaced
+			report := fiber.Map{"strategy": config.MergeStrategy.String(), "conflicts": conflicts}
+			g.app.Get("/swagger/conflicts", func(c *fiber.Ctx) error {
+				return c.JSON(report)
+			})
+			g.logger.Info("Registered swagger merge conflicts report", "endpoint", "/swagger/conflicts", "conflicts", len(conflicts))
+
 			// Parse descriptions from summaries if needed
 			processDescriptionsAndDefaults(mergedSwagger)
 
 			// Serve the merged swagger file
-			g.app.Get("/swagger/openapi.json", func(c *fiber.Ctx) error {
-				c.Set("Content-Type", "application/json")
-				return c.JSON(mergedSwagger)
-			})
-			g.logger.Info("Registered merged swagger definition", "endpoint", "/swagger/openapi.json")
+			if handler, err := serveSwaggerJSON(mergedSwagger, config); err != nil {
+				g.logger.Error("Failed to serialize merged swagger definition", "error", err)
+			} else {
+				g.app.Get("/swagger/openapi.json", handler)
+				g.logger.Info("Registered merged swagger definition", "endpoint", "/swagger/openapi.json")
+			}
+
+			// Serve client SDKs generated from the merged definition
+			g.registerSDK(mergedSwagger, config)
 		}
 	} else {
 		g.logger.Info("Proto directory not found", "path", protoDir)
@@ -91,6 +195,7 @@ func (g *Gateway) RegisterSwaggerUI(swaggerDir string) {
 	})
 
 	g.logger.Info("Registered Swagger UI static files", "endpoint", "/swagger/")
+	return nil
 }
 
 // Extract description from summary fields and set it to the description field
@@ -213,8 +318,9 @@ func processDescriptionsAndDefaults(swagger map[string]interface{}) {
 	}
 }
 
-// mergeSwaggerFiles finds and merges all swagger.json files in the proto directory
-func mergeSwaggerFiles(g *Gateway, protoDir string) (map[string]interface{}, error) {
+// mergeSwaggerFiles finds and merges all swagger.json files in the proto directory. It also returns the
+// paths and definitions that conflicted between services and how the strategy resolved each of them.
+func mergeSwaggerFiles(g *Gateway, protoDir string, strategy swaggerMergeStrategy) (map[string]interface{}, []swaggerConflict, error) {
 	// Initialize the merged swagger definition
 	mergedSwagger := map[string]interface{}{
 		"swagger": "2.0",
@@ -245,9 +351,11 @@ func mergeSwaggerFiles(g *Gateway, protoDir string) (map[string]interface{}, err
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	merger := newSwaggerMerger(strategy, mergedSwagger["paths"].(map[string]interface{}), mergedSwagger["definitions"].(map[string]interface{}))
+
 	// Maps to track existing tags for deduplication
 	existingTags := make(map[string]bool)
 	existingSecurityDefs := make(map[string]bool)
@@ -266,33 +374,8 @@ func mergeSwaggerFiles(g *Gateway, protoDir string) (map[string]interface{}, err
 			continue
 		}
 
-		// Merge paths
-		if paths, ok := swagger["paths"].(map[string]interface{}); ok {
-			mergedPaths := mergedSwagger["paths"].(map[string]interface{})
-			for path, pathDef := range paths {
-				// Check if this path already exists in the merged swagger
-				if _, exists := mergedPaths[path]; exists {
-					// Path already exists, log a warning and continue
-					g.logger.Warn("Path already exists in merged swagger, skipping", "path", path)
-					continue
-				}
-				mergedPaths[path] = pathDef
-			}
-		}
-
-		// Merge definitions
-		if definitions, ok := swagger["definitions"].(map[string]interface{}); ok {
-			mergedDefs := mergedSwagger["definitions"].(map[string]interface{})
-			for def, defObj := range definitions {
-				// Check if this definition already exists in the merged swagger
-				if _, exists := mergedDefs[def]; exists {
-					// Definition already exists, log a warning but don't overwrite
-					g.logger.Warn("Definition already exists in merged swagger, skipping", "definition", def)
-					continue
-				}
-				mergedDefs[def] = defObj
-			}
-		}
+		// Merge paths and definitions, resolving conflicts with the configured strategy
+		merger.merge(swagger, swaggerService(protoDir, file), file)
 
 		// Merge tags (making sure to avoid duplicates)
 		if tags, ok := swagger["tags"].([]interface{}); ok {
@@ -387,5 +470,22 @@ func mergeSwaggerFiles(g *Gateway, protoDir string) (map[string]interface{}, err
 		}
 	}
 
-	return mergedSwagger, nil
+	sort.Slice(merger.conflicts, func(i, j int) bool {
+		a, b := merger.conflicts[i], merger.conflicts[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Method < b.Method
+	})
+	for _, conflict := range merger.conflicts {
+		g.logger.Warn("Swagger merge conflict", "kind", conflict.Kind, "name", conflict.Name, "method", conflict.Method,
+			"service", conflict.Service, "existing_service", conflict.ExistingService, "resolution", conflict.Resolution, "renamed_to", conflict.RenamedTo)
+	}
+	if strategy.FailOnConflict && len(merger.conflicts) > 0 {
+		return nil, merger.conflicts, &swaggerConflictError{Conflicts: merger.conflicts}
+	}
+	return mergedSwagger, merger.conflicts, nil
 }
@@ -0,0 +1,191 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
+)
+
+// versionHeader is used both for negotiating unversioned requests and to report the served version
+const versionHeader = "X-API-Version"
+
+// APIVersion is one API version served by the gateway, backed by its own gRPC-Gateway mux
+type APIVersion struct {
+	Name       string
+	Mux        *runtime.ServeMux
+	Deprecated bool
+	// DeprecatedAt and Sunset are optional; zero values omit the date from the headers
+	DeprecatedAt time.Time
+	Sunset       time.Time
+	// Backends routes a service to a different deployment for this version, e.g. user-service -> user-service-v2
+	Backends map[string]string
+	// successor is the next served version, advertised to clients of a deprecated version
+	successor string
+}
+
+// serviceRegistrar registers a service's handlers on a version mux
+type serviceRegistrar func(g *Gateway, mux *runtime.ServeMux, service domain.Service) error
+
+// versionRegistry lists, per API version, the services whose handlers are served under /api/<version>.
+// Add an entry here when a service ships handlers for a new version.
+var versionRegistry = map[string]map[string]serviceRegistrar{
+	"v1": {
+		"user-service":          (*Gateway).setupUserServiceHandlers,
+		"water-quality-service": (*Gateway).setupWaterQualityServiceHandlers,
+	},
+}
+
+// loadAPIVersions reads the served versions from env.
+//
+//	API_VERSIONS=v1,v2               versions to serve, oldest first
+//	API_DEFAULT_VERSION=v1           version for unversioned /api/... requests without X-API-Version
+//	API_V1_DEPRECATED=true           or an RFC3339 date when the version was deprecated
+//	API_V1_SUNSET=2027-01-01T00:00:00Z
+//	API_V2_BACKENDS=user-service=user-service-v2
+func loadAPIVersions(muxOpts []runtime.ServeMuxOption) ([]*APIVersion, string, error) {
+	names := strings.Split(utils.GetEnv("API_VERSIONS", "v1"), ",")
+	versions := make([]*APIVersion, 0, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "API_" + strings.ToUpper(name) + "_"
+		v := &APIVersion{
+			Name:     name,
+			Mux:      runtime.NewServeMux(muxOpts...),
+			Backends: make(map[string]string),
+		}
+
+		if deprecated := utils.GetEnv(prefix+"DEPRECATED", ""); deprecated != "" && deprecated != "false" {
+			v.Deprecated = true
+			if deprecated != "true" {
+				at, err := time.Parse(time.RFC3339, deprecated)
+				if err != nil {
+					return nil, "", fmt.Errorf("invalid %sDEPRECATED: %w", prefix, err)
+				}
+				v.DeprecatedAt = at
+			}
+		}
+		if sunset := utils.GetEnv(prefix+"SUNSET", ""); sunset != "" {
+			at, err := time.Parse(time.RFC3339, sunset)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid %sSUNSET: %w", prefix, err)
+			}
+			v.Sunset = at
+		}
+		for _, pair := range strings.Split(utils.GetEnv(prefix+"BACKENDS", ""), ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			service, backend, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, "", fmt.Errorf("invalid %sBACKENDS entry %q, expected service=backend", prefix, pair)
+			}
+			v.Backends[strings.TrimSpace(service)] = strings.TrimSpace(backend)
+		}
+
+		if len(versions) > 0 {
+			versions[len(versions)-1].successor = name
+		}
+		versions = append(versions, v)
+	}
+	if len(versions) == 0 {
+		return nil, "", fmt.Errorf("API_VERSIONS must list at least one version")
+	}
+
+	defaultVersion := utils.GetEnv("API_DEFAULT_VERSION", versions[0].Name)
+	if !slices.ContainsFunc(versions, func(v *APIVersion) bool { return v.Name == defaultVersion }) {
+		return nil, "", fmt.Errorf("API_DEFAULT_VERSION %q is not in API_VERSIONS", defaultVersion)
+	}
+	return versions, defaultVersion, nil
+}
+
+// backendFor returns the discovered service that serves registryName for this version
+func (v *APIVersion) backendFor(registryName string, services []domain.Service) (domain.Service, bool) {
+	target := registryName
+	if backend, ok := v.Backends[registryName]; ok {
+		target = backend
+	}
+	for _, s := range services {
+		if sameServiceName(s.Name, target) {
+			return s, true
+		}
+	}
+	return domain.Service{}, false
+}
+
+// sameServiceName compares service names, accepting both "user" and "user-service"
+func sameServiceName(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	return a == b || a+"-service" == b || a == b+"-service"
+}
+
+// mountVersions serves every version under /api/<version>
+func (g *Gateway) mountVersions() {
+	for _, v := range g.versions {
+		g.app.Use("/api/"+v.Name, versionHeaders(v), g.muxHandler(withRequestInContext(v.Mux)))
+	}
+}
+
+// negotiateVersion rewrites /api/<path> to /api/<version>/<path> when the path has no version segment,
+// using X-API-Version if the client sent a served version and the default version otherwise.
+func (g *Gateway) negotiateVersion(c *fiber.Ctx) error {
+	rest := strings.TrimPrefix(c.Path(), "/api")
+	first, _, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/")
+	if g.servesVersion(first) {
+		return c.Next()
+	}
+
+	version := g.defaultVersion
+	if requested := strings.ToLower(c.Get(versionHeader)); requested != "" {
+		if !g.servesVersion(requested) {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("unsupported API version %q", requested),
+			})
+		}
+		version = requested
+	}
+	path := "/api/" + version + rest
+	c.Path(path)
+	// The HTTP adaptor reads the raw request URI, so rewrite it as well
+	if query := c.Request().URI().QueryString(); len(query) > 0 {
+		path += "?" + string(query)
+	}
+	c.Request().SetRequestURI(path)
+	return c.Next()
+}
+
+func (g *Gateway) servesVersion(name string) bool {
+	return slices.ContainsFunc(g.versions, func(v *APIVersion) bool { return v.Name == name })
+}
+
+// versionHeaders reports the served version and, for deprecated versions, the Deprecation/Sunset headers
+func versionHeaders(v *APIVersion) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(versionHeader, v.Name)
+		if v.Deprecated {
+			if v.DeprecatedAt.IsZero() {
+				c.Set("Deprecation", "true")
+			} else {
+				c.Set("Deprecation", fmt.Sprintf("@%d", v.DeprecatedAt.Unix()))
+			}
+			if v.successor != "" {
+				c.Append(fiber.HeaderLink, fmt.Sprintf("</api/%s>; rel=\"successor-version\"", v.successor))
+			}
+		}
+		if !v.Sunset.IsZero() {
+			c.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+		}
+		return c.Next()
+	}
+}
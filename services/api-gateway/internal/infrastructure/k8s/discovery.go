@@ -2,8 +2,10 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -11,6 +13,9 @@ import (
 
 	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
 
+	"google.golang.org/grpc/resolver"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -26,6 +31,8 @@ type KubernetesDiscovery struct {
 	logger        *log.Logger
 	services      []domain.Service
 	servicesMutex sync.RWMutex // Mutex for services slice
+	// resolveEndpoints makes service endpoints resolve to the ready pods (EndpointSlices) instead of the ClusterIP
+	resolveEndpoints bool
 	// done channel removed
 	// refreshInterval removed
 }
@@ -54,6 +61,15 @@ func WithLogger(logger *log.Logger) DiscoveryOption {
 	}
 }
 
+// WithEndpointResolution makes discovered endpoints use the k8s:/// resolver, which tracks the
+// ready pods of each service, so connections using the round_robin balancer spread calls across
+// pods and follow pod churn. Requires list/watch permission on endpointslices.
+func WithEndpointResolution(enabled bool) DiscoveryOption {
+	return func(kd *KubernetesDiscovery) {
+		kd.resolveEndpoints = enabled
+	}
+}
+
 // NewKubernetesDiscovery creates a new KubernetesDiscovery instance
 // and performs service discovery once.
 func NewKubernetesDiscovery(opts ...DiscoveryOption) (*KubernetesDiscovery, error) {
@@ -87,6 +103,9 @@ func NewKubernetesDiscovery(opts ...DiscoveryOption) (*KubernetesDiscovery, erro
 		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 	kd.client = clientset
+	if kd.resolveEndpoints {
+		resolver.Register(&endpointsResolverBuilder{client: clientset, logger: kd.logger})
+	}
 
 	kd.logger.Println("KubernetesDiscovery initializing...")
 
@@ -119,6 +138,32 @@ func (kd *KubernetesDiscovery) Close() error {
 	return nil
 }
 
+// CheckPermissions verifies that the gateway's service account may do what discovery needs: list
+// services and, with endpoint resolution, list and watch endpointslices. Missing RBAC rules would
+// otherwise surface later as resolvers that never find an address.
+func (kd *KubernetesDiscovery) CheckPermissions(ctx context.Context) error {
+	type permission struct{ group, resource, verb string }
+	required := []permission{{"", "services", "list"}}
+	if kd.resolveEndpoints {
+		required = append(required, permission{"discovery.k8s.io", "endpointslices", "list"}, permission{"discovery.k8s.io", "endpointslices", "watch"})
+	}
+
+	var errs []error
+	for _, p := range required {
+		review, err := kd.client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{Namespace: kd.namespace, Group: p.group, Resource: p.resource, Verb: p.verb},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check permission to %s %s: %w", p.verb, p.resource, err))
+		} else if !review.Status.Allowed {
+			errs = append(errs, fmt.Errorf("not allowed to %s %s in namespace %q", p.verb, p.resource, kd.namespace))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // discoverAndStoreServices discovers services once and stores them.
 // Renamed from RefreshConnections.
 func (kd *KubernetesDiscovery) discoverAndStoreServices() error {
@@ -162,9 +207,11 @@ func (kd *KubernetesDiscovery) discoverServices() ([]domain.Service, error) {
 
 		// Find the gRPC port
 		var port int32
+		var portSpec corev1.ServicePort
 		for _, p := range svc.Spec.Ports {
 			if p.Name == "grpc" || p.Port == 50051 { // Common gRPC port names/numbers
 				port = p.Port
+				portSpec = p
 				break
 			}
 		}
@@ -172,6 +219,7 @@ func (kd *KubernetesDiscovery) discoverServices() ([]domain.Service, error) {
 		// If no specifically named gRPC port found, use the first one
 		if port == 0 && len(svc.Spec.Ports) > 0 {
 			port = svc.Spec.Ports[0].Port
+			portSpec = svc.Spec.Ports[0]
 			kd.logger.Printf("Service %s: No 'grpc' or '50051' port found, using first port: %d", svc.Name, port)
 		}
 
@@ -184,6 +232,16 @@ func (kd *KubernetesDiscovery) discoverServices() ([]domain.Service, error) {
 		// Create endpoint (adjust if using ClusterIP, NodePort, or LoadBalancer differently)
 		// This assumes ClusterIP service type and internal cluster DNS resolution.
 		endpoint := fmt.Sprintf("%s.%s.svc.cluster.local:%d", svc.Name, kd.namespace, port)
+		if kd.resolveEndpoints {
+			// EndpointSlices list the pods' target ports, which are matched by port name when there is one
+			target := portSpec.TargetPort.String()
+			if portSpec.Name != "" {
+				target = portSpec.Name
+			} else if target == "" || target == "0" {
+				target = strconv.Itoa(int(port))
+			}
+			endpoint = fmt.Sprintf("%s:///%s.%s:%s", EndpointsScheme, svc.Name, kd.namespace, target)
+		}
 
 		// Service name is the Kubernetes service name
 		serviceName := svc.Name
@@ -0,0 +1,145 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/resolver"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EndpointsScheme is the gRPC target scheme resolved from EndpointSlices:
+// k8s:///<service>.<namespace>:<port>, where port is the service port's name or target port number.
+const EndpointsScheme = "k8s"
+
+// endpointsResolverBuilder builds resolvers that watch a service's EndpointSlices
+type endpointsResolverBuilder struct {
+	client kubernetes.Interface
+	logger *log.Logger
+}
+
+// Scheme implements resolver.Builder
+func (b *endpointsResolverBuilder) Scheme() string {
+	return EndpointsScheme
+}
+
+// Build implements resolver.Builder
+func (b *endpointsResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	host, port, err := net.SplitHostPort(target.Endpoint())
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s target %q: %w", EndpointsScheme, target.Endpoint(), err)
+	}
+	service, namespace, ok := strings.Cut(host, ".")
+	if !ok || service == "" || namespace == "" {
+		return nil, fmt.Errorf("invalid %s target %q, expected <service>.<namespace>:<port>", EndpointsScheme, target.Endpoint())
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(b.client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.LabelSelector = discoveryv1.LabelServiceName + "=" + service
+		}),
+	)
+	slices := factory.Discovery().V1().EndpointSlices()
+
+	r := &endpointsResolver{
+		service: service,
+		port:    port,
+		cc:      cc,
+		lister:  slices.Lister().EndpointSlices(namespace),
+		logger:  b.logger,
+		stop:    make(chan struct{}),
+	}
+	onChange := func(interface{}) { r.update() }
+	if _, err := slices.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onChange,
+		UpdateFunc: func(_, _ interface{}) { r.update() },
+		DeleteFunc: onChange,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to watch endpoints of %s: %w", service, err)
+	}
+	factory.Start(r.stop)
+	return r, nil
+}
+
+// endpointsResolver pushes the ready pod addresses of one service to a gRPC connection
+type endpointsResolver struct {
+	service string
+	port    string
+	cc      resolver.ClientConn
+	lister  discoverylisters.EndpointSliceNamespaceLister
+	logger  *log.Logger
+	stop    chan struct{}
+}
+
+// update recomputes the address list from all EndpointSlices of the service
+func (r *endpointsResolver) update() {
+	list, err := r.lister.List(labels.Everything())
+	if err != nil {
+		r.cc.ReportError(fmt.Errorf("failed to list endpoints of %s: %w", r.service, err))
+		return
+	}
+
+	var addrs []resolver.Address
+	seen := make(map[string]bool)
+	for _, slice := range list {
+		port, ok := r.slicePort(slice)
+		if !ok {
+			continue
+		}
+		for _, ep := range slice.Endpoints {
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue // Not ready or terminating
+			}
+			for _, ip := range ep.Addresses {
+				addr := net.JoinHostPort(ip, strconv.Itoa(int(port)))
+				if !seen[addr] {
+					seen[addr] = true
+					addrs = append(addrs, resolver.Address{Addr: addr})
+				}
+			}
+		}
+	}
+
+	if len(addrs) == 0 {
+		r.cc.ReportError(errors.New("no ready endpoints for " + r.service))
+		return
+	}
+	if err := r.cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+		r.logger.Printf("Failed to update endpoints of %s: %v", r.service, err)
+	}
+}
+
+// slicePort finds the port of the target: by name, by number, or the only port of the slice
+func (r *endpointsResolver) slicePort(slice *discoveryv1.EndpointSlice) (int32, bool) {
+	for _, p := range slice.Ports {
+		if p.Port == nil {
+			continue
+		}
+		if (p.Name != nil && *p.Name == r.port) || strconv.Itoa(int(*p.Port)) == r.port {
+			return *p.Port, true
+		}
+	}
+	if len(slice.Ports) == 1 && slice.Ports[0].Port != nil {
+		return *slice.Ports[0].Port, true
+	}
+	return 0, false
+}
+
+// ResolveNow implements resolver.Resolver; the informer already delivers every change
+func (r *endpointsResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+// Close implements resolver.Resolver
+func (r *endpointsResolver) Close() {
+	close(r.stop)
+}
@@ -0,0 +1,327 @@
+package sdk
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// goGenerator renders the Go SDK; inline object schemas are hoisted into named types as they are met
+type goGenerator struct {
+	api     api
+	defined map[string]bool   // Go type names already rendered
+	types   map[string]string // Go type name -> declaration
+}
+
+// generateGo returns the files of the Go SDK: go.mod, types.go and client.go
+func generateGo(a api, opts Options) (map[string][]byte, error) {
+	g := &goGenerator{api: a, defined: make(map[string]bool), types: make(map[string]string)}
+	for _, name := range a.sortedDefinitionNames() {
+		g.define(exportedName(name), a.Definitions[name])
+	}
+
+	pkg := goPackageName(opts.GoModule)
+	var client strings.Builder
+	fmt.Fprintf(&client, goClientRuntime, pkg, opts.Version)
+	for _, op := range a.Operations {
+		g.writeOperation(&client, op)
+	}
+
+	var types strings.Builder
+	fmt.Fprintf(&types, "%s\npackage %s\n", goHeader, pkg)
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		types.WriteString("\n" + g.types[name])
+	}
+
+	files := map[string][]byte{
+		"go.mod": []byte(fmt.Sprintf("module %s\n\ngo 1.21\n", opts.GoModule)),
+	}
+	for name, source := range map[string]string{"client.go": client.String(), "types.go": types.String()} {
+		formatted, err := format.Source([]byte(source))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// goPackageName derives the package name from the last element of the module path
+func goPackageName(module string) string {
+	name := strings.ToLower(nonAlphanumeric.ReplaceAllString(path.Base(module), ""))
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "sdk"
+	}
+	return name
+}
+
+const goHeader = "// Code generated by the api-gateway SDK generator. DO NOT EDIT.\n"
+
+// define renders a named type for a schema
+func (g *goGenerator) define(name string, s schema) {
+	if g.defined[name] {
+		return
+	}
+	g.defined[name] = true
+
+	var b strings.Builder
+	if doc := s.doc(); doc != "" {
+		fmt.Fprintf(&b, "// %s %s\n", name, doc)
+	}
+	switch {
+	case len(s.enum()) > 0:
+		fmt.Fprintf(&b, "type %s string\n\nconst (\n", name)
+		for _, value := range s.enum() {
+			fmt.Fprintf(&b, "\t%s_%s %s = %q\n", name, nonAlphanumeric.ReplaceAllString(value, "_"), name, value)
+		}
+		b.WriteString(")\n")
+	case s.isObject():
+		fmt.Fprintf(&b, "type %s struct {\n", name)
+		for _, prop := range s.sortedProperties() {
+			propSchema := s.property(prop)
+			field := exportedName(prop)
+			if doc := propSchema.doc(); doc != "" {
+				fmt.Fprintf(&b, "\t// %s\n", doc)
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:\"%s,omitempty\"`\n", field, g.typeOf(propSchema, name+field), prop)
+		}
+		b.WriteString("}\n")
+	default:
+		fmt.Fprintf(&b, "type %s %s\n", name, strings.TrimPrefix(g.typeOf(s, name+"Value"), "*"))
+	}
+	g.types[name] = b.String()
+}
+
+// typeOf returns the Go type of a schema. Objects with properties become named types called hint;
+// references to objects are pointers so optional fields can be left out.
+func (g *goGenerator) typeOf(s schema, hint string) string {
+	if ref := s.ref(); ref != "" {
+		if def, ok := g.api.Definitions[ref]; ok && !def.isObject() {
+			return exportedName(ref)
+		}
+		return "*" + exportedName(ref)
+	}
+	switch s.str("type") {
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	case "integer":
+		switch s.str("format") {
+		case "int32":
+			return "int32"
+		case "uint32":
+			return "uint32"
+		case "int64":
+			return "int64"
+		case "uint64":
+			return "uint64"
+		}
+		return "int"
+	case "number":
+		if s.str("format") == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "array":
+		items, _ := s["items"].(map[string]interface{})
+		return "[]" + g.typeOf(items, hint+"Item")
+	}
+	if s.isObject() {
+		g.define(hint, s)
+		return "*" + hint
+	}
+	if values, ok := s["additionalProperties"].(map[string]interface{}); ok {
+		return "map[string]" + g.typeOf(values, hint+"Value")
+	}
+	if s.str("type") == "object" {
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// writeOperation renders the parameter struct and client method of an operation
+func (g *goGenerator) writeOperation(b *strings.Builder, op operation) {
+	paramsType := op.Name + "Params"
+	fmt.Fprintf(b, "\n// %s holds the parameters of %s\ntype %s struct {\n", paramsType, op.Name, paramsType)
+	for _, param := range append(append([]parameter{}, op.PathParams...), op.QueryParams...) {
+		if doc := param.Schema.doc(); doc != "" {
+			fmt.Fprintf(b, "\t// %s\n", doc)
+		}
+		fmt.Fprintf(b, "\t%s %s\n", param.Field, g.typeOf(param.Schema, op.Name+param.Field))
+	}
+	if op.Body != nil {
+		body := g.typeOf(op.Body, op.Name+"Body")
+		if !strings.HasPrefix(body, "*") && !strings.HasPrefix(body, "[]") && !strings.HasPrefix(body, "map[") && body != "interface{}" {
+			body = "*" + body
+		}
+		fmt.Fprintf(b, "\tBody %s\n", body)
+	}
+	b.WriteString("}\n\n")
+
+	summary := op.Method + " " + op.Path
+	if op.Summary != "" {
+		summary += ": " + firstLine(op.Summary)
+	}
+	response := ""
+	if op.Response != nil {
+		response = g.typeOf(op.Response, op.Name+"Response")
+	}
+	if response == "" {
+		fmt.Fprintf(b, "// %s calls %s\nfunc (c *Client) %s(ctx context.Context, params %s) error {\n", op.Name, summary, op.Name, paramsType)
+	} else {
+		fmt.Fprintf(b, "// %s calls %s\nfunc (c *Client) %s(ctx context.Context, params %s) (%s, error) {\n", op.Name, summary, op.Name, paramsType, response)
+	}
+
+	fmt.Fprintf(b, "\tpath := %q\n", op.Path)
+	placeholders := pathPlaceholders(op.Path)
+	for _, param := range op.PathParams {
+		placeholder, ok := placeholders[param.Name]
+		if !ok {
+			continue
+		}
+		value := fmt.Sprintf("fmt.Sprint(params.%s)", param.Field)
+		// Patterns such as {name=users/*} span several segments, so their slashes are kept
+		if !strings.Contains(placeholder, "=") {
+			value = "url.PathEscape(" + value + ")"
+		}
+		fmt.Fprintf(b, "\tpath = strings.Replace(path, %q, %s, 1)\n", placeholder, value)
+	}
+	b.WriteString("\tquery := url.Values{}\n")
+	for _, param := range op.QueryParams {
+		if strings.HasPrefix(g.typeOf(param.Schema, op.Name+param.Field), "[]") {
+			fmt.Fprintf(b, "\tfor _, value := range params.%s {\n\t\tquery.Add(%q, fmt.Sprint(value))\n\t}\n", param.Field, param.Name)
+			continue
+		}
+		fmt.Fprintf(b, "\tif !isZero(params.%s) {\n\t\tquery.Set(%q, fmt.Sprint(params.%s))\n\t}\n", param.Field, param.Name, param.Field)
+	}
+	body := "nil"
+	if op.Body != nil {
+		body = "params.Body"
+	}
+
+	switch {
+	case response == "":
+		fmt.Fprintf(b, "\treturn c.do(ctx, %s, path, query, %s, nil)\n}\n", strconv.Quote(op.Method), body)
+	case strings.HasPrefix(response, "*"):
+		fmt.Fprintf(b, "\tvar out %s\n\tif err := c.do(ctx, %s, path, query, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &out, nil\n}\n",
+			strings.TrimPrefix(response, "*"), strconv.Quote(op.Method), body)
+	default:
+		fmt.Fprintf(b, "\tvar out %s\n\terr := c.do(ctx, %s, path, query, %s, &out)\n\treturn out, err\n}\n", response, strconv.Quote(op.Method), body)
+	}
+}
+
+// goClientRuntime is the hand-written part of client.go; the package name and version are filled in
+const goClientRuntime = goHeader + `
+package %s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Version is the SDK version, which changes whenever the API definition does
+const Version = %q
+
+// Client calls the API through the gateway
+type Client struct {
+	BaseURL    string       // Gateway address, e.g. https://api.example.com
+	HTTPClient *http.Client // Defaults to http.DefaultClient
+	Token      string       // Sent as a bearer token when set
+	Header     http.Header  // Added to every request
+}
+
+// NewClient returns a client for the gateway at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient, Header: http.Header{}}
+}
+
+// APIError is returned for responses with a non-2xx status
+type APIError struct {
+	StatusCode int    ` + "`json:\"-\"`" + `
+	Code       int    ` + "`json:\"code\"`" + `
+	Message    string ` + "`json:\"message\"`" + `
+	RequestID  string ` + "`json:\"request_id\"`" + `
+	Body       []byte ` + "`json:\"-\"`" + `
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("api error %%d: %%s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("api error %%d", e.StatusCode)
+}
+
+// do sends a request with an optional JSON body and decodes a JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if !isZero(body) {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: data}
+		_ = json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// isZero reports whether a query parameter or body was left unset
+func isZero(value interface{}) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
+`
@@ -0,0 +1,377 @@
+// Package sdk generates typed client SDKs from the gateway's merged swagger (OpenAPI 2.0) definition.
+package sdk
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Supported SDK languages
+const (
+	LanguageGo         = "go"
+	LanguageTypeScript = "typescript"
+)
+
+// Options customises the generated SDKs
+type Options struct {
+	Version    string // SDK version; derived from info.version and a hash of the definition when empty
+	GoModule   string // Module path of the Go SDK
+	NPMPackage string // Package name of the TypeScript SDK
+}
+
+// DefaultOptions returns the options used when none are configured
+func DefaultOptions() Options {
+	return Options{
+		GoModule:   "golang-microservices-boilerplate/sdk",
+		NPMPackage: "golang-microservices-boilerplate-sdk",
+	}
+}
+
+// SDK holds the generated source files of every language
+type SDK struct {
+	Version string
+	files   map[string]map[string][]byte // language -> file name -> contents
+}
+
+// Generate builds the Go and TypeScript SDKs for a swagger definition
+func Generate(spec map[string]interface{}, opts Options) (*SDK, error) {
+	defaults := DefaultOptions()
+	if opts.GoModule == "" {
+		opts.GoModule = defaults.GoModule
+	}
+	if opts.NPMPackage == "" {
+		opts.NPMPackage = defaults.NPMPackage
+	}
+	if opts.Version == "" {
+		version, err := specVersion(spec)
+		if err != nil {
+			return nil, err
+		}
+		opts.Version = version
+	}
+
+	api := parseSpec(spec)
+	goFiles, err := generateGo(api, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Go SDK: %w", err)
+	}
+	return &SDK{
+		Version: opts.Version,
+		files: map[string]map[string][]byte{
+			LanguageGo:         goFiles,
+			LanguageTypeScript: generateTypeScript(api, opts),
+		},
+	}, nil
+}
+
+// Languages returns the generated languages, sorted
+func (s *SDK) Languages() []string {
+	languages := make([]string, 0, len(s.files))
+	for language := range s.files {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Files returns the generated files of a language, keyed by their path inside the SDK
+func (s *SDK) Files(language string) (map[string][]byte, bool) {
+	files, ok := s.files[language]
+	return files, ok
+}
+
+// ArchiveName returns the file name of a language's tarball, e.g. go-sdk-1.0.0-0123456789ab.tar.gz
+func (s *SDK) ArchiveName(language string) string {
+	return s.archiveRoot(language) + ".tar.gz"
+}
+
+func (s *SDK) archiveRoot(language string) string {
+	return language + "-sdk-" + s.Version
+}
+
+// Tarball packs a language's files into a gzipped tar under a "<language>-sdk-<version>/" directory
+func (s *SDK) Tarball(language string) ([]byte, error) {
+	files, ok := s.files[language]
+	if !ok {
+		return nil, fmt.Errorf("unknown SDK language %q", language)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	// A fixed modification time keeps the tarball identical for the same definition
+	modTime := time.Unix(0, 0)
+	for _, name := range names {
+		header := &tar.Header{Name: s.archiveRoot(language) + "/" + name, Mode: 0o644, Size: int64(len(files[name])), ModTime: modTime}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// specVersion turns info.version into a semantic version and appends a hash of the definition, so
+// every change of the API produces a new SDK version
+func specVersion(spec map[string]interface{}) (string, error) {
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+
+	base := "0.0.0"
+	if info, ok := spec["info"].(map[string]interface{}); ok {
+		if version, ok := info["version"].(string); ok {
+			parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+			if len(parts) <= 3 && allNumeric(parts) {
+				for len(parts) < 3 {
+					parts = append(parts, "0")
+				}
+				base = strings.Join(parts, ".")
+			}
+		}
+	}
+	return base + "-" + hex.EncodeToString(sum[:6]), nil
+}
+
+func allNumeric(parts []string) bool {
+	for _, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// api is the part of a swagger definition the generators need
+type api struct {
+	Title       string
+	Definitions map[string]schema
+	Operations  []operation
+}
+
+// schema is a swagger schema object, or a non-body parameter (which carries type, format and items itself)
+type schema map[string]interface{}
+
+// operation is one HTTP method of a path
+type operation struct {
+	Name        string // Exported identifier derived from the operationId
+	Method      string // Upper-case HTTP method
+	Path        string // Path template, e.g. /api/v1/users/{id}
+	Summary     string
+	PathParams  []parameter
+	QueryParams []parameter
+	Body        schema // nil when the operation has no body
+	Response    schema // Schema of the 200 response; nil when it has none
+}
+
+// parameter is a path or query parameter
+type parameter struct {
+	Name     string // Name on the wire, e.g. options.limit
+	Field    string // Exported identifier, e.g. OptionsLimit
+	Required bool
+	Schema   schema
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// parseSpec collects the definitions and operations, sorted by path and method
+func parseSpec(spec map[string]interface{}) api {
+	result := api{Definitions: make(map[string]schema)}
+	if info, ok := spec["info"].(map[string]interface{}); ok {
+		result.Title, _ = info["title"].(string)
+	}
+	if definitions, ok := spec["definitions"].(map[string]interface{}); ok {
+		for name, def := range definitions {
+			if s, ok := def.(map[string]interface{}); ok {
+				result.Definitions[name] = s
+			}
+		}
+	}
+
+	paths, _ := spec["paths"].(map[string]interface{})
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	usedNames := make(map[string]bool)
+	for _, path := range pathNames {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			raw, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			op := operation{Method: strings.ToUpper(method), Path: path}
+			op.Summary, _ = raw["summary"].(string)
+
+			id, _ := raw["operationId"].(string)
+			if id == "" {
+				id = method + " " + path
+			}
+			op.Name = exportedName(id)
+			for base, i := op.Name, 2; usedNames[op.Name]; i++ {
+				op.Name = fmt.Sprintf("%s%d", base, i)
+			}
+			usedNames[op.Name] = true
+
+			params, _ := raw["parameters"].([]interface{})
+			for _, p := range params {
+				param, ok := p.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := param["name"].(string)
+				required, _ := param["required"].(bool)
+				switch param["in"] {
+				case "path":
+					op.PathParams = append(op.PathParams, parameter{Name: name, Field: exportedName(name), Required: true, Schema: param})
+				case "query":
+					op.QueryParams = append(op.QueryParams, parameter{Name: name, Field: exportedName(name), Required: required, Schema: param})
+				case "body":
+					op.Body, _ = param["schema"].(map[string]interface{})
+				}
+			}
+
+			if responses, ok := raw["responses"].(map[string]interface{}); ok {
+				if ok200, ok := responses["200"].(map[string]interface{}); ok {
+					op.Response, _ = ok200["schema"].(map[string]interface{})
+				}
+			}
+			result.Operations = append(result.Operations, op)
+		}
+	}
+	return result
+}
+
+// sortedDefinitionNames returns the definition names in a stable order
+func (a api) sortedDefinitionNames() []string {
+	names := make([]string, 0, len(a.Definitions))
+	for name := range a.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedProperties returns the property names of an object schema in a stable order
+func (s schema) sortedProperties() []string {
+	props, _ := s["properties"].(map[string]interface{})
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// property returns the schema of a property
+func (s schema) property(name string) schema {
+	props, _ := s["properties"].(map[string]interface{})
+	prop, _ := props[name].(map[string]interface{})
+	return prop
+}
+
+// ref returns the definition name a "$ref" points at, or ""
+func (s schema) ref() string {
+	ref, _ := s["$ref"].(string)
+	return strings.TrimPrefix(ref, "#/definitions/")
+}
+
+func (s schema) str(key string) string {
+	value, _ := s[key].(string)
+	return value
+}
+
+// enum returns the string values of an enum schema
+func (s schema) enum() []string {
+	raw, _ := s["enum"].([]interface{})
+	values := make([]string, 0, len(raw))
+	for _, value := range raw {
+		if str, ok := value.(string); ok {
+			values = append(values, str)
+		}
+	}
+	return values
+}
+
+// isObject reports whether a schema has properties of its own
+func (s schema) isObject() bool {
+	props, ok := s["properties"].(map[string]interface{})
+	return ok && len(props) > 0
+}
+
+// doc returns the first line of a schema's description, or its title
+func (s schema) doc() string {
+	text := s.str("description")
+	if text == "" {
+		text = s.str("title")
+	}
+	return firstLine(text)
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// exportedName turns a definition, operation or parameter name into an exported identifier,
+// e.g. "UserService_List" -> "UserServiceList", "options.sortBy" -> "OptionsSortBy"
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range nonAlphanumeric.Split(name, -1) {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	result := b.String()
+	if result == "" || (result[0] >= '0' && result[0] <= '9') {
+		result = "X" + result
+	}
+	return result
+}
+
+// unexportedName is exportedName with a lower-case first letter
+func unexportedName(name string) string {
+	exported := exportedName(name)
+	return strings.ToLower(exported[:1]) + exported[1:]
+}
+
+var pathPlaceholder = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// pathPlaceholders maps each path parameter to its placeholder in the template, e.g. "name" -> "{name=users/*}"
+func pathPlaceholders(path string) map[string]string {
+	placeholders := make(map[string]string)
+	for _, match := range pathPlaceholder.FindAllStringSubmatch(path, -1) {
+		placeholders[match[1]] = match[0]
+	}
+	return placeholders
+}
@@ -0,0 +1,273 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tsGenerator renders the TypeScript SDK; inline object schemas are hoisted into named interfaces
+type tsGenerator struct {
+	defined map[string]bool
+	types   map[string]string // TypeScript type name -> declaration
+}
+
+// generateTypeScript returns the files of the TypeScript SDK: package.json and index.ts
+func generateTypeScript(a api, opts Options) map[string][]byte {
+	g := &tsGenerator{defined: make(map[string]bool), types: make(map[string]string)}
+	for _, name := range a.sortedDefinitionNames() {
+		g.define(exportedName(name), a.Definitions[name])
+	}
+
+	var methods strings.Builder
+	var params strings.Builder
+	for _, op := range a.Operations {
+		g.writeOperation(&params, &methods, op)
+	}
+
+	var b strings.Builder
+	b.WriteString(tsHeader)
+	fmt.Fprintf(&b, "\n/** SDK version, which changes whenever the API definition does */\nexport const VERSION = %q;\n", opts.Version)
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString("\n" + g.types[name])
+	}
+	b.WriteString(params.String())
+	b.WriteString(tsClientRuntime)
+	b.WriteString(methods.String())
+	b.WriteString("}\n")
+
+	pkg, _ := json.MarshalIndent(map[string]interface{}{
+		"name":        opts.NPMPackage,
+		"version":     opts.Version,
+		"description": "Typed client for " + a.Title,
+		"main":        "index.ts",
+		"types":       "index.ts",
+	}, "", "  ")
+	return map[string][]byte{
+		"package.json": append(pkg, '\n'),
+		"index.ts":     []byte(b.String()),
+	}
+}
+
+const tsHeader = "// Code generated by the api-gateway SDK generator. DO NOT EDIT.\n"
+
+// define renders a named interface or type alias for a schema
+func (g *tsGenerator) define(name string, s schema) {
+	if g.defined[name] {
+		return
+	}
+	g.defined[name] = true
+
+	var b strings.Builder
+	if doc := s.doc(); doc != "" {
+		fmt.Fprintf(&b, "/** %s */\n", tsComment(doc))
+	}
+	switch {
+	case len(s.enum()) > 0:
+		values := make([]string, 0, len(s.enum()))
+		for _, value := range s.enum() {
+			values = append(values, fmt.Sprintf("%q", value))
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n", name, strings.Join(values, " | "))
+	case s.isObject():
+		fmt.Fprintf(&b, "export interface %s {\n", name)
+		for _, prop := range s.sortedProperties() {
+			propSchema := s.property(prop)
+			if doc := propSchema.doc(); doc != "" {
+				fmt.Fprintf(&b, "  /** %s */\n", tsComment(doc))
+			}
+			fmt.Fprintf(&b, "  %s?: %s;\n", tsPropertyName(prop), g.typeOf(propSchema, name+exportedName(prop)))
+		}
+		b.WriteString("}\n")
+	default:
+		fmt.Fprintf(&b, "export type %s = %s;\n", name, g.typeOf(s, name+"Value"))
+	}
+	g.types[name] = b.String()
+}
+
+// typeOf returns the TypeScript type of a schema. Objects with properties become interfaces called hint.
+func (g *tsGenerator) typeOf(s schema, hint string) string {
+	if ref := s.ref(); ref != "" {
+		return exportedName(ref)
+	}
+	switch s.str("type") {
+	case "string":
+		return "string"
+	case "boolean":
+		return "boolean"
+	case "integer", "number":
+		return "number"
+	case "array":
+		items, _ := s["items"].(map[string]interface{})
+		item := g.typeOf(items, hint+"Item")
+		if strings.ContainsAny(item, " |") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	}
+	if s.isObject() {
+		g.define(hint, s)
+		return hint
+	}
+	if values, ok := s["additionalProperties"].(map[string]interface{}); ok {
+		return "Record<string, " + g.typeOf(values, hint+"Value") + ">"
+	}
+	if s.str("type") == "object" {
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// tsPropertyName quotes property names that are not valid identifiers, e.g. "@type"
+func tsPropertyName(name string) string {
+	if name != "" && nonAlphanumeric.FindString(name) == "" && (name[0] < '0' || name[0] > '9') {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// tsComment keeps text from closing the doc comment it is written into
+func tsComment(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// writeOperation renders the parameter interface of an operation into params and its method into methods
+func (g *tsGenerator) writeOperation(params, methods *strings.Builder, op operation) {
+	paramsType := op.Name + "Params"
+	fmt.Fprintf(params, "\n/** Parameters of %s */\nexport interface %s {\n", unexportedName(op.Name), paramsType)
+	required := false
+	for _, param := range append(append([]parameter{}, op.PathParams...), op.QueryParams...) {
+		if doc := param.Schema.doc(); doc != "" {
+			fmt.Fprintf(params, "  /** %s */\n", tsComment(doc))
+		}
+		optional := "?"
+		if param.Required {
+			optional, required = "", true
+		}
+		fmt.Fprintf(params, "  %s%s: %s;\n", unexportedName(param.Field), optional, g.typeOf(param.Schema, op.Name+param.Field))
+	}
+	if op.Body != nil {
+		fmt.Fprintf(params, "  body?: %s;\n", g.typeOf(op.Body, op.Name+"Body"))
+	}
+	params.WriteString("}\n")
+
+	response := "void"
+	if op.Response != nil {
+		response = g.typeOf(op.Response, op.Name+"Response")
+	}
+	summary := op.Method + " " + op.Path
+	if op.Summary != "" {
+		summary += ": " + firstLine(op.Summary)
+	}
+	defaultParams := " = {}"
+	if required {
+		defaultParams = ""
+	}
+	fmt.Fprintf(methods, "\n  /** Calls %s */\n  async %s(params: %s%s): Promise<%s> {\n", tsComment(summary), unexportedName(op.Name), paramsType, defaultParams, response)
+
+	fmt.Fprintf(methods, "    let path = %q;\n", op.Path)
+	placeholders := pathPlaceholders(op.Path)
+	for _, param := range op.PathParams {
+		placeholder, ok := placeholders[param.Name]
+		if !ok {
+			continue
+		}
+		value := "String(params." + unexportedName(param.Field) + ")"
+		// Patterns such as {name=users/*} span several segments, so their slashes are kept
+		if !strings.Contains(placeholder, "=") {
+			value = "encodeURIComponent(" + value + ")"
+		}
+		fmt.Fprintf(methods, "    path = path.replace(%q, %s);\n", placeholder, value)
+	}
+	methods.WriteString("    const query: Record<string, unknown> = {")
+	for i, param := range op.QueryParams {
+		if i > 0 {
+			methods.WriteString(",")
+		}
+		fmt.Fprintf(methods, "\n      %q: params.%s", param.Name, unexportedName(param.Field))
+	}
+	if len(op.QueryParams) > 0 {
+		methods.WriteString(",\n    ")
+	}
+	methods.WriteString("};\n")
+	body := "undefined"
+	if op.Body != nil {
+		body = "params.body"
+	}
+	fmt.Fprintf(methods, "    return this.request<%s>(%q, path, query, %s);\n  }\n", response, op.Method, body)
+}
+
+// tsClientRuntime is the hand-written part of index.ts; the generated methods follow it inside Client
+const tsClientRuntime = `
+/** Error thrown for responses with a non-2xx status */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    message: string,
+    readonly code?: number,
+    readonly requestId?: string,
+    readonly body?: unknown,
+  ) {
+    super(message);
+    this.name = "ApiError";
+  }
+}
+
+/** Options of a Client */
+export interface ClientOptions {
+  /** Sent as a bearer token when set */
+  token?: string;
+  /** Added to every request */
+  headers?: Record<string, string>;
+  /** Defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+/** Calls the API through the gateway */
+export class Client {
+  constructor(
+    private readonly baseUrl: string,
+    private readonly options: ClientOptions = {},
+  ) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+  }
+
+  private async request<T>(method: string, path: string, query: Record<string, unknown>, body?: unknown): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(query)) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      for (const item of Array.isArray(value) ? value : [value]) {
+        search.append(key, String(item));
+      }
+    }
+    const qs = search.toString();
+    const headers: Record<string, string> = { Accept: "application/json", ...this.options.headers };
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+    }
+    if (this.options.token) {
+      headers.Authorization = "Bearer " + this.options.token;
+    }
+
+    const doFetch = this.options.fetch ?? fetch;
+    const response = await doFetch(this.baseUrl + path + (qs ? "?" + qs : ""), {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const text = await response.text();
+    const data = text ? JSON.parse(text) : undefined;
+    if (!response.ok) {
+      throw new ApiError(response.status, data?.message ?? response.statusText, data?.code, data?.request_id, data);
+    }
+    return data as T;
+  }
+`
@@ -1,10 +1,8 @@
 package main
 
 import (
+	"context"
 	"log"
-	"os"
-	"os/signal"
-	"syscall"
 
 	"golang-microservices-boilerplate/pkg/utils"
 )
@@ -16,23 +14,14 @@ func main() {
 	}
 
 	// Setup all services
-	grpcServer, err := SetupServices()
+	ctx := context.Background()
+	lc, err := SetupServices(ctx)
 	if err != nil {
 		log.Fatalf("Failed to setup services: %v", err)
 	}
 
-	// Start gRPC server
-	if err := grpcServer.Start(); err != nil {
-		log.Fatalf("Failed to start gRPC server: %v", err)
+	// Run the gRPC server and workers until SIGINT/SIGTERM, then stop them within SHUTDOWN_TIMEOUT
+	if err := lc.Run(ctx); err != nil {
+		log.Fatalf("User service stopped with errors: %v", err)
 	}
-	log.Printf("gRPC server started successfully at %s:%s\n", grpcServer.Config.Host, grpcServer.Config.Port)
-
-	// Wait for termination signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-	<-quit
-
-	log.Println("Shutting down server...")
-	grpcServer.Stop()
-	log.Println("Server gracefully stopped")
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"path/filepath"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/database/seed"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+	entity "golang-microservices-boilerplate/services/user-service/internal/entity"
+)
+
+// Seeds the user service database with the "common" seed set plus the set for the chosen environment.
+//
+//	go run ./services/user-service/cmd/seed -env dev
+func main() {
+	if err := utils.LoadEnv(); err != nil {
+		log.Printf("Warning: .env file not found, using environment variables")
+	}
+
+	env := flag.String("env", utils.GetEnv("SEED_ENV", "dev"), "seed set to apply (dev, staging, demo)")
+	dir := flag.String("dir", utils.GetEnv("SEED_DIR", "services/user-service/seeds"), "directory containing the seed sets")
+	flag.Parse()
+
+	logConfig := logger.LoadLogConfigFromEnv()
+	logConfig.AppName = "User Service Seeder"
+	appLogger, err := logger.NewLogger(logConfig)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+
+	if err := database.UseFieldCipherFromEnv(appLogger); err != nil {
+		appLogger.Fatal("Failed to load field encryption keys", "error", err)
+	}
+
+	db, err := database.NewDatabaseConnection(database.DefaultDBConfig())
+	if err != nil {
+		appLogger.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	if err := db.MigrateModels(&entity.User{}, &entity.SecurityEvent{}); err != nil {
+		appLogger.Fatal("Failed to auto-migrate models", "error", err)
+	}
+
+	fixtures, err := seed.LoadDirs(filepath.Join(*dir, "common"), filepath.Join(*dir, *env))
+	if err != nil {
+		appLogger.Fatal("Failed to load seed fixtures", "error", err)
+	}
+
+	seeder := seed.NewSeeder(db.DB, appLogger)
+	seeder.Register("users", func() interface{} { return &entity.User{} })
+
+	if err := seeder.Run(context.Background(), fixtures); err != nil {
+		appLogger.Fatal("Seeding failed", "error", err)
+	}
+	appLogger.Info("Seeding completed", "env", *env, "fixtures", len(fixtures))
+}
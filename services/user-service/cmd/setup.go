@@ -1,21 +1,43 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 	"time"
 
+	"golang-microservices-boilerplate/pkg/core/authz"
+	"golang-microservices-boilerplate/pkg/core/blob"
+	"golang-microservices-boilerplate/pkg/core/bootstrap"
+	"golang-microservices-boilerplate/pkg/core/cache"
 	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/deadletter"
+	"golang-microservices-boilerplate/pkg/core/diagnostics"
+	"golang-microservices-boilerplate/pkg/core/events"
 	"golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/jobs"
+	"golang-microservices-boilerplate/pkg/core/leaderelection"
+	"golang-microservices-boilerplate/pkg/core/lifecycle"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	"golang-microservices-boilerplate/pkg/core/preflight"
+	"golang-microservices-boilerplate/pkg/core/quota"
+	"golang-microservices-boilerplate/pkg/core/report"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/watchdog"
+	"golang-microservices-boilerplate/pkg/middleware"
 	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/pkg/webhooks"
 	controller "golang-microservices-boilerplate/services/user-service/internal/controller"
 	entity "golang-microservices-boilerplate/services/user-service/internal/entity"
 	"golang-microservices-boilerplate/services/user-service/internal/repository"
 	"golang-microservices-boilerplate/services/user-service/internal/usecase"
 )
 
-// SetupServices initializes all the services needed by the application
-func SetupServices() (*grpc.BaseGrpcServer, error) {
+// SetupServices initializes all the services needed by the application and registers the
+// long-lived ones with the returned lifecycle manager; Run starts them and stops them in order.
+// The probes are already serving /live and /ready when it returns.
+func SetupServices(ctx context.Context) (*lifecycle.Manager, error) {
 	// Initialize logger
 	logConfig := logger.LoadLogConfigFromEnv()
 	logConfig.AppName = utils.GetEnv("SERVER_APP_NAME", "User Service")
@@ -25,40 +47,266 @@ func SetupServices() (*grpc.BaseGrpcServer, error) {
 	}
 
 	appLogger.Info("Setting up user service")
+	lc := lifecycle.New(appLogger)
 
-	// Initialize database connection
-	db, err := database.NewDatabaseConnection(database.DefaultDBConfig())
+	// Report every configuration problem at once, before anything is started
+	dbConfig := database.DefaultDBConfig()
+	serverConfig := grpc.DefaultGrpcServerConfig()
+	diagnosticsConfig := diagnostics.LoadConfigFromEnv()
+	var deleteCascade []core_repo.CascadeRule
+	checks := preflight.New(appLogger).
+		Check("jwt-secrets", preflight.JWTSecrets).
+		Check("permissions", permissions.UseFromEnv).
+		Check("authz", authz.UseFromEnv).
+		Check("delete-cascade", func(ctx context.Context) (err error) {
+			deleteCascade, err = core_repo.ParseCascadeRules(utils.GetEnv("USER_DELETE_CASCADE", repository.DefaultDeleteCascade))
+			return err
+		}).
+		Check("field-encryption", bootstrap.FieldEncryptionCheck).
+		Check("database", bootstrap.DatabaseCheck(dbConfig)).
+		Check("grpc-port", preflight.PortAvailable(net.JoinHostPort(serverConfig.Host, serverConfig.Port))).
+		Check("health-port", preflight.PortAvailable(":"+utils.GetEnv("HEALTH_PORT", "8081")))
+	if diagnosticsConfig.Enabled {
+		checks.Check("diagnostics-port", preflight.PortAvailable(diagnosticsConfig.Addr))
+	}
+	if err := checks.Run(ctx); err != nil {
+		return nil, err
+	}
+
+	// Serve liveness right away so the pod is not restarted while waiting for dependencies
+	probes := bootstrap.NewProbesFromEnv(appLogger)
+	probes.Start()
+	lc.Add(lifecycle.Component{Name: "probes", Stop: probes.Shutdown})
+	lc.Add(lifecycle.Component{Name: "diagnostics", Start: func(ctx context.Context) error {
+		return diagnostics.Serve(ctx, diagnosticsConfig, appLogger)
+	}})
+
+	// Sensitive user columns are encrypted at rest; production refuses to start without real keys
+	if err := database.UseFieldCipherFromEnv(appLogger); err != nil {
+		return nil, err
+	}
+
+	var db *database.DatabaseConnection
+	err = bootstrap.NewRunner(appLogger).
+		Phase("database", func(ctx context.Context) error {
+			db, err = bootstrap.ConnectDatabase(ctx, appLogger, dbConfig)
+			return err
+		}).
+		Phase("migrations", func(ctx context.Context) error {
+			mode, err := database.MigrationModeFromEnv()
+			if err != nil {
+				return err
+			}
+			database.RegisterModels(&entity.User{}, &entity.SecurityEvent{}, &entity.ErasureTombstone{}, &entity.DataExport{}, &entity.Report{},
+				&entity.Organization{}, &entity.Membership{}, &entity.Invitation{}, &entity.UserHistory{})
+			database.RegisterModels(jobs.Models()...)
+			database.RegisterModels(webhooks.Models()...)
+			database.RegisterModels(quota.Models()...)
+			database.RegisterModels(deadletter.Models()...)
+			database.RegisterModels(core_repo.CascadeModels()...)
+			diff, err := db.SyncRegisteredModels(mode)
+			if err != nil {
+				return err
+			}
+			if diff.Empty() {
+				return nil
+			}
+			// Drift is reported, not fixed: production schemas are changed by reviewed migrations
+			for _, change := range diff.Changes {
+				appLogger.Warn("Schema drift detected", "change", change.String())
+			}
+			return nil
+		}).
+		Phase("encryption", func(ctx context.Context) error {
+			// Encrypts rows written before the columns were encrypted and moves values to the active key
+			if utils.GetEnv("FIELD_ENCRYPTION_REENCRYPT", "false") != "true" {
+				return nil
+			}
+			rewritten, err := database.ReencryptColumns(ctx, db.DB, &entity.User{}, utils.GetEnvAsInt("FIELD_ENCRYPTION_BATCH_SIZE", 500))
+			if err != nil {
+				return err
+			}
+			history, err := database.ReencryptColumns(ctx, db.DB, &entity.UserHistory{}, utils.GetEnvAsInt("FIELD_ENCRYPTION_BATCH_SIZE", 500))
+			if err != nil {
+				return err
+			}
+			appLogger.Info("Re-encrypted user columns", "values", rewritten, "history_values", history)
+			return nil
+		}).
+		Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	probes.AddReadinessCheck("database", db.PingContext)
+
+	queryMetrics, err := db.UseQueryMetrics(database.LoadQueryMetricsConfigFromEnv(), appLogger)
 	if err != nil {
-		appLogger.Error("Failed to connect to database", "error", err)
 		return nil, err
 	}
-	appLogger.Info("Connected to database")
+	slowRequests := watchdog.NewFromEnv(appLogger)
 
-	// Auto migrate models
-	if err := db.MigrateModels(&entity.User{}); err != nil {
-		appLogger.Error("Failed to auto-migrate models", "error", err)
+	// Singleton maintenance runs on the replica holding the lease, or on every replica when
+	// election is disabled
+	quotas := quota.NewManager(quota.NewGormStore(db.DB), appLogger)
+	elector, err := leaderelection.New(leaderelection.LoadConfigFromEnv("user-service-maintenance"), leaderelection.Callbacks{
+		OnStartedLeading: func(ctx context.Context) {
+			quotas.RunPruner(ctx, utils.GetEnvDuration("QUOTA_PRUNE_INTERVAL", time.Hour))
+		},
+	}, appLogger)
+	if err != nil {
 		return nil, err
 	}
+	lc.Go("leader-election", elector.Run)
+	probes.AddLivenessCheck("leader-election", func(context.Context) error { return elector.Check() })
+	probes.Handle("/metrics", bootstrap.MetricsHandler(queryMetrics, slowRequests.Metrics(), elector))
+	explainConfig := core_repo.LoadExplainConfigFromEnv()
+	core_repo.SetExplainer(core_repo.NewExplainer(explainConfig, appLogger))
 
 	// Initialize repositories
-	userRepo := repository.NewUserRepository(db.DB)
+	userRepo := repository.NewUserRepository(db.DB, deleteCascade...)
+	securityEventRepo := repository.NewSecurityEventRepository(db.DB)
+	userHistoryRepo := repository.NewUserHistoryRepository(db.DB)
+	webhookSubscriptionRepo := webhooks.NewSubscriptionRepository(db.DB)
+	webhookDeliveryRepo := webhooks.NewDeliveryRepository(db.DB)
+	dataExportRepo := repository.NewDataExportRepository(db.DB)
+	reportRepo := repository.NewReportRepository(db.DB)
+	organizationRepo := repository.NewOrganizationRepository(db.DB)
+	membershipRepo := repository.NewMembershipRepository(db.DB)
+	invitationRepo := repository.NewInvitationRepository(db.DB)
+	jobRepo := jobs.NewRepository(db.DB)
+	deadLetterRepo := deadletter.NewRepository(db.DB)
+
+	// Domain events are turned into webhook deliveries
+	eventBus := events.NewInMemoryBus(appLogger)
+	// Published events are checked against their versioned payload schemas
+	schemaMode, err := events.SchemaModeFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	eventSchemas := events.NewSchemaRegistry()
+	if err := eventSchemas.Register(events.CoreSchemas()...); err != nil {
+		return nil, err
+	}
+	if err := usecase.RegisterEventSchemas(eventSchemas); err != nil {
+		return nil, err
+	}
+	eventBus.UseSchemas(eventSchemas, schemaMode)
+	// Events a named consumer such as the webhook dispatcher fails to handle are kept for replay
+	deadLetters := deadletter.NewService(deadLetterRepo, eventBus, appLogger)
+	deadLetters.Attach(eventBus)
+	webhooks.NewDispatcher(webhookSubscriptionRepo, webhookDeliveryRepo, appLogger).Attach(eventBus)
+	// ... and streamed to the gateway's change feed
+	changeFeed := events.NewFeed(utils.GetEnvAsInt("EVENT_FEED_BUFFER", 1000))
+	changeFeed.Attach(eventBus)
+	// Committed changes of the users table are published as user.changed events for read models,
+	// and recorded as versions in user_history for point-in-time queries
+	changeEvents := utils.GetEnv("USER_CHANGE_EVENTS_ENABLED", "true") == "true"
+	userHistory := utils.GetEnv("USER_HISTORY_ENABLED", "true") == "true"
+	if changeEvents || userHistory {
+		var publisher events.Publisher // Nil records history only
+		if changeEvents {
+			publisher = eventBus
+		}
+		capture := repository.NewUserChangeCapture(publisher, appLogger)
+		if userHistory {
+			capture.WithHistory()
+		}
+		if err := db.DB.Use(capture); err != nil {
+			return nil, err
+		}
+	}
+	if utils.GetEnv("WEBHOOK_WORKER_ENABLED", "true") == "true" {
+		worker := webhooks.NewWorker(webhookSubscriptionRepo, webhookDeliveryRepo, webhooks.LoadConfigFromEnv(), appLogger)
+		lc.Go("webhook-worker", worker.Run)
+	}
+
+	// Revoked refresh tokens are kept in the shared cache so every replica rejects them
+	tokenCache, err := cache.NewFromConfig(cache.LoadConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	revokedTokens := cache.NewTokenBlacklist(tokenCache)
+	middleware.SetRevocationList(revokedTokens)
 
 	// Token generation durations
 	accessTokenDuration := 7 * 24 * time.Hour   // Example: 7 days
 	refreshTokenDuration := 30 * 24 * time.Hour // Example: 30 days
 
-	// Initialize use cases with all required arguments
-	userUseCase := usecase.NewUserUseCase(userRepo, appLogger, &accessTokenDuration, &refreshTokenDuration)
+	// Quotas; a limit of 0 leaves the quota unlimited but still reported
+	quotas.Define(quota.Definition{
+		Name:  usecase.QuotaUsers,
+		Limit: int64(utils.GetEnvAsInt("USER_QUOTA_MAX_USERS", 0)),
+		Soft:  int64(utils.GetEnvAsInt("USER_QUOTA_SOFT_USERS", 0)),
+		Count: func(ctx context.Context, _ string) (int64, error) {
+			return userRepo.Count(ctx, nil)
+		},
+	})
+
+	// Initialize use cases with all required arguments; invite links reach users as notifications
+	notifier := events.NewPublisherNotifier(eventBus)
+	invitations := usecase.LoadInvitationConfigFromEnv(invitationRepo, notifier)
+	userUseCase := usecase.NewUserUseCase(userRepo, securityEventRepo, userHistoryRepo, membershipRepo, appLogger, &accessTokenDuration, &refreshTokenDuration, eventBus, revokedTokens, quotas, invitations)
+	organizationUseCase := usecase.NewOrganizationUseCase(organizationRepo, membershipRepo, userRepo, appLogger)
+	webhookService := webhooks.NewService(webhookSubscriptionRepo, webhookDeliveryRepo, appLogger)
+
+	// Data exports and reports are generated by background jobs into the blob store
+	blobStore, err := blob.NewFromConfig(blob.LoadConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	jobQueue := jobs.NewQueue(jobRepo)
+	dataExportUseCase := usecase.NewDataExportUseCase(dataExportRepo, userRepo, securityEventRepo, jobQueue, blobStore,
+		notifier, utils.GetEnvDuration("DATA_EXPORT_TTL", 7*24*time.Hour), appLogger)
+	// Reports are rendered the same way from the registered templates
+	reportTemplates := report.NewRegistry()
+	usecase.RegisterReportTemplates(reportTemplates, userRepo, securityEventRepo)
+	reportUseCase := usecase.NewReportUseCase(reportRepo, reportTemplates, jobQueue, blobStore,
+		notifier, utils.GetEnvDuration("REPORT_TTL", 7*24*time.Hour), appLogger)
+	if utils.GetEnv("JOB_WORKER_ENABLED", "true") == "true" {
+		jobWorker := jobs.NewWorker(jobRepo, jobs.LoadConfigFromEnv(), appLogger)
+		dataExportUseCase.RegisterJobs(jobWorker)
+		reportUseCase.RegisterJobs(jobWorker)
+		lc.Go("job-worker", jobWorker.Run)
+	}
 
 	// Initialize mapper
 	userMapper := controller.NewUserMapper()
 
 	// Initialize gRPC server with interceptors
-	grpcServer := grpc.NewBaseGrpcServer(appLogger)
+	serverOptions := []grpc.ServerOption{grpc.WithUnaryInterceptors(grpc.ExplainUnaryInterceptor(explainConfig))}
+	// Opt-in: writes of mutating requests commit or roll back together
+	if utils.GetEnv("DB_REQUEST_TRANSACTIONS", "false") == "true" {
+		serverOptions = append(serverOptions, grpc.WithUnaryInterceptors(grpc.TransactionUnaryInterceptor(db.DB, appLogger, nil)))
+	}
+	serverConfig.Watchdog = slowRequests
+	grpcServer := grpc.NewBaseGrpcServerWithConfig(appLogger, serverConfig, serverOptions...)
 
 	// Register the service implementation with the gRPC server
-	controller.RegisterUserServiceServer(grpcServer.Server(), userUseCase, userMapper)
+	controller.RegisterUserServiceServer(grpcServer.Server(), userUseCase, dataExportUseCase, userMapper)
+	controller.RegisterWebhookServiceServer(grpcServer.Server(), webhookService)
+	controller.RegisterDeadLetterServiceServer(grpcServer.Server(), deadLetters)
+	controller.RegisterEventServiceServer(grpcServer.Server(), changeFeed)
+	controller.RegisterQuotaServiceServer(grpcServer.Server(), quotas)
+	controller.RegisterReportServiceServer(grpcServer.Server(), reportUseCase)
+	controller.RegisterOrganizationServiceServer(grpcServer.Server(), organizationUseCase)
+
+	// Registered last so it stops first: readiness drops and in-flight calls drain before the
+	// workers and probes stop
+	lc.Add(lifecycle.Component{
+		Name: "grpc",
+		Start: func(context.Context) error {
+			if err := grpcServer.Start(); err != nil {
+				return err
+			}
+			probes.SetReady(true)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			probes.SetReady(false) // Stop receiving new traffic while draining
+			return grpcServer.Shutdown(ctx)
+		},
+	})
 
 	log.Printf("User service setup completed successfully")
-	return grpcServer, nil
+	return lc, nil
 }
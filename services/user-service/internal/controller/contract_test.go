@@ -0,0 +1,56 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	dbtest "golang-microservices-boilerplate/pkg/core/database/testing"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/testkit"
+	pb "golang-microservices-boilerplate/proto/user-service"
+	"golang-microservices-boilerplate/services/user-service/internal/controller"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	"golang-microservices-boilerplate/services/user-service/internal/repository"
+	"golang-microservices-boilerplate/services/user-service/internal/usecase"
+)
+
+// nopRevoker accepts every revocation; the contract does not reuse rotated refresh tokens
+type nopRevoker struct{}
+
+func (nopRevoker) Revoke(context.Context, string, time.Time) error { return nil }
+
+// TestUserServiceContract runs the contract testkit.FakeUserService is checked against on the real
+// controller, use case and repositories, so the two cannot drift apart.
+func TestUserServiceContract(t *testing.T) {
+	models := []interface{}{&entity.User{}, &entity.SecurityEvent{}, &entity.ErasureTombstone{}, &entity.Organization{},
+		&entity.Membership{}, &entity.Invitation{}, &entity.UserHistory{}}
+	pc := dbtest.MustStartPostgres(t, append(models, core_repo.CascadeModels()...)...)
+	db := pc.Conn.DB
+
+	appLogger, err := logger.NewLoggerFromEnv()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	memberships := repository.NewMembershipRepository(db)
+	users := usecase.NewUserUseCase(repository.NewUserRepository(db), repository.NewSecurityEventRepository(db),
+		repository.NewUserHistoryRepository(db), memberships, appLogger, nil, nil, nil, nopRevoker{}, nil,
+		usecase.InvitationConfig{Repository: repository.NewInvitationRepository(db)})
+
+	srv := testkit.NewBufconnServer(func(s *grpc.Server) {
+		controller.RegisterUserServiceServer(s, users, nil, controller.NewUserMapper())
+	})
+	t.Cleanup(srv.Close)
+	conn, err := srv.Dial()
+	if err != nil {
+		t.Fatalf("failed to dial user service: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	if err := testkit.VerifyUserServiceContract(context.Background(), pb.NewUserServiceClient(conn)); err != nil {
+		t.Fatal(err)
+	}
+}
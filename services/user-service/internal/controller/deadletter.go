@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	"golang-microservices-boilerplate/pkg/core/deadletter"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// deadLetterServer implements pb.DeadLetterServiceServer on top of the dead-letter service
+type deadLetterServer struct {
+	pb.UnimplementedDeadLetterServiceServer
+	svc *deadletter.Service
+}
+
+// RegisterDeadLetterServiceServer registers the dead-letter management service with the gRPC server.
+func RegisterDeadLetterServiceServer(s *grpc.Server, svc *deadletter.Service) {
+	pb.RegisterDeadLetterServiceServer(s, &deadLetterServer{svc: svc})
+}
+
+// ListDeadLetters implements proto.DeadLetterServiceServer.
+func (s *deadLetterServer) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+	result, err := s.svc.Messages(ctx, req.GetConsumer(), deadletter.Status(req.GetStatus()), opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	resp := &pb.ListDeadLettersResponse{
+		DeadLetters:    make([]*pb.DeadLetter, 0, len(result.Items)),
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
+	}
+	for _, message := range result.Items {
+		resp.DeadLetters = append(resp.DeadLetters, deadLetterToProto(message))
+	}
+	return resp, nil
+}
+
+// GetDeadLetter implements proto.DeadLetterServiceServer.
+func (s *deadLetterServer) GetDeadLetter(ctx context.Context, req *pb.DeadLetterIDRequest) (*pb.DeadLetter, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid dead letter ID format: %v", err)
+	}
+	message, err := s.svc.GetByID(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return deadLetterToProto(message), nil
+}
+
+// ReplayDeadLetters implements proto.DeadLetterServiceServer.
+func (s *deadLetterServer) ReplayDeadLetters(ctx context.Context, req *pb.ReplayDeadLettersRequest) (*pb.ReplayDeadLettersResponse, error) {
+	ids := make([]uuid.UUID, 0, len(req.GetIds()))
+	for _, raw := range req.GetIds() {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid dead letter ID format %q: %v", raw, err)
+		}
+		ids = append(ids, id)
+	}
+	results, err := s.svc.Replay(ctx, ids)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	resp := &pb.ReplayDeadLettersResponse{Results: make([]*pb.ReplayResult, 0, len(results))}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &pb.ReplayResult{
+			Id:       result.ID.String(),
+			Replayed: result.Replayed,
+			Error:    result.Error,
+		})
+	}
+	return resp, nil
+}
+
+// DiscardDeadLetter implements proto.DeadLetterServiceServer.
+func (s *deadLetterServer) DiscardDeadLetter(ctx context.Context, req *pb.DeadLetterIDRequest) (*pb.DeadLetter, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid dead letter ID format: %v", err)
+	}
+	message, err := s.svc.Discard(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return deadLetterToProto(message), nil
+}
+
+// deadLetterToProto maps a dead-lettered message
+func deadLetterToProto(message *deadletter.Message) *pb.DeadLetter {
+	resp := &pb.DeadLetter{
+		Id:           message.ID.String(),
+		Consumer:     message.Consumer,
+		EventId:      message.EventID.String(),
+		EventType:    message.EventType,
+		Payload:      message.Payload,
+		Status:       string(message.Status),
+		Attempts:     int32(message.Attempts),
+		LastError:    message.LastError,
+		LastFailedAt: timestamppb.New(message.LastFailedAt),
+		CreatedAt:    timestamppb.New(message.CreatedAt),
+	}
+	if message.ReplayedAt != nil {
+		resp.ReplayedAt = timestamppb.New(*message.ReplayedAt)
+	}
+	return resp
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"encoding/json"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	"golang-microservices-boilerplate/pkg/core/events"
+	pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// eventServer streams the service's change feed to the API gateway
+type eventServer struct {
+	pb.UnimplementedEventServiceServer
+	feed *events.Feed
+}
+
+// RegisterEventServiceServer registers the change feed stream with the gRPC server.
+func RegisterEventServiceServer(s *grpc.Server, feed *events.Feed) {
+	pb.RegisterEventServiceServer(s, &eventServer{feed: feed})
+}
+
+// WatchEvents implements proto.EventServiceServer.
+func (s *eventServer) WatchEvents(req *pb.WatchEventsRequest, stream pb.EventService_WatchEventsServer) error {
+	backlog, live, unsubscribe := s.feed.Subscribe(req.GetAfterSequence())
+	defer unsubscribe()
+
+	send := func(se events.SequencedEvent) error {
+		if len(req.GetResources()) > 0 && !slices.Contains(req.GetResources(), se.Resource()) {
+			return nil
+		}
+		msg, err := changeEventToProto(se)
+		if err != nil {
+			return coreController.GrpcErrorf(codes.Internal, "failed to map event %s: %v", se.ID, err)
+		}
+		return stream.Send(msg)
+	}
+
+	for _, se := range backlog {
+		if err := send(se); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case se, ok := <-live:
+			if !ok {
+				return nil
+			}
+			if err := send(se); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// changeEventToProto maps an event, converting its payload to a Struct via JSON
+func changeEventToProto(se events.SequencedEvent) (*pb.ChangeEvent, error) {
+	raw, err := json.Marshal(se.Data)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	data, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ChangeEvent{
+		Sequence:      se.Sequence,
+		Id:            se.ID.String(),
+		Type:          se.Type,
+		Resource:      se.Resource(),
+		OccurredAt:    timestamppb.New(se.OccurredAt),
+		Data:          data,
+		SchemaVersion: int32(se.SchemaVersion),
+	}, nil
+}
@@ -4,16 +4,19 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"golang-microservices-boilerplate/pkg/core/dto"
 	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	coreUsecase "golang-microservices-boilerplate/pkg/core/usecase"
 	corePb "golang-microservices-boilerplate/proto/core"
 	pb "golang-microservices-boilerplate/proto/user-service"
 	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
 	userschema "golang-microservices-boilerplate/services/user-service/internal/schema"
-	// Keep usecase import only if needed, maybe not needed now
-	// userservice_usecase "golang-microservices-boilerplate/services/user-service/internal/usecase"
+	userservice_usecase "golang-microservices-boilerplate/services/user-service/internal/usecase"
 )
 
 // Mapper defines the interface for mapping between gRPC proto messages and internal types.
@@ -24,8 +27,18 @@ type Mapper interface {
 	ProtoLoginToSchema(req *pb.LoginRequest) (userschema.LoginCredentials, error)
 	SchemaLoginResultToProto(result *userschema.LoginResult) (*pb.LoginResponse, error)
 	SchemaRefreshResultToProto(result *userschema.RefreshResult) (*pb.RefreshResponse, error)
-	ProtoListRequestToFilterOptions(req *pb.ListUsersRequest) coreTypes.FilterOptions
+	ProtoListRequestToFilterOptions(req *pb.ListUsersRequest) (coreTypes.FilterOptions, error)
+	ProtoCountRequestToFilterOptions(req *pb.CountUsersRequest) (coreTypes.FilterOptions, error)
+	UserStatsToProto(stats *userservice_usecase.UserStats) *pb.GetUserStatsResponse
 	PaginationResultToProtoList(result *coreTypes.PaginationResult[entity.User]) (*pb.ListUsersResponse, error)
+	SecurityEventsToProto(result *coreTypes.PaginationResult[entity.SecurityEvent]) (*pb.GetSecurityEventsResponse, error)
+	TombstoneToProto(tombstone *entity.ErasureTombstone) (*pb.AnonymizeUserResponse, error)
+	UserVersionToProto(version *entity.UserHistory) (*pb.UserVersion, error)
+	UserHistoryToProto(result *coreTypes.PaginationResult[entity.UserHistory]) (*pb.ListUserHistoryResponse, error)
+	UserVersionDiffToProto(diff *userservice_usecase.UserVersionDiff) (*pb.DiffUserVersionsResponse, error)
+	DataExportToProto(export *entity.DataExport) (*pb.DataExport, error)
+	ProtoInviteToEntity(req *pb.InviteUserRequest) (*entity.User, error)
+	InviteResultToProto(result *userservice_usecase.InviteResult) (*pb.InviteUserResponse, error)
 }
 
 // Ensure UserMapper implements Mapper interface.
@@ -91,22 +104,39 @@ func (m *UserMapper) ProtoCreateToEntity(req *pb.CreateUserRequest) (*entity.Use
 		ProfilePic: derefString(req.ProfilePic),
 		IsActive:   false, // Explicitly set default, though BeforeCreate/DB default handles it
 	}
-	// Role validation can happen here or rely on BeforeCreate hook
-	if !user.Role.IsValid() {
-		// Decide how to handle invalid roles from proto (e.g., error out or default)
-		// Currently, BeforeCreate hook will default it to Officer if invalid.
-		// You could return an error here if strict proto validation is needed:
-		// return nil, fmt.Errorf("invalid role provided: %s", req.Role)
-		return nil, fmt.Errorf("invalid role provided: %s", req.Role)
-	}
+	// Role validation can happen here or rely on BeforeCreate hook; the request must name a valid one.
 	// Basic email validation (more in entity.Validate)
-	if user.Email == "" || user.FirstName == "" || user.LastName == "" {
-		return nil, errors.New("email, first name, and last name are required")
+	if err := validateUserInput(user); err != nil {
+		return nil, err
 	}
 
 	return user, nil
 }
 
+// userInput holds the fields of a create or invite request that the mappers check, by their proto names
+type userInput struct {
+	Email     string `json:"email" validate:"required,email"`
+	FirstName string `json:"first_name" validate:"required"`
+	LastName  string `json:"last_name" validate:"required"`
+	Role      string `json:"role" validate:"oneof=admin manager officer"`
+}
+
+// validateUserInput checks the fields of a user mapped from a request, returning an invalid input
+// error with one violation per field
+func validateUserInput(user *entity.User) error {
+	input := userInput{Email: user.Email, FirstName: user.FirstName, LastName: user.LastName, Role: string(user.Role)}
+	if err := dto.Validate(input); err != nil {
+		return coreUsecase.NewValidationError(err)
+	}
+	return nil
+}
+
+// userUpdateInput holds the fields of an update request that the mapper checks when the update sets them
+type userUpdateInput struct {
+	Role     *string `json:"role" validate:"omitnil,oneof=admin manager officer"`
+	Password *string `json:"password" validate:"omitnil,min=8"` // Cannot be cleared
+}
+
 // Helper function to safely dereference string pointers
 func derefString(s *string) string {
 	if s == nil {
@@ -115,56 +145,42 @@ func derefString(s *string) string {
 	return *s
 }
 
-// ApplyProtoUpdateToEntity applies fields from proto.UpdateUserRequest to an existing entity.User.
-// It modifies the existingUser in place.
+// userUpdateIgnoredFields are the fields of an update request that are not user fields
+var userUpdateIgnoredFields = []string{"id", "update_mask"}
+
+// ApplyProtoUpdateToEntity applies the fields selected by the request's update mask to an existing
+// entity.User, or its non-empty fields when there is no mask. It modifies the existingUser in place.
 func (m *UserMapper) ApplyProtoUpdateToEntity(req *pb.UpdateUserRequest, existingUser *entity.User) error {
 	if req == nil || existingUser == nil {
 		return errors.New("request and existing entity must not be nil")
 	}
 
-	// Apply only the fields present in the request
-	if req.Username != nil {
-		existingUser.Username = req.Username.Value
-	}
-	if req.Email != nil {
-		existingUser.Email = req.Email.Value
-	}
-	if req.Password != nil {
-		// Setting the plain password here; BeforeUpdate hook should handle hashing
-		existingUser.Password = req.Password.Value
-	}
-	if req.FirstName != nil {
-		existingUser.FirstName = req.FirstName.Value
-	}
-	if req.LastName != nil {
-		existingUser.LastName = req.LastName.Value
-	}
-	if req.Role != nil {
-		role := entity.Role(req.Role.Value)
-		if role.IsValid() {
-			existingUser.Role = role
-		} else {
-			// Optionally return error for invalid role update, or let Validate handle it
-			// return fmt.Errorf("invalid role provided for update: %s", req.Role.Value)
+	paths, err := dto.UpdateMaskPaths(req, req.GetUpdateMask(), userUpdateIgnoredFields...)
+	if err != nil {
+		return coreUsecase.NewUseCaseError(coreUsecase.ErrInvalidInput, err.Error())
+	}
+	var input userUpdateInput
+	for _, path := range paths {
+		switch path {
+		case "role":
+			role := req.GetRole()
+			input.Role = &role
+		case "password":
+			password := req.GetPassword()
+			input.Password = &password
 		}
 	}
-	if req.IsActive != nil {
-		existingUser.IsActive = req.IsActive.Value
-	}
-	if req.Phone != nil {
-		existingUser.Phone = req.Phone.Value
-	}
-	if req.Address != nil {
-		existingUser.Address = req.Address.Value
-	}
-	if req.Age != nil {
-		existingUser.Age = req.Age.Value
-	}
-	if req.ProfilePic != nil {
-		existingUser.ProfilePic = req.ProfilePic.Value
+	if err := dto.Validate(input); err != nil {
+		return coreUsecase.NewValidationError(err)
 	}
 
-	return nil // Return nil on success
+	// Setting the plain password here; BeforeUpdate hook should handle hashing
+	if err := dto.ApplyFieldMask(req, req.GetUpdateMask(), existingUser, userUpdateIgnoredFields...); err != nil {
+		return coreUsecase.NewUseCaseError(coreUsecase.ErrInvalidInput, err.Error())
+	}
+	// Proto field names are the column names, so cleared fields are written too
+	existingUser.SetUpdateColumns(paths...)
+	return nil
 }
 
 // ProtoLoginToSchema converts proto.LoginRequest to schema.LoginCredentials.
@@ -173,10 +189,18 @@ func (m *UserMapper) ProtoLoginToSchema(req *pb.LoginRequest) (userschema.LoginC
 	if req == nil {
 		return userschema.LoginCredentials{}, errors.New("cannot map nil login request")
 	}
-	return userschema.LoginCredentials{
+	creds := userschema.LoginCredentials{
 		Email:    req.Email,
 		Password: req.Password,
-	}, nil
+	}
+	if req.GetOrganizationId() != "" {
+		orgID, err := uuid.Parse(req.GetOrganizationId())
+		if err != nil {
+			return userschema.LoginCredentials{}, fmt.Errorf("invalid organization ID format: %w", err)
+		}
+		creds.OrganizationID = orgID
+	}
+	return creds, nil
 }
 
 // Re-add SchemaLoginResultToProto
@@ -211,36 +235,90 @@ func (m *UserMapper) SchemaRefreshResultToProto(result *userschema.RefreshResult
 	}, nil
 }
 
+// userSearchColumns are the columns the search parameter of ListUsersRequest is matched against
+var userSearchColumns = []string{"username", "email", "first_name", "last_name"}
+
 // ProtoListRequestToFilterOptions converts proto.ListUsersRequest to coreTypes.FilterOptions.
-func (m *UserMapper) ProtoListRequestToFilterOptions(req *pb.ListUsersRequest) coreTypes.FilterOptions {
-	opts := coreTypes.DefaultFilterOptions()
-	if req == nil || req.Options == nil {
-		return opts
+// The explicit filter parameters become conditions added to those of the options.
+func (m *UserMapper) ProtoListRequestToFilterOptions(req *pb.ListUsersRequest) (coreTypes.FilterOptions, error) {
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return opts, err
 	}
+	conditions, err := userFilterConditions(req.GetRole(), req.IsActive, req.GetCreatedAfter(), req.GetCreatedBefore())
+	if err != nil {
+		return opts, err
+	}
+	return coreTypes.FilterOptionsBuilderFrom(opts).
+		WithConditions(conditions...).
+		WithSearch(req.GetSearch(), userSearchColumns...).
+		Build()
+}
 
-	if req.Options.Limit != nil {
-		opts.Limit = int(*req.Options.Limit)
+// ProtoCountRequestToFilterOptions converts proto.CountUsersRequest to coreTypes.FilterOptions,
+// like ProtoListRequestToFilterOptions.
+func (m *UserMapper) ProtoCountRequestToFilterOptions(req *pb.CountUsersRequest) (coreTypes.FilterOptions, error) {
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return opts, err
 	}
-	if req.Options.Offset != nil {
-		opts.Offset = int(*req.Options.Offset)
+	conditions, err := userFilterConditions(req.GetRole(), req.IsActive, req.GetCreatedAfter(), req.GetCreatedBefore())
+	if err != nil {
+		return opts, err
 	}
-	if req.Options.SortBy != nil {
-		opts.SortBy = *req.Options.SortBy
+	return coreTypes.FilterOptionsBuilderFrom(opts).
+		WithConditions(conditions...).
+		WithSearch(req.GetSearch(), userSearchColumns...).
+		Build()
+}
+
+// UserStatsToProto converts usecase.UserStats to proto.GetUserStatsResponse.
+func (m *UserMapper) UserStatsToProto(stats *userservice_usecase.UserStats) *pb.GetUserStatsResponse {
+	resp := &pb.GetUserStatsResponse{
+		Total:         stats.Total,
+		Active:        stats.Active,
+		PerRole:       make([]*pb.RoleCount, 0, len(stats.PerRole)),
+		SignupsPerDay: make([]*pb.DailyCount, 0, len(stats.SignupsPerDay)),
+		Since:         timestamppb.New(stats.Since),
+	}
+	for _, group := range stats.PerRole {
+		resp.PerRole = append(resp.PerRole, &pb.RoleCount{Role: group.Key, Count: group.Count})
+	}
+	for _, group := range stats.SignupsPerDay {
+		resp.SignupsPerDay = append(resp.SignupsPerDay, &pb.DailyCount{Date: group.Key, Count: group.Count})
+	}
+	return resp
+}
+
+// userFilterConditions validates the explicit user filter parameters of list and count requests
+// and converts them to conditions
+func userFilterConditions(role string, isActive *bool, createdAfter, createdBefore *timestamppb.Timestamp) ([]coreTypes.FilterCondition, error) {
+	var conditions []coreTypes.FilterCondition
+	if role != "" {
+		if !entity.Role(role).IsValid() {
+			return nil, fmt.Errorf("invalid role %q", role)
+		}
+		conditions = append(conditions, coreTypes.NewCondition("role", coreTypes.OpEq, role))
 	}
-	if req.Options.SortDesc != nil {
-		opts.SortDesc = *req.Options.SortDesc
+	if isActive != nil {
+		conditions = append(conditions, coreTypes.NewCondition("is_active", coreTypes.OpEq, *isActive))
 	}
-	if req.Options.IncludeDeleted != nil {
-		opts.IncludeDeleted = *req.Options.IncludeDeleted
+	if createdAfter != nil {
+		if err := createdAfter.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid created_after: %w", err)
+		}
+		conditions = append(conditions, coreTypes.NewCondition("created_at", coreTypes.OpGte, createdAfter.AsTime()))
 	}
-
-	if len(req.Options.Filters) > 0 {
-		opts.Filters = make(map[string]interface{}, len(req.Options.Filters))
-		for k, v := range req.Options.Filters {
-			opts.Filters[k] = mapProtoValueToGo(v)
+	if createdBefore != nil {
+		if err := createdBefore.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid created_before: %w", err)
+		}
+		if createdAfter != nil && !createdBefore.AsTime().After(createdAfter.AsTime()) {
+			return nil, errors.New("created_before must be after created_after")
 		}
+		conditions = append(conditions, coreTypes.NewCondition("created_at", coreTypes.OpLt, createdBefore.AsTime()))
 	}
-	return opts
+	return conditions, nil
 }
 
 // PaginationResultToProtoList converts coreTypes.PaginationResult[entity.User] to proto.ListUsersResponse.
@@ -261,45 +339,211 @@ func (m *UserMapper) PaginationResultToProtoList(result *coreTypes.PaginationRes
 		usersProto = append(usersProto, userProto)
 	}
 
-	paginationInfo := &corePb.PaginationInfo{
-		TotalItems: result.TotalItems,
-		Limit:      int32(result.Limit),
-		Offset:     int32(result.Offset),
-	}
-
 	return &pb.ListUsersResponse{
 		Users:          usersProto,
-		PaginationInfo: paginationInfo,
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
 	}, nil
 }
 
-// mapProtoValueToGo converts structpb.Value to its corresponding Go type.
-func mapProtoValueToGo(v *structpb.Value) interface{} {
-	if v == nil {
-		return nil
-	}
-	switch k := v.Kind.(type) {
-	case *structpb.Value_NullValue:
-		return nil
-	case *structpb.Value_NumberValue:
-		return k.NumberValue
-	case *structpb.Value_StringValue:
-		return k.StringValue
-	case *structpb.Value_BoolValue:
-		return k.BoolValue
-	case *structpb.Value_StructValue:
-		goMap := make(map[string]interface{}, len(k.StructValue.Fields))
-		for fieldName, fieldValue := range k.StructValue.Fields {
-			goMap[fieldName] = mapProtoValueToGo(fieldValue)
+// SecurityEventsToProto converts a page of entity.SecurityEvent to proto.GetSecurityEventsResponse.
+func (m *UserMapper) SecurityEventsToProto(result *coreTypes.PaginationResult[entity.SecurityEvent]) (*pb.GetSecurityEventsResponse, error) {
+	if result == nil {
+		return &pb.GetSecurityEventsResponse{
+			Events:         []*pb.SecurityEvent{},
+			PaginationInfo: &corePb.PaginationInfo{TotalItems: 0, Limit: 0, Offset: 0},
+		}, nil
+	}
+
+	eventsProto := make([]*pb.SecurityEvent, 0, len(result.Items))
+	for _, event := range result.Items {
+		if event == nil {
+			return nil, errors.New("cannot map nil security event to proto")
 		}
-		return goMap
-	case *structpb.Value_ListValue:
-		goSlice := make([]interface{}, len(k.ListValue.Values))
-		for i, itemValue := range k.ListValue.Values {
-			goSlice[i] = mapProtoValueToGo(itemValue)
+		userID := ""
+		if event.UserID != nil {
+			userID = event.UserID.String()
 		}
-		return goSlice
-	default:
-		return nil
+		eventsProto = append(eventsProto, &pb.SecurityEvent{
+			Id:        event.ID.String(),
+			UserId:    userID,
+			EventType: string(event.EventType),
+			IpAddress: event.IPAddress,
+			UserAgent: event.UserAgent,
+			Details:   event.Details,
+			CreatedAt: timestamppb.New(event.CreatedAt),
+		})
+	}
+
+	return &pb.GetSecurityEventsResponse{
+		Events:         eventsProto,
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
+	}, nil
+}
+
+// UserVersionToProto converts an entity.UserHistory to proto.UserVersion, decoding its snapshot.
+func (m *UserMapper) UserVersionToProto(version *entity.UserHistory) (*pb.UserVersion, error) {
+	if version == nil {
+		return nil, errors.New("cannot map nil user version to proto")
+	}
+	snapshot, err := user_repository.DecodeSnapshot(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot of user version %d: %w", version.Version, err)
 	}
+	user := &entity.User{
+		Username:    snapshot.Username,
+		Email:       snapshot.Email,
+		FirstName:   snapshot.FirstName,
+		LastName:    snapshot.LastName,
+		Role:        entity.Role(snapshot.Role),
+		IsActive:    snapshot.IsActive,
+		LastLoginAt: snapshot.LastLoginAt,
+		Phone:       snapshot.Phone,
+		Address:     snapshot.Address,
+		Age:         snapshot.Age,
+		ProfilePic:  snapshot.ProfilePic,
+	}
+	user.ID, user.CreatedAt, user.UpdatedAt, user.DeletedAt = snapshot.ID, snapshot.CreatedAt, snapshot.UpdatedAt, snapshot.DeletedAt
+	userProto, err := m.EntityToProto(user)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID := ""
+	if version.ActorID != nil {
+		actorID = version.ActorID.String()
+	}
+	return &pb.UserVersion{
+		Version:         version.Version,
+		Operation:       version.Operation,
+		User:            userProto,
+		ChangedFields:   version.ChangedFieldList(),
+		PasswordChanged: version.PasswordChanged,
+		ActorId:         actorID,
+		RecordedAt:      timestamppb.New(version.CreatedAt),
+	}, nil
+}
+
+// UserHistoryToProto converts a paginated result of entity.UserHistory to proto.ListUserHistoryResponse.
+func (m *UserMapper) UserHistoryToProto(result *coreTypes.PaginationResult[entity.UserHistory]) (*pb.ListUserHistoryResponse, error) {
+	if result == nil {
+		return &pb.ListUserHistoryResponse{
+			Versions:       []*pb.UserVersion{},
+			PaginationInfo: &corePb.PaginationInfo{TotalItems: 0, Limit: 0, Offset: 0},
+		}, nil
+	}
+
+	versions := make([]*pb.UserVersion, 0, len(result.Items))
+	for _, version := range result.Items {
+		versionProto, err := m.UserVersionToProto(version)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, versionProto)
+	}
+
+	return &pb.ListUserHistoryResponse{
+		Versions:       versions,
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
+	}, nil
+}
+
+// UserVersionDiffToProto converts a userservice_usecase.UserVersionDiff to proto.DiffUserVersionsResponse.
+func (m *UserMapper) UserVersionDiffToProto(diff *userservice_usecase.UserVersionDiff) (*pb.DiffUserVersionsResponse, error) {
+	if diff == nil {
+		return nil, errors.New("cannot map nil user version diff to proto")
+	}
+	changes := make([]*pb.FieldChange, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		oldValue, err := structpb.NewValue(change.OldValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of field %s: %w", change.Field, err)
+		}
+		newValue, err := structpb.NewValue(change.NewValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of field %s: %w", change.Field, err)
+		}
+		changes = append(changes, &pb.FieldChange{Field: change.Field, OldValue: oldValue, NewValue: newValue})
+	}
+	return &pb.DiffUserVersionsResponse{
+		FromVersion:     diff.FromVersion,
+		ToVersion:       diff.ToVersion,
+		Changes:         changes,
+		PasswordChanged: diff.PasswordChanged,
+	}, nil
+}
+
+// TombstoneToProto converts an entity.ErasureTombstone to proto.AnonymizeUserResponse.
+func (m *UserMapper) TombstoneToProto(tombstone *entity.ErasureTombstone) (*pb.AnonymizeUserResponse, error) {
+	if tombstone == nil {
+		return nil, errors.New("cannot map nil erasure tombstone to proto")
+	}
+	return &pb.AnonymizeUserResponse{
+		TombstoneId: tombstone.ID.String(),
+		UserId:      tombstone.UserID.String(),
+		EmailHash:   tombstone.EmailHash,
+		ErasedAt:    timestamppb.New(tombstone.ErasedAt),
+	}, nil
+}
+
+// DataExportToProto converts an entity.DataExport to proto.DataExport.
+func (m *UserMapper) DataExportToProto(export *entity.DataExport) (*pb.DataExport, error) {
+	if export == nil {
+		return nil, errors.New("cannot map nil data export to proto")
+	}
+	response := &pb.DataExport{
+		Id:        export.ID.String(),
+		Status:    string(export.Status),
+		Format:    string(export.Format),
+		SizeBytes: export.SizeBytes,
+		Error:     export.Error,
+		CreatedAt: timestamppb.New(export.CreatedAt),
+	}
+	if export.Status == entity.DataExportReady {
+		response.DownloadUrl = userservice_usecase.DataExportDownloadPath(export.ID)
+	}
+	if export.CompletedAt != nil {
+		response.CompletedAt = timestamppb.New(*export.CompletedAt)
+	}
+	if export.ExpiresAt != nil {
+		response.ExpiresAt = timestamppb.New(*export.ExpiresAt)
+	}
+	return response, nil
+}
+
+// ProtoInviteToEntity converts proto.InviteUserRequest to the entity.User to invite.
+func (m *UserMapper) ProtoInviteToEntity(req *pb.InviteUserRequest) (*entity.User, error) {
+	if req == nil {
+		return nil, errors.New("cannot map nil invite request to entity")
+	}
+	user := &entity.User{
+		Username:  req.GetUsername(),
+		Email:     req.GetEmail(),
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+		Role:      entity.Role(req.GetRole()),
+	}
+	if user.Role == "" {
+		user.Role = entity.RoleOfficer
+	}
+	if err := validateUserInput(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// InviteResultToProto converts a usecase.InviteResult to proto.InviteUserResponse.
+func (m *UserMapper) InviteResultToProto(result *userservice_usecase.InviteResult) (*pb.InviteUserResponse, error) {
+	if result == nil {
+		return nil, errors.New("cannot map nil invite result")
+	}
+	userProto, err := m.EntityToProto(result.User)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map user entity to proto: %w", err)
+	}
+	return &pb.InviteUserResponse{
+		User:        userProto,
+		InviteToken: result.Token,
+		InviteUrl:   result.Link,
+		ExpiresAt:   timestamppb.New(result.Invitation.ExpiresAt),
+	}, nil
 }
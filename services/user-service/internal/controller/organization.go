@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	pb "golang-microservices-boilerplate/proto/user-service"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	userservice_usecase "golang-microservices-boilerplate/services/user-service/internal/usecase"
+)
+
+// organizationServer implements pb.OrganizationServiceServer on top of the organization use case
+type organizationServer struct {
+	pb.UnimplementedOrganizationServiceServer
+	organizations userservice_usecase.OrganizationUsecase
+}
+
+// RegisterOrganizationServiceServer registers the organization service with the gRPC server.
+func RegisterOrganizationServiceServer(s *grpc.Server, organizations userservice_usecase.OrganizationUsecase) {
+	pb.RegisterOrganizationServiceServer(s, &organizationServer{organizations: organizations})
+}
+
+// CreateOrg implements proto.OrganizationServiceServer.
+func (s *organizationServer) CreateOrg(ctx context.Context, req *pb.CreateOrgRequest) (*pb.Organization, error) {
+	org, err := s.organizations.CreateOrganization(ctx, req.GetName(), req.GetSlug())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return &pb.Organization{
+		Id:        org.ID.String(),
+		Name:      org.Name,
+		Slug:      org.Slug,
+		CreatedBy: org.CreatedBy.String(),
+		CreatedAt: timestamppb.New(org.CreatedAt),
+	}, nil
+}
+
+// InviteMember implements proto.OrganizationServiceServer.
+func (s *organizationServer) InviteMember(ctx context.Context, req *pb.InviteMemberRequest) (*pb.Member, error) {
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid organization ID format: %v", err)
+	}
+	membership, err := s.organizations.InviteMember(ctx, orgID, req.GetEmail(), entity.MemberRole(req.GetRole()))
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	member := memberToProto(membership, nil)
+	member.Email = req.GetEmail()
+	return member, nil
+}
+
+// ListMembers implements proto.OrganizationServiceServer.
+func (s *organizationServer) ListMembers(ctx context.Context, req *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid organization ID format: %v", err)
+	}
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+	page, err := s.organizations.ListMembers(ctx, orgID, opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	members := make([]*pb.Member, 0, len(page.Items))
+	for _, membership := range page.Items {
+		members = append(members, memberToProto(membership, page.Users[membership.UserID]))
+	}
+	return &pb.ListMembersResponse{
+		Members:        members,
+		PaginationInfo: coreTypes.PaginationInfoToProto(page.PaginationResult),
+	}, nil
+}
+
+// ChangeMemberRole implements proto.OrganizationServiceServer.
+func (s *organizationServer) ChangeMemberRole(ctx context.Context, req *pb.ChangeMemberRoleRequest) (*pb.Member, error) {
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid organization ID format: %v", err)
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+	membership, err := s.organizations.ChangeMemberRole(ctx, orgID, userID, entity.MemberRole(req.GetRole()))
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return memberToProto(membership, nil), nil
+}
+
+// memberToProto converts an entity.Membership and, if known, its user to proto.Member.
+func memberToProto(membership *entity.Membership, user *entity.User) *pb.Member {
+	member := &pb.Member{
+		OrganizationId: membership.OrganizationID.String(),
+		UserId:         membership.UserID.String(),
+		Role:           string(membership.Role),
+		JoinedAt:       timestamppb.New(membership.CreatedAt),
+	}
+	if membership.InvitedBy != nil {
+		member.InvitedBy = membership.InvitedBy.String()
+	}
+	if user != nil {
+		member.Email, member.FirstName, member.LastName = user.Email, user.FirstName, user.LastName
+	}
+	return member
+}
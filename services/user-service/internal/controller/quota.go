@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	"golang-microservices-boilerplate/pkg/core/quota"
+	pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// quotaServer implements pb.QuotaServiceServer on top of the quota manager
+type quotaServer struct {
+	pb.UnimplementedQuotaServiceServer
+	quotas *quota.Manager
+}
+
+// RegisterQuotaServiceServer registers the quota reporting service with the gRPC server.
+func RegisterQuotaServiceServer(s *grpc.Server, quotas *quota.Manager) {
+	pb.RegisterQuotaServiceServer(s, &quotaServer{quotas: quotas})
+}
+
+// ListQuotaUsage implements proto.QuotaServiceServer.
+func (s *quotaServer) ListQuotaUsage(ctx context.Context, req *pb.ListQuotaUsageRequest) (*pb.ListQuotaUsageResponse, error) {
+	subject := req.GetSubject()
+	if subject == "" {
+		subject = quota.Global
+	}
+	usages, err := s.quotas.Usage(ctx, subject)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to read quota usage: %v", err)
+	}
+
+	resp := &pb.ListQuotaUsageResponse{Usages: make([]*pb.QuotaUsage, 0, len(usages))}
+	for _, usage := range usages {
+		msg := &pb.QuotaUsage{
+			Name:          usage.Name,
+			Subject:       usage.Subject,
+			Used:          usage.Used,
+			Limit:         usage.Limit,
+			SoftLimit:     usage.Soft,
+			Remaining:     usage.Remaining(),
+			OverSoftLimit: usage.OverSoft(),
+		}
+		if usage.Period > 0 {
+			msg.Period = usage.Period.String()
+		}
+		if usage.ResetsAt != nil {
+			msg.ResetsAt = timestamppb.New(*usage.ResetsAt)
+		}
+		resp.Usages = append(resp.Usages, msg)
+	}
+	return resp, nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	"golang-microservices-boilerplate/pkg/core/report"
+	pb "golang-microservices-boilerplate/proto/user-service"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	userservice_usecase "golang-microservices-boilerplate/services/user-service/internal/usecase"
+)
+
+// reportServer implements pb.ReportServiceServer on top of the report use case
+type reportServer struct {
+	pb.UnimplementedReportServiceServer
+	reports userservice_usecase.ReportUsecase
+}
+
+// RegisterReportServiceServer registers the report service with the gRPC server.
+func RegisterReportServiceServer(s *grpc.Server, reports userservice_usecase.ReportUsecase) {
+	pb.RegisterReportServiceServer(s, &reportServer{reports: reports})
+}
+
+// CreateReport implements proto.ReportServiceServer.
+func (s *reportServer) CreateReport(ctx context.Context, req *pb.CreateReportRequest) (*pb.Report, error) {
+	rep, err := s.reports.CreateReport(ctx, req.GetType(), report.Format(req.GetFormat()), req.GetParams())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return reportToProto(rep), nil
+}
+
+// GetReportStatus implements proto.ReportServiceServer.
+func (s *reportServer) GetReportStatus(ctx context.Context, req *pb.ReportIDRequest) (*pb.Report, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid report ID format: %v", err)
+	}
+	rep, err := s.reports.GetReport(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return reportToProto(rep), nil
+}
+
+// DownloadReport implements proto.ReportServiceServer.
+// The file is sent as one message, so the gateway's MAX_RECV_MSG_SIZE must allow its size.
+func (s *reportServer) DownloadReport(ctx context.Context, req *pb.ReportIDRequest) (*httpbody.HttpBody, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid report ID format: %v", err)
+	}
+	rep, file, err := s.reports.OpenReport(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to read report: %v", err)
+	}
+	return &httpbody.HttpBody{ContentType: rep.Format.ContentType(), Data: data}, nil
+}
+
+// reportToProto converts an entity.Report to proto.Report.
+func reportToProto(rep *entity.Report) *pb.Report {
+	response := &pb.Report{
+		Id:        rep.ID.String(),
+		Type:      rep.Type,
+		Format:    string(rep.Format),
+		Params:    rep.ParamMap(),
+		Status:    string(rep.Status),
+		SizeBytes: rep.SizeBytes,
+		Error:     rep.Error,
+		CreatedAt: timestamppb.New(rep.CreatedAt),
+	}
+	if rep.Status == entity.ReportReady {
+		response.DownloadUrl = userservice_usecase.ReportDownloadPath(rep.ID)
+	}
+	if rep.CompletedAt != nil {
+		response.CompletedAt = timestamppb.New(*rep.CompletedAt)
+	}
+	if rep.ExpiresAt != nil {
+		response.ExpiresAt = timestamppb.New(*rep.ExpiresAt)
+	}
+	return response
+}
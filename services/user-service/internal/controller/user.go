@@ -2,16 +2,19 @@ package controller
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"io"
 
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	"golang-microservices-boilerplate/pkg/core/permissions"
 	coreTypes "golang-microservices-boilerplate/pkg/core/types"
-	corePb "golang-microservices-boilerplate/proto/core"
+	coreUsecase "golang-microservices-boilerplate/pkg/core/usecase"
 	pb "golang-microservices-boilerplate/proto/user-service"
 	"golang-microservices-boilerplate/services/user-service/internal/entity"
 	userservice_usecase "golang-microservices-boilerplate/services/user-service/internal/usecase"
@@ -31,23 +34,25 @@ var _ UserServer = (*userServer)(nil)
 
 type userServer struct {
 	pb.UnimplementedUserServiceServer
-	uc     userservice_usecase.UserUsecase
-	mapper Mapper // Use the Mapper interface
+	uc      userservice_usecase.UserUsecase
+	exports userservice_usecase.DataExportUsecase
+	mapper  Mapper // Use the Mapper interface
 }
 
 // NewUserServer creates a new gRPC server instance.
 // Accepts Mapper interface and returns UserServer interface.
-func NewUserServer(uc userservice_usecase.UserUsecase, mapper Mapper) UserServer {
+func NewUserServer(uc userservice_usecase.UserUsecase, exports userservice_usecase.DataExportUsecase, mapper Mapper) UserServer {
 	return &userServer{
-		uc:     uc,
-		mapper: mapper, // Inject mapper
+		uc:      uc,
+		exports: exports,
+		mapper:  mapper, // Inject mapper
 	}
 }
 
 // RegisterUserServiceServer registers the user service implementation with the gRPC server.
 // Accepts use case and mapper to create the server.
-func RegisterUserServiceServer(s *grpc.Server, uc userservice_usecase.UserUsecase, mapper Mapper) {
-	server := NewUserServer(uc, mapper) // Pass mapper
+func RegisterUserServiceServer(s *grpc.Server, uc userservice_usecase.UserUsecase, exports userservice_usecase.DataExportUsecase, mapper Mapper) {
+	server := NewUserServer(uc, exports, mapper) // Pass mapper
 	pb.RegisterUserServiceServer(s, server)
 }
 
@@ -56,19 +61,19 @@ func (s *userServer) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	// Map proto directly to entity
 	userEntity, err := s.mapper.ProtoCreateToEntity(req)
 	if err != nil {
-		return nil, status.Errorf(http.StatusBadRequest, "failed to map request: %v", err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// Call use case Create method with the entity
 	err = s.uc.Create(ctx, userEntity)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// The userEntity is updated in place (e.g., with ID) by the Create method
 	userProto, err := s.mapper.EntityToProto(userEntity)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to map result: %v", err)
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map result: %v", err)
 	}
 
 	return &pb.CreateUserResponse{User: userProto}, nil
@@ -78,17 +83,17 @@ func (s *userServer) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb
 func (s *userServer) GetByID(ctx context.Context, req *pb.GetUserByIDRequest) (*pb.GetUserByIDResponse, error) {
 	id, err := uuid.Parse(req.GetId())
 	if err != nil {
-		return nil, status.Errorf(http.StatusBadRequest, "invalid user ID format: %v", err)
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
 	}
 
 	user, err := s.uc.GetByID(ctx, id)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	userProto, err := s.mapper.EntityToProto(user)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to map result: %v", err)
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map result: %v", err)
 	}
 
 	return &pb.GetUserByIDResponse{User: userProto}, nil
@@ -96,49 +101,112 @@ func (s *userServer) GetByID(ctx context.Context, req *pb.GetUserByIDRequest) (*
 
 // List implements proto.UserServiceServer.
 func (s *userServer) List(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
-	opts := s.mapper.ProtoListRequestToFilterOptions(req)
+	opts, err := s.mapper.ProtoListRequestToFilterOptions(req)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
 
 	result, err := s.uc.List(ctx, opts)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	response, err := s.mapper.PaginationResultToProtoList(result)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to map result list: %v", err)
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map result list: %v", err)
 	}
 
 	return response, nil
 }
 
+// StreamUsers implements proto.UserServiceServer. The matching users are read DB_BATCH_SIZE at a
+// time and sent as each page arrives; options.limit and options.offset are ignored. Lists without a
+// sort order are sorted by ID, so pages do not overlap.
+func (s *userServer) StreamUsers(req *pb.ListUsersRequest, stream grpc.ServerStreamingServer[pb.User]) error {
+	opts, err := s.mapper.ProtoListRequestToFilterOptions(req)
+	if err == nil {
+		opts, err = coreTypes.FilterOptionsBuilderFrom(opts).
+			WithDefaultSort("id").
+			WithLimit(coreTypes.DefaultBatchOptions().BatchSize).
+			WithOffset(0).
+			BuildUnlimited()
+	}
+	if err != nil {
+		return coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+
+	ctx := stream.Context()
+	for {
+		result, err := s.uc.List(ctx, opts)
+		if err != nil {
+			return coreController.FromUseCaseError(ctx, err)
+		}
+		for _, user := range result.Items {
+			userProto, err := s.mapper.EntityToProto(user)
+			if err != nil {
+				return coreController.GrpcErrorf(codes.Internal, "failed to map user %s: %v", user.ID, err)
+			}
+			if err := stream.Send(userProto); err != nil {
+				return err
+			}
+		}
+		if len(result.Items) < opts.Limit {
+			return nil
+		}
+		opts.Offset += opts.Limit
+	}
+}
+
+// CountUsers implements proto.UserServiceServer.
+func (s *userServer) CountUsers(ctx context.Context, req *pb.CountUsersRequest) (*pb.CountUsersResponse, error) {
+	opts, err := s.mapper.ProtoCountRequestToFilterOptions(req)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid count options: %v", err)
+	}
+	count, err := s.uc.CountUsers(ctx, opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return &pb.CountUsersResponse{Count: count}, nil
+}
+
+// GetUserStats implements proto.UserServiceServer.
+func (s *userServer) GetUserStats(ctx context.Context, req *pb.GetUserStatsRequest) (*pb.GetUserStatsResponse, error) {
+	stats, err := s.uc.GetUserStats(ctx, int(req.GetDays()))
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return s.mapper.UserStatsToProto(stats), nil
+}
+
 // Update implements proto.UserServiceServer.
 func (s *userServer) Update(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	id, err := uuid.Parse(req.GetId())
 	if err != nil {
-		return nil, status.Errorf(http.StatusBadRequest, "invalid user ID format: %v", err)
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
 	}
 
 	// 1. Get the existing user entity
 	existingUser, err := s.uc.GetByID(ctx, id)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err) // Handle not found etc.
+		return nil, coreController.FromUseCaseError(ctx, err) // Handle not found etc.
 	}
 
 	// 2. Apply updates from proto request to the existing entity
 	if err := s.mapper.ApplyProtoUpdateToEntity(req, existingUser); err != nil {
-		return nil, status.Errorf(http.StatusBadRequest, "failed to map update request: %v", err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// 3. Call the use case Update method with the modified entity
 	err = s.uc.Update(ctx, existingUser)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// 4. Map the updated entity back to proto for response
 	userProto, err := s.mapper.EntityToProto(existingUser)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to map result: %v", err)
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map result: %v", err)
 	}
 
 	return &pb.UpdateUserResponse{User: userProto}, nil
@@ -148,31 +216,52 @@ func (s *userServer) Update(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 func (s *userServer) Delete(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
 	id, err := uuid.Parse(req.GetId())
 	if err != nil {
-		return nil, status.Errorf(http.StatusBadRequest, "invalid user ID format: %v", err)
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
 	}
 
 	hardDelete := req.GetHardDelete() // Get the flag from the request
 
 	// Call the consolidated use case method
 	if err := s.uc.Delete(ctx, id, hardDelete); err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// Restore implements proto.UserServiceServer.
+func (s *userServer) Restore(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+	if err := s.uc.Restore(ctx, id); err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	user, err := s.uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	userProto, err := s.mapper.EntityToProto(user)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map result: %v", err)
+	}
+	return &pb.RestoreUserResponse{User: userProto}, nil
+}
+
 // FindWithFilter implements proto.UserServiceServer.
 func (s *userServer) FindWithFilter(ctx context.Context, req *pb.FindUsersWithFilterRequest) (*pb.FindUsersWithFilterResponse, error) {
 	// Map the options from the request, which now contains the filters map internally
-	opts := coreTypes.DefaultFilterOptions()
-	if req.Options != nil {
-		opts = s.mapper.ProtoListRequestToFilterOptions(&pb.ListUsersRequest{Options: req.Options})
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
 	}
 
 	// Pass opts.Filters directly to the use case
 	result, err := s.uc.FindWithFilter(ctx, opts.Filters, opts)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// Need to map PaginationResult[entity.User] to FindUsersWithFilterResponse
@@ -180,80 +269,91 @@ func (s *userServer) FindWithFilter(ctx context.Context, req *pb.FindUsersWithFi
 	for _, userEntity := range result.Items {
 		userProto, mapErr := s.mapper.EntityToProto(userEntity)
 		if mapErr != nil {
-			return nil, status.Errorf(http.StatusInternalServerError, "failed to map user entity %s: %v", userEntity.ID, mapErr)
+			return nil, coreController.GrpcErrorf(codes.Internal, "failed to map user entity %s: %v", userEntity.ID, mapErr)
 		}
 		usersProto = append(usersProto, userProto)
 	}
 
-	paginationInfo := &corePb.PaginationInfo{ // Use corepb alias
-		TotalItems: result.TotalItems,
-		Limit:      int32(result.Limit),
-		Offset:     int32(result.Offset),
-	}
-
 	return &pb.FindUsersWithFilterResponse{
 		Users:          usersProto,
-		PaginationInfo: paginationInfo,
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
 	}, nil
 }
 
-// CreateMany implements proto.UserServiceServer.
+// CreateMany implements proto.UserServiceServer. Each user is created independently; users that
+// fail mapping or creation are reported in the result instead of failing the request.
 func (s *userServer) CreateMany(ctx context.Context, req *pb.CreateUsersRequest) (*pb.CreateUsersResponse, error) {
-	if req == nil || len(req.Users) == 0 {
-		return &pb.CreateUsersResponse{Users: []*pb.User{}}, nil
-	}
-
-	// Map proto requests directly to entities
-	entities := make([]*entity.User, 0, len(req.Users))
-	for i, createReq := range req.Users {
+	result := coreTypes.NewBulkResult(len(req.GetUsers()))
+	entities := make([]*entity.User, 0, len(req.GetUsers()))
+	indices := make([]int, 0, len(req.GetUsers()))
+	for i, createReq := range req.GetUsers() {
 		userEntity, err := s.mapper.ProtoCreateToEntity(createReq)
 		if err != nil {
-			return nil, status.Errorf(http.StatusBadRequest, "failed to map user %d in bulk request: %v", i, err)
+			result.Fail(i, coreUsecase.FailureReason(ctx, err))
+			continue
 		}
-		entities = append(entities, userEntity)
+		entities, indices = append(entities, userEntity), append(indices, i)
 	}
 
-	// Call use case CreateMany, capturing the returned entities and error
-	createdEntities, err := s.uc.CreateMany(ctx, entities)
-	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
-	}
-
-	// Map the returned created entities (now with IDs) back to proto
-	usersProto := make([]*pb.User, 0, len(createdEntities))
-	for _, userEntity := range createdEntities { // Use the returned slice
-		userProto, mapErr := s.mapper.EntityToProto(userEntity)
-		if mapErr != nil {
-			// Log or handle potential partial failure? For now, fail the whole request.
-			return nil, status.Errorf(http.StatusInternalServerError, "failed to map created user %s: %v", userEntity.ID, mapErr)
+	usersProto := make([]*pb.User, 0, len(entities))
+	if len(entities) > 0 {
+		created, err := s.uc.CreateMany(ctx, entities)
+		if err != nil {
+			return nil, coreController.FromUseCaseError(ctx, err)
 		}
-		usersProto = append(usersProto, userProto)
+		for _, userEntity := range coreTypes.SucceededItems(created, entities) {
+			userProto, mapErr := s.mapper.EntityToProto(userEntity)
+			if mapErr != nil {
+				return nil, coreController.GrpcErrorf(codes.Internal, "failed to map created user %s: %v", userEntity.ID, mapErr)
+			}
+			usersProto = append(usersProto, userProto)
+		}
+		result.Merge(created, indices)
 	}
 
-	return &pb.CreateUsersResponse{Users: usersProto}, nil
+	return &pb.CreateUsersResponse{Users: usersProto, Result: coreTypes.BulkResultToProto(result)}, nil
 }
 
-// UpdateMany implements proto.UserServiceServer.
-// Note: The proto currently defines the response as Empty.
-// This implementation calls the usecase which returns updated entities, but discards them to match the proto.
-func (s *userServer) UpdateMany(ctx context.Context, req *pb.UpdateUsersRequest) (*emptypb.Empty, error) {
-	if req == nil || len(req.Items) == 0 {
-		return &emptypb.Empty{}, nil // Nothing to update
+// CreateUsersStream implements proto.UserServiceServer. Users are created DB_BATCH_SIZE at a time as
+// they arrive; users that fail mapping or insertion are reported instead of aborting the stream.
+func (s *userServer) CreateUsersStream(stream grpc.ClientStreamingServer[pb.CreateUserRequest, pb.CreateUsersStreamResponse]) error {
+	opts := coreTypes.DefaultBatchOptions()
+	opts.ContinueOnError = true
+
+	summary, err := coreController.CreateFromStream(stream.Context(), stream.Recv, s.mapper.ProtoCreateToEntity, s.uc, opts)
+	if err != nil {
+		return err // The stream broke; with ContinueOnError item failures are in the summary
+	}
+
+	createdIDs := make([]string, 0, len(summary.CreatedIDs))
+	for _, id := range summary.CreatedIDs {
+		createdIDs = append(createdIDs, id.String())
 	}
+	return stream.SendAndClose(&pb.CreateUsersStreamResponse{
+		Received:   int32(summary.Received),
+		CreatedIds: createdIDs,
+		Failures:   coreTypes.BatchFailuresToProto(summary.Failed),
+	})
+}
 
-	// Map proto request items to the map expected by the use case
-	// Instead of mapping to DTOs, fetch entities and apply updates
-	entitiesToUpdate := make([]*entity.User, 0, len(req.Items))
-	for i, item := range req.Items {
+// UpdateMany implements proto.UserServiceServer. Each item is applied independently; items with an
+// invalid ID, an unknown user or invalid fields are reported in the result instead of failing the request.
+func (s *userServer) UpdateMany(ctx context.Context, req *pb.UpdateUsersRequest) (*pb.UpdateUsersResponse, error) {
+	result := coreTypes.NewBulkResult(len(req.GetItems()))
+	entitiesToUpdate := make([]*entity.User, 0, len(req.GetItems()))
+	indices := make([]int, 0, len(req.GetItems()))
+	for i, item := range req.GetItems() {
 		id, err := uuid.Parse(item.GetId())
 		if err != nil {
-			return nil, status.Errorf(http.StatusBadRequest, "invalid user ID format for item %d: %v", i, err)
+			result.Fail(i, fmt.Sprintf("invalid user ID format: %v", err))
+			continue
 		}
 
 		// Fetch existing entity
 		existingUser, err := s.uc.GetByID(ctx, id)
 		if err != nil {
-			return nil, coreController.MapErrorToHttpStatus(err)
+			result.Fail(i, coreUsecase.FailureReason(ctx, err))
+			continue
 		}
 
 		// Create a temporary UpdateUserRequest from the item to reuse mapping logic
@@ -270,47 +370,51 @@ func (s *userServer) UpdateMany(ctx context.Context, req *pb.UpdateUsersRequest)
 			Address:    item.Address,
 			Age:        item.Age,
 			ProfilePic: item.ProfilePic,
+			UpdateMask: item.UpdateMask,
 		}
 		if err := s.mapper.ApplyProtoUpdateToEntity(updateReq, existingUser); err != nil {
-			return nil, status.Errorf(http.StatusBadRequest, "failed to map update item %d (ID: %s): %v", i, id, err)
+			result.Fail(i, coreUsecase.FailureReason(ctx, err))
+			continue
 		}
 
-		entitiesToUpdate = append(entitiesToUpdate, existingUser)
+		entitiesToUpdate, indices = append(entitiesToUpdate, existingUser), append(indices, i)
 	}
 
-	// Call the use case UpdateMany, capturing the returned updated entities and error
-	_, err := s.uc.UpdateMany(ctx, entitiesToUpdate) // Capture and discard the returned slice for now
-	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+	if len(entitiesToUpdate) > 0 {
+		updated, err := s.uc.UpdateMany(ctx, entitiesToUpdate)
+		if err != nil {
+			return nil, coreController.FromUseCaseError(ctx, err)
+		}
+		result.Merge(updated, indices)
 	}
 
-	// Return empty response on success as defined by the current proto
-	return &emptypb.Empty{}, nil
+	return &pb.UpdateUsersResponse{Result: coreTypes.BulkResultToProto(result)}, nil
 }
 
-// DeleteMany implements proto.UserServiceServer (handles soft and hard delete).
-func (s *userServer) DeleteMany(ctx context.Context, req *pb.DeleteUsersRequest) (*emptypb.Empty, error) {
-	if req == nil || len(req.Ids) == 0 {
-		return &emptypb.Empty{}, nil // Nothing to delete
-	}
-
-	hardDelete := req.GetHardDelete()
-	uuidSlice := make([]uuid.UUID, 0, len(req.Ids))
-
-	for i, idStr := range req.Ids {
+// DeleteMany implements proto.UserServiceServer (handles soft and hard delete). Malformed and
+// unknown IDs are reported in the result instead of failing the request.
+func (s *userServer) DeleteMany(ctx context.Context, req *pb.DeleteUsersRequest) (*pb.DeleteUsersResponse, error) {
+	result := coreTypes.NewBulkResult(len(req.GetIds()))
+	uuidSlice := make([]uuid.UUID, 0, len(req.GetIds()))
+	indices := make([]int, 0, len(req.GetIds()))
+	for i, idStr := range req.GetIds() {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
-			return nil, status.Errorf(http.StatusBadRequest, "invalid user ID format at index %d: %v", i, err)
+			result.Fail(i, fmt.Sprintf("invalid user ID format: %v", err))
+			continue
 		}
-		uuidSlice = append(uuidSlice, id)
+		uuidSlice, indices = append(uuidSlice, id), append(indices, i)
 	}
 
-	// Call the consolidated use case method
-	if err := s.uc.DeleteMany(ctx, uuidSlice, hardDelete); err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+	if len(uuidSlice) > 0 {
+		deleted, err := s.uc.DeleteMany(ctx, uuidSlice, req.GetHardDelete())
+		if err != nil {
+			return nil, coreController.FromUseCaseError(ctx, err)
+		}
+		result.Merge(deleted, indices)
 	}
 
-	return &emptypb.Empty{}, nil
+	return &pb.DeleteUsersResponse{Result: coreTypes.BulkResultToProto(result)}, nil
 }
 
 // Login implements proto.UserServiceServer.
@@ -318,19 +422,19 @@ func (s *userServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 	// Map proto to schema.LoginCredentials
 	creds, err := s.mapper.ProtoLoginToSchema(req)
 	if err != nil {
-		return nil, status.Errorf(http.StatusBadRequest, "failed to map login request: %v", err)
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "failed to map login request: %v", err)
 	}
 
 	// Call use case Login, which now returns schema.LoginResult
 	loginResult, err := s.uc.Login(ctx, creds)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// Map the schema.LoginResult to proto response using the mapper
 	response, err := s.mapper.SchemaLoginResultToProto(loginResult)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to map login result: %v", err)
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map login result: %v", err)
 	}
 
 	return response, nil
@@ -340,20 +444,308 @@ func (s *userServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 func (s *userServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
 	refreshToken := req.GetRefreshToken()
 	if refreshToken == "" {
-		return nil, status.Errorf(http.StatusBadRequest, "refresh token cannot be empty")
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "refresh token cannot be empty")
 	}
 
 	// Call use case Refresh, returns schema.RefreshResult
 	refreshResult, err := s.uc.Refresh(ctx, refreshToken)
 	if err != nil {
-		return nil, coreController.MapErrorToHttpStatus(err)
+		return nil, coreController.FromUseCaseError(ctx, err)
 	}
 
 	// Map the schema.RefreshResult to proto response using the mapper
 	response, err := s.mapper.SchemaRefreshResultToProto(refreshResult)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to map refresh result: %v", err)
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map refresh result: %v", err)
+	}
+
+	return response, nil
+}
+
+// Logout implements proto.UserServiceServer.
+func (s *userServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*emptypb.Empty, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "refresh token cannot be empty")
+	}
+	if err := s.uc.Logout(ctx, req.GetRefreshToken()); err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Introspect implements proto.UserServiceServer.
+func (s *userServer) Introspect(ctx context.Context, req *pb.IntrospectRequest) (*pb.IntrospectResponse, error) {
+	if req.GetToken() == "" {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "token cannot be empty")
+	}
+	result, err := s.uc.Introspect(ctx, req.GetToken(), req.GetTokenTypeHint())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	if !result.Active {
+		return &pb.IntrospectResponse{Active: false}, nil
+	}
+
+	resp := &pb.IntrospectResponse{
+		Active:    true,
+		TokenType: result.TokenType,
+		UserId:    result.Claims.UserID.String(),
+		Email:     result.Claims.Email,
+		Role:      result.Claims.Role,
+		OrgRole:   result.Claims.OrgRole,
+		Scopes:    result.Claims.Scopes,
+		TokenId:   result.TokenID,
+		Issuer:    result.Issuer,
+		Audience:  result.Audience,
+	}
+	if result.Claims.TenantID != uuid.Nil {
+		resp.TenantId = result.Claims.TenantID.String()
+	}
+	if !result.IssuedAt.IsZero() {
+		resp.IssuedAt = result.IssuedAt.Unix()
+	}
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = result.ExpiresAt.Unix()
+	}
+	return resp, nil
+}
+
+// GetSecurityEvents implements proto.UserServiceServer.
+func (s *userServer) GetSecurityEvents(ctx context.Context, req *pb.GetSecurityEventsRequest) (*pb.GetSecurityEventsResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+
+	result, err := s.uc.GetSecurityEvents(ctx, userID, opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.SecurityEventsToProto(result)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map security events: %v", err)
+	}
+
+	return response, nil
+}
+
+// ListUserHistory implements proto.UserServiceServer.
+func (s *userServer) ListUserHistory(ctx context.Context, req *pb.ListUserHistoryRequest) (*pb.ListUserHistoryResponse, error) {
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+
+	result, err := s.uc.ListUserHistory(ctx, userID, opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.UserHistoryToProto(result)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map user history: %v", err)
+	}
+	return response, nil
+}
+
+// GetUserAsOf implements proto.UserServiceServer.
+func (s *userServer) GetUserAsOf(ctx context.Context, req *pb.GetUserAsOfRequest) (*pb.GetUserAsOfResponse, error) {
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+	if req.GetAsOf() == nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "as_of is required")
+	}
+	if err := req.GetAsOf().CheckValid(); err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid as_of: %v", err)
+	}
+
+	version, err := s.uc.GetUserAsOf(ctx, userID, req.GetAsOf().AsTime())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	versionProto, err := s.mapper.UserVersionToProto(version)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map user version: %v", err)
+	}
+	return &pb.GetUserAsOfResponse{Version: versionProto}, nil
+}
+
+// DiffUserVersions implements proto.UserServiceServer.
+func (s *userServer) DiffUserVersions(ctx context.Context, req *pb.DiffUserVersionsRequest) (*pb.DiffUserVersionsResponse, error) {
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+
+	diff, err := s.uc.DiffUserVersions(ctx, userID, req.GetFromVersion(), req.GetToVersion())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.UserVersionDiffToProto(diff)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map user version diff: %v", err)
+	}
+	return response, nil
+}
+
+// AnonymizeUser implements proto.UserServiceServer.
+func (s *userServer) AnonymizeUser(ctx context.Context, req *pb.AnonymizeUserRequest) (*pb.AnonymizeUserResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+
+	tombstone, err := s.uc.AnonymizeUser(ctx, id, req.GetReason())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.TombstoneToProto(tombstone)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map erasure tombstone: %v", err)
+	}
+
+	return response, nil
+}
+
+// InviteUser implements proto.UserServiceServer.
+func (s *userServer) InviteUser(ctx context.Context, req *pb.InviteUserRequest) (*pb.InviteUserResponse, error) {
+	user, err := s.mapper.ProtoInviteToEntity(req)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	result, err := s.uc.InviteUser(ctx, user)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.InviteResultToProto(result)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map invitation: %v", err)
+	}
+
+	return response, nil
+}
+
+// ResendInvite implements proto.UserServiceServer.
+func (s *userServer) ResendInvite(ctx context.Context, req *pb.ResendInviteRequest) (*pb.InviteUserResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid user ID format: %v", err)
 	}
 
+	result, err := s.uc.ResendInvite(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.InviteResultToProto(result)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map invitation: %v", err)
+	}
+
+	return response, nil
+}
+
+// AcceptInvite implements proto.UserServiceServer.
+func (s *userServer) AcceptInvite(ctx context.Context, req *pb.AcceptInviteRequest) (*pb.AcceptInviteResponse, error) {
+	user, err := s.uc.AcceptInvite(ctx, req.GetToken(), req.GetPassword())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	userProto, err := s.mapper.EntityToProto(user)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map user: %v", err)
+	}
+
+	return &pb.AcceptInviteResponse{User: userProto}, nil
+}
+
+// ExportMyData implements proto.UserServiceServer.
+func (s *userServer) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	export, err := s.exports.RequestExport(ctx, entity.ExportFormat(req.GetFormat()))
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.DataExportToProto(export)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map data export: %v", err)
+	}
+
+	return response, nil
+}
+
+// GetDataExport implements proto.UserServiceServer.
+func (s *userServer) GetDataExport(ctx context.Context, req *pb.GetDataExportRequest) (*pb.DataExport, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid export ID format: %v", err)
+	}
+
+	export, err := s.exports.GetExport(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	response, err := s.mapper.DataExportToProto(export)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to map data export: %v", err)
+	}
+
+	return response, nil
+}
+
+// DownloadDataExport implements proto.UserServiceServer.
+// The archive is sent as one message, so the gateway's MAX_RECV_MSG_SIZE must allow its size.
+func (s *userServer) DownloadDataExport(ctx context.Context, req *pb.GetDataExportRequest) (*httpbody.HttpBody, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid export ID format: %v", err)
+	}
+
+	_, archive, err := s.exports.OpenExport(ctx, id)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	defer archive.Close()
+
+	data, err := io.ReadAll(archive)
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.Internal, "failed to read data export: %v", err)
+	}
+
+	return &httpbody.HttpBody{ContentType: "application/zip", Data: data}, nil
+}
+
+// ListMyPermissions implements proto.UserServiceServer.
+func (s *userServer) ListMyPermissions(ctx context.Context, _ *emptypb.Empty) (*pb.ListMyPermissionsResponse, error) {
+	actor, ok := coreUsecase.ActorFromContext(ctx)
+	if !ok {
+		return nil, coreController.GrpcErrorf(codes.Unauthenticated, "authentication required")
+	}
+
+	granted := permissions.Default().Permissions(actor.Role)
+	response := &pb.ListMyPermissionsResponse{Role: actor.Role, Permissions: make([]string, len(granted))}
+	for i, p := range granted {
+		response.Permissions[i] = p.String()
+	}
 	return response, nil
 }
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/pkg/webhooks"
+	pb "golang-microservices-boilerplate/proto/user-service"
+)
+
+// webhookServer implements pb.WebhookServiceServer on top of the webhooks service
+type webhookServer struct {
+	pb.UnimplementedWebhookServiceServer
+	svc *webhooks.Service
+}
+
+// RegisterWebhookServiceServer registers the webhook management service with the gRPC server.
+func RegisterWebhookServiceServer(s *grpc.Server, svc *webhooks.Service) {
+	pb.RegisterWebhookServiceServer(s, &webhookServer{svc: svc})
+}
+
+// CreateWebhook implements proto.WebhookServiceServer.
+func (s *webhookServer) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.WebhookSubscription, error) {
+	sub, err := s.svc.Subscribe(ctx, req.GetUrl(), req.GetEventTypes(), req.GetDescription())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	resp := subscriptionToProto(sub)
+	resp.Secret = sub.Secret // Only exposed on creation
+	return resp, nil
+}
+
+// ListWebhooks implements proto.WebhookServiceServer.
+func (s *webhookServer) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+	result, err := s.svc.List(ctx, opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	resp := &pb.ListWebhooksResponse{
+		Webhooks:       make([]*pb.WebhookSubscription, 0, len(result.Items)),
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
+	}
+	for _, sub := range result.Items {
+		resp.Webhooks = append(resp.Webhooks, subscriptionToProto(sub))
+	}
+	return resp, nil
+}
+
+// SetWebhookActive implements proto.WebhookServiceServer.
+func (s *webhookServer) SetWebhookActive(ctx context.Context, req *pb.SetWebhookActiveRequest) (*pb.WebhookSubscription, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid webhook ID format: %v", err)
+	}
+	sub, err := s.svc.SetActive(ctx, id, req.GetActive())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return subscriptionToProto(sub), nil
+}
+
+// DeleteWebhook implements proto.WebhookServiceServer.
+func (s *webhookServer) DeleteWebhook(ctx context.Context, req *pb.WebhookIDRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid webhook ID format: %v", err)
+	}
+	if err := s.svc.Delete(ctx, id, false); err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListWebhookDeliveries implements proto.WebhookServiceServer.
+func (s *webhookServer) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid webhook ID format: %v", err)
+	}
+	opts, err := coreTypes.FilterOptionsFromProto(req.GetOptions())
+	if err != nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid list options: %v", err)
+	}
+	result, err := s.svc.Deliveries(ctx, id, opts)
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	resp := &pb.ListWebhookDeliveriesResponse{
+		Deliveries:     make([]*pb.WebhookDelivery, 0, len(result.Items)),
+		PaginationInfo: coreTypes.PaginationInfoToProto(result),
+	}
+	for _, d := range result.Items {
+		delivery := &pb.WebhookDelivery{
+			Id:             d.ID.String(),
+			EventId:        d.EventID.String(),
+			EventType:      d.EventType,
+			Status:         string(d.Status),
+			Attempts:       int32(d.Attempts),
+			LastStatusCode: int32(d.LastStatusCode),
+			LastError:      d.LastError,
+			CreatedAt:      timestamppb.New(d.CreatedAt),
+		}
+		if d.Status == webhooks.DeliveryPending {
+			delivery.NextAttemptAt = timestamppb.New(d.NextAttemptAt)
+		}
+		if d.DeliveredAt != nil {
+			delivery.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+		}
+		resp.Deliveries = append(resp.Deliveries, delivery)
+	}
+	return resp, nil
+}
+
+// subscriptionToProto maps a subscription without its secret
+func subscriptionToProto(sub *webhooks.Subscription) *pb.WebhookSubscription {
+	return &pb.WebhookSubscription{
+		Id:          sub.ID.String(),
+		Url:         sub.URL,
+		EventTypes:  sub.Events(),
+		Description: sub.Description,
+		Active:      sub.Active,
+		CreatedAt:   timestamppb.New(sub.CreatedAt),
+	}
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+
+	"github.com/google/uuid"
+)
+
+// ExportFormat is the layout of the files in a data export archive
+type ExportFormat string
+
+const (
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatCSV  ExportFormat = "csv"
+)
+
+// IsValid checks if the format is one of the supported formats.
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatJSON || f == ExportFormatCSV
+}
+
+// DataExportStatus is the state of a data export
+type DataExportStatus string
+
+const (
+	DataExportPending DataExportStatus = "pending" // Queued or being generated
+	DataExportReady   DataExportStatus = "ready"   // The archive can be downloaded until ExpiresAt
+	DataExportFailed  DataExportStatus = "failed"  // Generation gave up; Error says why
+	DataExportExpired DataExportStatus = "expired" // The archive was deleted
+)
+
+// DataExport is a user's request for a copy of the data held about them, and the resulting archive
+type DataExport struct {
+	entity.BaseEntity                  // Embed core base entity
+	UserID            uuid.UUID        `json:"user_id" gorm:"type:uuid;not null;index"`
+	Format            ExportFormat     `json:"format" gorm:"size:8;not null"`
+	Status            DataExportStatus `json:"status" gorm:"size:16;not null;index"`
+	BlobKey           string           `json:"-" gorm:"size:255"` // Key of the zip archive in the blob store
+	SizeBytes         int64            `json:"size_bytes,omitempty"`
+	Error             string           `json:"error,omitempty" gorm:"type:text"`
+	CompletedAt       *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt         *time.Time       `json:"expires_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (DataExport) TableName() string {
+	return "data_exports"
+}
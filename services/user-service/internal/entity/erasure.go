@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+
+	"github.com/google/uuid"
+)
+
+// ErasedName replaces the first and last name of an anonymized user
+const ErasedName = "redacted"
+
+// ErasedEmailDomain is the domain of the placeholder email of an anonymized user; .invalid never resolves
+const ErasedEmailDomain = "erased.invalid"
+
+// ErasureTombstone records that a user's personal data was irreversibly erased. It keeps no PII:
+// the email is stored as a hash, so a repeated request for the same address can be recognised.
+type ErasureTombstone struct {
+	entity.BaseEntity            // Embed core base entity
+	UserID            uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	EmailHash         string     `json:"email_hash" gorm:"size:64;not null;index"`
+	RequestedBy       *uuid.UUID `json:"requested_by,omitempty" gorm:"type:uuid"` // Nil when the caller is not a known user
+	Reason            string     `json:"reason,omitempty" gorm:"type:text"`
+	ErasedAt          time.Time  `json:"erased_at" gorm:"not null"`
+}
+
+// TableName overrides the table name
+func (ErasureTombstone) TableName() string {
+	return "user_erasures"
+}
+
+// HashEmail returns the hex SHA-256 of the normalised email address
+func HashEmail(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
+
+// ErasedEmail is the unique, undeliverable email that replaces the address of an anonymized user
+func ErasedEmail(emailHash string) string {
+	return emailHash + "@" + ErasedEmailDomain
+}
+
+// IsErased reports whether the user's personal data has been erased
+func (u *User) IsErased() bool {
+	return strings.HasSuffix(u.Email, "@"+ErasedEmailDomain)
+}
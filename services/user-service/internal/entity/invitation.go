@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+
+	"github.com/google/uuid"
+)
+
+// Invitation is the pending invitation of a user created without a password. The user stays
+// inactive until the invite token is accepted with a password. Only the hash of the token is
+// stored; resending replaces it, so earlier links stop working.
+type Invitation struct {
+	entity.BaseEntity            // Embed core base entity
+	UserID            uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	TokenHash         string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	InvitedBy         *uuid.UUID `json:"invited_by,omitempty" gorm:"type:uuid"` // Nil when the caller is not a known user
+	ExpiresAt         time.Time  `json:"expires_at" gorm:"not null"`
+	SentCount         int        `json:"sent_count" gorm:"not null"`
+	LastSentAt        time.Time  `json:"last_sent_at" gorm:"not null"`
+	AcceptedAt        *time.Time `json:"accepted_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (Invitation) TableName() string {
+	return "invitations"
+}
+
+// IsExpired reports whether the invite token can no longer be accepted at now
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// Issue replaces the invite token with a new one valid for ttl and returns it. The token is not
+// stored and cannot be recovered later.
+func (i *Invitation) Issue(now time.Time, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	i.TokenHash = HashInviteToken(token)
+	i.ExpiresAt = now.Add(ttl)
+	i.LastSentAt = now
+	i.SentCount++
+	return token, nil
+}
+
+// HashInviteToken returns the hex SHA-256 of an invite token, as stored in TokenHash
+func HashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
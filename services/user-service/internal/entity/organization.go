@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"golang-microservices-boilerplate/pkg/core/entity"
+
+	"github.com/google/uuid"
+)
+
+// MemberRole is the role of a user within an organization, independent of the user's global Role
+type MemberRole string
+
+const (
+	MemberOwner  MemberRole = "owner"  // Manages the organization and its members, including other owners
+	MemberAdmin  MemberRole = "admin"  // Manages the members other than owners
+	MemberMember MemberRole = "member" // Sees the organization's users
+)
+
+// IsValid reports whether r is one of the defined member roles
+func (r MemberRole) IsValid() bool {
+	switch r {
+	case MemberOwner, MemberAdmin, MemberMember:
+		return true
+	}
+	return false
+}
+
+// CanManage reports whether a member with role r may add, remove or change members with role other
+func (r MemberRole) CanManage(other MemberRole) bool {
+	switch r {
+	case MemberOwner:
+		return true
+	case MemberAdmin:
+		return other != MemberOwner
+	}
+	return false
+}
+
+// Organization groups users; users see the members of the organization their token is bound to
+type Organization struct {
+	entity.BaseEntity           // Embed core base entity
+	Name              string    `json:"name" gorm:"size:100;not null"`
+	Slug              string    `json:"slug" gorm:"size:64;not null;uniqueIndex"` // URL-safe unique name
+	CreatedBy         uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+}
+
+// TableName overrides the table name
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// Membership is a user's membership of an organization
+type Membership struct {
+	entity.BaseEntity            // Embed core base entity
+	OrganizationID    uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex:idx_memberships_org_user"`
+	UserID            uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_memberships_org_user;index"`
+	Role              MemberRole `json:"role" gorm:"size:16;not null"`
+	InvitedBy         *uuid.UUID `json:"invited_by,omitempty" gorm:"type:uuid"` // Nil for the creator of the organization
+}
+
+// TableName overrides the table name
+func (Membership) TableName() string {
+	return "memberships"
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/report"
+
+	"github.com/google/uuid"
+)
+
+// ReportStatus is the state of a report
+type ReportStatus string
+
+const (
+	ReportPending ReportStatus = "pending" // Queued or being rendered
+	ReportReady   ReportStatus = "ready"   // The file can be downloaded until ExpiresAt
+	ReportFailed  ReportStatus = "failed"  // Rendering gave up; Error says why
+	ReportExpired ReportStatus = "expired" // The file was deleted
+)
+
+// Report is a user's request for a rendered report, and the resulting file
+type Report struct {
+	entity.BaseEntity               // Embed core base entity
+	UserID            uuid.UUID     `json:"user_id" gorm:"type:uuid;not null;index"`
+	Type              string        `json:"type" gorm:"size:64;not null"` // Name of the report template
+	Format            report.Format `json:"format" gorm:"size:8;not null"`
+	Params            string        `json:"-" gorm:"type:text"` // Template parameters as a JSON object
+	Status            ReportStatus  `json:"status" gorm:"size:16;not null;index"`
+	BlobKey           string        `json:"-" gorm:"size:255"` // Key of the file in the blob store
+	SizeBytes         int64         `json:"size_bytes,omitempty"`
+	Error             string        `json:"error,omitempty" gorm:"type:text"`
+	CompletedAt       *time.Time    `json:"completed_at,omitempty"`
+	ExpiresAt         *time.Time    `json:"expires_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (Report) TableName() string {
+	return "reports"
+}
+
+// ParamMap decodes the template parameters
+func (r *Report) ParamMap() map[string]string {
+	params := map[string]string{}
+	if r.Params != "" {
+		_ = json.Unmarshal([]byte(r.Params), &params)
+	}
+	return params
+}
+
+// SetParams encodes the template parameters
+func (r *Report) SetParams(params map[string]string) {
+	if len(params) == 0 {
+		r.Params = ""
+		return
+	}
+	data, _ := json.Marshal(params)
+	r.Params = string(data)
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"golang-microservices-boilerplate/pkg/core/entity"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType identifies the kind of security-relevant action that was recorded.
+type SecurityEventType string
+
+const (
+	SecurityEventLoginSuccess   SecurityEventType = "login_success"
+	SecurityEventLoginFailed    SecurityEventType = "login_failed"
+	SecurityEventTokenRefresh   SecurityEventType = "token_refresh"
+	SecurityEventLogout         SecurityEventType = "logout"
+	SecurityEventPasswordChange SecurityEventType = "password_change"
+)
+
+// SecurityEvent is an append-only record of an authentication or credential event for a user.
+type SecurityEvent struct {
+	entity.BaseEntity // Embed core base entity
+	// UserID is nil when the event could not be tied to a known user (e.g. login with unknown email)
+	UserID    *uuid.UUID        `json:"user_id,omitempty" gorm:"type:uuid;index"`
+	Email     string            `json:"email,omitempty" gorm:"size:255;index"`
+	EventType SecurityEventType `json:"event_type" gorm:"size:32;not null;index"`
+	IPAddress string            `json:"ip_address,omitempty" gorm:"size:64"`
+	UserAgent string            `json:"user_agent,omitempty" gorm:"size:512"`
+	Details   string            `json:"details,omitempty" gorm:"type:text"` // Free-form reason, e.g. why a login failed
+}
+
+// TableName overrides the table name
+func (SecurityEvent) TableName() string {
+	return "security_events"
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"golang-microservices-boilerplate/pkg/core/dto"
 	"golang-microservices-boilerplate/pkg/core/entity"
 
 	"github.com/google/uuid"
@@ -47,8 +48,10 @@ type User struct {
 	LastLoginAt *time.Time `json:"last_login_at,omitempty" gorm:"default:null"`
 	// Add other fields from proto if they belong in the core domain model
 	// Example: Phone, Address, ProfilePic, Age might or might not be core domain fields
-	Phone      string `json:"phone,omitempty" gorm:"size:20"`
-	Address    string `json:"address,omitempty" gorm:"type:text"`
+	// Phone and Address are encrypted at rest (see database.EncryptedSerializer); phone stays
+	// searchable by exact match through database.SearchableValues
+	Phone      string `json:"phone,omitempty" gorm:"type:text;serializer:encrypted_searchable"`
+	Address    string `json:"address,omitempty" gorm:"type:text;serializer:encrypted"`
 	Age        int32  `json:"age,omitempty"`
 	ProfilePic string `json:"profile_pic,omitempty" gorm:"size:255"`
 }
@@ -58,6 +61,16 @@ func (User) TableName() string {
 	return "users"
 }
 
+// FilterableFields lists the columns list queries may filter, sort and group users on. Password
+// hashes are left out, and so is the address, which is not searchable; the phone can be matched
+// exactly.
+func (User) FilterableFields() []string {
+	return []string{
+		"id", "username", "email", "first_name", "last_name", "role", "is_active", "age", "phone",
+		"last_login_at", "created_at", "updated_at", "deleted_at",
+	}
+}
+
 // Add required methods for core.Entity interface with value receivers
 func (u User) GetID() uuid.UUID {
 	return u.ID
@@ -106,6 +119,11 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 		}
 	}
 
+	// Validate before hashing, so the length of a new password can be checked
+	if err := u.Validate(); err != nil {
+		return err
+	}
+
 	// Hash password if provided and not already hashed
 	if u.Password != "" && !isHashedPassword(u.Password) {
 		err := u.SetPassword(u.Password)
@@ -117,7 +135,7 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	// GORM's default:false for IsActive will handle the initial state.
 	// No need to explicitly set u.IsActive = false here.
 
-	return u.Validate() // Validate before saving
+	return nil
 }
 
 // BeforeUpdate hook
@@ -127,6 +145,11 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 		return err
 	}
 
+	// Validate before hashing, so the length of a new password can be checked
+	if err := u.Validate(); err != nil {
+		return err
+	}
+
 	// Hash password if it's being updated and is not already hashed
 	// Check if the password field is actually being updated if GORM allows partial updates easily
 	if u.Password != "" && !isHashedPassword(u.Password) {
@@ -137,30 +160,26 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 		}
 	}
 
-	return u.Validate() // Validate before updating
+	return nil
+}
+
+// userRules are the validation rules of the user fields checked by Validate
+type userRules struct {
+	Email    string `json:"email" validate:"required,email"`
+	Username string `json:"username" validate:"required"`
+	Role     Role   `json:"role" validate:"oneof=admin manager officer"`
+	Password string `json:"password" validate:"omitempty,min=8"` // Only a password not hashed yet
 }
 
-// Validate performs validation on the user data
+// Validate performs validation on the user data. The error is a dto.ValidationErrors, which the use
+// cases report as invalid input with one violation per field (see usecase.NewValidationError).
 func (u *User) Validate() error {
-	if u.Email == "" {
-		return errors.New("email is required")
-	}
-	if !strings.Contains(u.Email, "@") {
-		return errors.New("email format is invalid")
-	}
-	// Password validation might only be needed if it's not already hashed
-	if u.Password != "" && len(u.Password) < 8 && !isHashedPassword(u.Password) {
-		return errors.New("password must be at least 8 characters")
-	}
-	// Validate role is one of the allowed non-empty values
-	if !u.Role.IsValid() {
-		return errors.New("invalid role: must be admin, manager, or officer")
-	}
-	if u.Username == "" {
-		return errors.New("username is required") // Assuming username becomes mandatory
+	rules := userRules{Email: u.Email, Username: u.Username, Role: u.Role}
+	if !isHashedPassword(u.Password) {
+		rules.Password = u.Password
 	}
 	// Add other validations (e.g., name length, age constraints)
-	return nil
+	return dto.Validate(rules)
 }
 
 // SetPassword hashes and sets the user password safely
@@ -194,6 +213,11 @@ func HashPassword(password string) (string, error) {
 	return string(hashedBytes), nil
 }
 
+// HasPendingPasswordChange reports whether Password holds a new plain-text value that will be hashed on save
+func (u *User) HasPendingPasswordChange() bool {
+	return u.Password != "" && !isHashedPassword(u.Password)
+}
+
 // isHashedPassword checks if the password is already hashed with bcrypt
 func isHashedPassword(password string) bool {
 	// Basic check for bcrypt hash format
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"encoding/json"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+
+	"github.com/google/uuid"
+)
+
+// UserHistory is an append-only version of a users row, recorded in the transaction of every create,
+// update and delete (see repository.UserChangeCapture). CreatedAt is when the change was made.
+type UserHistory struct {
+	entity.BaseEntity           // Embed core base entity
+	UserID            uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_user_history_version,priority:1"`
+	Version           int64     `json:"version" gorm:"not null;uniqueIndex:idx_user_history_version,priority:2"` // 1 for the creation, then one more per change
+	Operation         string    `json:"operation" gorm:"size:10;not null"`
+	// Snapshot is the row after the change as JSON, without the password hash. It holds personal
+	// data, so it is encrypted at rest like the user's own encrypted columns.
+	Snapshot        string     `json:"-" gorm:"type:text;serializer:encrypted"`
+	ChangedFields   string     `json:"-" gorm:"type:text"` // JSON names of the fields that differ from the previous version, as a JSON array
+	PasswordChanged bool       `json:"password_changed,omitempty"`
+	ActorID         *uuid.UUID `json:"actor_id,omitempty" gorm:"type:uuid"` // Nil for changes without an authenticated caller
+}
+
+// TableName overrides the table name
+func (UserHistory) TableName() string {
+	return "user_history"
+}
+
+// ChangedFieldList decodes the changed fields
+func (h *UserHistory) ChangedFieldList() []string {
+	var fields []string
+	if h.ChangedFields != "" {
+		_ = json.Unmarshal([]byte(h.ChangedFields), &fields)
+	}
+	return fields
+}
+
+// SetChangedFields encodes the changed fields
+func (h *UserHistory) SetChangedFields(fields []string) {
+	if len(fields) == 0 {
+		h.ChangedFields = ""
+		return
+	}
+	data, _ := json.Marshal(fields)
+	h.ChangedFields = string(data)
+}
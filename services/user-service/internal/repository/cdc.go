@@ -0,0 +1,433 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	core_events "golang-microservices-boilerplate/pkg/core/events"
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+)
+
+// EventUserChanged is published for every committed change of a users row
+const EventUserChanged = "user.changed"
+
+// Change operations of a UserChange
+const (
+	ChangeCreate  = "create"
+	ChangeUpdate  = "update"
+	ChangeDelete  = "delete"
+	ChangeRestore = "restore"
+)
+
+// UserSnapshot is the published state of a users row; the password hash is left out
+type UserSnapshot struct {
+	ID          uuid.UUID  `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Role        string     `json:"role"`
+	IsActive    bool       `json:"is_active"`
+	Phone       string     `json:"phone"`
+	Address     string     `json:"address"`
+	Age         int32      `json:"age"`
+	ProfilePic  string     `json:"profile_pic"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+// UserChange is the data of a user.changed event. Old is nil for creates, New is nil for hard
+// deletes. Setting deleted_at is published as a delete, clearing it as a restore.
+type UserChange struct {
+	Operation       string        `json:"operation"`
+	ID              uuid.UUID     `json:"id"`
+	Old             *UserSnapshot `json:"old,omitempty"`
+	New             *UserSnapshot `json:"new,omitempty"`
+	ChangedFields   []string      `json:"changed_fields,omitempty"` // JSON names of the fields that differ
+	PasswordChanged bool          `json:"password_changed,omitempty"`
+}
+
+// Statement settings carrying captured rows between callbacks
+const (
+	cdcOldRowsKey = "user:cdc:old"
+	cdcChangesKey = "user:cdc:changes"
+)
+
+// cdcErasureKey marks a statement that erases personal data (see UserRepository.Erase); its changes
+// are published without the old snapshot
+const cdcErasureKey = "user:cdc:erasure"
+
+// historyIgnoredFields are fields whose changes alone record no version: the last login time
+// changes on every login, which the security events already record
+var historyIgnoredFields = map[string]bool{"last_login_at": true}
+
+// UserChangeCapture is a GORM plugin that publishes a user.changed event for each users row
+// created, updated or deleted through GORM, including bulk writes and UpdateWhere. Rows changed by a
+// statement are read inside its transaction and published once that transaction committed: a
+// statement running inside a longer transaction, such as the request transaction
+// (DB_REQUEST_TRANSACTIONS), Erase or a cascading delete, hands its changes to
+// core_repo.AfterCommit, so a rollback publishes nothing. Dry runs never publish.
+//
+// With WithHistory, each change is also recorded as a version in user_history, inside the
+// statement's transaction, so versions are rolled back with the change; a change whose version
+// cannot be recorded fails.
+type UserChangeCapture struct {
+	events  core_events.Publisher // Nil to record history only
+	logger  core_logger.Logger
+	history bool
+}
+
+// NewUserChangeCapture creates the plugin; register it with db.Use
+func NewUserChangeCapture(events core_events.Publisher, logger core_logger.Logger) *UserChangeCapture {
+	return &UserChangeCapture{events: events, logger: logger}
+}
+
+// WithHistory records every change in user_history
+func (p *UserChangeCapture) WithHistory() *UserChangeCapture {
+	p.history = true
+	return p
+}
+
+// Name implements gorm.Plugin
+func (p *UserChangeCapture) Name() string {
+	return "user:cdc"
+}
+
+// Initialize implements gorm.Plugin
+func (p *UserChangeCapture) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:commit_or_rollback_transaction").Register("user:cdc:after_create", p.captureCreated),
+		cb.Create().After("gorm:commit_or_rollback_transaction").Register("user:cdc:publish_create", p.publish),
+		cb.Update().Before("gorm:update").Register("user:cdc:before_update", p.loadOld),
+		cb.Update().Before("gorm:commit_or_rollback_transaction").Register("user:cdc:after_update", p.captureUpdated),
+		cb.Update().After("gorm:commit_or_rollback_transaction").Register("user:cdc:publish_update", p.publish),
+		cb.Delete().Before("gorm:delete").Register("user:cdc:before_delete", p.loadOld),
+		cb.Delete().Before("gorm:commit_or_rollback_transaction").Register("user:cdc:after_delete", p.captureDeleted),
+		cb.Delete().After("gorm:commit_or_rollback_transaction").Register("user:cdc:publish_delete", p.publish),
+	)
+}
+
+// captures reports whether the statement writes the users table and should be captured
+func captures(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Schema != nil && db.Statement.Schema.Table == (entity.User{}).TableName() &&
+		!core_usecase.IsDryRun(db.Statement.Context)
+}
+
+// captureCreated records the inserted rows from the statement's destination
+func (p *UserChangeCapture) captureCreated(db *gorm.DB) {
+	if !captures(db) {
+		return
+	}
+	var changes []UserChange
+	for _, user := range usersIn(db.Statement.ReflectValue) {
+		snapshot := snapshotOf(user)
+		changes = append(changes, UserChange{Operation: ChangeCreate, ID: user.ID, New: &snapshot})
+	}
+	db.InstanceSet(cdcChangesKey, changes)
+	p.recordHistory(db)
+}
+
+// loadOld reads the rows an update or delete is about to change, inside the statement's transaction
+func (p *UserChangeCapture) loadOld(db *gorm.DB) {
+	if !captures(db) {
+		return
+	}
+	old, err := p.findAffected(db, nil)
+	if err != nil {
+		core_logger.FromContext(db.Statement.Context, p.logger).Warn("Failed to read users before change, change events skipped", "error", err)
+		return
+	}
+	db.InstanceSet(cdcOldRowsKey, old)
+}
+
+// captureUpdated diffs the rows read by loadOld against their new state
+func (p *UserChangeCapture) captureUpdated(db *gorm.DB) {
+	old, ok := oldRows(db)
+	if !ok || !captures(db) || len(old) == 0 {
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(old))
+	for _, user := range old {
+		ids = append(ids, user.ID)
+	}
+	current, err := p.findAffected(db, ids)
+	if err != nil {
+		core_logger.FromContext(db.Statement.Context, p.logger).Warn("Failed to read users after update, change events skipped", "error", err)
+		return
+	}
+	byID := make(map[uuid.UUID]entity.User, len(current))
+	for _, user := range current {
+		byID[user.ID] = user
+	}
+
+	var changes []UserChange
+	for _, before := range old {
+		after, ok := byID[before.ID]
+		if !ok {
+			continue
+		}
+		oldSnapshot, newSnapshot := snapshotOf(before), snapshotOf(after)
+		changed := changedFields(oldSnapshot, newSnapshot)
+		passwordChanged := before.Password != after.Password
+		if len(changed) == 0 && !passwordChanged {
+			continue
+		}
+		change := UserChange{
+			Operation:       ChangeUpdate,
+			ID:              before.ID,
+			Old:             &oldSnapshot,
+			New:             &newSnapshot,
+			ChangedFields:   changed,
+			PasswordChanged: passwordChanged,
+		}
+		switch {
+		case before.DeletedAt == nil && after.DeletedAt != nil:
+			change.Operation = ChangeDelete
+		case before.DeletedAt != nil && after.DeletedAt == nil:
+			change.Operation = ChangeRestore
+		}
+		if _, erasure := db.Get(cdcErasureKey); erasure {
+			change.Old = nil
+		}
+		changes = append(changes, change)
+	}
+	db.InstanceSet(cdcChangesKey, changes)
+	p.recordHistory(db)
+}
+
+// captureDeleted records the rows read by loadOld as deleted; only hard deletes get here, as soft
+// deletes update deleted_at
+func (p *UserChangeCapture) captureDeleted(db *gorm.DB) {
+	old, ok := oldRows(db)
+	if !ok || !captures(db) || db.RowsAffected == 0 {
+		return
+	}
+	var changes []UserChange
+	for _, user := range old {
+		snapshot := snapshotOf(user)
+		changes = append(changes, UserChange{Operation: ChangeDelete, ID: user.ID, Old: &snapshot})
+	}
+	db.InstanceSet(cdcChangesKey, changes)
+	p.recordHistory(db)
+}
+
+// publish sends the captured changes once the statement succeeded and its transaction committed.
+// A transaction the statement started itself has committed by now; an enclosing one has not.
+func (p *UserChangeCapture) publish(db *gorm.DB) {
+	value, ok := db.InstanceGet(cdcChangesKey)
+	if !ok || db.Error != nil || p.events == nil {
+		return
+	}
+	ctx := db.Statement.Context
+	changes := value.([]UserChange)
+	send := func() {
+		for _, change := range changes {
+			if err := p.events.Publish(ctx, core_events.NewEvent(EventUserChanged, change)); err != nil {
+				core_logger.FromContext(ctx, p.logger).Warn("Failed to publish user change", "user_id", change.ID, "operation", change.Operation, "error", err)
+			}
+		}
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		core_repo.AfterCommit(ctx, send)
+		return
+	}
+	send()
+}
+
+// recordHistory writes a user_history version for each captured change, on the statement's
+// connection before its transaction commits. Versions are numbered per user; concurrent changes of
+// the same user are serialized by the row lock of the change itself.
+func (p *UserChangeCapture) recordHistory(db *gorm.DB) {
+	value, ok := db.InstanceGet(cdcChangesKey)
+	if !ok || !p.history || db.Error != nil {
+		return
+	}
+	var changes []UserChange
+	for _, change := range value.([]UserChange) {
+		if change.Operation != ChangeUpdate || change.PasswordChanged || slices.ContainsFunc(change.ChangedFields, func(f string) bool { return !historyIgnoredFields[f] }) {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+	var latest []struct {
+		UserID  uuid.UUID
+		Version int64
+	}
+	err := tx.Model(&entity.UserHistory{}).Select("user_id, MAX(version) AS version").
+		Where("user_id IN ?", ids).Group("user_id").Scan(&latest).Error
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to record user history: %w", err))
+		return
+	}
+	versions := make(map[uuid.UUID]int64, len(latest))
+	for _, l := range latest {
+		versions[l.UserID] = l.Version
+	}
+
+	var actorID *uuid.UUID
+	if actor, ok := core_usecase.ActorFromContext(db.Statement.Context); ok {
+		if id, err := uuid.Parse(actor.ID); err == nil {
+			actorID = &id
+		}
+	}
+	now := time.Now().UTC()
+	rows := make([]entity.UserHistory, 0, len(changes))
+	for _, change := range changes {
+		snapshot := change.New
+		if snapshot == nil { // Hard delete
+			deleted := *change.Old
+			if deleted.DeletedAt == nil {
+				deleted.DeletedAt = &now
+			}
+			snapshot = &deleted
+		}
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			db.AddError(fmt.Errorf("failed to record user history: %w", err))
+			return
+		}
+		versions[change.ID]++
+		row := entity.UserHistory{
+			UserID:          change.ID,
+			Version:         versions[change.ID],
+			Operation:       change.Operation,
+			Snapshot:        string(data),
+			PasswordChanged: change.PasswordChanged,
+			ActorID:         actorID,
+		}
+		row.ID, row.CreatedAt, row.UpdatedAt = uuid.New(), now, now // Hooks are skipped
+		row.SetChangedFields(change.ChangedFields)
+		rows = append(rows, row)
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to record user history: %w", err))
+	}
+}
+
+// findAffected reads the users matched by the statement's conditions, or the given IDs, on the
+// statement's connection so an open transaction sees its own writes
+func (p *UserChangeCapture) findAffected(db *gorm.DB, ids []uuid.UUID) ([]entity.User, error) {
+	query := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Model(&entity.User{})
+	if ids != nil {
+		query = query.Where("id IN ?", ids)
+	} else {
+		where, hasWhere := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+		primaryKeys := usersIn(db.Statement.ReflectValue)
+		switch {
+		case hasWhere && len(where.Exprs) > 0:
+			query = query.Clauses(where)
+		case len(primaryKeys) == 1 && primaryKeys[0].ID != uuid.Nil:
+			query = query.Where("id = ?", primaryKeys[0].ID)
+		default:
+			return nil, nil // Nothing identifies the rows; GORM rejects such global writes anyway
+		}
+	}
+	var users []entity.User
+	err := query.Find(&users).Error
+	return users, err
+}
+
+// oldRows returns the rows read by loadOld for this statement
+func oldRows(db *gorm.DB) ([]entity.User, bool) {
+	value, ok := db.InstanceGet(cdcOldRowsKey)
+	if !ok {
+		return nil, false
+	}
+	users, ok := value.([]entity.User)
+	return users, ok
+}
+
+// usersIn returns the users held by a statement destination (a user, or a slice or array of users or pointers)
+func usersIn(value reflect.Value) []entity.User {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if user, ok := value.Interface().(entity.User); ok {
+			return []entity.User{user}
+		}
+	case reflect.Slice, reflect.Array:
+		var users []entity.User
+		for i := 0; i < value.Len(); i++ {
+			users = append(users, usersIn(value.Index(i))...)
+		}
+		return users
+	}
+	return nil
+}
+
+// snapshotOf converts a user to its published snapshot
+func snapshotOf(u entity.User) UserSnapshot {
+	return UserSnapshot{
+		ID:          u.ID,
+		Username:    u.Username,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Role:        string(u.Role),
+		IsActive:    u.IsActive,
+		Phone:       u.Phone,
+		Address:     u.Address,
+		Age:         u.Age,
+		ProfilePic:  u.ProfilePic,
+		LastLoginAt: u.LastLoginAt,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+		DeletedAt:   u.DeletedAt,
+	}
+}
+
+// SnapshotFieldNames lists the JSON names of the snapshot fields in order, without updated_at,
+// which changes with every other field
+func SnapshotFieldNames() []string {
+	t := reflect.TypeOf(UserSnapshot{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := t.Field(i).Tag.Get("json"); name != "updated_at" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// changedFields lists the JSON names of the snapshot fields that differ, ignoring updated_at
+func changedFields(old, new UserSnapshot) []string {
+	var changed []string
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	for i := 0; i < oldValue.NumField(); i++ {
+		name := oldValue.Type().Field(i).Tag.Get("json")
+		if name == "updated_at" {
+			continue
+		}
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExportRepository defines persistence operations for the data_exports table.
+type DataExportRepository interface {
+	core_repo.BaseRepository[entity.DataExport]
+
+	// FindAllByUserID returns every export of a user, oldest first.
+	FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.DataExport, error)
+	// FindPendingByUserID returns the user's export that is still being generated, or ErrNotFound.
+	FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*entity.DataExport, error)
+}
+
+// gormDataExportRepository implements DataExportRepository using GORM
+type gormDataExportRepository struct {
+	*core_repo.GormBaseRepository[entity.DataExport]
+}
+
+// NewDataExportRepository creates a new DataExportRepository using the provided GORM DB connection.
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &gormDataExportRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.DataExport](db),
+	}
+}
+
+// FindAllByUserID implements DataExportRepository.
+func (r *gormDataExportRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.DataExport, error) {
+	var exports []*entity.DataExport
+	err := r.Conn(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Order("created_at").Find(&exports).Error
+	return exports, err
+}
+
+// FindPendingByUserID implements DataExportRepository.
+func (r *gormDataExportRepository) FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*entity.DataExport, error) {
+	var export entity.DataExport
+	err := r.Conn(ctx).Where("user_id = ? AND status = ? AND deleted_at IS NULL", userID, entity.DataExportPending).
+		Order("created_at").First(&export).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, core_repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InvitationRepository defines persistence operations for the invitations table.
+type InvitationRepository interface {
+	core_repo.BaseRepository[entity.Invitation]
+
+	// CreateWithUser creates an inactive user and its invitation in one transaction.
+	CreateWithUser(ctx context.Context, user *entity.User, invitation *entity.Invitation) error
+	// FindByTokenHash returns the invitation whose token hashes to tokenHash, or ErrNotFound.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	// FindByUserID returns the invitation of a user, or ErrNotFound.
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Invitation, error)
+	// Accept sets the user's password hash, activates the user and marks the invitation accepted
+	// in one transaction. ErrNotFound means the invitation was accepted concurrently.
+	Accept(ctx context.Context, invitation *entity.Invitation, passwordHash string, at time.Time) error
+}
+
+// gormInvitationRepository implements InvitationRepository using GORM
+type gormInvitationRepository struct {
+	*core_repo.GormBaseRepository[entity.Invitation]
+}
+
+// NewInvitationRepository creates a new InvitationRepository using the provided GORM DB connection.
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &gormInvitationRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.Invitation](db),
+	}
+}
+
+// CreateWithUser implements InvitationRepository.
+func (r *gormInvitationRepository) CreateWithUser(ctx context.Context, user *entity.User, invitation *entity.Invitation) error {
+	return core_repo.InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		// is_active has a database default of true, which an INSERT of false would not override
+		if err := tx.Model(user).UpdateColumn("is_active", false).Error; err != nil {
+			return fmt.Errorf("failed to deactivate invited user: %w", err)
+		}
+		user.IsActive = false
+		invitation.UserID = user.ID
+		if err := tx.Create(invitation).Error; err != nil {
+			return fmt.Errorf("failed to create invitation: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindByTokenHash implements InvitationRepository.
+func (r *gormInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	return r.FindOneWithFilter(ctx, map[string]interface{}{"token_hash": tokenHash})
+}
+
+// FindByUserID implements InvitationRepository.
+func (r *gormInvitationRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Invitation, error) {
+	return r.FindOneWithFilter(ctx, map[string]interface{}{"user_id": userID})
+}
+
+// Accept implements InvitationRepository. UpdateColumns skips the user hooks, which would hash
+// the already hashed password again.
+func (r *gormInvitationRepository) Accept(ctx context.Context, invitation *entity.Invitation, passwordHash string, at time.Time) error {
+	return core_repo.InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Invitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			UpdateColumn("accepted_at", at)
+		if result.Error != nil {
+			return fmt.Errorf("failed to accept invitation: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return core_repo.ErrNotFound
+		}
+		result = tx.Model(&entity.User{}).
+			Where("id = ? AND deleted_at IS NULL", invitation.UserID).
+			UpdateColumns(map[string]interface{}{"password": passwordHash, "is_active": true, "updated_at": at})
+		if result.Error != nil {
+			return fmt.Errorf("failed to activate invited user: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return core_repo.ErrNotFound
+		}
+		invitation.AcceptedAt = &at
+		return nil
+	})
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository defines persistence operations for the organizations table.
+type OrganizationRepository interface {
+	core_repo.BaseRepository[entity.Organization]
+
+	// CreateWithOwner creates the organization and the owner membership of its creator in one transaction.
+	CreateWithOwner(ctx context.Context, org *entity.Organization) (*entity.Membership, error)
+}
+
+// gormOrganizationRepository implements OrganizationRepository using GORM
+type gormOrganizationRepository struct {
+	*core_repo.GormBaseRepository[entity.Organization]
+}
+
+// NewOrganizationRepository creates a new OrganizationRepository using the provided GORM DB connection.
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &gormOrganizationRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.Organization](db),
+	}
+}
+
+// CreateWithOwner implements OrganizationRepository.
+func (r *gormOrganizationRepository) CreateWithOwner(ctx context.Context, org *entity.Organization) (*entity.Membership, error) {
+	owner := &entity.Membership{UserID: org.CreatedBy, Role: entity.MemberOwner}
+	err := core_repo.InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+		owner.OrganizationID = org.ID
+		if err := tx.Create(owner).Error; err != nil {
+			return fmt.Errorf("failed to create owner membership: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// MembershipRepository defines persistence operations for the memberships table.
+type MembershipRepository interface {
+	core_repo.BaseRepository[entity.Membership]
+
+	// FindMembership returns the membership of a user in an organization, or ErrNotFound.
+	FindMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error)
+	// FindByOrganization returns the members of an organization, by join date unless opts sorts by another field.
+	FindByOrganization(ctx context.Context, orgID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[entity.Membership], error)
+	// FirstByUser returns the user's oldest membership, the organization tokens are bound to by default, or ErrNotFound.
+	FirstByUser(ctx context.Context, userID uuid.UUID) (*entity.Membership, error)
+	// MemberIDs returns the user IDs of the members of an organization.
+	MemberIDs(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error)
+	// WasMember reports whether a user is or was a member of an organization, counting
+	// memberships soft-deleted with the user.
+	WasMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+}
+
+// gormMembershipRepository implements MembershipRepository using GORM
+type gormMembershipRepository struct {
+	*core_repo.GormBaseRepository[entity.Membership]
+}
+
+// NewMembershipRepository creates a new MembershipRepository using the provided GORM DB connection.
+func NewMembershipRepository(db *gorm.DB) MembershipRepository {
+	return &gormMembershipRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.Membership](db),
+	}
+}
+
+// FindMembership implements MembershipRepository.
+func (r *gormMembershipRepository) FindMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error) {
+	return r.FindOneWithFilter(ctx, map[string]interface{}{"organization_id": orgID, "user_id": userID})
+}
+
+// FindByOrganization implements MembershipRepository.
+func (r *gormMembershipRepository) FindByOrganization(ctx context.Context, orgID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[entity.Membership], error) {
+	opts, err := types.FilterOptionsBuilderFrom(opts).WithDefaultSort("created_at").BuildUnlimited()
+	if err != nil {
+		return nil, err
+	}
+	return r.FindWithFilter(ctx, map[string]interface{}{"organization_id": orgID}, opts)
+}
+
+// FirstByUser implements MembershipRepository.
+func (r *gormMembershipRepository) FirstByUser(ctx context.Context, userID uuid.UUID) (*entity.Membership, error) {
+	var membership entity.Membership
+	err := r.Conn(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Order("created_at ASC").First(&membership).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core_repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// WasMember implements MembershipRepository.
+func (r *gormMembershipRepository) WasMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.Conn(ctx).Model(&entity.Membership{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// MemberIDs implements MembershipRepository.
+func (r *gormMembershipRepository) MemberIDs(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.Conn(ctx).Model(&entity.Membership{}).
+		Where("organization_id = ? AND deleted_at IS NULL", orgID).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
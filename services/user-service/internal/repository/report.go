@@ -0,0 +1,25 @@
+package repository
+
+import (
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository defines persistence operations for the reports table.
+type ReportRepository interface {
+	core_repo.BaseRepository[entity.Report]
+}
+
+// gormReportRepository implements ReportRepository using GORM
+type gormReportRepository struct {
+	*core_repo.GormBaseRepository[entity.Report]
+}
+
+// NewReportRepository creates a new ReportRepository using the provided GORM DB connection.
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &gormReportRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.Report](db),
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SecurityEventRepository defines persistence operations for the security_events table.
+type SecurityEventRepository interface {
+	core_repo.BaseRepository[entity.SecurityEvent]
+
+	// FindByUserID returns the security events recorded for a user, newest first unless opts says otherwise.
+	FindByUserID(ctx context.Context, userID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[entity.SecurityEvent], error)
+}
+
+// gormSecurityEventRepository implements SecurityEventRepository using GORM
+type gormSecurityEventRepository struct {
+	*core_repo.GormBaseRepository[entity.SecurityEvent]
+}
+
+// NewSecurityEventRepository creates a new SecurityEventRepository using the provided GORM DB connection.
+func NewSecurityEventRepository(db *gorm.DB) SecurityEventRepository {
+	return &gormSecurityEventRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.SecurityEvent](db),
+	}
+}
+
+// FindByUserID finds the security events of a single user using the embedded FindWithFilter.
+func (r *gormSecurityEventRepository) FindByUserID(ctx context.Context, userID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[entity.SecurityEvent], error) {
+	filter := map[string]interface{}{"user_id": userID}
+	return r.FindWithFilter(ctx, filter, opts)
+}
@@ -2,10 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
 	"golang-microservices-boilerplate/services/user-service/internal/entity"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -19,8 +23,24 @@ type UserRepository interface {
 
 	// FindByEmail retrieves a user by their email address.
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+
+	// UpdateLastLogin persists the last_login_at column only, without touching other fields or running update hooks.
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// Erase irreversibly scrubs the personal data of the user tombstone.UserID (soft-deleted users
+	// included) and of its security events, and stores the tombstone, in one transaction.
+	// It fills tombstone.EmailHash from the stored email.
+	Erase(ctx context.Context, tombstone *entity.ErasureTombstone) error
+
+	// CountMatching, CountBy and CountByDay aggregate users; the embedded GORM repository implements them.
+	CountMatching(ctx context.Context, opts types.FilterOptions) (int64, error)
+	CountBy(ctx context.Context, column string, opts types.FilterOptions) ([]core_repo.GroupCount, error)
+	CountByDay(ctx context.Context, column string, opts types.FilterOptions) ([]core_repo.GroupCount, error)
 }
 
+// ErrAlreadyErased is returned by Erase when the user's personal data was erased before
+var ErrAlreadyErased = errors.New("user already erased")
+
 // gormUserRepository implements UserRepository using GORM
 // It embeds the generic GORM repository and provides specific finders.
 type gormUserRepository struct {
@@ -29,10 +49,20 @@ type gormUserRepository struct {
 	*core_repo.GormBaseRepository[entity.User]
 }
 
-// NewUserRepository creates a new UserRepository using the provided GORM DB connection.
-func NewUserRepository(db *gorm.DB) UserRepository {
+// DefaultDeleteCascade is what soft-deleting a user does to its records unless USER_DELETE_CASCADE
+// says otherwise: its organization memberships and pending invitation are soft-deleted with it, so
+// neither keeps granting access, and come back when the user is restored.
+const DefaultDeleteCascade = "memberships.user_id:soft_delete,invitations.user_id:soft_delete"
+
+// NewUserRepository creates a new UserRepository using the provided GORM DB connection. Soft
+// deletes apply the cascade rules to the records referencing the users (see core_repo.CascadeRule).
+func NewUserRepository(db *gorm.DB, cascade ...core_repo.CascadeRule) UserRepository {
+	base := core_repo.NewGormBaseRepository[entity.User](db)
+	base.Cascade = cascade
+	// Bulk updates bypass the entity hooks, so password (hashed in BeforeUpdate) and identity fields stay out
+	base.UpdatableFields = []string{"first_name", "last_name", "role", "is_active", "phone", "address", "age", "profile_pic"}
 	return &gormUserRepository{
-		GormBaseRepository: core_repo.NewGormBaseRepository[entity.User](db),
+		GormBaseRepository: base,
 	}
 }
 
@@ -45,6 +75,74 @@ func (r *gormUserRepository) FindByEmail(ctx context.Context, email string) (*en
 	return r.FindOneWithFilter(ctx, filter)
 }
 
+// UpdateLastLogin sets last_login_at for the given user.
+// UpdateColumn is used so the BeforeUpdate hook (password hashing, validation) is skipped.
+func (r *gormUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.Conn(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumn("last_login_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return core_repo.ErrNotFound
+	}
+	return nil
+}
+
+// Erase implements UserRepository. The users row keeps its ID, so rows referencing it stay valid;
+// UpdateColumns skips the hooks that would re-validate or hash the placeholder values.
+func (r *gormUserRepository) Erase(ctx context.Context, tombstone *entity.ErasureTombstone) error {
+	return core_repo.InTransaction(ctx, r.Conn(ctx), func(tx *gorm.DB) error {
+		var user entity.User
+		if err := tx.Where("id = ?", tombstone.UserID).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return core_repo.ErrNotFound
+			}
+			return err
+		}
+		if user.IsErased() {
+			return ErrAlreadyErased
+		}
+		tombstone.EmailHash = entity.HashEmail(user.Email)
+		erasedEmail := entity.ErasedEmail(tombstone.EmailHash)
+
+		// Earlier versions hold the data being erased; the erasure itself is recorded as the only version left
+		if err := tx.Where("user_id = ?", user.ID).Delete(&entity.UserHistory{}).Error; err != nil {
+			return err
+		}
+
+		// The change event of an erasure carries no old snapshot, so the data does not leak through it
+		err := tx.Set(cdcErasureKey, true).Model(&entity.User{}).Where("id = ?", user.ID).UpdateColumns(map[string]interface{}{
+			"username":    "erased-" + user.ID.String(),
+			"email":       erasedEmail,
+			"password":    "", // Matches no password, so the account cannot sign in again
+			"first_name":  entity.ErasedName,
+			"last_name":   entity.ErasedName,
+			"phone":       "",
+			"address":     "",
+			"age":         0,
+			"profile_pic": "",
+			"is_active":   false,
+			"updated_at":  time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// Audit records stay linked to the user ID but lose the address and client details, including
+		// failed logins that were only recorded by email
+		err = tx.Model(&entity.SecurityEvent{}).Where("user_id = ? OR email = ?", user.ID, user.Email).Updates(map[string]interface{}{
+			"email":      erasedEmail,
+			"ip_address": "",
+			"user_agent": "",
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(tombstone).Error
+	})
+}
+
 /*
 // Example implementation for FindByUsername
 func (r *gormUserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserHistoryRepository defines read operations for the user_history table. Versions are written by
+// UserChangeCapture, in the transaction of the change they record.
+type UserHistoryRepository interface {
+	core_repo.BaseRepository[entity.UserHistory]
+
+	// FindByUserID returns the versions of a user, newest first unless opts says otherwise.
+	FindByUserID(ctx context.Context, userID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[entity.UserHistory], error)
+	// FindAsOf returns the latest version of a user recorded at or before at, or ErrNotFound.
+	FindAsOf(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.UserHistory, error)
+	// FindVersion returns a single version of a user, or ErrNotFound.
+	FindVersion(ctx context.Context, userID uuid.UUID, version int64) (*entity.UserHistory, error)
+	// PasswordChangedBetween reports whether a version after from and up to to changed the password.
+	PasswordChangedBetween(ctx context.Context, userID uuid.UUID, from, to int64) (bool, error)
+}
+
+// gormUserHistoryRepository implements UserHistoryRepository using GORM
+type gormUserHistoryRepository struct {
+	*core_repo.GormBaseRepository[entity.UserHistory]
+}
+
+// NewUserHistoryRepository creates a new UserHistoryRepository using the provided GORM DB connection.
+func NewUserHistoryRepository(db *gorm.DB) UserHistoryRepository {
+	return &gormUserHistoryRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.UserHistory](db),
+	}
+}
+
+// FindByUserID implements UserHistoryRepository.
+func (r *gormUserHistoryRepository) FindByUserID(ctx context.Context, userID uuid.UUID, opts types.FilterOptions) (*types.PaginationResult[entity.UserHistory], error) {
+	filter := map[string]interface{}{"user_id": userID}
+	return r.FindWithFilter(ctx, filter, opts)
+}
+
+// FindAsOf implements UserHistoryRepository.
+func (r *gormUserHistoryRepository) FindAsOf(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.UserHistory, error) {
+	var version entity.UserHistory
+	err := r.Conn(ctx).Where("user_id = ? AND created_at <= ?", userID, at).Order("version DESC").First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, core_repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// FindVersion implements UserHistoryRepository.
+func (r *gormUserHistoryRepository) FindVersion(ctx context.Context, userID uuid.UUID, version int64) (*entity.UserHistory, error) {
+	var found entity.UserHistory
+	err := r.Conn(ctx).Where("user_id = ? AND version = ?", userID, version).First(&found).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, core_repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// PasswordChangedBetween implements UserHistoryRepository.
+func (r *gormUserHistoryRepository) PasswordChangedBetween(ctx context.Context, userID uuid.UUID, from, to int64) (bool, error) {
+	var count int64
+	err := r.Conn(ctx).Model(&entity.UserHistory{}).
+		Where("user_id = ? AND version > ? AND version <= ? AND password_changed", userID, from, to).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// DecodeSnapshot returns the state of the user recorded by a version
+func DecodeSnapshot(version *entity.UserHistory) (UserSnapshot, error) {
+	var snapshot UserSnapshot
+	err := json.Unmarshal([]byte(version.Snapshot), &snapshot)
+	return snapshot, err
+}
@@ -1,10 +1,18 @@
 package schema
 
-import "golang-microservices-boilerplate/services/user-service/internal/entity"
+import (
+	"time"
+
+	"golang-microservices-boilerplate/pkg/middleware"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+
+	"github.com/google/uuid"
+)
 
 type LoginCredentials struct {
-	Email    string
-	Password string
+	Email          string
+	Password       string
+	OrganizationID uuid.UUID // Organization to bind the tokens to; uuid.Nil picks the user's first one
 }
 
 // LoginResult holds the data returned upon successful login
@@ -21,3 +29,21 @@ type RefreshResult struct {
 	RefreshToken string
 	ExpiresAt    int64 // Unix timestamp for new access token expiry
 }
+
+// Token types of introspection, named as the token_type_hint values of RFC 7662
+const (
+	TokenTypeAccess  = "access_token"
+	TokenTypeRefresh = "refresh_token"
+)
+
+// IntrospectionResult describes an introspected token; for inactive tokens only Active is set
+type IntrospectionResult struct {
+	Active    bool
+	TokenType string
+	Claims    middleware.Claims
+	TokenID   string
+	Issuer    string
+	Audience  []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
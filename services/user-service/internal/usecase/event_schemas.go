@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	core_events "golang-microservices-boilerplate/pkg/core/events"
+	pb "golang-microservices-boilerplate/proto/user-service"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+)
+
+// RegisterEventSchemas adds the payload schemas of the user service's events to registry. A
+// breaking change to an event adds the next version here instead of editing a registered message.
+func RegisterEventSchemas(registry *core_events.SchemaRegistry) error {
+	return registry.Register(
+		core_events.Schema{Type: EventUserCreated, Version: 1, Message: &pb.UserEventV1{}},
+		core_events.Schema{Type: EventUserUpdated, Version: 1, Message: &pb.UserEventV1{}},
+		core_events.Schema{Type: EventUserDeleted, Version: 1, Message: &pb.UserDeletedEventV1{}},
+		core_events.Schema{Type: EventUserRestored, Version: 1, Message: &pb.UserEventV1{}},
+		core_events.Schema{Type: EventUserErased, Version: 1, Message: &pb.UserErasedEventV1{}},
+		core_events.Schema{Type: user_repository.EventUserChanged, Version: 1, Message: &pb.UserChangedEventV1{}},
+	)
+}
@@ -0,0 +1,461 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	core_blob "golang-microservices-boilerplate/pkg/core/blob"
+	core_events "golang-microservices-boilerplate/pkg/core/events"
+	core_jobs "golang-microservices-boilerplate/pkg/core/jobs"
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Job types of data exports
+const (
+	JobGenerateDataExport = "user.data_export.generate"
+	JobExpireDataExport   = "user.data_export.expire"
+)
+
+// NotificationDataExportReady is the notification type sent when an export can be downloaded
+const NotificationDataExportReady = "data_export.ready"
+
+// dataExportPageSize is the number of security events read per query while exporting
+const dataExportPageSize = 500
+
+// DataExportUsecase lets users obtain a copy of the data held about them (GDPR data portability).
+// Archives are generated by a background job and expire after a retention period.
+type DataExportUsecase interface {
+	// RequestExport queues an export for the calling user. A request while another export of the
+	// user is still pending returns that export instead of queueing a second one.
+	RequestExport(ctx context.Context, format entity.ExportFormat) (*entity.DataExport, error)
+	// GetExport returns an export of the calling user
+	GetExport(ctx context.Context, id uuid.UUID) (*entity.DataExport, error)
+	// OpenExport returns a ready export of the calling user and its zip archive; the caller closes it
+	OpenExport(ctx context.Context, id uuid.UUID) (*entity.DataExport, io.ReadCloser, error)
+	// RegisterJobs registers the generation and expiry handlers with the job worker
+	RegisterJobs(worker *core_jobs.Worker)
+}
+
+// dataExportJob is the payload of the data export jobs
+type dataExportJob struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// dataExportUseCaseImpl implements the DataExportUsecase interface.
+type dataExportUseCaseImpl struct {
+	exports           user_repository.DataExportRepository
+	users             user_repository.UserRepository
+	securityEventRepo user_repository.SecurityEventRepository
+	queue             *core_jobs.Queue
+	blobs             core_blob.Store
+	notifier          core_events.Notifier
+	retention         time.Duration
+	logger            core_logger.Logger
+}
+
+// NewDataExportUseCase creates a new instance of DataExportUsecase. Archives are deleted retention
+// after they were generated.
+func NewDataExportUseCase(
+	exports user_repository.DataExportRepository,
+	users user_repository.UserRepository,
+	securityEventRepo user_repository.SecurityEventRepository,
+	queue *core_jobs.Queue,
+	blobs core_blob.Store,
+	notifier core_events.Notifier,
+	retention time.Duration,
+	logger core_logger.Logger,
+) DataExportUsecase {
+	return &dataExportUseCaseImpl{
+		exports:           exports,
+		users:             users,
+		securityEventRepo: securityEventRepo,
+		queue:             queue,
+		blobs:             blobs,
+		notifier:          notifier,
+		retention:         retention,
+		logger:            logger,
+	}
+}
+
+// DataExportDownloadPath is the gateway path of an export's archive, sent in the ready notification
+func DataExportDownloadPath(id uuid.UUID) string {
+	return "/api/v1/users/me/exports/" + id.String() + "/download"
+}
+
+// caller returns the ID of the authenticated user
+func (uc *dataExportUseCaseImpl) caller(ctx context.Context) (uuid.UUID, error) {
+	actor, ok := core_usecase.ActorFromContext(ctx)
+	if !ok {
+		return uuid.Nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return uuid.Nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "the caller is not a user")
+	}
+	return id, nil
+}
+
+// RequestExport implements DataExportUsecase.
+func (uc *dataExportUseCaseImpl) RequestExport(ctx context.Context, format entity.ExportFormat) (*entity.DataExport, error) {
+	userID, err := uc.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = entity.ExportFormatJSON
+	}
+	if !format.IsValid() {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "format must be json or csv")
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "data exports do not support dry runs")
+	}
+
+	pending, err := uc.exports.FindPendingByUserID(ctx, userID)
+	if err == nil {
+		return pending, nil
+	}
+	if !errors.Is(err, core_repo.ErrNotFound) {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to look up pending data export", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to request data export")
+	}
+
+	export := &entity.DataExport{UserID: userID, Format: format, Status: entity.DataExportPending}
+	if err := uc.exports.Create(ctx, export); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to create data export", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to request data export")
+	}
+	if _, err := uc.queue.Enqueue(ctx, JobGenerateDataExport, dataExportJob{ExportID: export.ID}); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to queue data export", "export_id", export.ID, "error", err)
+		uc.fail(ctx, export, "failed to queue the export")
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to request data export")
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Data export requested", "user_id", userID, "export_id", export.ID, "format", format)
+	return export, nil
+}
+
+// GetExport implements DataExportUsecase.
+func (uc *dataExportUseCaseImpl) GetExport(ctx context.Context, id uuid.UUID) (*entity.DataExport, error) {
+	userID, err := uc.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	export, err := uc.exports.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load data export", "export_id", id, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve data export")
+	}
+	// Other users' exports are reported as missing rather than forbidden, so IDs cannot be probed
+	if export.UserID != userID {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+	}
+	return export, nil
+}
+
+// OpenExport implements DataExportUsecase.
+func (uc *dataExportUseCaseImpl) OpenExport(ctx context.Context, id uuid.UUID) (*entity.DataExport, io.ReadCloser, error) {
+	export, err := uc.GetExport(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != entity.DataExportReady || (export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt)) {
+		return nil, nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, fmt.Sprintf("data export is %s, not ready for download", export.Status))
+	}
+	archive, err := uc.blobs.Open(ctx, export.BlobKey)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to open data export archive", "export_id", id, "error", err)
+		return nil, nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to open data export")
+	}
+	return export, archive, nil
+}
+
+// RegisterJobs implements DataExportUsecase.
+func (uc *dataExportUseCaseImpl) RegisterJobs(worker *core_jobs.Worker) {
+	worker.Handle(JobGenerateDataExport, uc.generate)
+	worker.OnFailed(JobGenerateDataExport, func(ctx context.Context, job *core_jobs.Job, err error) {
+		var payload dataExportJob
+		if job.Decode(&payload) != nil {
+			return
+		}
+		if export, findErr := uc.exports.FindByID(ctx, payload.ExportID); findErr == nil {
+			uc.fail(ctx, export, "the export could not be generated")
+		}
+	})
+	worker.Handle(JobExpireDataExport, uc.expire)
+}
+
+// generate builds the archive of an export, stores it and notifies the user
+func (uc *dataExportUseCaseImpl) generate(ctx context.Context, job *core_jobs.Job) error {
+	var payload dataExportJob
+	if err := job.Decode(&payload); err != nil {
+		return core_jobs.Permanent(err)
+	}
+	export, err := uc.exports.FindByID(ctx, payload.ExportID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return core_jobs.Permanent(err)
+		}
+		return err
+	}
+	if export.Status != entity.DataExportPending {
+		return nil // Generated by an earlier attempt whose outcome was not recorded
+	}
+
+	archive, err := uc.buildArchive(ctx, export)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			uc.fail(ctx, export, "the user no longer exists")
+			return core_jobs.Permanent(err)
+		}
+		return err
+	}
+	key := fmt.Sprintf("data-exports/%s/%s.zip", export.UserID, export.ID)
+	if err := uc.blobs.Put(ctx, key, bytes.NewReader(archive)); err != nil {
+		return fmt.Errorf("failed to store data export archive: %w", err)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(uc.retention)
+	export.Status = entity.DataExportReady
+	export.BlobKey = key
+	export.SizeBytes = int64(len(archive))
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	if err := uc.exports.Update(ctx, export); err != nil {
+		return fmt.Errorf("failed to mark data export ready: %w", err)
+	}
+	if _, err := uc.queue.EnqueueAt(ctx, JobExpireDataExport, dataExportJob{ExportID: export.ID}, expiresAt); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to schedule data export expiry", "export_id", export.ID, "error", err)
+	}
+
+	notification := core_events.Notification{
+		UserID:  export.UserID,
+		Type:    NotificationDataExportReady,
+		Message: "Your data export is ready for download",
+		Link:    DataExportDownloadPath(export.ID),
+		Data:    map[string]interface{}{"export_id": export.ID, "expires_at": expiresAt},
+	}
+	if err := uc.notifier.Notify(ctx, notification); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to notify user of data export", "export_id", export.ID, "error", err)
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Data export generated", "user_id", export.UserID, "export_id", export.ID, "size_bytes", export.SizeBytes)
+	return nil
+}
+
+// expire deletes the archive of an export whose retention has passed
+func (uc *dataExportUseCaseImpl) expire(ctx context.Context, job *core_jobs.Job) error {
+	var payload dataExportJob
+	if err := job.Decode(&payload); err != nil {
+		return core_jobs.Permanent(err)
+	}
+	export, err := uc.exports.FindByID(ctx, payload.ExportID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if export.Status != entity.DataExportReady {
+		return nil
+	}
+	if err := uc.blobs.Delete(ctx, export.BlobKey); err != nil {
+		return fmt.Errorf("failed to delete data export archive: %w", err)
+	}
+	export.Status = entity.DataExportExpired
+	return uc.exports.Update(ctx, export)
+}
+
+// fail marks an export failed; errors are logged because the caller is already handling a failure
+func (uc *dataExportUseCaseImpl) fail(ctx context.Context, export *entity.DataExport, reason string) {
+	now := time.Now().UTC()
+	export.Status = entity.DataExportFailed
+	export.Error = reason
+	export.CompletedAt = &now
+	if err := uc.exports.Update(ctx, export); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to mark data export failed", "export_id", export.ID, "error", err)
+	}
+}
+
+// exportedProfile is the profile in an export; the password hash is never included
+type exportedProfile struct {
+	ID          uuid.UUID  `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Role        string     `json:"role"`
+	IsActive    bool       `json:"is_active"`
+	Phone       string     `json:"phone"`
+	Address     string     `json:"address"`
+	Age         int32      `json:"age"`
+	ProfilePic  string     `json:"profile_pic"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// exportManifest describes the archive
+type exportManifest struct {
+	ExportID    uuid.UUID           `json:"export_id"`
+	UserID      uuid.UUID           `json:"user_id"`
+	Format      entity.ExportFormat `json:"format"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Files       []string            `json:"files"`
+}
+
+// exportTable is one exported data set, written as <name>.json or <name>.csv
+type exportTable struct {
+	name    string
+	records interface{} // Written as-is in JSON archives
+	header  []string
+	rows    [][]string
+}
+
+// buildArchive collects everything held about the export's user and zips it in the export's format
+func (uc *dataExportUseCaseImpl) buildArchive(ctx context.Context, export *entity.DataExport) ([]byte, error) {
+	user, err := uc.users.FindByID(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.securityEvents(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+	exports, err := uc.exports.FindAllByUserID(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := exportedProfile{
+		ID: user.ID, Username: user.Username, Email: user.Email, FirstName: user.FirstName, LastName: user.LastName,
+		Role: string(user.Role), IsActive: user.IsActive, Phone: user.Phone, Address: user.Address, Age: user.Age,
+		ProfilePic: user.ProfilePic, LastLoginAt: user.LastLoginAt, CreatedAt: user.CreatedAt, UpdatedAt: user.UpdatedAt,
+	}
+	tables := []exportTable{
+		{
+			name:    "profile",
+			records: profile,
+			header:  []string{"id", "username", "email", "first_name", "last_name", "role", "is_active", "phone", "address", "age", "profile_pic", "last_login_at", "created_at", "updated_at"},
+			rows: [][]string{{
+				profile.ID.String(), profile.Username, profile.Email, profile.FirstName, profile.LastName, profile.Role,
+				strconv.FormatBool(profile.IsActive), profile.Phone, profile.Address, strconv.Itoa(int(profile.Age)),
+				profile.ProfilePic, formatTime(profile.LastLoginAt), formatTime(&profile.CreatedAt), formatTime(&profile.UpdatedAt),
+			}},
+		},
+		{
+			name:    "security_events",
+			records: events,
+			header:  []string{"id", "event_type", "email", "ip_address", "user_agent", "details", "created_at"},
+		},
+		{
+			name:    "data_exports",
+			records: exports,
+			header:  []string{"id", "format", "status", "size_bytes", "created_at", "completed_at", "expires_at"},
+		},
+	}
+	for _, event := range events {
+		tables[1].rows = append(tables[1].rows, []string{
+			event.ID.String(), string(event.EventType), event.Email, event.IPAddress, event.UserAgent, event.Details, formatTime(&event.CreatedAt),
+		})
+	}
+	for _, previous := range exports {
+		tables[2].rows = append(tables[2].rows, []string{
+			previous.ID.String(), string(previous.Format), string(previous.Status), strconv.FormatInt(previous.SizeBytes, 10),
+			formatTime(&previous.CreatedAt), formatTime(previous.CompletedAt), formatTime(previous.ExpiresAt),
+		})
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	manifest := exportManifest{ExportID: export.ID, UserID: export.UserID, Format: export.Format, GeneratedAt: time.Now().UTC()}
+	for _, table := range tables {
+		name := table.name + "." + string(export.Format)
+		w, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if export.Format == entity.ExportFormatCSV {
+			err = writeCSV(w, table.header, table.rows)
+		} else {
+			err = writeJSON(w, table.records)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		manifest.Files = append(manifest.Files, name)
+	}
+	w, err := archive.Create("manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSON(w, manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// securityEvents reads all security events of a user, oldest first
+func (uc *dataExportUseCaseImpl) securityEvents(ctx context.Context, userID uuid.UUID) ([]*entity.SecurityEvent, error) {
+	var events []*entity.SecurityEvent
+	opts, err := core_types.NewFilterOptionsBuilder().WithLimit(dataExportPageSize).WithSort("created_at", false).BuildUnlimited()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		page, err := uc.securityEventRepo.FindByUserID(ctx, userID, opts)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page.Items...)
+		if len(page.Items) < dataExportPageSize || int64(len(events)) >= page.TotalItems {
+			return events, nil
+		}
+		opts.Offset += len(page.Items)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// formatTime renders an optional timestamp as RFC 3339, or an empty cell
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
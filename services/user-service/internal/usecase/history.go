@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// FieldChange is a field that differs between two versions of a user
+type FieldChange struct {
+	Field    string      // JSON name of the field, as in user.changed events
+	OldValue interface{} // JSON value in the earlier version
+	NewValue interface{} // JSON value in the later version
+}
+
+// UserVersionDiff compares two versions of a user
+type UserVersionDiff struct {
+	FromVersion     int64
+	ToVersion       int64
+	Changes         []FieldChange // In the order of the snapshot fields
+	PasswordChanged bool          // Whether a version after FromVersion, up to ToVersion, set a new password
+}
+
+// ListUserHistory implements UserUsecase.
+func (uc *userUseCaseImpl) ListUserHistory(ctx context.Context, userID uuid.UUID, opts core_types.FilterOptions) (*core_types.PaginationResult[entity.UserHistory], error) {
+	result, err := uc.history.FindByUserID(ctx, userID, opts)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to list user history", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user history")
+	}
+	// Users created before history was recorded have none; unknown IDs surface as NotFound
+	if result.TotalItems == 0 {
+		if err := uc.BaseUseCaseImpl.RequireExists(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetUserAsOf implements UserUsecase. A user deleted at that time is returned with its deletion
+// time; a user that did not exist yet, or whose history was erased, is not found.
+func (uc *userUseCaseImpl) GetUserAsOf(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.UserHistory, error) {
+	if at.IsZero() {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "as_of is required")
+	}
+	version, err := uc.history.FindAsOf(ctx, userID, at)
+	if err != nil {
+		return nil, uc.historyError(ctx, userID, err)
+	}
+	return version, nil
+}
+
+// DiffUserVersions implements UserUsecase.
+func (uc *userUseCaseImpl) DiffUserVersions(ctx context.Context, userID uuid.UUID, from, to int64) (*UserVersionDiff, error) {
+	if from < 1 || to <= from {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "from_version must be at least 1 and lower than to_version")
+	}
+	versions := make([]map[string]interface{}, 0, 2)
+	for _, number := range []int64{from, to} {
+		version, err := uc.history.FindVersion(ctx, userID, number)
+		if err != nil {
+			return nil, uc.historyError(ctx, userID, err)
+		}
+		fields, err := snapshotFields(version)
+		if err != nil {
+			core_logger.FromContext(ctx, uc.logger).Error("Failed to decode user version", "user_id", userID, "version", number, "error", err)
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to decode user version")
+		}
+		versions = append(versions, fields)
+	}
+
+	diff := &UserVersionDiff{FromVersion: from, ToVersion: to}
+	for _, field := range user_repository.SnapshotFieldNames() {
+		oldValue, newValue := versions[0][field], versions[1][field]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff.Changes = append(diff.Changes, FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	passwordChanged, err := uc.history.PasswordChangedBetween(ctx, userID, from, to)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to read user history", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user history")
+	}
+	diff.PasswordChanged = passwordChanged
+	return diff, nil
+}
+
+// historyError maps a repository error of a history lookup
+func (uc *userUseCaseImpl) historyError(ctx context.Context, userID uuid.UUID, err error) error {
+	if errors.Is(err, core_repo.ErrNotFound) {
+		return core_usecase.NewUseCaseError(core_usecase.ErrNotFound, "no recorded version of the user")
+	}
+	core_logger.FromContext(ctx, uc.logger).Error("Failed to read user history", "user_id", userID, "error", err)
+	return core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user history")
+}
+
+// snapshotFields decodes the snapshot of a version into its JSON fields
+func snapshotFields(version *entity.UserHistory) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	err := json.Unmarshal([]byte(version.Snapshot), &fields)
+	return fields, err
+}
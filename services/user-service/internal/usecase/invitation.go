@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/dto"
+	core_events "golang-microservices-boilerplate/pkg/core/events"
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// NotificationUserInvited is the notification type carrying the invite link of a new user
+const NotificationUserInvited = "user.invited"
+
+// AcceptInvitePath is the gateway path of the public endpoint accepting an invitation
+const AcceptInvitePath = "/api/v1/auth/invitations/accept"
+
+// InvitationConfig configures the invitation flow of the user use case
+type InvitationConfig struct {
+	Repository user_repository.InvitationRepository
+	Notifier   core_events.Notifier // Receives the invite links; nil only returns them to the caller
+	TTL        time.Duration        // How long an invite token can be accepted
+	AcceptURL  string               // Frontend page the invite link points to; the token is added as ?token=
+}
+
+// LoadInvitationConfigFromEnv reads INVITE_TTL (default 72h) and INVITE_ACCEPT_URL
+func LoadInvitationConfigFromEnv(repo user_repository.InvitationRepository, notifier core_events.Notifier) InvitationConfig {
+	return InvitationConfig{
+		Repository: repo,
+		Notifier:   notifier,
+		TTL:        utils.GetEnvDuration("INVITE_TTL", 72*time.Hour),
+		AcceptURL:  utils.GetEnv("INVITE_ACCEPT_URL", ""),
+	}
+}
+
+// InviteResult is an issued invitation and its token. The token is only available here.
+type InviteResult struct {
+	User       *entity.User
+	Invitation *entity.Invitation
+	Token      string
+	Link       string // The invite link if InvitationConfig.AcceptURL is set
+}
+
+// InviteUser implements UserUsecase.
+func (uc *userUseCaseImpl) InviteUser(ctx context.Context, user *entity.User) (*InviteResult, error) {
+	if uc.invitations.Repository == nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "invitations are not configured")
+	}
+	if user == nil || user.Email == "" {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "email is required")
+	}
+	if taken, err := uc.userRepo.Exists(ctx, map[string]interface{}{"email": user.Email}); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to check email of invited user", "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to invite user")
+	} else if taken {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, "a user with this email already exists")
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "invitations do not support dry runs")
+	}
+
+	// The user cannot sign in until the invitation is accepted with a password
+	user.Password = ""
+	user.IsActive = false
+	invitation := &entity.Invitation{InvitedBy: callerIDIfUser(ctx)}
+	token, err := invitation.Issue(time.Now().UTC(), uc.invitations.TTL)
+	if err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to invite user")
+	}
+	err = uc.withinUsersQuota(ctx, 1, func(ctx context.Context) error {
+		return uc.invitations.Repository.CreateWithUser(ctx, user, invitation)
+	})
+	if err != nil {
+		var ucErr *core_usecase.UseCaseError
+		if errors.As(err, &ucErr) {
+			return nil, err // The users quota is exhausted or could not be checked
+		}
+		var validationErrs dto.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return nil, core_usecase.NewValidationError(err) // Rejected by the user's BeforeCreate hook
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to create invited user", "email", user.Email, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to invite user")
+	}
+	uc.publish(ctx, EventUserCreated, user)
+
+	result := uc.sendInvite(ctx, user, invitation, token)
+	core_logger.FromContext(ctx, uc.logger).Info("User invited", "user_id", user.ID, "expires_at", invitation.ExpiresAt)
+	return result, nil
+}
+
+// ResendInvite implements UserUsecase.
+func (uc *userUseCaseImpl) ResendInvite(ctx context.Context, userID uuid.UUID) (*InviteResult, error) {
+	if uc.invitations.Repository == nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "invitations are not configured")
+	}
+	invitation, err := uc.invitations.Repository.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "invitation.not_found", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load invitation", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to resend invitation")
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrConflict, "invitation.already_accepted", nil)
+	}
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "user.not_found", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load invited user", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to resend invitation")
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "invitations do not support dry runs")
+	}
+
+	// A new token, so links sent earlier stop working
+	token, err := invitation.Issue(time.Now().UTC(), uc.invitations.TTL)
+	if err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to resend invitation")
+	}
+	if err := uc.invitations.Repository.Update(ctx, invitation); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to update invitation", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to resend invitation")
+	}
+
+	result := uc.sendInvite(ctx, user, invitation, token)
+	core_logger.FromContext(ctx, uc.logger).Info("Invitation resent", "user_id", userID, "sent_count", invitation.SentCount, "expires_at", invitation.ExpiresAt)
+	return result, nil
+}
+
+// acceptInviteInput holds the fields of an accepted invitation that are validated
+type acceptInviteInput struct {
+	Password string `json:"password" validate:"min=8"`
+}
+
+// AcceptInvite implements UserUsecase.
+func (uc *userUseCaseImpl) AcceptInvite(ctx context.Context, token, password string) (*entity.User, error) {
+	if uc.invitations.Repository == nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "invitations are not configured")
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "invitation.invalid", nil)
+	}
+	invitation, err := uc.invitations.Repository.FindByTokenHash(ctx, entity.HashInviteToken(token))
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "invitation.invalid", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load invitation", "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to accept invitation")
+	}
+	now := time.Now().UTC()
+	if invitation.AcceptedAt != nil {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "invitation.invalid", nil)
+	}
+	if invitation.IsExpired(now) {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrInvalidInput, "invitation.expired", nil)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, invitation.UserID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "invitation.invalid", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load invited user", "user_id", invitation.UserID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to accept invitation")
+	}
+	if err := dto.Validate(acceptInviteInput{Password: password}); err != nil {
+		return nil, core_usecase.NewValidationError(err)
+	}
+	if err := user.SetPassword(password); err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, err.Error())
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "invitations do not support dry runs")
+	}
+
+	if err := uc.invitations.Repository.Accept(ctx, invitation, user.Password, now); err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "invitation.invalid", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to accept invitation", "user_id", user.ID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to accept invitation")
+	}
+	user.IsActive = true
+	uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventPasswordChange, "invitation accepted")
+	uc.publish(ctx, EventUserUpdated, user)
+	core_logger.FromContext(ctx, uc.logger).Info("Invitation accepted", "user_id", user.ID)
+	return user, nil
+}
+
+// sendInvite notifies the invited user's channels of the invite link. A failed notification is
+// logged only; the caller still gets the token and can resend.
+func (uc *userUseCaseImpl) sendInvite(ctx context.Context, user *entity.User, invitation *entity.Invitation, token string) *InviteResult {
+	result := &InviteResult{User: user, Invitation: invitation, Token: token, Link: uc.inviteLink(token)}
+	if uc.invitations.Notifier == nil {
+		return result
+	}
+	notification := core_events.Notification{
+		UserID:  user.ID,
+		Type:    NotificationUserInvited,
+		Message: "You have been invited to create an account",
+		Link:    AcceptInvitePath,
+		Data: map[string]interface{}{
+			"email":      user.Email,
+			"token":      token,
+			"invite_url": result.Link,
+			"expires_at": invitation.ExpiresAt,
+		},
+	}
+	if err := uc.invitations.Notifier.Notify(ctx, notification); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to send invitation", "user_id", user.ID, "error", err)
+	}
+	return result
+}
+
+// inviteLink adds the token to InvitationConfig.AcceptURL
+func (uc *userUseCaseImpl) inviteLink(token string) string {
+	if uc.invitations.AcceptURL == "" {
+		return ""
+	}
+	u, err := url.Parse(uc.invitations.AcceptURL)
+	if err != nil {
+		return ""
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// callerIDIfUser returns the ID of the calling user, or nil when the caller is not a known user
+func callerIDIfUser(ctx context.Context) *uuid.UUID {
+	actor, ok := core_usecase.ActorFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	id, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// PermissionManageOrganizations lets a global role manage every organization without being a
+// member (admins have it through "*:*")
+var PermissionManageOrganizations = permissions.MustParse("organizations:manage")
+
+// slugPattern is the format of organization slugs
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const maxSlugLength = 64
+
+// OrganizationUsecase manages organizations and their members. Members are managed by the owners
+// and admins of an organization; tokens are bound to one organization at login (see Login).
+type OrganizationUsecase interface {
+	// CreateOrganization creates an organization owned by the caller. An empty slug is derived from the name.
+	CreateOrganization(ctx context.Context, name, slug string) (*entity.Organization, error)
+	// InviteMember adds the existing user with the email to the organization with the role
+	InviteMember(ctx context.Context, orgID uuid.UUID, email string, role entity.MemberRole) (*entity.Membership, error)
+	// ListMembers returns a page of the organization's members; the caller must be a member
+	ListMembers(ctx context.Context, orgID uuid.UUID, opts core_types.FilterOptions) (*MemberPage, error)
+	// ChangeMemberRole changes the role of a member. The last owner cannot be demoted.
+	ChangeMemberRole(ctx context.Context, orgID, userID uuid.UUID, role entity.MemberRole) (*entity.Membership, error)
+}
+
+// MemberPage is a page of memberships with the users they belong to
+type MemberPage struct {
+	*core_types.PaginationResult[entity.Membership]
+	Users map[uuid.UUID]*entity.User // Missing for members whose user was deleted
+}
+
+// organizationUseCaseImpl implements the OrganizationUsecase interface.
+type organizationUseCaseImpl struct {
+	organizations user_repository.OrganizationRepository
+	memberships   user_repository.MembershipRepository
+	users         user_repository.UserRepository
+	logger        core_logger.Logger
+}
+
+// NewOrganizationUseCase creates a new instance of OrganizationUsecase.
+func NewOrganizationUseCase(
+	organizations user_repository.OrganizationRepository,
+	memberships user_repository.MembershipRepository,
+	users user_repository.UserRepository,
+	logger core_logger.Logger,
+) OrganizationUsecase {
+	return &organizationUseCaseImpl{
+		organizations: organizations,
+		memberships:   memberships,
+		users:         users,
+		logger:        logger,
+	}
+}
+
+// CreateOrganization implements OrganizationUsecase.
+func (uc *organizationUseCaseImpl) CreateOrganization(ctx context.Context, name, slug string) (*entity.Organization, error) {
+	callerID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "name is required and must be at most 100 characters")
+	}
+	if slug == "" {
+		slug = Slugify(name)
+	}
+	if len(slug) > maxSlugLength || !slugPattern.MatchString(slug) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput,
+			fmt.Sprintf("slug must be lowercase letters, digits and single dashes, at most %d characters", maxSlugLength))
+	}
+	if taken, err := uc.organizations.Exists(ctx, map[string]interface{}{"slug": slug}); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to check organization slug", "slug", slug, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to create organization")
+	} else if taken {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, fmt.Sprintf("organization slug %q is taken", slug))
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "organizations do not support dry runs")
+	}
+
+	org := &entity.Organization{Name: name, Slug: slug, CreatedBy: callerID}
+	if _, err := uc.organizations.CreateWithOwner(ctx, org); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to create organization", "slug", slug, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to create organization")
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Organization created", "organization_id", org.ID, "slug", slug, "owner_id", callerID)
+	return org, nil
+}
+
+// InviteMember implements OrganizationUsecase.
+func (uc *organizationUseCaseImpl) InviteMember(ctx context.Context, orgID uuid.UUID, email string, role entity.MemberRole) (*entity.Membership, error) {
+	if role == "" {
+		role = entity.MemberMember
+	}
+	if !role.IsValid() {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "role must be owner, admin or member")
+	}
+	caller, err := uc.membershipOf(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanManage(caller, role); err != nil {
+		return nil, err
+	}
+	invitedBy, _ := callerUserID(ctx)
+
+	user, err := uc.users.FindByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "user.not_found", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to find user to invite", "organization_id", orgID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to add member")
+	}
+	if _, err := uc.memberships.FindMembership(ctx, orgID, user.ID); err == nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, "the user is already a member of the organization")
+	} else if !errors.Is(err, core_repo.ErrNotFound) {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to check membership", "organization_id", orgID, "user_id", user.ID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to add member")
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "organizations do not support dry runs")
+	}
+
+	membership := &entity.Membership{OrganizationID: orgID, UserID: user.ID, Role: role, InvitedBy: &invitedBy}
+	if err := uc.memberships.Create(ctx, membership); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to create membership", "organization_id", orgID, "user_id", user.ID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to add member")
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Organization member added", "organization_id", orgID, "user_id", user.ID, "role", role)
+	return membership, nil
+}
+
+// ListMembers implements OrganizationUsecase.
+func (uc *organizationUseCaseImpl) ListMembers(ctx context.Context, orgID uuid.UUID, opts core_types.FilterOptions) (*MemberPage, error) {
+	if _, err := uc.membershipOf(ctx, orgID); err != nil {
+		return nil, err
+	}
+	result, err := uc.memberships.FindByOrganization(ctx, orgID, opts)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to list members", "organization_id", orgID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to list members")
+	}
+	ids := make([]uuid.UUID, 0, len(result.Items))
+	for _, membership := range result.Items {
+		ids = append(ids, membership.UserID)
+	}
+	users, err := uc.users.FindByIDs(ctx, ids)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load members", "organization_id", orgID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to list members")
+	}
+	return &MemberPage{PaginationResult: result, Users: users}, nil
+}
+
+// ChangeMemberRole implements OrganizationUsecase.
+func (uc *organizationUseCaseImpl) ChangeMemberRole(ctx context.Context, orgID, userID uuid.UUID, role entity.MemberRole) (*entity.Membership, error) {
+	if !role.IsValid() {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "role must be owner, admin or member")
+	}
+	caller, err := uc.membershipOf(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := uc.memberships.FindMembership(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrNotFound, "the user is not a member of the organization")
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load membership", "organization_id", orgID, "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to change member role")
+	}
+	// Both roles must be manageable by the caller, so admins can neither promote to nor demote owners
+	if err := checkCanManage(caller, membership.Role, role); err != nil {
+		return nil, err
+	}
+	if membership.Role == role {
+		return membership, nil
+	}
+	if membership.Role == entity.MemberOwner {
+		owners, err := uc.memberships.Count(ctx, map[string]interface{}{"organization_id": orgID, "role": entity.MemberOwner})
+		if err != nil {
+			core_logger.FromContext(ctx, uc.logger).Error("Failed to count owners", "organization_id", orgID, "error", err)
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to change member role")
+		}
+		if owners <= 1 {
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, "the organization must keep at least one owner")
+		}
+	}
+	if core_usecase.IsDryRun(ctx) {
+		membership.Role = role
+		return membership, nil
+	}
+
+	previous := membership.Role
+	membership.Role = role
+	if err := uc.memberships.Update(ctx, membership); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to update membership", "organization_id", orgID, "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to change member role")
+	}
+	// Tokens issued before the change keep the old role until they are refreshed
+	core_logger.FromContext(ctx, uc.logger).Info("Organization member role changed", "organization_id", orgID, "user_id", userID,
+		"from", previous, "to", role)
+	return membership, nil
+}
+
+// membershipOf returns the caller's membership of the organization. Callers with
+// PermissionManageOrganizations get a nil membership instead of ErrForbidden.
+func (uc *organizationUseCaseImpl) membershipOf(ctx context.Context, orgID uuid.UUID) (*entity.Membership, error) {
+	callerID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exists, err := uc.organizations.ExistsByID(ctx, orgID); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load organization", "organization_id", orgID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to load organization")
+	} else if !exists {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": orgID.String()})
+	}
+	membership, err := uc.memberships.FindMembership(ctx, orgID, callerID)
+	switch {
+	case err == nil:
+		return membership, nil
+	case !errors.Is(err, core_repo.ErrNotFound):
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load membership", "organization_id", orgID, "user_id", callerID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to load membership")
+	case core_usecase.RequirePermission(ctx, PermissionManageOrganizations) == nil:
+		return nil, nil
+	default:
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrForbidden, "auth.resource_forbidden", nil)
+	}
+}
+
+// checkCanManage returns ErrForbidden unless the caller's membership may manage members with
+// the roles; a nil membership stands for a caller with PermissionManageOrganizations
+func checkCanManage(caller *entity.Membership, roles ...entity.MemberRole) error {
+	if caller == nil {
+		return nil
+	}
+	for _, role := range roles {
+		if !caller.Role.CanManage(role) {
+			return core_usecase.NewUseCaseError(core_usecase.ErrForbidden,
+				fmt.Sprintf("organization role %s cannot manage %s members", caller.Role, role))
+		}
+	}
+	return nil
+}
+
+// callerUserID returns the ID of the authenticated user calling the use case
+func callerUserID(ctx context.Context) (uuid.UUID, error) {
+	actor, ok := core_usecase.ActorFromContext(ctx)
+	if !ok {
+		return uuid.Nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return uuid.Nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "the caller is not a user")
+	}
+	return id, nil
+}
+
+// Slugify derives an organization slug from a name: lowercase ASCII letters and digits separated
+// by single dashes, cut to the maximum slug length
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+		if b.Len() >= maxSlugLength {
+			break
+		}
+	}
+	return strings.TrimSuffix(b.String()[:min(b.Len(), maxSlugLength)], "-")
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	core_blob "golang-microservices-boilerplate/pkg/core/blob"
+	core_events "golang-microservices-boilerplate/pkg/core/events"
+	core_jobs "golang-microservices-boilerplate/pkg/core/jobs"
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/report"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Job types of reports
+const (
+	JobGenerateReport = "user.report.generate"
+	JobExpireReport   = "user.report.expire"
+)
+
+// NotificationReportReady is the notification type sent when a report can be downloaded
+const NotificationReportReady = "report.ready"
+
+// ReportUsecase renders reports from service data in the background. Reports are built by the
+// templates of a report.Registry, stored in the blob store and expire after a retention period.
+type ReportUsecase interface {
+	// CreateReport queues a report of the given template for the calling user
+	CreateReport(ctx context.Context, reportType string, format report.Format, params map[string]string) (*entity.Report, error)
+	// GetReport returns a report of the calling user
+	GetReport(ctx context.Context, id uuid.UUID) (*entity.Report, error)
+	// OpenReport returns a ready report of the calling user and its file; the caller closes it
+	OpenReport(ctx context.Context, id uuid.UUID) (*entity.Report, io.ReadCloser, error)
+	// RegisterJobs registers the generation and expiry handlers with the job worker
+	RegisterJobs(worker *core_jobs.Worker)
+}
+
+// reportJob is the payload of the report jobs
+type reportJob struct {
+	ReportID uuid.UUID `json:"report_id"`
+}
+
+// reportUseCaseImpl implements the ReportUsecase interface.
+type reportUseCaseImpl struct {
+	reports   user_repository.ReportRepository
+	templates *report.Registry
+	queue     *core_jobs.Queue
+	blobs     core_blob.Store
+	notifier  core_events.Notifier
+	retention time.Duration
+	logger    core_logger.Logger
+}
+
+// NewReportUseCase creates a new instance of ReportUsecase. Files are deleted retention after
+// they were rendered.
+func NewReportUseCase(
+	reports user_repository.ReportRepository,
+	templates *report.Registry,
+	queue *core_jobs.Queue,
+	blobs core_blob.Store,
+	notifier core_events.Notifier,
+	retention time.Duration,
+	logger core_logger.Logger,
+) ReportUsecase {
+	return &reportUseCaseImpl{
+		reports:   reports,
+		templates: templates,
+		queue:     queue,
+		blobs:     blobs,
+		notifier:  notifier,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// ReportDownloadPath is the gateway path of a report's file, sent in the ready notification
+func ReportDownloadPath(id uuid.UUID) string {
+	return "/api/v1/reports/" + id.String() + "/download"
+}
+
+// CreateReport implements ReportUsecase.
+func (uc *reportUseCaseImpl) CreateReport(ctx context.Context, reportType string, format report.Format, params map[string]string) (*entity.Report, error) {
+	actor, ok := core_usecase.ActorFromContext(ctx)
+	if !ok {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "authentication required")
+	}
+	userID, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "the caller is not a user")
+	}
+
+	template, ok := uc.templates.Get(reportType)
+	if !ok {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput,
+			fmt.Sprintf("unknown report type %q; available types: %s", reportType, strings.Join(uc.templates.Names(), ", ")))
+	}
+	if len(template.Roles) > 0 && !actor.HasRole(template.Roles...) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrForbidden, fmt.Sprintf("report %s requires one of the roles %s", reportType, strings.Join(template.Roles, ", ")))
+	}
+	if format == "" {
+		format = report.FormatPDF
+	}
+	if _, err := report.ParseFormat(string(format)); err != nil {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "format must be pdf or xlsx")
+	}
+	if template.Validate != nil {
+		if err := template.Validate(params); err != nil {
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, err.Error())
+		}
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "reports do not support dry runs")
+	}
+
+	rep := &entity.Report{UserID: userID, Type: reportType, Format: format, Status: entity.ReportPending}
+	rep.SetParams(params)
+	if err := uc.reports.Create(ctx, rep); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to create report", "user_id", userID, "type", reportType, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to create report")
+	}
+	if _, err := uc.queue.Enqueue(ctx, JobGenerateReport, reportJob{ReportID: rep.ID}); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to queue report", "report_id", rep.ID, "error", err)
+		uc.fail(ctx, rep, "failed to queue the report")
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to create report")
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Report requested", "user_id", userID, "report_id", rep.ID, "type", reportType, "format", format)
+	return rep, nil
+}
+
+// GetReport implements ReportUsecase.
+func (uc *reportUseCaseImpl) GetReport(ctx context.Context, id uuid.UUID) (*entity.Report, error) {
+	actor, ok := core_usecase.ActorFromContext(ctx)
+	if !ok {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "authentication required")
+	}
+	rep, err := uc.reports.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load report", "report_id", id, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve report")
+	}
+	// Other users' reports are reported as missing rather than forbidden, so IDs cannot be probed
+	if rep.UserID.String() != actor.ID {
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+	}
+	return rep, nil
+}
+
+// OpenReport implements ReportUsecase.
+func (uc *reportUseCaseImpl) OpenReport(ctx context.Context, id uuid.UUID) (*entity.Report, io.ReadCloser, error) {
+	rep, err := uc.GetReport(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rep.Status != entity.ReportReady || (rep.ExpiresAt != nil && time.Now().After(*rep.ExpiresAt)) {
+		return nil, nil, core_usecase.NewUseCaseError(core_usecase.ErrConflict, fmt.Sprintf("report is %s, not ready for download", rep.Status))
+	}
+	file, err := uc.blobs.Open(ctx, rep.BlobKey)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to open report file", "report_id", id, "error", err)
+		return nil, nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to open report")
+	}
+	return rep, file, nil
+}
+
+// RegisterJobs implements ReportUsecase.
+func (uc *reportUseCaseImpl) RegisterJobs(worker *core_jobs.Worker) {
+	worker.Handle(JobGenerateReport, uc.generate)
+	worker.OnFailed(JobGenerateReport, func(ctx context.Context, job *core_jobs.Job, err error) {
+		var payload reportJob
+		if job.Decode(&payload) != nil {
+			return
+		}
+		if rep, findErr := uc.reports.FindByID(ctx, payload.ReportID); findErr == nil && rep.Status == entity.ReportPending {
+			uc.fail(ctx, rep, "the report could not be generated")
+		}
+	})
+	worker.Handle(JobExpireReport, uc.expire)
+}
+
+// generate renders a report with its template, stores the file and notifies the user
+func (uc *reportUseCaseImpl) generate(ctx context.Context, job *core_jobs.Job) error {
+	var payload reportJob
+	if err := job.Decode(&payload); err != nil {
+		return core_jobs.Permanent(err)
+	}
+	rep, err := uc.reports.FindByID(ctx, payload.ReportID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return core_jobs.Permanent(err)
+		}
+		return err
+	}
+	if rep.Status != entity.ReportPending {
+		return nil // Rendered by an earlier attempt whose outcome was not recorded
+	}
+	template, ok := uc.templates.Get(rep.Type)
+	if !ok {
+		uc.fail(ctx, rep, "the report type is no longer available")
+		return core_jobs.Permanent(fmt.Errorf("unknown report type %q", rep.Type))
+	}
+
+	doc, err := template.Build(ctx, report.Request{OwnerID: rep.UserID.String(), Params: rep.ParamMap()})
+	if err != nil {
+		return fmt.Errorf("failed to build report: %w", err)
+	}
+	now := time.Now().UTC()
+	doc.GeneratedAt = now
+	var file bytes.Buffer
+	if err := report.Render(&file, doc, rep.Format); err != nil {
+		return core_jobs.Permanent(fmt.Errorf("failed to render report: %w", err))
+	}
+	key := fmt.Sprintf("reports/%s/%s.%s", rep.UserID, rep.ID, rep.Format)
+	if err := uc.blobs.Put(ctx, key, bytes.NewReader(file.Bytes())); err != nil {
+		return fmt.Errorf("failed to store report: %w", err)
+	}
+
+	expiresAt := now.Add(uc.retention)
+	rep.Status = entity.ReportReady
+	rep.BlobKey = key
+	rep.SizeBytes = int64(file.Len())
+	rep.CompletedAt = &now
+	rep.ExpiresAt = &expiresAt
+	if err := uc.reports.Update(ctx, rep); err != nil {
+		return fmt.Errorf("failed to mark report ready: %w", err)
+	}
+	if _, err := uc.queue.EnqueueAt(ctx, JobExpireReport, reportJob{ReportID: rep.ID}, expiresAt); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to schedule report expiry", "report_id", rep.ID, "error", err)
+	}
+
+	notification := core_events.Notification{
+		UserID:  rep.UserID,
+		Type:    NotificationReportReady,
+		Message: fmt.Sprintf("Your %s report is ready for download", rep.Type),
+		Link:    ReportDownloadPath(rep.ID),
+		Data:    map[string]interface{}{"report_id": rep.ID, "type": rep.Type, "expires_at": expiresAt},
+	}
+	if err := uc.notifier.Notify(ctx, notification); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to notify user of report", "report_id", rep.ID, "error", err)
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Report generated", "user_id", rep.UserID, "report_id", rep.ID, "type", rep.Type, "size_bytes", rep.SizeBytes)
+	return nil
+}
+
+// expire deletes the file of a report whose retention has passed
+func (uc *reportUseCaseImpl) expire(ctx context.Context, job *core_jobs.Job) error {
+	var payload reportJob
+	if err := job.Decode(&payload); err != nil {
+		return core_jobs.Permanent(err)
+	}
+	rep, err := uc.reports.FindByID(ctx, payload.ReportID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if rep.Status != entity.ReportReady {
+		return nil
+	}
+	if err := uc.blobs.Delete(ctx, rep.BlobKey); err != nil {
+		return fmt.Errorf("failed to delete report file: %w", err)
+	}
+	rep.Status = entity.ReportExpired
+	return uc.reports.Update(ctx, rep)
+}
+
+// fail marks a report failed; errors are logged because the caller is already handling a failure
+func (uc *reportUseCaseImpl) fail(ctx context.Context, rep *entity.Report, reason string) {
+	now := time.Now().UTC()
+	rep.Status = entity.ReportFailed
+	rep.Error = reason
+	rep.CompletedAt = &now
+	if err := uc.reports.Update(ctx, rep); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to mark report failed", "report_id", rep.ID, "error", err)
+	}
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/report"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/services/user-service/internal/entity"
+	user_repository "golang-microservices-boilerplate/services/user-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Report types offered by the user service
+const (
+	ReportUserActivity  = "user_activity"
+	ReportUserDirectory = "user_directory"
+)
+
+// reportPageSize is the number of records read per query while building a report
+const reportPageSize = 500
+
+// RegisterReportTemplates adds the user service's report templates to registry
+func RegisterReportTemplates(registry *report.Registry, users user_repository.UserRepository, securityEvents user_repository.SecurityEventRepository) {
+	registry.Register(report.Template{
+		Name:        ReportUserActivity,
+		Description: "Security events of the requesting user; optional from and to dates (YYYY-MM-DD, inclusive)",
+		Validate: func(params map[string]string) error {
+			_, _, err := activityPeriod(params)
+			return err
+		},
+		Build: func(ctx context.Context, req report.Request) (*report.Document, error) {
+			return buildUserActivityReport(ctx, securityEvents, req)
+		},
+	})
+	registry.Register(report.Template{
+		Name:        ReportUserDirectory,
+		Description: "All users with their role and status; optional role and active (true or false) filters",
+		Roles:       []string{string(entity.RoleAdmin)},
+		Validate: func(params map[string]string) error {
+			_, err := directoryFilter(params)
+			return err
+		},
+		Build: func(ctx context.Context, req report.Request) (*report.Document, error) {
+			return buildUserDirectoryReport(ctx, users, req)
+		},
+	})
+}
+
+// checkParams rejects parameters a template does not know, so typos are not silently ignored
+func checkParams(params map[string]string, known ...string) error {
+	var unknown []string
+	for name := range params {
+		if !slices.Contains(known, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown report parameters: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// activityPeriod reads the optional from/to dates of the activity report
+func activityPeriod(params map[string]string) (from, to core_types.Date, err error) {
+	if err := checkParams(params, "from", "to"); err != nil {
+		return from, to, err
+	}
+	if s := params["from"]; s != "" {
+		if from, err = core_types.ParseDate(s); err != nil {
+			return from, to, err
+		}
+	}
+	if s := params["to"]; s != "" {
+		if to, err = core_types.ParseDate(s); err != nil {
+			return from, to, err
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return from, to, fmt.Errorf("to (%s) is before from (%s)", to, from)
+	}
+	return from, to, nil
+}
+
+// buildUserActivityReport lists the security events of the report's owner, oldest first
+func buildUserActivityReport(ctx context.Context, securityEvents user_repository.SecurityEventRepository, req report.Request) (*report.Document, error) {
+	from, to, err := activityPeriod(req.Params)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := core_types.NewFilterOptionsBuilder().WithLimit(reportPageSize).WithSort("created_at", false)
+	period := "All time"
+	if !from.IsZero() {
+		query = query.WithConditions(core_types.NewCondition("created_at", core_types.OpGte, from.In(time.UTC)))
+		period = "From " + from.String()
+	}
+	if !to.IsZero() {
+		query = query.WithConditions(core_types.NewCondition("created_at", core_types.OpLt, to.AddDays(1).In(time.UTC)))
+		if from.IsZero() {
+			period = "Until " + to.String()
+		} else {
+			period += " to " + to.String()
+		}
+	}
+	opts, err := query.BuildUnlimited()
+	if err != nil {
+		return nil, err
+	}
+
+	table := report.Table{Name: "Security events", Columns: []string{"Time (UTC)", "Event", "IP address", "User agent", "Details"}}
+	for {
+		page, err := securityEvents.FindByUserID(ctx, userID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.Items {
+			table.Rows = append(table.Rows, []interface{}{event.CreatedAt, string(event.EventType), event.IPAddress, event.UserAgent, event.Details})
+		}
+		if len(page.Items) < reportPageSize {
+			break
+		}
+		opts.Offset += len(page.Items)
+	}
+
+	return &report.Document{Title: "User Activity", Subtitle: period, Tables: []report.Table{table}}, nil
+}
+
+// directoryFilter reads the optional role/active filters of the directory report
+func directoryFilter(params map[string]string) (map[string]interface{}, error) {
+	if err := checkParams(params, "role", "active"); err != nil {
+		return nil, err
+	}
+	filter := map[string]interface{}{}
+	if role := params["role"]; role != "" {
+		if !entity.Role(role).IsValid() {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+		filter["role"] = role
+	}
+	if active := params["active"]; active != "" {
+		isActive, err := strconv.ParseBool(active)
+		if err != nil {
+			return nil, fmt.Errorf("active must be true or false")
+		}
+		filter["is_active"] = isActive
+	}
+	return filter, nil
+}
+
+// buildUserDirectoryReport lists users by username, with a summary of the counts per role
+func buildUserDirectoryReport(ctx context.Context, users user_repository.UserRepository, req report.Request) (*report.Document, error) {
+	filter, err := directoryFilter(req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := core_types.NewFilterOptionsBuilder().WithLimit(reportPageSize).WithSort("username", false).BuildUnlimited()
+	if err != nil {
+		return nil, err
+	}
+	table := report.Table{Name: "Users", Columns: []string{"Username", "Email", "Name", "Role", "Active", "Last login (UTC)", "Created (UTC)"}}
+	perRole := map[string]int{}
+	for {
+		page, err := users.FindWithFilter(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range page.Items {
+			table.Rows = append(table.Rows, []interface{}{
+				user.Username, user.Email, strings.TrimSpace(user.FirstName + " " + user.LastName), string(user.Role),
+				user.IsActive, user.LastLoginAt, user.CreatedAt,
+			})
+			perRole[string(user.Role)]++
+		}
+		if len(page.Items) < reportPageSize {
+			break
+		}
+		opts.Offset += len(page.Items)
+	}
+
+	summary := report.Table{Name: "Summary", Columns: []string{"Role", "Users"}}
+	roles := make([]string, 0, len(perRole))
+	for role := range perRole {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		summary.Rows = append(summary.Rows, []interface{}{role, perRole[role]})
+	}
+	summary.Rows = append(summary.Rows, []interface{}{"Total", len(table.Rows)})
+
+	var subtitle []string
+	for _, key := range []string{"role", "active"} {
+		if v := req.Params[key]; v != "" {
+			subtitle = append(subtitle, key+"="+v)
+		}
+	}
+	doc := &report.Document{Title: "User Directory", Tables: []report.Table{summary, table}}
+	if len(subtitle) > 0 {
+		doc.Subtitle = "Filtered by " + strings.Join(subtitle, ", ")
+	}
+	return doc, nil
+}
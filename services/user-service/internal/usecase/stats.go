@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// Signup window of GetUserStats in days
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 366
+)
+
+// UserStats summarizes the users visible to the caller
+type UserStats struct {
+	Total         int64
+	Active        int64
+	PerRole       []core_repo.GroupCount // Users per role, by role name
+	SignupsPerDay []core_repo.GroupCount // Users created per UTC day (YYYY-MM-DD) in the window, oldest first
+	Since         time.Time              // Start of the signup window, midnight UTC
+}
+
+// CountUsers implements UserUsecase.
+func (uc *userUseCaseImpl) CountUsers(ctx context.Context, opts core_types.FilterOptions) (int64, error) {
+	opts, err := uc.organizationScope(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	count, err := uc.userRepo.CountMatching(ctx, opts)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to count users", "error", err)
+		return 0, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to count users")
+	}
+	return count, nil
+}
+
+// GetUserStats implements UserUsecase.
+func (uc *userUseCaseImpl) GetUserStats(ctx context.Context, days int) (*UserStats, error) {
+	if days == 0 {
+		days = DefaultStatsDays
+	}
+	if days < 1 || days > MaxStatsDays {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, fmt.Sprintf("days must be between 1 and %d", MaxStatsDays))
+	}
+	opts, err := uc.organizationScope(ctx, core_types.DefaultFilterOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	// The window includes today, so days=1 covers the signups since midnight UTC
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	stats := &UserStats{Since: today.AddDate(0, 0, 1-days)}
+	fail := func(what string, err error) (*UserStats, error) {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to compute user stats", "stat", what, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to compute user stats")
+	}
+
+	if stats.PerRole, err = uc.userRepo.CountBy(ctx, "role", opts); err != nil {
+		return fail("per_role", err)
+	}
+	for _, group := range stats.PerRole {
+		stats.Total += group.Count
+	}
+
+	scope := core_types.FilterOptionsBuilderFrom(opts)
+	active, err := scope.WithConditions(core_types.NewCondition("is_active", core_types.OpEq, true)).BuildUnlimited()
+	if err != nil {
+		return fail("active", err)
+	}
+	if stats.Active, err = uc.userRepo.CountMatching(ctx, active); err != nil {
+		return fail("active", err)
+	}
+
+	signups, err := scope.WithConditions(core_types.NewCondition("created_at", core_types.OpGte, stats.Since)).BuildUnlimited()
+	if err != nil {
+		return fail("signups_per_day", err)
+	}
+	if stats.SignupsPerDay, err = uc.userRepo.CountByDay(ctx, "created_at", signups); err != nil {
+		return fail("signups_per_day", err)
+	}
+	return stats, nil
+}
@@ -6,7 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	core_events "golang-microservices-boilerplate/pkg/core/events"
+	core_grpc "golang-microservices-boilerplate/pkg/core/grpc"
 	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/permissions"
+	core_quota "golang-microservices-boilerplate/pkg/core/quota"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
 	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
 	"golang-microservices-boilerplate/pkg/middleware"
 	"golang-microservices-boilerplate/pkg/utils"
@@ -20,15 +26,27 @@ import (
 	"github.com/google/uuid"
 )
 
-// Specific error messages to check against repository errors
-const errUserNotFoundMsg = "entity not found"
-
 // Define JWT expiration durations (can be configured externally)
 const (
 	defaultAccessTokenDuration  = 7 * 24 * time.Hour  // 7 days
 	defaultRefreshTokenDuration = 30 * 24 * time.Hour // 30 days
 )
 
+// Domain events published by the user use case
+const (
+	EventUserCreated  = "user.created"
+	EventUserUpdated  = "user.updated"
+	EventUserDeleted  = "user.deleted"
+	EventUserRestored = "user.restored"
+	EventUserErased   = "user.erased"
+)
+
+// QuotaUsers limits the number of users of the service (subject core_quota.Global)
+const QuotaUsers = "users"
+
+// PermissionListAllUsers exempts user queries from the organization scope (admins have it through "*:*")
+var PermissionListAllUsers = permissions.MustParse("users:read_all")
+
 // LoginCredentials, LoginResult, RefreshResult are now defined in the schema package
 // type LoginCredentials struct { ... }
 // type LoginResult struct { ... }
@@ -42,6 +60,34 @@ type UserUsecase interface {
 	// Login returns entity and token details directly, uses locally defined LoginCredentials
 	Login(ctx context.Context, creds schema.LoginCredentials) (*schema.LoginResult, error)
 	Refresh(ctx context.Context, refreshToken string) (*schema.RefreshResult, error)
+	// Logout revokes a refresh token so it can no longer be used to obtain access tokens.
+	Logout(ctx context.Context, refreshToken string) error
+	// Introspect reports whether a token is active and returns its claims, trying the token type
+	// named by typeHint first.
+	Introspect(ctx context.Context, token, typeHint string) (*schema.IntrospectionResult, error)
+	// GetSecurityEvents returns the recorded login/refresh/password events of a user.
+	GetSecurityEvents(ctx context.Context, userID uuid.UUID, opts core_types.FilterOptions) (*core_types.PaginationResult[entity.SecurityEvent], error)
+	// AnonymizeUser irreversibly erases the personal data of a user and its security events, keeping
+	// the rows (and the references to them) in place, and returns the recorded tombstone.
+	AnonymizeUser(ctx context.Context, id uuid.UUID, reason string) (*entity.ErasureTombstone, error)
+	// InviteUser creates an inactive user without a password and issues an invite token for it,
+	// sent through the invitation notifier.
+	InviteUser(ctx context.Context, user *entity.User) (*InviteResult, error)
+	// ResendInvite issues a new invite token for a pending invitation, invalidating the previous one.
+	ResendInvite(ctx context.Context, userID uuid.UUID) (*InviteResult, error)
+	// AcceptInvite sets the password of an invited user and activates the account.
+	AcceptInvite(ctx context.Context, token, password string) (*entity.User, error)
+	// CountUsers counts the users matching the filters and conditions of opts, within the caller's organization.
+	CountUsers(ctx context.Context, opts core_types.FilterOptions) (int64, error)
+	// GetUserStats returns the totals, the users per role and the signups per day of the last days
+	// (DefaultStatsDays when 0), within the caller's organization.
+	GetUserStats(ctx context.Context, days int) (*UserStats, error)
+	// ListUserHistory returns the recorded versions of a user, newest first unless opts says otherwise.
+	ListUserHistory(ctx context.Context, userID uuid.UUID, opts core_types.FilterOptions) (*core_types.PaginationResult[entity.UserHistory], error)
+	// GetUserAsOf returns the version of a user current at a point in time.
+	GetUserAsOf(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.UserHistory, error)
+	// DiffUserVersions returns the fields that differ between two versions of a user.
+	DiffUserVersions(ctx context.Context, userID uuid.UUID, from, to int64) (*UserVersionDiff, error)
 	// PromoteUser(ctx context.Context, userID uuid.UUID, newRole entity.Role) error // Example custom method
 }
 
@@ -50,17 +96,36 @@ type userUseCaseImpl struct {
 	// Embed the core use case implementation, now without DTO generics
 	*core_usecase.BaseUseCaseImpl[entity.User]
 	userRepo             user_repository.UserRepository
+	securityEventRepo    user_repository.SecurityEventRepository
+	history              user_repository.UserHistoryRepository
+	memberships          user_repository.MembershipRepository
 	logger               core_logger.Logger
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
+	events               core_events.Publisher
+	revoked              TokenRevoker
+	quotas               *core_quota.Manager
+	invitations          InvitationConfig
+}
+
+// TokenRevoker revokes tokens by JWT ID until they expire (e.g. *cache.TokenBlacklist)
+type TokenRevoker interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
 }
 
 // NewUserUseCase creates a new instance of UserUsecase.
 func NewUserUseCase(
 	userRepo user_repository.UserRepository,
+	securityEventRepo user_repository.SecurityEventRepository,
+	history user_repository.UserHistoryRepository,
+	memberships user_repository.MembershipRepository,
 	logger core_logger.Logger,
 	accessTokenDur *time.Duration,
 	refreshTokenDur *time.Duration,
+	events core_events.Publisher,
+	revoked TokenRevoker,
+	quotas *core_quota.Manager,
+	invitations InvitationConfig,
 ) UserUsecase { // Return the UserUsecase interface type
 	// Remove DTO generics when creating the base use case
 	baseUseCase := core_usecase.NewBaseUseCase(userRepo, logger)
@@ -72,12 +137,25 @@ func NewUserUseCase(
 	if refreshTokenDur != nil {
 		rtDur = *refreshTokenDur
 	}
+	if events == nil {
+		events = core_events.NopPublisher{}
+	}
+	if quotas == nil {
+		quotas = core_quota.NewManager(core_quota.NewMemoryStore(), logger) // No quotas defined
+	}
 	return &userUseCaseImpl{
 		BaseUseCaseImpl:      baseUseCase,
 		userRepo:             userRepo,
+		securityEventRepo:    securityEventRepo,
+		history:              history,
+		memberships:          memberships,
 		logger:               logger,
 		accessTokenDuration:  atDur,
 		refreshTokenDuration: rtDur,
+		events:               events,
+		revoked:              revoked,
+		quotas:               quotas,
+		invitations:          invitations,
 	}
 }
 
@@ -87,49 +165,59 @@ func NewUserUseCase(
 // Modified to return *entity.User and token details directly
 // Uses locally defined LoginCredentials struct
 func (uc *userUseCaseImpl) Login(ctx context.Context, creds schema.LoginCredentials) (*schema.LoginResult, error) {
-	uc.logger.Info("Attempting login", "email", creds.Email)
+	core_logger.FromContext(ctx, uc.logger).Info("Attempting login", "email", creds.Email)
 
 	// 1. Find user by email, check active, check password
 	user, err := uc.userRepo.FindByEmail(ctx, creds.Email)
 	if err != nil {
-		if err.Error() == errUserNotFoundMsg {
-			uc.logger.Warn("Login failed: user not found", "email", creds.Email)
+		if errors.Is(err, core_repo.ErrNotFound) {
+			core_logger.FromContext(ctx, uc.logger).Warn("Login failed: user not found", "email", creds.Email)
+			uc.recordSecurityEvent(ctx, nil, creds.Email, entity.SecurityEventLoginFailed, "user not found")
 			// Return nils and zero values for tokens along with the error
-			return nil, core_usecase.NewUseCaseError(core_usecase.ErrNotFound, "user not found")
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "user.not_found", nil)
 		}
-		uc.logger.Error("Failed to find user by email during login", "email", creds.Email, "error", err)
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to find user by email during login", "email", creds.Email, "error", err)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user data")
 	}
 	if !user.IsActive {
-		uc.logger.Warn("Login failed: user is inactive", "email", creds.Email, "user_id", user.ID)
-		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "user account is inactive")
+		core_logger.FromContext(ctx, uc.logger).Warn("Login failed: user is inactive", "email", creds.Email, "user_id", user.ID)
+		uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventLoginFailed, "user account is inactive")
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.account_inactive", nil)
 	}
 	if !user.CheckPassword(creds.Password) {
-		uc.logger.Warn("Login failed: invalid password", "email", creds.Email, "user_id", user.ID)
-		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "invalid credentials")
+		core_logger.FromContext(ctx, uc.logger).Warn("Login failed: invalid password", "email", creds.Email, "user_id", user.ID)
+		uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventLoginFailed, "invalid password")
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.invalid_credentials", nil)
 	}
 
-	// 4. Prepare custom claims map including the standard "sub" claim
-	customClaims := map[string]interface{}{
-		"sub":   user.ID.String(),
-		"email": user.Email,
-		"role":  string(user.Role),
+	// 4. Prepare the token claims, bound to the requested or the user's first organization
+	claims := middleware.Claims{UserID: user.ID, Email: user.Email, Role: string(user.Role)}
+	if err := uc.bindOrganization(ctx, &claims, creds.OrganizationID); err != nil {
+		return nil, err
 	}
+	customClaims := claims.Encode()
 
 	// 5. Generate JWT token pair using the TokenGenerator interface
 	accessToken, refreshToken, expiresAt, err := middleware.GenerateTokenPair(
 		customClaims,
 		uc.accessTokenDuration,
 		uc.refreshTokenDuration,
-		utils.GetEnv("ACCESS_TOKEN_SECRET", "access_token_secret_wqim"),
-		utils.GetEnv("REFRESH_TOKEN_SECRET", "refresh_token_secret_KMT"),
+		utils.GetEnv("ACCESS_TOKEN_SECRET", middleware.DevelopmentAccessTokenSecret),
+		utils.GetEnv("REFRESH_TOKEN_SECRET", middleware.DevelopmentRefreshTokenSecret),
 	)
 	if err != nil {
-		uc.logger.Error("Failed to generate token pair", "user_id", user.ID, "error", err)
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to generate token pair", "user_id", user.ID, "error", err)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to generate authentication tokens")
 	}
 
-	uc.logger.Info("Login successful", "email", creds.Email, "user_id", user.ID)
+	// Persist the last login time; a failure here should not block the login itself
+	user.UpdateLoginTime()
+	if err := uc.userRepo.UpdateLastLogin(ctx, user.ID, *user.LastLoginAt); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to persist last login time", "user_id", user.ID, "error", err)
+	}
+	uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventLoginSuccess, "")
+
+	core_logger.FromContext(ctx, uc.logger).Info("Login successful", "email", creds.Email, "user_id", user.ID)
 
 	// 6. Return LoginResult (using schema type)
 	// Return the entity and token details directly
@@ -147,65 +235,73 @@ func (uc *userUseCaseImpl) Login(ctx context.Context, creds schema.LoginCredenti
 // TODO: Refactor Refresh to not depend on schema.RefreshResult if schema package is removed
 // Uses locally defined RefreshResult struct
 func (uc *userUseCaseImpl) Refresh(ctx context.Context, refreshToken string) (*schema.RefreshResult, error) {
-	validatedClaims, err := middleware.ValidateRefreshToken(refreshToken, utils.GetEnv("REFRESH_TOKEN_SECRET", "refresh_token_secret_KMT"))
+	validatedClaims, err := middleware.ValidateRefreshToken(refreshToken, utils.GetEnv("REFRESH_TOKEN_SECRET", middleware.DevelopmentRefreshTokenSecret))
 	if err != nil {
 		// Wrap the error for consistency
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, fmt.Sprintf("invalid refresh token: %v", err))
 	}
 
-	userIDStr, okSub := validatedClaims.Data["sub"].(string)
-	if !okSub {
-		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "missing or invalid sub claim in refresh token")
-	}
-	userID, err := uuid.Parse(userIDStr)
+	typedClaims, err := validatedClaims.Claims()
 	if err != nil {
-		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "invalid user id format in refresh token sub claim")
-	}
-	if userID == uuid.Nil {
-		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "refresh token validation yielded invalid user ID")
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, fmt.Sprintf("invalid refresh token claims: %v", err))
 	}
+	userID := typedClaims.UserID
 
 	// 2. Load user from DB using the embedded GetByID
 	// The returned 'user' is *entity.User because the BaseUseCaseImpl is specialized
 	user, err := uc.BaseUseCaseImpl.GetByID(ctx, userID)
 	if err != nil {
 		// Handle GetByID errors (which might already be UseCaseError types)
-		uc.logger.Warn("User for refresh token not found or GetByID failed", "user_id", userID, "error", err)
+		core_logger.FromContext(ctx, uc.logger).Warn("User for refresh token not found or GetByID failed", "user_id", userID, "error", err)
 		// Check if it was a standard 'not found' or another error
 		var ucErr *core_usecase.UseCaseError
 		if errors.As(err, &ucErr) && ucErr.Type == core_usecase.ErrNotFound {
-			return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "invalid user session")
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.invalid_session", nil)
 		}
 		// Return the original error if it wasn't ErrNotFound or wrap it
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user data for refresh")
 	}
-	// Check if user is active *after* confirming user is not nil
+	// Check if user is active *after* confirming user is not nil; soft-deleted users keep their row
+	if user.IsDeleted() {
+		core_logger.FromContext(ctx, uc.logger).Warn("User for refresh token is deleted", "user_id", userID)
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.invalid_session", nil)
+	}
 	if !user.IsActive {
-		uc.logger.Warn("User for refresh token is inactive", "user_id", userID)
-		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "user account is inactive")
+		core_logger.FromContext(ctx, uc.logger).Warn("User for refresh token is inactive", "user_id", userID)
+		return nil, core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.account_inactive", nil)
 	}
 
-	// 3. Prepare claims for the *new* access token (using the fetched user)
-	newAccessTokenClaims := map[string]interface{}{
-		"sub":   user.ID.String(),
-		"email": user.Email,
-		"role":  string(user.Role),
+	// 3. Prepare claims for the *new* access token (using the fetched user); the organization of
+	// the session is kept, with the member's current role
+	claims := middleware.Claims{UserID: user.ID, Email: user.Email, Role: string(user.Role)}
+	if typedClaims.TenantID != uuid.Nil {
+		if err := uc.bindOrganization(ctx, &claims, typedClaims.TenantID); err != nil {
+			var ucErr *core_usecase.UseCaseError
+			if errors.As(err, &ucErr) && ucErr.Type == core_usecase.ErrForbidden {
+				// No longer a member; a token without the organization would widen the user's view
+				return nil, core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.invalid_session", nil)
+			}
+			return nil, err
+		}
 	}
+	newAccessTokenClaims := claims.Encode()
 
 	// 4. Generate *only* a new access token
 	newAccessToken, _, newExpiresAt, err := middleware.GenerateTokenPair(
 		newAccessTokenClaims,
 		uc.accessTokenDuration,
 		uc.refreshTokenDuration,
-		utils.GetEnv("ACCESS_TOKEN_SECRET", "access_token_secret_wqim"),
-		utils.GetEnv("REFRESH_TOKEN_SECRET", "refresh_token_secret_KMT"),
+		utils.GetEnv("ACCESS_TOKEN_SECRET", middleware.DevelopmentAccessTokenSecret),
+		utils.GetEnv("REFRESH_TOKEN_SECRET", middleware.DevelopmentRefreshTokenSecret),
 	)
 	if err != nil {
-		uc.logger.Error("Failed to generate new access token during refresh", "user_id", user.ID, "error", err)
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to generate new access token during refresh", "user_id", user.ID, "error", err)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to refresh access token")
 	}
 
-	uc.logger.Info("Token refresh successful", "user_id", user.ID)
+	uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventTokenRefresh, "")
+
+	core_logger.FromContext(ctx, uc.logger).Info("Token refresh successful", "user_id", user.ID)
 
 	// 5. Return RefreshResult (using locally defined type)
 	return &schema.RefreshResult{
@@ -214,3 +310,527 @@ func (uc *userUseCaseImpl) Refresh(ctx context.Context, refreshToken string) (*s
 		ExpiresAt:    newExpiresAt,
 	}, nil
 }
+
+// Logout implements UserUsecase.
+func (uc *userUseCaseImpl) Logout(ctx context.Context, refreshToken string) error {
+	if uc.revoked == nil {
+		return core_usecase.NewUseCaseError(core_usecase.ErrInternal, "token revocation is not configured")
+	}
+	validatedClaims, err := middleware.ValidateRefreshToken(refreshToken, utils.GetEnv("REFRESH_TOKEN_SECRET", middleware.DevelopmentRefreshTokenSecret))
+	if err != nil {
+		return core_usecase.NewLocalizedError(core_usecase.ErrUnauthorized, "auth.invalid_session", nil)
+	}
+	if validatedClaims.ID == "" || validatedClaims.ExpiresAt == nil {
+		// Issued before tokens carried an ID; it stays valid until it expires
+		core_logger.FromContext(ctx, uc.logger).Warn("Logout with a refresh token that cannot be revoked", "subject", validatedClaims.Subject)
+		return nil
+	}
+
+	if err := uc.revoked.Revoke(ctx, validatedClaims.ID, validatedClaims.ExpiresAt.Time); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to revoke refresh token", "subject", validatedClaims.Subject, "error", err)
+		return core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to revoke refresh token")
+	}
+
+	if typedClaims, err := validatedClaims.Claims(); err == nil {
+		uc.recordSecurityEvent(ctx, &typedClaims.UserID, typedClaims.Email, entity.SecurityEventLogout, "")
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("Logout successful", "subject", validatedClaims.Subject)
+	return nil
+}
+
+// Introspect implements UserUsecase. A token is active when its signature, issuer, audience and
+// expiry are valid, it is not revoked, and its user still exists and is active. Why a token is
+// inactive is logged but not returned, as RFC 7662 recommends.
+func (uc *userUseCaseImpl) Introspect(ctx context.Context, token, typeHint string) (*schema.IntrospectionResult, error) {
+	if token == "" {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "token cannot be empty")
+	}
+	log := core_logger.FromContext(ctx, uc.logger)
+	inactive := &schema.IntrospectionResult{Active: false}
+
+	validators := map[string]func() (*middleware.UserClaims, error){
+		schema.TokenTypeAccess: func() (*middleware.UserClaims, error) {
+			return middleware.ValidateAccessToken(token, utils.GetEnv("ACCESS_TOKEN_SECRET", middleware.DevelopmentAccessTokenSecret))
+		},
+		schema.TokenTypeRefresh: func() (*middleware.UserClaims, error) {
+			return middleware.ValidateRefreshToken(token, utils.GetEnv("REFRESH_TOKEN_SECRET", middleware.DevelopmentRefreshTokenSecret))
+		},
+	}
+	order := []string{schema.TokenTypeAccess, schema.TokenTypeRefresh}
+	if typeHint == schema.TokenTypeRefresh {
+		order[0], order[1] = order[1], order[0]
+	}
+	var claims *middleware.UserClaims
+	var tokenType string
+	for _, candidate := range order {
+		validated, err := validators[candidate]()
+		if err == nil {
+			claims, tokenType = validated, candidate
+			break
+		}
+		log.Debug("Token is not an active "+candidate, "error", err)
+	}
+	if claims == nil {
+		return inactive, nil
+	}
+
+	typed, err := claims.Claims()
+	if err != nil {
+		log.Debug("Introspected token has invalid claims", "error", err)
+		return inactive, nil
+	}
+	user, err := uc.userRepo.FindByID(ctx, typed.UserID)
+	if err != nil {
+		if errors.Is(err, core_repo.ErrNotFound) {
+			log.Debug("Introspected token belongs to a deleted user", "user_id", typed.UserID)
+			return inactive, nil
+		}
+		log.Error("Failed to load the user of an introspected token", "user_id", typed.UserID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to introspect token")
+	}
+	if user.IsDeleted() {
+		log.Debug("Introspected token belongs to a deleted user", "user_id", typed.UserID)
+		return inactive, nil
+	}
+	if !user.IsActive {
+		log.Debug("Introspected token belongs to an inactive user", "user_id", typed.UserID)
+		return inactive, nil
+	}
+
+	result := &schema.IntrospectionResult{
+		Active:    true,
+		TokenType: tokenType,
+		Claims:    typed,
+		TokenID:   claims.ID,
+		Issuer:    claims.Issuer,
+		Audience:  claims.Audience,
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return result, nil
+}
+
+// bindOrganization sets the organization claims of a token. orgID uuid.Nil selects the user's
+// oldest membership, and users without memberships get a token without organization.
+func (uc *userUseCaseImpl) bindOrganization(ctx context.Context, claims *middleware.Claims, orgID uuid.UUID) error {
+	if uc.memberships == nil {
+		return nil
+	}
+	var membership *entity.Membership
+	var err error
+	if orgID == uuid.Nil {
+		membership, err = uc.memberships.FirstByUser(ctx, claims.UserID)
+	} else {
+		membership, err = uc.memberships.FindMembership(ctx, orgID, claims.UserID)
+	}
+	switch {
+	case err == nil:
+		claims.TenantID, claims.OrgRole = membership.OrganizationID, string(membership.Role)
+		return nil
+	case !errors.Is(err, core_repo.ErrNotFound):
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load membership for token", "user_id", claims.UserID, "organization_id", orgID, "error", err)
+		return core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to generate authentication tokens")
+	case orgID != uuid.Nil:
+		return core_usecase.NewUseCaseError(core_usecase.ErrForbidden, "the user is not a member of the organization")
+	default:
+		return nil
+	}
+}
+
+// List overrides the base List to scope it to the caller's organization (see organizationScope).
+func (uc *userUseCaseImpl) List(ctx context.Context, opts core_types.FilterOptions) (*core_types.PaginationResult[entity.User], error) {
+	opts, err := uc.organizationScope(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return uc.BaseUseCaseImpl.List(ctx, opts)
+}
+
+// FindWithFilter overrides the base FindWithFilter to scope it to the caller's organization.
+func (uc *userUseCaseImpl) FindWithFilter(ctx context.Context, filter map[string]interface{}, opts core_types.FilterOptions) (*core_types.PaginationResult[entity.User], error) {
+	opts, err := uc.organizationScope(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return uc.BaseUseCaseImpl.FindWithFilter(ctx, filter, opts)
+}
+
+// GetByID overrides the base GetByID to scope it to the caller's organization: a user outside it
+// is reported as not found, the same as List leaving it out.
+func (uc *userUseCaseImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	if err := uc.requireInOrganization(ctx, id, false); err != nil {
+		return nil, err
+	}
+	return uc.BaseUseCaseImpl.GetByID(ctx, id)
+}
+
+// requireInOrganization returns NotFound for a user outside the caller's organization (see
+// scopedOrganization), so it can be neither read nor written. With deleted, memberships
+// soft-deleted with the user count, for restoring it.
+func (uc *userUseCaseImpl) requireInOrganization(ctx context.Context, id uuid.UUID, deleted bool) error {
+	orgID, scoped, err := uc.scopedOrganization(ctx)
+	if err != nil || !scoped {
+		return err
+	}
+	member := true
+	if deleted {
+		member, err = uc.memberships.WasMember(ctx, orgID, id)
+	} else if _, err = uc.memberships.FindMembership(ctx, orgID, id); errors.Is(err, core_repo.ErrNotFound) {
+		member, err = false, nil
+	}
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load organization membership", "organization_id", orgID, "user_id", id, "error", err)
+		return core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to check organization membership")
+	}
+	if !member {
+		return core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+	}
+	return nil
+}
+
+// organizationMembers returns the IDs of the members of the caller's organization, and false when
+// the caller is not scoped to one (see scopedOrganization)
+func (uc *userUseCaseImpl) organizationMembers(ctx context.Context) (map[uuid.UUID]bool, bool, error) {
+	orgID, scoped, err := uc.scopedOrganization(ctx)
+	if err != nil || !scoped {
+		return nil, false, err
+	}
+	memberIDs, err := uc.memberships.MemberIDs(ctx, orgID)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load organization members", "organization_id", orgID, "error", err)
+		return nil, false, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to check organization membership")
+	}
+	members := make(map[uuid.UUID]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		members[id] = true
+	}
+	return members, true, nil
+}
+
+// failOutsideOrganization reports the bulk item at index as not found, as requireInOrganization does
+func failOutsideOrganization(ctx context.Context, result *core_types.BulkResult, index int, id uuid.UUID) {
+	err := core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "resource.not_found", map[string]string{"id": id.String()})
+	result.Failed = append(result.Failed, core_types.BatchFailure{Index: index, Reason: core_usecase.FailureReason(ctx, err), Err: err})
+}
+
+// scopedOrganization returns the organization the caller's user queries are restricted to, and
+// false when they are not: callers with PermissionListAllUsers, and tokens without an
+// organization, see every user.
+func (uc *userUseCaseImpl) scopedOrganization(ctx context.Context) (uuid.UUID, bool, error) {
+	actor, ok := core_usecase.ActorFromContext(ctx)
+	if !ok || actor.TenantID == "" || uc.memberships == nil || actor.HasPermission(PermissionListAllUsers) {
+		return uuid.Nil, false, nil
+	}
+	orgID, err := uuid.Parse(actor.TenantID)
+	if err != nil {
+		return uuid.Nil, false, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "invalid organization claim")
+	}
+	return orgID, true, nil
+}
+
+// organizationScope restricts user queries of a caller whose token is bound to an organization to
+// the members of that organization (see scopedOrganization).
+func (uc *userUseCaseImpl) organizationScope(ctx context.Context, opts core_types.FilterOptions) (core_types.FilterOptions, error) {
+	orgID, scoped, err := uc.scopedOrganization(ctx)
+	if err != nil || !scoped {
+		return opts, err
+	}
+	memberIDs, err := uc.memberships.MemberIDs(ctx, orgID)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to load organization members", "organization_id", orgID, "error", err)
+		return opts, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to list users")
+	}
+	return core_types.FilterOptionsBuilderFrom(opts).
+		WithConditions(core_types.NewCondition("id", core_types.OpIn, memberIDs)).
+		BuildUnlimited()
+}
+
+// withinUsersQuota enforces the users quota for n more users and runs create in the same
+// transaction, so the quota's lock is held until the created users are committed and concurrent
+// creates cannot pass the limit together
+func (uc *userUseCaseImpl) withinUsersQuota(ctx context.Context, n int64, create func(ctx context.Context) error) error {
+	return uc.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.quotas.Enforce(ctx, QuotaUsers, core_quota.Global, n); err != nil {
+			return err
+		}
+		return create(ctx)
+	})
+}
+
+// Create overrides the base Create to enforce the users quota and publish a user.created event.
+func (uc *userUseCaseImpl) Create(ctx context.Context, user *entity.User) error {
+	err := uc.withinUsersQuota(ctx, 1, func(ctx context.Context) error {
+		return uc.BaseUseCaseImpl.Create(ctx, user)
+	})
+	if err != nil {
+		return err
+	}
+	uc.publish(ctx, EventUserCreated, user)
+	return nil
+}
+
+// CreateMany overrides the base CreateMany to enforce the users quota and publish a user.created event per created user.
+func (uc *userUseCaseImpl) CreateMany(ctx context.Context, users []*entity.User) (*core_types.BulkResult, error) {
+	var result *core_types.BulkResult
+	err := uc.withinUsersQuota(ctx, int64(len(users)), func(ctx context.Context) error {
+		var err error
+		result, err = uc.BaseUseCaseImpl.CreateMany(ctx, users)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range core_types.SucceededItems(result, users) {
+		uc.publish(ctx, EventUserCreated, user)
+	}
+	return result, nil
+}
+
+// CreateInBatches overrides the base CreateInBatches to enforce the users quota and publish a
+// user.created event per created user. The quota is checked for the whole call up front.
+func (uc *userUseCaseImpl) CreateInBatches(ctx context.Context, users []*entity.User, opts core_types.BatchOptions) (*core_types.BatchReport[entity.User], error) {
+	var report *core_types.BatchReport[entity.User]
+	var batchErr error
+	err := uc.withinUsersQuota(ctx, int64(len(users)), func(ctx context.Context) error {
+		// A failed batch does not undo the batches written before it, as without the quota
+		report, batchErr = uc.BaseUseCaseImpl.CreateInBatches(ctx, users, opts)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = batchErr
+	if report != nil {
+		for _, user := range report.Succeeded {
+			uc.publish(ctx, EventUserCreated, user)
+		}
+	}
+	return report, err
+}
+
+// Update overrides the base Update to scope it to the caller's organization and to record a
+// password_change event when a new password is saved.
+func (uc *userUseCaseImpl) Update(ctx context.Context, user *entity.User) error {
+	if user != nil && user.ID != uuid.Nil {
+		if err := uc.requireInOrganization(ctx, user.ID, false); err != nil {
+			return err
+		}
+	}
+	passwordChanged := user != nil && user.HasPendingPasswordChange()
+	if err := uc.BaseUseCaseImpl.Update(ctx, user); err != nil {
+		return err
+	}
+	if passwordChanged && !core_usecase.IsDryRun(ctx) {
+		uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventPasswordChange, "")
+	}
+	uc.publish(ctx, EventUserUpdated, user)
+	return nil
+}
+
+// Delete overrides the base Delete to scope it to the caller's organization and to publish a
+// user.deleted event.
+func (uc *userUseCaseImpl) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+	if err := uc.requireInOrganization(ctx, id, false); err != nil {
+		return err
+	}
+	if err := uc.BaseUseCaseImpl.Delete(ctx, id, hardDelete); err != nil {
+		return err
+	}
+	uc.publishDeleted(ctx, id, hardDelete)
+	return nil
+}
+
+// Restore overrides the base Restore to scope it to the caller's organization and to publish a
+// user.restored event.
+func (uc *userUseCaseImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := uc.requireInOrganization(ctx, id, true); err != nil {
+		return err
+	}
+	if err := uc.BaseUseCaseImpl.Restore(ctx, id); err != nil {
+		return err
+	}
+	if core_usecase.IsDryRun(ctx) {
+		return nil
+	}
+	user, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to load restored user, user.restored not published", "user_id", id, "error", err)
+		return nil
+	}
+	uc.publish(ctx, EventUserRestored, user)
+	return nil
+}
+
+// DeleteMany overrides the base DeleteMany to scope it to the caller's organization and to
+// publish a user.deleted event per deleted ID. IDs outside the organization are reported as not found.
+func (uc *userUseCaseImpl) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) (*core_types.BulkResult, error) {
+	members, scoped, err := uc.organizationMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := core_types.NewBulkResult(len(ids))
+	inScope, indices := make([]uuid.UUID, 0, len(ids)), make([]int, 0, len(ids))
+	for i, id := range ids {
+		if scoped && !members[id] {
+			failOutsideOrganization(ctx, result, i, id)
+			continue
+		}
+		inScope, indices = append(inScope, id), append(indices, i)
+	}
+	deleted, err := uc.BaseUseCaseImpl.DeleteMany(ctx, inScope, hardDelete)
+	if err != nil {
+		return nil, err
+	}
+	result.Merge(deleted, indices)
+	for _, id := range result.Succeeded {
+		uc.publishDeleted(ctx, id, hardDelete)
+	}
+	return result, nil
+}
+
+// UpdateMany overrides the base UpdateMany to scope it to the caller's organization and to record
+// password_change events for bulk updates. Users outside the organization are reported as not found.
+func (uc *userUseCaseImpl) UpdateMany(ctx context.Context, users []*entity.User) (*core_types.BulkResult, error) {
+	members, scoped, err := uc.organizationMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := core_types.NewBulkResult(len(users))
+	inScope, indices := make([]*entity.User, 0, len(users)), make([]int, 0, len(users))
+	passwordChanged := make(map[*entity.User]bool)
+	for i, user := range users {
+		if scoped && user != nil && user.ID != uuid.Nil && !members[user.ID] {
+			failOutsideOrganization(ctx, result, i, user.ID)
+			continue
+		}
+		if user != nil && user.HasPendingPasswordChange() {
+			passwordChanged[user] = true
+		}
+		inScope, indices = append(inScope, user), append(indices, i)
+	}
+	updated, err := uc.BaseUseCaseImpl.UpdateMany(ctx, inScope)
+	if err != nil {
+		return nil, err
+	}
+	result.Merge(updated, indices)
+	for _, user := range core_types.SucceededItems(result, users) {
+		if passwordChanged[user] && !core_usecase.IsDryRun(ctx) {
+			uc.recordSecurityEvent(ctx, &user.ID, user.Email, entity.SecurityEventPasswordChange, "bulk update")
+		}
+		uc.publish(ctx, EventUserUpdated, user)
+	}
+	return result, nil
+}
+
+// GetSecurityEvents implements UserUsecase.
+func (uc *userUseCaseImpl) GetSecurityEvents(ctx context.Context, userID uuid.UUID, opts core_types.FilterOptions) (*core_types.PaginationResult[entity.SecurityEvent], error) {
+	// Make sure the user exists so unknown IDs surface as NotFound instead of an empty list
+	if err := uc.BaseUseCaseImpl.RequireExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.securityEventRepo.FindByUserID(ctx, userID, opts)
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to list security events", "user_id", userID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve security events")
+	}
+	return result, nil
+}
+
+// AnonymizeUser implements UserUsecase. Issued access tokens stay valid until they expire, but the
+// account is deactivated, so they can no longer be refreshed.
+func (uc *userUseCaseImpl) AnonymizeUser(ctx context.Context, id uuid.UUID, reason string) (*entity.ErasureTombstone, error) {
+	if core_usecase.IsDryRun(ctx) {
+		// The erasure cannot be previewed without reading the data it removes
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "erasure does not support dry runs")
+	}
+
+	tombstone := &entity.ErasureTombstone{UserID: id, Reason: reason, ErasedAt: time.Now().UTC()}
+	if actor, ok := core_usecase.ActorFromContext(ctx); ok {
+		if actorID, err := uuid.Parse(actor.ID); err == nil {
+			tombstone.RequestedBy = &actorID
+		}
+	}
+
+	if err := uc.userRepo.Erase(ctx, tombstone); err != nil {
+		switch {
+		case errors.Is(err, core_repo.ErrNotFound):
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrNotFound, "user.not_found", nil)
+		case errors.Is(err, user_repository.ErrAlreadyErased):
+			return nil, core_usecase.NewLocalizedError(core_usecase.ErrConflict, "user.already_erased", nil)
+		}
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to erase user", "user_id", id, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to erase user data")
+	}
+
+	data := map[string]interface{}{"id": id, "erased_at": tombstone.ErasedAt}
+	if err := uc.events.Publish(ctx, core_events.NewEvent(EventUserErased, data)); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to publish user event", "event_type", EventUserErased, "user_id", id, "error", err)
+	}
+	core_logger.FromContext(ctx, uc.logger).Info("User personal data erased", "user_id", id, "tombstone_id", tombstone.ID)
+	return tombstone, nil
+}
+
+// recordSecurityEvent stores a security event, enriched with the caller IP and User-Agent from gRPC metadata.
+// Failures are logged and swallowed so auditing never breaks the main flow.
+func (uc *userUseCaseImpl) recordSecurityEvent(ctx context.Context, userID *uuid.UUID, email string, eventType entity.SecurityEventType, details string) {
+	if uc.securityEventRepo == nil {
+		return
+	}
+	client := core_grpc.ClientInfoFromContext(ctx)
+	event := &entity.SecurityEvent{
+		UserID:    userID,
+		Email:     email,
+		EventType: eventType,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Details:   details,
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to record security event", "event_type", eventType, "email", email, "error", err)
+	}
+}
+
+// userEventData is the payload of user events; it deliberately omits the password hash
+type userEventData struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	FirstName string    `json:"first_name,omitempty"`
+	LastName  string    `json:"last_name,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	IsActive  bool      `json:"is_active"`
+}
+
+// publish emits a user event; publishing failures never fail the use case
+func (uc *userUseCaseImpl) publish(ctx context.Context, eventType string, user *entity.User) {
+	if user == nil || core_usecase.IsDryRun(ctx) {
+		return
+	}
+	data := userEventData{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      string(user.Role),
+		IsActive:  user.IsActive,
+	}
+	if err := uc.events.Publish(ctx, core_events.NewEvent(eventType, data)); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to publish user event", "event_type", eventType, "user_id", user.ID, "error", err)
+	}
+}
+
+func (uc *userUseCaseImpl) publishDeleted(ctx context.Context, id uuid.UUID, hardDelete bool) {
+	if core_usecase.IsDryRun(ctx) {
+		return
+	}
+	data := map[string]interface{}{"id": id, "hard_delete": hardDelete}
+	if err := uc.events.Publish(ctx, core_events.NewEvent(EventUserDeleted, data)); err != nil {
+		core_logger.FromContext(ctx, uc.logger).Warn("Failed to publish user event", "event_type", EventUserDeleted, "user_id", id, "error", err)
+	}
+}
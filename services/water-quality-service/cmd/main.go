@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+func main() {
+	// Load environment variables
+	if err := utils.LoadEnv(); err != nil {
+		log.Printf("Warning: .env file not found, using environment variables")
+	}
+
+	// Setup all services
+	ctx := context.Background()
+	lc, err := SetupServices(ctx)
+	if err != nil {
+		log.Fatalf("Failed to setup services: %v", err)
+	}
+
+	// Run the gRPC server until SIGINT/SIGTERM, then stop it within SHUTDOWN_TIMEOUT
+	if err := lc.Run(ctx); err != nil {
+		log.Fatalf("Water quality service stopped with errors: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/blob"
+	"golang-microservices-boilerplate/pkg/core/bootstrap"
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/lifecycle"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/preflight"
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/controller"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/entity"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/repository"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/usecase"
+)
+
+// SetupServices initializes the water quality service and registers its long-lived components
+// with the returned lifecycle manager. The probes are already serving /live and /ready when it returns.
+func SetupServices(ctx context.Context) (*lifecycle.Manager, error) {
+	// Initialize logger
+	logConfig := logger.LoadLogConfigFromEnv()
+	logConfig.AppName = utils.GetEnv("SERVER_APP_NAME", "Water Quality Service")
+	appLogger, err := logger.NewLogger(logConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	appLogger.Info("Setting up water quality service")
+	lc := lifecycle.New(appLogger)
+
+	// Report every configuration problem at once, before anything is started
+	dbConfig := database.DefaultDBConfig()
+	serverConfig := grpc.DefaultGrpcServerConfig()
+	err = preflight.New(appLogger).
+		Check("jwt-secrets", preflight.JWTSecrets).
+		Check("database", bootstrap.DatabaseCheck(dbConfig)).
+		Check("grpc-port", preflight.PortAvailable(net.JoinHostPort(serverConfig.Host, serverConfig.Port))).
+		Check("health-port", preflight.PortAvailable(":"+utils.GetEnv("HEALTH_PORT", "8081"))).
+		Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serve liveness right away so the pod is not restarted while waiting for dependencies
+	probes := bootstrap.NewProbesFromEnv(appLogger)
+	probes.Start()
+	lc.Add(lifecycle.Component{Name: "probes", Stop: probes.Shutdown})
+
+	// Measurements are kept in a TimescaleDB hypertable when the extension is available
+	timescale := utils.GetEnv("TIMESCALEDB_ENABLED", "false") == "true"
+	var db *database.DatabaseConnection
+	err = bootstrap.NewRunner(appLogger).
+		Phase("database", func(ctx context.Context) error {
+			db, err = bootstrap.ConnectDatabase(ctx, appLogger, dbConfig)
+			return err
+		}).
+		Phase("postgis", func(ctx context.Context) error {
+			// Measurement locations are PostGIS points, so the extension must exist before migrating
+			return repository.EnablePostGIS(ctx, db.DB)
+		}).
+		Phase("migrations", func(ctx context.Context) error {
+			mode, err := database.MigrationModeFromEnv()
+			if err != nil {
+				return err
+			}
+			database.RegisterModels(&entity.Measurement{}, &entity.Upload{})
+			diff, err := db.SyncRegisteredModels(mode)
+			if err != nil {
+				return err
+			}
+			// Drift is reported, not fixed: production schemas are changed by reviewed migrations
+			for _, change := range diff.Changes {
+				appLogger.Warn("Schema drift detected", "change", change.String())
+			}
+			return nil
+		}).
+		Phase("hypertable", func(ctx context.Context) error {
+			if !timescale {
+				return nil
+			}
+			return repository.EnableHypertable(ctx, db.DB, utils.GetEnvDuration("MEASUREMENT_CHUNK_INTERVAL", 7*24*time.Hour))
+		}).
+		Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	probes.AddReadinessCheck("database", db.PingContext)
+
+	queryMetrics, err := db.UseQueryMetrics(database.LoadQueryMetricsConfigFromEnv(), appLogger)
+	if err != nil {
+		return nil, err
+	}
+	probes.Handle("/metrics", bootstrap.MetricsHandler(queryMetrics))
+
+	// Uploaded files are kept in the blob store next to the measurements read from them
+	blobStore, err := blob.NewFromConfig(blob.LoadConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize repositories and use cases
+	measurementRepo := repository.NewMeasurementRepository(db.DB, timescale)
+	uploadRepo := repository.NewUploadRepository(db.DB)
+	measurementUseCase := usecase.NewMeasurementUseCase(measurementRepo, uploadRepo, blobStore,
+		utils.GetEnvDuration("MEASUREMENT_QUERY_MAX_RANGE", 366*24*time.Hour),
+		utils.GetEnvAsInt("MEASUREMENT_QUERY_MAX_RESULTS", 10000), appLogger)
+
+	// Initialize gRPC server and register the service implementation
+	grpcServer := grpc.NewBaseGrpcServerWithConfig(appLogger, serverConfig)
+	controller.RegisterWaterQualityServiceServer(grpcServer.Server(), measurementUseCase)
+
+	// Registered last so it stops first: readiness drops and in-flight calls drain before the
+	// probes stop
+	lc.Add(lifecycle.Component{
+		Name: "grpc",
+		Start: func(context.Context) error {
+			if err := grpcServer.Start(); err != nil {
+				return err
+			}
+			probes.SetReady(true)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			probes.SetReady(false) // Stop receiving new traffic while draining
+			return grpcServer.Shutdown(ctx)
+		},
+	})
+
+	log.Printf("Water quality service setup completed successfully")
+	return lc, nil
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreController "golang-microservices-boilerplate/pkg/core/controller"
+	"golang-microservices-boilerplate/pkg/core/dto"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	pb "golang-microservices-boilerplate/proto/water-quality-service"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/repository"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/usecase"
+)
+
+// waterQualityServer implements pb.WaterQualityServiceServer on top of the measurement use case
+type waterQualityServer struct {
+	pb.UnimplementedWaterQualityServiceServer
+	measurements usecase.MeasurementUsecase
+}
+
+// RegisterWaterQualityServiceServer registers the water quality service with the gRPC server.
+func RegisterWaterQualityServiceServer(s *grpc.Server, measurements usecase.MeasurementUsecase) {
+	pb.RegisterWaterQualityServiceServer(s, &waterQualityServer{measurements: measurements})
+}
+
+// UploadData implements proto.WaterQualityServiceServer. The filename and file type must be sent
+// before the first data chunk; the content is ingested as it arrives.
+func (s *waterQualityServer) UploadData(stream grpc.ClientStreamingServer[pb.UploadRequest, pb.UploadResponse]) error {
+	var filename, fileType string
+	var first []byte
+	for first == nil {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch payload := req.GetPayload().(type) {
+		case *pb.UploadRequest_Filename:
+			filename = payload.Filename
+		case *pb.UploadRequest_FileType:
+			fileType = payload.FileType
+		case *pb.UploadRequest_DataChunk:
+			first = payload.DataChunk
+			if first == nil {
+				first = []byte{}
+			}
+		}
+	}
+	if filename == "" || fileType == "" {
+		return coreController.GrpcErrorf(codes.InvalidArgument, "filename and file_type must be sent before the file content")
+	}
+
+	content := &chunkReader{recv: stream.Recv, buf: first}
+	result, err := s.measurements.Ingest(stream.Context(), filename, fileType, content)
+	if content.err != nil && !errors.Is(content.err, io.EOF) {
+		return content.err // The stream broke; its status is more useful than the ingest error
+	}
+	if err != nil {
+		return coreController.FromUseCaseError(stream.Context(), err)
+	}
+	return stream.SendAndClose(&pb.UploadResponse{
+		UploadId: result.Upload.ID.String(),
+		Rows:     result.Upload.Rows,
+		Ingested: result.Upload.Ingested,
+		Failures: coreTypes.BatchFailuresToProto(result.Failures),
+	})
+}
+
+// QueryMeasurements implements proto.WaterQualityServiceServer.
+func (s *waterQualityServer) QueryMeasurements(ctx context.Context, req *pb.QueryMeasurementsRequest) (*pb.QueryMeasurementsResponse, error) {
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return nil, coreController.GrpcErrorf(codes.InvalidArgument, "from and to are required")
+	}
+	q := repository.MeasurementQuery{
+		From:      req.GetFrom().AsTime(),
+		To:        req.GetTo().AsTime(),
+		StationID: req.GetStationId(),
+		Parameter: req.GetParameter(),
+		Limit:     int(req.GetLimit()),
+	}
+	if req.WithinRadius != nil {
+		radius, err := dto.GeoRadiusFromProto(req.GetWithinRadius())
+		if err != nil {
+			return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid within_radius: %v", err)
+		}
+		q.WithinRadius = &radius
+	}
+	if req.WithinBox != nil {
+		box, err := dto.GeoBoundingBoxFromProto(req.GetWithinBox())
+		if err != nil {
+			return nil, coreController.GrpcErrorf(codes.InvalidArgument, "invalid within_box: %v", err)
+		}
+		q.WithinBox = &box
+	}
+	result, err := s.measurements.Query(ctx, q, req.GetInterval())
+	if err != nil {
+		return nil, coreController.FromUseCaseError(ctx, err)
+	}
+
+	resp := &pb.QueryMeasurementsResponse{Truncated: result.Truncated}
+	for _, m := range result.Measurements {
+		measurement := &pb.Measurement{
+			StationId:  m.StationID,
+			Parameter:  m.Parameter,
+			Value:      m.Value,
+			Unit:       m.Unit,
+			MeasuredAt: timestamppb.New(m.MeasuredAt),
+		}
+		if m.Location != nil {
+			measurement.Location = dto.GeoPointToProto(*m.Location)
+		}
+		resp.Measurements = append(resp.Measurements, measurement)
+	}
+	for _, b := range result.Buckets {
+		resp.Buckets = append(resp.Buckets, &pb.MeasurementBucket{
+			StationId:   b.StationID,
+			Parameter:   b.Parameter,
+			BucketStart: timestamppb.New(b.BucketStart),
+			Avg:         b.Avg,
+			Min:         b.Min,
+			Max:         b.Max,
+			Count:       b.Count,
+		})
+	}
+	return resp, nil
+}
+
+// chunkReader reads the data chunks of an upload stream as one file. Metadata messages after the
+// first chunk are ignored.
+type chunkReader struct {
+	recv func() (*pb.UploadRequest, error)
+	buf  []byte
+	err  error // Error that ended the stream, io.EOF once it is complete
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		req, err := r.recv()
+		if err != nil {
+			r.err = err
+			continue
+		}
+		r.buf = req.GetDataChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
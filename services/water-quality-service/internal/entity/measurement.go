@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+
+	"github.com/google/uuid"
+)
+
+// Measurement is one reading of a parameter at a station. Its primary key leads with the time
+// column so the table can be turned into a TimescaleDB hypertable partitioned by measured_at, and
+// a reading ingested twice replaces the first one instead of duplicating it. Locations are PostGIS
+// points, so the table needs the postgis extension (see repository.EnablePostGIS).
+type Measurement struct {
+	MeasuredAt time.Time `json:"measured_at" gorm:"primaryKey;not null;index:idx_measurements_station_time,priority:2"`
+	StationID  string    `json:"station_id" gorm:"primaryKey;size:64;not null;index:idx_measurements_station_time,priority:1"`
+	Parameter  string    `json:"parameter" gorm:"primaryKey;size:64;not null"`
+	Value      float64   `json:"value" gorm:"not null"`
+	Unit       string    `json:"unit" gorm:"size:32"`
+	UploadID   uuid.UUID `json:"upload_id" gorm:"type:uuid;not null"` // Upload the reading was last ingested from
+	// Location of the station when the reading was taken; nil when the file had no coordinates
+	Location *types.GeoPoint `json:"location,omitempty" gorm:"index:idx_measurements_location,type:gist"`
+}
+
+// TableName overrides the table name
+func (Measurement) TableName() string {
+	return "measurements"
+}
+
+// UploadStatus is the state of an upload
+type UploadStatus string
+
+const (
+	UploadIngested UploadStatus = "ingested" // Every row was stored
+	UploadPartial  UploadStatus = "partial"  // Some rows were rejected; the others were stored
+	UploadFailed   UploadStatus = "failed"   // The file could not be read or stored
+)
+
+// Upload is an uploaded measurement file. The raw file is kept in the blob store under BlobKey.
+type Upload struct {
+	entity.BaseEntity              // Embed core base entity
+	Filename          string       `json:"filename" gorm:"size:255;not null"`
+	FileType          string       `json:"file_type" gorm:"size:16;not null"`
+	BlobKey           string       `json:"-" gorm:"size:255"`
+	SizeBytes         int64        `json:"size_bytes"`
+	Rows              int64        `json:"rows"`
+	Ingested          int64        `json:"ingested"`
+	Status            UploadStatus `json:"status" gorm:"size:16;not null"`
+	UploadedBy        *uuid.UUID   `json:"uploaded_by,omitempty" gorm:"type:uuid"`
+}
+
+// TableName overrides the table name
+func (Upload) TableName() string {
+	return "uploads"
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Interval is the width of the buckets measurements are aggregated into
+type Interval string
+
+const (
+	IntervalHour Interval = "hour"
+	IntervalDay  Interval = "day"
+)
+
+// IsValid reports whether i is a supported interval
+func (i Interval) IsValid() bool {
+	return i == IntervalHour || i == IntervalDay
+}
+
+// MeasurementQuery selects the measurements in [From, To); empty StationID and Parameter and nil
+// geo filters match all. The geo filters only match measurements with a location.
+type MeasurementQuery struct {
+	From         time.Time
+	To           time.Time
+	StationID    string
+	Parameter    string
+	WithinRadius *core_types.GeoRadius      // Stations within a distance of a point
+	WithinBox    *core_types.GeoBoundingBox // Stations inside a bounding box
+	Limit        int                        // Maximum number of rows returned, oldest first
+}
+
+// Bucket aggregates the measurements of a station and parameter within one interval
+type Bucket struct {
+	StationID   string
+	Parameter   string
+	BucketStart time.Time
+	Avg         float64
+	Min         float64
+	Max         float64
+	Count       int64
+}
+
+// MeasurementRepository defines persistence operations for the measurements table. Measurements
+// have a natural key instead of an ID, so it does not embed the core BaseRepository.
+type MeasurementRepository interface {
+	// Upsert stores the measurements DB_BATCH_SIZE rows per statement. A measurement of the same
+	// station, parameter and time replaces the stored one.
+	Upsert(ctx context.Context, measurements []entity.Measurement) error
+	// Find returns the measurements matching q, oldest first.
+	Find(ctx context.Context, q MeasurementQuery) ([]entity.Measurement, error)
+	// Aggregate returns the average, minimum and maximum of the measurements matching q per
+	// station, parameter and interval, oldest bucket first.
+	Aggregate(ctx context.Context, q MeasurementQuery, interval Interval) ([]Bucket, error)
+}
+
+// gormMeasurementRepository implements MeasurementRepository using GORM
+type gormMeasurementRepository struct {
+	db        *gorm.DB
+	timescale bool // Buckets with time_bucket instead of date_trunc
+}
+
+// NewMeasurementRepository creates a new MeasurementRepository using the provided GORM DB
+// connection. Pass timescale when the table is a hypertable (see EnableHypertable).
+func NewMeasurementRepository(db *gorm.DB, timescale bool) MeasurementRepository {
+	return &gormMeasurementRepository{db: db, timescale: timescale}
+}
+
+// EnableHypertable turns the measurements table into a TimescaleDB hypertable partitioned by
+// measured_at in chunks of chunkInterval, creating the extension if needed. Existing rows are
+// moved into chunks; calling it again is a no-op.
+func EnableHypertable(ctx context.Context, db *gorm.DB, chunkInterval time.Duration) error {
+	db = db.WithContext(ctx)
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS timescaledb").Error; err != nil {
+		return fmt.Errorf("failed to create timescaledb extension: %w", err)
+	}
+	err := db.Exec("SELECT create_hypertable('measurements', 'measured_at', chunk_time_interval => ?::interval, if_not_exists => TRUE, migrate_data => TRUE)",
+		fmt.Sprintf("%d seconds", int64(chunkInterval.Seconds()))).Error
+	if err != nil {
+		return fmt.Errorf("failed to create measurements hypertable: %w", err)
+	}
+	return nil
+}
+
+// EnablePostGIS creates the postgis extension the location column needs. It is a no-op on other
+// databases, which store locations as text and cannot run the geo filters.
+func EnablePostGIS(ctx context.Context, db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	if err := db.WithContext(ctx).Exec("CREATE EXTENSION IF NOT EXISTS postgis").Error; err != nil {
+		return fmt.Errorf("failed to create postgis extension: %w", err)
+	}
+	return nil
+}
+
+// conn returns the request's transaction when ctx carries one, else the repository's DB
+func (r *gormMeasurementRepository) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := core_repo.TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Upsert implements MeasurementRepository.
+func (r *gormMeasurementRepository) Upsert(ctx context.Context, measurements []entity.Measurement) error {
+	if len(measurements) == 0 {
+		return nil
+	}
+	return r.conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "measured_at"}, {Name: "station_id"}, {Name: "parameter"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "unit", "upload_id", "location"}),
+	}).CreateInBatches(&measurements, core_repo.DefaultBatchSize()).Error
+}
+
+// matching returns the query of the measurements selected by q
+func (r *gormMeasurementRepository) matching(ctx context.Context, q MeasurementQuery) *gorm.DB {
+	db := r.conn(ctx).Model(&entity.Measurement{}).Where("measured_at >= ? AND measured_at < ?", q.From, q.To)
+	if q.StationID != "" {
+		db = db.Where("station_id = ?", q.StationID)
+	}
+	if q.Parameter != "" {
+		db = db.Where("parameter = ?", q.Parameter)
+	}
+	var conditions []core_types.FilterCondition
+	if q.WithinRadius != nil {
+		conditions = append(conditions, core_types.WithinRadius("location", q.WithinRadius.Center, q.WithinRadius.RadiusMeters))
+	}
+	if q.WithinBox != nil {
+		conditions = append(conditions, core_types.WithinBox("location", *q.WithinBox))
+	}
+	db = core_repo.ApplyConditions(db, conditions)
+	if q.Limit > 0 {
+		db = db.Limit(q.Limit)
+	}
+	return db
+}
+
+// Find implements MeasurementRepository.
+func (r *gormMeasurementRepository) Find(ctx context.Context, q MeasurementQuery) ([]entity.Measurement, error) {
+	var measurements []entity.Measurement
+	err := r.matching(ctx, q).Order("measured_at, station_id, parameter").Find(&measurements).Error
+	return measurements, err
+}
+
+// Aggregate implements MeasurementRepository.
+func (r *gormMeasurementRepository) Aggregate(ctx context.Context, q MeasurementQuery, interval Interval) ([]Bucket, error) {
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("unknown interval %q", interval)
+	}
+	db := r.conn(ctx)
+	if dialect := db.Dialector.Name(); dialect != "postgres" {
+		return nil, fmt.Errorf("aggregating measurements is not supported on %s", dialect)
+	}
+	// Both align hours and days to UTC; time_bucket lets TimescaleDB skip chunks outside the range
+	bucket := "date_trunc('" + string(interval) + "', measured_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'"
+	if r.timescale {
+		bucket = "time_bucket(INTERVAL '1 " + string(interval) + "', measured_at)"
+	}
+	var buckets []Bucket
+	err := r.matching(ctx, q).
+		Select("station_id, parameter, " + bucket + " AS bucket_start, AVG(value) AS avg, MIN(value) AS min, MAX(value) AS max, COUNT(*) AS count").
+		Group("station_id, parameter, bucket_start").
+		Order("bucket_start, station_id, parameter").
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+// UploadRepository defines persistence operations for the uploads table.
+type UploadRepository interface {
+	core_repo.BaseRepository[entity.Upload]
+}
+
+// gormUploadRepository implements UploadRepository using GORM
+type gormUploadRepository struct {
+	*core_repo.GormBaseRepository[entity.Upload]
+}
+
+// NewUploadRepository creates a new UploadRepository using the provided GORM DB connection.
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &gormUploadRepository{
+		GormBaseRepository: core_repo.NewGormBaseRepository[entity.Upload](db),
+	}
+}
@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	dbtest "golang-microservices-boilerplate/pkg/core/database/testing"
+	"golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/pkg/utils"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/entity"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/repository"
+)
+
+// center is the point the geo queries search around
+var center = types.GeoPoint{Lat: 10.7769, Lng: 106.7009}
+
+func TestFindWithinRadiusSQL(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open dry-run database: %v", err)
+	}
+	var sql string
+	var vars []interface{}
+	err = db.Callback().Query().After("gorm:query").Register("test:record_sql", func(db *gorm.DB) {
+		sql, vars = db.Statement.SQL.String(), db.Statement.Vars
+	})
+	if err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	_, err = repository.NewMeasurementRepository(db, false).Find(context.Background(), repository.MeasurementQuery{
+		From:         from,
+		To:           from.Add(24 * time.Hour),
+		WithinRadius: &types.GeoRadius{Center: center, RadiusMeters: 5000},
+	})
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if !strings.Contains(sql, "ST_DWithin(location::geography, ST_SetSRID(ST_MakePoint($3, $4), $5)::geography, $6)") {
+		t.Errorf("query = %s; want an ST_DWithin condition on location", sql)
+	}
+	if len(vars) < 6 || vars[2] != center.Lng || vars[3] != center.Lat || vars[5] != 5000.0 {
+		t.Errorf("vars = %v; want the center and 5000 meters", vars)
+	}
+}
+
+// TestFindWithinRadius stores stations about 3 and 8 km from center, and one without a location,
+// and checks that a 5 km query returns only the nearest. It needs PostGIS: the container uses
+// TEST_POSTGIS_IMAGE, and a TEST_DB_URI database without the extension skips the test.
+func TestFindWithinRadius(t *testing.T) {
+	config := dbtest.DefaultPostgresConfig()
+	config.Image = utils.GetEnv("TEST_POSTGIS_IMAGE", "postgis/postgis:16-3.4-alpine")
+	pc := dbtest.MustStartPostgresWithConfig(t, config)
+	ctx := context.Background()
+	if err := repository.EnablePostGIS(ctx, pc.Conn.DB); err != nil {
+		t.Skipf("postgis not available: %v", err)
+	}
+	if err := pc.Conn.MigrateModels(&entity.Measurement{}); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+
+	measuredAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	at := func(station string, location *types.GeoPoint) entity.Measurement {
+		return entity.Measurement{MeasuredAt: measuredAt, StationID: station, Parameter: "ph", Value: 7, UploadID: uuid.New(), Location: location}
+	}
+	err := pc.WithTx(func(tx *gorm.DB) error {
+		repo := repository.NewMeasurementRepository(tx, false)
+		err := repo.Upsert(ctx, []entity.Measurement{
+			at("NEAR", &types.GeoPoint{Lat: center.Lat + 0.027, Lng: center.Lng}), // ~3 km north
+			at("FAR", &types.GeoPoint{Lat: center.Lat + 0.072, Lng: center.Lng}),  // ~8 km north
+			at("UNKNOWN", nil),
+		})
+		if err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+
+		found, err := repo.Find(ctx, repository.MeasurementQuery{
+			From:         measuredAt,
+			To:           measuredAt.Add(time.Hour),
+			WithinRadius: &types.GeoRadius{Center: center, RadiusMeters: 5000},
+		})
+		if err != nil {
+			t.Fatalf("Find: %v", err)
+		}
+		if len(found) != 1 || found[0].StationID != "NEAR" {
+			t.Fatalf("Find within 5 km = %+v; want only NEAR", found)
+		}
+		if loc := found[0].Location; loc == nil || loc.Lat != center.Lat+0.027 || loc.Lng != center.Lng {
+			t.Errorf("location = %+v; want the stored point", loc)
+		}
+
+		all, err := repo.Find(ctx, repository.MeasurementQuery{From: measuredAt, To: measuredAt.Add(time.Hour)})
+		if err != nil {
+			t.Fatalf("Find: %v", err)
+		}
+		if len(all) != 3 {
+			t.Errorf("Find without geo filter returned %d measurements; want 3", len(all))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction: %v", err)
+	}
+}
@@ -0,0 +1,383 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/blob"
+	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repo "golang-microservices-boilerplate/pkg/core/repository"
+	core_types "golang-microservices-boilerplate/pkg/core/types"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/entity"
+	"golang-microservices-boilerplate/services/water-quality-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// FileTypeCSV is the only file type ingested. The header names the columns station_id,
+// parameter, value, measured_at (RFC3339) and the optional unit and lat and lng (WGS 84 degrees
+// of the station), in any order.
+const FileTypeCSV = "csv"
+
+// maxReportedFailures caps the rejected rows listed in an IngestResult; the rest are only counted
+const maxReportedFailures = 100
+
+// IngestResult is the outcome of an upload
+type IngestResult struct {
+	Upload   *entity.Upload
+	Failures []core_types.BatchFailure // Index is the line number in the file
+}
+
+// QueryResult holds the measurements of a raw query, or the buckets of an aggregated one
+type QueryResult struct {
+	Measurements []entity.Measurement
+	Buckets      []repository.Bucket
+	Truncated    bool // More results than the limit matched
+}
+
+// MeasurementUsecase ingests uploaded measurement files and queries the measurements
+type MeasurementUsecase interface {
+	// Ingest stores the raw file and then reads its rows into measurements, in batches of
+	// DB_BATCH_SIZE. Rows that cannot be parsed are reported and skipped.
+	Ingest(ctx context.Context, filename, fileType string, content io.Reader) (*IngestResult, error)
+	// Query returns the measurements matching q, or their aggregates per interval unless
+	// interval is empty or "raw"
+	Query(ctx context.Context, q repository.MeasurementQuery, interval string) (*QueryResult, error)
+}
+
+// measurementUseCaseImpl implements the MeasurementUsecase interface.
+type measurementUseCaseImpl struct {
+	measurements repository.MeasurementRepository
+	uploads      repository.UploadRepository
+	blobs        blob.Store
+	maxRange     time.Duration
+	maxResults   int
+	logger       core_logger.Logger
+}
+
+// NewMeasurementUseCase creates a new instance of MeasurementUsecase. Queries may span at most
+// maxRange and return at most maxResults measurements or buckets.
+func NewMeasurementUseCase(
+	measurements repository.MeasurementRepository,
+	uploads repository.UploadRepository,
+	blobs blob.Store,
+	maxRange time.Duration,
+	maxResults int,
+	logger core_logger.Logger,
+) MeasurementUsecase {
+	return &measurementUseCaseImpl{
+		measurements: measurements,
+		uploads:      uploads,
+		blobs:        blobs,
+		maxRange:     maxRange,
+		maxResults:   maxResults,
+		logger:       logger,
+	}
+}
+
+// Ingest implements MeasurementUsecase.
+func (uc *measurementUseCaseImpl) Ingest(ctx context.Context, filename, fileType string, content io.Reader) (*IngestResult, error) {
+	log := core_logger.FromContext(ctx, uc.logger)
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "" || name == "." || name == "/" {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "filename is required")
+	}
+	fileType = strings.ToLower(strings.TrimSpace(fileType))
+	if fileType != FileTypeCSV {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, fmt.Sprintf("unsupported file type %q, expected csv", fileType))
+	}
+
+	upload := &entity.Upload{Filename: name, FileType: fileType, Status: entity.UploadFailed}
+	upload.ID = uuid.New()
+	if actor, ok := core_usecase.ActorFromContext(ctx); ok {
+		if id, err := uuid.Parse(actor.ID); err == nil {
+			upload.UploadedBy = &id
+		}
+	}
+	upload.BlobKey = "uploads/" + upload.ID.String() + "/" + name
+
+	// The raw file is kept first, so a file that fails to ingest can be inspected and replayed
+	counted := &countingReader{r: content}
+	if err := uc.blobs.Put(ctx, upload.BlobKey, counted); err != nil {
+		log.Error("Failed to store uploaded file", "upload_id", upload.ID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to store uploaded file")
+	}
+	upload.SizeBytes = counted.n
+
+	result := &IngestResult{Upload: upload}
+	ingestErr := uc.ingestFile(ctx, upload, result)
+	switch {
+	case ingestErr != nil:
+		upload.Status = entity.UploadFailed
+	case upload.Ingested < upload.Rows:
+		upload.Status = entity.UploadPartial
+	default:
+		upload.Status = entity.UploadIngested
+	}
+	if err := uc.uploads.Create(ctx, upload); err != nil {
+		log.Error("Failed to record upload", "upload_id", upload.ID, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to record upload")
+	}
+	if ingestErr != nil {
+		var ucErr *core_usecase.UseCaseError
+		if errors.As(ingestErr, &ucErr) {
+			return nil, ingestErr
+		}
+		log.Error("Failed to ingest measurements", "upload_id", upload.ID, "rows", upload.Rows, "ingested", upload.Ingested, "error", ingestErr)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to ingest measurements")
+	}
+	log.Info("Measurements ingested", "upload_id", upload.ID, "filename", name, "rows", upload.Rows,
+		"ingested", upload.Ingested, "rejected", upload.Rows-upload.Ingested)
+	return result, nil
+}
+
+// ingestFile reads the stored file of upload and upserts its measurements batch by batch,
+// counting the rows into upload and the rejected ones into result
+func (uc *measurementUseCaseImpl) ingestFile(ctx context.Context, upload *entity.Upload, result *IngestResult) error {
+	file, err := uc.blobs.Open(ctx, upload.BlobKey)
+	if err != nil {
+		return fmt.Errorf("failed to open stored file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "the file is empty")
+	}
+	if err != nil {
+		return core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, fmt.Sprintf("invalid csv header: %v", err))
+	}
+	columns, err := parseHeader(header)
+	if err != nil {
+		return err
+	}
+
+	batchSize := core_repo.DefaultBatchSize()
+	batch := newMeasurementBatch(batchSize)
+	flush := func() error {
+		if err := uc.measurements.Upsert(ctx, batch.items); err != nil {
+			return err
+		}
+		upload.Ingested += batch.rows
+		batch.reset()
+		return nil
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		upload.Rows++
+		var m entity.Measurement
+		var line int
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.StartLine
+		} else if err != nil {
+			return fmt.Errorf("failed to read stored file: %w", err)
+		} else {
+			line, _ = reader.FieldPos(0)
+			m, err = columns.parse(record)
+		}
+		if err != nil {
+			if len(result.Failures) < maxReportedFailures {
+				result.Failures = append(result.Failures, core_types.BatchFailure{Index: line, Reason: err.Error()})
+			}
+			continue
+		}
+		m.UploadID = upload.ID
+		batch.add(m)
+		if len(batch.items) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// Query implements MeasurementUsecase.
+func (uc *measurementUseCaseImpl) Query(ctx context.Context, q repository.MeasurementQuery, interval string) (*QueryResult, error) {
+	if q.From.IsZero() || q.To.IsZero() || !q.From.Before(q.To) {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "from and to are required and from must be before to")
+	}
+	if uc.maxRange > 0 && q.To.Sub(q.From) > uc.maxRange {
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, fmt.Sprintf("the time range must not exceed %s", uc.maxRange))
+	}
+	limit := q.Limit
+	if limit <= 0 || limit > uc.maxResults {
+		limit = uc.maxResults
+	}
+	q.Limit = limit + 1 // One more row tells whether the result was truncated
+
+	result := &QueryResult{}
+	var err error
+	switch interval {
+	case "", "raw":
+		result.Measurements, err = uc.measurements.Find(ctx, q)
+		if len(result.Measurements) > limit {
+			result.Measurements, result.Truncated = result.Measurements[:limit], true
+		}
+	default:
+		if !repository.Interval(interval).IsValid() {
+			return nil, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "interval must be raw, hour or day")
+		}
+		result.Buckets, err = uc.measurements.Aggregate(ctx, q, repository.Interval(interval))
+		if len(result.Buckets) > limit {
+			result.Buckets, result.Truncated = result.Buckets[:limit], true
+		}
+	}
+	if err != nil {
+		core_logger.FromContext(ctx, uc.logger).Error("Failed to query measurements", "interval", interval, "error", err)
+		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to query measurements")
+	}
+	return result, nil
+}
+
+// csvColumns holds the positions of the columns in a csv file; unit, lat and lng are -1 when absent
+type csvColumns struct {
+	station, parameter, value, measuredAt, unit, lat, lng int
+}
+
+// parseHeader locates the columns by name
+func parseHeader(header []string) (csvColumns, error) {
+	columns := csvColumns{station: -1, parameter: -1, value: -1, measuredAt: -1, unit: -1, lat: -1, lng: -1}
+	positions := map[string]*int{
+		"station_id":  &columns.station,
+		"parameter":   &columns.parameter,
+		"value":       &columns.value,
+		"measured_at": &columns.measuredAt,
+		"unit":        &columns.unit,
+		"lat":         &columns.lat,
+		"lng":         &columns.lng,
+	}
+	for i, name := range header {
+		if pos, ok := positions[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))]; ok {
+			*pos = i
+		}
+	}
+	var missing []string
+	for _, name := range []string{"station_id", "parameter", "value", "measured_at"} {
+		if *positions[name] < 0 {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return columns, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "the csv header lacks the columns "+strings.Join(missing, ", "))
+	}
+	if (columns.lat < 0) != (columns.lng < 0) {
+		return columns, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "the csv header must have both lat and lng columns or neither")
+	}
+	return columns, nil
+}
+
+// parse reads a measurement from a record
+func (c csvColumns) parse(record []string) (entity.Measurement, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	m := entity.Measurement{StationID: field(c.station), Parameter: strings.ToLower(field(c.parameter)), Unit: field(c.unit)}
+	if m.StationID == "" || len(m.StationID) > 64 {
+		return m, errors.New("station_id is required and must be at most 64 characters")
+	}
+	if m.Parameter == "" || len(m.Parameter) > 64 {
+		return m, errors.New("parameter is required and must be at most 64 characters")
+	}
+	if len(m.Unit) > 32 {
+		return m, errors.New("unit must be at most 32 characters")
+	}
+	value, err := strconv.ParseFloat(field(c.value), 64)
+	if err != nil {
+		return m, fmt.Errorf("invalid value %q", field(c.value))
+	}
+	m.Value = value
+	measuredAt, err := time.Parse(time.RFC3339, field(c.measuredAt))
+	if err != nil {
+		return m, fmt.Errorf("invalid measured_at %q, expected RFC3339", field(c.measuredAt))
+	}
+	m.MeasuredAt = measuredAt.UTC()
+	if lat, lng := field(c.lat), field(c.lng); lat != "" || lng != "" {
+		location, err := parseLocation(lat, lng)
+		if err != nil {
+			return m, err
+		}
+		m.Location = &location
+	}
+	return m, nil
+}
+
+// parseLocation reads the coordinates of a row; a row with a location must have both
+func parseLocation(lat, lng string) (core_types.GeoPoint, error) {
+	var p core_types.GeoPoint
+	var err error
+	if p.Lat, err = strconv.ParseFloat(lat, 64); err != nil {
+		return p, fmt.Errorf("invalid lat %q", lat)
+	}
+	if p.Lng, err = strconv.ParseFloat(lng, 64); err != nil {
+		return p, fmt.Errorf("invalid lng %q", lng)
+	}
+	return p, p.Validate()
+}
+
+// measurementKey identifies a measurement
+type measurementKey struct {
+	station, parameter string
+	measuredAt         time.Time
+}
+
+// measurementBatch collects measurements to upsert together. A key repeated within a batch keeps
+// its last value, since one upsert statement cannot write the same row twice.
+type measurementBatch struct {
+	items []entity.Measurement
+	index map[measurementKey]int
+	rows  int64 // Rows added, including those replaced by a later row with the same key
+}
+
+func newMeasurementBatch(size int) *measurementBatch {
+	return &measurementBatch{items: make([]entity.Measurement, 0, size), index: make(map[measurementKey]int, size)}
+}
+
+// add appends m, or replaces the measurement with the same key
+func (b *measurementBatch) add(m entity.Measurement) {
+	b.rows++
+	key := measurementKey{station: m.StationID, parameter: m.Parameter, measuredAt: m.MeasuredAt}
+	if i, ok := b.index[key]; ok {
+		b.items[i] = m
+		return
+	}
+	b.index[key] = len(b.items)
+	b.items = append(b.items, m)
+}
+
+// reset empties the batch
+func (b *measurementBatch) reset() {
+	b.items = b.items[:0]
+	b.rows = 0
+	clear(b.index)
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}